@@ -0,0 +1,10 @@
+
+-- +migrate Up
+ALTER TABLE Users
+    ADD COLUMN role VARCHAR(32) NOT NULL DEFAULT 'user' COMMENT "ユーザーの権限",
+    ADD INDEX idx_users_email (email);
+
+-- +migrate Down
+ALTER TABLE Users
+    DROP INDEX idx_users_email,
+    DROP COLUMN role;
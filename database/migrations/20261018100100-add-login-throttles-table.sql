@@ -0,0 +1,14 @@
+
+-- +migrate Up
+CREATE TABLE login_throttles(
+    scope VARCHAR(16) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    failed_count INT NOT NULL DEFAULT 0,
+    lockout_count INT NOT NULL DEFAULT 0,
+    locked_until timestamp NULL,
+    last_failed_at timestamp NULL,
+    PRIMARY KEY (scope, subject)
+) COMMENT "ログイン失敗の追跡テーブル";
+
+-- +migrate Down
+DROP TABLE login_throttles;
@@ -0,0 +1,23 @@
+
+-- +migrate Up
+CREATE TABLE user_mfa_settings(
+    user_id binary(16) PRIMARY KEY,
+    secret_ciphertext BLOB NOT NULL COMMENT "暗号化されたTOTPシークレット",
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    confirmed_at timestamp NULL,
+    CONSTRAINT fk_user_mfa_settings_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+) COMMENT "ユーザーの二要素認証設定";
+
+CREATE TABLE user_recovery_codes(
+    user_id binary(16) NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    used_at timestamp NULL,
+    PRIMARY KEY (user_id, code_hash),
+    CONSTRAINT fk_user_recovery_codes_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+) COMMENT "二要素認証のリカバリーコード";
+
+-- +migrate Down
+DROP TABLE user_recovery_codes;
+DROP TABLE user_mfa_settings;
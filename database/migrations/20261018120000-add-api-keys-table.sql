@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE api_keys(
+    id binary(16) PRIMARY KEY,
+    user_id binary(16) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    prefix CHAR(12) NOT NULL COMMENT "キーの先頭部分。検索と表示に使用",
+    secret_hash CHAR(64) NOT NULL COMMENT "キー全体のSHA-256ハッシュ",
+    scopes VARCHAR(255) NOT NULL COMMENT "スペース区切りのスコープ",
+    expires_at timestamp NULL,
+    last_used_at timestamp NULL,
+    revoked_at timestamp NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_api_keys_prefix (prefix),
+    CONSTRAINT fk_api_keys_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+) COMMENT "サービス間連携用のAPIキー";
+
+-- +migrate Down
+DROP TABLE api_keys;
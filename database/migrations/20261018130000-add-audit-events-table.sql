@@ -0,0 +1,36 @@
+-- +migrate Up
+CREATE TABLE audit_events(
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    action VARCHAR(64) NOT NULL,
+    actor_id CHAR(36) NULL COMMENT "操作したユーザーのID。匿名の場合はNULL",
+    target_id CHAR(36) NULL COMMENT "操作対象のユーザーのID",
+    changes JSON NULL COMMENT "フィールド単位の変更内容。機密項目はマスク済み",
+    metadata JSON NULL,
+    request_id VARCHAR(64) NULL,
+    ip VARCHAR(45) NULL,
+    occurred_at timestamp(6) NOT NULL,
+    INDEX idx_audit_events_actor (actor_id, occurred_at),
+    INDEX idx_audit_events_target (target_id, occurred_at),
+    INDEX idx_audit_events_occurred_at (occurred_at)
+) COMMENT "監査ログ。追記のみ可能";
+
+-- +migrate StatementBegin
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events
+FOR EACH ROW
+BEGIN
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_events is append-only';
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events
+FOR EACH ROW
+BEGIN
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_events is append-only';
+END;
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TRIGGER audit_events_no_delete;
+DROP TRIGGER audit_events_no_update;
+DROP TABLE audit_events;
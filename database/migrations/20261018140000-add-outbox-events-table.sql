@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE outbox_events(
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_id CHAR(36) NOT NULL COMMENT "イベントID。購読側での重複排除に使用",
+    event_type VARCHAR(64) NOT NULL,
+    aggregate_id CHAR(36) NOT NULL COMMENT "イベントの対象となるエンティティのID",
+    payload JSON NOT NULL,
+    occurred_at timestamp(6) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0 COMMENT "配信に失敗した回数",
+    next_attempt_at timestamp(6) NOT NULL COMMENT "次に配信を試みる日時",
+    last_error VARCHAR(1024) NULL,
+    published_at timestamp(6) NULL COMMENT "配信が完了した日時。未配信の場合はNULL",
+    UNIQUE KEY uq_outbox_events_event_id (event_id),
+    INDEX idx_outbox_events_pending (published_at, next_attempt_at)
+) COMMENT "トランザクショナルアウトボックス。ユーザーの更新と同じトランザクションで書き込まれます";
+
+-- +migrate Down
+DROP TABLE outbox_events;
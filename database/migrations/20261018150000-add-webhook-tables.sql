@@ -0,0 +1,35 @@
+-- +migrate Up
+CREATE TABLE webhook_subscriptions(
+    id binary(16) PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL COMMENT "配信先のURL",
+    event_types VARCHAR(255) NOT NULL COMMENT "スペース区切りの購読するイベント種別",
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    secret_ciphertext BLOB NOT NULL COMMENT "暗号化された署名用シークレット",
+    active BOOLEAN NOT NULL DEFAULT TRUE COMMENT "無効の場合は配信しません",
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) COMMENT "外部向けWebhookの購読設定";
+
+CREATE TABLE webhook_deliveries(
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    subscription_id binary(16) NOT NULL,
+    event_id CHAR(36) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSON NOT NULL COMMENT "送信するリクエストボディ",
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT "pending: 配信待ち, succeeded: 配信済み, dead: 再試行上限に達した",
+    attempts INT NOT NULL DEFAULT 0 COMMENT "配信を試みた回数",
+    next_attempt_at timestamp(6) NOT NULL,
+    last_attempt_at timestamp(6) NULL,
+    last_status_code INT NULL COMMENT "最後の配信でのHTTPステータスコード",
+    last_error VARCHAR(1024) NULL,
+    delivered_at timestamp(6) NULL,
+    created_at timestamp(6) NOT NULL,
+    UNIQUE KEY uq_webhook_deliveries_event (subscription_id, event_id),
+    INDEX idx_webhook_deliveries_subscription (subscription_id, id),
+    INDEX idx_webhook_deliveries_due (status, next_attempt_at),
+    CONSTRAINT fk_webhook_deliveries_subscription FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id) ON DELETE CASCADE
+) COMMENT "Webhookの配信履歴と配信キュー";
+
+-- +migrate Down
+DROP TABLE webhook_deliveries;
+DROP TABLE webhook_subscriptions;
@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE user_import_jobs(
+    id binary(16) PRIMARY KEY,
+    format VARCHAR(16) NOT NULL COMMENT "csv または ndjson",
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT "pending: 開始待ち, running: 実行中, completed: 完了, failed: 途中で中断",
+    total_rows INT NOT NULL DEFAULT 0 COMMENT "処理済みの行数",
+    created_rows INT NOT NULL DEFAULT 0,
+    failed_rows INT NOT NULL DEFAULT 0,
+    error VARCHAR(1024) NULL COMMENT "中断した理由",
+    report JSON NULL COMMENT "行ごとの結果。終了時に保存",
+    created_by CHAR(36) NULL COMMENT "インポートを開始したユーザーのID",
+    created_at timestamp(6) NOT NULL,
+    updated_at timestamp(6) NOT NULL,
+    finished_at timestamp(6) NULL
+) COMMENT "ユーザー一括インポートのジョブ";
+
+-- +migrate Down
+DROP TABLE user_import_jobs;
@@ -0,0 +1,8 @@
+-- +migrate Up
+-- ngram は ngram_token_size (既定は2) 文字ごとに分割するため、日本語の名前も部分一致で検索できます
+ALTER TABLE Users
+    ADD FULLTEXT INDEX ft_users_name_email (name, email) WITH PARSER ngram;
+
+-- +migrate Down
+ALTER TABLE Users
+    DROP INDEX ft_users_name_email;
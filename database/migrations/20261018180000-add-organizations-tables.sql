@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE organizations(
+    id binary(16) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(63) NOT NULL COMMENT "URLなどで使う識別子。英小文字、数字、ハイフン",
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_organizations_slug (slug)
+) COMMENT "顧客企業などの組織(テナント)";
+
+CREATE TABLE organization_members(
+    organization_id binary(16) NOT NULL,
+    user_id binary(16) NOT NULL,
+    role VARCHAR(32) NOT NULL DEFAULT 'member' COMMENT "組織内の権限。owner, admin, member",
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organization_id, user_id),
+    KEY idx_organization_members_user (user_id),
+    CONSTRAINT fk_organization_members_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_organization_members_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+) COMMENT "組織に所属するユーザー";
+
+-- +migrate Down
+DROP TABLE organization_members;
+DROP TABLE organizations;
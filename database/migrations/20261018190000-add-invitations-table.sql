@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE invitations(
+    id binary(16) PRIMARY KEY,
+    organization_id binary(16) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(32) NOT NULL COMMENT "承諾時に付与する組織内の権限",
+    token_hash CHAR(64) NOT NULL COMMENT "招待リンクのトークンのSHA-256ハッシュ",
+    invited_by CHAR(36) NULL COMMENT "招待したユーザーのID",
+    expires_at timestamp NOT NULL,
+    accepted_at timestamp NULL,
+    accepted_by CHAR(36) NULL COMMENT "招待を承諾したユーザーのID",
+    revoked_at timestamp NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_invitations_token_hash (token_hash),
+    KEY idx_invitations_organization_email (organization_id, email),
+    CONSTRAINT fk_invitations_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+) COMMENT "組織へのユーザーの招待";
+
+-- +migrate Down
+DROP TABLE invitations;
@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE user_groups(
+    id binary(16) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(1000) NOT NULL DEFAULT '',
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_user_groups_name (name)
+) COMMENT "権限や通知の単位となるユーザーのグループ";
+
+CREATE TABLE user_group_members(
+    group_id binary(16) NOT NULL,
+    user_id binary(16) NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (group_id, user_id),
+    KEY idx_user_group_members_user (user_id),
+    CONSTRAINT fk_user_group_members_group FOREIGN KEY (group_id) REFERENCES user_groups(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_group_members_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+) COMMENT "グループに所属するユーザー。ユーザーまたはグループの削除時に削除されます";
+
+-- +migrate Down
+DROP TABLE user_group_members;
+DROP TABLE user_groups;
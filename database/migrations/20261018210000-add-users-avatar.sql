@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE Users
+    ADD COLUMN avatar_url VARCHAR(1024) NULL COMMENT "アバター画像のURL",
+    ADD COLUMN avatar_thumbnail_url VARCHAR(1024) NULL COMMENT "アバター画像のサムネイルのURL";
+
+-- +migrate Down
+ALTER TABLE Users
+    DROP COLUMN avatar_thumbnail_url,
+    DROP COLUMN avatar_url;
@@ -0,0 +1,20 @@
+-- +migrate Up
+ALTER TABLE Users
+    ADD COLUMN preferences JSON NULL COMMENT "ユーザーが選択した設定(言語、タイムゾーン、通知)。NULLの場合は既定値",
+    ADD COLUMN attributes JSON NULL COMMENT "管理者が定義したカスタム属性の値";
+
+CREATE TABLE user_attribute_definitions(
+    attribute_key VARCHAR(64) PRIMARY KEY,
+    type VARCHAR(16) NOT NULL COMMENT "string, number, integer, boolean または date",
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    enum_values JSON NULL COMMENT "string 型の属性に許可する値。NULLの場合は制限なし",
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) COMMENT "ユーザーのカスタム属性の定義";
+
+-- +migrate Down
+DROP TABLE user_attribute_definitions;
+ALTER TABLE Users
+    DROP COLUMN attributes,
+    DROP COLUMN preferences;
@@ -0,0 +1,15 @@
+-- +migrate Up
+ALTER TABLE Users
+    ADD COLUMN name_encrypted BLOB NULL COMMENT "暗号化された名前。設定されている場合は name より優先される",
+    ADD COLUMN email_encrypted BLOB NULL COMMENT "暗号化されたメールアドレス。設定されている場合は email より優先される",
+    ADD COLUMN email_index CHAR(64) CHARACTER SET ascii COLLATE ascii_bin NULL COMMENT "正規化したメールアドレスのHMAC(16進数)。暗号化したまま検索と一意性の確認に使う",
+    ADD COLUMN pii_key_version INT UNSIGNED NULL COMMENT "name_encrypted と email_encrypted の暗号化に使った鍵のバージョン。NULLの場合は平文",
+    ADD UNIQUE KEY uq_users_email_index (email_index);
+
+-- +migrate Down
+ALTER TABLE Users
+    DROP INDEX uq_users_email_index,
+    DROP COLUMN pii_key_version,
+    DROP COLUMN email_index,
+    DROP COLUMN email_encrypted,
+    DROP COLUMN name_encrypted;
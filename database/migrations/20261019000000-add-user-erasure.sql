@@ -0,0 +1,58 @@
+-- +migrate Up
+ALTER TABLE Users
+    ADD COLUMN erased_at timestamp NULL COMMENT "個人データを消去した日時。消去済みのユーザーは匿名化された行だけが残る";
+
+ALTER TABLE audit_events
+    ADD COLUMN scrubbed_at timestamp(6) NULL COMMENT "個人データの消去に伴い内容を削除した日時";
+
+CREATE TABLE user_erasure_receipts(
+    id binary(16) PRIMARY KEY,
+    user_id binary(16) NOT NULL COMMENT "消去されたユーザーのID",
+    requested_by CHAR(36) NULL COMMENT "消去を実行したユーザーのID",
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    audit_events_scrubbed INT NOT NULL COMMENT "内容を削除した監査ログの件数",
+    erased_at timestamp NOT NULL,
+    UNIQUE KEY uq_user_erasure_receipts_user (user_id)
+) COMMENT "個人データ消去の記録。ユーザーが削除されても残す";
+
+-- The audit log stays append-only, except that rows may be scrubbed: their
+-- payload can only be cleared, never changed, and who did what to whom and
+-- when is kept. A row can be scrubbed again when a second user it concerns is
+-- erased.
+DROP TRIGGER audit_events_no_update;
+
+-- +migrate StatementBegin
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events
+FOR EACH ROW
+BEGIN
+    IF NOT (NEW.scrubbed_at IS NOT NULL
+        AND NEW.id <=> OLD.id
+        AND NEW.action <=> OLD.action
+        AND NEW.actor_id <=> OLD.actor_id
+        AND NEW.target_id <=> OLD.target_id
+        AND NEW.request_id <=> OLD.request_id
+        AND NEW.occurred_at <=> OLD.occurred_at
+        AND (NEW.changes IS NULL OR NEW.changes <=> OLD.changes)
+        AND (NEW.metadata IS NULL OR NEW.metadata <=> OLD.metadata)
+        AND (NEW.ip IS NULL OR NEW.ip <=> OLD.ip)) THEN
+        SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_events is append-only';
+    END IF;
+END;
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TRIGGER audit_events_no_update;
+
+-- +migrate StatementBegin
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events
+FOR EACH ROW
+BEGIN
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_events is append-only';
+END;
+-- +migrate StatementEnd
+
+DROP TABLE user_erasure_receipts;
+ALTER TABLE audit_events
+    DROP COLUMN scrubbed_at;
+ALTER TABLE Users
+    DROP COLUMN erased_at;
@@ -0,0 +1,41 @@
+-- +migrate Up
+CREATE TABLE job_runs(
+    id binary(16) PRIMARY KEY,
+    job_name VARCHAR(64) NOT NULL,
+    scheduled_at timestamp NOT NULL COMMENT "スケジュール上の実行時刻。ジョブ名と合わせて一意で、複数のレプリカが同じ回を実行しないようにする",
+    instance VARCHAR(255) NOT NULL COMMENT "実行したサーバーのホスト名",
+    status VARCHAR(16) NOT NULL COMMENT "running, succeeded, failed のいずれか",
+    result JSON NULL COMMENT "ジョブが返した件数などの結果",
+    error VARCHAR(1024) NULL,
+    started_at timestamp(6) NOT NULL,
+    finished_at timestamp(6) NULL,
+    UNIQUE KEY uq_job_runs_job_scheduled (job_name, scheduled_at)
+) COMMENT "定期ジョブの実行履歴";
+
+-- Old audit events may now be purged by the retention job, which sets
+-- @audit_events_purge_before on its connection for the duration of the
+-- delete. Any other delete is still refused.
+DROP TRIGGER audit_events_no_delete;
+
+-- +migrate StatementBegin
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events
+FOR EACH ROW
+BEGIN
+    IF @audit_events_purge_before IS NULL OR OLD.occurred_at >= @audit_events_purge_before THEN
+        SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_events is append-only';
+    END IF;
+END;
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TRIGGER audit_events_no_delete;
+
+-- +migrate StatementBegin
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events
+FOR EACH ROW
+BEGIN
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_events is append-only';
+END;
+-- +migrate StatementEnd
+
+DROP TABLE job_runs;
@@ -0,0 +1,6 @@
+-- +migrate Up
+ALTER TABLE Users MODIFY password VARCHAR(255) COMMENT "パスワードのハッシュ。先頭にアルゴリズムとパラメータを含む(bcrypt または argon2id)";
+
+-- +migrate Down
+-- Fails while Argon2id hashes, which are longer than 60 characters, are stored
+ALTER TABLE Users MODIFY password VARCHAR(60);
@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE user_identities(
+    id binary(16) PRIMARY KEY,
+    user_id binary(16) NOT NULL,
+    provider VARCHAR(64) NOT NULL COMMENT "設定上のIDプロバイダー名",
+    subject VARCHAR(255) NOT NULL COMMENT "IDプロバイダーが発行したIDトークンの sub クレーム",
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_login_at timestamp NULL,
+    UNIQUE KEY uq_user_identities_provider_subject (provider, subject),
+    CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE
+) COMMENT "外部IDプロバイダーのアカウントとユーザーの紐付け";
+
+CREATE TABLE oidc_login_states(
+    state_hash CHAR(64) PRIMARY KEY COMMENT "state パラメーターのSHA-256ハッシュ",
+    provider VARCHAR(64) NOT NULL,
+    nonce VARCHAR(64) NOT NULL COMMENT "IDトークンに含まれるべき nonce",
+    code_verifier VARCHAR(128) NOT NULL COMMENT "PKCE のコード検証子",
+    expires_at timestamp NOT NULL,
+    KEY idx_oidc_login_states_expires_at (expires_at)
+) COMMENT "進行中の外部IDプロバイダーでのログイン。コールバックで一度だけ使用される";
+
+-- +migrate Down
+DROP TABLE oidc_login_states;
+DROP TABLE user_identities;
@@ -0,0 +1,62 @@
+-- +migrate Up
+CREATE TABLE oauth_clients(
+    id binary(16) PRIMARY KEY COMMENT "client_id として使用",
+    name VARCHAR(255) NOT NULL COMMENT "同意画面に表示する名前",
+    redirect_uris TEXT NOT NULL COMMENT "スペース区切りの登録済みリダイレクトURI",
+    secret_hash CHAR(64) NULL COMMENT "クライアントシークレットのSHA-256ハッシュ。公開クライアントはNULL",
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+) COMMENT "このサービスをOpenIDプロバイダーとして利用するアプリケーション";
+
+CREATE TABLE oauth_consents(
+    user_id binary(16) NOT NULL,
+    client_id binary(16) NOT NULL,
+    scopes VARCHAR(255) NOT NULL COMMENT "スペース区切りの同意済みスコープ",
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, client_id),
+    CONSTRAINT fk_oauth_consents_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_oauth_consents_client FOREIGN KEY (client_id) REFERENCES oauth_clients(id) ON DELETE CASCADE
+) COMMENT "ユーザーがクライアントに許可したスコープ";
+
+CREATE TABLE oauth_authorization_codes(
+    code_hash CHAR(64) PRIMARY KEY COMMENT "認可コードのSHA-256ハッシュ",
+    client_id binary(16) NOT NULL,
+    user_id binary(16) NOT NULL,
+    redirect_uri VARCHAR(2048) NOT NULL,
+    scopes VARCHAR(255) NOT NULL COMMENT "スペース区切りの許可されたスコープ",
+    nonce VARCHAR(255) NOT NULL DEFAULT '' COMMENT "IDトークンに含める nonce",
+    code_challenge VARCHAR(128) NOT NULL DEFAULT '' COMMENT "PKCE のコードチャレンジ(S256)。使用しない場合は空",
+    expires_at timestamp NOT NULL,
+    KEY idx_oauth_authorization_codes_expires_at (expires_at),
+    CONSTRAINT fk_oauth_authorization_codes_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_oauth_authorization_codes_client FOREIGN KEY (client_id) REFERENCES oauth_clients(id) ON DELETE CASCADE
+) COMMENT "トークンエンドポイントで一度だけ交換できる認可コード";
+
+CREATE TABLE oauth_access_tokens(
+    token_hash CHAR(64) PRIMARY KEY COMMENT "アクセストークンのSHA-256ハッシュ",
+    client_id binary(16) NOT NULL,
+    user_id binary(16) NOT NULL,
+    scopes VARCHAR(255) NOT NULL COMMENT "スペース区切りの許可されたスコープ",
+    expires_at timestamp NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_oauth_access_tokens_user_client (user_id, client_id),
+    KEY idx_oauth_access_tokens_expires_at (expires_at),
+    CONSTRAINT fk_oauth_access_tokens_user FOREIGN KEY (user_id) REFERENCES Users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_oauth_access_tokens_client FOREIGN KEY (client_id) REFERENCES oauth_clients(id) ON DELETE CASCADE
+) COMMENT "userinfo エンドポイント用のアクセストークン";
+
+CREATE TABLE oidc_signing_keys(
+    id VARCHAR(64) PRIMARY KEY COMMENT "JWKの kid",
+    public_key BLOB NOT NULL COMMENT "PKIX形式の公開鍵",
+    private_key_ciphertext BLOB NOT NULL COMMENT "暗号化されたPKCS #8形式の秘密鍵",
+    created_at timestamp(6) NOT NULL,
+    retired_at timestamp(6) NULL COMMENT "署名に使われなくなった日時。署名中の鍵はNULL",
+    KEY idx_oidc_signing_keys_retired_at (retired_at)
+) COMMENT "IDトークンの署名鍵。ローテーション後も猶予期間中は JWKS で公開されます";
+
+-- +migrate Down
+DROP TABLE oidc_signing_keys;
+DROP TABLE oauth_access_tokens;
+DROP TABLE oauth_authorization_codes;
+DROP TABLE oauth_consents;
+DROP TABLE oauth_clients;
@@ -0,0 +1,16 @@
+-- +migrate Up
+-- Keys are published before they start signing, and the current key retires
+-- when its successor activates, so retired_at may now lie in the future.
+ALTER TABLE oidc_signing_keys
+    ADD COLUMN activated_at timestamp(6) NULL COMMENT "署名に使われ始める日時。それまでは JWKS で公開だけされます" AFTER created_at,
+    MODIFY retired_at timestamp(6) NULL COMMENT "署名に使われなくなる日時。後継の鍵が有効になる日時なので未来のこともあります。後継のない鍵はNULL";
+
+UPDATE oidc_signing_keys SET activated_at = created_at;
+
+ALTER TABLE oidc_signing_keys
+    MODIFY activated_at timestamp(6) NOT NULL COMMENT "署名に使われ始める日時。それまでは JWKS で公開だけされます";
+
+-- +migrate Down
+ALTER TABLE oidc_signing_keys
+    DROP COLUMN activated_at,
+    MODIFY retired_at timestamp(6) NULL COMMENT "署名に使われなくなった日時。署名中の鍵はNULL";
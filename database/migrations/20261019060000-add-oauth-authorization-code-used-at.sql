@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE oauth_authorization_codes
+    ADD COLUMN used_at timestamp NULL COMMENT "トークンと交換された日時。使用済みのコードも再利用を検知するため期限切れ後の削除まで残す" AFTER code_challenge;
+
+-- +migrate Down
+DELETE FROM oauth_authorization_codes WHERE used_at IS NOT NULL;
+ALTER TABLE oauth_authorization_codes
+    DROP COLUMN used_at;
@@ -0,0 +1,13 @@
+-- +migrate Up
+ALTER TABLE user_erasure_receipts
+    ADD COLUMN events_scrubbed INT NOT NULL DEFAULT 0 COMMENT "名前とメールアドレスを削除したアウトボックスのイベントの件数" AFTER audit_events_scrubbed,
+    ADD COLUMN webhook_deliveries_scrubbed INT NOT NULL DEFAULT 0 COMMENT "名前とメールアドレスを削除したWebhook配信の件数" AFTER events_scrubbed,
+    ADD COLUMN import_reports_scrubbed INT NOT NULL DEFAULT 0 COMMENT "メールアドレスを削除したユーザー一括インポートの結果の件数" AFTER webhook_deliveries_scrubbed,
+    ADD COLUMN invitations_deleted INT NOT NULL DEFAULT 0 COMMENT "削除した未承諾の招待の件数" AFTER import_reports_scrubbed;
+
+-- +migrate Down
+ALTER TABLE user_erasure_receipts
+    DROP COLUMN invitations_deleted,
+    DROP COLUMN import_reports_scrubbed,
+    DROP COLUMN webhook_deliveries_scrubbed,
+    DROP COLUMN events_scrubbed;
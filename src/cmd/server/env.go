@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/base64"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// getEnv returns the value of key, or def when it is unset.
+func getEnv(key, def string) string {
+	v := os.Getenv(key)
+	if v == "" {
+		log.Printf("Warning: %s not set, using default '%s'", key, def)
+		return def
+	}
+	return v
+}
+
+// getEnvInt returns the integer value of key, or def when it is unset or invalid.
+func getEnvInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		log.Printf("Warning: %s not set, using default '%d'", key, def)
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("Warning: %s=%q is not an integer, using default '%d'", key, v, def)
+		return def
+	}
+	return n
+}
+
+// getEnvBool returns the boolean value of key (e.g. "true", "0"), or def when it is unset or invalid.
+func getEnvBool(key string, def bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		log.Printf("Warning: %s not set, using default '%t'", key, def)
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Printf("Warning: %s=%q is not a boolean, using default '%t'", key, v, def)
+		return def
+	}
+	return b
+}
+
+// getEnvDuration returns the duration value of key (e.g. "15m"), or def when it is unset or invalid.
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		log.Printf("Warning: %s not set, using default '%s'", key, def)
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("Warning: %s=%q is not a duration, using default '%s'", key, v, def)
+		return def
+	}
+	return d
+}
+
+// getEnvKey returns the base64-encoded key of exactly size bytes stored in key.
+// There is no fallback: data encrypted with a generated key would be unreadable
+// after a restart or on other instances, so the server refuses to start.
+func getEnvKey(key string, size int) []byte {
+	v := os.Getenv(key)
+	if v == "" {
+		log.Fatalf("%s must be set; generate one with `openssl rand -base64 %d`", key, size)
+	}
+	k, err := base64.StdEncoding.DecodeString(v)
+	if err != nil || len(k) != size {
+		log.Fatalf("%s must be a base64-encoded %d-byte key", key, size)
+	}
+	return k
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"apiserver/internal/events"
+)
+
+// newEventPublisher builds the Publisher selected by EVENTS_PUBLISHER
+// ("log", "http" or "nats").
+func newEventPublisher() events.Publisher {
+	switch kind := getEnv("EVENTS_PUBLISHER", "log"); kind {
+	case "log":
+		return events.NewLogPublisher(log.Default())
+	case "http":
+		url := os.Getenv("EVENTS_WEBHOOK_URL")
+		if url == "" {
+			log.Fatal("EVENTS_WEBHOOK_URL is required when EVENTS_PUBLISHER=http")
+		}
+		client := &http.Client{Timeout: getEnvDuration("EVENTS_WEBHOOK_TIMEOUT", 10*time.Second)}
+		return events.NewHTTPPublisher(url, client)
+	case "nats":
+		conn, err := nats.Connect(getEnv("EVENTS_NATS_URL", nats.DefaultURL), nats.Name("apiserver outbox relay"), nats.MaxReconnects(-1))
+		if err != nil {
+			log.Fatalf("Error connecting to NATS: %v", err)
+		}
+		return events.NewNATSPublisher(conn, getEnv("EVENTS_NATS_SUBJECT_PREFIX", "events."))
+	default:
+		log.Fatalf("Unknown EVENTS_PUBLISHER %q: use log, http or nats", kind)
+		return nil
+	}
+}
+
+// newRelayConfig reads the outbox relay settings.
+func newRelayConfig() events.RelayConfig {
+	cfg := events.DefaultRelayConfig()
+	cfg.PollInterval = getEnvDuration("OUTBOX_POLL_INTERVAL", cfg.PollInterval)
+	cfg.BatchSize = getEnvInt("OUTBOX_BATCH_SIZE", cfg.BatchSize)
+	cfg.MaxBackoff = getEnvDuration("OUTBOX_MAX_BACKOFF", cfg.MaxBackoff)
+	cfg.Lease = getEnvDuration("OUTBOX_LEASE", cfg.Lease)
+	return cfg
+}
+
+// newFeedConfig reads the settings of the feed behind the user event stream.
+func newFeedConfig() events.FeedConfig {
+	cfg := events.DefaultFeedConfig()
+	cfg.PollInterval = getEnvDuration("EVENT_STREAM_POLL_INTERVAL", cfg.PollInterval)
+	cfg.BufferSize = getEnvInt("EVENT_STREAM_BUFFER_SIZE", cfg.BufferSize)
+	return cfg
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/repositories"
+	"apiserver/internal/scheduler"
+	"apiserver/internal/usecases"
+)
+
+// newRetentionPolicies reads the RETENTION_* settings. A zero duration keeps
+// those records forever.
+func newRetentionPolicies() usecases.RetentionPolicies {
+	p := usecases.DefaultRetentionPolicies()
+	p.AuditEvents = getEnvDuration("RETENTION_AUDIT_EVENTS", p.AuditEvents)
+	p.ErasedUsers = getEnvDuration("RETENTION_ERASED_USERS", p.ErasedUsers)
+	p.Invitations = getEnvDuration("RETENTION_INVITATIONS", p.Invitations)
+	p.APIKeys = getEnvDuration("RETENTION_API_KEYS", p.APIKeys)
+	p.OutboxEvents = getEnvDuration("RETENTION_OUTBOX_EVENTS", p.OutboxEvents)
+	p.LoginThrottles = getEnvDuration("RETENTION_LOGIN_THROTTLES", p.LoginThrottles)
+	p.OIDCLoginStates = getEnvDuration("RETENTION_OIDC_LOGIN_STATES", p.OIDCLoginStates)
+	p.OAuthCodes = getEnvDuration("RETENTION_OAUTH_CODES", p.OAuthCodes)
+	p.OAuthTokens = getEnvDuration("RETENTION_OAUTH_TOKENS", p.OAuthTokens)
+	p.BatchSize = getEnvInt("RETENTION_BATCH_SIZE", p.BatchSize)
+	return p
+}
+
+// signingKeyRotationJob is the job that rotates the ID token signing key.
+// Startup takes its lock too when it creates the first key.
+const signingKeyRotationJob = "oidc.signing_key_rotation"
+
+// newScheduler builds the job scheduler and registers a retention job per
+// enabled policy, named retention.<policy> and run on RETENTION_SCHEDULE, and
+// the rotation of the ID token signing key on OIDC_PROVIDER_KEY_ROTATION_SCHEDULE.
+func newScheduler(dbConn *sql.DB, jobRunRepo repositories.JobRunRepository, retention usecases.RetentionInteractor, oidcProvider usecases.OIDCProviderInteractor, clk clock.Clock) *scheduler.Scheduler {
+	instance, err := os.Hostname()
+	if err != nil {
+		log.Fatalf("Failed to read the host name: %v", err)
+	}
+	sched := scheduler.New(repositories.NewAdvisoryLocker(dbConn), jobRunRepo, clk, instance, log.Default())
+
+	schedule, err := scheduler.ParseSchedule(getEnv("RETENTION_SCHEDULE", "0 3 * * *"))
+	if err != nil {
+		log.Fatalf("Invalid RETENTION_SCHEDULE: %v", err)
+	}
+	for _, policy := range retention.Policies() {
+		err := sched.Add("retention."+policy, schedule, func(ctx context.Context) (map[string]int64, error) {
+			n, err := retention.Purge(ctx, policy)
+			return map[string]int64{"deleted": n}, err
+		})
+		if err != nil {
+			log.Fatalf("Failed to register the %s retention job: %v", policy, err)
+		}
+	}
+
+	rotation, err := scheduler.ParseSchedule(getEnv("OIDC_PROVIDER_KEY_ROTATION_SCHEDULE", "0 4 1 * *"))
+	if err != nil {
+		log.Fatalf("Invalid OIDC_PROVIDER_KEY_ROTATION_SCHEDULE: %v", err)
+	}
+	err = sched.Add(signingKeyRotationJob, rotation, func(ctx context.Context) (map[string]int64, error) {
+		n, err := oidcProvider.RotateSigningKey(ctx)
+		return map[string]int64{"deleted": int64(n)}, err
+	})
+	if err != nil {
+		log.Fatalf("Failed to register the signing key rotation job: %v", err)
+	}
+	return sched
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"apiserver/internal/mail"
+)
+
+// newMailSender builds the Sender selected by MAIL_SENDER ("log" or "smtp").
+func newMailSender() mail.Sender {
+	switch kind := getEnv("MAIL_SENDER", "log"); kind {
+	case "log":
+		return mail.NewLogSender(log.Default())
+	case "smtp":
+		cfg := mail.SMTPConfig{
+			Addr:     os.Getenv("SMTP_ADDR"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("MAIL_FROM"),
+		}
+		if cfg.Addr == "" || cfg.From == "" {
+			log.Fatal("SMTP_ADDR and MAIL_FROM are required when MAIL_SENDER=smtp")
+		}
+		return mail.NewSMTPSender(cfg)
+	default:
+		log.Fatalf("Unknown MAIL_SENDER %q: use log or smtp", kind)
+		return nil
+	}
+}
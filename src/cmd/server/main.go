@@ -1,21 +1,32 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"database/sql"
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql" // MySQL driver
 	"github.com/joho/godotenv"         // For loading .env files
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 
+	"apiserver/internal/audit"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/encryption"
+	"apiserver/internal/events"
 	"apiserver/internal/generated/api" // Generated API server
+	"apiserver/internal/gql"
 	"apiserver/internal/handlers"
 	"apiserver/internal/repositories"
+	"apiserver/internal/rpc"
 	"apiserver/internal/usecases"
-	// "apiserver/internal/routes" // Old routes, replaced by api.RegisterHandlers
+	"apiserver/internal/webhooks"
 )
 
 func main() {
@@ -69,11 +80,154 @@ func main() {
 	}
 	log.Println("Successfully connected to the database.")
 
+	// User names and emails are encrypted when PII keys are configured.
+	// "server pii-rekey" migrates stored users to the primary key and exits.
+	piiConfig := newPIIConfig()
+	if len(os.Args) > 1 && os.Args[1] == "pii-rekey" {
+		runPIIRekey(dbConn, piiConfig)
+		return
+	}
+
+	// Authentication settings
+	jwtSecret := []byte(os.Getenv("JWT_SECRET"))
+	if len(jwtSecret) == 0 {
+		jwtSecret = make([]byte, 32)
+		if _, err := rand.Read(jwtSecret); err != nil {
+			log.Fatalf("Failed to generate JWT secret: %v", err)
+		}
+		log.Println("Warning: JWT_SECRET not set, using a random secret; issued tokens will not survive a restart")
+	}
+	tokenTTL := getEnvDuration("JWT_TTL", time.Hour)
+	lockoutPolicy := usecases.DefaultLockoutPolicy()
+	lockoutPolicy.AccountThreshold = getEnvInt("LOCKOUT_ACCOUNT_THRESHOLD", lockoutPolicy.AccountThreshold)
+	lockoutPolicy.IPThreshold = getEnvInt("LOCKOUT_IP_THRESHOLD", lockoutPolicy.IPThreshold)
+	lockoutPolicy.BaseDuration = getEnvDuration("LOCKOUT_BASE_DURATION", lockoutPolicy.BaseDuration)
+	lockoutPolicy.MaxDuration = getEnvDuration("LOCKOUT_MAX_DURATION", lockoutPolicy.MaxDuration)
+	authSettings := usecases.AuthSettings{
+		Lockout:   lockoutPolicy,
+		MFAIssuer: getEnv("MFA_ISSUER", "echo_tutrial"),
+	}
+	passwordHasher := newPasswordHasher()
+	mfaCipher, err := encryption.NewAESGCM(getEnvKey("MFA_ENCRYPTION_KEY", 32))
+	if err != nil {
+		log.Fatalf("Failed to initialize MFA encryption: %v", err)
+	}
+	webhookCipher, err := encryption.NewAESGCM(getEnvKey("WEBHOOK_ENCRYPTION_KEY", 32))
+	if err != nil {
+		log.Fatalf("Failed to initialize webhook encryption: %v", err)
+	}
+	signingKeyCipher, err := encryption.NewAESGCM(getEnvKey("OIDC_PROVIDER_KEY_ENCRYPTION_KEY", 32))
+	if err != nil {
+		log.Fatalf("Failed to initialize signing key encryption: %v", err)
+	}
+	importSettings := usecases.DefaultUserImportSettings()
+	importSettings.ChunkSize = getEnvInt("USER_IMPORT_CHUNK_SIZE", importSettings.ChunkSize)
+	importSettings.HashWorkers = getEnvInt("USER_IMPORT_HASH_WORKERS", importSettings.HashWorkers)
+	importSettings.SpoolDir = os.Getenv("USER_IMPORT_SPOOL_DIR")
+	batchSettings := usecases.DefaultUserBatchSettings()
+	batchSettings.MaxOperations = getEnvInt("USER_BATCH_MAX_OPERATIONS", batchSettings.MaxOperations)
+	searchSettings := usecases.DefaultUserSearchSettings()
+	searchSettings.NgramTokenSize = getEnvInt("USER_SEARCH_NGRAM_TOKEN_SIZE", searchSettings.NgramTokenSize)
+	invitationSettings := usecases.DefaultInvitationSettings()
+	invitationSettings.TTL = getEnvDuration("INVITATION_TTL", invitationSettings.TTL)
+	invitationSettings.AcceptURL = getEnv("INVITATION_ACCEPT_URL", invitationSettings.AcceptURL)
+	avatarSettings := usecases.DefaultAvatarSettings()
+	avatarSettings.MaxBytes = int64(getEnvInt("AVATAR_MAX_BYTES", int(avatarSettings.MaxBytes)))
+
 	// Initialize layers
-	userRepo := repositories.NewUserRepository(dbConn)
-	userInteractor := usecases.NewUserInteractor(userRepo)
-	// UserHandler implements api.ServerInterface
-	userHandler := handlers.NewUserHandler(userInteractor) 
+	clk := clock.Real()
+	tokenService := auth.NewJWTTokenService(jwtSecret, tokenTTL, clk)
+	blobStore, blobDir := newBlobStore(clk)
+
+	txManager := repositories.NewTxManager(dbConn)
+	auditRepo := repositories.NewAuditRepository(dbConn)
+	// Audit events are stored in the same transaction as the change they describe
+	auditRecorder := audit.NewStoreRecorder(auditRepo)
+	outboxRepo := repositories.NewOutboxRepository(dbConn, piiConfig)
+	userRepo := repositories.NewUserRepository(dbConn, piiConfig)
+	loginThrottleRepo := repositories.NewLoginThrottleRepository(dbConn)
+	mfaRepo := repositories.NewMFARepository(dbConn)
+	apiKeyRepo := repositories.NewAPIKeyRepository(dbConn)
+	webhookRepo := repositories.NewWebhookRepository(dbConn)
+	userImportRepo := repositories.NewUserImportRepository(dbConn)
+	organizationRepo := repositories.NewOrganizationRepository(dbConn)
+	orgUserRepo := repositories.NewOrgUserRepository(dbConn, piiConfig)
+	invitationRepo := repositories.NewInvitationRepository(dbConn)
+	groupRepo := repositories.NewGroupRepository(dbConn, piiConfig)
+	userAttrRepo := repositories.NewUserAttributeRepository(dbConn)
+	privacyRepo := repositories.NewPrivacyRepository(dbConn)
+	jobRunRepo := repositories.NewJobRunRepository(dbConn)
+	identityRepo := repositories.NewIdentityRepository(dbConn)
+	oauthClientRepo := repositories.NewOAuthClientRepository(dbConn)
+	oauthGrantRepo := repositories.NewOAuthGrantRepository(dbConn)
+	signingKeyRepo := repositories.NewSigningKeyRepository(dbConn)
+	breachChecker := newBreachChecker()
+	userInteractor := usecases.NewUserInteractor(userRepo, userAttrRepo, passwordHasher, breachChecker, blobStore, txManager, auditRecorder, outboxRepo, clk)
+	authInteractor := usecases.NewAuthInteractor(userRepo, loginThrottleRepo, mfaRepo, passwordHasher, tokenService, mfaCipher, auditRecorder, authSettings, clk, log.Default())
+	oidcLoginInteractor := usecases.NewOIDCLoginInteractor(newIdentityProviders(clk), identityRepo, userRepo, mfaRepo, userInteractor, tokenService, txManager, auditRecorder, newOIDCSettings(), clk)
+	oauthClientInteractor := usecases.NewOAuthClientInteractor(oauthClientRepo, auditRecorder, clk)
+	oidcProviderInteractor := usecases.NewOIDCProviderInteractor(oauthClientRepo, oauthGrantRepo, signingKeyRepo, userRepo, signingKeyCipher, txManager, auditRecorder, newOIDCProviderSettings(), clk)
+	apiKeyInteractor := usecases.NewAPIKeyInteractor(apiKeyRepo, userRepo, auditRecorder, clk)
+	auditInteractor := usecases.NewAuditInteractor(auditRepo)
+	webhookInteractor := usecases.NewWebhookInteractor(webhookRepo, webhookCipher, auditRecorder, clk)
+	userBatchInteractor := usecases.NewUserBatchInteractor(userInteractor, txManager, batchSettings)
+	userSearchInteractor := usecases.NewUserSearchInteractor(userRepo, searchSettings)
+	organizationInteractor := usecases.NewOrganizationInteractor(organizationRepo, userRepo, txManager, tokenService, auditRecorder, clk)
+	orgUserInteractor := usecases.NewOrgUserInteractor(userInteractor, orgUserRepo, txManager, auditRecorder, clk)
+	groupInteractor := usecases.NewGroupInteractor(groupRepo, userRepo, txManager, auditRecorder, clk)
+	userAttributeInteractor := usecases.NewUserAttributeInteractor(userAttrRepo, txManager, auditRecorder, clk)
+	avatarInteractor := usecases.NewAvatarInteractor(userRepo, blobStore, txManager, auditRecorder, clk, avatarSettings)
+	privacyInteractor := usecases.NewPrivacyInteractor(userRepo, privacyRepo, mfaRepo, apiKeyRepo, groupRepo, organizationRepo, loginThrottleRepo, blobStore, txManager, auditRecorder, outboxRepo, clk)
+	invitationInteractor := usecases.NewInvitationInteractor(invitationRepo, organizationRepo, userRepo, userInteractor, txManager, newMailSender(), auditRecorder, clk, invitationSettings)
+	userImportInteractor := usecases.NewUserImportInteractor(userRepo, userImportRepo, passwordHasher, breachChecker, txManager, auditRecorder, outboxRepo, clk, importSettings, log.Default())
+	// The user event stream follows the outbox through an in-memory feed
+	feed := events.NewFeed(outboxRepo, clk, newFeedConfig(), log.Default())
+	if _, err := feed.PollOnce(context.Background()); err != nil {
+		log.Fatalf("Failed to load the event feed: %v", err)
+	}
+	userEventInteractor := usecases.NewUserEventInteractor(feed)
+	// Scheduled jobs run on one instance at a time, coordinated through MySQL
+	retentionInteractor := usecases.NewRetentionInteractor(repositories.NewRetentionRepository(dbConn), txManager, clk, newRetentionPolicies())
+	sched := newScheduler(dbConn, jobRunRepo, retentionInteractor, oidcProviderInteractor, clk)
+	// Instances starting together would each create a key, so they take turns
+	if err := sched.RunExclusive(context.Background(), signingKeyRotationJob, oidcProviderInteractor.EnsureSigningKey); err != nil {
+		log.Fatalf("Failed to prepare the ID token signing key: %v", err)
+	}
+	jobInteractor := usecases.NewJobInteractor(sched, jobRunRepo)
+	// Server implements api.ServerInterface by combining the per-resource handlers
+	server := &handlers.Server{
+		UserHandler:          handlers.NewUserHandler(userInteractor),
+		UserEventHandler:     handlers.NewUserEventHandler(userEventInteractor, getEnvDuration("EVENT_STREAM_HEARTBEAT", 15*time.Second)),
+		AuthHandler:          handlers.NewAuthHandler(authInteractor),
+		OIDCLoginHandler:     handlers.NewOIDCLoginHandler(oidcLoginInteractor),
+		OIDCProviderHandler:  handlers.NewOIDCProviderHandler(oidcProviderInteractor),
+		OAuthClientHandler:   handlers.NewOAuthClientHandler(oauthClientInteractor),
+		APIKeyHandler:        handlers.NewAPIKeyHandler(apiKeyInteractor),
+		AuditHandler:         handlers.NewAuditHandler(auditInteractor),
+		WebhookHandler:       handlers.NewWebhookHandler(webhookInteractor),
+		UserImportHandler:    handlers.NewUserImportHandler(userImportInteractor),
+		UserBatchHandler:     handlers.NewUserBatchHandler(userBatchInteractor),
+		UserSearchHandler:    handlers.NewUserSearchHandler(userSearchInteractor),
+		OrganizationHandler:  handlers.NewOrganizationHandler(organizationInteractor),
+		OrgUserHandler:       handlers.NewOrgUserHandler(orgUserInteractor),
+		InvitationHandler:    handlers.NewInvitationHandler(invitationInteractor),
+		GroupHandler:         handlers.NewGroupHandler(groupInteractor),
+		AvatarHandler:        handlers.NewAvatarHandler(avatarInteractor),
+		UserAttributeHandler: handlers.NewUserAttributeHandler(userAttributeInteractor),
+		PrivacyHandler:       handlers.NewPrivacyHandler(privacyInteractor),
+		JobHandler:           handlers.NewJobHandler(jobInteractor),
+	}
+
+	// Relay domain events from the outbox in the background, at least once. The
+	// webhook dispatcher queues a delivery per subscription and ignores events
+	// it already queued for a subscription, so redelivered events are not sent
+	// twice.
+	publisher := events.NewMultiPublisher(newEventPublisher(), webhooks.NewDispatcher(webhookRepo, clk))
+	relay := events.NewRelay(outboxRepo, txManager, publisher, clk, newRelayConfig(), log.Default())
+	go relay.Run(context.Background())
+	go newWebhookWorker(webhookRepo, txManager, webhookCipher, clk).Run(context.Background())
+	go feed.Run(context.Background())
+	go sched.Run(context.Background())
 
 	// Echo instance
 	e := echo.New()
@@ -81,12 +235,45 @@ func main() {
 	// Middleware
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
+	e.Use(middleware.RequestID())
+	e.Use(handlers.RequestInfo())
+	e.Use(handlers.Authenticate(tokenService, apiKeyInteractor))
+	e.Use(handlers.ResolveTenant(organizationInteractor))
 
 	// Register handlers - oapi-codegen generates this function
 	// The first argument is the Echo instance, the second is our ServerInterface implementation
-	api.RegisterHandlers(e, userHandler) 
+	api.RegisterHandlers(e, server)
+	// Blobs such as avatars are public; serve them when they are stored locally
+	if blobDir != "" {
+		e.Static(blobRoute, blobDir)
+	}
 
-	// The old routes.SetupRoutes(e) is now replaced by api.RegisterHandlers(e, userHandler)
+	// GraphQL resolves through the same interactors; GraphiQL is off unless enabled
+	graphqlLimits := gql.DefaultLimits()
+	graphqlLimits.MaxDepth = getEnvInt("GRAPHQL_MAX_DEPTH", graphqlLimits.MaxDepth)
+	graphqlLimits.MaxComplexity = getEnvInt("GRAPHQL_MAX_COMPLEXITY", graphqlLimits.MaxComplexity)
+	graphqlHandler, err := gql.NewHandler(userInteractor, graphqlLimits)
+	if err != nil {
+		log.Fatalf("Failed to build the GraphQL schema: %v", err)
+	}
+	e.POST("/graphql", graphqlHandler.Serve)
+	if getEnvBool("GRAPHIQL_ENABLED", false) {
+		e.GET("/graphql", graphqlHandler.GraphiQL)
+	}
+
+	// The gRPC API runs on its own port and shares the interactors with the HTTP API
+	grpcPort := getEnv("GRPC_PORT", "9090")
+	grpcListener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("Failed to listen on gRPC port %s: %v", grpcPort, err)
+	}
+	grpcServer := rpc.NewServer(userInteractor, tokenService, apiKeyInteractor, log.Default())
+	go func() {
+		log.Printf("Starting gRPC server on :%s", grpcPort)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatalf("gRPC server stopped: %v", err)
+		}
+	}()
 
 	// Start server
 	serverPort := os.Getenv("SERVER_PORT")
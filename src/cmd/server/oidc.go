@@ -0,0 +1,71 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/oidc"
+	"apiserver/internal/usecases"
+)
+
+// newIdentityProviders builds the identity providers named in the
+// comma-separated OIDC_PROVIDERS. Each is configured by OIDC_<NAME>_*
+// settings, with the name upper-cased and dashes turned into underscores.
+func newIdentityProviders(clk clock.Clock) []usecases.IdentityProvider {
+	client := &http.Client{Timeout: getEnvDuration("OIDC_HTTP_TIMEOUT", 10*time.Second)}
+	var providers []usecases.IdentityProvider
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		cfg := oidc.Config{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		}
+		if cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+			log.Fatalf("Identity provider %s needs %sISSUER, %sCLIENT_ID and %sREDIRECT_URL", name, prefix, prefix, prefix)
+		}
+		providers = append(providers, usecases.IdentityProvider{
+			Provider:  oidc.NewProvider(cfg, client, clk),
+			Provision: getEnvBool(prefix+"PROVISION", false),
+		})
+	}
+	return providers
+}
+
+// newOIDCSettings reads the OIDC_* settings shared by all identity providers.
+func newOIDCSettings() usecases.OIDCSettings {
+	s := usecases.DefaultOIDCSettings()
+	s.LoginTTL = getEnvDuration("OIDC_LOGIN_TTL", s.LoginTTL)
+	return s
+}
+
+// newOIDCProviderSettings reads the OIDC_PROVIDER_* settings for acting as the
+// OpenID provider of internal applications.
+func newOIDCProviderSettings() usecases.OIDCProviderSettings {
+	s := usecases.DefaultOIDCProviderSettings()
+	s.Issuer = getEnv("OIDC_PROVIDER_ISSUER", s.Issuer)
+	s.ConsentURL = getEnv("OIDC_PROVIDER_CONSENT_URL", s.ConsentURL)
+	s.CodeTTL = getEnvDuration("OIDC_PROVIDER_CODE_TTL", s.CodeTTL)
+	s.AccessTokenTTL = getEnvDuration("OIDC_PROVIDER_ACCESS_TOKEN_TTL", s.AccessTokenTTL)
+	s.IDTokenTTL = getEnvDuration("OIDC_PROVIDER_ID_TOKEN_TTL", s.IDTokenTTL)
+	s.KeyGracePeriod = getEnvDuration("OIDC_PROVIDER_KEY_GRACE_PERIOD", s.KeyGracePeriod)
+	if s.KeyGracePeriod < s.IDTokenTTL {
+		log.Fatal("OIDC_PROVIDER_KEY_GRACE_PERIOD must be at least OIDC_PROVIDER_ID_TOKEN_TTL")
+	}
+	s.KeyPublishAhead = getEnvDuration("OIDC_PROVIDER_KEY_PUBLISH_AHEAD", s.KeyPublishAhead)
+	if s.KeyPublishAhead < oidc.JWKSMaxAge {
+		log.Fatalf("OIDC_PROVIDER_KEY_PUBLISH_AHEAD must be at least %s, how long clients cache the key set", oidc.JWKSMaxAge)
+	}
+	return s
+}
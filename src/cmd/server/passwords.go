@@ -0,0 +1,49 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"apiserver/internal/password"
+)
+
+// newPasswordHasher builds the Hasher from the PASSWORD_* settings. Stored
+// hashes of either algorithm keep working; they are replaced with ones using
+// these settings as users sign in.
+func newPasswordHasher() password.Hasher {
+	cfg := password.DefaultConfig()
+	cfg.Algorithm = getEnv("PASSWORD_HASH_ALGORITHM", cfg.Algorithm)
+	cfg.BcryptCost = getEnvInt("PASSWORD_BCRYPT_COST", cfg.BcryptCost)
+	cfg.Argon2id.Memory = uint32(getEnvInt("PASSWORD_ARGON2ID_MEMORY_KIB", int(cfg.Argon2id.Memory)))
+	cfg.Argon2id.Iterations = uint32(getEnvInt("PASSWORD_ARGON2ID_ITERATIONS", int(cfg.Argon2id.Iterations)))
+	cfg.Argon2id.Parallelism = uint8(getEnvInt("PASSWORD_ARGON2ID_PARALLELISM", int(cfg.Argon2id.Parallelism)))
+	hasher, err := password.New(cfg)
+	if err != nil {
+		log.Fatalf("Invalid password hash settings: %v", err)
+	}
+	return hasher
+}
+
+// newBreachChecker builds the breached-password check selected by
+// PASSWORD_BREACH_CHECK: "off", "local" for a downloaded copy of the Pwned
+// Passwords range files, or "http" for the range API. It returns nil when off.
+func newBreachChecker() password.BreachChecker {
+	var source password.RangeSource
+	switch kind := getEnv("PASSWORD_BREACH_CHECK", "off"); kind {
+	case "off":
+		return nil
+	case "local":
+		dir := getEnv("PASSWORD_BREACH_DIR", "")
+		if dir == "" {
+			log.Fatal("PASSWORD_BREACH_DIR is required when PASSWORD_BREACH_CHECK=local")
+		}
+		source = password.NewDirRangeSource(dir)
+	case "http":
+		client := &http.Client{Timeout: getEnvDuration("PASSWORD_BREACH_API_TIMEOUT", 5*time.Second)}
+		source = password.NewHTTPRangeSource(getEnv("PASSWORD_BREACH_API_URL", "https://api.pwnedpasswords.com"), client)
+	default:
+		log.Fatalf("Unknown PASSWORD_BREACH_CHECK %q: use off, local or http", kind)
+	}
+	return password.NewBreachChecker(source, getEnvInt("PASSWORD_BREACH_MIN_COUNT", 1))
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"apiserver/internal/encryption"
+	"apiserver/internal/repositories"
+)
+
+// newPIIConfig builds the user PII encryption settings from PII_ENCRYPTION_KEYS
+// and PII_BLIND_INDEX_KEY. Unlike the other keys they are optional: names and
+// emails are stored in plaintext until both are set.
+func newPIIConfig() repositories.PIIConfig {
+	keys := os.Getenv("PII_ENCRYPTION_KEYS")
+	indexKey := os.Getenv("PII_BLIND_INDEX_KEY")
+	if keys == "" && indexKey == "" {
+		log.Println("Warning: PII_ENCRYPTION_KEYS not set, user names and emails are stored in plaintext")
+		return repositories.PIIConfig{}
+	}
+	if keys == "" || indexKey == "" {
+		log.Fatalf("PII_ENCRYPTION_KEYS and PII_BLIND_INDEX_KEY must be set together")
+	}
+
+	var primary uint64
+	if v := os.Getenv("PII_ENCRYPTION_PRIMARY_VERSION"); v != "" {
+		var err error
+		if primary, err = strconv.ParseUint(v, 10, 32); err != nil {
+			log.Fatalf("PII_ENCRYPTION_PRIMARY_VERSION must be a key version: %v", err)
+		}
+	}
+	keyring, err := encryption.ParseKeyring(keys, uint32(primary))
+	if err != nil {
+		log.Fatalf("Invalid PII_ENCRYPTION_KEYS: %v", err)
+	}
+	rawIndexKey, err := base64.StdEncoding.DecodeString(indexKey)
+	if err != nil {
+		log.Fatalf("PII_BLIND_INDEX_KEY must be base64-encoded")
+	}
+	blindIndex, err := encryption.NewBlindIndex(rawIndexKey)
+	if err != nil {
+		log.Fatalf("Invalid PII_BLIND_INDEX_KEY: %v", err)
+	}
+	log.Printf("User PII encryption enabled with key version %d (keyring has %v)", keyring.PrimaryVersion(), keyring.Versions())
+	return repositories.PIIConfig{Keyring: keyring, BlindIndex: blindIndex}
+}
+
+// runPIIRekey re-encrypts every user whose name and email are in plaintext or
+// under a key other than the primary one. Each batch is its own short
+// transaction and the server keeps running meanwhile, since it reads any key
+// in the keyring. Once it finishes, retired keys can be removed from
+// PII_ENCRYPTION_KEYS. It is safe to interrupt and run again.
+func runPIIRekey(dbConn *sql.DB, pii repositories.PIIConfig) {
+	repo, err := repositories.NewUserPIIRepository(dbConn, pii)
+	if err != nil {
+		log.Fatalf("Cannot re-encrypt users: %v", err)
+	}
+	tx := repositories.NewTxManager(dbConn)
+	batchSize := getEnvInt("PII_REKEY_BATCH_SIZE", 200)
+	pause := getEnvDuration("PII_REKEY_PAUSE", 100*time.Millisecond)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	lastID, total := "", 0
+	for {
+		var n int
+		err := tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			lastID, n, err = repo.ReencryptUsers(ctx, lastID, batchSize)
+			return err
+		})
+		if err != nil {
+			log.Fatalf("Re-encryption stopped after %d users: %v", total, err)
+		}
+		total += n
+		if n < batchSize {
+			break
+		}
+		log.Printf("Re-encrypted %d users so far (last %s)", total, lastID)
+		select {
+		case <-ctx.Done():
+			log.Printf("Interrupted after %d users; run again to continue", total)
+			return
+		case <-time.After(pause):
+		}
+	}
+	log.Printf("Re-encrypted %d users; all are under key version %d", total, pii.Keyring.PrimaryVersion())
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/storage"
+)
+
+// blobRoute is where the local blob store is served from.
+const blobRoute = "/blobs"
+
+// newBlobStore builds the BlobStore selected by BLOB_STORE ("local" or "s3").
+// For the local store it also returns the directory to serve at blobRoute.
+func newBlobStore(clk clock.Clock) (storage.BlobStore, string) {
+	switch kind := getEnv("BLOB_STORE", "local"); kind {
+	case "local":
+		dir := getEnv("BLOB_LOCAL_DIR", "data/blobs")
+		return storage.NewLocalBlobStore(dir, getEnv("BLOB_PUBLIC_URL", blobRoute)), dir
+	case "s3":
+		cfg := storage.S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          getEnv("S3_REGION", "us-east-1"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PublicURL:       os.Getenv("BLOB_PUBLIC_URL"),
+		}
+		if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+			log.Fatal("S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required when BLOB_STORE=s3")
+		}
+		return storage.NewS3BlobStore(cfg, clk), ""
+	default:
+		log.Fatalf("Unknown BLOB_STORE %q: use local or s3", kind)
+		return nil, ""
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/encryption"
+	"apiserver/internal/repositories"
+	"apiserver/internal/webhooks"
+)
+
+// newWebhookWorker builds the delivery worker from the WEBHOOK_* settings.
+func newWebhookWorker(repo repositories.WebhookRepository, tx repositories.TxManager, secrets encryption.Cipher, clk clock.Clock) *webhooks.Worker {
+	cfg := webhooks.DefaultWorkerConfig()
+	cfg.PollInterval = getEnvDuration("WEBHOOK_POLL_INTERVAL", cfg.PollInterval)
+	cfg.MaxAttempts = getEnvInt("WEBHOOK_MAX_ATTEMPTS", cfg.MaxAttempts)
+	cfg.MaxBackoff = getEnvDuration("WEBHOOK_MAX_BACKOFF", cfg.MaxBackoff)
+	cfg.Lease = getEnvDuration("WEBHOOK_LEASE", cfg.Lease)
+	cfg.Concurrency = getEnvInt("WEBHOOK_CONCURRENCY", cfg.Concurrency)
+	client := &http.Client{
+		Timeout: getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		// A redirect is treated as a failed delivery rather than followed
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return webhooks.NewWorker(repo, tx, secrets, client, clk, cfg, log.Default())
+}
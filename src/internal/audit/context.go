@@ -0,0 +1,22 @@
+package audit
+
+import "context"
+
+// RequestInfo identifies the request an audit event originates from.
+type RequestInfo struct {
+	RequestID string
+	IP        string
+}
+
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a copy of ctx carrying info.
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFrom returns the request information stored in ctx, if any.
+func RequestInfoFrom(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info
+}
@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRecorder struct {
+	mock.Mock
+}
+
+func (m *MockRecorder) Record(ctx context.Context, event domain.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
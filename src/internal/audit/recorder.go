@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"apiserver/internal/domain"
+)
+
+// Recorder persists audit events.
+type Recorder interface {
+	Record(ctx context.Context, event domain.AuditEvent) error
+}
+
+// Store appends audit events to durable storage. It is satisfied by
+// repositories.AuditRepository.
+type Store interface {
+	AppendAuditEvent(ctx context.Context, event domain.AuditEvent) error
+}
+
+// withRequestInfo fills the request ID and IP from ctx when the caller did not set them.
+func withRequestInfo(ctx context.Context, event domain.AuditEvent) domain.AuditEvent {
+	info := RequestInfoFrom(ctx)
+	if event.RequestID == "" {
+		event.RequestID = info.RequestID
+	}
+	if event.IP == "" {
+		event.IP = info.IP
+	}
+	return event
+}
+
+// logRecorder writes audit events as JSON lines to a logger.
+type logRecorder struct {
+	logger *log.Logger
+}
+
+// NewLogRecorder creates a Recorder that writes each event to logger.
+func NewLogRecorder(logger *log.Logger) Recorder {
+	return &logRecorder{logger: logger}
+}
+
+func (r *logRecorder) Record(ctx context.Context, event domain.AuditEvent) error {
+	b, err := json.Marshal(withRequestInfo(ctx, event))
+	if err != nil {
+		return err
+	}
+	r.logger.Printf("audit: %s", b)
+	return nil
+}
+
+// storeRecorder appends audit events to a Store. Because the store joins the
+// transaction carried by ctx, an event recorded inside a unit of work is
+// committed or rolled back together with the change it describes.
+type storeRecorder struct {
+	store Store
+}
+
+// NewStoreRecorder creates a Recorder that appends each event to store.
+func NewStoreRecorder(store Store) Recorder {
+	return &storeRecorder{store: store}
+}
+
+func (r *storeRecorder) Record(ctx context.Context, event domain.AuditEvent) error {
+	return r.store.AppendAuditEvent(ctx, withRequestInfo(ctx, event))
+}
@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStore struct {
+	events []domain.AuditEvent
+}
+
+func (s *fakeStore) AppendAuditEvent(ctx context.Context, event domain.AuditEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestStoreRecorder_FillsRequestInfo(t *testing.T) {
+	store := &fakeStore{}
+	recorder := NewStoreRecorder(store)
+	ctx := WithRequestInfo(context.Background(), RequestInfo{RequestID: "req-1", IP: "192.0.2.1"})
+
+	assert.NoError(t, recorder.Record(ctx, domain.AuditEvent{Action: domain.AuditActionUserCreated}))
+	assert.NoError(t, recorder.Record(ctx, domain.AuditEvent{Action: domain.AuditActionLoginLockout, IP: "198.51.100.7"}))
+
+	assert.Equal(t, "req-1", store.events[0].RequestID)
+	assert.Equal(t, "192.0.2.1", store.events[0].IP)
+	assert.Equal(t, "198.51.100.7", store.events[1].IP, "an explicit IP is kept")
+}
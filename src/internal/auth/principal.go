@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"apiserver/internal/domain"
+)
+
+// Principal is the authenticated caller of a request.
+// Callers authenticated with an API key are limited to the key's scopes;
+// interactive sessions may do anything their role allows.
+type Principal struct {
+	UserID   string
+	Role     string
+	MFA      bool     // True when the caller signed in with a second factor
+	APIKeyID string   // Set when the caller authenticated with an API key
+	Scopes   []string // Scopes granted to the API key; unused for sessions
+	// OrgID is the organization an access token was issued for. It is only a
+	// request; membership is verified before a tenant is selected from it.
+	OrgID     string
+	ExpiresAt time.Time // When the access token expires; zero for API keys
+}
+
+// IsAdmin reports whether the principal holds the admin role.
+func (p *Principal) IsAdmin() bool {
+	return p != nil && p.Role == domain.RoleAdmin
+}
+
+// IsAPIKey reports whether the principal authenticated with an API key.
+func (p *Principal) IsAPIKey() bool {
+	return p != nil && p.APIKeyID != ""
+}
+
+// HasScope reports whether the principal may act within scope.
+func (p *Principal) HasScope(scope string) bool {
+	if p == nil {
+		return false
+	}
+	if !p.IsAPIKey() {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying p.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom returns the principal stored in ctx, or nil for anonymous callers.
+func PrincipalFrom(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"testing"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal_HasScope(t *testing.T) {
+	session := &Principal{UserID: "user-1", Role: domain.RoleUser}
+	assert.True(t, session.HasScope(domain.ScopeUsersWrite), "sessions are not limited by scopes")
+
+	key := &Principal{UserID: "user-1", Role: domain.RoleUser, APIKeyID: "key-1", Scopes: []string{domain.ScopeUsersRead}}
+	assert.True(t, key.HasScope(domain.ScopeUsersRead))
+	assert.False(t, key.HasScope(domain.ScopeUsersWrite))
+
+	var anonymous *Principal
+	assert.False(t, anonymous.HasScope(domain.ScopeUsersRead))
+}
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned when a bearer token is malformed, expired or has a bad signature.
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// mfaChallengeTTL bounds how long a user has to enter their second factor after the password step.
+const mfaChallengeTTL = 5 * time.Minute
+
+// Token purposes. An MFA challenge token must never be accepted as an access token.
+const (
+	purposeAccess       = "access"
+	purposeMFAChallenge = "mfa_challenge"
+)
+
+// Authentication method references (RFC 8176) recorded in access tokens.
+const (
+	amrPassword = "pwd"
+	amrOTP      = "otp"
+)
+
+// TokenService issues and verifies access tokens and MFA challenge tokens.
+type TokenService interface {
+	Issue(user *domain.User, mfa bool) (token string, expiresAt time.Time, err error)
+	// IssueForOrganization reissues the access token p was verified from for
+	// the organization orgID. The new token expires with the original.
+	IssueForOrganization(p *Principal, orgID string) (token string, expiresAt time.Time, err error)
+	Verify(token string) (*Principal, error)
+	IssueMFAChallenge(user *domain.User) (token string, expiresAt time.Time, err error)
+	VerifyMFAChallenge(token string) (userID string, err error)
+}
+
+type claims struct {
+	Purpose string   `json:"purpose"`
+	Role    string   `json:"role,omitempty"`
+	AMR     []string `json:"amr,omitempty"`
+	Org     string   `json:"org,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// jwtTokenService implements TokenService with HS256-signed JWTs.
+type jwtTokenService struct {
+	secret []byte
+	ttl    time.Duration
+	clock  clock.Clock
+}
+
+// NewJWTTokenService creates a TokenService signing tokens with secret. Access tokens expire after ttl.
+func NewJWTTokenService(secret []byte, ttl time.Duration, clk clock.Clock) TokenService {
+	return &jwtTokenService{secret: secret, ttl: ttl, clock: clk}
+}
+
+func (s *jwtTokenService) sign(c claims, ttl time.Duration) (string, time.Time, error) {
+	now := s.clock.Now()
+	expiresAt := now.Add(ttl)
+	c.IssuedAt = jwt.NewNumericDate(now)
+	c.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString(s.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+func (s *jwtTokenService) parse(token, purpose string) (*claims, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.clock.Now),
+	)
+	if err != nil || c.Subject == "" || c.Purpose != purpose {
+		return nil, ErrInvalidToken
+	}
+	return &c, nil
+}
+
+// accessAMR returns the authentication methods recorded in an access token.
+func accessAMR(mfa bool) []string {
+	amr := []string{amrPassword}
+	if mfa {
+		amr = append(amr, amrOTP)
+	}
+	return amr
+}
+
+func (s *jwtTokenService) Issue(user *domain.User, mfa bool) (string, time.Time, error) {
+	return s.sign(claims{
+		Purpose:          purposeAccess,
+		Role:             user.Role,
+		AMR:              accessAMR(mfa),
+		RegisteredClaims: jwt.RegisteredClaims{Subject: user.ID},
+	}, s.ttl)
+}
+
+func (s *jwtTokenService) IssueForOrganization(p *Principal, orgID string) (string, time.Time, error) {
+	// Switching organizations must not extend a session
+	ttl := p.ExpiresAt.Sub(s.clock.Now())
+	if p.UserID == "" || ttl <= 0 {
+		return "", time.Time{}, ErrInvalidToken
+	}
+	return s.sign(claims{
+		Purpose:          purposeAccess,
+		Role:             p.Role,
+		AMR:              accessAMR(p.MFA),
+		Org:              orgID,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: p.UserID},
+	}, ttl)
+}
+
+func (s *jwtTokenService) Verify(token string) (*Principal, error) {
+	c, err := s.parse(token, purposeAccess)
+	if err != nil {
+		return nil, err
+	}
+	return &Principal{
+		UserID:    c.Subject,
+		Role:      c.Role,
+		MFA:       slices.Contains(c.AMR, amrOTP),
+		OrgID:     c.Org,
+		ExpiresAt: c.ExpiresAt.Time,
+	}, nil
+}
+
+func (s *jwtTokenService) IssueMFAChallenge(user *domain.User) (string, time.Time, error) {
+	return s.sign(claims{
+		Purpose:          purposeMFAChallenge,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: user.ID},
+	}, mfaChallengeTTL)
+}
+
+func (s *jwtTokenService) VerifyMFAChallenge(token string) (string, error) {
+	c, err := s.parse(token, purposeMFAChallenge)
+	if err != nil {
+		return "", err
+	}
+	return c.Subject, nil
+}
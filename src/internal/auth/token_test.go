@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWTTokenService_IssueAndVerify(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	svc := NewJWTTokenService([]byte("secret"), time.Hour, clk)
+
+	token, expiresAt, err := svc.Issue(&domain.User{ID: "user-1", Role: domain.RoleAdmin}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, clk.Now().Add(time.Hour), expiresAt)
+
+	principal, err := svc.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, &Principal{UserID: "user-1", Role: domain.RoleAdmin, MFA: false, ExpiresAt: expiresAt.Local()}, principal)
+	assert.True(t, principal.IsAdmin())
+}
+
+func TestJWTTokenService_Verify_Expired(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	svc := NewJWTTokenService([]byte("secret"), time.Hour, clk)
+
+	token, _, err := svc.Issue(&domain.User{ID: "user-1", Role: domain.RoleUser}, false)
+	assert.NoError(t, err)
+
+	clk.Advance(2 * time.Hour)
+	_, err = svc.Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestJWTTokenService_Verify_WrongSecret(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	token, _, err := NewJWTTokenService([]byte("secret"), time.Hour, clk).Issue(&domain.User{ID: "user-1"}, false)
+	assert.NoError(t, err)
+
+	_, err = NewJWTTokenService([]byte("other"), time.Hour, clk).Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestJWTTokenService_Issue_RecordsMFA(t *testing.T) {
+	svc := NewJWTTokenService([]byte("secret"), time.Hour, clock.Real())
+
+	token, _, err := svc.Issue(&domain.User{ID: "user-1", Role: domain.RoleAdmin}, true)
+	assert.NoError(t, err)
+
+	principal, err := svc.Verify(token)
+	assert.NoError(t, err)
+	assert.True(t, principal.MFA)
+}
+
+func TestJWTTokenService_IssueForOrganization(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	svc := NewJWTTokenService([]byte("secret"), time.Hour, clk)
+	token, expiresAt, _ := svc.Issue(&domain.User{ID: "user-1", Role: domain.RoleUser}, true)
+	principal, _ := svc.Verify(token)
+
+	clk.Advance(10 * time.Minute)
+	orgToken, orgExpiresAt, err := svc.IssueForOrganization(principal, "org-1")
+	assert.NoError(t, err)
+	assert.Equal(t, expiresAt, orgExpiresAt, "switching organizations does not extend the session")
+
+	orgPrincipal, err := svc.Verify(orgToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "org-1", orgPrincipal.OrgID)
+	assert.Equal(t, "user-1", orgPrincipal.UserID)
+	assert.True(t, orgPrincipal.MFA)
+
+	clk.Advance(time.Hour)
+	_, _, err = svc.IssueForOrganization(principal, "org-1")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestJWTTokenService_MFAChallenge(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	svc := NewJWTTokenService([]byte("secret"), time.Hour, clk)
+
+	challenge, _, err := svc.IssueMFAChallenge(&domain.User{ID: "user-1"})
+	assert.NoError(t, err)
+
+	userID, err := svc.VerifyMFAChallenge(challenge)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+
+	// A challenge must not be usable as an access token, nor the other way round
+	_, err = svc.Verify(challenge)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	access, _, _ := svc.Issue(&domain.User{ID: "user-1"}, false)
+	_, err = svc.VerifyMFAChallenge(access)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	clk.Advance(mfaChallengeTTL + time.Second)
+	_, err = svc.VerifyMFAChallenge(challenge)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
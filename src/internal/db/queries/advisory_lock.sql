@@ -0,0 +1,5 @@
+-- name: GetLock :one
+SELECT COALESCE(GET_LOCK(sqlc.arg(name), 0), 0) = 1 AS acquired;
+
+-- name: ReleaseLock :exec
+DO RELEASE_LOCK(sqlc.arg(name));
@@ -0,0 +1,29 @@
+-- name: CreateAPIKey :execresult
+INSERT INTO api_keys (
+  id, user_id, name, prefix, secret_hash, scopes, expires_at
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetAPIKeyByID :one
+SELECT * FROM api_keys
+WHERE id = ? LIMIT 1;
+
+-- name: GetAPIKeyByPrefix :one
+SELECT * FROM api_keys
+WHERE prefix = ? LIMIT 1;
+
+-- name: ListAPIKeysByUser :many
+SELECT * FROM api_keys
+WHERE user_id = ?
+ORDER BY created_at DESC;
+
+-- name: RevokeAPIKey :execresult
+UPDATE api_keys
+SET revoked_at = ?
+WHERE id = ? AND revoked_at IS NULL;
+
+-- name: TouchAPIKey :execresult
+UPDATE api_keys
+SET last_used_at = ?
+WHERE id = ?;
@@ -0,0 +1,17 @@
+-- name: CreateAuditEvent :execresult
+INSERT INTO audit_events (
+  action, actor_id, target_id, changes, metadata, request_id, ip, occurred_at
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: ListAuditEvents :many
+SELECT * FROM audit_events
+WHERE (sqlc.narg('actor_id') IS NULL OR actor_id = sqlc.narg('actor_id'))
+  AND (sqlc.narg('target_id') IS NULL OR target_id = sqlc.narg('target_id'))
+  AND (sqlc.narg('action') IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('occurred_from') IS NULL OR occurred_at >= sqlc.narg('occurred_from'))
+  AND (sqlc.narg('occurred_to') IS NULL OR occurred_at < sqlc.narg('occurred_to'))
+  AND (sqlc.narg('before_id') IS NULL OR id < sqlc.narg('before_id'))
+ORDER BY id DESC
+LIMIT ?;
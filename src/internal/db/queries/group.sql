@@ -0,0 +1,52 @@
+-- name: CreateGroup :execresult
+INSERT INTO user_groups (
+  id, name, description
+) VALUES (
+  ?, ?, ?
+);
+
+-- name: GetGroup :one
+SELECT * FROM user_groups
+WHERE id = ? LIMIT 1;
+
+-- name: GetGroupByName :one
+SELECT * FROM user_groups
+WHERE name = ? LIMIT 1;
+
+-- name: ListGroups :many
+SELECT * FROM user_groups
+ORDER BY name, id;
+
+-- name: UpdateGroup :execresult
+UPDATE user_groups
+SET name = ?, description = ?
+WHERE id = ?;
+
+-- name: DeleteGroup :execresult
+DELETE FROM user_groups
+WHERE id = ?;
+
+-- name: AddGroupMember :execresult
+INSERT IGNORE INTO user_group_members (
+  group_id, user_id
+) VALUES (
+  ?, ?
+);
+
+-- name: RemoveGroupMember :execresult
+DELETE FROM user_group_members
+WHERE group_id = ? AND user_id = ?;
+
+-- name: ListGroupMembers :many
+SELECT u.id, u.name, u.email, u.name_encrypted, u.email_encrypted, u.role, u.created_at, u.UpdatedAt, u.avatar_url, u.avatar_thumbnail_url, u.preferences, u.attributes
+FROM user_group_members m
+JOIN Users u ON u.id = m.user_id
+WHERE m.group_id = ?
+ORDER BY u.name, u.id;
+
+-- name: ListUserGroups :many
+SELECT g.id, g.name, g.description, g.created_at, g.updated_at
+FROM user_group_members m
+JOIN user_groups g ON g.id = m.group_id
+WHERE m.user_id = ?
+ORDER BY g.name, g.id;
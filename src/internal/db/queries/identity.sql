@@ -0,0 +1,30 @@
+-- name: GetUserIdentity :one
+SELECT * FROM user_identities
+WHERE provider = ? AND subject = ? LIMIT 1;
+
+-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (
+  id, user_id, provider, subject, last_login_at
+) VALUES (
+  ?, ?, ?, ?, ?
+);
+
+-- name: TouchUserIdentity :exec
+UPDATE user_identities
+SET last_login_at = ?
+WHERE id = ?;
+
+-- name: CreateOIDCLoginState :exec
+INSERT INTO oidc_login_states (
+  state_hash, provider, nonce, code_verifier, expires_at
+) VALUES (
+  ?, ?, ?, ?, ?
+);
+
+-- name: GetOIDCLoginState :one
+SELECT * FROM oidc_login_states
+WHERE state_hash = ? LIMIT 1;
+
+-- name: DeleteOIDCLoginState :execresult
+DELETE FROM oidc_login_states
+WHERE state_hash = ?;
@@ -0,0 +1,36 @@
+-- name: CreateInvitation :execresult
+INSERT INTO invitations (
+  id, organization_id, email, role, token_hash, invited_by, expires_at, created_at, updated_at
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetInvitation :one
+SELECT * FROM invitations
+WHERE id = ? LIMIT 1;
+
+-- name: GetInvitationByTokenHash :one
+SELECT * FROM invitations
+WHERE token_hash = ? LIMIT 1;
+
+-- name: GetPendingInvitation :one
+SELECT * FROM invitations
+WHERE organization_id = ? AND email = ?
+  AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: AcceptInvitation :execresult
+UPDATE invitations
+SET accepted_at = ?, accepted_by = ?, updated_at = ?
+WHERE id = ? AND accepted_at IS NULL AND revoked_at IS NULL;
+
+-- name: RenewInvitation :execresult
+UPDATE invitations
+SET token_hash = ?, expires_at = ?, updated_at = ?
+WHERE id = ? AND accepted_at IS NULL AND revoked_at IS NULL;
+
+-- name: RevokeInvitation :execresult
+UPDATE invitations
+SET revoked_at = ?, updated_at = ?
+WHERE id = ? AND accepted_at IS NULL AND revoked_at IS NULL;
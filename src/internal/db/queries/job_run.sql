@@ -0,0 +1,19 @@
+-- name: CreateJobRun :execresult
+INSERT IGNORE INTO job_runs (
+  id, job_name, scheduled_at, instance, status, started_at
+) VALUES (
+  ?, ?, ?, ?, ?, ?
+);
+
+-- name: FinishJobRun :exec
+UPDATE job_runs
+SET status = ?, result = ?, error = ?, finished_at = ?
+WHERE id = ?;
+
+-- name: ListLatestJobRuns :many
+SELECT r.* FROM job_runs r
+JOIN (
+  SELECT job_name, MAX(scheduled_at) AS scheduled_at FROM job_runs
+  GROUP BY job_name
+) latest ON latest.job_name = r.job_name AND latest.scheduled_at = r.scheduled_at
+ORDER BY r.job_name;
@@ -0,0 +1,20 @@
+-- name: GetLoginThrottle :one
+SELECT * FROM login_throttles
+WHERE scope = ? AND subject = ? LIMIT 1;
+
+-- name: IncrementLoginFailure :execresult
+INSERT INTO login_throttles (
+  scope, subject, failed_count, last_failed_at
+) VALUES (
+  ?, ?, 1, ?
+)
+ON DUPLICATE KEY UPDATE failed_count = failed_count + 1, last_failed_at = VALUES(last_failed_at);
+
+-- name: LockLoginThrottle :execresult
+UPDATE login_throttles
+SET failed_count = 0, lockout_count = lockout_count + 1, locked_until = ?
+WHERE scope = ? AND subject = ?;
+
+-- name: DeleteLoginThrottle :execresult
+DELETE FROM login_throttles
+WHERE scope = ? AND subject = ?;
@@ -0,0 +1,37 @@
+-- name: GetUserMFASetting :one
+SELECT * FROM user_mfa_settings
+WHERE user_id = ? LIMIT 1;
+
+-- name: UpsertUserMFASecret :execresult
+INSERT INTO user_mfa_settings (
+  user_id, secret_ciphertext
+) VALUES (
+  ?, ?
+)
+ON DUPLICATE KEY UPDATE secret_ciphertext = VALUES(secret_ciphertext), enabled = FALSE, last_used_step = 0, confirmed_at = NULL;
+
+-- name: EnableUserMFA :execresult
+UPDATE user_mfa_settings
+SET enabled = TRUE, confirmed_at = ?, last_used_step = ?
+WHERE user_id = ?;
+
+-- name: AdvanceUserMFAStep :execresult
+UPDATE user_mfa_settings
+SET last_used_step = ?
+WHERE user_id = ? AND last_used_step < ?;
+
+-- name: DeleteUserRecoveryCodes :execresult
+DELETE FROM user_recovery_codes
+WHERE user_id = ?;
+
+-- name: CreateUserRecoveryCode :execresult
+INSERT INTO user_recovery_codes (
+  user_id, code_hash
+) VALUES (
+  ?, ?
+);
+
+-- name: UseUserRecoveryCode :execresult
+UPDATE user_recovery_codes
+SET used_at = ?
+WHERE user_id = ? AND code_hash = ? AND used_at IS NULL;
@@ -0,0 +1,93 @@
+-- name: CreateOAuthClient :exec
+INSERT INTO oauth_clients (
+  id, name, redirect_uris, secret_hash
+) VALUES (
+  ?, ?, ?, ?
+);
+
+-- name: GetOAuthClient :one
+SELECT * FROM oauth_clients
+WHERE id = ? LIMIT 1;
+
+-- name: ListOAuthClients :many
+SELECT * FROM oauth_clients
+ORDER BY created_at DESC;
+
+-- name: DeleteOAuthClient :execresult
+DELETE FROM oauth_clients
+WHERE id = ?;
+
+-- name: GetOAuthConsent :one
+SELECT * FROM oauth_consents
+WHERE user_id = ? AND client_id = ? LIMIT 1;
+
+-- name: UpsertOAuthConsent :exec
+INSERT INTO oauth_consents (
+  user_id, client_id, scopes
+) VALUES (
+  ?, ?, ?
+)
+ON DUPLICATE KEY UPDATE scopes = VALUES(scopes);
+
+-- name: ListUserOAuthConsents :many
+SELECT c.user_id, c.client_id, c.scopes, c.created_at, c.updated_at, o.name AS client_name
+FROM oauth_consents c
+JOIN oauth_clients o ON o.id = c.client_id
+WHERE c.user_id = ?
+ORDER BY o.name, c.client_id;
+
+-- name: DeleteOAuthConsent :execresult
+DELETE FROM oauth_consents
+WHERE user_id = ? AND client_id = ?;
+
+-- name: CreateOAuthAuthorizationCode :exec
+INSERT INTO oauth_authorization_codes (
+  code_hash, client_id, user_id, redirect_uri, scopes, nonce, code_challenge, expires_at
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetOAuthAuthorizationCode :one
+SELECT * FROM oauth_authorization_codes
+WHERE code_hash = ? LIMIT 1;
+
+-- name: UseOAuthAuthorizationCode :execresult
+UPDATE oauth_authorization_codes
+SET used_at = ?
+WHERE code_hash = ? AND used_at IS NULL;
+
+-- name: CreateOAuthAccessToken :exec
+INSERT INTO oauth_access_tokens (
+  token_hash, client_id, user_id, scopes, expires_at
+) VALUES (
+  ?, ?, ?, ?, ?
+);
+
+-- name: GetOAuthAccessToken :one
+SELECT * FROM oauth_access_tokens
+WHERE token_hash = ? LIMIT 1;
+
+-- name: DeleteOAuthAccessTokens :exec
+DELETE FROM oauth_access_tokens
+WHERE user_id = ? AND client_id = ?;
+
+-- name: CreateOIDCSigningKey :exec
+INSERT INTO oidc_signing_keys (
+  id, public_key, private_key_ciphertext, created_at, activated_at
+) VALUES (
+  ?, ?, ?, ?, ?
+);
+
+-- name: ListOIDCSigningKeys :many
+SELECT * FROM oidc_signing_keys
+WHERE retired_at IS NULL OR retired_at > ?
+ORDER BY created_at DESC;
+
+-- name: RetireOIDCSigningKeys :exec
+UPDATE oidc_signing_keys
+SET retired_at = ?
+WHERE retired_at IS NULL AND id <> ?;
+
+-- name: DeleteOIDCSigningKeys :execresult
+DELETE FROM oidc_signing_keys
+WHERE retired_at <= ?;
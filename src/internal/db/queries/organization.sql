@@ -0,0 +1,58 @@
+-- name: CreateOrganization :execresult
+INSERT INTO organizations (
+  id, name, slug
+) VALUES (
+  ?, ?, ?
+);
+
+-- name: GetOrganization :one
+SELECT * FROM organizations
+WHERE id = ? LIMIT 1;
+
+-- name: GetOrganizationBySlug :one
+SELECT * FROM organizations
+WHERE slug = ? LIMIT 1;
+
+-- name: ListUserOrganizations :many
+SELECT o.id, o.name, o.slug, o.created_at, o.updated_at, m.role, m.created_at AS joined_at
+FROM organization_members m
+JOIN organizations o ON o.id = m.organization_id
+WHERE m.user_id = ?
+ORDER BY o.name, o.id;
+
+-- name: AddOrganizationMember :execresult
+INSERT INTO organization_members (
+  organization_id, user_id, role
+) VALUES (
+  ?, ?, ?
+);
+
+-- name: GetOrganizationMember :one
+SELECT u.id, u.name, u.email, u.name_encrypted, u.email_encrypted, u.role, u.created_at, u.UpdatedAt, m.role AS member_role, m.created_at AS joined_at
+FROM organization_members m
+JOIN Users u ON u.id = m.user_id
+WHERE m.organization_id = ? AND m.user_id = ? LIMIT 1;
+
+-- name: ListOrganizationMembers :many
+SELECT u.id, u.name, u.email, u.name_encrypted, u.email_encrypted, u.role, u.created_at, u.UpdatedAt, m.role AS member_role, m.created_at AS joined_at
+FROM organization_members m
+JOIN Users u ON u.id = m.user_id
+WHERE m.organization_id = ?
+ORDER BY u.name, u.id;
+
+-- name: CountOrganizationOwners :one
+SELECT COUNT(*) FROM organization_members
+WHERE organization_id = ? AND role = 'owner';
+
+-- name: CountUserOrganizations :one
+SELECT COUNT(*) FROM organization_members
+WHERE user_id = ?;
+
+-- name: UpdateOrganizationMemberRole :execresult
+UPDATE organization_members
+SET role = ?
+WHERE organization_id = ? AND user_id = ?;
+
+-- name: RemoveOrganizationMember :execresult
+DELETE FROM organization_members
+WHERE organization_id = ? AND user_id = ?;
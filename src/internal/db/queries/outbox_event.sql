@@ -0,0 +1,35 @@
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (
+  event_id, event_type, aggregate_id, payload, occurred_at, next_attempt_at
+) VALUES (
+  ?, ?, ?, ?, ?, ?
+);
+
+-- name: ListDueOutboxEvents :many
+SELECT * FROM outbox_events
+WHERE published_at IS NULL AND next_attempt_at <= ?
+ORDER BY id
+LIMIT ?
+FOR UPDATE SKIP LOCKED;
+
+-- name: LeaseOutboxEvent :exec
+UPDATE outbox_events SET next_attempt_at = ? WHERE id = ?;
+
+-- name: ListLatestOutboxEvents :many
+SELECT * FROM outbox_events
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: ListOutboxEventsAfter :many
+SELECT * FROM outbox_events
+WHERE id > ?
+ORDER BY id
+LIMIT ?;
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE outbox_events SET published_at = ? WHERE id = ?;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE outbox_events
+SET attempts = attempts + 1, next_attempt_at = ?, last_error = ?
+WHERE id = ? AND published_at IS NULL;
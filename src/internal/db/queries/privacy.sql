@@ -0,0 +1,87 @@
+-- name: EraseUser :execresult
+UPDATE Users
+SET name = NULL, email = NULL, name_encrypted = NULL, email_encrypted = NULL, email_index = NULL, pii_key_version = NULL,
+  password = NULL, avatar_url = NULL, avatar_thumbnail_url = NULL, preferences = NULL, attributes = NULL, erased_at = ?
+WHERE id = ? AND erased_at IS NULL;
+
+-- name: DeleteUserMFASetting :exec
+DELETE FROM user_mfa_settings
+WHERE user_id = ?;
+
+-- name: RevokeUserAPIKeys :exec
+UPDATE api_keys
+SET revoked_at = ?
+WHERE user_id = ? AND revoked_at IS NULL;
+
+-- name: ClearAcceptedInvitationEmails :exec
+UPDATE invitations
+SET email = ''
+WHERE accepted_by = ?;
+
+-- name: DeleteUnacceptedInvitations :execresult
+DELETE FROM invitations
+WHERE email = ? AND accepted_at IS NULL;
+
+-- name: ScrubUserOutboxEvents :execresult
+UPDATE outbox_events
+SET payload = JSON_REMOVE(payload, '$.name', '$.email')
+WHERE aggregate_id = ? AND JSON_CONTAINS_PATH(payload, 'one', '$.name', '$.email');
+
+-- name: ScrubUserWebhookDeliveries :execresult
+UPDATE webhook_deliveries
+SET payload = JSON_REMOVE(payload, '$.data.name', '$.data.email')
+WHERE JSON_UNQUOTE(JSON_EXTRACT(payload, '$.aggregate_id')) = sqlc.arg('aggregate_id')
+  AND JSON_CONTAINS_PATH(payload, 'one', '$.data.name', '$.data.email');
+
+-- name: ListUserImportReports :many
+SELECT id, report FROM user_import_jobs
+WHERE report IS NOT NULL
+  AND (LOCATE(sqlc.arg('user_id'), CAST(report AS CHAR)) > 0 OR LOCATE(sqlc.arg('email'), LOWER(CAST(report AS CHAR))) > 0);
+
+-- name: UpdateUserImportReport :exec
+UPDATE user_import_jobs
+SET report = ?
+WHERE id = ?;
+
+-- name: ListUserAuditEvents :many
+SELECT * FROM audit_events
+WHERE actor_id = sqlc.arg('user_id') OR target_id = sqlc.arg('user_id')
+ORDER BY id;
+
+-- name: ScrubAuditEventsByTarget :execresult
+UPDATE audit_events
+SET changes = NULL, metadata = NULL, ip = NULL, scrubbed_at = ?
+WHERE target_id = ? AND (changes IS NOT NULL OR metadata IS NOT NULL OR ip IS NOT NULL);
+
+-- name: ScrubAuditEventsByActor :execresult
+UPDATE audit_events
+SET ip = NULL, scrubbed_at = ?
+WHERE actor_id = ? AND ip IS NOT NULL;
+
+-- name: CreateErasureReceipt :exec
+INSERT INTO user_erasure_receipts (
+  id, user_id, requested_by, reason, audit_events_scrubbed, events_scrubbed,
+  webhook_deliveries_scrubbed, import_reports_scrubbed, invitations_deleted, erased_at
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetErasureReceiptByUser :one
+SELECT * FROM user_erasure_receipts
+WHERE user_id = ? LIMIT 1;
+
+-- name: DeleteUserIdentities :exec
+DELETE FROM user_identities
+WHERE user_id = ?;
+
+-- name: DeleteUserOAuthConsents :exec
+DELETE FROM oauth_consents
+WHERE user_id = ?;
+
+-- name: DeleteUserOAuthAuthorizationCodes :exec
+DELETE FROM oauth_authorization_codes
+WHERE user_id = ?;
+
+-- name: DeleteUserOAuthAccessTokens :exec
+DELETE FROM oauth_access_tokens
+WHERE user_id = ?;
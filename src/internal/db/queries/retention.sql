@@ -0,0 +1,47 @@
+-- name: PurgeAuditEvents :execresult
+DELETE FROM audit_events
+WHERE occurred_at < ?
+ORDER BY id
+LIMIT ?;
+
+-- name: PurgeErasedUsers :execresult
+DELETE FROM Users
+WHERE erased_at < ?
+LIMIT ?;
+
+-- name: PurgeInvitations :execresult
+DELETE FROM invitations
+WHERE expires_at < sqlc.arg(before) OR revoked_at < sqlc.arg(before)
+LIMIT ?;
+
+-- name: PurgeAPIKeys :execresult
+DELETE FROM api_keys
+WHERE expires_at < sqlc.arg(before) OR revoked_at < sqlc.arg(before)
+LIMIT ?;
+
+-- name: PurgeOutboxEvents :execresult
+DELETE FROM outbox_events
+WHERE published_at < ?
+ORDER BY id
+LIMIT ?;
+
+-- name: PurgeLoginThrottles :execresult
+DELETE FROM login_throttles
+WHERE (locked_until IS NULL OR locked_until < sqlc.arg(before))
+  AND (last_failed_at IS NULL OR last_failed_at < sqlc.arg(before))
+LIMIT ?;
+
+-- name: PurgeOIDCLoginStates :execresult
+DELETE FROM oidc_login_states
+WHERE expires_at < ?
+LIMIT ?;
+
+-- name: PurgeOAuthAuthorizationCodes :execresult
+DELETE FROM oauth_authorization_codes
+WHERE expires_at < ?
+LIMIT ?;
+
+-- name: PurgeOAuthAccessTokens :execresult
+DELETE FROM oauth_access_tokens
+WHERE expires_at < ?
+LIMIT ?;
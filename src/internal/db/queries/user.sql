@@ -4,20 +4,82 @@ WHERE id = ? LIMIT 1;
 
 -- name: ListUsers :many
 SELECT * FROM Users
+WHERE (sqlc.narg('role') IS NULL OR role = sqlc.narg('role'))
+  AND (sqlc.narg('created_from') IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to') IS NULL OR created_at < sqlc.narg('created_to'))
+  AND (sqlc.narg('group_id') IS NULL OR id IN (
+    SELECT user_id FROM user_group_members WHERE group_id = sqlc.narg('group_id')
+  ))
 ORDER BY name;
 
+-- name: ListUsersAfter :many
+SELECT * FROM Users
+WHERE id > ?
+ORDER BY id
+LIMIT ?;
+
 -- name: CreateUser :execresult
 INSERT INTO Users (
-  id, name, email, password
+  id, name, email, name_encrypted, email_encrypted, email_index, pii_key_version, password
 ) VALUES (
-  ?, ?, ?, ?
+  ?, ?, ?, ?, ?, ?, ?, ?
 );
 
 -- name: UpdateUser :execresult
 UPDATE Users
-SET name = ?, email = ?, password = ?
+SET name = ?, email = ?, name_encrypted = ?, email_encrypted = ?, email_index = ?, pii_key_version = ?,
+  password = ?, preferences = ?, attributes = ?
+WHERE id = ?;
+
+-- name: UpdateUserAvatar :execresult
+UPDATE Users
+SET avatar_url = ?, avatar_thumbnail_url = ?
 WHERE id = ?;
 
+-- name: UpdateUserPassword :execresult
+UPDATE Users
+SET password = sqlc.arg('new_password')
+WHERE id = sqlc.arg('id') AND password = sqlc.arg('old_password');
+
+-- name: RemoveUserAttribute :exec
+UPDATE Users
+SET attributes = JSON_REMOVE(attributes, CONCAT('$.', sqlc.arg('attribute_key')))
+WHERE JSON_CONTAINS_PATH(attributes, 'one', CONCAT('$.', sqlc.arg('attribute_key')));
+
 -- name: DeleteUser :execresult
 DELETE FROM Users
 WHERE id = ?;
+
+-- name: GetUserByEmail :one
+SELECT * FROM Users
+WHERE email_index = sqlc.narg('email_index') OR email = sqlc.narg('email') LIMIT 1;
+
+-- name: ListExistingUserEmails :many
+SELECT id, email, email_encrypted FROM Users
+WHERE email IN (sqlc.slice('emails')) OR email_index IN (sqlc.slice('email_indexes'));
+
+-- name: SearchUsers :many
+SELECT id, name, email, role, created_at, UpdatedAt, avatar_url, avatar_thumbnail_url, preferences, attributes,
+  MATCH(name, email) AGAINST (sqlc.arg('query') IN NATURAL LANGUAGE MODE) AS relevance
+FROM Users
+WHERE MATCH(name, email) AGAINST (sqlc.arg('query') IN NATURAL LANGUAGE MODE)
+ORDER BY relevance DESC, id
+LIMIT ? OFFSET ?;
+
+-- name: SearchUsersByEmailPrefix :many
+SELECT * FROM Users
+WHERE email LIKE ?
+ORDER BY email, id
+LIMIT ? OFFSET ?;
+
+-- name: ListUsersWithStalePII :many
+SELECT id, name, email, name_encrypted, email_encrypted FROM Users
+WHERE id > ? AND (pii_key_version IS NULL OR pii_key_version <> ?)
+ORDER BY id
+LIMIT ?
+FOR UPDATE;
+
+-- name: UpdateUserPII :exec
+UPDATE Users
+SET name = ?, email = ?, name_encrypted = ?, email_encrypted = ?, email_index = ?, pii_key_version = ?
+WHERE id = ?;
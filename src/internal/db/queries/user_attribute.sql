@@ -0,0 +1,23 @@
+-- name: ListUserAttributeDefinitions :many
+SELECT * FROM user_attribute_definitions
+ORDER BY attribute_key;
+
+-- name: GetUserAttributeDefinition :one
+SELECT * FROM user_attribute_definitions
+WHERE attribute_key = ? LIMIT 1;
+
+-- name: CreateUserAttributeDefinition :exec
+INSERT INTO user_attribute_definitions (
+  attribute_key, type, required, enum_values, description
+) VALUES (
+  ?, ?, ?, ?, ?
+);
+
+-- name: UpdateUserAttributeDefinition :execresult
+UPDATE user_attribute_definitions
+SET required = ?, enum_values = ?, description = ?
+WHERE attribute_key = ?;
+
+-- name: DeleteUserAttributeDefinition :execresult
+DELETE FROM user_attribute_definitions
+WHERE attribute_key = ?;
@@ -0,0 +1,15 @@
+-- name: CreateUserImportJob :execresult
+INSERT INTO user_import_jobs (
+  id, format, status, created_by, created_at, updated_at
+) VALUES (
+  ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetUserImportJob :one
+SELECT * FROM user_import_jobs
+WHERE id = ? LIMIT 1;
+
+-- name: UpdateUserImportJob :execresult
+UPDATE user_import_jobs
+SET status = ?, total_rows = ?, created_rows = ?, failed_rows = ?, error = ?, report = ?, updated_at = ?, finished_at = ?
+WHERE id = ?;
@@ -0,0 +1,66 @@
+-- name: CreateWebhookSubscription :execresult
+INSERT INTO webhook_subscriptions (
+  id, url, event_types, description, secret_ciphertext, active
+) VALUES (
+  ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscriptions
+WHERE id = ? LIMIT 1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions
+ORDER BY created_at DESC;
+
+-- name: ListActiveWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions
+WHERE active = TRUE;
+
+-- name: UpdateWebhookSubscription :execresult
+UPDATE webhook_subscriptions
+SET url = ?, event_types = ?, description = ?, secret_ciphertext = ?, active = ?
+WHERE id = ?;
+
+-- name: DeleteWebhookSubscription :execresult
+DELETE FROM webhook_subscriptions
+WHERE id = ?;
+
+-- name: CreateWebhookDelivery :execresult
+INSERT IGNORE INTO webhook_deliveries (
+  subscription_id, event_id, event_type, payload, next_attempt_at, created_at
+) VALUES (
+  ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = ? LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE subscription_id = sqlc.arg('subscription_id')
+  AND (sqlc.narg('before_id') IS NULL OR id < sqlc.narg('before_id'))
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: ListDueWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE status = 'pending' AND next_attempt_at <= ?
+ORDER BY id
+LIMIT ?
+FOR UPDATE SKIP LOCKED;
+
+-- name: LeaseWebhookDelivery :exec
+UPDATE webhook_deliveries SET next_attempt_at = ? WHERE id = ?;
+
+-- name: RecordWebhookDeliveryAttempt :execresult
+UPDATE webhook_deliveries
+SET status = ?, attempts = ?, next_attempt_at = ?, last_attempt_at = ?,
+    last_status_code = ?, last_error = ?, delivered_at = ?
+WHERE id = ? AND status = 'pending';
+
+-- name: ResetWebhookDelivery :execresult
+UPDATE webhook_deliveries
+SET status = 'pending', attempts = 0, next_attempt_at = ?
+WHERE id = ?;
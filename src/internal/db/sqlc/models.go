@@ -6,17 +6,378 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// サービス間連携用のAPIキー
+type ApiKey struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"userID"`
+	Name   string    `json:"name"`
+	// キーの先頭部分。検索と表示に使用
+	Prefix string `json:"prefix"`
+	// キー全体のSHA-256ハッシュ
+	SecretHash string `json:"secretHash"`
+	// スペース区切りのスコープ
+	Scopes     string       `json:"scopes"`
+	ExpiresAt  sql.NullTime `json:"expiresAt"`
+	LastUsedAt sql.NullTime `json:"lastUsedAt"`
+	RevokedAt  sql.NullTime `json:"revokedAt"`
+	CreatedAt  time.Time    `json:"createdAt"`
+}
+
+// 監査ログ。追記のみ可能
+type AuditEvent struct {
+	ID     int64  `json:"id"`
+	Action string `json:"action"`
+	// 操作したユーザーのID。匿名の場合はNULL
+	ActorID sql.NullString `json:"actorID"`
+	// 操作対象のユーザーのID
+	TargetID sql.NullString `json:"targetID"`
+	// フィールド単位の変更内容。機密項目はマスク済み
+	Changes    json.RawMessage `json:"changes"`
+	Metadata   json.RawMessage `json:"metadata"`
+	RequestID  sql.NullString  `json:"requestID"`
+	Ip         sql.NullString  `json:"ip"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	// 個人データの消去に伴い内容を削除した日時
+	ScrubbedAt sql.NullTime `json:"scrubbedAt"`
+}
+
+// 組織へのユーザーの招待
+type Invitation struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organizationID"`
+	Email          string    `json:"email"`
+	// 承諾時に付与する組織内の権限
+	Role string `json:"role"`
+	// 招待リンクのトークンのSHA-256ハッシュ
+	TokenHash string `json:"tokenHash"`
+	// 招待したユーザーのID
+	InvitedBy  sql.NullString `json:"invitedBy"`
+	ExpiresAt  time.Time      `json:"expiresAt"`
+	AcceptedAt sql.NullTime   `json:"acceptedAt"`
+	// 招待を承諾したユーザーのID
+	AcceptedBy sql.NullString `json:"acceptedBy"`
+	RevokedAt  sql.NullTime   `json:"revokedAt"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+}
+
+// 定期ジョブの実行履歴
+type JobRun struct {
+	ID      uuid.UUID `json:"id"`
+	JobName string    `json:"jobName"`
+	// スケジュール上の実行時刻。ジョブ名と合わせて一意で、複数のレプリカが同じ回を実行しないようにする
+	ScheduledAt time.Time `json:"scheduledAt"`
+	// 実行したサーバーのホスト名
+	Instance string `json:"instance"`
+	// running, succeeded, failed のいずれか
+	Status string `json:"status"`
+	// ジョブが返した件数などの結果
+	Result     json.RawMessage `json:"result"`
+	Error      sql.NullString  `json:"error"`
+	StartedAt  time.Time       `json:"startedAt"`
+	FinishedAt sql.NullTime    `json:"finishedAt"`
+}
+
+// ログイン失敗の追跡テーブル
+type LoginThrottle struct {
+	Scope        string       `json:"scope"`
+	Subject      string       `json:"subject"`
+	FailedCount  int32        `json:"failedCount"`
+	LockoutCount int32        `json:"lockoutCount"`
+	LockedUntil  sql.NullTime `json:"lockedUntil"`
+	LastFailedAt sql.NullTime `json:"lastFailedAt"`
+}
+
+// userinfo エンドポイント用のアクセストークン
+type OauthAccessToken struct {
+	// アクセストークンのSHA-256ハッシュ
+	TokenHash string    `json:"tokenHash"`
+	ClientID  uuid.UUID `json:"clientID"`
+	UserID    uuid.UUID `json:"userID"`
+	// スペース区切りの許可されたスコープ
+	Scopes    string    `json:"scopes"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// トークンエンドポイントで一度だけ交換できる認可コード
+type OauthAuthorizationCode struct {
+	// 認可コードのSHA-256ハッシュ
+	CodeHash    string    `json:"codeHash"`
+	ClientID    uuid.UUID `json:"clientID"`
+	UserID      uuid.UUID `json:"userID"`
+	RedirectUri string    `json:"redirectUri"`
+	// スペース区切りの許可されたスコープ
+	Scopes string `json:"scopes"`
+	// IDトークンに含める nonce
+	Nonce string `json:"nonce"`
+	// PKCE のコードチャレンジ(S256)。使用しない場合は空
+	CodeChallenge string `json:"codeChallenge"`
+	// トークンと交換された日時。使用済みのコードも再利用を検知するため期限切れ後の削除まで残す
+	UsedAt    sql.NullTime `json:"usedAt"`
+	ExpiresAt time.Time    `json:"expiresAt"`
+}
+
+// このサービスをOpenIDプロバイダーとして利用するアプリケーション
+type OauthClient struct {
+	// client_id として使用
+	ID uuid.UUID `json:"id"`
+	// 同意画面に表示する名前
+	Name string `json:"name"`
+	// スペース区切りの登録済みリダイレクトURI
+	RedirectUris string `json:"redirectUris"`
+	// クライアントシークレットのSHA-256ハッシュ。公開クライアントはNULL
+	SecretHash sql.NullString `json:"secretHash"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
+// ユーザーがクライアントに許可したスコープ
+type OauthConsent struct {
+	UserID   uuid.UUID `json:"userID"`
+	ClientID uuid.UUID `json:"clientID"`
+	// スペース区切りの同意済みスコープ
+	Scopes    string    `json:"scopes"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// 進行中の外部IDプロバイダーでのログイン。コールバックで一度だけ使用される
+type OidcLoginState struct {
+	// state パラメーターのSHA-256ハッシュ
+	StateHash string `json:"stateHash"`
+	Provider  string `json:"provider"`
+	// IDトークンに含まれるべき nonce
+	Nonce string `json:"nonce"`
+	// PKCE のコード検証子
+	CodeVerifier string    `json:"codeVerifier"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+// IDトークンの署名鍵。ローテーション後も猶予期間中は JWKS で公開されます
+type OidcSigningKey struct {
+	// JWKの kid
+	ID string `json:"id"`
+	// PKIX形式の公開鍵
+	PublicKey []byte `json:"publicKey"`
+	// 暗号化されたPKCS #8形式の秘密鍵
+	PrivateKeyCiphertext []byte    `json:"privateKeyCiphertext"`
+	CreatedAt            time.Time `json:"createdAt"`
+	// 署名に使われ始める日時。それまでは JWKS で公開だけされます
+	ActivatedAt time.Time `json:"activatedAt"`
+	// 署名に使われなくなる日時。後継の鍵が有効になる日時なので未来のこともあります。後継のない鍵はNULL
+	RetiredAt sql.NullTime `json:"retiredAt"`
+}
+
+// 顧客企業などの組織(テナント)
+type Organization struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// URLなどで使う識別子。英小文字、数字、ハイフン
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// 組織に所属するユーザー
+type OrganizationMember struct {
+	OrganizationID uuid.UUID `json:"organizationID"`
+	UserID         uuid.UUID `json:"userID"`
+	// 組織内の権限。owner, admin, member
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// トランザクショナルアウトボックス。ユーザーの更新と同じトランザクションで書き込まれます
+type OutboxEvent struct {
+	ID int64 `json:"id"`
+	// イベントID。購読側での重複排除に使用
+	EventID   string `json:"eventID"`
+	EventType string `json:"eventType"`
+	// イベントの対象となるエンティティのID
+	AggregateID string          `json:"aggregateID"`
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	// 配信に失敗した回数
+	Attempts int32 `json:"attempts"`
+	// 次に配信を試みる日時
+	NextAttemptAt time.Time      `json:"nextAttemptAt"`
+	LastError     sql.NullString `json:"lastError"`
+	// 配信が完了した日時。未配信の場合はNULL
+	PublishedAt sql.NullTime `json:"publishedAt"`
+}
+
 // ユーザーテーブル
 type User struct {
-	ID        uuid.UUID      `json:"id"`
-	Name      sql.NullString `json:"name"`
-	Email     sql.NullString `json:"email"`
+	ID    uuid.UUID      `json:"id"`
+	Name  sql.NullString `json:"name"`
+	Email sql.NullString `json:"email"`
+	// パスワードのハッシュ。先頭にアルゴリズムとパラメータを含む(bcrypt または argon2id)
 	Password  sql.NullString `json:"password"`
 	CreatedAt time.Time      `json:"createdAt"`
 	Updatedat time.Time      `json:"updatedat"`
+	// ユーザーの権限
+	Role string `json:"role"`
+	// アバター画像のURL
+	AvatarUrl sql.NullString `json:"avatarUrl"`
+	// アバター画像のサムネイルのURL
+	AvatarThumbnailUrl sql.NullString `json:"avatarThumbnailUrl"`
+	// ユーザーが選択した設定(言語、タイムゾーン、通知)。NULLの場合は既定値
+	Preferences json.RawMessage `json:"preferences"`
+	// 管理者が定義したカスタム属性の値
+	Attributes json.RawMessage `json:"attributes"`
+	// 暗号化された名前。設定されている場合は name より優先される
+	NameEncrypted []byte `json:"nameEncrypted"`
+	// 暗号化されたメールアドレス。設定されている場合は email より優先される
+	EmailEncrypted []byte `json:"emailEncrypted"`
+	// 正規化したメールアドレスのHMAC(16進数)。暗号化したまま検索と一意性の確認に使う
+	EmailIndex sql.NullString `json:"emailIndex"`
+	// name_encrypted と email_encrypted の暗号化に使った鍵のバージョン。NULLの場合は平文
+	PiiKeyVersion sql.NullInt32 `json:"piiKeyVersion"`
+	// 個人データを消去した日時。消去済みのユーザーは匿名化された行だけが残る
+	ErasedAt sql.NullTime `json:"erasedAt"`
+}
+
+// ユーザーのカスタム属性の定義
+type UserAttributeDefinition struct {
+	AttributeKey string `json:"attributeKey"`
+	// string, number, integer, boolean または date
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	// string 型の属性に許可する値。NULLの場合は制限なし
+	EnumValues  json.RawMessage `json:"enumValues"`
+	Description string          `json:"description"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+}
+
+// 個人データ消去の記録。ユーザーが削除されても残す
+type UserErasureReceipt struct {
+	ID uuid.UUID `json:"id"`
+	// 消去されたユーザーのID
+	UserID uuid.UUID `json:"userID"`
+	// 消去を実行したユーザーのID
+	RequestedBy sql.NullString `json:"requestedBy"`
+	Reason      string         `json:"reason"`
+	// 内容を削除した監査ログの件数
+	AuditEventsScrubbed int32 `json:"auditEventsScrubbed"`
+	// 名前とメールアドレスを削除したアウトボックスのイベントの件数
+	EventsScrubbed int32 `json:"eventsScrubbed"`
+	// 名前とメールアドレスを削除したWebhook配信の件数
+	WebhookDeliveriesScrubbed int32 `json:"webhookDeliveriesScrubbed"`
+	// メールアドレスを削除したユーザー一括インポートの結果の件数
+	ImportReportsScrubbed int32 `json:"importReportsScrubbed"`
+	// 削除した未承諾の招待の件数
+	InvitationsDeleted int32     `json:"invitationsDeleted"`
+	ErasedAt           time.Time `json:"erasedAt"`
+}
+
+// 権限や通知の単位となるユーザーのグループ
+type UserGroup struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// グループに所属するユーザー。ユーザーまたはグループの削除時に削除されます
+type UserGroupMember struct {
+	GroupID   uuid.UUID `json:"groupID"`
+	UserID    uuid.UUID `json:"userID"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// 外部IDプロバイダーのアカウントとユーザーの紐付け
+type UserIdentity struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"userID"`
+	// 設定上のIDプロバイダー名
+	Provider string `json:"provider"`
+	// IDプロバイダーが発行したIDトークンの sub クレーム
+	Subject     string       `json:"subject"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	LastLoginAt sql.NullTime `json:"lastLoginAt"`
+}
+
+// ユーザー一括インポートのジョブ
+type UserImportJob struct {
+	ID uuid.UUID `json:"id"`
+	// csv または ndjson
+	Format string `json:"format"`
+	// pending: 開始待ち, running: 実行中, completed: 完了, failed: 途中で中断
+	Status string `json:"status"`
+	// 処理済みの行数
+	TotalRows   int32 `json:"totalRows"`
+	CreatedRows int32 `json:"createdRows"`
+	FailedRows  int32 `json:"failedRows"`
+	// 中断した理由
+	Error sql.NullString `json:"error"`
+	// 行ごとの結果。終了時に保存
+	Report json.RawMessage `json:"report"`
+	// インポートを開始したユーザーのID
+	CreatedBy  sql.NullString `json:"createdBy"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+	FinishedAt sql.NullTime   `json:"finishedAt"`
+}
+
+// ユーザーの二要素認証設定
+type UserMfaSetting struct {
+	UserID uuid.UUID `json:"userID"`
+	// 暗号化されたTOTPシークレット
+	SecretCiphertext []byte       `json:"secretCiphertext"`
+	Enabled          bool         `json:"enabled"`
+	LastUsedStep     int64        `json:"lastUsedStep"`
+	CreatedAt        time.Time    `json:"createdAt"`
+	ConfirmedAt      sql.NullTime `json:"confirmedAt"`
+}
+
+// 二要素認証のリカバリーコード
+type UserRecoveryCode struct {
+	UserID   uuid.UUID    `json:"userID"`
+	CodeHash string       `json:"codeHash"`
+	UsedAt   sql.NullTime `json:"usedAt"`
+}
+
+// Webhookの配信履歴と配信キュー
+type WebhookDelivery struct {
+	ID             int64     `json:"id"`
+	SubscriptionID uuid.UUID `json:"subscriptionID"`
+	EventID        string    `json:"eventID"`
+	EventType      string    `json:"eventType"`
+	// 送信するリクエストボディ
+	Payload json.RawMessage `json:"payload"`
+	// pending: 配信待ち, succeeded: 配信済み, dead: 再試行上限に達した
+	Status string `json:"status"`
+	// 配信を試みた回数
+	Attempts      int32        `json:"attempts"`
+	NextAttemptAt time.Time    `json:"nextAttemptAt"`
+	LastAttemptAt sql.NullTime `json:"lastAttemptAt"`
+	// 最後の配信でのHTTPステータスコード
+	LastStatusCode sql.NullInt32  `json:"lastStatusCode"`
+	LastError      sql.NullString `json:"lastError"`
+	DeliveredAt    sql.NullTime   `json:"deliveredAt"`
+	CreatedAt      time.Time      `json:"createdAt"`
+}
+
+// 外部向けWebhookの購読設定
+type WebhookSubscription struct {
+	ID uuid.UUID `json:"id"`
+	// 配信先のURL
+	Url string `json:"url"`
+	// スペース区切りの購読するイベント種別
+	EventTypes  string `json:"eventTypes"`
+	Description string `json:"description"`
+	// 暗号化された署名用シークレット
+	SecretCiphertext []byte `json:"secretCiphertext"`
+	// 無効の場合は配信しません
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
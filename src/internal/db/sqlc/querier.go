@@ -12,11 +12,152 @@ import (
 )
 
 type Querier interface {
+	AcceptInvitation(ctx context.Context, arg AcceptInvitationParams) (sql.Result, error)
+	AddGroupMember(ctx context.Context, arg AddGroupMemberParams) (sql.Result, error)
+	AddOrganizationMember(ctx context.Context, arg AddOrganizationMemberParams) (sql.Result, error)
+	AdvanceUserMFAStep(ctx context.Context, arg AdvanceUserMFAStepParams) (sql.Result, error)
+	ClearAcceptedInvitationEmails(ctx context.Context, acceptedBy sql.NullString) error
+	CountOrganizationOwners(ctx context.Context, organizationID uuid.UUID) (int64, error)
+	CountUserOrganizations(ctx context.Context, userID uuid.UUID) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (sql.Result, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (sql.Result, error)
+	CreateErasureReceipt(ctx context.Context, arg CreateErasureReceiptParams) error
+	CreateGroup(ctx context.Context, arg CreateGroupParams) (sql.Result, error)
+	CreateInvitation(ctx context.Context, arg CreateInvitationParams) (sql.Result, error)
+	CreateJobRun(ctx context.Context, arg CreateJobRunParams) (sql.Result, error)
+	CreateOAuthAccessToken(ctx context.Context, arg CreateOAuthAccessTokenParams) error
+	CreateOAuthAuthorizationCode(ctx context.Context, arg CreateOAuthAuthorizationCodeParams) error
+	CreateOAuthClient(ctx context.Context, arg CreateOAuthClientParams) error
+	CreateOIDCLoginState(ctx context.Context, arg CreateOIDCLoginStateParams) error
+	CreateOIDCSigningKey(ctx context.Context, arg CreateOIDCSigningKeyParams) error
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (sql.Result, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (sql.Result, error)
+	CreateUserAttributeDefinition(ctx context.Context, arg CreateUserAttributeDefinitionParams) error
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) error
+	CreateUserImportJob(ctx context.Context, arg CreateUserImportJobParams) (sql.Result, error)
+	CreateUserRecoveryCode(ctx context.Context, arg CreateUserRecoveryCodeParams) (sql.Result, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (sql.Result, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (sql.Result, error)
+	DeleteGroup(ctx context.Context, id uuid.UUID) (sql.Result, error)
+	DeleteLoginThrottle(ctx context.Context, arg DeleteLoginThrottleParams) (sql.Result, error)
+	DeleteOAuthAccessTokens(ctx context.Context, arg DeleteOAuthAccessTokensParams) error
+	DeleteOAuthClient(ctx context.Context, id uuid.UUID) (sql.Result, error)
+	DeleteOAuthConsent(ctx context.Context, arg DeleteOAuthConsentParams) (sql.Result, error)
+	DeleteOIDCLoginState(ctx context.Context, stateHash string) (sql.Result, error)
+	DeleteOIDCSigningKeys(ctx context.Context, retiredAt sql.NullTime) (sql.Result, error)
+	DeleteUnacceptedInvitations(ctx context.Context, email string) (sql.Result, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) (sql.Result, error)
+	DeleteUserAttributeDefinition(ctx context.Context, attributeKey string) (sql.Result, error)
+	DeleteUserIdentities(ctx context.Context, userID uuid.UUID) error
+	DeleteUserMFASetting(ctx context.Context, userID uuid.UUID) error
+	DeleteUserOAuthAccessTokens(ctx context.Context, userID uuid.UUID) error
+	DeleteUserOAuthAuthorizationCodes(ctx context.Context, userID uuid.UUID) error
+	DeleteUserOAuthConsents(ctx context.Context, userID uuid.UUID) error
+	DeleteUserRecoveryCodes(ctx context.Context, userID uuid.UUID) (sql.Result, error)
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) (sql.Result, error)
+	EnableUserMFA(ctx context.Context, arg EnableUserMFAParams) (sql.Result, error)
+	EraseUser(ctx context.Context, arg EraseUserParams) (sql.Result, error)
+	FinishJobRun(ctx context.Context, arg FinishJobRunParams) error
+	GetAPIKeyByID(ctx context.Context, id uuid.UUID) (ApiKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error)
+	GetErasureReceiptByUser(ctx context.Context, userID uuid.UUID) (UserErasureReceipt, error)
+	GetGroup(ctx context.Context, id uuid.UUID) (UserGroup, error)
+	GetGroupByName(ctx context.Context, name string) (UserGroup, error)
+	GetInvitation(ctx context.Context, id uuid.UUID) (Invitation, error)
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (Invitation, error)
+	GetLock(ctx context.Context, name string) (bool, error)
+	GetLoginThrottle(ctx context.Context, arg GetLoginThrottleParams) (LoginThrottle, error)
+	GetOAuthAccessToken(ctx context.Context, tokenHash string) (OauthAccessToken, error)
+	GetOAuthAuthorizationCode(ctx context.Context, codeHash string) (OauthAuthorizationCode, error)
+	GetOAuthClient(ctx context.Context, id uuid.UUID) (OauthClient, error)
+	GetOAuthConsent(ctx context.Context, arg GetOAuthConsentParams) (OauthConsent, error)
+	GetOIDCLoginState(ctx context.Context, stateHash string) (OidcLoginState, error)
+	GetOrganization(ctx context.Context, id uuid.UUID) (Organization, error)
+	GetOrganizationBySlug(ctx context.Context, slug string) (Organization, error)
+	GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (GetOrganizationMemberRow, error)
+	GetPendingInvitation(ctx context.Context, arg GetPendingInvitationParams) (Invitation, error)
+	GetUserAttributeDefinition(ctx context.Context, attributeKey string) (UserAttributeDefinition, error)
+	GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
-	ListUsers(ctx context.Context) ([]User, error)
+	GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error)
+	GetUserImportJob(ctx context.Context, id uuid.UUID) (UserImportJob, error)
+	GetUserMFASetting(ctx context.Context, userID uuid.UUID) (UserMfaSetting, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (WebhookSubscription, error)
+	IncrementLoginFailure(ctx context.Context, arg IncrementLoginFailureParams) (sql.Result, error)
+	LeaseOutboxEvent(ctx context.Context, arg LeaseOutboxEventParams) error
+	LeaseWebhookDelivery(ctx context.Context, arg LeaseWebhookDeliveryParams) error
+	ListAPIKeysByUser(ctx context.Context, userID uuid.UUID) ([]ApiKey, error)
+	ListActiveWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListDueOutboxEvents(ctx context.Context, arg ListDueOutboxEventsParams) ([]OutboxEvent, error)
+	ListDueWebhookDeliveries(ctx context.Context, arg ListDueWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListExistingUserEmails(ctx context.Context, arg ListExistingUserEmailsParams) ([]ListExistingUserEmailsRow, error)
+	ListGroupMembers(ctx context.Context, groupID uuid.UUID) ([]ListGroupMembersRow, error)
+	ListGroups(ctx context.Context) ([]UserGroup, error)
+	ListLatestJobRuns(ctx context.Context) ([]JobRun, error)
+	ListLatestOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error)
+	ListOAuthClients(ctx context.Context) ([]OauthClient, error)
+	ListOIDCSigningKeys(ctx context.Context, retiredAt sql.NullTime) ([]OidcSigningKey, error)
+	ListOrganizationMembers(ctx context.Context, organizationID uuid.UUID) ([]ListOrganizationMembersRow, error)
+	ListOutboxEventsAfter(ctx context.Context, arg ListOutboxEventsAfterParams) ([]OutboxEvent, error)
+	ListUserAttributeDefinitions(ctx context.Context) ([]UserAttributeDefinition, error)
+	ListUserAuditEvents(ctx context.Context, userID sql.NullString) ([]AuditEvent, error)
+	ListUserGroups(ctx context.Context, userID uuid.UUID) ([]UserGroup, error)
+	ListUserImportReports(ctx context.Context, arg ListUserImportReportsParams) ([]ListUserImportReportsRow, error)
+	ListUserOAuthConsents(ctx context.Context, userID uuid.UUID) ([]ListUserOAuthConsentsRow, error)
+	ListUserOrganizations(ctx context.Context, userID uuid.UUID) ([]ListUserOrganizationsRow, error)
+	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListUsersAfter(ctx context.Context, arg ListUsersAfterParams) ([]User, error)
+	ListUsersWithStalePII(ctx context.Context, arg ListUsersWithStalePIIParams) ([]ListUsersWithStalePIIRow, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	LockLoginThrottle(ctx context.Context, arg LockLoginThrottleParams) (sql.Result, error)
+	MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error
+	MarkOutboxEventPublished(ctx context.Context, arg MarkOutboxEventPublishedParams) error
+	PurgeAPIKeys(ctx context.Context, arg PurgeAPIKeysParams) (sql.Result, error)
+	PurgeAuditEvents(ctx context.Context, arg PurgeAuditEventsParams) (sql.Result, error)
+	PurgeErasedUsers(ctx context.Context, arg PurgeErasedUsersParams) (sql.Result, error)
+	PurgeInvitations(ctx context.Context, arg PurgeInvitationsParams) (sql.Result, error)
+	PurgeLoginThrottles(ctx context.Context, arg PurgeLoginThrottlesParams) (sql.Result, error)
+	PurgeOAuthAccessTokens(ctx context.Context, arg PurgeOAuthAccessTokensParams) (sql.Result, error)
+	PurgeOAuthAuthorizationCodes(ctx context.Context, arg PurgeOAuthAuthorizationCodesParams) (sql.Result, error)
+	PurgeOIDCLoginStates(ctx context.Context, arg PurgeOIDCLoginStatesParams) (sql.Result, error)
+	PurgeOutboxEvents(ctx context.Context, arg PurgeOutboxEventsParams) (sql.Result, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) (sql.Result, error)
+	ReleaseLock(ctx context.Context, name string) error
+	RemoveGroupMember(ctx context.Context, arg RemoveGroupMemberParams) (sql.Result, error)
+	RemoveOrganizationMember(ctx context.Context, arg RemoveOrganizationMemberParams) (sql.Result, error)
+	RemoveUserAttribute(ctx context.Context, attributeKey string) error
+	RenewInvitation(ctx context.Context, arg RenewInvitationParams) (sql.Result, error)
+	ResetWebhookDelivery(ctx context.Context, arg ResetWebhookDeliveryParams) (sql.Result, error)
+	RetireOIDCSigningKeys(ctx context.Context, arg RetireOIDCSigningKeysParams) error
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (sql.Result, error)
+	RevokeInvitation(ctx context.Context, arg RevokeInvitationParams) (sql.Result, error)
+	RevokeUserAPIKeys(ctx context.Context, arg RevokeUserAPIKeysParams) error
+	ScrubAuditEventsByActor(ctx context.Context, arg ScrubAuditEventsByActorParams) (sql.Result, error)
+	ScrubAuditEventsByTarget(ctx context.Context, arg ScrubAuditEventsByTargetParams) (sql.Result, error)
+	ScrubUserOutboxEvents(ctx context.Context, aggregateID string) (sql.Result, error)
+	ScrubUserWebhookDeliveries(ctx context.Context, aggregateID string) (sql.Result, error)
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error)
+	SearchUsersByEmailPrefix(ctx context.Context, arg SearchUsersByEmailPrefixParams) ([]User, error)
+	TouchAPIKey(ctx context.Context, arg TouchAPIKeyParams) (sql.Result, error)
+	TouchUserIdentity(ctx context.Context, arg TouchUserIdentityParams) error
+	UpdateGroup(ctx context.Context, arg UpdateGroupParams) (sql.Result, error)
+	UpdateOrganizationMemberRole(ctx context.Context, arg UpdateOrganizationMemberRoleParams) (sql.Result, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (sql.Result, error)
+	UpdateUserAttributeDefinition(ctx context.Context, arg UpdateUserAttributeDefinitionParams) (sql.Result, error)
+	UpdateUserAvatar(ctx context.Context, arg UpdateUserAvatarParams) (sql.Result, error)
+	UpdateUserImportJob(ctx context.Context, arg UpdateUserImportJobParams) (sql.Result, error)
+	UpdateUserImportReport(ctx context.Context, arg UpdateUserImportReportParams) error
+	UpdateUserPII(ctx context.Context, arg UpdateUserPIIParams) error
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (sql.Result, error)
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (sql.Result, error)
+	UpsertOAuthConsent(ctx context.Context, arg UpsertOAuthConsentParams) error
+	UpsertUserMFASecret(ctx context.Context, arg UpsertUserMFASecretParams) (sql.Result, error)
+	UseOAuthAuthorizationCode(ctx context.Context, arg UseOAuthAuthorizationCodeParams) (sql.Result, error)
+	UseUserRecoveryCode(ctx context.Context, arg UseUserRecoveryCodeParams) (sql.Result, error)
 }
 
 var _ Querier = (*Queries)(nil)
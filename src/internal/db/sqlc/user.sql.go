@@ -8,23 +8,30 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 const createUser = `-- name: CreateUser :execresult
 INSERT INTO Users (
-  id, name, email, password
+  id, name, email, name_encrypted, email_encrypted, email_index, pii_key_version, password
 ) VALUES (
-  ?, ?, ?, ?
+  ?, ?, ?, ?, ?, ?, ?, ?
 )
 `
 
 type CreateUserParams struct {
-	ID       uuid.UUID      `json:"id"`
-	Name     sql.NullString `json:"name"`
-	Email    sql.NullString `json:"email"`
-	Password sql.NullString `json:"password"`
+	ID             uuid.UUID      `json:"id"`
+	Name           sql.NullString `json:"name"`
+	Email          sql.NullString `json:"email"`
+	NameEncrypted  []byte         `json:"nameEncrypted"`
+	EmailEncrypted []byte         `json:"emailEncrypted"`
+	EmailIndex     sql.NullString `json:"emailIndex"`
+	PiiKeyVersion  sql.NullInt32  `json:"piiKeyVersion"`
+	Password       sql.NullString `json:"password"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (sql.Result, error) {
@@ -32,6 +39,10 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (sql.Res
 		arg.ID,
 		arg.Name,
 		arg.Email,
+		arg.NameEncrypted,
+		arg.EmailEncrypted,
+		arg.EmailIndex,
+		arg.PiiKeyVersion,
 		arg.Password,
 	)
 }
@@ -45,8 +56,42 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) (sql.Result, err
 	return q.db.ExecContext(ctx, deleteUser, id)
 }
 
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, name, email, password, created_at, updatedat, role, avatar_url, avatar_thumbnail_url, preferences, attributes, name_encrypted, email_encrypted, email_index, pii_key_version, erased_at FROM Users
+WHERE email_index = ? OR email = ? LIMIT 1
+`
+
+type GetUserByEmailParams struct {
+	EmailIndex sql.NullString `json:"emailIndex"`
+	Email      sql.NullString `json:"email"`
+}
+
+func (q *Queries) GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, arg.EmailIndex, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.Password,
+		&i.CreatedAt,
+		&i.Updatedat,
+		&i.Role,
+		&i.AvatarUrl,
+		&i.AvatarThumbnailUrl,
+		&i.Preferences,
+		&i.Attributes,
+		&i.NameEncrypted,
+		&i.EmailEncrypted,
+		&i.EmailIndex,
+		&i.PiiKeyVersion,
+		&i.ErasedAt,
+	)
+	return i, err
+}
+
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, name, email, password, created_at, updatedat FROM Users
+SELECT id, name, email, password, created_at, updatedat, role, avatar_url, avatar_thumbnail_url, preferences, attributes, name_encrypted, email_encrypted, email_index, pii_key_version, erased_at FROM Users
 WHERE id = ? LIMIT 1
 `
 
@@ -60,17 +105,346 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.Password,
 		&i.CreatedAt,
 		&i.Updatedat,
+		&i.Role,
+		&i.AvatarUrl,
+		&i.AvatarThumbnailUrl,
+		&i.Preferences,
+		&i.Attributes,
+		&i.NameEncrypted,
+		&i.EmailEncrypted,
+		&i.EmailIndex,
+		&i.PiiKeyVersion,
+		&i.ErasedAt,
 	)
 	return i, err
 }
 
+const listExistingUserEmails = `-- name: ListExistingUserEmails :many
+SELECT id, email, email_encrypted FROM Users
+WHERE email IN (/*SLICE:emails*/?) OR email_index IN (/*SLICE:email_indexes*/?)
+`
+
+type ListExistingUserEmailsParams struct {
+	Emails       []sql.NullString `json:"emails"`
+	EmailIndexes []sql.NullString `json:"emailIndexes"`
+}
+
+type ListExistingUserEmailsRow struct {
+	ID             uuid.UUID      `json:"id"`
+	Email          sql.NullString `json:"email"`
+	EmailEncrypted []byte         `json:"emailEncrypted"`
+}
+
+func (q *Queries) ListExistingUserEmails(ctx context.Context, arg ListExistingUserEmailsParams) ([]ListExistingUserEmailsRow, error) {
+	query := listExistingUserEmails
+	var queryParams []interface{}
+	if len(arg.Emails) > 0 {
+		for _, v := range arg.Emails {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:emails*/?", strings.Repeat(",?", len(arg.Emails))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:emails*/?", "NULL", 1)
+	}
+	if len(arg.EmailIndexes) > 0 {
+		for _, v := range arg.EmailIndexes {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:email_indexes*/?", strings.Repeat(",?", len(arg.EmailIndexes))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:email_indexes*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListExistingUserEmailsRow
+	for rows.Next() {
+		var i ListExistingUserEmailsRow
+		if err := rows.Scan(&i.ID, &i.Email, &i.EmailEncrypted); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUsers = `-- name: ListUsers :many
-SELECT id, name, email, password, created_at, updatedat FROM Users
+SELECT id, name, email, password, created_at, updatedat, role, avatar_url, avatar_thumbnail_url, preferences, attributes, name_encrypted, email_encrypted, email_index, pii_key_version, erased_at FROM Users
+WHERE (? IS NULL OR role = ?)
+  AND (? IS NULL OR created_at >= ?)
+  AND (? IS NULL OR created_at < ?)
+  AND (? IS NULL OR id IN (
+    SELECT user_id FROM user_group_members WHERE group_id = ?
+  ))
 ORDER BY name
 `
 
-func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, listUsers)
+type ListUsersParams struct {
+	Role        sql.NullString `json:"role"`
+	CreatedFrom sql.NullTime   `json:"createdFrom"`
+	CreatedTo   sql.NullTime   `json:"createdTo"`
+	GroupID     uuid.NullUUID  `json:"groupID"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers,
+		arg.Role,
+		arg.Role,
+		arg.CreatedFrom,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CreatedTo,
+		arg.GroupID,
+		arg.GroupID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Password,
+			&i.CreatedAt,
+			&i.Updatedat,
+			&i.Role,
+			&i.AvatarUrl,
+			&i.AvatarThumbnailUrl,
+			&i.Preferences,
+			&i.Attributes,
+			&i.NameEncrypted,
+			&i.EmailEncrypted,
+			&i.EmailIndex,
+			&i.PiiKeyVersion,
+			&i.ErasedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersAfter = `-- name: ListUsersAfter :many
+SELECT id, name, email, password, created_at, updatedat, role, avatar_url, avatar_thumbnail_url, preferences, attributes, name_encrypted, email_encrypted, email_index, pii_key_version, erased_at FROM Users
+WHERE id > ?
+ORDER BY id
+LIMIT ?
+`
+
+type ListUsersAfterParams struct {
+	ID    uuid.UUID `json:"id"`
+	Limit int32     `json:"limit"`
+}
+
+func (q *Queries) ListUsersAfter(ctx context.Context, arg ListUsersAfterParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersAfter, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Password,
+			&i.CreatedAt,
+			&i.Updatedat,
+			&i.Role,
+			&i.AvatarUrl,
+			&i.AvatarThumbnailUrl,
+			&i.Preferences,
+			&i.Attributes,
+			&i.NameEncrypted,
+			&i.EmailEncrypted,
+			&i.EmailIndex,
+			&i.PiiKeyVersion,
+			&i.ErasedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersWithStalePII = `-- name: ListUsersWithStalePII :many
+SELECT id, name, email, name_encrypted, email_encrypted FROM Users
+WHERE id > ? AND (pii_key_version IS NULL OR pii_key_version <> ?)
+ORDER BY id
+LIMIT ?
+FOR UPDATE
+`
+
+type ListUsersWithStalePIIParams struct {
+	ID            uuid.UUID     `json:"id"`
+	PiiKeyVersion sql.NullInt32 `json:"piiKeyVersion"`
+	Limit         int32         `json:"limit"`
+}
+
+type ListUsersWithStalePIIRow struct {
+	ID             uuid.UUID      `json:"id"`
+	Name           sql.NullString `json:"name"`
+	Email          sql.NullString `json:"email"`
+	NameEncrypted  []byte         `json:"nameEncrypted"`
+	EmailEncrypted []byte         `json:"emailEncrypted"`
+}
+
+func (q *Queries) ListUsersWithStalePII(ctx context.Context, arg ListUsersWithStalePIIParams) ([]ListUsersWithStalePIIRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersWithStalePII, arg.ID, arg.PiiKeyVersion, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersWithStalePIIRow
+	for rows.Next() {
+		var i ListUsersWithStalePIIRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.NameEncrypted,
+			&i.EmailEncrypted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeUserAttribute = `-- name: RemoveUserAttribute :exec
+UPDATE Users
+SET attributes = JSON_REMOVE(attributes, CONCAT('$.', ?))
+WHERE JSON_CONTAINS_PATH(attributes, 'one', CONCAT('$.', ?))
+`
+
+func (q *Queries) RemoveUserAttribute(ctx context.Context, attributeKey string) error {
+	_, err := q.db.ExecContext(ctx, removeUserAttribute, attributeKey, attributeKey)
+	return err
+}
+
+const searchUsers = `-- name: SearchUsers :many
+SELECT id, name, email, role, created_at, UpdatedAt, avatar_url, avatar_thumbnail_url, preferences, attributes,
+  MATCH(name, email) AGAINST (? IN NATURAL LANGUAGE MODE) AS relevance
+FROM Users
+WHERE MATCH(name, email) AGAINST (? IN NATURAL LANGUAGE MODE)
+ORDER BY relevance DESC, id
+LIMIT ? OFFSET ?
+`
+
+type SearchUsersParams struct {
+	Query  string `json:"query"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+type SearchUsersRow struct {
+	ID                 uuid.UUID       `json:"id"`
+	Name               sql.NullString  `json:"name"`
+	Email              sql.NullString  `json:"email"`
+	Role               string          `json:"role"`
+	CreatedAt          time.Time       `json:"createdAt"`
+	Updatedat          time.Time       `json:"updatedat"`
+	AvatarUrl          sql.NullString  `json:"avatarUrl"`
+	AvatarThumbnailUrl sql.NullString  `json:"avatarThumbnailUrl"`
+	Preferences        json.RawMessage `json:"preferences"`
+	Attributes         json.RawMessage `json:"attributes"`
+	Relevance          float64         `json:"relevance"`
+}
+
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchUsers,
+		arg.Query,
+		arg.Query,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchUsersRow
+	for rows.Next() {
+		var i SearchUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Role,
+			&i.CreatedAt,
+			&i.Updatedat,
+			&i.AvatarUrl,
+			&i.AvatarThumbnailUrl,
+			&i.Preferences,
+			&i.Attributes,
+			&i.Relevance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchUsersByEmailPrefix = `-- name: SearchUsersByEmailPrefix :many
+SELECT id, name, email, password, created_at, updatedat, role, avatar_url, avatar_thumbnail_url, preferences, attributes, name_encrypted, email_encrypted, email_index, pii_key_version, erased_at FROM Users
+WHERE email LIKE ?
+ORDER BY email, id
+LIMIT ? OFFSET ?
+`
+
+type SearchUsersByEmailPrefixParams struct {
+	Email  sql.NullString `json:"email"`
+	Limit  int32          `json:"limit"`
+	Offset int32          `json:"offset"`
+}
+
+func (q *Queries) SearchUsersByEmailPrefix(ctx context.Context, arg SearchUsersByEmailPrefixParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, searchUsersByEmailPrefix,
+		arg.Email,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +459,16 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 			&i.Password,
 			&i.CreatedAt,
 			&i.Updatedat,
+			&i.Role,
+			&i.AvatarUrl,
+			&i.AvatarThumbnailUrl,
+			&i.Preferences,
+			&i.Attributes,
+			&i.NameEncrypted,
+			&i.EmailEncrypted,
+			&i.EmailIndex,
+			&i.PiiKeyVersion,
+			&i.ErasedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -101,22 +485,100 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 
 const updateUser = `-- name: UpdateUser :execresult
 UPDATE Users
-SET name = ?, email = ?, password = ?
+SET name = ?, email = ?, name_encrypted = ?, email_encrypted = ?, email_index = ?, pii_key_version = ?,
+  password = ?, preferences = ?, attributes = ?
 WHERE id = ?
 `
 
 type UpdateUserParams struct {
-	Name     sql.NullString `json:"name"`
-	Email    sql.NullString `json:"email"`
-	Password sql.NullString `json:"password"`
-	ID       uuid.UUID      `json:"id"`
+	Name           sql.NullString  `json:"name"`
+	Email          sql.NullString  `json:"email"`
+	NameEncrypted  []byte          `json:"nameEncrypted"`
+	EmailEncrypted []byte          `json:"emailEncrypted"`
+	EmailIndex     sql.NullString  `json:"emailIndex"`
+	PiiKeyVersion  sql.NullInt32   `json:"piiKeyVersion"`
+	Password       sql.NullString  `json:"password"`
+	Preferences    json.RawMessage `json:"preferences"`
+	Attributes     json.RawMessage `json:"attributes"`
+	ID             uuid.UUID       `json:"id"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (sql.Result, error) {
 	return q.db.ExecContext(ctx, updateUser,
 		arg.Name,
 		arg.Email,
+		arg.NameEncrypted,
+		arg.EmailEncrypted,
+		arg.EmailIndex,
+		arg.PiiKeyVersion,
 		arg.Password,
+		arg.Preferences,
+		arg.Attributes,
+		arg.ID,
+	)
+}
+
+const updateUserAvatar = `-- name: UpdateUserAvatar :execresult
+UPDATE Users
+SET avatar_url = ?, avatar_thumbnail_url = ?
+WHERE id = ?
+`
+
+type UpdateUserAvatarParams struct {
+	AvatarUrl          sql.NullString `json:"avatarUrl"`
+	AvatarThumbnailUrl sql.NullString `json:"avatarThumbnailUrl"`
+	ID                 uuid.UUID      `json:"id"`
+}
+
+func (q *Queries) UpdateUserAvatar(ctx context.Context, arg UpdateUserAvatarParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateUserAvatar,
+		arg.AvatarUrl,
+		arg.AvatarThumbnailUrl,
+		arg.ID,
+	)
+}
+
+const updateUserPII = `-- name: UpdateUserPII :exec
+UPDATE Users
+SET name = ?, email = ?, name_encrypted = ?, email_encrypted = ?, email_index = ?, pii_key_version = ?
+WHERE id = ?
+`
+
+type UpdateUserPIIParams struct {
+	Name           sql.NullString `json:"name"`
+	Email          sql.NullString `json:"email"`
+	NameEncrypted  []byte         `json:"nameEncrypted"`
+	EmailEncrypted []byte         `json:"emailEncrypted"`
+	EmailIndex     sql.NullString `json:"emailIndex"`
+	PiiKeyVersion  sql.NullInt32  `json:"piiKeyVersion"`
+	ID             uuid.UUID      `json:"id"`
+}
+
+func (q *Queries) UpdateUserPII(ctx context.Context, arg UpdateUserPIIParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPII,
+		arg.Name,
+		arg.Email,
+		arg.NameEncrypted,
+		arg.EmailEncrypted,
+		arg.EmailIndex,
+		arg.PiiKeyVersion,
 		arg.ID,
 	)
+	return err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :execresult
+UPDATE Users
+SET password = ?
+WHERE id = ? AND password = ?
+`
+
+type UpdateUserPasswordParams struct {
+	NewPassword sql.NullString `json:"newPassword"`
+	ID          uuid.UUID      `json:"id"`
+	OldPassword sql.NullString `json:"oldPassword"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateUserPassword, arg.NewPassword, arg.ID, arg.OldPassword)
 }
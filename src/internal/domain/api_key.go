@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Scopes an API key can be granted.
+const (
+	ScopeUsersRead  = "users:read"
+	ScopeUsersWrite = "users:write"
+	ScopeUsersAdmin = "users:admin"
+)
+
+// APIKey is a long-lived credential for service-to-service callers. Only a hash
+// of the secret is stored; the full key is shown once when it is created.
+type APIKey struct {
+	ID         string
+	UserID     string // The user the key acts as
+	Name       string
+	Prefix     string // Leading, non-secret part of the key used for lookup and display
+	SecretHash string // SHA-256 of the full key, hex encoded
+	Scopes     []string
+	ExpiresAt  time.Time // Zero when the key never expires
+	LastUsedAt time.Time // Zero until the key is first used
+	RevokedAt  time.Time // Zero while the key is active
+	CreatedAt  time.Time
+}
+
+// IsActive reports whether the key may still be used at now.
+func (k *APIKey) IsActive(now time.Time) bool {
+	if !k.RevokedAt.IsZero() {
+		return false
+	}
+	return k.ExpiresAt.IsZero() || now.Before(k.ExpiresAt)
+}
+
+// HasScope reports whether the key was granted scope.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
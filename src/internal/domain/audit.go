@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+// Audit actions emitted by the application.
+const (
+	AuditActionLoginLockout        = "auth.lockout"
+	AuditActionUserUnlocked        = "user.unlocked"
+	AuditActionIdentityLinked      = "user.identity_linked"
+	AuditActionMFAEnabled          = "mfa.enabled"
+	AuditActionRecoveryUsed        = "mfa.recovery_code_used"
+	AuditActionAPIKeyCreated       = "api_key.created"
+	AuditActionAPIKeyRevoked       = "api_key.revoked"
+	AuditActionUserCreated         = "user.created"
+	AuditActionUserUpdated         = "user.updated"
+	AuditActionUserDeleted         = "user.deleted"
+	AuditActionUserAvatarUpdated   = "user.avatar_updated"
+	AuditActionUserDataExported    = "user.data_exported"
+	AuditActionUserErased          = "user.erased"
+	AuditActionUsersExported       = "users.exported"
+	AuditActionWebhookCreated      = "webhook.created"
+	AuditActionWebhookUpdated      = "webhook.updated"
+	AuditActionWebhookDeleted      = "webhook.deleted"
+	AuditActionWebhookRedelivered  = "webhook.redelivered"
+	AuditActionOrgCreated          = "organization.created"
+	AuditActionOrgMemberAdded      = "organization.member_added"
+	AuditActionOrgMemberUpdated    = "organization.member_updated"
+	AuditActionOrgMemberRemoved    = "organization.member_removed"
+	AuditActionInvitationCreated   = "invitation.created"
+	AuditActionInvitationResent    = "invitation.resent"
+	AuditActionInvitationRevoked   = "invitation.revoked"
+	AuditActionInvitationAccepted  = "invitation.accepted"
+	AuditActionGroupCreated        = "group.created"
+	AuditActionGroupUpdated        = "group.updated"
+	AuditActionGroupDeleted        = "group.deleted"
+	AuditActionGroupMemberAdded    = "group.member_added"
+	AuditActionGroupMemberRemoved  = "group.member_removed"
+	AuditActionAttributeCreated    = "user_attribute.created"
+	AuditActionAttributeUpdated    = "user_attribute.updated"
+	AuditActionAttributeDeleted    = "user_attribute.deleted"
+	AuditActionOAuthClientCreated  = "oauth_client.created"
+	AuditActionOAuthClientDeleted  = "oauth_client.deleted"
+	AuditActionOAuthConsentGiven   = "oauth_consent.granted"
+	AuditActionOAuthConsentRevoked = "oauth_consent.revoked"
+	AuditActionOAuthCodeReplayed   = "oauth_code.replayed"
+)
+
+// MaskedValue replaces sensitive values in recorded changes.
+const MaskedValue = "***"
+
+// AuditEvent records a security-relevant action taken by or against a user.
+type AuditEvent struct {
+	ID         int64 // Assigned when the event is stored
+	Action     string
+	ActorID    string // Empty when the actor is anonymous
+	TargetID   string
+	Changes    []FieldChange // Field-level diff for mutations, sensitive values masked
+	RequestID  string
+	IP         string
+	Metadata   map[string]string
+	OccurredAt time.Time
+}
+
+// FieldChange is the before and after value of a single field. Old is empty
+// for created entities and New is empty for deleted ones.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// AuditFilter selects audit events. Zero values do not filter.
+type AuditFilter struct {
+	ActorID  string
+	TargetID string
+	Action   string
+	From     time.Time // Inclusive
+	To       time.Time // Exclusive
+	BeforeID int64     // Cursor: only events older than this ID
+	Limit    int
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when a requested entity does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidCredentials is returned when a login attempt fails. It deliberately
+	// does not distinguish between an unknown email and a wrong password.
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	// ErrUnauthenticated is returned when an operation requires an authenticated caller.
+	ErrUnauthenticated = errors.New("authentication required")
+	// ErrForbidden is returned when the caller lacks permission for an operation.
+	ErrForbidden = errors.New("permission denied")
+	// ErrMFARequired is returned when an administrator signed in without a second factor.
+	ErrMFARequired = errors.New("two-factor authentication is required")
+	// ErrInvalidMFACode is returned when a TOTP or recovery code is wrong or already used.
+	ErrInvalidMFACode = errors.New("invalid two-factor authentication code")
+	// ErrConflict is returned when an operation conflicts with the current state of a resource.
+	ErrConflict = errors.New("conflict with current state")
+	// ErrInvalidArgument is wrapped by validation errors that should be reported to the caller as-is.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrPasswordBreached is returned when a new password is known from data
+	// breaches. Like other validation errors it is reported to the caller.
+	ErrPasswordBreached = fmt.Errorf("%w: password has appeared in a data breach; choose a different one", ErrInvalidArgument)
+	// ErrAPIKeyNotFound is returned when an API key does not exist or belongs to someone else.
+	ErrAPIKeyNotFound = fmt.Errorf("api key %w", ErrNotFound)
+	// ErrWebhookNotFound is returned when a webhook subscription does not exist.
+	ErrWebhookNotFound = fmt.Errorf("webhook %w", ErrNotFound)
+	// ErrWebhookDeliveryNotFound is returned when a delivery does not exist or belongs to another subscription.
+	ErrWebhookDeliveryNotFound = fmt.Errorf("webhook delivery %w", ErrNotFound)
+	// ErrUserImportNotFound is returned when a user import job does not exist.
+	ErrUserImportNotFound = fmt.Errorf("user import %w", ErrNotFound)
+	// ErrInvitationNotFound is returned when an invitation does not exist.
+	ErrInvitationNotFound = fmt.Errorf("invitation %w", ErrNotFound)
+	// ErrInvitationInvalid is returned when an invitation link is unknown,
+	// expired, revoked or already used. The cases are not told apart.
+	ErrInvitationInvalid = errors.New("invitation is invalid or has expired")
+	// ErrGroupNotFound is returned when a group does not exist.
+	ErrGroupNotFound = fmt.Errorf("group %w", ErrNotFound)
+	// ErrAttributeDefinitionNotFound is returned when a custom attribute is
+	// not defined.
+	ErrAttributeDefinitionNotFound = fmt.Errorf("attribute definition %w", ErrNotFound)
+	// ErrErasureReceiptNotFound is returned when a user has not been erased.
+	ErrErasureReceiptNotFound = fmt.Errorf("erasure receipt %w", ErrNotFound)
+	// ErrIdentityProviderNotFound is returned when no identity provider is
+	// configured under a name.
+	ErrIdentityProviderNotFound = fmt.Errorf("identity provider %w", ErrNotFound)
+	// ErrExternalLoginFailed is returned when a sign-in at an identity provider
+	// cannot be completed: the state is unknown, used or expired, or the
+	// provider's code or ID token is rejected. The cases are not told apart.
+	ErrExternalLoginFailed = errors.New("sign-in with the identity provider failed or has expired")
+	// ErrIdentityNotLinked is returned when an identity provider account is
+	// not linked to a user and cannot be linked or provisioned automatically.
+	ErrIdentityNotLinked = errors.New("the identity provider account is not linked to a user")
+	// ErrOAuthClientNotFound is returned when an OAuth client is not registered.
+	ErrOAuthClientNotFound = fmt.Errorf("oauth client %w", ErrNotFound)
+	// ErrOAuthConsentNotFound is returned when a user has not consented to a client.
+	ErrOAuthConsentNotFound = fmt.Errorf("oauth consent %w", ErrNotFound)
+	// ErrNoTenant is returned when a tenant-scoped operation runs without an
+	// organization selected for the request.
+	ErrNoTenant = errors.New("no organization selected")
+	// ErrBatchAborted is reported for the operations of an atomic batch that were
+	// rolled back, or never run, because another operation failed.
+	ErrBatchAborted = errors.New("not applied because another operation in the atomic batch failed")
+	// ErrUserSearchUnavailable is returned by user search while names and
+	// emails are encrypted, as neither can be matched in the database then.
+	ErrUserSearchUnavailable = errors.New("user search is unavailable while user names and emails are encrypted")
+)
+
+// LockedError is returned when login is refused because the account or the
+// client IP has been locked out after too many failed attempts.
+type LockedError struct {
+	Until time.Time
+}
+
+func (e *LockedError) Error() string {
+	return "too many failed login attempts, try again after " + e.Until.UTC().Format(time.RFC3339)
+}
+
+// OAuth error codes (RFC 6749 §4.1.2.1 and §5.2, OpenID Connect Core §3.1.2.6).
+const (
+	OAuthErrInvalidRequest          = "invalid_request"
+	OAuthErrInvalidClient           = "invalid_client"
+	OAuthErrInvalidGrant            = "invalid_grant"
+	OAuthErrInvalidScope            = "invalid_scope"
+	OAuthErrAccessDenied            = "access_denied"
+	OAuthErrUnsupportedResponseType = "unsupported_response_type"
+	OAuthErrUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthErrConsentRequired         = "consent_required"
+)
+
+// OAuthError is an error reported to an OAuth client in the protocol's own
+// format, either on its redirect URI or by the token endpoint.
+type OAuthError struct {
+	Code        string // One of the OAuthErr codes
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
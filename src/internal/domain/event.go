@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Domain event types published to other services.
+const (
+	EventUserCreated = "user.created"
+	EventUserUpdated = "user.updated"
+	EventUserDeleted = "user.deleted"
+)
+
+// UserEventTypes lists the user lifecycle event types.
+var UserEventTypes = []string{EventUserCreated, EventUserUpdated, EventUserDeleted}
+
+// IsUserEventType reports whether eventType is a user lifecycle event.
+func IsUserEventType(eventType string) bool {
+	for _, t := range UserEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// DomainEvent is a fact about a change to an aggregate that other services can
+// react to. Events are delivered at least once, so consumers should
+// deduplicate by ID.
+type DomainEvent struct {
+	ID          string
+	Type        string
+	AggregateID string
+	Payload     json.RawMessage
+	OccurredAt  time.Time
+}
+
+// UserEventPayload is the payload of user lifecycle events. It never carries
+// the password hash; for deletions only ID is set. Name and Email are dropped
+// when events are stored while PII encryption is on.
+type UserEventPayload struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name,omitempty"`
+	Email         string   `json:"email,omitempty"`
+	Role          string   `json:"role,omitempty"`
+	ChangedFields []string `json:"changed_fields,omitempty"` // Set for user.updated
+}
+
+// OutboxMessage is a domain event waiting in the outbox to be published.
+type OutboxMessage struct {
+	Seq      int64 // Position in the outbox, assigned when stored
+	Event    DomainEvent
+	Attempts int // Failed publish attempts so far
+}
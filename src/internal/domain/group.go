@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// Group is a named set of users, used to grant permissions and address
+// notifications to several users at once. A user can belong to any number of
+// groups.
+type Group struct {
+	ID          string
+	Name        string // Unique
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
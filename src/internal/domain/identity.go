@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// UserIdentity links a user to their account at an external identity
+// provider, which is identified there by Subject.
+type UserIdentity struct {
+	ID          string
+	UserID      string
+	Provider    string
+	Subject     string
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+// OIDCLoginState is a sign-in started at an external identity provider and
+// not completed yet. It is stored under the hash of the state parameter that
+// comes back with the authorization code, and can be used once.
+type OIDCLoginState struct {
+	StateHash    string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
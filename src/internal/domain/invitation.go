@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Invitation statuses. Only pending invitations can be accepted, resent or revoked.
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationRevoked  = "revoked"
+	InvitationExpired  = "expired"
+)
+
+// Invitation invites an email address to join an organization. Only a hash of
+// the token in the invitation link is stored.
+type Invitation struct {
+	ID             string
+	OrganizationID string
+	Email          string
+	Role           string // Role in the organization granted on acceptance
+	InvitedBy      string // Empty when the inviter is unknown
+	ExpiresAt      time.Time
+	AcceptedAt     time.Time // Zero until accepted
+	AcceptedBy     string    // The user who accepted, empty until accepted
+	RevokedAt      time.Time // Zero while not revoked
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Status returns the state of the invitation at now.
+func (i *Invitation) Status(now time.Time) string {
+	switch {
+	case !i.AcceptedAt.IsZero():
+		return InvitationAccepted
+	case !i.RevokedAt.IsZero():
+		return InvitationRevoked
+	case !now.Before(i.ExpiresAt):
+		return InvitationExpired
+	}
+	return InvitationPending
+}
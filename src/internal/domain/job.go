@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// Job run states. A run stays running if the instance executing it stopped
+// before it finished.
+const (
+	JobRunRunning   = "running"
+	JobRunSucceeded = "succeeded"
+	JobRunFailed    = "failed"
+)
+
+// Retention policies, named after what they purge. Each enabled policy runs
+// as its own job, retention.<policy>.
+const (
+	RetentionAuditEvents     = "audit_events"
+	RetentionErasedUsers     = "erased_users"
+	RetentionInvitations     = "invitations"
+	RetentionAPIKeys         = "api_keys"
+	RetentionOutboxEvents    = "outbox_events"
+	RetentionLoginThrottles  = "login_throttles"
+	RetentionOIDCLoginStates = "oidc_login_states"
+	RetentionOAuthCodes      = "oauth_authorization_codes"
+	RetentionOAuthTokens     = "oauth_access_tokens"
+)
+
+// JobRun is one execution of a scheduled job. Each scheduled time of a job
+// runs at most once, on whichever instance claimed it first.
+type JobRun struct {
+	ID          string
+	JobName     string
+	ScheduledAt time.Time
+	Instance    string // Host name of the server that ran the job
+	Status      string
+	Result      map[string]int64 // Counts reported by the job, such as rows purged
+	Error       string
+	StartedAt   time.Time
+	FinishedAt  time.Time // Zero while running
+}
+
+// ScheduledJob is a job registered with the scheduler.
+type ScheduledJob struct {
+	Name      string
+	Schedule  string // Cron expression, evaluated in UTC
+	NextRunAt time.Time
+}
+
+// JobStatus is a scheduled job with its most recent run.
+type JobStatus struct {
+	ScheduledJob
+	LastRun *JobRun // Nil when the job never ran
+}
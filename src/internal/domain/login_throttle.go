@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// ThrottleScope identifies what a LoginThrottle counts failures against.
+type ThrottleScope string
+
+const (
+	ThrottleScopeAccount ThrottleScope = "account"
+	ThrottleScopeIP      ThrottleScope = "ip"
+)
+
+// LoginThrottle tracks failed login attempts for an account or a client IP.
+type LoginThrottle struct {
+	Scope        ThrottleScope
+	Subject      string // Normalized email for accounts, address for IPs
+	FailedCount  int
+	LockoutCount int
+	LockedUntil  time.Time // Zero when not locked
+	LastFailedAt time.Time
+}
+
+// IsLocked reports whether the throttle is locked at the given time.
+func (t *LoginThrottle) IsLocked(now time.Time) bool {
+	return t != nil && t.LockedUntil.After(now)
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// MFASetting is a user's TOTP enrollment. The secret is only ever held encrypted.
+type MFASetting struct {
+	UserID           string
+	SecretCiphertext []byte
+	Enabled          bool      // False until the user confirms enrollment with a first code
+	LastUsedStep     int64     // Time step of the last accepted code, used to reject replays
+	ConfirmedAt      time.Time // Zero until enabled
+}
@@ -0,0 +1,89 @@
+package domain
+
+import "time"
+
+// Scopes an OpenID Connect client can request. openid is required; profile
+// and email release the claims of the same names.
+const (
+	OAuthScopeOpenID  = "openid"
+	OAuthScopeProfile = "profile"
+	OAuthScopeEmail   = "email"
+)
+
+// OAuthScopes lists the scopes clients can request, in the order they are stored.
+var OAuthScopes = []string{OAuthScopeOpenID, OAuthScopeProfile, OAuthScopeEmail}
+
+// OAuthClient is an application that signs users in with this service as its
+// OpenID provider.
+type OAuthClient struct {
+	ID           string // The client_id
+	Name         string // Shown to users when they are asked for consent
+	RedirectURIs []string
+	SecretHash   string // SHA-256 of the client secret; empty for public clients
+	CreatedAt    time.Time
+}
+
+// IsPublic reports whether the client cannot keep a secret, like a
+// single-page or native app. Public clients must use PKCE.
+func (c *OAuthClient) IsPublic() bool {
+	return c.SecretHash == ""
+}
+
+// AllowsRedirectURI reports whether uri is registered for the client. URIs
+// are compared exactly.
+func (c *OAuthClient) AllowsRedirectURI(uri string) bool {
+	for _, u := range c.RedirectURIs {
+		if u == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// OAuthConsent records the scopes a user allowed a client.
+type OAuthConsent struct {
+	UserID     string
+	ClientID   string
+	ClientName string // Only set when listing a user's consents
+	Scopes     []string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// OAuthAuthorizationCode is a code a client redeems once at the token
+// endpoint for the tokens of a user. Used codes are kept until they are
+// purged, so that a second attempt to redeem one is noticed.
+type OAuthAuthorizationCode struct {
+	CodeHash      string // SHA-256 of the code
+	ClientID      string
+	UserID        string
+	RedirectURI   string // Must be sent again with the code
+	Scopes        []string
+	Nonce         string    // Copied into the ID token
+	CodeChallenge string    // S256 PKCE challenge; empty when the client did not use PKCE
+	UsedAt        time.Time // Zero until the code is exchanged
+	ExpiresAt     time.Time
+}
+
+// OAuthAccessToken is an access token issued to a client. It is only
+// accepted by the userinfo endpoint.
+type OAuthAccessToken struct {
+	TokenHash string // SHA-256 of the token
+	ClientID  string
+	UserID    string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+// SigningKey is an RSA key that signs ID tokens. A new key is published
+// before it is activated, so that clients caching the key set know it by the
+// time it signs. Retired keys no longer sign but stay published for a while,
+// so tokens signed with them still verify.
+type SigningKey struct {
+	ID                   string // Key ID (kid)
+	PublicKey            []byte // PKIX, DER encoded
+	PrivateKeyCiphertext []byte // PKCS #8, DER encoded and encrypted at rest
+	CreatedAt            time.Time
+	ActivatedAt          time.Time // When the key starts signing
+	RetiredAt            time.Time // When the next key takes over; zero while there is none
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Roles a user can hold within an organization, from most to least privileged.
+// Owners manage the organization and its owners, admins manage its members,
+// and members may only read.
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleAdmin  = "admin"
+	OrgRoleMember = "member"
+)
+
+// Organization is a tenant, such as a customer company. Users belong to
+// organizations through memberships.
+type Organization struct {
+	ID        string
+	Name      string
+	Slug      string // Unique, URL-safe identifier
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OrganizationMembership is an organization a user belongs to, with their role in it.
+type OrganizationMembership struct {
+	Organization Organization
+	Role         string
+	JoinedAt     time.Time
+}
+
+// OrganizationMember is a user seen from within an organization.
+type OrganizationMember struct {
+	User     User
+	Role     string // Role in the organization, not User.Role
+	JoinedAt time.Time
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// UserDataExport is everything stored about a user, gathered to answer a data
+// subject access request.
+type UserDataExport struct {
+	User          User
+	MFAEnabled    bool
+	APIKeys       []APIKey
+	Groups        []Group
+	Organizations []OrganizationMembership
+	AuditEvents   []AuditEvent // Events the user performed or was the target of, oldest first
+	ExportedAt    time.Time
+}
+
+// ErasureReceipt records that the personal data of a user was erased. It
+// holds no personal data itself and is kept even if the user is deleted.
+type ErasureReceipt struct {
+	ID                        string
+	UserID                    string
+	RequestedBy               string // Empty when unknown
+	Reason                    string
+	AuditEventsScrubbed       int
+	EventsScrubbed            int // Outbox events of the user
+	WebhookDeliveriesScrubbed int
+	ImportReportsScrubbed     int
+	InvitationsDeleted        int // Not accepted, sent to the user's email
+	ErasedAt                  time.Time
+}
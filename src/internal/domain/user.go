@@ -2,12 +2,66 @@ package domain // Changed from models
 
 import "time"
 
+// Roles a user can hold. RoleUser is assigned by default on creation.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 // User represents the core domain entity for a user.
 type User struct {
     ID        string    // Assuming UUID stored as string
     Name      string
     Email     string
     Password  string    // This is part of the domain, but might not be exposed directly
+    Role      string
     CreatedAt time.Time
     UpdatedAt time.Time // Note: Schema had 'UpdatedAt'
+    AvatarURL          string // Empty until an avatar is uploaded
+    AvatarThumbnailURL string
+    Preferences        UserPreferences
+    Attributes         map[string]interface{} // Custom attributes, keyed by AttributeDefinition.Key
+    ErasedAt           time.Time              // Set once the personal data of the user has been erased
+}
+
+// UserPage is one page of users in ID order.
+type UserPage struct {
+	Users   []User
+	HasMore bool // True when users follow the last one on this page
+}
+
+// UserFilter selects users. Zero values do not filter.
+type UserFilter struct {
+	Role        string
+	CreatedFrom time.Time // Inclusive
+	CreatedTo   time.Time // Exclusive
+	GroupID     string    // Members of this group
+}
+
+// Ways a user search can match. Full-text search is tried first; short
+// queries and email fragments fall back to matching the start of the email.
+const (
+	UserSearchFullText    = "fulltext"
+	UserSearchEmailPrefix = "email_prefix"
+)
+
+// TextRange is a matched fragment of a string, in runes. End is exclusive.
+type TextRange struct {
+	Start int
+	End   int
+}
+
+// UserSearchHit is one user found by a search.
+type UserSearchHit struct {
+	User         User
+	Relevance    float64 // Full-text score; zero for email prefix matches
+	NameMatches  []TextRange
+	EmailMatches []TextRange
+}
+
+// UserSearchPage is one page of search hits, best match first.
+type UserSearchPage struct {
+	Hits       []UserSearchHit
+	Mode       string // UserSearchFullText or UserSearchEmailPrefix
+	NextCursor string // Empty on the last page
 }
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// Upload formats accepted by the user import.
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+)
+
+// User import job states. A job is completed once every row has been
+// processed, whether or not individual rows failed; it fails when the import
+// stops early, leaving the rows processed so far in place.
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// UserImportRowResult is the outcome of one row of an import. Exactly one of
+// UserID and Error is set.
+type UserImportRowResult struct {
+	Row    int    `json:"row"` // 1-based position among the data rows
+	Email  string `json:"email,omitempty"`
+	UserID string `json:"user_id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// UserImportReport summarises an import.
+type UserImportReport struct {
+	Total   int
+	Created int
+	Failed  int
+	Error   string // Why the import stopped early, if it did
+	Rows    []UserImportRowResult
+}
+
+// UserImportJob is an import running in the background. Report.Rows is only
+// filled in once the job has finished; the counts are updated as it runs.
+type UserImportJob struct {
+	ID         string
+	Format     string
+	Status     string
+	Report     UserImportReport
+	CreatedBy  string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Finished reports whether the job has stopped running.
+func (j *UserImportJob) Finished() bool {
+	return j.Status == ImportStatusCompleted || j.Status == ImportStatusFailed
+}
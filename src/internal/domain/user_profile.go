@@ -0,0 +1,77 @@
+package domain
+
+import "time"
+
+// Digest frequencies for notification emails.
+const (
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// UserPreferences are the settings a user chooses for themselves.
+type UserPreferences struct {
+	Locale        string // BCP 47 language tag, e.g. "ja-JP"; empty when not chosen
+	Timezone      string // IANA time zone name, e.g. "Asia/Tokyo"; empty when not chosen
+	Notifications NotificationPreferences
+}
+
+// NotificationPreferences control which notifications a user receives.
+type NotificationPreferences struct {
+	Email  bool   // Notifications by email
+	Digest string // DigestOff, DigestDaily or DigestWeekly
+}
+
+// DefaultUserPreferences returns the preferences of users who have not
+// changed any.
+func DefaultUserPreferences() UserPreferences {
+	return UserPreferences{Notifications: NotificationPreferences{Email: true, Digest: DigestOff}}
+}
+
+// PreferencesPatch changes some preferences. Nil fields are left unchanged.
+type PreferencesPatch struct {
+	Locale              *string
+	Timezone            *string
+	NotificationsEmail  *bool
+	NotificationsDigest *string
+}
+
+// UserPatch is a partial update of a user. Nil fields are left unchanged.
+type UserPatch struct {
+	Name        *string
+	Email       *string
+	Password    *string // Plain text; hashed before it is stored
+	Preferences *PreferencesPatch
+	// Attributes are merged into the user's attributes. A nil value removes
+	// the attribute.
+	Attributes map[string]interface{}
+}
+
+// Types a custom attribute can have.
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeNumber  = "number"
+	AttributeTypeInteger = "integer"
+	AttributeTypeBoolean = "boolean"
+	AttributeTypeDate    = "date" // YYYY-MM-DD
+)
+
+// AttributeDefinition declares a custom user attribute. Administrators define
+// attributes; values that do not match their definition are refused.
+type AttributeDefinition struct {
+	Key         string
+	Type        string   // One of the AttributeType constants; cannot be changed
+	Required    bool     // Every user whose attributes are written must have a value
+	Enum        []string // Allowed values of a string attribute; empty allows any
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// AttributeDefinitionPatch changes an attribute definition. Nil fields are
+// left unchanged.
+type AttributeDefinitionPatch struct {
+	Required    *bool
+	Enum        *[]string
+	Description *string
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook delivery states. A delivery becomes dead after it has used up its
+// retries; dead deliveries are only retried when redelivered manually.
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusDead      = "dead"
+)
+
+// WebhookEventTypes lists the event types a webhook can subscribe to.
+var WebhookEventTypes = UserEventTypes
+
+// IsWebhookEventType reports whether webhooks can subscribe to eventType.
+func IsWebhookEventType(eventType string) bool {
+	return IsUserEventType(eventType)
+}
+
+// WebhookSubscription is a partner endpoint that receives signed HTTP callbacks
+// for the event types it subscribes to.
+type WebhookSubscription struct {
+	ID               string
+	URL              string
+	EventTypes       []string
+	Description      string
+	SecretCiphertext []byte // Signing secret, encrypted at rest
+	Active           bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Subscribes reports whether the subscription wants events of eventType.
+func (s *WebhookSubscription) Subscribes(eventType string) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is a single event queued for, or delivered to, a subscription.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Payload        json.RawMessage // Request body, identical for every attempt
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  time.Time
+	LastStatusCode int // Zero when no response was received
+	LastError      string
+	DeliveredAt    time.Time
+	CreatedAt      time.Time
+}
@@ -0,0 +1,59 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// ErrDecrypt is returned when a ciphertext cannot be authenticated or decrypted.
+var ErrDecrypt = errors.New("failed to decrypt value")
+
+// Cipher encrypts small values, such as secrets, before they are stored.
+type Cipher interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// aesGCM implements Cipher with AES-256-GCM. The random nonce is prepended to the ciphertext.
+type aesGCM struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCM creates a Cipher from a 32-byte key.
+func NewAESGCM(key []byte) (Cipher, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesGCM{aead: aead}, nil
+}
+
+func (c *aesGCM) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (c *aesGCM) Decrypt(ciphertext []byte) ([]byte, error) {
+	n := c.aead.NonceSize()
+	if len(ciphertext) < n {
+		return nil, ErrDecrypt
+	}
+	plaintext, err := c.aead.Open(nil, ciphertext[:n], ciphertext[n:], nil)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,39 @@
+package encryption
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAESGCM_RoundTrip(t *testing.T) {
+	c, err := NewAESGCM(bytes.Repeat([]byte{1}, 32))
+	assert.NoError(t, err)
+
+	ciphertext, err := c.Encrypt([]byte("secret"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "secret")
+
+	plaintext, err := c.Decrypt(ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+}
+
+func TestAESGCM_DecryptWithWrongKey(t *testing.T) {
+	c1, _ := NewAESGCM(bytes.Repeat([]byte{1}, 32))
+	c2, _ := NewAESGCM(bytes.Repeat([]byte{2}, 32))
+
+	ciphertext, err := c1.Encrypt([]byte("secret"))
+	assert.NoError(t, err)
+
+	_, err = c2.Decrypt(ciphertext)
+	assert.ErrorIs(t, err, ErrDecrypt)
+	_, err = c1.Decrypt([]byte("short"))
+	assert.ErrorIs(t, err, ErrDecrypt)
+}
+
+func TestNewAESGCM_InvalidKeyLength(t *testing.T) {
+	_, err := NewAESGCM([]byte("too short"))
+	assert.Error(t, err)
+}
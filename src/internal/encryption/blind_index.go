@@ -0,0 +1,33 @@
+package encryption
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+)
+
+// BlindIndexSize is the length of a blind index, in bytes.
+const BlindIndexSize = sha256.Size
+
+// BlindIndex derives a keyed hash of a value, so that encrypted values can be
+// looked up and kept unique by equality without being decrypted. Callers
+// normalize values first; equal inputs always give equal indexes.
+type BlindIndex struct {
+	key []byte
+}
+
+// NewBlindIndex creates a BlindIndex from a key of at least 32 bytes. The key
+// cannot be rotated without recomputing every stored index.
+func NewBlindIndex(key []byte) (*BlindIndex, error) {
+	if len(key) < 32 {
+		return nil, fmt.Errorf("blind index key must be at least 32 bytes, got %d", len(key))
+	}
+	return &BlindIndex{key: append([]byte(nil), key...)}, nil
+}
+
+// Sum returns the HMAC-SHA256 of value.
+func (b *BlindIndex) Sum(value []byte) []byte {
+	mac := hmac.New(sha256.New, b.key)
+	mac.Write(value)
+	return mac.Sum(nil)
+}
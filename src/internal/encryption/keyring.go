@@ -0,0 +1,195 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// envelopeFormat is the first byte of every envelope, so that the layout can
+// change without guessing.
+const envelopeFormat byte = 1
+
+const (
+	dataKeySize = 32
+	// Layout: format (1) | key version (4) | wrapped data key | sealed value.
+	// The wrapped data key is a nonce, the key and a tag.
+	envelopeHeaderSize = 1 + 4
+	wrappedKeySize     = 12 + dataKeySize + 16
+)
+
+// ErrUnknownKeyVersion is returned when a value was encrypted with a key that
+// is not in the keyring.
+var ErrUnknownKeyVersion = errors.New("encryption key version is not in the keyring")
+
+// Keyring encrypts values with envelope encryption: each value is sealed with
+// its own random data key, which is in turn sealed with the primary key
+// encryption key. Older keys stay in the keyring so that values encrypted
+// before a rotation can still be read.
+type Keyring struct {
+	keys    map[uint32]cipher.AEAD
+	primary uint32
+}
+
+// NewKeyring creates a Keyring from 32-byte keys indexed by version. New
+// values are encrypted with the primary version.
+func NewKeyring(keys map[uint32][]byte, primary uint32) (*Keyring, error) {
+	if _, ok := keys[primary]; !ok {
+		return nil, fmt.Errorf("primary key version %d is not in the keyring", primary)
+	}
+	k := &Keyring{keys: make(map[uint32]cipher.AEAD, len(keys)), primary: primary}
+	for version, key := range keys {
+		if len(key) != 32 {
+			return nil, fmt.Errorf("encryption key %d must be 32 bytes, got %d", version, len(key))
+		}
+		aead, err := newGCM(key)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[version] = aead
+	}
+	return k, nil
+}
+
+// ParseKeyring reads a keyring from a comma-separated list of
+// "version:base64-key" pairs, e.g. "1:AAAA...,2:BBBB...". primary is the
+// version new values are encrypted with; 0 selects the highest version.
+func ParseKeyring(spec string, primary uint32) (*Keyring, error) {
+	keys := make(map[uint32][]byte)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		v, b64, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("keyring entry %q must be version:key", entry)
+		}
+		version, err := strconv.ParseUint(v, 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("keyring entry %q must have a positive version", entry)
+		}
+		if _, dup := keys[uint32(version)]; dup {
+			return nil, fmt.Errorf("key version %d appears more than once", version)
+		}
+		key, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			return nil, fmt.Errorf("key version %d is not valid base64", version)
+		}
+		keys[uint32(version)] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("keyring is empty")
+	}
+	if primary == 0 {
+		for version := range keys {
+			primary = max(primary, version)
+		}
+	}
+	return NewKeyring(keys, primary)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// PrimaryVersion returns the version new values are encrypted with.
+func (k *Keyring) PrimaryVersion() uint32 {
+	return k.primary
+}
+
+// Versions returns the versions in the keyring, in ascending order.
+func (k *Keyring) Versions() []uint32 {
+	versions := make([]uint32, 0, len(k.keys))
+	for v := range k.keys {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+// Encrypt seals plaintext under the primary key. additionalData is
+// authenticated but not stored; the same value must be passed to Decrypt, so
+// callers use it to bind a value to where it is stored.
+func (k *Keyring) Encrypt(plaintext, additionalData []byte) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	valueAEAD, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, envelopeHeaderSize, envelopeHeaderSize+wrappedKeySize+valueAEAD.NonceSize()+len(plaintext)+valueAEAD.Overhead())
+	out[0] = envelopeFormat
+	binary.BigEndian.PutUint32(out[1:], k.primary)
+	// The header is authenticated with the data key so it cannot be altered
+	if out, err = seal(k.keys[k.primary], out, dataKey, out[:envelopeHeaderSize]); err != nil {
+		return nil, err
+	}
+	return seal(valueAEAD, out, plaintext, additionalData)
+}
+
+// seal appends a random nonce and the sealed plaintext to dst.
+func seal(aead cipher.AEAD, dst, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	dst = append(dst, nonce...)
+	return aead.Seal(dst, nonce, plaintext, additionalData), nil
+}
+
+// Decrypt opens a value sealed by Encrypt with any key in the keyring.
+func (k *Keyring) Decrypt(envelope, additionalData []byte) ([]byte, error) {
+	version, err := KeyVersion(envelope)
+	if err != nil {
+		return nil, err
+	}
+	keyAEAD, ok := k.keys[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownKeyVersion, version)
+	}
+	header, rest := envelope[:envelopeHeaderSize], envelope[envelopeHeaderSize:]
+	if len(rest) < wrappedKeySize {
+		return nil, ErrDecrypt
+	}
+	nonce, wrapped := rest[:keyAEAD.NonceSize()], rest[keyAEAD.NonceSize():wrappedKeySize]
+	dataKey, err := keyAEAD.Open(nil, nonce, wrapped, header)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	valueAEAD, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	sealed := rest[wrappedKeySize:]
+	if len(sealed) < valueAEAD.NonceSize() {
+		return nil, ErrDecrypt
+	}
+	plaintext, err := valueAEAD.Open(nil, sealed[:valueAEAD.NonceSize()], sealed[valueAEAD.NonceSize():], additionalData)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// KeyVersion returns the version of the key an envelope was sealed with.
+func KeyVersion(envelope []byte) (uint32, error) {
+	if len(envelope) < envelopeHeaderSize || envelope[0] != envelopeFormat {
+		return 0, ErrDecrypt
+	}
+	return binary.BigEndian.Uint32(envelope[1:envelopeHeaderSize]), nil
+}
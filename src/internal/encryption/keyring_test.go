@@ -0,0 +1,118 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func TestKeyring_RoundTrip(t *testing.T) {
+	k, err := NewKeyring(map[uint32][]byte{1: testKey(1)}, 1)
+	require.NoError(t, err)
+
+	envelope, err := k.Encrypt([]byte("alice@example.com"), []byte("row-1"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(envelope), "alice")
+
+	plaintext, err := k.Decrypt(envelope, []byte("row-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", string(plaintext))
+
+	// The same value is encrypted differently every time
+	again, err := k.Encrypt([]byte("alice@example.com"), []byte("row-1"))
+	require.NoError(t, err)
+	assert.NotEqual(t, envelope, again)
+}
+
+func TestKeyring_AdditionalDataMustMatch(t *testing.T) {
+	k, _ := NewKeyring(map[uint32][]byte{1: testKey(1)}, 1)
+	envelope, err := k.Encrypt([]byte("secret"), []byte("row-1"))
+	require.NoError(t, err)
+
+	_, err = k.Decrypt(envelope, []byte("row-2"))
+	assert.ErrorIs(t, err, ErrDecrypt)
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	old, _ := NewKeyring(map[uint32][]byte{1: testKey(1)}, 1)
+	envelope, err := old.Encrypt([]byte("secret"), nil)
+	require.NoError(t, err)
+
+	rotated, err := NewKeyring(map[uint32][]byte{1: testKey(1), 2: testKey(2)}, 2)
+	require.NoError(t, err)
+	plaintext, err := rotated.Decrypt(envelope, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+
+	reencrypted, err := rotated.Encrypt(plaintext, nil)
+	require.NoError(t, err)
+	version, err := KeyVersion(reencrypted)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), version)
+
+	// Once the old key is removed, values still under it cannot be read
+	newOnly, _ := NewKeyring(map[uint32][]byte{2: testKey(2)}, 2)
+	_, err = newOnly.Decrypt(envelope, nil)
+	assert.ErrorIs(t, err, ErrUnknownKeyVersion)
+}
+
+func TestKeyring_TamperedEnvelope(t *testing.T) {
+	k, _ := NewKeyring(map[uint32][]byte{1: testKey(1), 2: testKey(2)}, 1)
+	envelope, err := k.Encrypt([]byte("secret"), nil)
+	require.NoError(t, err)
+
+	// Claiming another key version is detected
+	tampered := append([]byte(nil), envelope...)
+	tampered[4] = 2
+	_, err = k.Decrypt(tampered, nil)
+	assert.ErrorIs(t, err, ErrDecrypt)
+
+	tampered = append([]byte(nil), envelope...)
+	tampered[len(tampered)-1] ^= 1
+	_, err = k.Decrypt(tampered, nil)
+	assert.ErrorIs(t, err, ErrDecrypt)
+
+	_, err = k.Decrypt(envelope[:10], nil)
+	assert.ErrorIs(t, err, ErrDecrypt)
+}
+
+func TestParseKeyring(t *testing.T) {
+	spec := "1:" + base64.StdEncoding.EncodeToString(testKey(1)) + ", 3:" + base64.StdEncoding.EncodeToString(testKey(3))
+
+	k, err := ParseKeyring(spec, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), k.PrimaryVersion())
+	assert.Equal(t, []uint32{1, 3}, k.Versions())
+
+	k, err = ParseKeyring(spec, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), k.PrimaryVersion())
+
+	for _, bad := range []string{"", "1", "0:" + base64.StdEncoding.EncodeToString(testKey(1)), "1:not-base64!", "1:" + base64.StdEncoding.EncodeToString([]byte("short"))} {
+		_, err := ParseKeyring(bad, 0)
+		assert.Error(t, err, bad)
+	}
+	_, err = ParseKeyring(spec, 2)
+	assert.Error(t, err, "primary version must be in the keyring")
+}
+
+func TestBlindIndex(t *testing.T) {
+	idx, err := NewBlindIndex(testKey(1))
+	require.NoError(t, err)
+	other, _ := NewBlindIndex(testKey(2))
+
+	assert.Equal(t, idx.Sum([]byte("alice@example.com")), idx.Sum([]byte("alice@example.com")))
+	assert.Len(t, idx.Sum([]byte("alice@example.com")), BlindIndexSize)
+	assert.NotEqual(t, idx.Sum([]byte("alice@example.com")), idx.Sum([]byte("bob@example.com")))
+	assert.NotEqual(t, idx.Sum([]byte("alice@example.com")), other.Sum([]byte("alice@example.com")))
+
+	_, err = NewBlindIndex([]byte("short"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package events
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+)
+
+// FeedSource reads stored events from the outbox in sequence order.
+type FeedSource interface {
+	ListOutboxEventsAfter(ctx context.Context, afterSeq int64, limit int) ([]domain.OutboxMessage, error)
+	ListLatestOutboxEvents(ctx context.Context, limit int) ([]domain.OutboxMessage, error)
+}
+
+// FeedConfig tunes the event feed.
+type FeedConfig struct {
+	PollInterval time.Duration // Wait between reads of the outbox
+	BufferSize   int           // Most recent events kept for clients that resume
+	GapTimeout   time.Duration // How long to wait for a skipped sequence number to commit
+}
+
+// DefaultFeedConfig returns the settings used when none are configured.
+func DefaultFeedConfig() FeedConfig {
+	return FeedConfig{
+		PollInterval: 500 * time.Millisecond,
+		BufferSize:   1000,
+		GapTimeout:   5 * time.Second,
+	}
+}
+
+// Feed keeps the most recent committed events in memory and wakes readers when
+// new ones arrive. It follows the outbox table rather than the relay, so every
+// instance sees the changes made through any instance, whether or not they
+// have been published yet.
+//
+// Sequence numbers are assigned when a row is inserted but become visible when
+// its transaction commits, so a later number can appear first. The feed stops
+// at such a gap until the missing number commits or GapTimeout passes, which
+// is what happens when the transaction that took it rolled back.
+type Feed struct {
+	source FeedSource
+	clock  clock.Clock
+	config FeedConfig
+	logger *log.Logger
+
+	mu       sync.Mutex
+	loaded   bool
+	events   []domain.OutboxMessage // Oldest first, at most BufferSize
+	floor    int64                  // Events up to this sequence number may be missing from events
+	latest   int64                  // Sequence number of the newest event read
+	gapSince time.Time              // When the gap after latest was first seen
+	notify   chan struct{}          // Closed when events are appended
+}
+
+// NewFeed creates a new Feed. Call PollOnce before serving readers so that
+// the events already stored are not reported as new.
+func NewFeed(source FeedSource, clk clock.Clock, config FeedConfig, logger *log.Logger) *Feed {
+	return &Feed{source: source, clock: clk, config: config, logger: logger, notify: make(chan struct{})}
+}
+
+// Run follows the outbox until ctx is cancelled.
+func (f *Feed) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		n, err := f.PollOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			f.logger.Printf("event feed: %v", err)
+		}
+		if err == nil && n == f.config.BufferSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(f.config.PollInterval)
+		}
+	}
+}
+
+// PollOnce reads the events stored since the last call and returns how many
+// were appended. The first call loads the most recent BufferSize events.
+func (f *Feed) PollOnce(ctx context.Context) (int, error) {
+	if !f.loaded {
+		return f.load(ctx)
+	}
+
+	messages, err := f.source.ListOutboxEventsAfter(ctx, f.latest, f.config.BufferSize)
+	if err != nil {
+		return 0, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := f.clock.Now()
+	appended := 0
+	for _, m := range messages {
+		if f.latest != 0 && m.Seq != f.latest+1 {
+			if f.gapSince.IsZero() {
+				f.gapSince = now
+			}
+			if now.Sub(f.gapSince) < f.config.GapTimeout {
+				break
+			}
+		}
+		f.gapSince = time.Time{}
+		f.events = append(f.events, m)
+		f.latest = m.Seq
+		appended++
+	}
+	if drop := len(f.events) - f.config.BufferSize; drop > 0 {
+		f.floor = f.events[drop-1].Seq
+		f.events = append([]domain.OutboxMessage(nil), f.events[drop:]...)
+	}
+	if appended > 0 {
+		close(f.notify)
+		f.notify = make(chan struct{})
+	}
+	return appended, nil
+}
+
+func (f *Feed) load(ctx context.Context) (int, error) {
+	messages, err := f.source.ListLatestOutboxEvents(ctx, f.config.BufferSize)
+	if err != nil {
+		return 0, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = messages
+	if len(messages) > 0 {
+		f.floor = messages[0].Seq - 1
+		f.latest = messages[len(messages)-1].Seq
+	}
+	f.loaded = true
+	return len(messages), nil
+}
+
+// Since returns the events after seq and a channel that is closed when more
+// arrive. ok is false when events after seq have already been dropped from
+// the buffer.
+func (f *Feed) Since(seq int64) (events []domain.OutboxMessage, wait <-chan struct{}, ok bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if seq < f.floor {
+		return nil, f.notify, false
+	}
+	i := sort.Search(len(f.events), func(i int) bool { return f.events[i].Seq > seq })
+	return append([]domain.OutboxMessage(nil), f.events[i:]...), f.notify, true
+}
+
+// Latest returns the sequence number of the newest event read.
+func (f *Feed) Latest() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.latest
+}
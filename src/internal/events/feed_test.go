@@ -0,0 +1,129 @@
+package events
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func outboxMessages(seqs ...int64) []domain.OutboxMessage {
+	messages := make([]domain.OutboxMessage, len(seqs))
+	for i, seq := range seqs {
+		messages[i] = domain.OutboxMessage{Seq: seq, Event: domain.DomainEvent{Type: domain.EventUserUpdated}}
+	}
+	return messages
+}
+
+func seqsOf(messages []domain.OutboxMessage) []int64 {
+	seqs := make([]int64, len(messages))
+	for i, m := range messages {
+		seqs[i] = m.Seq
+	}
+	return seqs
+}
+
+func newTestFeed(outbox *mocks.MockOutboxRepository, clk clock.Clock, bufferSize int) *Feed {
+	config := DefaultFeedConfig()
+	config.BufferSize = bufferSize
+	return NewFeed(outbox, clk, config, log.New(io.Discard, "", 0))
+}
+
+func TestFeed_PollOnce_LoadsThenFollows(t *testing.T) {
+	outbox := new(mocks.MockOutboxRepository)
+	feed := newTestFeed(outbox, clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)), 3)
+
+	outbox.On("ListLatestOutboxEvents", mock.Anything, 3).Return(outboxMessages(4, 5), nil).Once()
+	n, err := feed.PollOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, int64(5), feed.Latest())
+
+	events, wait, ok := feed.Since(5)
+	assert.True(t, ok)
+	assert.Empty(t, events)
+
+	outbox.On("ListOutboxEventsAfter", mock.Anything, int64(5), 3).Return(outboxMessages(6, 7), nil).Once()
+	n, err = feed.PollOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	select {
+	case <-wait:
+	default:
+		t.Fatal("readers were not woken")
+	}
+	events, _, ok = feed.Since(5)
+	assert.True(t, ok)
+	assert.Equal(t, []int64{6, 7}, seqsOf(events))
+
+	// 4 was dropped to keep three events
+	_, _, ok = feed.Since(3)
+	assert.False(t, ok)
+	events, _, ok = feed.Since(4)
+	assert.True(t, ok)
+	assert.Equal(t, []int64{5, 6, 7}, seqsOf(events))
+	outbox.AssertExpectations(t)
+}
+
+func TestFeed_PollOnce_WaitsForGap(t *testing.T) {
+	outbox := new(mocks.MockOutboxRepository)
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	feed := newTestFeed(outbox, clk, 100)
+
+	outbox.On("ListLatestOutboxEvents", mock.Anything, 100).Return(outboxMessages(1), nil).Once()
+	_, err := feed.PollOnce(context.Background())
+	assert.NoError(t, err)
+
+	// 2 was taken by a transaction that has not committed yet
+	outbox.On("ListOutboxEventsAfter", mock.Anything, int64(1), 100).Return(outboxMessages(3), nil).Twice()
+	n, err := feed.PollOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	clk.Advance(time.Second)
+	n, _ = feed.PollOnce(context.Background())
+	assert.Equal(t, 0, n)
+
+	// It committed
+	outbox.On("ListOutboxEventsAfter", mock.Anything, int64(1), 100).Return(outboxMessages(2, 3), nil).Once()
+	n, _ = feed.PollOnce(context.Background())
+	assert.Equal(t, 2, n)
+	assert.Equal(t, int64(3), feed.Latest())
+
+	// 4 was rolled back and never appears
+	outbox.On("ListOutboxEventsAfter", mock.Anything, int64(3), 100).Return(outboxMessages(5), nil).Twice()
+	n, _ = feed.PollOnce(context.Background())
+	assert.Equal(t, 0, n)
+	clk.Advance(DefaultFeedConfig().GapTimeout)
+	n, _ = feed.PollOnce(context.Background())
+	assert.Equal(t, 1, n)
+	assert.Equal(t, int64(5), feed.Latest())
+	outbox.AssertExpectations(t)
+}
+
+func TestFeed_PollOnce_EmptyOutbox(t *testing.T) {
+	outbox := new(mocks.MockOutboxRepository)
+	feed := newTestFeed(outbox, clock.Real(), 100)
+
+	outbox.On("ListLatestOutboxEvents", mock.Anything, 100).Return([]domain.OutboxMessage{}, nil).Once()
+	_, err := feed.PollOnce(context.Background())
+	assert.NoError(t, err)
+
+	// The first sequence number is not known, so there is nothing to wait for
+	outbox.On("ListOutboxEventsAfter", mock.Anything, int64(0), 100).Return(outboxMessages(41), nil).Once()
+	n, err := feed.PollOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	events, _, ok := feed.Since(0)
+	assert.True(t, ok)
+	assert.Equal(t, []int64{41}, seqsOf(events))
+}
@@ -0,0 +1,58 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"apiserver/internal/domain"
+)
+
+// Headers sent with every webhook request so receivers can route and
+// deduplicate without parsing the body.
+const (
+	HeaderEventID   = "X-Event-ID"
+	HeaderEventType = "X-Event-Type"
+)
+
+// httpPublisher POSTs events to a fixed webhook URL.
+type httpPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPPublisher creates a Publisher that POSTs each event as JSON to url.
+// Any 2xx response counts as accepted. client should have a timeout, since
+// the relay waits for each delivery.
+func NewHTTPPublisher(url string, client *http.Client) Publisher {
+	return &httpPublisher{url: url, client: client}
+}
+
+func (p *httpPublisher) Publish(ctx context.Context, event domain.DomainEvent) error {
+	body, err := Encode(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, event.ID)
+	req.Header.Set(HeaderEventType, event.Type)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
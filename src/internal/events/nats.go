@@ -0,0 +1,42 @@
+package events
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/nats-io/nats.go"
+)
+
+// NATSConn is the subset of *nats.Conn used by the NATS publisher.
+type NATSConn interface {
+	PublishMsg(msg *nats.Msg) error
+	FlushWithContext(ctx context.Context) error
+}
+
+// natsPublisher publishes events to NATS subjects.
+type natsPublisher struct {
+	conn          NATSConn
+	subjectPrefix string
+}
+
+// NewNATSPublisher creates a Publisher that publishes each event to the
+// subject subjectPrefix+event type, e.g. "events.user.created". Each publish
+// is flushed so a lost connection is reported and the event retried. The
+// Nats-Msg-Id header lets JetStream streams drop redeliveries.
+func NewNATSPublisher(conn NATSConn, subjectPrefix string) Publisher {
+	return &natsPublisher{conn: conn, subjectPrefix: subjectPrefix}
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, event domain.DomainEvent) error {
+	data, err := Encode(event)
+	if err != nil {
+		return err
+	}
+	msg := nats.NewMsg(p.subjectPrefix + event.Type)
+	msg.Header.Set(nats.MsgIdHdr, event.ID)
+	msg.Data = data
+	if err := p.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+	return p.conn.FlushWithContext(ctx)
+}
@@ -0,0 +1,78 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"apiserver/internal/domain"
+)
+
+// Publisher delivers domain events to other services. Publish returns only
+// after the broker or endpoint has accepted the event; an error makes the
+// relay retry it later, so the same event may be published more than once.
+type Publisher interface {
+	Publish(ctx context.Context, event domain.DomainEvent) error
+}
+
+// Envelope is the wire format shared by all publishers.
+type Envelope struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Encode returns the JSON envelope for event.
+func Encode(event domain.DomainEvent) ([]byte, error) {
+	return json.Marshal(Envelope{
+		ID:          event.ID,
+		Type:        event.Type,
+		AggregateID: event.AggregateID,
+		OccurredAt:  event.OccurredAt.UTC(),
+		Data:        event.Payload,
+	})
+}
+
+// logPublisher writes events as JSON lines to a logger.
+type logPublisher struct {
+	logger *log.Logger
+}
+
+// NewLogPublisher creates a Publisher that writes each event to logger. It is
+// meant for development, where no broker is available.
+func NewLogPublisher(logger *log.Logger) Publisher {
+	return &logPublisher{logger: logger}
+}
+
+func (p *logPublisher) Publish(ctx context.Context, event domain.DomainEvent) error {
+	b, err := Encode(event)
+	if err != nil {
+		return err
+	}
+	p.logger.Printf("event: %s", b)
+	return nil
+}
+
+// multiPublisher publishes every event to several publishers.
+type multiPublisher []Publisher
+
+// NewMultiPublisher creates a Publisher that publishes each event to all of
+// publishers. If any of them fails the event is retried for all, so each
+// publisher must tolerate duplicates.
+func NewMultiPublisher(publishers ...Publisher) Publisher {
+	return multiPublisher(publishers)
+}
+
+func (m multiPublisher) Publish(ctx context.Context, event domain.DomainEvent) error {
+	var errs []error
+	for _, p := range m {
+		if err := p.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
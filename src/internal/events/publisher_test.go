@@ -0,0 +1,133 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"apiserver/internal/domain"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEvent() domain.DomainEvent {
+	return domain.DomainEvent{
+		ID:          "6f1c2a52-0d52-4c47-9a57-4f1b7d1a0c11",
+		Type:        domain.EventUserCreated,
+		AggregateID: "user-1",
+		Payload:     json.RawMessage(`{"id":"user-1","name":"Alice"}`),
+		OccurredAt:  time.Date(2026, 1, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60)),
+	}
+}
+
+func TestEncode(t *testing.T) {
+	b, err := Encode(testEvent())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": "6f1c2a52-0d52-4c47-9a57-4f1b7d1a0c11",
+		"type": "user.created",
+		"aggregate_id": "user-1",
+		"occurred_at": "2026-01-01T00:00:00Z",
+		"data": {"id": "user-1", "name": "Alice"}
+	}`, string(b))
+}
+
+func TestLogPublisher(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewLogPublisher(log.New(&buf, "", 0))
+
+	assert.NoError(t, p.Publish(context.Background(), testEvent()))
+	assert.Contains(t, buf.String(), `"type":"user.created"`)
+}
+
+func TestHTTPPublisher_Success(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	p := NewHTTPPublisher(srv.URL, srv.Client())
+	assert.NoError(t, p.Publish(context.Background(), testEvent()))
+
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+	assert.Equal(t, testEvent().ID, got.Header.Get(HeaderEventID))
+	assert.Equal(t, domain.EventUserCreated, got.Header.Get(HeaderEventType))
+	assert.Contains(t, string(body), `"aggregate_id":"user-1"`)
+}
+
+func TestHTTPPublisher_Non2xxIsAnError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	err := NewHTTPPublisher(srv.URL, srv.Client()).Publish(context.Background(), testEvent())
+	assert.ErrorContains(t, err, "503")
+}
+
+type fakeNATSConn struct {
+	published []*nats.Msg
+	flushErr  error
+}
+
+func (c *fakeNATSConn) PublishMsg(msg *nats.Msg) error {
+	c.published = append(c.published, msg)
+	return nil
+}
+
+func (c *fakeNATSConn) FlushWithContext(ctx context.Context) error {
+	return c.flushErr
+}
+
+func TestNATSPublisher(t *testing.T) {
+	conn := &fakeNATSConn{}
+	p := NewNATSPublisher(conn, "events.")
+
+	assert.NoError(t, p.Publish(context.Background(), testEvent()))
+	if assert.Len(t, conn.published, 1) {
+		msg := conn.published[0]
+		assert.Equal(t, "events.user.created", msg.Subject)
+		assert.Equal(t, testEvent().ID, msg.Header.Get(nats.MsgIdHdr))
+		assert.Contains(t, string(msg.Data), `"type":"user.created"`)
+	}
+}
+
+func TestNATSPublisher_FlushFailureIsAnError(t *testing.T) {
+	flushErr := errors.New("connection closed")
+	p := NewNATSPublisher(&fakeNATSConn{flushErr: flushErr}, "events.")
+
+	assert.ErrorIs(t, p.Publish(context.Background(), testEvent()), flushErr)
+}
+
+type recordingPublisher struct {
+	err   error
+	calls int
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event domain.DomainEvent) error {
+	p.calls++
+	return p.err
+}
+
+func TestMultiPublisher(t *testing.T) {
+	failing := &recordingPublisher{err: errors.New("down")}
+	ok := &recordingPublisher{}
+	p := NewMultiPublisher(failing, ok)
+
+	// Every publisher sees the event even when an earlier one fails
+	assert.ErrorIs(t, p.Publish(context.Background(), testEvent()), failing.err)
+	assert.Equal(t, 1, failing.calls)
+	assert.Equal(t, 1, ok.calls)
+}
@@ -0,0 +1,149 @@
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+)
+
+// Outbox is the storage side of the relay. It is satisfied by
+// repositories.OutboxRepository.
+type Outbox interface {
+	ClaimDueOutboxEvents(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.OutboxMessage, error)
+	MarkOutboxEventPublished(ctx context.Context, seq int64, at time.Time) error
+	MarkOutboxEventFailed(ctx context.Context, seq int64, nextAttemptAt time.Time, reason string) error
+}
+
+// Transactor runs a unit of work in a single transaction. It is satisfied by
+// repositories.TxManager.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// RelayConfig tunes the outbox relay.
+type RelayConfig struct {
+	PollInterval   time.Duration // Wait between polls when the outbox is drained
+	BatchSize      int           // Events claimed at a time
+	PublishTimeout time.Duration // Deadline for a single publish
+	Lease          time.Duration // How long claimed events are reserved for this relay
+	MinBackoff     time.Duration // Delay before the first retry, doubled per attempt
+	MaxBackoff     time.Duration // Upper bound for the retry delay
+}
+
+// DefaultRelayConfig returns the settings used when none are configured.
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		PollInterval:   time.Second,
+		BatchSize:      100,
+		PublishTimeout: 10 * time.Second,
+		Lease:          5 * time.Minute,
+		MinBackoff:     time.Second,
+		MaxBackoff:     10 * time.Minute,
+	}
+}
+
+// Relay moves events from the outbox to a Publisher. Events are marked as
+// published only after the publisher accepted them, so delivery is at least
+// once; failed events are retried with exponential backoff and never dropped.
+// Several relays can run against the same outbox: claimed rows are leased and
+// skipped by the others. Events are published outside the claiming
+// transaction, so a slow publisher holds no row locks or connections.
+type Relay struct {
+	outbox    Outbox
+	tx        Transactor
+	publisher Publisher
+	clock     clock.Clock
+	config    RelayConfig
+	logger    *log.Logger
+}
+
+// NewRelay creates a new Relay.
+func NewRelay(outbox Outbox, tx Transactor, publisher Publisher, clk clock.Clock, config RelayConfig, logger *log.Logger) *Relay {
+	return &Relay{outbox: outbox, tx: tx, publisher: publisher, clock: clk, config: config, logger: logger}
+}
+
+// Run relays events until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		n, err := r.RelayOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Printf("outbox relay: %v", err)
+		}
+		// Keep going without waiting while there is a backlog
+		if err == nil && n == r.config.BatchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(r.config.PollInterval)
+		}
+	}
+}
+
+// RelayOnce publishes one batch of due events and returns how many were
+// claimed. A publish failure is recorded on the event and is not an error.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	now := r.clock.Now()
+	leaseUntil := now.Add(r.config.Lease)
+	var messages []domain.OutboxMessage
+	err := r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		messages, err = r.outbox.ClaimDueOutboxEvents(ctx, now, leaseUntil, r.config.BatchSize)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, m := range messages {
+		// Past the lease another relay may claim the event; leave the rest to it
+		if r.clock.Now().Add(r.config.PublishTimeout).After(leaseUntil) {
+			break
+		}
+		if err := r.publish(ctx, m); err != nil {
+			return len(messages), err
+		}
+	}
+	return len(messages), nil
+}
+
+// publish delivers a single message and records the outcome.
+func (r *Relay) publish(ctx context.Context, m domain.OutboxMessage) error {
+	pubCtx, cancel := context.WithTimeout(ctx, r.config.PublishTimeout)
+	err := r.publisher.Publish(pubCtx, m.Event)
+	cancel()
+
+	now := r.clock.Now()
+	if err == nil {
+		return r.outbox.MarkOutboxEventPublished(ctx, m.Seq, now)
+	}
+	r.logger.Printf("outbox relay: publishing %s %s (attempt %d) failed: %v", m.Event.Type, m.Event.ID, m.Attempts+1, err)
+	return r.outbox.MarkOutboxEventFailed(ctx, m.Seq, now.Add(r.backoff(m.Attempts)), err.Error())
+}
+
+// backoff returns the delay before retrying an event that has already failed
+// attempts times.
+func (r *Relay) backoff(attempts int) time.Duration {
+	return Backoff(r.config.MinBackoff, r.config.MaxBackoff, attempts)
+}
+
+// Backoff returns min doubled once per previous failed attempt, capped at max.
+func Backoff(min, max time.Duration, attempts int) time.Duration {
+	d := min
+	for i := 0; i < attempts && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
@@ -0,0 +1,149 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type stubPublisher struct {
+	errs      map[string]error // Keyed by event ID
+	published []string
+	tx        *trackingTx
+	onPublish func()
+}
+
+func (p *stubPublisher) Publish(ctx context.Context, event domain.DomainEvent) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("publish called without a deadline")
+	}
+	if p.tx != nil && p.tx.active {
+		return errors.New("publish called inside the claiming transaction")
+	}
+	if p.onPublish != nil {
+		p.onPublish()
+	}
+	if err := p.errs[event.ID]; err != nil {
+		return err
+	}
+	p.published = append(p.published, event.ID)
+	return nil
+}
+
+// trackingTx runs the unit of work inline and records whether it is running.
+type trackingTx struct {
+	mocks.InlineTxManager
+	active bool
+}
+
+func (m *trackingTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.active = true
+	defer func() { m.active = false }()
+	return m.InlineTxManager.WithinTx(ctx, fn)
+}
+
+func newTestRelay(outbox *mocks.MockOutboxRepository, publisher Publisher, clk clock.Clock) (*Relay, *trackingTx) {
+	tx := new(trackingTx)
+	if p, ok := publisher.(*stubPublisher); ok {
+		p.tx = tx
+	}
+	return NewRelay(outbox, tx, publisher, clk, DefaultRelayConfig(), log.New(io.Discard, "", 0)), tx
+}
+
+func TestRelay_RelayOnce_PublishesAndRetries(t *testing.T) {
+	outbox := new(mocks.MockOutboxRepository)
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	publisher := &stubPublisher{errs: map[string]error{"e2": errors.New("broker unavailable")}}
+	relay, tx := newTestRelay(outbox, publisher, clk)
+
+	outbox.On("ClaimDueOutboxEvents", mock.Anything, clk.Now(), clk.Now().Add(5*time.Minute), 100).Return([]domain.OutboxMessage{
+		{Seq: 1, Event: domain.DomainEvent{ID: "e1", Type: domain.EventUserCreated}},
+		{Seq: 2, Event: domain.DomainEvent{ID: "e2", Type: domain.EventUserUpdated}, Attempts: 3},
+		{Seq: 3, Event: domain.DomainEvent{ID: "e3", Type: domain.EventUserDeleted}},
+	}, nil).Once()
+	outbox.On("MarkOutboxEventPublished", mock.Anything, int64(1), clk.Now()).Return(nil).Once()
+	// Fourth attempt: 1s doubled three times
+	outbox.On("MarkOutboxEventFailed", mock.Anything, int64(2), clk.Now().Add(8*time.Second), "broker unavailable").Return(nil).Once()
+	outbox.On("MarkOutboxEventPublished", mock.Anything, int64(3), clk.Now()).Return(nil).Once()
+
+	n, err := relay.RelayOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	// Only the claim runs in a transaction
+	assert.Equal(t, 1, tx.Calls)
+	// A failed event does not hold back the rest of the batch
+	assert.Equal(t, []string{"e1", "e3"}, publisher.published)
+	outbox.AssertExpectations(t)
+}
+
+func TestRelay_RelayOnce_StopsAtLeaseEnd(t *testing.T) {
+	outbox := new(mocks.MockOutboxRepository)
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	// Each publish takes long enough that the lease runs out after the first
+	publisher := &stubPublisher{onPublish: func() { clk.Advance(4*time.Minute + 55*time.Second) }}
+	relay, _ := newTestRelay(outbox, publisher, clk)
+
+	outbox.On("ClaimDueOutboxEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]domain.OutboxMessage{
+		{Seq: 1, Event: domain.DomainEvent{ID: "e1"}},
+		{Seq: 2, Event: domain.DomainEvent{ID: "e2"}},
+	}, nil).Once()
+	outbox.On("MarkOutboxEventPublished", mock.Anything, int64(1), mock.Anything).Return(nil).Once()
+
+	n, err := relay.RelayOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	// e2 is left for the relay that claims it once the lease has run out
+	assert.Equal(t, []string{"e1"}, publisher.published)
+	outbox.AssertExpectations(t)
+}
+
+func TestRelay_RelayOnce_ClaimError(t *testing.T) {
+	outbox := new(mocks.MockOutboxRepository)
+	relay, _ := newTestRelay(outbox, &stubPublisher{}, clock.Real())
+	claimErr := errors.New("deadlock")
+	outbox.On("ClaimDueOutboxEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, claimErr).Once()
+
+	_, err := relay.RelayOnce(context.Background())
+
+	assert.ErrorIs(t, err, claimErr)
+}
+
+func TestRelay_Backoff(t *testing.T) {
+	relay, _ := newTestRelay(new(mocks.MockOutboxRepository), &stubPublisher{}, clock.Real())
+
+	assert.Equal(t, time.Second, relay.backoff(0))
+	assert.Equal(t, 2*time.Second, relay.backoff(1))
+	assert.Equal(t, 64*time.Second, relay.backoff(6))
+	assert.Equal(t, 10*time.Minute, relay.backoff(20))
+	assert.Equal(t, 10*time.Minute, relay.backoff(1000))
+}
+
+func TestRelay_Run_StopsOnCancel(t *testing.T) {
+	outbox := new(mocks.MockOutboxRepository)
+	relay, _ := newTestRelay(outbox, &stubPublisher{}, clock.Real())
+	ctx, cancel := context.WithCancel(context.Background())
+	outbox.On("ClaimDueOutboxEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(mock.Arguments) { cancel() }).Return([]domain.OutboxMessage{}, nil)
+
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
@@ -0,0 +1,123 @@
+// Package export writes tables as CSV, NDJSON or XLSX one row at a time, so
+// that an export of any size is written with a constant amount of memory.
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Supported formats.
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXLSX   = "xlsx"
+)
+
+var contentTypes = map[string]string{
+	FormatCSV:    "text/csv; charset=utf-8",
+	FormatNDJSON: "application/x-ndjson",
+	FormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ContentType returns the media type of format, or "" when it is not supported.
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// Writer writes the rows of a table with a fixed set of columns.
+type Writer interface {
+	// WriteRow writes one row. values are in column order.
+	WriteRow(values []string) error
+	// Close writes whatever the format needs after the last row and flushes.
+	// It does not close the underlying writer.
+	Close() error
+}
+
+// NewWriter returns a Writer of format to w. CSV and XLSX output starts with a
+// header row of column names; NDJSON rows are objects keyed by column name.
+func NewWriter(format string, w io.Writer, columns []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw}, nil
+	case FormatNDJSON:
+		keys := make([][]byte, len(columns))
+		for i, c := range columns {
+			k, err := json.Marshal(c)
+			if err != nil {
+				return nil, err
+			}
+			keys[i] = k
+		}
+		return &ndjsonWriter{w: bufio.NewWriter(w), keys: keys}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w, columns)
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+type csvWriter struct {
+	w   *csv.Writer
+	row []string // Reused for the escaped values
+}
+
+func (c *csvWriter) WriteRow(values []string) error {
+	c.row = c.row[:0]
+	for _, v := range values {
+		c.row = append(c.row, escapeFormula(v))
+	}
+	return c.w.Write(c.row)
+}
+
+// escapeFormula prefixes a value that spreadsheets would evaluate as a formula
+// with a quote, so that opening an export cannot run a user's name or email.
+func escapeFormula(v string) string {
+	if v == "" {
+		return v
+	}
+	switch v[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + v
+	}
+	return v
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type ndjsonWriter struct {
+	w    *bufio.Writer
+	keys [][]byte // JSON-encoded column names
+}
+
+func (n *ndjsonWriter) WriteRow(values []string) error {
+	n.w.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			n.w.WriteByte(',')
+		}
+		n.w.Write(n.keys[i])
+		n.w.WriteByte(':')
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		n.w.Write(b)
+	}
+	n.w.WriteByte('}')
+	// bufio.Writer keeps the first write error and returns it from every later call
+	return n.w.WriteByte('\n')
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.w.Flush()
+}
@@ -0,0 +1,84 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTable(t *testing.T, format string, rows ...[]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf, []string{"name", "email"})
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, w.WriteRow(row))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestWriter_CSV(t *testing.T) {
+	out := writeTable(t, FormatCSV, []string{"Alice", "alice@example.com"}, []string{"Bob, Jr.", "bob@example.com"})
+
+	assert.Equal(t, "name,email\nAlice,alice@example.com\n\"Bob, Jr.\",bob@example.com\n", string(out))
+}
+
+func TestWriter_CSV_EscapesFormulas(t *testing.T) {
+	out := writeTable(t, FormatCSV,
+		[]string{"=HYPERLINK(\"http://evil.example\")", "+1@example.com"},
+		[]string{"-2+3", "@SUM(A1)"},
+		[]string{"\tTab", "\rReturn"},
+		[]string{"Alice", "a=b@example.com"},
+	)
+
+	assert.Equal(t, "name,email\n"+
+		"\"'=HYPERLINK(\"\"http://evil.example\"\")\",'+1@example.com\n"+
+		"'-2+3,'@SUM(A1)\n"+
+		"'\tTab,\"'\rReturn\"\n"+
+		"Alice,a=b@example.com\n", string(out))
+}
+
+func TestWriter_NDJSON(t *testing.T) {
+	out := writeTable(t, FormatNDJSON, []string{"Alice", "alice@example.com"}, []string{`山田 "太郎"`, ""})
+
+	assert.Equal(t, `{"name":"Alice","email":"alice@example.com"}`+"\n"+`{"name":"山田 \"太郎\"","email":""}`+"\n", string(out))
+}
+
+func TestWriter_XLSX(t *testing.T) {
+	out := writeTable(t, FormatXLSX, []string{"=1+1", "a<b>&c@example.com"}, []string{" padded ", "x\x00y"})
+
+	zr, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	require.NoError(t, err)
+	var names []string
+	var sheet []byte
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			sheet, err = io.ReadAll(rc)
+			require.NoError(t, err)
+			rc.Close()
+		}
+	}
+	assert.ElementsMatch(t, []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"}, names)
+
+	s := string(sheet)
+	assert.Contains(t, s, `<row><c t="inlineStr"><is><t>name</t></is></c><c t="inlineStr"><is><t>email</t></is></c></row>`)
+	assert.Contains(t, s, `<t>=1+1</t>`, "values are stored as strings, never formulas")
+	assert.Contains(t, s, `<t>a&lt;b&gt;&amp;c@example.com</t>`)
+	assert.Contains(t, s, `<t xml:space="preserve"> padded </t>`)
+	assert.Contains(t, s, "<t>x\uFFFDy</t>")
+	assert.True(t, bytes.HasSuffix(sheet, []byte("</sheetData></worksheet>")))
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	_, err := NewWriter("pdf", io.Discard, []string{"name"})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// maxXLSXRows is the number of rows a worksheet can hold, header included.
+const maxXLSXRows = 1 << 20
+
+// ErrTooManyRows is returned when a table does not fit in one worksheet.
+var ErrTooManyRows = errors.New("export: too many rows for an XLSX worksheet")
+
+// xlsxParts are the fixed parts of a workbook with a single worksheet.
+var xlsxParts = []struct{ name, body string }{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// xlsxWriter streams a workbook. The fixed parts are written first and the
+// worksheet last, so rows go straight into the compressed zip entry. Every
+// cell is an inline string, which keeps values from being read as formulas.
+type xlsxWriter struct {
+	zip  *zip.Writer
+	w    *bufio.Writer // The worksheet entry
+	rows int
+}
+
+func newXLSXWriter(w io.Writer, columns []string) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return nil, err
+		}
+	}
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	x := &xlsxWriter{zip: zw, w: bufio.NewWriter(f)}
+	x.w.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	if err := x.WriteRow(columns); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+func (x *xlsxWriter) WriteRow(values []string) error {
+	if x.rows == maxXLSXRows {
+		return ErrTooManyRows
+	}
+	x.rows++
+	x.w.WriteString("<row>")
+	for _, v := range values {
+		x.w.WriteString(`<c t="inlineStr"><is><t`)
+		if strings.TrimSpace(v) != v {
+			x.w.WriteString(` xml:space="preserve"`)
+		}
+		x.w.WriteByte('>')
+		// EscapeText also replaces characters XML cannot represent
+		if err := xml.EscapeText(x.w, []byte(v)); err != nil {
+			return err
+		}
+		x.w.WriteString("</t></is></c>")
+	}
+	_, err := x.w.WriteString("</row>")
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	x.w.WriteString("</sheetData></worksheet>")
+	if err := x.w.Flush(); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
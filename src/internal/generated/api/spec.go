@@ -6,12 +6,245 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for ApiKeyScope.
+const (
+	UsersAdmin ApiKeyScope = "users:admin"
+	UsersRead  ApiKeyScope = "users:read"
+	UsersWrite ApiKeyScope = "users:write"
+)
+
+// Defines values for GetUsersExportParamsFormat.
+const (
+	GetUsersExportParamsFormatCsv    GetUsersExportParamsFormat = "csv"
+	GetUsersExportParamsFormatNdjson GetUsersExportParamsFormat = "ndjson"
+	GetUsersExportParamsFormatXlsx   GetUsersExportParamsFormat = "xlsx"
+)
+
+// Defines values for InvitationStatus.
+const (
+	Accepted InvitationStatus = "accepted"
+	Expired  InvitationStatus = "expired"
+	Pending  InvitationStatus = "pending"
+	Revoked  InvitationStatus = "revoked"
+)
+
+// Defines values for JobRunStatus.
+const (
+	JobRunStatusFailed    JobRunStatus = "failed"
+	JobRunStatusRunning   JobRunStatus = "running"
+	JobRunStatusSucceeded JobRunStatus = "succeeded"
+)
+
+// Defines values for NotificationDigest.
+const (
+	Daily  NotificationDigest = "daily"
+	Off    NotificationDigest = "off"
+	Weekly NotificationDigest = "weekly"
+)
+
+// Defines values for OrganizationRole.
+const (
+	Admin  OrganizationRole = "admin"
+	Member OrganizationRole = "member"
+	Owner  OrganizationRole = "owner"
+)
+
+// Defines values for UserAttributeType.
+const (
+	Boolean UserAttributeType = "boolean"
+	Date    UserAttributeType = "date"
+	Integer UserAttributeType = "integer"
+	Number  UserAttributeType = "number"
+	String  UserAttributeType = "string"
+)
+
+// Defines values for UserBatchMode.
+const (
+	Atomic     UserBatchMode = "atomic"
+	BestEffort UserBatchMode = "best_effort"
+)
+
+// Defines values for UserBatchOp.
+const (
+	Create UserBatchOp = "create"
+	Delete UserBatchOp = "delete"
+	Patch  UserBatchOp = "patch"
+)
+
+// Defines values for UserImportFormat.
+const (
+	UserImportFormatCsv    UserImportFormat = "csv"
+	UserImportFormatNdjson UserImportFormat = "ndjson"
+)
+
+// Defines values for UserImportStatus.
+const (
+	UserImportStatusCompleted UserImportStatus = "completed"
+	UserImportStatusFailed    UserImportStatus = "failed"
+	UserImportStatusPending   UserImportStatus = "pending"
+	UserImportStatusRunning   UserImportStatus = "running"
+)
+
+// Defines values for UserSearchMode.
+const (
+	EmailPrefix UserSearchMode = "email_prefix"
+	Fulltext    UserSearchMode = "fulltext"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "dead"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+)
+
+// Defines values for WebhookEventType.
+const (
+	UserCreated WebhookEventType = "user.created"
+	UserDeleted WebhookEventType = "user.deleted"
+	UserUpdated WebhookEventType = "user.updated"
+)
+
+// ApiKey defines model for api_key.
+type ApiKey struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt 有効期限。未設定の場合は無期限
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Id APIキーのID
+	Id openapi_types.UUID `json:"id"`
+
+	// LastUsedAt 最後に使用された日時(分単位)
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	// Name APIキーの名前
+	Name string `json:"name"`
+
+	// Prefix キーの先頭部分。どのキーかを識別するために表示されます。
+	Prefix string `json:"prefix"`
+
+	// RevokedAt 失効日時
+	RevokedAt *time.Time    `json:"revoked_at,omitempty"`
+	Scopes    []ApiKeyScope `json:"scopes"`
+
+	// UserId APIキーが代理するユーザーのID
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+// ApiKeyInfo defines model for api_key_info.
+type ApiKeyInfo struct {
+	// ExpiresAt 有効期限
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Name APIキーの名前(用途の説明)
+	Name   string        `json:"name"`
+	Scopes []ApiKeyScope `json:"scopes"`
+
+	// UserId APIキーを発行するユーザーのID。省略時はログイン中のユーザー。他のユーザーへの発行は管理者のみ可能です。
+	UserId *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// ApiKeyScope APIキーのスコープ。
+// users:read はユーザーの参照、users:write はユーザーの作成・更新・削除、users:admin は管理者操作を許可します。
+type ApiKeyScope string
+
+// AuditEvent defines model for audit_event.
+type AuditEvent struct {
+	// Action 操作の種類(例 user.created, user.updated, user.deleted)
+	Action string `json:"action"`
+
+	// ActorId 操作したユーザーのID。匿名の場合は省略されます。
+	ActorId *string        `json:"actor_id,omitempty"`
+	Changes *[]FieldChange `json:"changes,omitempty"`
+
+	// Id 監査イベントのID
+	Id int64 `json:"id"`
+
+	// Ip 操作元のIPアドレス
+	Ip         *string            `json:"ip,omitempty"`
+	Metadata   *map[string]string `json:"metadata,omitempty"`
+	OccurredAt time.Time          `json:"occurred_at"`
+
+	// RequestId リクエストID(X-Request-ID)
+	RequestId *string `json:"request_id,omitempty"`
+
+	// TargetId 操作対象のユーザーのID
+	TargetId *string `json:"target_id,omitempty"`
+}
+
+// AuditEventList defines model for audit_event_list.
+type AuditEventList struct {
+	Events []AuditEvent `json:"events"`
+
+	// NextCursor 次のページを取得する際に cursor に指定する値。最後のページでは省略されます。
+	NextCursor *int64 `json:"next_cursor,omitempty"`
+}
+
+// CreatedApiKey defines model for created_api_key.
+type CreatedApiKey struct {
+	ApiKey ApiKey `json:"api_key"`
+
+	// Key APIキー本体。この応答でのみ表示されます。
+	Key string `json:"key"`
+}
+
+// CreatedOauthClient defines model for created_oauth_client.
+type CreatedOauthClient struct {
+	Client OauthClient `json:"client"`
+
+	// ClientSecret クライアントシークレット。この応答でのみ返されます。公開クライアントでは省略されます。
+	ClientSecret *string `json:"client_secret,omitempty"`
+}
+
+// CreatedWebhook defines model for created_webhook.
+type CreatedWebhook struct {
+	// Secret 署名用のシークレット。この応答でのみ表示されます。
+	// 配信には "X-Webhook-Signature: v1=<署名>" ヘッダーが付与されます。署名は "<X-Webhook-Timestamp>.<リクエストボディ>" をこのシークレットで HMAC-SHA256 した値の16進表記です。
+	Secret  string  `json:"secret"`
+	Webhook Webhook `json:"webhook"`
+}
+
+// ErasureReceipt 個人データを消去した記録。個人データは含みません
+type ErasureReceipt struct {
+	// AuditEventsScrubbed 内容を削除した監査ログの件数
+	AuditEventsScrubbed int       `json:"audit_events_scrubbed"`
+	ErasedAt            time.Time `json:"erased_at"`
+
+	// EventsScrubbed 名前とメールアドレスを削除したイベントの件数
+	EventsScrubbed int                `json:"events_scrubbed"`
+	Id             openapi_types.UUID `json:"id"`
+
+	// ImportReportsScrubbed メールアドレスを削除したユーザー一括インポートの結果の件数
+	ImportReportsScrubbed int `json:"import_reports_scrubbed"`
+
+	// InvitationsDeleted 削除した未承諾の招待の件数
+	InvitationsDeleted int `json:"invitations_deleted"`
+
+	// Reason 消去の理由
+	Reason string `json:"reason"`
+
+	// RequestedBy 消去を実行したユーザーのID
+	RequestedBy *openapi_types.UUID `json:"requested_by,omitempty"`
+
+	// UserId 消去されたユーザーのID
+	UserId openapi_types.UUID `json:"user_id"`
+
+	// WebhookDeliveriesScrubbed 名前とメールアドレスを削除したWebhook配信の件数
+	WebhookDeliveriesScrubbed int `json:"webhook_deliveries_scrubbed"`
+}
+
 // Error defines model for error.
 type Error struct {
 	// Code エラーコード
@@ -30,179 +263,2765 @@ type Error struct {
 	Message string `json:"message"`
 }
 
-// User defines model for user.
-type User struct {
-	// Name ユーザーの名前
+// FieldChange defines model for field_change.
+type FieldChange struct {
+	// Field 変更されたフィールド名
+	Field string `json:"field"`
+
+	// New 変更後の値。パスワード、名前、カスタム属性は *** に、メールアドレスは一部がマスクされます。
+	New *string `json:"new,omitempty"`
+
+	// Old 変更前の値。パスワード、名前、カスタム属性は *** に、メールアドレスは一部がマスクされます。
+	Old *string `json:"old,omitempty"`
+}
+
+// Group defines model for group.
+type Group struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Description グループの説明
+	Description string `json:"description"`
+
+	// Id グループのID
+	Id openapi_types.UUID `json:"id"`
+
+	// Name グループの名前
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GroupInfo defines model for group_info.
+type GroupInfo struct {
+	// Description グループの説明
+	Description *string `json:"description,omitempty"`
+
+	// Name グループの名前。他のグループと重複できません。
 	Name string `json:"name"`
 }
 
-// UserInfo defines model for user_info.
-type UserInfo struct {
+// GroupMemberInfo defines model for group_member_info.
+type GroupMemberInfo struct {
+	// UserId グループに追加するユーザーのID
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+// GroupPatch 変更する項目のみ指定します。
+type GroupPatch struct {
+	// Description グループの説明
+	Description *string `json:"description,omitempty"`
+
+	// Name グループの名前。他のグループと重複できません。
+	Name *string `json:"name,omitempty"`
+}
+
+// Invitation defines model for invitation.
+type Invitation struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Email 招待したメールアドレス
 	Email openapi_types.Email `json:"email"`
-	Name  string              `json:"name"`
+
+	// ExpiresAt 招待リンクの有効期限
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Id 招待のID
+	Id openapi_types.UUID `json:"id"`
+
+	// InvitedBy 招待したユーザーのID。ユーザーが削除された場合は含まれません
+	InvitedBy *openapi_types.UUID `json:"invited_by,omitempty"`
+
+	// OrganizationId 招待先の組織のID
+	OrganizationId openapi_types.UUID `json:"organization_id"`
+
+	// Role 組織内のロール。
+	// owner は組織のすべての操作と owner の付与ができます。admin はユーザーを管理できます。member は閲覧のみできます。
+	Role OrganizationRole `json:"role"`
+
+	// Status 招待の状態。
+	// pending は承諾待ち、accepted は承諾済み、revoked は取り消し済み、expired は承諾されないまま有効期限が切れた招待です。
+	Status InvitationStatus `json:"status"`
 }
 
-// BadRequest defines model for BadRequest.
-type BadRequest struct {
-	// Code エラーコード
-	Code string `json:"code"`
+// InvitationAccept defines model for invitation_accept.
+type InvitationAccept struct {
+	// Name 作成するユーザーの名前。アカウントを持っていない場合は必須です
+	Name *string `json:"name,omitempty"`
 
-	// Details エラーの詳細情報
-	Details *[]struct {
-		// Field エラーが発生したフィールド
-		Field *string `json:"field,omitempty"`
+	// Password 作成するユーザーのパスワード。アカウントを持っていない場合は必須です。データ侵害で流出したことが知られているパスワードは使用できません
+	Password *string `json:"password,omitempty"`
 
-		// Message フィールドに関するエラーメッセージ
-		Message *string `json:"message,omitempty"`
-	} `json:"details,omitempty"`
+	// Token 招待メールのリンクに含まれるトークン
+	Token string `json:"token"`
+}
 
-	// Message エラーメッセージ
-	Message string `json:"message"`
+// InvitationInfo defines model for invitation_info.
+type InvitationInfo struct {
+	// Email 招待するメールアドレス
+	Email openapi_types.Email `json:"email"`
+
+	// OrganizationId 招待先の組織のID
+	OrganizationId openapi_types.UUID `json:"organization_id"`
+
+	// Role 組織内のロール。
+	// owner は組織のすべての操作と owner の付与ができます。admin はユーザーを管理できます。member は閲覧のみできます。
+	Role *OrganizationRole `json:"role,omitempty"`
 }
 
-// Forbidden defines model for Forbidden.
-type Forbidden struct {
-	// Code エラーコード
-	Code string `json:"code"`
+// InvitationStatus 招待の状態。
+// pending は承諾待ち、accepted は承諾済み、revoked は取り消し済み、expired は承諾されないまま有効期限が切れた招待です。
+type InvitationStatus string
 
-	// Details エラーの詳細情報
-	Details *[]struct {
-		// Field エラーが発生したフィールド
-		Field *string `json:"field,omitempty"`
+// Job defines model for job.
+type Job struct {
+	// LastRun ジョブの最新の実行。一度も実行されていない場合は省略されます
+	LastRun *JobRun `json:"last_run,omitempty"`
 
-		// Message フィールドに関するエラーメッセージ
-		Message *string `json:"message,omitempty"`
-	} `json:"details,omitempty"`
+	// Name ジョブ名
+	Name string `json:"name"`
 
-	// Message エラーメッセージ
-	Message string `json:"message"`
+	// NextRunAt 次回の実行予定日時。スケジュールが一致しない場合は省略されます
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+
+	// Schedule 実行スケジュールを表す cron 式(UTC)
+	Schedule string `json:"schedule"`
 }
 
-// InternalServerError defines model for InternalServerError.
-type InternalServerError struct {
-	// Code エラーコード
-	Code string `json:"code"`
+// JobRun ジョブの最新の実行。一度も実行されていない場合は省略されます
+type JobRun struct {
+	// Error 失敗した理由
+	Error      *string            `json:"error,omitempty"`
+	FinishedAt *time.Time         `json:"finished_at,omitempty"`
+	Id         openapi_types.UUID `json:"id"`
 
-	// Details エラーの詳細情報
-	Details *[]struct {
-		// Field エラーが発生したフィールド
-		Field *string `json:"field,omitempty"`
+	// Instance 実行したサーバーのホスト名
+	Instance string `json:"instance"`
 
-		// Message フィールドに関するエラーメッセージ
-		Message *string `json:"message,omitempty"`
-	} `json:"details,omitempty"`
+	// Result 削除した件数など、ジョブが報告した結果
+	Result *map[string]int64 `json:"result,omitempty"`
 
-	// Message エラーメッセージ
-	Message string `json:"message"`
+	// ScheduledAt スケジュール上の実行日時
+	ScheduledAt time.Time `json:"scheduled_at"`
+	StartedAt   time.Time `json:"started_at"`
+
+	// Status 実行状態。
+	// running は実行中、succeeded は成功、failed は失敗を表します。実行中にサーバーが停止した場合は running のまま残ります。
+	Status JobRunStatus `json:"status"`
 }
 
-// NotFound defines model for NotFound.
-type NotFound struct {
-	// Code エラーコード
+// JobRunStatus 実行状態。
+// running は実行中、succeeded は成功、failed は失敗を表します。実行中にサーバーが停止した場合は running のまま残ります。
+type JobRunStatus string
+
+// Jwk IDトークンの検証に使用する公開鍵(RFC 7517)
+type Jwk struct {
+	Alg *string `json:"alg,omitempty"`
+	Crv *string `json:"crv,omitempty"`
+	E   *string `json:"e,omitempty"`
+
+	// Kid 鍵のID。IDトークンのヘッダーの kid と一致します
+	Kid string `json:"kid"`
+
+	// Kty 鍵の種類(RSA または EC)
+	Kty string  `json:"kty"`
+	N   *string `json:"n,omitempty"`
+	Use *string `json:"use,omitempty"`
+	X   *string `json:"x,omitempty"`
+	Y   *string `json:"y,omitempty"`
+}
+
+// JwkSet defines model for jwk_set.
+type JwkSet struct {
+	// Keys 署名に使用中の鍵、次に署名に使われる鍵と、ローテーション後の猶予期間中の鍵
+	Keys []Jwk `json:"keys"`
+}
+
+// LoginInfo defines model for login_info.
+type LoginInfo struct {
+	Email    openapi_types.Email `json:"email"`
+	Password string              `json:"password"`
+}
+
+// LoginResult ログイン結果。二要素認証が有効なユーザーの場合は mfa_required が true となり、
+// アクセストークンの代わりに mfa_token が返されます。mfa_token と認証コードを POST /v1/login/mfa に送信してログインを完了してください。
+type LoginResult struct {
+	// AccessToken アクセストークン
+	AccessToken *string `json:"access_token,omitempty"`
+
+	// ExpiresIn アクセストークンの有効期間(秒)
+	ExpiresIn *int `json:"expires_in,omitempty"`
+
+	// MfaRequired 二要素認証が必要かどうか
+	MfaRequired bool `json:"mfa_required"`
+
+	// MfaToken 二要素認証ステップで使用する一時トークン
+	MfaToken *string `json:"mfa_token,omitempty"`
+
+	// TokenType トークンの種別
+	TokenType *string `json:"token_type,omitempty"`
+}
+
+// MfaCode defines model for mfa_code.
+type MfaCode struct {
+	// Code 認証アプリに表示される6桁のコード
 	Code string `json:"code"`
+}
 
-	// Details エラーの詳細情報
-	Details *[]struct {
-		// Field エラーが発生したフィールド
-		Field *string `json:"field,omitempty"`
+// MfaEnrollment defines model for mfa_enrollment.
+type MfaEnrollment struct {
+	// OtpauthUri 認証アプリ登録用の otpauth:// URI
+	OtpauthUri string `json:"otpauth_uri"`
 
-		// Message フィールドに関するエラーメッセージ
-		Message *string `json:"message,omitempty"`
-	} `json:"details,omitempty"`
+	// QrCodePng otpauth_uri を表すQRコード画像(PNG, Base64)
+	QrCodePng []byte `json:"qr_code_png"`
 
-	// Message エラーメッセージ
-	Message string `json:"message"`
+	// Secret TOTPシークレット(Base32)
+	Secret string `json:"secret"`
 }
 
-// ServiceUnavailable defines model for ServiceUnavailable.
-type ServiceUnavailable struct {
-	// Code エラーコード
+// MfaLoginInfo defines model for mfa_login_info.
+type MfaLoginInfo struct {
+	// Code 認証アプリに表示される6桁のコード、またはリカバリーコード
 	Code string `json:"code"`
 
-	// Details エラーの詳細情報
-	Details *[]struct {
-		// Field エラーが発生したフィールド
-		Field *string `json:"field,omitempty"`
+	// MfaToken ログイン時に発行された一時トークン
+	MfaToken string `json:"mfa_token"`
+}
 
-		// Message フィールドに関するエラーメッセージ
-		Message *string `json:"message,omitempty"`
-	} `json:"details,omitempty"`
+// NotificationDigest 通知のダイジェストメールの頻度。off の場合は送信しません。
+type NotificationDigest string
 
-	// Message エラーメッセージ
-	Message string `json:"message"`
+// NotificationPreferences defines model for notification_preferences.
+type NotificationPreferences struct {
+	// Digest 通知のダイジェストメールの頻度。off の場合は送信しません。
+	Digest NotificationDigest `json:"digest"`
+
+	// Email メールで通知を受け取るかどうか
+	Email bool `json:"email"`
 }
 
-// PostUserJSONRequestBody defines body for PostUser for application/json ContentType.
-type PostUserJSONRequestBody = UserInfo
+// NotificationPreferencesPatch 変更する項目のみ指定します。
+type NotificationPreferencesPatch struct {
+	// Digest 通知のダイジェストメールの頻度。off の場合は送信しません。
+	Digest *NotificationDigest `json:"digest,omitempty"`
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// ユーザー登録
-	// (POST /v1/user)
-	PostUser(ctx echo.Context) error
-	// ユーザー一覧取得
-	// (GET /v1/users)
-	GetUsers(ctx echo.Context) error
-	// ユーザー削除
-	// (DELETE /v1/users/{user_id})
-	DeleteUser(ctx echo.Context, userId openapi_types.UUID) error
-	// ユーザー情報更新
-	// (PATCH /v1/users/{user_id})
-	PathUser(ctx echo.Context, userId openapi_types.UUID) error
+	// Email メールで通知を受け取るかどうか
+	Email *bool `json:"email,omitempty"`
 }
 
-// ServerInterfaceWrapper converts echo contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+// OauthAuthorizationRequest 同意画面が受け取った認可リクエストのパラメーターをそのまま指定します。
+type OauthAuthorizationRequest struct {
+	// Approve 同意画面でのユーザーの選択。省略時は同意済みの場合のみ認可します
+	Approve             *bool   `json:"approve,omitempty"`
+	ClientId            string  `json:"client_id"`
+	CodeChallenge       *string `json:"code_challenge,omitempty"`
+	CodeChallengeMethod *string `json:"code_challenge_method,omitempty"`
+	Nonce               *string `json:"nonce,omitempty"`
+	Prompt              *string `json:"prompt,omitempty"`
+	RedirectUri         string  `json:"redirect_uri"`
+	ResponseType        string  `json:"response_type"`
+	Scope               string  `json:"scope"`
+	State               *string `json:"state,omitempty"`
 }
 
-// PostUser converts echo context to params.
-func (w *ServerInterfaceWrapper) PostUser(ctx echo.Context) error {
-	var err error
+// OauthAuthorizationResult defines model for oauth_authorization_result.
+type OauthAuthorizationResult struct {
+	Client *OauthClient `json:"client,omitempty"`
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostUser(ctx)
-	return err
+	// ConsentRequired true の場合は同意画面を表示し、approve を指定して再度リクエストしてください
+	ConsentRequired bool `json:"consent_required"`
+
+	// RedirectTo 認可コードまたはエラーを付与したクライアントのリダイレクトURI。consent_required が false の場合に返されます
+	RedirectTo *string `json:"redirect_to,omitempty"`
+
+	// Scopes 同意を求めるスコープ
+	Scopes *[]string `json:"scopes,omitempty"`
 }
 
-// GetUsers converts echo context to params.
-func (w *ServerInterfaceWrapper) GetUsers(ctx echo.Context) error {
-	var err error
+// OauthClient defines model for oauth_client.
+type OauthClient struct {
+	CreatedAt time.Time `json:"created_at"`
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetUsers(ctx)
-	return err
+	// Id クライアントID。認可リクエストの client_id に指定します
+	Id openapi_types.UUID `json:"id"`
+
+	// Name 同意画面に表示するアプリケーション名
+	Name string `json:"name"`
+
+	// Public シークレットを持たないクライアント。PKCEが必須です
+	Public bool `json:"public"`
+
+	// RedirectUris 登録済みのリダイレクトURI
+	RedirectUris []string `json:"redirect_uris"`
 }
 
-// DeleteUser converts echo context to params.
-func (w *ServerInterfaceWrapper) DeleteUser(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "user_id" -------------
-	var userId openapi_types.UUID
+// OauthClientInfo defines model for oauth_client_info.
+type OauthClientInfo struct {
+	// Name 同意画面に表示するアプリケーション名
+	Name string `json:"name"`
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
-	}
+	// Public SPAやネイティブアプリなどシークレットを保持できないクライアントの場合は true。シークレットは発行されず、PKCEが必須になります
+	Public *bool `json:"public,omitempty"`
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.DeleteUser(ctx, userId)
-	return err
+	// RedirectUris https、またはループバックアドレスの http のURI
+	RedirectUris []string `json:"redirect_uris"`
 }
 
-// PathUser converts echo context to params.
-func (w *ServerInterfaceWrapper) PathUser(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "user_id" -------------
-	var userId openapi_types.UUID
+// OauthConsent defines model for oauth_consent.
+type OauthConsent struct {
+	ClientId   openapi_types.UUID `json:"client_id"`
+	ClientName string             `json:"client_name"`
+	CreatedAt  time.Time          `json:"created_at"`
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
-	}
+	// Scopes 同意済みのスコープ
+	Scopes    []string  `json:"scopes"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PathUser(ctx, userId)
+// OauthError RFC 6749 §5.2 のエラー応答
+type OauthError struct {
+	// Error invalid_request, invalid_client, invalid_grant, unsupported_grant_type など
+	Error            string  `json:"error"`
+	ErrorDescription *string `json:"error_description,omitempty"`
+}
+
+// OauthToken defines model for oauth_token.
+type OauthToken struct {
+	// AccessToken ユーザー情報エンドポイント用のアクセストークン
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn アクセストークンの有効期間(秒)
+	ExpiresIn int `json:"expires_in"`
+
+	// IdToken RS256で署名されたIDトークン
+	IdToken string `json:"id_token"`
+
+	// Scope 付与されたスコープ(空白区切り)
+	Scope string `json:"scope"`
+
+	// TokenType 常に Bearer
+	TokenType string `json:"token_type"`
+}
+
+// OauthTokenRequest defines model for oauth_token_request.
+type OauthTokenRequest struct {
+	// ClientId client_secret_post または公開クライアントの場合に指定します
+	ClientId *string `json:"client_id,omitempty"`
+
+	// ClientSecret client_secret_post の場合に指定します。client_secret_basic の場合は Authorization ヘッダーで送信してください
+	ClientSecret *string `json:"client_secret,omitempty"`
+	Code         *string `json:"code,omitempty"`
+	CodeVerifier *string `json:"code_verifier,omitempty"`
+
+	// GrantType authorization_code のみ対応しています
+	GrantType   string  `json:"grant_type"`
+	RedirectUri *string `json:"redirect_uri,omitempty"`
+}
+
+// OauthUserInfo アクセストークンのスコープに応じたユーザーのクレーム
+type OauthUserInfo struct {
+	// Email email スコープで返されます
+	Email *string `json:"email,omitempty"`
+
+	// Locale profile スコープで返されます
+	Locale *string `json:"locale,omitempty"`
+
+	// Name profile スコープで返されます
+	Name *string `json:"name,omitempty"`
+
+	// Picture profile スコープで返されます
+	Picture *string `json:"picture,omitempty"`
+
+	// Sub ユーザーID
+	Sub string `json:"sub"`
+
+	// UpdatedAt profile スコープで返されます(UNIX時刻)
+	UpdatedAt *int64 `json:"updated_at,omitempty"`
+
+	// Zoneinfo profile スコープで返されます
+	Zoneinfo *string `json:"zoneinfo,omitempty"`
+}
+
+// OpenidConfiguration OpenID Connect Discovery 1.0 のプロバイダーメタデータ
+type OpenidConfiguration struct {
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	IdTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	Issuer                            string   `json:"issuer"`
+	JwksUri                           string   `json:"jwks_uri"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+}
+
+// OrgUserInfo defines model for org_user_info.
+type OrgUserInfo struct {
+	Email    openapi_types.Email `json:"email"`
+	Name     string              `json:"name"`
+	Password string              `json:"password"`
+
+	// Role 組織内のロール。
+	// owner は組織のすべての操作と owner の付与ができます。admin はユーザーを管理できます。member は閲覧のみできます。
+	Role *OrganizationRole `json:"role,omitempty"`
+}
+
+// OrgUserPatch 変更する項目のみ指定します。
+type OrgUserPatch struct {
+	Email    *openapi_types.Email `json:"email,omitempty"`
+	Name     *string              `json:"name,omitempty"`
+	Password *string              `json:"password,omitempty"`
+
+	// Role 組織内のロール。
+	// owner は組織のすべての操作と owner の付与ができます。admin はユーザーを管理できます。member は閲覧のみできます。
+	Role *OrganizationRole `json:"role,omitempty"`
+}
+
+// Organization defines model for organization.
+type Organization struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Id 組織のID
+	Id openapi_types.UUID `json:"id"`
+
+	// Name 組織の名前
+	Name string `json:"name"`
+
+	// Slug URLなどで使用する組織の識別子
+	Slug      string    `json:"slug"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrganizationInfo defines model for organization_info.
+type OrganizationInfo struct {
+	// Name 組織の名前
+	Name string `json:"name"`
+
+	// OwnerId 組織の owner となるユーザーのID。省略した場合は実行したユーザーです。
+	OwnerId *openapi_types.UUID `json:"owner_id,omitempty"`
+
+	// Slug 英小文字・数字・ハイフンからなる組織の識別子。他の組織と重複できません。
+	Slug string `json:"slug"`
+}
+
+// OrganizationMember defines model for organization_member.
+type OrganizationMember struct {
+	Email openapi_types.Email `json:"email"`
+
+	// Id ユーザーのID
+	Id openapi_types.UUID `json:"id"`
+
+	// JoinedAt 組織に参加した日時
+	JoinedAt time.Time `json:"joined_at"`
+
+	// Name ユーザーの名前
+	Name string `json:"name"`
+
+	// Role 組織内のロール。
+	// owner は組織のすべての操作と owner の付与ができます。admin はユーザーを管理できます。member は閲覧のみできます。
+	Role OrganizationRole `json:"role"`
+}
+
+// OrganizationMembership defines model for organization_membership.
+type OrganizationMembership struct {
+	// JoinedAt 組織に参加した日時
+	JoinedAt     time.Time    `json:"joined_at"`
+	Organization Organization `json:"organization"`
+
+	// Role 組織内のロール。
+	// owner は組織のすべての操作と owner の付与ができます。admin はユーザーを管理できます。member は閲覧のみできます。
+	Role OrganizationRole `json:"role"`
+}
+
+// OrganizationRole 組織内のロール。
+// owner は組織のすべての操作と owner の付与ができます。admin はユーザーを管理できます。member は閲覧のみできます。
+type OrganizationRole string
+
+// RecoveryCodes defines model for recovery_codes.
+type RecoveryCodes struct {
+	// RecoveryCodes 使い捨てのリカバリーコード。この応答でのみ表示されます。
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Token defines model for token.
+type Token struct {
+	// AccessToken アクセストークン
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn アクセストークンの有効期間(秒)
+	ExpiresIn int `json:"expires_in"`
+
+	// TokenType トークンの種別
+	TokenType string `json:"token_type"`
+}
+
+// User defines model for user.
+type User struct {
+	// Attributes 管理者が定義したカスタム属性の値。キーは属性定義の key です
+	Attributes *map[string]interface{} `json:"attributes,omitempty"`
+
+	// AvatarThumbnailUrl アバター画像のサムネイル(64px)のURL。未設定の場合は省略されます
+	AvatarThumbnailUrl *string `json:"avatar_thumbnail_url,omitempty"`
+
+	// AvatarUrl アバター画像(256px)のURL。未設定の場合は省略されます
+	AvatarUrl *string `json:"avatar_url,omitempty"`
+
+	// ErasedAt 個人データを消去した日時。消去済みのユーザーの場合のみ含まれます
+	ErasedAt *time.Time `json:"erased_at,omitempty"`
+
+	// Name ユーザーの名前
+	Name string `json:"name"`
+
+	// Preferences ユーザーが自分で選択する設定
+	Preferences UserPreferences `json:"preferences"`
+}
+
+// UserAttributeDefinition 管理者が定義するユーザーのカスタム属性
+type UserAttributeDefinition struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Description 属性の説明
+	Description string `json:"description"`
+
+	// Enum 許可される値(string 型のみ)。省略された場合は任意の値を許可します
+	Enum *[]string `json:"enum,omitempty"`
+
+	// Key 属性のキー。User の attributes のキーになります
+	Key string `json:"key"`
+
+	// Required true の場合、ユーザーの属性を更新する際に値が必須になります
+	Required bool `json:"required"`
+
+	// Type カスタム属性の型。
+	// number は任意の数値、integer は整数、date は YYYY-MM-DD 形式の文字列です。
+	Type      UserAttributeType `json:"type"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// UserAttributeType カスタム属性の型。
+// number は任意の数値、integer は整数、date は YYYY-MM-DD 形式の文字列です。
+type UserAttributeType string
+
+// UserBatchMode 実行モード。
+// atomic はすべての操作を1つのトランザクションで実行し、1つでも失敗した場合はすべて取り消します。best_effort は操作ごとに確定し、失敗した操作があっても残りを実行します。
+type UserBatchMode string
+
+// UserBatchOp 操作の種類
+type UserBatchOp string
+
+// UserBatchOperation defines model for user_batch_operation.
+type UserBatchOperation struct {
+	Email *openapi_types.Email `json:"email,omitempty"`
+
+	// Id 対象ユーザーのID。patch と delete で必須です
+	Id   *openapi_types.UUID `json:"id,omitempty"`
+	Name *string             `json:"name,omitempty"`
+
+	// Op 操作の種類
+	Op UserBatchOp `json:"op"`
+
+	// Password パスワード。データ侵害で流出したことが知られているパスワードは使用できません。パスワードのハッシュに bcrypt を使う設定では72バイトまでです
+	Password *string `json:"password,omitempty"`
+}
+
+// UserBatchRequest defines model for user_batch_request.
+type UserBatchRequest struct {
+	// Mode 実行モード。
+	// atomic はすべての操作を1つのトランザクションで実行し、1つでも失敗した場合はすべて取り消します。best_effort は操作ごとに確定し、失敗した操作があっても残りを実行します。
+	Mode *UserBatchMode `json:"mode,omitempty"`
+
+	// Operations 実行する操作。指定した順に実行されます。1回に送れる数には上限があります(既定では100)
+	Operations []UserBatchOperation `json:"operations"`
+}
+
+// UserBatchResponse defines model for user_batch_response.
+type UserBatchResponse struct {
+	// Failed 失敗した操作の数(取り消された操作を含む)
+	Failed int `json:"failed"`
+
+	// Mode 実行モード。
+	// atomic はすべての操作を1つのトランザクションで実行し、1つでも失敗した場合はすべて取り消します。best_effort は操作ごとに確定し、失敗した操作があっても残りを実行します。
+	Mode UserBatchMode `json:"mode"`
+
+	// Results 操作ごとの結果。operations と同じ順に並びます
+	Results []UserBatchResult `json:"results"`
+
+	// RolledBack atomic モードで操作が失敗し、すべての操作が取り消されたかどうか
+	RolledBack bool `json:"rolled_back"`
+
+	// Succeeded 成功した操作の数
+	Succeeded int `json:"succeeded"`
+}
+
+// UserBatchResult defines model for user_batch_result.
+type UserBatchResult struct {
+	// Error 失敗した理由
+	Error *string `json:"error,omitempty"`
+
+	// Id 作成・更新・削除したユーザーのID
+	Id *openapi_types.UUID `json:"id,omitempty"`
+
+	// Index operations 内の位置(0始まり)
+	Index int `json:"index"`
+
+	// Op 操作の種類
+	Op UserBatchOp `json:"op"`
+
+	// Status 操作の結果を表すHTTPステータスコード。atomic モードで取り消された、または実行されなかった操作は 424 です
+	Status int   `json:"status"`
+	User   *User `json:"user,omitempty"`
+}
+
+// UserImportFormat アップロードの形式
+type UserImportFormat string
+
+// UserImportJob defines model for user_import_job.
+type UserImportJob struct {
+	// Created 作成したユーザー数
+	Created   int       `json:"created"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Error インポートが途中で中断した理由
+	Error *string `json:"error,omitempty"`
+
+	// Failed 取り込めなかった行数
+	Failed     int        `json:"failed"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	// Format アップロードの形式
+	Format UserImportFormat `json:"format"`
+
+	// Id インポートのID
+	Id openapi_types.UUID `json:"id"`
+
+	// Rows 行ごとの結果。インポートが終了するまでは省略されます
+	Rows *[]UserImportRowResult `json:"rows,omitempty"`
+
+	// Status インポートの状態。
+	// completed はすべての行を処理したこと(失敗した行を含む)、failed は途中で中断したことを表します。中断するまでに作成されたユーザーは残ります。
+	Status UserImportStatus `json:"status"`
+
+	// Total 処理済みの行数
+	Total     int       `json:"total"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserImportReport defines model for user_import_report.
+type UserImportReport struct {
+	// Created 作成したユーザー数
+	Created int `json:"created"`
+
+	// Error インポートが途中で中断した理由。中断するまでに作成されたユーザーは残ります
+	Error *string `json:"error,omitempty"`
+
+	// Failed 取り込めなかった行数
+	Failed int                   `json:"failed"`
+	Rows   []UserImportRowResult `json:"rows"`
+
+	// Total 処理した行数
+	Total int `json:"total"`
+}
+
+// UserImportRowResult defines model for user_import_row_result.
+type UserImportRowResult struct {
+	Email *string `json:"email,omitempty"`
+
+	// Error 行を取り込めなかった理由
+	Error *string `json:"error,omitempty"`
+
+	// Row データ行の番号(1始まり。CSVのヘッダー行と空行は数えません)
+	Row int `json:"row"`
+
+	// UserId 作成されたユーザーのID。失敗した行では省略されます
+	UserId *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// UserImportStatus インポートの状態。
+// completed はすべての行を処理したこと(失敗した行を含む)、failed は途中で中断したことを表します。中断するまでに作成されたユーザーは残ります。
+type UserImportStatus string
+
+// UserSearchHighlights 一致した部分を <em> タグで囲んだ値。それ以外の部分はHTMLエスケープされています。
+// 一致した部分がない項目は省略されます。
+type UserSearchHighlights struct {
+	Email *string `json:"email,omitempty"`
+	Name  *string `json:"name,omitempty"`
+}
+
+// UserSearchHit defines model for user_search_hit.
+type UserSearchHit struct {
+	// Email ユーザーのメールアドレス
+	Email openapi_types.Email `json:"email"`
+
+	// Highlights 一致した部分を <em> タグで囲んだ値。それ以外の部分はHTMLエスケープされています。
+	// 一致した部分がない項目は省略されます。
+	Highlights UserSearchHighlights `json:"highlights"`
+
+	// Id ユーザーのID
+	Id openapi_types.UUID `json:"id"`
+
+	// Relevance 全文検索の関連度。大きいほどよく一致しています。email_prefix では常に0です
+	Relevance float64 `json:"relevance"`
+	User      User    `json:"user"`
+}
+
+// UserSearchMode 検索方法。
+// fulltext は名前とメールアドレスの全文検索(ngram)です。email_prefix はメールアドレスの前方一致で、"@" を含む検索語、短すぎる検索語、全文検索で見つからなかった検索語に使われます。
+type UserSearchMode string
+
+// UserSearchResult defines model for user_search_result.
+type UserSearchResult struct {
+	// Hits 一致したユーザー。よく一致しているものから順に並びます
+	Hits []UserSearchHit `json:"hits"`
+
+	// Mode 検索方法。
+	// fulltext は名前とメールアドレスの全文検索(ngram)です。email_prefix はメールアドレスの前方一致で、"@" を含む検索語、短すぎる検索語、全文検索で見つからなかった検索語に使われます。
+	Mode UserSearchMode `json:"mode"`
+
+	// NextCursor 次のページを取得する際に cursor に指定する値。最後のページでは省略されます。
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// UserInfo defines model for user_info.
+type UserInfo struct {
+	Email openapi_types.Email `json:"email"`
+	Name  string              `json:"name"`
+}
+
+// UserAttributeDefinitionInfo defines model for user_attribute_definition_info.
+type UserAttributeDefinitionInfo struct {
+	// Description 属性の説明
+	Description *string `json:"description,omitempty"`
+
+	// Enum 許可される値(string 型のみ)
+	Enum *[]string `json:"enum,omitempty"`
+
+	// Key 属性のキー。英小文字で始まり、英小文字・数字・アンダースコアのみ使用できます
+	Key string `json:"key"`
+
+	// Required true の場合、ユーザーの属性を更新する際に値が必須になります
+	Required *bool `json:"required,omitempty"`
+
+	// Type カスタム属性の型。
+	// number は任意の数値、integer は整数、date は YYYY-MM-DD 形式の文字列です。
+	Type UserAttributeType `json:"type"`
+}
+
+// UserAttributeDefinitionPatch 変更する項目のみ指定します。型は変更できません。
+type UserAttributeDefinitionPatch struct {
+	// Description 属性の説明
+	Description *string `json:"description,omitempty"`
+
+	// Enum 許可される値(string 型のみ)。空の配列を指定すると制限をなくします
+	Enum *[]string `json:"enum,omitempty"`
+
+	// Required true の場合、ユーザーの属性を更新する際に値が必須になります
+	Required *bool `json:"required,omitempty"`
+}
+
+// UserErasureRequest defines model for user_erasure_request.
+type UserErasureRequest struct {
+	// Reason 消去の理由(削除請求の受付番号など)。個人データを含めないでください
+	Reason *string `json:"reason,omitempty"`
+}
+
+// UserPatch 変更する項目のみ指定します。
+type UserPatch struct {
+	// Attributes 変更するカスタム属性。値は属性定義の型と一致する必要があります。
+	// null を指定した属性は削除されます。指定しなかった属性は変更されません。
+	Attributes *map[string]interface{} `json:"attributes,omitempty"`
+	Email      *openapi_types.Email    `json:"email,omitempty"`
+	Name       *string                 `json:"name,omitempty"`
+
+	// Preferences 変更する項目のみ指定します。
+	Preferences *UserPreferencesPatch `json:"preferences,omitempty"`
+}
+
+// UserPreferences ユーザーが自分で選択する設定
+type UserPreferences struct {
+	// Locale BCP 47 の言語タグ(例 ja-JP)。未設定の場合は省略されます
+	Locale        *string                 `json:"locale,omitempty"`
+	Notifications NotificationPreferences `json:"notifications"`
+
+	// Timezone IANA のタイムゾーン名(例 Asia/Tokyo)。未設定の場合は省略されます
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// UserPreferencesPatch 変更する項目のみ指定します。
+type UserPreferencesPatch struct {
+	// Locale BCP 47 の言語タグ(例 ja-JP)。空文字を指定すると未設定に戻します
+	Locale *string `json:"locale,omitempty"`
+
+	// Notifications 変更する項目のみ指定します。
+	Notifications *NotificationPreferencesPatch `json:"notifications,omitempty"`
+
+	// Timezone IANA のタイムゾーン名(例 Asia/Tokyo)。空文字を指定すると未設定に戻します
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// Webhook defines model for webhook.
+type Webhook struct {
+	// Active 無効の場合は配信されません
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Description Webhookの説明
+	Description string             `json:"description"`
+	EventTypes  []WebhookEventType `json:"event_types"`
+
+	// Id WebhookのID
+	Id        openapi_types.UUID `json:"id"`
+	UpdatedAt time.Time          `json:"updated_at"`
+
+	// Url 配信先のURL
+	Url string `json:"url"`
+}
+
+// WebhookDelivery defines model for webhook_delivery.
+type WebhookDelivery struct {
+	// Attempts 配信を試みた回数
+	Attempts    int        `json:"attempts"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+
+	// EventId イベントのID。再試行しても変わりません(X-Webhook-Id ヘッダー)
+	EventId string `json:"event_id"`
+
+	// EventType Webhookで購読できるイベントの種類
+	EventType WebhookEventType `json:"event_type"`
+
+	// Id 配信のID
+	Id            int64      `json:"id"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+
+	// LastError 最後の配信が失敗した理由
+	LastError *string `json:"last_error,omitempty"`
+
+	// LastStatusCode 最後の配信で受け取ったHTTPステータスコード
+	LastStatusCode *int `json:"last_status_code,omitempty"`
+
+	// NextAttemptAt 次に配信を試みる日時
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+
+	// Status 配信状態。
+	// pending は配信待ち(再試行待ちを含む)、succeeded は配信済み、dead は再試行の上限に達したことを表します。
+	Status    WebhookDeliveryStatus `json:"status"`
+	WebhookId openapi_types.UUID    `json:"webhook_id"`
+}
+
+// WebhookDeliveryList defines model for webhook_delivery_list.
+type WebhookDeliveryList struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+
+	// NextCursor 次のページを取得する際に cursor に指定する値。最後のページでは省略されます。
+	NextCursor *int64 `json:"next_cursor,omitempty"`
+}
+
+// WebhookDeliveryStatus 配信状態。
+// pending は配信待ち(再試行待ちを含む)、succeeded は配信済み、dead は再試行の上限に達したことを表します。
+type WebhookDeliveryStatus string
+
+// WebhookEventType Webhookで購読できるイベントの種類
+type WebhookEventType string
+
+// WebhookInfo defines model for webhook_info.
+type WebhookInfo struct {
+	// Description Webhookの説明
+	Description *string            `json:"description,omitempty"`
+	EventTypes  []WebhookEventType `json:"event_types"`
+
+	// Secret 署名用のシークレット。省略時は生成されます。
+	Secret *string `json:"secret,omitempty"`
+
+	// Url 配信先のURL(http または https)
+	Url string `json:"url"`
+}
+
+// WebhookPatch 変更する項目のみ指定します。
+type WebhookPatch struct {
+	// Active false にすると配信を停止します
+	Active *bool `json:"active,omitempty"`
+
+	// Description Webhookの説明
+	Description *string             `json:"description,omitempty"`
+	EventTypes  *[]WebhookEventType `json:"event_types,omitempty"`
+
+	// Secret 新しい署名用のシークレット
+	Secret *string `json:"secret,omitempty"`
+
+	// Url 配信先のURL(http または https)
+	Url *string `json:"url,omitempty"`
+}
+
+// BadRequest defines model for BadRequest.
+type BadRequest struct {
+	// Code エラーコード
+	Code string `json:"code"`
+
+	// Details エラーの詳細情報
+	Details *[]struct {
+		// Field エラーが発生したフィールド
+		Field *string `json:"field,omitempty"`
+
+		// Message フィールドに関するエラーメッセージ
+		Message *string `json:"message,omitempty"`
+	} `json:"details,omitempty"`
+
+	// Message エラーメッセージ
+	Message string `json:"message"`
+}
+
+// Conflict defines model for Conflict.
+type Conflict struct {
+	// Code エラーコード
+	Code string `json:"code"`
+
+	// Details エラーの詳細情報
+	Details *[]struct {
+		// Field エラーが発生したフィールド
+		Field *string `json:"field,omitempty"`
+
+		// Message フィールドに関するエラーメッセージ
+		Message *string `json:"message,omitempty"`
+	} `json:"details,omitempty"`
+
+	// Message エラーメッセージ
+	Message string `json:"message"`
+}
+
+// Forbidden defines model for Forbidden.
+type Forbidden struct {
+	// Code エラーコード
+	Code string `json:"code"`
+
+	// Details エラーの詳細情報
+	Details *[]struct {
+		// Field エラーが発生したフィールド
+		Field *string `json:"field,omitempty"`
+
+		// Message フィールドに関するエラーメッセージ
+		Message *string `json:"message,omitempty"`
+	} `json:"details,omitempty"`
+
+	// Message エラーメッセージ
+	Message string `json:"message"`
+}
+
+// Gone defines model for Gone.
+type Gone struct {
+	// Code エラーコード
+	Code string `json:"code"`
+
+	// Details エラーの詳細情報
+	Details *[]struct {
+		// Field エラーが発生したフィールド
+		Field *string `json:"field,omitempty"`
+
+		// Message フィールドに関するエラーメッセージ
+		Message *string `json:"message,omitempty"`
+	} `json:"details,omitempty"`
+
+	// Message エラーメッセージ
+	Message string `json:"message"`
+}
+
+// InternalServerError defines model for InternalServerError.
+type InternalServerError struct {
+	// Code エラーコード
+	Code string `json:"code"`
+
+	// Details エラーの詳細情報
+	Details *[]struct {
+		// Field エラーが発生したフィールド
+		Field *string `json:"field,omitempty"`
+
+		// Message フィールドに関するエラーメッセージ
+		Message *string `json:"message,omitempty"`
+	} `json:"details,omitempty"`
+
+	// Message エラーメッセージ
+	Message string `json:"message"`
+}
+
+// NotFound defines model for NotFound.
+type NotFound struct {
+	// Code エラーコード
+	Code string `json:"code"`
+
+	// Details エラーの詳細情報
+	Details *[]struct {
+		// Field エラーが発生したフィールド
+		Field *string `json:"field,omitempty"`
+
+		// Message フィールドに関するエラーメッセージ
+		Message *string `json:"message,omitempty"`
+	} `json:"details,omitempty"`
+
+	// Message エラーメッセージ
+	Message string `json:"message"`
+}
+
+// ServiceUnavailable defines model for ServiceUnavailable.
+type ServiceUnavailable struct {
+	// Code エラーコード
+	Code string `json:"code"`
+
+	// Details エラーの詳細情報
+	Details *[]struct {
+		// Field エラーが発生したフィールド
+		Field *string `json:"field,omitempty"`
+
+		// Message フィールドに関するエラーメッセージ
+		Message *string `json:"message,omitempty"`
+	} `json:"details,omitempty"`
+
+	// Message エラーメッセージ
+	Message string `json:"message"`
+}
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests struct {
+	// Code エラーコード
+	Code string `json:"code"`
+
+	// Details エラーの詳細情報
+	Details *[]struct {
+		// Field エラーが発生したフィールド
+		Field *string `json:"field,omitempty"`
+
+		// Message フィールドに関するエラーメッセージ
+		Message *string `json:"message,omitempty"`
+	} `json:"details,omitempty"`
+
+	// Message エラーメッセージ
+	Message string `json:"message"`
+}
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized struct {
+	// Code エラーコード
+	Code string `json:"code"`
+
+	// Details エラーの詳細情報
+	Details *[]struct {
+		// Field エラーが発生したフィールド
+		Field *string `json:"field,omitempty"`
+
+		// Message フィールドに関するエラーメッセージ
+		Message *string `json:"message,omitempty"`
+	} `json:"details,omitempty"`
+
+	// Message エラーメッセージ
+	Message string `json:"message"`
+}
+
+// GetApiKeysParams defines parameters for GetApiKeys.
+type GetApiKeysParams struct {
+	// UserId 対象ユーザーのID。省略時はログイン中のユーザー
+	UserId *openapi_types.UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+}
+
+// GetAuditEventsParams defines parameters for GetAuditEvents.
+type GetAuditEventsParams struct {
+	// ActorId 操作したユーザーのID
+	ActorId *openapi_types.UUID `form:"actor_id,omitempty" json:"actor_id,omitempty"`
+
+	// TargetId 操作対象のユーザーのID
+	TargetId *openapi_types.UUID `form:"target_id,omitempty" json:"target_id,omitempty"`
+
+	// Action 操作の種類
+	Action *string `form:"action,omitempty" json:"action,omitempty"`
+
+	// From この日時以降のイベントを取得します
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To この日時より前のイベントを取得します
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit 1ページあたりの件数
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor 前のページの next_cursor
+	Cursor *int64 `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetLoginOidcCallbackParams defines parameters for GetLoginOidcCallback.
+type GetLoginOidcCallbackParams struct {
+	// Code プロバイダーが発行した認可コード
+	Code *string `form:"code,omitempty" json:"code,omitempty"`
+
+	// State ログイン開始時に発行された state
+	State *string `form:"state,omitempty" json:"state,omitempty"`
+
+	// Error プロバイダーがログインを拒否した場合のエラーコード
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
+}
+
+// GetOauthAuthorizeParams defines parameters for GetOauthAuthorize.
+type GetOauthAuthorizeParams struct {
+	// ResponseType code のみ対応しています
+	ResponseType *string `form:"response_type,omitempty" json:"response_type,omitempty"`
+
+	// ClientId クライアントID
+	ClientId *string `form:"client_id,omitempty" json:"client_id,omitempty"`
+
+	// RedirectUri 登録済みのリダイレクトURI
+	RedirectUri *string `form:"redirect_uri,omitempty" json:"redirect_uri,omitempty"`
+
+	// Scope 空白区切りのスコープ。openid が必須です
+	Scope *string `form:"scope,omitempty" json:"scope,omitempty"`
+
+	// State クライアントが指定する値。リダイレクト時にそのまま返されます
+	State *string `form:"state,omitempty" json:"state,omitempty"`
+
+	// Nonce IDトークンに含める値
+	Nonce *string `form:"nonce,omitempty" json:"nonce,omitempty"`
+
+	// CodeChallenge PKCEのコードチャレンジ。公開クライアントでは必須です
+	CodeChallenge *string `form:"code_challenge,omitempty" json:"code_challenge,omitempty"`
+
+	// CodeChallengeMethod S256 のみ対応しています
+	CodeChallengeMethod *string `form:"code_challenge_method,omitempty" json:"code_challenge_method,omitempty"`
+
+	// Prompt none または consent
+	Prompt *string `form:"prompt,omitempty" json:"prompt,omitempty"`
+}
+
+// GetUsersParams defines parameters for GetUsers.
+type GetUsersParams struct {
+	// Role この権限(user または admin)のユーザーのみ取得します
+	Role *string `form:"role,omitempty" json:"role,omitempty"`
+
+	// CreatedFrom この日時以降に登録されたユーザーのみ取得します
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo この日時より前に登録されたユーザーのみ取得します
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// GroupId このグループに所属するユーザーのみ取得します
+	GroupId *openapi_types.UUID `form:"group_id,omitempty" json:"group_id,omitempty"`
+}
+
+// GetUserEventsParams defines parameters for GetUserEvents.
+type GetUserEventsParams struct {
+	// Type 配信するイベントの種類(user.created, user.updated, user.deleted)。省略時はすべて
+	Type *[]string `form:"type,omitempty" json:"type,omitempty"`
+
+	// LastEventID 最後に受信したイベントの id
+	LastEventID *int64 `json:"Last-Event-ID,omitempty"`
+}
+
+// GetUsersSearchParams defines parameters for GetUsersSearch.
+type GetUsersSearchParams struct {
+	// Q 検索語。空白で区切った語のいずれかに一致するユーザーを返します
+	Q string `form:"q" json:"q"`
+
+	// Limit 1ページあたりの件数
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor 前のページの next_cursor。同じ検索語で指定してください
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetUsersExportParams defines parameters for GetUsersExport.
+type GetUsersExportParams struct {
+	// Format 出力形式。指定した場合は Accept ヘッダーより優先されます
+	Format *GetUsersExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Columns 出力する列をカンマ区切りで指定します(id, name, email, role, created_at, updated_at)。
+	// 省略した場合はすべての列を出力します。
+	Columns *[]string `form:"columns,omitempty" json:"columns,omitempty"`
+
+	// Role この権限(user または admin)のユーザーのみ取得します
+	Role *string `form:"role,omitempty" json:"role,omitempty"`
+
+	// CreatedFrom この日時以降に登録されたユーザーのみ取得します
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo この日時より前に登録されたユーザーのみ取得します
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+}
+
+// GetUsersExportParamsFormat defines parameters for GetUsersExport.
+type GetUsersExportParamsFormat string
+
+// PostUsersImportParams defines parameters for PostUsersImport.
+type PostUsersImportParams struct {
+	// Async バックグラウンドで登録する
+	Async *bool `form:"async,omitempty" json:"async,omitempty"`
+}
+
+// PutUserAvatarMultipartBody defines parameters for PutUserAvatar.
+type PutUserAvatarMultipartBody struct {
+	// File 画像ファイル
+	File openapi_types.File `json:"file"`
+}
+
+// GetWebhookDeliveriesParams defines parameters for GetWebhookDeliveries.
+type GetWebhookDeliveriesParams struct {
+	// Limit 1ページあたりの件数
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor 前のページの next_cursor
+	Cursor *int64 `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// PostApiKeyJSONRequestBody defines body for PostApiKey for application/json ContentType.
+type PostApiKeyJSONRequestBody = ApiKeyInfo
+
+// PostGroupJSONRequestBody defines body for PostGroup for application/json ContentType.
+type PostGroupJSONRequestBody = GroupInfo
+
+// PatchGroupJSONRequestBody defines body for PatchGroup for application/json ContentType.
+type PatchGroupJSONRequestBody = GroupPatch
+
+// PostGroupMemberJSONRequestBody defines body for PostGroupMember for application/json ContentType.
+type PostGroupMemberJSONRequestBody = GroupMemberInfo
+
+// PostInvitationJSONRequestBody defines body for PostInvitation for application/json ContentType.
+type PostInvitationJSONRequestBody = InvitationInfo
+
+// PostInvitationAcceptJSONRequestBody defines body for PostInvitationAccept for application/json ContentType.
+type PostInvitationAcceptJSONRequestBody = InvitationAccept
+
+// PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
+type PostLoginJSONRequestBody = LoginInfo
+
+// PostLoginMfaJSONRequestBody defines body for PostLoginMfa for application/json ContentType.
+type PostLoginMfaJSONRequestBody = MfaLoginInfo
+
+// PostMfaConfirmJSONRequestBody defines body for PostMfaConfirm for application/json ContentType.
+type PostMfaConfirmJSONRequestBody = MfaCode
+
+// PostOauthAuthorizeJSONRequestBody defines body for PostOauthAuthorize for application/json ContentType.
+type PostOauthAuthorizeJSONRequestBody = OauthAuthorizationRequest
+
+// PostOauthClientJSONRequestBody defines body for PostOauthClient for application/json ContentType.
+type PostOauthClientJSONRequestBody = OauthClientInfo
+
+// PostOauthTokenFormdataRequestBody defines body for PostOauthToken for application/x-www-form-urlencoded ContentType.
+type PostOauthTokenFormdataRequestBody = OauthTokenRequest
+
+// PostOrgUserJSONRequestBody defines body for PostOrgUser for application/json ContentType.
+type PostOrgUserJSONRequestBody = OrgUserInfo
+
+// PatchOrgUserJSONRequestBody defines body for PatchOrgUser for application/json ContentType.
+type PatchOrgUserJSONRequestBody = OrgUserPatch
+
+// PostOrganizationJSONRequestBody defines body for PostOrganization for application/json ContentType.
+type PostOrganizationJSONRequestBody = OrganizationInfo
+
+// PostUserJSONRequestBody defines body for PostUser for application/json ContentType.
+type PostUserJSONRequestBody = UserInfo
+
+// PostUserAttributeJSONRequestBody defines body for PostUserAttribute for application/json ContentType.
+type PostUserAttributeJSONRequestBody = UserAttributeDefinitionInfo
+
+// PatchUserAttributeJSONRequestBody defines body for PatchUserAttribute for application/json ContentType.
+type PatchUserAttributeJSONRequestBody = UserAttributeDefinitionPatch
+
+// PostUsersBatchJSONRequestBody defines body for PostUsersBatch for application/json ContentType.
+type PostUsersBatchJSONRequestBody = UserBatchRequest
+
+// PathUserJSONRequestBody defines body for PathUser for application/json ContentType.
+type PathUserJSONRequestBody = UserPatch
+
+// PutUserAvatarMultipartRequestBody defines body for PutUserAvatar for multipart/form-data ContentType.
+type PutUserAvatarMultipartRequestBody PutUserAvatarMultipartBody
+
+// PostUserErasureJSONRequestBody defines body for PostUserErasure for application/json ContentType.
+type PostUserErasureJSONRequestBody = UserErasureRequest
+
+// PostWebhookJSONRequestBody defines body for PostWebhook for application/json ContentType.
+type PostWebhookJSONRequestBody = WebhookInfo
+
+// PatchWebhookJSONRequestBody defines body for PatchWebhook for application/json ContentType.
+type PatchWebhookJSONRequestBody = WebhookPatch
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// IDトークン検証用の公開鍵
+	// (GET /.well-known/jwks.json)
+	GetJwks(ctx echo.Context) error
+	// OpenID Connect ディスカバリー
+	// (GET /.well-known/openid-configuration)
+	GetOpenidConfiguration(ctx echo.Context) error
+	// APIキー一覧取得
+	// (GET /v1/api-keys)
+	GetApiKeys(ctx echo.Context, params GetApiKeysParams) error
+	// APIキー発行
+	// (POST /v1/api-keys)
+	PostApiKey(ctx echo.Context) error
+	// APIキー失効
+	// (DELETE /v1/api-keys/{api_key_id})
+	DeleteApiKey(ctx echo.Context, apiKeyId openapi_types.UUID) error
+	// 監査ログ取得
+	// (GET /v1/audit-events)
+	GetAuditEvents(ctx echo.Context, params GetAuditEventsParams) error
+	// グループ一覧取得
+	// (GET /v1/groups)
+	GetGroups(ctx echo.Context) error
+	// グループ作成
+	// (POST /v1/groups)
+	PostGroup(ctx echo.Context) error
+	// グループ削除
+	// (DELETE /v1/groups/{group_id})
+	DeleteGroup(ctx echo.Context, groupId openapi_types.UUID) error
+	// グループ取得
+	// (GET /v1/groups/{group_id})
+	GetGroup(ctx echo.Context, groupId openapi_types.UUID) error
+	// グループ更新
+	// (PATCH /v1/groups/{group_id})
+	PatchGroup(ctx echo.Context, groupId openapi_types.UUID) error
+	// グループのユーザー一覧取得
+	// (GET /v1/groups/{group_id}/members)
+	GetGroupMembers(ctx echo.Context, groupId openapi_types.UUID) error
+	// グループへのユーザー追加
+	// (POST /v1/groups/{group_id}/members)
+	PostGroupMember(ctx echo.Context, groupId openapi_types.UUID) error
+	// グループからのユーザー削除
+	// (DELETE /v1/groups/{group_id}/members/{user_id})
+	DeleteGroupMember(ctx echo.Context, groupId openapi_types.UUID, userId openapi_types.UUID) error
+	// 招待作成
+	// (POST /v1/invitations)
+	PostInvitation(ctx echo.Context) error
+	// 招待承諾
+	// (POST /v1/invitations/accept)
+	PostInvitationAccept(ctx echo.Context) error
+	// 招待取り消し
+	// (DELETE /v1/invitations/{invitation_id})
+	DeleteInvitation(ctx echo.Context, invitationId openapi_types.UUID) error
+	// 招待再送
+	// (POST /v1/invitations/{invitation_id}/resend)
+	PostInvitationResend(ctx echo.Context, invitationId openapi_types.UUID) error
+	// 定期ジョブ一覧取得
+	// (GET /v1/jobs)
+	GetJobs(ctx echo.Context) error
+	// ログイン
+	// (POST /v1/login)
+	PostLogin(ctx echo.Context) error
+	// 二要素認証ログイン
+	// (POST /v1/login/mfa)
+	PostLoginMfa(ctx echo.Context) error
+	// 外部IDプロバイダーでのログイン開始
+	// (GET /v1/login/oidc/{provider})
+	GetLoginOidc(ctx echo.Context, provider string) error
+	// 外部IDプロバイダーでのログイン完了
+	// (GET /v1/login/oidc/{provider}/callback)
+	GetLoginOidcCallback(ctx echo.Context, provider string, params GetLoginOidcCallbackParams) error
+	// 二要素認証の登録確認
+	// (POST /v1/mfa/confirm)
+	PostMfaConfirm(ctx echo.Context) error
+	// 二要素認証の登録開始
+	// (POST /v1/mfa/enroll)
+	PostMfaEnroll(ctx echo.Context) error
+	// 認可リクエスト
+	// (GET /v1/oauth/authorize)
+	GetOauthAuthorize(ctx echo.Context, params GetOauthAuthorizeParams) error
+	// 認可の承認
+	// (POST /v1/oauth/authorize)
+	PostOauthAuthorize(ctx echo.Context) error
+	// OAuthクライアント一覧取得
+	// (GET /v1/oauth/clients)
+	GetOauthClients(ctx echo.Context) error
+	// OAuthクライアント登録
+	// (POST /v1/oauth/clients)
+	PostOauthClient(ctx echo.Context) error
+	// OAuthクライアント削除
+	// (DELETE /v1/oauth/clients/{client_id})
+	DeleteOauthClient(ctx echo.Context, clientId openapi_types.UUID) error
+	// OAuthクライアント取得
+	// (GET /v1/oauth/clients/{client_id})
+	GetOauthClient(ctx echo.Context, clientId openapi_types.UUID) error
+	// 同意済みアプリケーション一覧
+	// (GET /v1/oauth/consents)
+	GetOauthConsents(ctx echo.Context) error
+	// 同意の取り消し
+	// (DELETE /v1/oauth/consents/{client_id})
+	DeleteOauthConsent(ctx echo.Context, clientId openapi_types.UUID) error
+	// トークン発行
+	// (POST /v1/oauth/token)
+	PostOauthToken(ctx echo.Context) error
+	// ユーザー情報
+	// (GET /v1/oauth/userinfo)
+	GetOauthUserinfo(ctx echo.Context) error
+	// 組織のユーザー一覧取得
+	// (GET /v1/org/users)
+	GetOrgUsers(ctx echo.Context) error
+	// 組織のユーザー登録
+	// (POST /v1/org/users)
+	PostOrgUser(ctx echo.Context) error
+	// 組織からのユーザー削除
+	// (DELETE /v1/org/users/{user_id})
+	DeleteOrgUser(ctx echo.Context, userId openapi_types.UUID) error
+	// 組織のユーザー情報更新
+	// (PATCH /v1/org/users/{user_id})
+	PatchOrgUser(ctx echo.Context, userId openapi_types.UUID) error
+	// 所属組織一覧取得
+	// (GET /v1/organizations)
+	GetOrganizations(ctx echo.Context) error
+	// 組織作成
+	// (POST /v1/organizations)
+	PostOrganization(ctx echo.Context) error
+	// 組織用トークン発行
+	// (POST /v1/organizations/{organization_id}/token)
+	PostOrganizationToken(ctx echo.Context, organizationId openapi_types.UUID) error
+	// ユーザー登録
+	// (POST /v1/user)
+	PostUser(ctx echo.Context) error
+	// カスタム属性定義一覧取得
+	// (GET /v1/user-attributes)
+	GetUserAttributes(ctx echo.Context) error
+	// カスタム属性定義作成
+	// (POST /v1/user-attributes)
+	PostUserAttribute(ctx echo.Context) error
+	// カスタム属性定義削除
+	// (DELETE /v1/user-attributes/{attribute_key})
+	DeleteUserAttribute(ctx echo.Context, attributeKey string) error
+	// カスタム属性定義更新
+	// (PATCH /v1/user-attributes/{attribute_key})
+	PatchUserAttribute(ctx echo.Context, attributeKey string) error
+	// ユーザー一覧取得
+	// (GET /v1/users)
+	GetUsers(ctx echo.Context, params GetUsersParams) error
+	// ユーザー変更イベントの購読
+	// (GET /v1/users/events)
+	GetUserEvents(ctx echo.Context, params GetUserEventsParams) error
+	// ユーザー一括登録の状態取得
+	// (GET /v1/users/imports/{import_id})
+	GetUserImport(ctx echo.Context, importId openapi_types.UUID) error
+	// ユーザー検索
+	// (GET /v1/users/search)
+	GetUsersSearch(ctx echo.Context, params GetUsersSearchParams) error
+	// ユーザー削除
+	// (DELETE /v1/users/{user_id})
+	DeleteUser(ctx echo.Context, userId openapi_types.UUID) error
+	// ユーザー情報更新
+	// (PATCH /v1/users/{user_id})
+	PathUser(ctx echo.Context, userId openapi_types.UUID) error
+	// アバター画像のアップロード
+	// (PUT /v1/users/{user_id}/avatar)
+	PutUserAvatar(ctx echo.Context, userId openapi_types.UUID) error
+	// ユーザーの個人データのエクスポート
+	// (GET /v1/users/{user_id}/data-export)
+	GetUserDataExport(ctx echo.Context, userId openapi_types.UUID) error
+	// 個人データ消去の記録取得
+	// (GET /v1/users/{user_id}/erasure)
+	GetUserErasure(ctx echo.Context, userId openapi_types.UUID) error
+	// ユーザーの個人データの消去
+	// (POST /v1/users/{user_id}/erasure)
+	PostUserErasure(ctx echo.Context, userId openapi_types.UUID) error
+	// ユーザーの所属グループ一覧取得
+	// (GET /v1/users/{user_id}/groups)
+	GetUserGroups(ctx echo.Context, userId openapi_types.UUID) error
+	// ユーザーのロック解除
+	// (POST /v1/users/{user_id}/unlock)
+	PostUserUnlock(ctx echo.Context, userId openapi_types.UUID) error
+	// ユーザー一括操作
+	// (POST /v1/users:batch)
+	PostUsersBatch(ctx echo.Context) error
+	// ユーザーエクスポート
+	// (GET /v1/users:export)
+	GetUsersExport(ctx echo.Context, params GetUsersExportParams) error
+	// ユーザー一括登録
+	// (POST /v1/users:import)
+	PostUsersImport(ctx echo.Context, params PostUsersImportParams) error
+	// Webhook一覧取得
+	// (GET /v1/webhooks)
+	GetWebhooks(ctx echo.Context) error
+	// Webhook登録
+	// (POST /v1/webhooks)
+	PostWebhook(ctx echo.Context) error
+	// Webhook削除
+	// (DELETE /v1/webhooks/{webhook_id})
+	DeleteWebhook(ctx echo.Context, webhookId openapi_types.UUID) error
+	// Webhook取得
+	// (GET /v1/webhooks/{webhook_id})
+	GetWebhook(ctx echo.Context, webhookId openapi_types.UUID) error
+	// Webhook更新
+	// (PATCH /v1/webhooks/{webhook_id})
+	PatchWebhook(ctx echo.Context, webhookId openapi_types.UUID) error
+	// Webhook配信履歴取得
+	// (GET /v1/webhooks/{webhook_id}/deliveries)
+	GetWebhookDeliveries(ctx echo.Context, webhookId openapi_types.UUID, params GetWebhookDeliveriesParams) error
+	// Webhook再送
+	// (POST /v1/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver)
+	PostWebhookRedeliver(ctx echo.Context, webhookId openapi_types.UUID, deliveryId int64) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetJwks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetJwks(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetJwks(ctx)
+	return err
+}
+
+// GetOpenidConfiguration converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOpenidConfiguration(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOpenidConfiguration(ctx)
+	return err
+}
+
+// GetApiKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) GetApiKeys(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiKeysParams
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetApiKeys(ctx, params)
+	return err
+}
+
+// PostApiKey converts echo context to params.
+func (w *ServerInterfaceWrapper) PostApiKey(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostApiKey(ctx)
+	return err
+}
+
+// DeleteApiKey converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteApiKey(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "api_key_id" -------------
+	var apiKeyId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "api_key_id", runtime.ParamLocationPath, ctx.Param("api_key_id"), &apiKeyId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter api_key_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteApiKey(ctx, apiKeyId)
+	return err
+}
+
+// GetAuditEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAuditEvents(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuditEventsParams
+	// ------------- Optional query parameter "actor_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor_id", ctx.QueryParams(), &params.ActorId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter actor_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "target_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target_id", ctx.QueryParams(), &params.TargetId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter target_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", ctx.QueryParams(), &params.Action)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter action: %s", err))
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetAuditEvents(ctx, params)
+	return err
+}
+
+// GetGroups converts echo context to params.
+func (w *ServerInterfaceWrapper) GetGroups(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetGroups(ctx)
+	return err
+}
+
+// PostGroup converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGroup(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostGroup(ctx)
+	return err
+}
+
+// DeleteGroup converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteGroup(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "group_id" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "group_id", runtime.ParamLocationPath, ctx.Param("group_id"), &groupId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter group_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteGroup(ctx, groupId)
+	return err
+}
+
+// GetGroup converts echo context to params.
+func (w *ServerInterfaceWrapper) GetGroup(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "group_id" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "group_id", runtime.ParamLocationPath, ctx.Param("group_id"), &groupId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter group_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetGroup(ctx, groupId)
+	return err
+}
+
+// PatchGroup converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchGroup(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "group_id" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "group_id", runtime.ParamLocationPath, ctx.Param("group_id"), &groupId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter group_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchGroup(ctx, groupId)
+	return err
+}
+
+// GetGroupMembers converts echo context to params.
+func (w *ServerInterfaceWrapper) GetGroupMembers(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "group_id" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "group_id", runtime.ParamLocationPath, ctx.Param("group_id"), &groupId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter group_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetGroupMembers(ctx, groupId)
+	return err
+}
+
+// PostGroupMember converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGroupMember(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "group_id" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "group_id", runtime.ParamLocationPath, ctx.Param("group_id"), &groupId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter group_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostGroupMember(ctx, groupId)
+	return err
+}
+
+// DeleteGroupMember converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteGroupMember(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "group_id" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "group_id", runtime.ParamLocationPath, ctx.Param("group_id"), &groupId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter group_id: %s", err))
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteGroupMember(ctx, groupId, userId)
+	return err
+}
+
+// PostInvitation converts echo context to params.
+func (w *ServerInterfaceWrapper) PostInvitation(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostInvitation(ctx)
+	return err
+}
+
+// PostInvitationAccept converts echo context to params.
+func (w *ServerInterfaceWrapper) PostInvitationAccept(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostInvitationAccept(ctx)
+	return err
+}
+
+// DeleteInvitation converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteInvitation(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "invitation_id" -------------
+	var invitationId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "invitation_id", runtime.ParamLocationPath, ctx.Param("invitation_id"), &invitationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter invitation_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteInvitation(ctx, invitationId)
+	return err
+}
+
+// PostInvitationResend converts echo context to params.
+func (w *ServerInterfaceWrapper) PostInvitationResend(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "invitation_id" -------------
+	var invitationId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "invitation_id", runtime.ParamLocationPath, ctx.Param("invitation_id"), &invitationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter invitation_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostInvitationResend(ctx, invitationId)
+	return err
+}
+
+// GetJobs converts echo context to params.
+func (w *ServerInterfaceWrapper) GetJobs(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetJobs(ctx)
+	return err
+}
+
+// PostLogin converts echo context to params.
+func (w *ServerInterfaceWrapper) PostLogin(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostLogin(ctx)
+	return err
+}
+
+// PostLoginMfa converts echo context to params.
+func (w *ServerInterfaceWrapper) PostLoginMfa(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostLoginMfa(ctx)
+	return err
+}
+
+// GetLoginOidc converts echo context to params.
+func (w *ServerInterfaceWrapper) GetLoginOidc(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "provider", runtime.ParamLocationPath, ctx.Param("provider"), &provider)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter provider: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetLoginOidc(ctx, provider)
+	return err
+}
+
+// GetLoginOidcCallback converts echo context to params.
+func (w *ServerInterfaceWrapper) GetLoginOidcCallback(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "provider", runtime.ParamLocationPath, ctx.Param("provider"), &provider)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter provider: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLoginOidcCallbackParams
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", ctx.QueryParams(), &params.Code)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter code: %s", err))
+	}
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", ctx.QueryParams(), &params.State)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter state: %s", err))
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", ctx.QueryParams(), &params.Error)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter error: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetLoginOidcCallback(ctx, provider, params)
+	return err
+}
+
+// PostMfaConfirm converts echo context to params.
+func (w *ServerInterfaceWrapper) PostMfaConfirm(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostMfaConfirm(ctx)
+	return err
+}
+
+// PostMfaEnroll converts echo context to params.
+func (w *ServerInterfaceWrapper) PostMfaEnroll(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostMfaEnroll(ctx)
+	return err
+}
+
+// GetOauthAuthorize converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOauthAuthorize(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOauthAuthorizeParams
+	// ------------- Optional query parameter "response_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "response_type", ctx.QueryParams(), &params.ResponseType)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter response_type: %s", err))
+	}
+
+	// ------------- Optional query parameter "client_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "client_id", ctx.QueryParams(), &params.ClientId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter client_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "redirect_uri" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "redirect_uri", ctx.QueryParams(), &params.RedirectUri)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter redirect_uri: %s", err))
+	}
+
+	// ------------- Optional query parameter "scope" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "scope", ctx.QueryParams(), &params.Scope)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter scope: %s", err))
+	}
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", ctx.QueryParams(), &params.State)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter state: %s", err))
+	}
+
+	// ------------- Optional query parameter "nonce" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "nonce", ctx.QueryParams(), &params.Nonce)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter nonce: %s", err))
+	}
+
+	// ------------- Optional query parameter "code_challenge" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code_challenge", ctx.QueryParams(), &params.CodeChallenge)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter code_challenge: %s", err))
+	}
+
+	// ------------- Optional query parameter "code_challenge_method" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code_challenge_method", ctx.QueryParams(), &params.CodeChallengeMethod)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter code_challenge_method: %s", err))
+	}
+
+	// ------------- Optional query parameter "prompt" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prompt", ctx.QueryParams(), &params.Prompt)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter prompt: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOauthAuthorize(ctx, params)
+	return err
+}
+
+// PostOauthAuthorize converts echo context to params.
+func (w *ServerInterfaceWrapper) PostOauthAuthorize(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostOauthAuthorize(ctx)
+	return err
+}
+
+// GetOauthClients converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOauthClients(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOauthClients(ctx)
+	return err
+}
+
+// PostOauthClient converts echo context to params.
+func (w *ServerInterfaceWrapper) PostOauthClient(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostOauthClient(ctx)
+	return err
+}
+
+// DeleteOauthClient converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteOauthClient(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "client_id" -------------
+	var clientId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "client_id", runtime.ParamLocationPath, ctx.Param("client_id"), &clientId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter client_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteOauthClient(ctx, clientId)
+	return err
+}
+
+// GetOauthClient converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOauthClient(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "client_id" -------------
+	var clientId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "client_id", runtime.ParamLocationPath, ctx.Param("client_id"), &clientId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter client_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOauthClient(ctx, clientId)
+	return err
+}
+
+// GetOauthConsents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOauthConsents(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOauthConsents(ctx)
+	return err
+}
+
+// DeleteOauthConsent converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteOauthConsent(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "client_id" -------------
+	var clientId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "client_id", runtime.ParamLocationPath, ctx.Param("client_id"), &clientId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter client_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteOauthConsent(ctx, clientId)
+	return err
+}
+
+// PostOauthToken converts echo context to params.
+func (w *ServerInterfaceWrapper) PostOauthToken(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostOauthToken(ctx)
+	return err
+}
+
+// GetOauthUserinfo converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOauthUserinfo(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOauthUserinfo(ctx)
+	return err
+}
+
+// GetOrgUsers converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOrgUsers(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOrgUsers(ctx)
+	return err
+}
+
+// PostOrgUser converts echo context to params.
+func (w *ServerInterfaceWrapper) PostOrgUser(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostOrgUser(ctx)
+	return err
+}
+
+// DeleteOrgUser converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteOrgUser(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteOrgUser(ctx, userId)
+	return err
+}
+
+// PatchOrgUser converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchOrgUser(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchOrgUser(ctx, userId)
+	return err
+}
+
+// GetOrganizations converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOrganizations(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOrganizations(ctx)
+	return err
+}
+
+// PostOrganization converts echo context to params.
+func (w *ServerInterfaceWrapper) PostOrganization(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostOrganization(ctx)
+	return err
+}
+
+// PostOrganizationToken converts echo context to params.
+func (w *ServerInterfaceWrapper) PostOrganizationToken(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "organization_id" -------------
+	var organizationId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "organization_id", runtime.ParamLocationPath, ctx.Param("organization_id"), &organizationId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter organization_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostOrganizationToken(ctx, organizationId)
+	return err
+}
+
+// PostUser converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUser(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUser(ctx)
+	return err
+}
+
+// GetUserAttributes converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUserAttributes(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUserAttributes(ctx)
+	return err
+}
+
+// PostUserAttribute converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUserAttribute(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUserAttribute(ctx)
+	return err
+}
+
+// DeleteUserAttribute converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteUserAttribute(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "attribute_key" -------------
+	var attributeKey string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "attribute_key", runtime.ParamLocationPath, ctx.Param("attribute_key"), &attributeKey)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter attribute_key: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteUserAttribute(ctx, attributeKey)
+	return err
+}
+
+// PatchUserAttribute converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchUserAttribute(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "attribute_key" -------------
+	var attributeKey string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "attribute_key", runtime.ParamLocationPath, ctx.Param("attribute_key"), &attributeKey)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter attribute_key: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchUserAttribute(ctx, attributeKey)
+	return err
+}
+
+// GetUsers converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsers(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersParams
+	// ------------- Optional query parameter "role" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "role", ctx.QueryParams(), &params.Role)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter role: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", ctx.QueryParams(), &params.CreatedFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_from: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", ctx.QueryParams(), &params.CreatedTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_to: %s", err))
+	}
+
+	// ------------- Optional query parameter "group_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group_id", ctx.QueryParams(), &params.GroupId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter group_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsers(ctx, params)
+	return err
+}
+
+// GetUserEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUserEvents(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserEventsParams
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", ctx.QueryParams(), &params.Type)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter type: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID int64
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Last-Event-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, valueList[0], &LastEventID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Last-Event-ID: %s", err))
+		}
+
+		params.LastEventID = &LastEventID
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUserEvents(ctx, params)
+	return err
+}
+
+// GetUserImport converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUserImport(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "import_id" -------------
+	var importId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "import_id", runtime.ParamLocationPath, ctx.Param("import_id"), &importId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter import_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUserImport(ctx, importId)
+	return err
+}
+
+// GetUsersSearch converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersSearch(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersSearchParams
+	// ------------- Required query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "q", ctx.QueryParams(), &params.Q)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter q: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersSearch(ctx, params)
+	return err
+}
+
+// DeleteUser converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteUser(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteUser(ctx, userId)
+	return err
+}
+
+// PathUser converts echo context to params.
+func (w *ServerInterfaceWrapper) PathUser(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PathUser(ctx, userId)
+	return err
+}
+
+// PutUserAvatar converts echo context to params.
+func (w *ServerInterfaceWrapper) PutUserAvatar(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutUserAvatar(ctx, userId)
+	return err
+}
+
+// GetUserDataExport converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUserDataExport(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUserDataExport(ctx, userId)
+	return err
+}
+
+// GetUserErasure converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUserErasure(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUserErasure(ctx, userId)
+	return err
+}
+
+// PostUserErasure converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUserErasure(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUserErasure(ctx, userId)
+	return err
+}
+
+// GetUserGroups converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUserGroups(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUserGroups(ctx, userId)
+	return err
+}
+
+// PostUserUnlock converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUserUnlock(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, ctx.Param("user_id"), &userId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUserUnlock(ctx, userId)
+	return err
+}
+
+// PostUsersBatch converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUsersBatch(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUsersBatch(ctx)
+	return err
+}
+
+// GetUsersExport converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersExport(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersExportParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// ------------- Optional query parameter "columns" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "columns", ctx.QueryParams(), &params.Columns)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter columns: %s", err))
+	}
+
+	// ------------- Optional query parameter "role" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "role", ctx.QueryParams(), &params.Role)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter role: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", ctx.QueryParams(), &params.CreatedFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_from: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", ctx.QueryParams(), &params.CreatedTo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersExport(ctx, params)
+	return err
+}
+
+// PostUsersImport converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUsersImport(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostUsersImportParams
+	// ------------- Optional query parameter "async" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "async", ctx.QueryParams(), &params.Async)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter async: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUsersImport(ctx, params)
+	return err
+}
+
+// GetWebhooks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhooks(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhooks(ctx)
+	return err
+}
+
+// PostWebhook converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhook(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostWebhook(ctx)
+	return err
+}
+
+// DeleteWebhook converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteWebhook(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "webhook_id", runtime.ParamLocationPath, ctx.Param("webhook_id"), &webhookId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter webhook_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteWebhook(ctx, webhookId)
+	return err
+}
+
+// GetWebhook converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhook(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "webhook_id", runtime.ParamLocationPath, ctx.Param("webhook_id"), &webhookId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter webhook_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhook(ctx, webhookId)
+	return err
+}
+
+// PatchWebhook converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchWebhook(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "webhook_id", runtime.ParamLocationPath, ctx.Param("webhook_id"), &webhookId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter webhook_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchWebhook(ctx, webhookId)
+	return err
+}
+
+// GetWebhookDeliveries converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhookDeliveries(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "webhook_id", runtime.ParamLocationPath, ctx.Param("webhook_id"), &webhookId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter webhook_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhookDeliveriesParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhookDeliveries(ctx, webhookId, params)
+	return err
+}
+
+// PostWebhookRedeliver converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhookRedeliver(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "webhook_id", runtime.ParamLocationPath, ctx.Param("webhook_id"), &webhookId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter webhook_id: %s", err))
+	}
+
+	// ------------- Path parameter "delivery_id" -------------
+	var deliveryId int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "delivery_id", runtime.ParamLocationPath, ctx.Param("delivery_id"), &deliveryId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter delivery_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostWebhookRedeliver(ctx, webhookId, deliveryId)
 	return err
 }
 
@@ -234,9 +3053,74 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/.well-known/jwks.json", wrapper.GetJwks)
+	router.GET(baseURL+"/.well-known/openid-configuration", wrapper.GetOpenidConfiguration)
+	router.GET(baseURL+"/v1/api-keys", wrapper.GetApiKeys)
+	router.POST(baseURL+"/v1/api-keys", wrapper.PostApiKey)
+	router.DELETE(baseURL+"/v1/api-keys/:api_key_id", wrapper.DeleteApiKey)
+	router.GET(baseURL+"/v1/audit-events", wrapper.GetAuditEvents)
+	router.GET(baseURL+"/v1/groups", wrapper.GetGroups)
+	router.POST(baseURL+"/v1/groups", wrapper.PostGroup)
+	router.DELETE(baseURL+"/v1/groups/:group_id", wrapper.DeleteGroup)
+	router.GET(baseURL+"/v1/groups/:group_id", wrapper.GetGroup)
+	router.PATCH(baseURL+"/v1/groups/:group_id", wrapper.PatchGroup)
+	router.GET(baseURL+"/v1/groups/:group_id/members", wrapper.GetGroupMembers)
+	router.POST(baseURL+"/v1/groups/:group_id/members", wrapper.PostGroupMember)
+	router.DELETE(baseURL+"/v1/groups/:group_id/members/:user_id", wrapper.DeleteGroupMember)
+	router.POST(baseURL+"/v1/invitations", wrapper.PostInvitation)
+	router.POST(baseURL+"/v1/invitations/accept", wrapper.PostInvitationAccept)
+	router.DELETE(baseURL+"/v1/invitations/:invitation_id", wrapper.DeleteInvitation)
+	router.POST(baseURL+"/v1/invitations/:invitation_id/resend", wrapper.PostInvitationResend)
+	router.GET(baseURL+"/v1/jobs", wrapper.GetJobs)
+	router.POST(baseURL+"/v1/login", wrapper.PostLogin)
+	router.POST(baseURL+"/v1/login/mfa", wrapper.PostLoginMfa)
+	router.GET(baseURL+"/v1/login/oidc/:provider", wrapper.GetLoginOidc)
+	router.GET(baseURL+"/v1/login/oidc/:provider/callback", wrapper.GetLoginOidcCallback)
+	router.POST(baseURL+"/v1/mfa/confirm", wrapper.PostMfaConfirm)
+	router.POST(baseURL+"/v1/mfa/enroll", wrapper.PostMfaEnroll)
+	router.GET(baseURL+"/v1/oauth/authorize", wrapper.GetOauthAuthorize)
+	router.POST(baseURL+"/v1/oauth/authorize", wrapper.PostOauthAuthorize)
+	router.GET(baseURL+"/v1/oauth/clients", wrapper.GetOauthClients)
+	router.POST(baseURL+"/v1/oauth/clients", wrapper.PostOauthClient)
+	router.DELETE(baseURL+"/v1/oauth/clients/:client_id", wrapper.DeleteOauthClient)
+	router.GET(baseURL+"/v1/oauth/clients/:client_id", wrapper.GetOauthClient)
+	router.GET(baseURL+"/v1/oauth/consents", wrapper.GetOauthConsents)
+	router.DELETE(baseURL+"/v1/oauth/consents/:client_id", wrapper.DeleteOauthConsent)
+	router.POST(baseURL+"/v1/oauth/token", wrapper.PostOauthToken)
+	router.GET(baseURL+"/v1/oauth/userinfo", wrapper.GetOauthUserinfo)
+	router.GET(baseURL+"/v1/org/users", wrapper.GetOrgUsers)
+	router.POST(baseURL+"/v1/org/users", wrapper.PostOrgUser)
+	router.DELETE(baseURL+"/v1/org/users/:user_id", wrapper.DeleteOrgUser)
+	router.PATCH(baseURL+"/v1/org/users/:user_id", wrapper.PatchOrgUser)
+	router.GET(baseURL+"/v1/organizations", wrapper.GetOrganizations)
+	router.POST(baseURL+"/v1/organizations", wrapper.PostOrganization)
+	router.POST(baseURL+"/v1/organizations/:organization_id/token", wrapper.PostOrganizationToken)
 	router.POST(baseURL+"/v1/user", wrapper.PostUser)
+	router.GET(baseURL+"/v1/user-attributes", wrapper.GetUserAttributes)
+	router.POST(baseURL+"/v1/user-attributes", wrapper.PostUserAttribute)
+	router.DELETE(baseURL+"/v1/user-attributes/:attribute_key", wrapper.DeleteUserAttribute)
+	router.PATCH(baseURL+"/v1/user-attributes/:attribute_key", wrapper.PatchUserAttribute)
 	router.GET(baseURL+"/v1/users", wrapper.GetUsers)
+	router.GET(baseURL+"/v1/users/events", wrapper.GetUserEvents)
+	router.GET(baseURL+"/v1/users/imports/:import_id", wrapper.GetUserImport)
+	router.GET(baseURL+"/v1/users/search", wrapper.GetUsersSearch)
 	router.DELETE(baseURL+"/v1/users/:user_id", wrapper.DeleteUser)
 	router.PATCH(baseURL+"/v1/users/:user_id", wrapper.PathUser)
+	router.PUT(baseURL+"/v1/users/:user_id/avatar", wrapper.PutUserAvatar)
+	router.GET(baseURL+"/v1/users/:user_id/data-export", wrapper.GetUserDataExport)
+	router.GET(baseURL+"/v1/users/:user_id/erasure", wrapper.GetUserErasure)
+	router.POST(baseURL+"/v1/users/:user_id/erasure", wrapper.PostUserErasure)
+	router.GET(baseURL+"/v1/users/:user_id/groups", wrapper.GetUserGroups)
+	router.POST(baseURL+"/v1/users/:user_id/unlock", wrapper.PostUserUnlock)
+	router.POST(baseURL+"/v1/users\\:batch", wrapper.PostUsersBatch)
+	router.GET(baseURL+"/v1/users\\:export", wrapper.GetUsersExport)
+	router.POST(baseURL+"/v1/users\\:import", wrapper.PostUsersImport)
+	router.GET(baseURL+"/v1/webhooks", wrapper.GetWebhooks)
+	router.POST(baseURL+"/v1/webhooks", wrapper.PostWebhook)
+	router.DELETE(baseURL+"/v1/webhooks/:webhook_id", wrapper.DeleteWebhook)
+	router.GET(baseURL+"/v1/webhooks/:webhook_id", wrapper.GetWebhook)
+	router.PATCH(baseURL+"/v1/webhooks/:webhook_id", wrapper.PatchWebhook)
+	router.GET(baseURL+"/v1/webhooks/:webhook_id/deliveries", wrapper.GetWebhookDeliveries)
+	router.POST(baseURL+"/v1/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", wrapper.PostWebhookRedeliver)
 
 }
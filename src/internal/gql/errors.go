@@ -0,0 +1,57 @@
+package gql
+
+import (
+	"errors"
+
+	"apiserver/internal/domain"
+)
+
+// Values of the code extension on GraphQL errors.
+const (
+	codeUnauthenticated = "UNAUTHENTICATED"
+	codeForbidden       = "FORBIDDEN"
+	codeNotFound        = "NOT_FOUND"
+	codeBadUserInput    = "BAD_USER_INPUT"
+	codeConflict        = "CONFLICT"
+	codeQueryTooComplex = "QUERY_TOO_COMPLEX"
+	codeInternal        = "INTERNAL_SERVER_ERROR"
+)
+
+// resolverError carries a machine-readable code to the client in the error's
+// extensions, the GraphQL counterpart of an HTTP status.
+type resolverError struct {
+	code    string
+	message string
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// badUserInput reports an argument the resolver rejected before calling a usecase.
+func badUserInput(message string) error {
+	return &resolverError{code: codeBadUserInput, message: message}
+}
+
+// toGraphQLError maps domain errors to coded resolver errors, like
+// toHTTPError does for the HTTP API.
+func toGraphQLError(err error) error {
+	code := codeInternal
+	switch {
+	case errors.Is(err, domain.ErrInvalidCredentials), errors.Is(err, domain.ErrUnauthenticated), errors.Is(err, domain.ErrInvalidMFACode):
+		code = codeUnauthenticated
+	case errors.Is(err, domain.ErrMFARequired), errors.Is(err, domain.ErrForbidden):
+		code = codeForbidden
+	case errors.Is(err, domain.ErrNotFound):
+		code = codeNotFound
+	case errors.Is(err, domain.ErrInvalidArgument):
+		code = codeBadUserInput
+	case errors.Is(err, domain.ErrConflict):
+		code = codeConflict
+	}
+	return &resolverError{code: code, message: err.Error()}
+}
@@ -0,0 +1,104 @@
+package gql
+
+import (
+	"context"
+	"net/http"
+
+	"apiserver/internal/usecases"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/labstack/echo/v4"
+)
+
+// Request is the JSON body of a GraphQL request.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler serves the users schema over HTTP.
+type Handler struct {
+	schema graphql.Schema
+	limits Limits
+}
+
+// NewHandler creates a new Handler resolving through uc.
+func NewHandler(uc usecases.UserInteractor, limits Limits) (*Handler, error) {
+	schema, err := NewSchema(uc)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{schema: schema, limits: limits}, nil
+}
+
+// Execute parses, validates, checks the limits of and executes req. Errors in
+// the request are reported in the result, as GraphQL requires.
+func (h *Handler) Execute(ctx context.Context, req Request) *graphql.Result {
+	src := source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})
+	doc, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if vr := graphql.ValidateDocument(&h.schema, doc, nil); !vr.IsValid {
+		return &graphql.Result{Errors: vr.Errors}
+	}
+	if err := checkLimits(doc, req.OperationName, req.Variables, h.limits); err != nil {
+		formatted := gqlerrors.NewFormattedError(err.Error())
+		formatted.Extensions = map[string]interface{}{"code": codeQueryTooComplex}
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{formatted}}
+	}
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+}
+
+// Serve handles POST /graphql. The response is 200 whenever the request could
+// be read; errors from the operation are in the body's errors array.
+func (h *Handler) Serve(c echo.Context) error {
+	var req Request
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	if req.Query == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "query is required")
+	}
+	return c.JSON(http.StatusOK, h.Execute(c.Request().Context(), req))
+}
+
+// GraphiQL handles GET /graphql with an in-browser IDE. It is only routed when
+// enabled by configuration.
+func (h *Handler) GraphiQL(c echo.Context) error {
+	return c.HTML(http.StatusOK, graphiQLPage)
+}
+
+// graphiQLPage loads GraphiQL from a CDN and points it at this endpoint.
+// Requests carry the browser's cookies; tokens can be set in the headers pane.
+const graphiQLPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>GraphiQL</title>
+  <link rel="stylesheet" href="https://unpkg.com/graphiql@3/graphiql.min.css">
+  <style>body { margin: 0; height: 100vh; } #graphiql { height: 100vh; }</style>
+</head>
+<body>
+  <div id="graphiql">Loading…</div>
+  <script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+  <script>
+    const fetcher = GraphiQL.createFetcher({ url: window.location.pathname });
+    ReactDOM.createRoot(document.getElementById('graphiql')).render(
+      React.createElement(GraphiQL, { fetcher: fetcher, headerEditorEnabled: true })
+    );
+  </script>
+</body>
+</html>
+`
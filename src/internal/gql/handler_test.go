@@ -0,0 +1,205 @@
+package gql
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/usecases/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testUserID = "3f0e9a4c-1b2d-4c5e-8f70-9a1b2c3d4e5f"
+
+type graphQLResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func setupTestEnv(t *testing.T, limits Limits) (*echo.Echo, *mocks.MockUserInteractor) {
+	t.Helper()
+	e := echo.New()
+	mockUsers := new(mocks.MockUserInteractor)
+	h, err := NewHandler(mockUsers, limits)
+	require.NoError(t, err)
+	e.POST("/graphql", h.Serve)
+	return e, mockUsers
+}
+
+func doGraphQL(t *testing.T, e *echo.Echo, query string, variables map[string]interface{}) graphQLResponse {
+	t.Helper()
+	body, err := json.Marshal(Request{Query: query, Variables: variables})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp graphQLResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+func testUser(id string) domain.User {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return domain.User{ID: id, Name: "Alice", Email: "alice@example.com", Role: domain.RoleUser, CreatedAt: now, UpdatedAt: now}
+}
+
+func TestUser_SelectsRequestedFields(t *testing.T) {
+	e, mockUsers := setupTestEnv(t, DefaultLimits())
+	user := testUser(testUserID)
+	mockUsers.On("FindUserByID", mock.Anything, testUserID).Return(&user, nil).Once()
+
+	resp := doGraphQL(t, e, `query($id: ID!) { user(id: $id) { id email createdAt } }`, map[string]interface{}{"id": testUserID})
+
+	assert.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"id":"`+testUserID+`","email":"alice@example.com","createdAt":"2024-01-02T03:04:05Z"}`, string(resp.Data["user"]))
+	mockUsers.AssertExpectations(t)
+}
+
+func TestUser_NotFoundIsNull(t *testing.T) {
+	e, mockUsers := setupTestEnv(t, DefaultLimits())
+	mockUsers.On("FindUserByID", mock.Anything, testUserID).Return(nil, nil).Once()
+
+	resp := doGraphQL(t, e, `{ user(id: "`+testUserID+`") { id } }`, nil)
+
+	assert.Empty(t, resp.Errors)
+	assert.Equal(t, "null", string(resp.Data["user"]))
+}
+
+func TestUser_InvalidID(t *testing.T) {
+	e, mockUsers := setupTestEnv(t, DefaultLimits())
+
+	resp := doGraphQL(t, e, `{ user(id: "nope") { id } }`, nil)
+
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, codeBadUserInput, resp.Errors[0].Extensions["code"])
+	mockUsers.AssertNotCalled(t, "FindUserByID", mock.Anything, mock.Anything)
+}
+
+func TestUsers_Paginates(t *testing.T) {
+	e, mockUsers := setupTestEnv(t, DefaultLimits())
+	secondID := "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	mockUsers.On("ListUsersPage", mock.Anything, "", 2).Return(&domain.UserPage{
+		Users:   []domain.User{testUser(testUserID), testUser(secondID)},
+		HasMore: true,
+	}, nil).Once()
+
+	resp := doGraphQL(t, e, `{ users(first: 2) { edges { cursor node { id } } pageInfo { hasNextPage endCursor } } }`, nil)
+	require.Empty(t, resp.Errors)
+
+	var conn struct {
+		Edges []struct {
+			Cursor string
+			Node   struct{ ID string }
+		}
+		PageInfo struct {
+			HasNextPage bool
+			EndCursor   string
+		}
+	}
+	require.NoError(t, json.Unmarshal(resp.Data["users"], &conn))
+	require.Len(t, conn.Edges, 2)
+	assert.Equal(t, secondID, conn.Edges[1].Node.ID)
+	assert.True(t, conn.PageInfo.HasNextPage)
+	assert.Equal(t, conn.Edges[1].Cursor, conn.PageInfo.EndCursor)
+
+	// The end cursor resumes after the last user
+	mockUsers.On("ListUsersPage", mock.Anything, secondID, 2).Return(&domain.UserPage{}, nil).Once()
+	resp = doGraphQL(t, e, `query($after: String) { users(first: 2, after: $after) { pageInfo { hasNextPage endCursor } } }`, map[string]interface{}{"after": conn.PageInfo.EndCursor})
+	assert.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"pageInfo":{"hasNextPage":false,"endCursor":null}}`, string(resp.Data["users"]))
+	mockUsers.AssertExpectations(t)
+}
+
+func TestUsers_InvalidCursor(t *testing.T) {
+	e, _ := setupTestEnv(t, DefaultLimits())
+
+	resp := doGraphQL(t, e, `{ users(after: "!!") { edges { cursor } } }`, nil)
+
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, codeBadUserInput, resp.Errors[0].Extensions["code"])
+}
+
+func TestCreateUser(t *testing.T) {
+	e, mockUsers := setupTestEnv(t, DefaultLimits())
+	user := testUser(testUserID)
+	mockUsers.On("CreateNewUser", mock.Anything, "Alice", "alice@example.com", "secret123").Return(&user, nil).Once()
+
+	resp := doGraphQL(t, e, `mutation { createUser(input: {name: "Alice", email: "alice@example.com", password: "secret123"}) { id } }`, nil)
+
+	assert.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"id":"`+testUserID+`"}`, string(resp.Data["createUser"]))
+	mockUsers.AssertExpectations(t)
+}
+
+func TestUpdateUser_PassesOnlyGivenFields(t *testing.T) {
+	e, mockUsers := setupTestEnv(t, DefaultLimits())
+	user := testUser(testUserID)
+	name := "Bob"
+	mockUsers.On("UpdateExistingUser", mock.Anything, testUserID, &name, (*string)(nil), (*string)(nil)).Return(&user, nil).Once()
+
+	resp := doGraphQL(t, e, `mutation { updateUser(id: "`+testUserID+`", input: {name: "Bob"}) { id } }`, nil)
+
+	assert.Empty(t, resp.Errors)
+	mockUsers.AssertExpectations(t)
+}
+
+func TestDeleteUser_MapsDomainErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{domain.ErrUnauthenticated, codeUnauthenticated},
+		{domain.ErrForbidden, codeForbidden},
+		{domain.ErrNotFound, codeNotFound},
+		{domain.ErrConflict, codeConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			e, mockUsers := setupTestEnv(t, DefaultLimits())
+			mockUsers.On("RemoveUser", mock.Anything, testUserID).Return(tt.err).Once()
+
+			resp := doGraphQL(t, e, `mutation { deleteUser(id: "`+testUserID+`") }`, nil)
+
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, tt.want, resp.Errors[0].Extensions["code"])
+		})
+	}
+}
+
+func TestLimits_RejectBeforeResolving(t *testing.T) {
+	e, mockUsers := setupTestEnv(t, Limits{MaxDepth: 3, MaxComplexity: 50})
+
+	resp := doGraphQL(t, e, `{ users { edges { node { id } } } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Contains(t, resp.Errors[0].Message, "depth")
+	assert.Equal(t, codeQueryTooComplex, resp.Errors[0].Extensions["code"])
+
+	resp = doGraphQL(t, e, `query($n: Int) { users(first: $n) { pageInfo { hasNextPage } } }`, map[string]interface{}{"n": 100})
+	require.Len(t, resp.Errors, 1)
+	assert.Contains(t, resp.Errors[0].Message, "complexity")
+	mockUsers.AssertNotCalled(t, "ListUsersPage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestServe_RequiresQuery(t *testing.T) {
+	e, _ := setupTestEnv(t, DefaultLimits())
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
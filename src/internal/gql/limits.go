@@ -0,0 +1,135 @@
+package gql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Limits bounds the cost of a single operation so that one request cannot
+// fan out into an unbounded amount of work.
+type Limits struct {
+	MaxDepth      int // Deepest field nesting; introspection fields are not counted
+	MaxComplexity int // Estimated number of fields resolved, see complexity
+}
+
+// DefaultLimits returns the limits used when none are configured.
+func DefaultLimits() Limits {
+	return Limits{MaxDepth: 10, MaxComplexity: 1000}
+}
+
+// checkLimits measures the selected operation of a validated document. An
+// operation that cannot be found is left for the executor to report.
+func checkLimits(doc *ast.Document, operationName string, variables map[string]interface{}, limits Limits) error {
+	var op *ast.OperationDefinition
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				op = def
+			}
+		case *ast.FragmentDefinition:
+			fragments[def.Name.Value] = def
+		}
+	}
+	if op == nil {
+		return nil
+	}
+
+	m := &measurer{fragments: fragments, variables: variables, visiting: make(map[string]bool)}
+	depth, complexity := m.selectionSet(op.SelectionSet)
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return fmt.Errorf("query depth %d exceeds the limit of %d", depth, limits.MaxDepth)
+	}
+	if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, limits.MaxComplexity)
+	}
+	return nil
+}
+
+// measurer computes depth and complexity of a selection set with fragments
+// expanded. Each field costs 1 plus the cost of its selections, multiplied by
+// the page size when the field takes a first argument.
+type measurer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	visiting  map[string]bool // Guards against fragment cycles
+}
+
+func (m *measurer) selectionSet(set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+	for _, sel := range set.Selections {
+		var d, c int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			d, c = m.field(sel)
+		case *ast.InlineFragment:
+			d, c = m.selectionSet(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			frag, ok := m.fragments[name]
+			if !ok || m.visiting[name] {
+				continue
+			}
+			m.visiting[name] = true
+			d, c = m.selectionSet(frag.SelectionSet)
+			delete(m.visiting, name)
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+	return depth, complexity
+}
+
+func (m *measurer) field(f *ast.Field) (depth, complexity int) {
+	childDepth, childComplexity := m.selectionSet(f.SelectionSet)
+	// Introspection is bounded by the schema itself; GraphiQL nests it deeply
+	if strings.HasPrefix(f.Name.Value, "__") {
+		return 0, 1 + childComplexity
+	}
+	return 1 + childDepth, 1 + m.multiplier(f)*childComplexity
+}
+
+// multiplier returns the page size requested by a paginated field, or 1.
+func (m *measurer) multiplier(f *ast.Field) int {
+	for _, arg := range f.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			if n, ok := intValue(m.variables[v.Name.Value]); ok && n > 0 {
+				return n
+			}
+		}
+		return defaultPageSize
+	}
+	if f.Name.Value == "users" {
+		return defaultPageSize
+	}
+	return 1
+}
+
+// intValue converts a decoded JSON variable to an int.
+func intValue(v interface{}) (int, bool) {
+	switch v := v.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err == nil
+	}
+	return 0, false
+}
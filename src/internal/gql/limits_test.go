@@ -0,0 +1,77 @@
+package gql
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLimits(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		variables     map[string]interface{}
+		depth         int
+		complexity    int
+		operationName string
+	}{
+		{
+			name:       "scalar fields",
+			query:      `{ user(id: "x") { id name } }`,
+			depth:      2,
+			complexity: 3,
+		},
+		{
+			name:       "page size multiplies the selection",
+			query:      `{ users(first: 5) { edges { node { id name } } } }`,
+			depth:      4,
+			complexity: 21,
+		},
+		{
+			name:       "page size from a variable",
+			query:      `query($n: Int) { users(first: $n) { edges { cursor } } }`,
+			variables:  map[string]interface{}{"n": float64(3)},
+			depth:      3,
+			complexity: 7,
+		},
+		{
+			name:       "omitted page size uses the default",
+			query:      `{ users { edges { cursor } } }`,
+			depth:      3,
+			complexity: 1 + defaultPageSize*2,
+		},
+		{
+			name:       "fragments are expanded",
+			query:      `{ ...F a: user(id: "x") { ...U } } fragment F on Query { user(id: "y") { ...U } } fragment U on User { id email }`,
+			depth:      2,
+			complexity: 6,
+		},
+		{
+			name:       "introspection does not count towards depth",
+			query:      `{ __schema { types { fields { type { ofType { ofType { name } } } } } } }`,
+			depth:      0,
+			complexity: 7,
+		},
+		{
+			name:          "only the selected operation is measured",
+			query:         `query A { user(id: "x") { id } } query B { users { edges { cursor } } }`,
+			operationName: "A",
+			depth:         2,
+			complexity:    2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			require.NoError(t, err)
+
+			assert.NoError(t, checkLimits(doc, tt.operationName, tt.variables, Limits{MaxDepth: tt.depth, MaxComplexity: tt.complexity}))
+			if tt.depth > 0 {
+				assert.ErrorContains(t, checkLimits(doc, tt.operationName, tt.variables, Limits{MaxDepth: tt.depth - 1}), "depth")
+			}
+			assert.ErrorContains(t, checkLimits(doc, tt.operationName, tt.variables, Limits{MaxComplexity: tt.complexity - 1}), "complexity")
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package gql
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+// defaultPageSize is used for users when first is omitted.
+const defaultPageSize = 20
+
+// cursorPrefix namespaces user cursors so they stay opaque to clients.
+const cursorPrefix = "user:"
+
+func encodeCursor(userID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + userID))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), cursorPrefix) {
+		return "", badUserInput("invalid cursor")
+	}
+	return strings.TrimPrefix(string(raw), cursorPrefix), nil
+}
+
+// resolvers resolves the schema's root fields through UserInteractor, so
+// GraphQL callers get the same authorization, audit events and domain events
+// as HTTP callers.
+type resolvers struct {
+	users usecases.UserInteractor
+}
+
+// NewSchema builds the users schema on top of uc.
+func NewSchema(uc usecases.UserInteractor) (graphql.Schema, error) {
+	r := &resolvers{users: uc}
+
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: userField(func(u *domain.User) interface{} { return u.ID })},
+			"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: userField(func(u *domain.User) interface{} { return u.Name })},
+			"email":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: userField(func(u *domain.User) interface{} { return u.Email })},
+			"role":      &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: userField(func(u *domain.User) interface{} { return u.Role })},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: userField(func(u *domain.User) interface{} { return u.CreatedAt })},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: userField(func(u *domain.User) interface{} { return u.UpdatedAt })},
+		},
+	})
+	userEdgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "UserEdge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: userField(func(u *domain.User) interface{} { return encodeCursor(u.ID) })},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(userType), Resolve: userField(func(u *domain.User) interface{} { return u })},
+		},
+	})
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"endCursor":   &graphql.Field{Type: graphql.String},
+		},
+	})
+	userConnectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "UserConnection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userEdgeType)))},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+		},
+	})
+
+	createUserInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateUserInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"email":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"password": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	updateUserInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "UpdateUserInput",
+		Description: "Omitted fields are left unchanged.",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"email":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"password": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.user,
+			},
+			"users": &graphql.Field{
+				Type:        graphql.NewNonNull(userConnectionType),
+				Description: "Users in a stable order, paginated with first and after.",
+				Args: graphql.FieldConfigArgument{
+					"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+					"after": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.usersConnection,
+			},
+		},
+	})
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createUser": &graphql.Field{
+				Type: graphql.NewNonNull(userType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createUserInput)},
+				},
+				Resolve: r.createUser,
+			},
+			"updateUser": &graphql.Field{
+				Type: graphql.NewNonNull(userType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(updateUserInput)},
+				},
+				Resolve: r.updateUser,
+			},
+			"deleteUser": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Deletes a user and returns its ID.",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.deleteUser,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// userField resolves a field of a *domain.User source.
+func userField(get func(u *domain.User) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		u, ok := p.Source.(*domain.User)
+		if !ok {
+			return nil, nil
+		}
+		return get(u), nil
+	}
+}
+
+// userIDArg returns the id argument, which must be a UUID.
+func userIDArg(p graphql.ResolveParams) (string, error) {
+	id, _ := p.Args["id"].(string)
+	if _, err := uuid.Parse(id); err != nil {
+		return "", badUserInput("id must be a UUID")
+	}
+	return id, nil
+}
+
+// optionalString returns a pointer to an input field, or nil when it is
+// omitted or null.
+func optionalString(input map[string]interface{}, key string) *string {
+	s, ok := input[key].(string)
+	if !ok {
+		return nil
+	}
+	return &s
+}
+
+func (r *resolvers) user(p graphql.ResolveParams) (interface{}, error) {
+	id, err := userIDArg(p)
+	if err != nil {
+		return nil, err
+	}
+	user, err := r.users.FindUserByID(p.Context, id)
+	if err != nil {
+		return nil, toGraphQLError(err)
+	}
+	if user == nil {
+		return nil, nil
+	}
+	return user, nil
+}
+
+func (r *resolvers) usersConnection(p graphql.ResolveParams) (interface{}, error) {
+	first, _ := p.Args["first"].(int)
+	if first < 1 {
+		return nil, badUserInput("first must be at least 1")
+	}
+	var afterID string
+	if after, ok := p.Args["after"].(string); ok {
+		var err error
+		if afterID, err = decodeCursor(after); err != nil {
+			return nil, err
+		}
+	}
+
+	page, err := r.users.ListUsersPage(p.Context, afterID, first)
+	if err != nil {
+		return nil, toGraphQLError(err)
+	}
+	edges := make([]interface{}, len(page.Users))
+	for i := range page.Users {
+		edges[i] = &page.Users[i]
+	}
+	pageInfo := map[string]interface{}{"hasNextPage": page.HasMore, "endCursor": nil}
+	if n := len(page.Users); n > 0 {
+		pageInfo["endCursor"] = encodeCursor(page.Users[n-1].ID)
+	}
+	return map[string]interface{}{"edges": edges, "pageInfo": pageInfo}, nil
+}
+
+func (r *resolvers) createUser(p graphql.ResolveParams) (interface{}, error) {
+	input, _ := p.Args["input"].(map[string]interface{})
+	name, _ := input["name"].(string)
+	email, _ := input["email"].(string)
+	password, _ := input["password"].(string)
+	user, err := r.users.CreateNewUser(p.Context, name, email, password)
+	if err != nil {
+		return nil, toGraphQLError(err)
+	}
+	return user, nil
+}
+
+func (r *resolvers) updateUser(p graphql.ResolveParams) (interface{}, error) {
+	id, err := userIDArg(p)
+	if err != nil {
+		return nil, err
+	}
+	input, _ := p.Args["input"].(map[string]interface{})
+	user, err := r.users.UpdateExistingUser(p.Context, id, optionalString(input, "name"), optionalString(input, "email"), optionalString(input, "password"))
+	if err != nil {
+		return nil, toGraphQLError(err)
+	}
+	return user, nil
+}
+
+func (r *resolvers) deleteUser(p graphql.ResolveParams) (interface{}, error) {
+	id, err := userIDArg(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.users.RemoveUser(p.Context, id); err != nil {
+		return nil, toGraphQLError(err)
+	}
+	return id, nil
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// APIKeyHandler handles HTTP requests for API key management.
+type APIKeyHandler struct {
+	apiKeyInteractor usecases.APIKeyInteractor
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler.
+func NewAPIKeyHandler(uc usecases.APIKeyInteractor) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyInteractor: uc}
+}
+
+// toAPIKey maps domain.APIKey to api.ApiKey. The secret hash is never exposed.
+func toAPIKey(k *domain.APIKey) api.ApiKey {
+	scopes := make([]api.ApiKeyScope, len(k.Scopes))
+	for i, s := range k.Scopes {
+		scopes[i] = api.ApiKeyScope(s)
+	}
+	return api.ApiKey{
+		Id:         uuid.MustParse(k.ID),
+		UserId:     uuid.MustParse(k.UserID),
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		CreatedAt:  k.CreatedAt,
+		ExpiresAt:  optionalTime(k.ExpiresAt),
+		LastUsedAt: optionalTime(k.LastUsedAt),
+		RevokedAt:  optionalTime(k.RevokedAt),
+	}
+}
+
+// optionalTime returns nil for the zero time so it is omitted from responses.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// GetApiKeys (corresponds to operationId: get-api-keys)
+// GET /v1/api-keys
+func (h *APIKeyHandler) GetApiKeys(c echo.Context, params api.GetApiKeysParams) error {
+	userID := ""
+	if params.UserId != nil {
+		userID = params.UserId.String()
+	}
+
+	keys, err := h.apiKeyInteractor.ListAPIKeys(c.Request().Context(), userID)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve API keys")
+	}
+
+	apiKeys := make([]api.ApiKey, len(keys))
+	for i := range keys {
+		apiKeys[i] = toAPIKey(&keys[i])
+	}
+	return c.JSON(http.StatusOK, apiKeys)
+}
+
+// PostApiKey (corresponds to operationId: post-api-key)
+// POST /v1/api-keys
+func (h *APIKeyHandler) PostApiKey(c echo.Context) error {
+	var requestBody api.PostApiKeyJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+
+	userID := ""
+	if requestBody.UserId != nil {
+		userID = requestBody.UserId.String()
+	}
+	scopes := make([]string, len(requestBody.Scopes))
+	for i, s := range requestBody.Scopes {
+		scopes[i] = string(s)
+	}
+	var expiresAt time.Time
+	if requestBody.ExpiresAt != nil {
+		expiresAt = *requestBody.ExpiresAt
+	}
+
+	key, secret, err := h.apiKeyInteractor.CreateAPIKey(c.Request().Context(), userID, requestBody.Name, scopes, expiresAt)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to create API key")
+	}
+
+	return c.JSON(http.StatusCreated, api.CreatedApiKey{
+		ApiKey: toAPIKey(key),
+		Key:    secret,
+	})
+}
+
+// DeleteApiKey (corresponds to operationId: delete-api-key)
+// DELETE /v1/api-keys/{api_key_id}
+func (h *APIKeyHandler) DeleteApiKey(c echo.Context, apiKeyId openapi_types.UUID) error {
+	if err := h.apiKeyInteractor.RevokeAPIKey(c.Request().Context(), apiKeyId.String()); err != nil {
+		return toHTTPError(c, err, "Failed to revoke API key")
+	}
+	return c.JSON(http.StatusOK, map[string]string{})
+}
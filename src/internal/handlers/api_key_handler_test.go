@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testAPIKey = "uak_abcd1234_0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func setupAPIKeyTestEnv() (*echo.Echo, *mocks.MockAPIKeyInteractor, *mocks.MockUserInteractor, auth.TokenService) {
+	e := echo.New()
+	mockKeys := new(mocks.MockAPIKeyInteractor)
+	mockUsers := new(mocks.MockUserInteractor)
+	tokens := auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real())
+	e.Use(Authenticate(tokens, mockKeys))
+	api.RegisterHandlers(e, &Server{
+		UserHandler:   NewUserHandler(mockUsers),
+		APIKeyHandler: NewAPIKeyHandler(mockKeys),
+	})
+	return e, mockKeys, mockUsers, tokens
+}
+
+func bearer(t *testing.T, tokens auth.TokenService, user *domain.User) string {
+	token, _, err := tokens.Issue(user, false)
+	assert.NoError(t, err)
+	return "Bearer " + token
+}
+
+func TestAPIKeyHandler_PostApiKey_Success(t *testing.T) {
+	e, mockKeys, _, tokens := setupAPIKeyTestEnv()
+	keyID, userID := uuid.New(), uuid.New()
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockKeys.On("CreateAPIKey", mock.Anything, "", "batch job", []string{domain.ScopeUsersRead}, expiresAt).Return(&domain.APIKey{
+		ID:        keyID.String(),
+		UserID:    userID.String(),
+		Name:      "batch job",
+		Prefix:    "uak_abcd1234",
+		Scopes:    []string{domain.ScopeUsersRead},
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}, testAPIKey, nil).Once()
+
+	req := newJSONRequest(http.MethodPost, "/v1/api-keys", api.ApiKeyInfo{Name: "batch job", Scopes: []api.ApiKeyScope{api.UsersRead}, ExpiresAt: &expiresAt})
+	req.Header.Set(echo.HeaderAuthorization, bearer(t, tokens, &domain.User{ID: userID.String(), Role: domain.RoleUser}))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var created api.CreatedApiKey
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, testAPIKey, created.Key)
+	assert.Equal(t, keyID, created.ApiKey.Id)
+	assert.Equal(t, []api.ApiKeyScope{api.UsersRead}, created.ApiKey.Scopes)
+	assert.Nil(t, created.ApiKey.LastUsedAt)
+	assert.NotContains(t, rec.Body.String(), "secret_hash")
+	mockKeys.AssertExpectations(t)
+}
+
+func TestAPIKeyHandler_PostApiKey_InvalidScope(t *testing.T) {
+	e, mockKeys, _, _ := setupAPIKeyTestEnv()
+
+	mockKeys.On("CreateAPIKey", mock.Anything, "", "job", []string{"users:delete"}, time.Time{}).
+		Return(nil, "", fmt.Errorf("%w: unknown scope %q", domain.ErrInvalidArgument, "users:delete")).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/v1/api-keys", map[string]interface{}{"name": "job", "scopes": []string{"users:delete"}}))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockKeys.AssertExpectations(t)
+}
+
+func TestAPIKeyHandler_GetApiKeys(t *testing.T) {
+	e, mockKeys, _, _ := setupAPIKeyTestEnv()
+	userID := uuid.New()
+
+	mockKeys.On("ListAPIKeys", mock.Anything, userID.String()).Return([]domain.APIKey{
+		{ID: uuid.NewString(), UserID: userID.String(), Name: "a", Prefix: "uak_aaaaaaaa", Scopes: []string{domain.ScopeUsersRead}, LastUsedAt: time.Now()},
+		{ID: uuid.NewString(), UserID: userID.String(), Name: "b", Prefix: "uak_bbbbbbbb", Scopes: []string{domain.ScopeUsersWrite}, RevokedAt: time.Now()},
+	}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/api-keys?user_id="+userID.String(), nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var keys []api.ApiKey
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &keys))
+	if assert.Len(t, keys, 2) {
+		assert.NotNil(t, keys[0].LastUsedAt)
+		assert.NotNil(t, keys[1].RevokedAt)
+	}
+	mockKeys.AssertExpectations(t)
+}
+
+func TestAPIKeyHandler_DeleteApiKey_NotFound(t *testing.T) {
+	e, mockKeys, _, _ := setupAPIKeyTestEnv()
+	keyID := uuid.New()
+
+	mockKeys.On("RevokeAPIKey", mock.Anything, keyID.String()).Return(domain.ErrAPIKeyNotFound).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/v1/api-keys/"+keyID.String(), nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockKeys.AssertExpectations(t)
+}
+
+func TestAuthenticate_APIKeyHeaders(t *testing.T) {
+	for name, setHeader := range map[string]func(*http.Request){
+		"X-API-Key":      func(r *http.Request) { r.Header.Set(HeaderAPIKey, testAPIKey) },
+		"Bearer API key": func(r *http.Request) { r.Header.Set(echo.HeaderAuthorization, "Bearer "+testAPIKey) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			e, mockKeys, mockUsers, _ := setupAPIKeyTestEnv()
+			principal := &auth.Principal{UserID: "svc-1", Role: domain.RoleUser, APIKeyID: "key-1", Scopes: []string{domain.ScopeUsersRead}}
+
+			mockKeys.On("AuthenticateAPIKey", mock.Anything, testAPIKey).Return(principal, nil).Once()
+			mockUsers.On("GetAllUsers", mock.MatchedBy(func(ctx context.Context) bool {
+				return auth.PrincipalFrom(ctx) == principal
+			}), domain.UserFilter{}).Return([]domain.User{}, nil).Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+			setHeader(req)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			mockKeys.AssertExpectations(t)
+			mockUsers.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthenticate_InvalidAPIKey(t *testing.T) {
+	e, mockKeys, mockUsers, _ := setupAPIKeyTestEnv()
+
+	mockKeys.On("AuthenticateAPIKey", mock.Anything, testAPIKey).Return(nil, domain.ErrUnauthenticated).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+	req.Header.Set(HeaderAPIKey, testAPIKey)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockUsers.AssertNotCalled(t, "GetAllUsers", mock.Anything, mock.Anything)
+}
+
+func TestAuthenticate_APIKeyScopeForbidden(t *testing.T) {
+	e, mockKeys, mockUsers, _ := setupAPIKeyTestEnv()
+	userID := uuid.New()
+
+	mockKeys.On("AuthenticateAPIKey", mock.Anything, testAPIKey).Return(&auth.Principal{UserID: "svc-1", APIKeyID: "key-1", Scopes: []string{domain.ScopeUsersRead}}, nil).Once()
+	mockUsers.On("RemoveUser", mock.Anything, userID.String()).Return(domain.ErrForbidden).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/users/"+userID.String(), nil)
+	req.Header.Set(HeaderAPIKey, testAPIKey)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockUsers.AssertExpectations(t)
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultAuditPageSize mirrors the usecase default so next_cursor can be
+// computed when the client does not send a limit.
+const defaultAuditPageSize = 100
+
+// AuditHandler handles HTTP requests for the audit log.
+type AuditHandler struct {
+	auditInteractor usecases.AuditInteractor
+}
+
+// NewAuditHandler creates a new AuditHandler.
+func NewAuditHandler(uc usecases.AuditInteractor) *AuditHandler {
+	return &AuditHandler{auditInteractor: uc}
+}
+
+// toAPIAuditEvent maps domain.AuditEvent to api.AuditEvent.
+func toAPIAuditEvent(e *domain.AuditEvent) api.AuditEvent {
+	out := api.AuditEvent{
+		Id:         e.ID,
+		Action:     e.Action,
+		ActorId:    optionalString(e.ActorID),
+		TargetId:   optionalString(e.TargetID),
+		RequestId:  optionalString(e.RequestID),
+		Ip:         optionalString(e.IP),
+		OccurredAt: e.OccurredAt,
+	}
+	if len(e.Changes) > 0 {
+		changes := make([]api.FieldChange, len(e.Changes))
+		for i, ch := range e.Changes {
+			changes[i] = api.FieldChange{Field: ch.Field, Old: optionalString(ch.Old), New: optionalString(ch.New)}
+		}
+		out.Changes = &changes
+	}
+	if len(e.Metadata) > 0 {
+		metadata := e.Metadata
+		out.Metadata = &metadata
+	}
+	return out
+}
+
+// optionalString returns nil for the empty string so it is omitted from responses.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// GetAuditEvents (corresponds to operationId: get-audit-events)
+// GET /v1/audit-events
+func (h *AuditHandler) GetAuditEvents(c echo.Context, params api.GetAuditEventsParams) error {
+	filter := domain.AuditFilter{Limit: defaultAuditPageSize}
+	if params.ActorId != nil {
+		filter.ActorID = params.ActorId.String()
+	}
+	if params.TargetId != nil {
+		filter.TargetID = params.TargetId.String()
+	}
+	if params.Action != nil {
+		filter.Action = *params.Action
+	}
+	if params.From != nil {
+		filter.From = *params.From
+	}
+	if params.To != nil {
+		filter.To = *params.To
+	}
+	if params.Limit != nil {
+		if *params.Limit < 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be at least 1")
+		}
+		filter.Limit = *params.Limit
+	}
+	if params.Cursor != nil {
+		filter.BeforeID = *params.Cursor
+	}
+
+	events, err := h.auditInteractor.ListAuditEvents(c.Request().Context(), filter)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve audit events")
+	}
+
+	resp := api.AuditEventList{Events: make([]api.AuditEvent, len(events))}
+	for i := range events {
+		resp.Events[i] = toAPIAuditEvent(&events[i])
+	}
+	// A full page means there may be more; the client continues from the oldest ID
+	if len(events) == filter.Limit {
+		next := events[len(events)-1].ID
+		resp.NextCursor = &next
+	}
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupAuditTestEnv() (*echo.Echo, *mocks.MockAuditInteractor, auth.TokenService) {
+	e := echo.New()
+	mockAudit := new(mocks.MockAuditInteractor)
+	tokens := auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real())
+	e.Use(Authenticate(tokens, nil))
+	api.RegisterHandlers(e, &Server{AuditHandler: NewAuditHandler(mockAudit)})
+	return e, mockAudit, tokens
+}
+
+func TestAuditHandler_GetAuditEvents_Filters(t *testing.T) {
+	e, mockAudit, tokens := setupAuditTestEnv()
+	adminID, targetID := uuid.New(), uuid.New()
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	mockAudit.On("ListAuditEvents", mock.Anything, domain.AuditFilter{
+		TargetID: targetID.String(),
+		Action:   domain.AuditActionUserUpdated,
+		From:     from,
+		BeforeID: 42,
+		Limit:    2,
+	}).Return([]domain.AuditEvent{
+		{ID: 41, Action: domain.AuditActionUserUpdated, ActorID: adminID.String(), TargetID: targetID.String(),
+			Changes: []domain.FieldChange{{Field: "role", Old: "user", New: "admin"}}, RequestID: "req-1", OccurredAt: from},
+		{ID: 40, Action: domain.AuditActionUserUpdated, TargetID: targetID.String(), OccurredAt: from},
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/audit-events?target_id="+targetID.String()+"&action=user.updated&from=2026-10-01T00:00:00Z&limit=2&cursor=42", nil)
+	req.Header.Set(echo.HeaderAuthorization, bearer(t, tokens, &domain.User{ID: adminID.String(), Role: domain.RoleAdmin}))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp api.AuditEventList
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.Events, 2)
+	assert.Equal(t, "role", (*resp.Events[0].Changes)[0].Field)
+	assert.Equal(t, "req-1", *resp.Events[0].RequestId)
+	assert.Nil(t, resp.Events[1].ActorId)
+	if assert.NotNil(t, resp.NextCursor) {
+		assert.Equal(t, int64(40), *resp.NextCursor)
+	}
+	mockAudit.AssertExpectations(t)
+}
+
+func TestAuditHandler_GetAuditEvents_LastPage(t *testing.T) {
+	e, mockAudit, tokens := setupAuditTestEnv()
+	mockAudit.On("ListAuditEvents", mock.Anything, domain.AuditFilter{Limit: defaultAuditPageSize}).
+		Return([]domain.AuditEvent{{ID: 1, Action: domain.AuditActionUserCreated}}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/audit-events", nil)
+	req.Header.Set(echo.HeaderAuthorization, bearer(t, tokens, &domain.User{ID: uuid.NewString(), Role: domain.RoleAdmin}))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp api.AuditEventList
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Nil(t, resp.NextCursor)
+}
+
+func TestAuditHandler_GetAuditEvents_Forbidden(t *testing.T) {
+	e, mockAudit, tokens := setupAuditTestEnv()
+	mockAudit.On("ListAuditEvents", mock.Anything, mock.Anything).Return(nil, domain.ErrForbidden).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/audit-events", nil)
+	req.Header.Set(echo.HeaderAuthorization, bearer(t, tokens, &domain.User{ID: uuid.NewString(), Role: domain.RoleUser}))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestRequestInfo_PropagatesRequestIDAndIP(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.RequestID())
+	e.Use(RequestInfo())
+	var got audit.RequestInfo
+	e.GET("/", func(c echo.Context) error {
+		got = audit.RequestInfoFrom(c.Request().Context())
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-123")
+	req.Header.Set(echo.HeaderXRealIP, "192.0.2.1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, audit.RequestInfo{RequestID: "req-123", IP: "192.0.2.1"}, got)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/skip2/go-qrcode"
+)
+
+// qrCodeSize is the edge length in pixels of the enrollment QR code.
+const qrCodeSize = 256
+
+// AuthHandler handles HTTP requests for authentication.
+type AuthHandler struct {
+	authInteractor usecases.AuthInteractor
+}
+
+// NewAuthHandler creates a new AuthHandler.
+func NewAuthHandler(uc usecases.AuthInteractor) *AuthHandler {
+	return &AuthHandler{authInteractor: uc}
+}
+
+// PostLogin (corresponds to operationId: post-login)
+// POST /v1/login
+func (h *AuthHandler) PostLogin(c echo.Context) error {
+	var requestBody api.PostLoginJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	if requestBody.Email == "" || requestBody.Password == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "email and password are required")
+	}
+
+	result, err := h.authInteractor.Login(c.Request().Context(), string(requestBody.Email), requestBody.Password, c.RealIP())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to log in")
+	}
+
+	return c.JSON(http.StatusOK, toAPILoginResult(result))
+}
+
+// PostLoginMfa (corresponds to operationId: post-login-mfa)
+// POST /v1/login/mfa
+func (h *AuthHandler) PostLoginMfa(c echo.Context) error {
+	var requestBody api.PostLoginMfaJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	if requestBody.MfaToken == "" || requestBody.Code == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "mfa_token and code are required")
+	}
+
+	result, err := h.authInteractor.CompleteMFALogin(c.Request().Context(), requestBody.MfaToken, requestBody.Code, c.RealIP())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to log in")
+	}
+
+	return c.JSON(http.StatusOK, toAPIToken(result))
+}
+
+// PostMfaEnroll (corresponds to operationId: post-mfa-enroll)
+// POST /v1/mfa/enroll
+func (h *AuthHandler) PostMfaEnroll(c echo.Context) error {
+	enrollment, err := h.authInteractor.BeginMFAEnrollment(c.Request().Context())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to start MFA enrollment")
+	}
+
+	png, err := qrcode.Encode(enrollment.KeyURI, qrcode.Medium, qrCodeSize)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to render QR code: "+err.Error())
+	}
+
+	return c.JSON(http.StatusOK, api.MfaEnrollment{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.KeyURI,
+		QrCodePng:  png,
+	})
+}
+
+// PostMfaConfirm (corresponds to operationId: post-mfa-confirm)
+// POST /v1/mfa/confirm
+func (h *AuthHandler) PostMfaConfirm(c echo.Context) error {
+	var requestBody api.PostMfaConfirmJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	if requestBody.Code == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "code is required")
+	}
+
+	codes, err := h.authInteractor.ConfirmMFAEnrollment(c.Request().Context(), requestBody.Code)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to confirm MFA enrollment")
+	}
+
+	return c.JSON(http.StatusOK, api.RecoveryCodes{RecoveryCodes: codes})
+}
+
+// toAPIToken converts a completed login into the API token representation.
+// toAPILoginResult returns either the access token or, when a second factor
+// is still needed, the MFA challenge token of the first login step.
+func toAPILoginResult(result *usecases.LoginResult) api.LoginResult {
+	if result.MFARequired {
+		return api.LoginResult{
+			MfaRequired: true,
+			MfaToken:    &result.MFAToken,
+		}
+	}
+	token := toAPIToken(result)
+	return api.LoginResult{
+		AccessToken: &token.AccessToken,
+		TokenType:   &token.TokenType,
+		ExpiresIn:   &token.ExpiresIn,
+		MfaRequired: false,
+	}
+}
+
+func toAPIToken(result *usecases.LoginResult) api.Token {
+	return api.Token{
+		AccessToken: result.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(time.Until(result.ExpiresAt).Seconds()),
+	}
+}
+
+// PostUserUnlock (corresponds to operationId: post-user-unlock)
+// POST /v1/users/{user_id}/unlock
+func (h *AuthHandler) PostUserUnlock(c echo.Context, userId openapi_types.UUID) error {
+	if err := h.authInteractor.UnlockUser(c.Request().Context(), userId.String()); err != nil {
+		return toHTTPError(c, err, "Failed to unlock user")
+	}
+	return c.JSON(http.StatusOK, map[string]string{})
+}
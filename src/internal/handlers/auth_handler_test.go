@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupAuthTestEnv() (*echo.Echo, *mocks.MockAuthInteractor, auth.TokenService) {
+	e := echo.New()
+	mockInteractor := new(mocks.MockAuthInteractor)
+	tokens := auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real())
+	e.Use(Authenticate(tokens, nil))
+	api.RegisterHandlers(e, &Server{AuthHandler: NewAuthHandler(mockInteractor)})
+	return e, mockInteractor, tokens
+}
+
+func newLoginRequest(email, password string) *http.Request {
+	jsonBody, _ := json.Marshal(api.LoginInfo{Email: openapi_types.Email(email), Password: password})
+	req := httptest.NewRequest(http.MethodPost, "/v1/login", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderXRealIP, "192.0.2.1")
+	return req
+}
+
+func TestAuthHandler_PostLogin_Success(t *testing.T) {
+	e, mockInteractor, _ := setupAuthTestEnv()
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("Login", mock.Anything, "user@example.com", "password123", "192.0.2.1").Return(&usecases.LoginResult{
+		User:        &domain.User{ID: "user-1"},
+		AccessToken: "token-value",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}, nil).Once()
+
+	e.ServeHTTP(rec, newLoginRequest("user@example.com", "password123"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var result api.LoginResult
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.False(t, result.MfaRequired)
+	if assert.NotNil(t, result.AccessToken) {
+		assert.Equal(t, "token-value", *result.AccessToken)
+	}
+	if assert.NotNil(t, result.TokenType) {
+		assert.Equal(t, "Bearer", *result.TokenType)
+	}
+	if assert.NotNil(t, result.ExpiresIn) {
+		assert.InDelta(t, 3600, *result.ExpiresIn, 5)
+	}
+	assert.Nil(t, result.MfaToken)
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestAuthHandler_PostLogin_MFARequired(t *testing.T) {
+	e, mockInteractor, _ := setupAuthTestEnv()
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("Login", mock.Anything, "user@example.com", "password123", "192.0.2.1").Return(&usecases.LoginResult{
+		User:        &domain.User{ID: "user-1"},
+		MFARequired: true,
+		MFAToken:    "challenge-value",
+		ExpiresAt:   time.Now().Add(5 * time.Minute),
+	}, nil).Once()
+
+	e.ServeHTTP(rec, newLoginRequest("user@example.com", "password123"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var result api.LoginResult
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.True(t, result.MfaRequired)
+	if assert.NotNil(t, result.MfaToken) {
+		assert.Equal(t, "challenge-value", *result.MfaToken)
+	}
+	assert.Nil(t, result.AccessToken)
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestAuthHandler_PostLogin_MissingFields(t *testing.T) {
+	e, mockInteractor, _ := setupAuthTestEnv()
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, newLoginRequest("user@example.com", ""))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockInteractor.AssertNotCalled(t, "Login", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthHandler_PostLogin_InvalidCredentials(t *testing.T) {
+	e, mockInteractor, _ := setupAuthTestEnv()
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("Login", mock.Anything, "user@example.com", "wrong", "192.0.2.1").Return(nil, domain.ErrInvalidCredentials).Once()
+
+	e.ServeHTTP(rec, newLoginRequest("user@example.com", "wrong"))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestAuthHandler_PostLogin_Locked(t *testing.T) {
+	e, mockInteractor, _ := setupAuthTestEnv()
+	rec := httptest.NewRecorder()
+
+	lockedErr := &domain.LockedError{Until: time.Now().Add(2 * time.Minute)}
+	mockInteractor.On("Login", mock.Anything, "user@example.com", "password123", "192.0.2.1").Return(nil, lockedErr).Once()
+
+	e.ServeHTTP(rec, newLoginRequest("user@example.com", "password123"))
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+	assert.NoError(t, err)
+	assert.InDelta(t, 120, retryAfter, 2)
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestAuthHandler_PostUserUnlock_Success(t *testing.T) {
+	e, mockInteractor, tokens := setupAuthTestEnv()
+	userID := uuid.New()
+	token, _, _ := tokens.Issue(&domain.User{ID: "admin-1", Role: domain.RoleAdmin}, true)
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/users/%s/unlock", userID), nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("UnlockUser", mock.MatchedBy(func(ctx context.Context) bool {
+		p := auth.PrincipalFrom(ctx)
+		return p != nil && p.UserID == "admin-1" && p.IsAdmin()
+	}), userID.String()).Return(nil).Once()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestAuthHandler_PostUserUnlock_Forbidden(t *testing.T) {
+	e, mockInteractor, _ := setupAuthTestEnv()
+	userID := uuid.New()
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/users/%s/unlock", userID), nil)
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("UnlockUser", mock.Anything, userID.String()).Return(domain.ErrForbidden).Once()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockInteractor.AssertExpectations(t)
+}
+
+func newJSONRequest(method, path string, body interface{}) *http.Request {
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, path, bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderXRealIP, "192.0.2.1")
+	return req
+}
+
+func TestAuthHandler_PostLoginMfa_Success(t *testing.T) {
+	e, mockInteractor, _ := setupAuthTestEnv()
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("CompleteMFALogin", mock.Anything, "challenge-value", "123456", "192.0.2.1").Return(&usecases.LoginResult{
+		User:        &domain.User{ID: "user-1"},
+		AccessToken: "token-value",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}, nil).Once()
+
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/v1/login/mfa", api.MfaLoginInfo{MfaToken: "challenge-value", Code: "123456"}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var token api.Token
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &token))
+	assert.Equal(t, "token-value", token.AccessToken)
+	assert.Equal(t, "Bearer", token.TokenType)
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestAuthHandler_PostLoginMfa_InvalidCode(t *testing.T) {
+	e, mockInteractor, _ := setupAuthTestEnv()
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("CompleteMFALogin", mock.Anything, "challenge-value", "000000", "192.0.2.1").Return(nil, domain.ErrInvalidMFACode).Once()
+
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/v1/login/mfa", api.MfaLoginInfo{MfaToken: "challenge-value", Code: "000000"}))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestAuthHandler_PostMfaEnroll_Success(t *testing.T) {
+	e, mockInteractor, tokens := setupAuthTestEnv()
+	token, _, _ := tokens.Issue(&domain.User{ID: "user-1", Role: domain.RoleUser}, false)
+	keyURI := "otpauth://totp/echo_tutrial:user@example.com?secret=JBSWY3DPEHPK3PXP&issuer=echo_tutrial"
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/mfa/enroll", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("BeginMFAEnrollment", mock.Anything).Return(&usecases.MFAEnrollment{
+		Secret: "JBSWY3DPEHPK3PXP",
+		KeyURI: keyURI,
+	}, nil).Once()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var enrollment api.MfaEnrollment
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &enrollment))
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", enrollment.Secret)
+	assert.Equal(t, keyURI, enrollment.OtpauthUri)
+	assert.True(t, bytes.HasPrefix(enrollment.QrCodePng, []byte("\x89PNG")))
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestAuthHandler_PostMfaEnroll_AlreadyEnabled(t *testing.T) {
+	e, mockInteractor, _ := setupAuthTestEnv()
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("BeginMFAEnrollment", mock.Anything).Return(nil, domain.ErrConflict).Once()
+
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/mfa/enroll", nil))
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestAuthHandler_PostMfaConfirm_Success(t *testing.T) {
+	e, mockInteractor, _ := setupAuthTestEnv()
+	rec := httptest.NewRecorder()
+	codes := []string{"abcde-fghjk", "mnpqr-stuvw"}
+
+	mockInteractor.On("ConfirmMFAEnrollment", mock.Anything, "123456").Return(codes, nil).Once()
+
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/v1/mfa/confirm", api.MfaCode{Code: "123456"}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var result api.RecoveryCodes
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, codes, result.RecoveryCodes)
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestAuthHandler_PostMfaConfirm_MissingCode(t *testing.T) {
+	e, mockInteractor, _ := setupAuthTestEnv()
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/v1/mfa/confirm", api.MfaCode{}))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockInteractor.AssertNotCalled(t, "ConfirmMFAEnrollment", mock.Anything, mock.Anything)
+}
+
+func TestAuthenticate_InvalidToken(t *testing.T) {
+	e, mockInteractor, _ := setupAuthTestEnv()
+	userID := uuid.New()
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/users/%s/unlock", userID), nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer not-a-jwt")
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockInteractor.AssertNotCalled(t, "UnlockUser", mock.Anything, mock.Anything)
+}
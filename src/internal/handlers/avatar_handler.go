@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"apiserver/internal/usecases"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// avatarFormField is the multipart field holding the uploaded image.
+const avatarFormField = "file"
+
+// AvatarHandler handles HTTP requests for user avatars.
+type AvatarHandler struct {
+	avatarInteractor usecases.AvatarInteractor
+}
+
+// NewAvatarHandler creates a new AvatarHandler.
+func NewAvatarHandler(uc usecases.AvatarInteractor) *AvatarHandler {
+	return &AvatarHandler{avatarInteractor: uc}
+}
+
+// PutUserAvatar (corresponds to operationId: put-user-avatar)
+// PUT /v1/users/{user_id}/avatar
+func (h *AvatarHandler) PutUserAvatar(c echo.Context, userId openapi_types.UUID) error {
+	// The parts are read as a stream, so the upload is never spooled to disk
+	reader, err := c.Request().MultipartReader()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Request body must be multipart/form-data")
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return echo.NewHTTPError(http.StatusBadRequest, "The "+avatarFormField+" field is required")
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+		}
+		if part.FormName() != avatarFormField {
+			continue
+		}
+
+		user, err := h.avatarInteractor.UploadAvatar(c.Request().Context(), userId.String(), part)
+		if err != nil {
+			return toHTTPError(c, err, "Failed to upload avatar")
+		}
+		return c.JSON(http.StatusOK, toAPIUser(user))
+	}
+}
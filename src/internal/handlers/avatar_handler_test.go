@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAvatarTestEnv(t *testing.T) (*echo.Echo, *mocks.MockAvatarInteractor, string) {
+	tokens := auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real())
+	token, _, err := tokens.Issue(&domain.User{ID: "user-1", Role: domain.RoleUser}, false)
+	require.NoError(t, err)
+	avatars := new(mocks.MockAvatarInteractor)
+	e := echo.New()
+	e.Use(Authenticate(tokens, nil))
+	api.RegisterHandlers(e, &Server{AvatarHandler: NewAvatarHandler(avatars)})
+	return e, avatars, token
+}
+
+// multipartBody builds a multipart/form-data body with a single file field.
+func multipartBody(t *testing.T, field string, content []byte) (*bytes.Buffer, string) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	require.NoError(t, w.WriteField("note", "ignored"))
+	part, err := w.CreateFormFile(field, "avatar.png")
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return &buf, w.FormDataContentType()
+}
+
+func TestAvatarHandler_PutUserAvatar(t *testing.T) {
+	e, avatars, token := setupAvatarTestEnv(t)
+	userID := uuid.NewString()
+	avatars.On("UploadAvatar", mock.Anything, userID, []byte("image-bytes")).Return(&domain.User{
+		ID:                 userID,
+		Name:               "Alice",
+		AvatarURL:          "https://cdn.example.com/avatars/" + userID + "/256.png?v=1",
+		AvatarThumbnailURL: "https://cdn.example.com/avatars/" + userID + "/64.png?v=1",
+	}, nil).Once()
+
+	body, contentType := multipartBody(t, "file", []byte("image-bytes"))
+	req := httptest.NewRequest(http.MethodPut, "/v1/users/"+userID+"/avatar", body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var got api.User
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.NotNil(t, got.AvatarUrl)
+	assert.Equal(t, "https://cdn.example.com/avatars/"+userID+"/256.png?v=1", *got.AvatarUrl)
+	require.NotNil(t, got.AvatarThumbnailUrl)
+}
+
+func TestAvatarHandler_PutUserAvatar_BadRequest(t *testing.T) {
+	e, avatars, token := setupAvatarTestEnv(t)
+	missingField, missingType := multipartBody(t, "image", []byte("image-bytes"))
+	tests := []struct {
+		name        string
+		body        *bytes.Buffer
+		contentType string
+	}{
+		{"not multipart", bytes.NewBufferString(`{"file":"x"}`), echo.MIMEApplicationJSON},
+		{"missing file field", missingField, missingType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/v1/users/"+uuid.NewString()+"/avatar", tt.body)
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+	avatars.AssertNotCalled(t, "UploadAvatar", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"apiserver/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// toHTTPError maps domain errors to HTTP errors. Anything unrecognised becomes
+// a 500 prefixed with fallbackMsg.
+func toHTTPError(c echo.Context, err error, fallbackMsg string) error {
+	var lockedErr *domain.LockedError
+	switch {
+	case errors.As(err, &lockedErr):
+		retryAfter := int(math.Ceil(time.Until(lockedErr.Until).Seconds()))
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		return echo.NewHTTPError(http.StatusTooManyRequests, "Too many failed login attempts")
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid email or password")
+	case errors.Is(err, domain.ErrUnauthenticated):
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	case errors.Is(err, domain.ErrInvalidMFACode):
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid authentication code")
+	case errors.Is(err, domain.ErrMFARequired):
+		return echo.NewHTTPError(http.StatusForbidden, "Two-factor authentication required")
+	case errors.Is(err, domain.ErrForbidden):
+		return echo.NewHTTPError(http.StatusForbidden, "Permission denied")
+	case errors.Is(err, domain.ErrAPIKeyNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "API key not found")
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Webhook not found")
+	case errors.Is(err, domain.ErrWebhookDeliveryNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Webhook delivery not found")
+	case errors.Is(err, domain.ErrUserImportNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "User import not found")
+	case errors.Is(err, domain.ErrInvitationNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Invitation not found")
+	case errors.Is(err, domain.ErrInvitationInvalid):
+		return echo.NewHTTPError(http.StatusGone, "Invitation is invalid or has expired")
+	case errors.Is(err, domain.ErrGroupNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Group not found")
+	case errors.Is(err, domain.ErrAttributeDefinitionNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Attribute definition not found")
+	case errors.Is(err, domain.ErrErasureReceiptNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "User was not erased")
+	case errors.Is(err, domain.ErrIdentityProviderNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Identity provider not found")
+	case errors.Is(err, domain.ErrExternalLoginFailed):
+		return echo.NewHTTPError(http.StatusUnauthorized, "Sign-in with the identity provider failed or has expired")
+	case errors.Is(err, domain.ErrIdentityNotLinked):
+		return echo.NewHTTPError(http.StatusForbidden, "No user is linked to this identity provider account")
+	case errors.Is(err, domain.ErrOAuthClientNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "OAuth client not found")
+	case errors.Is(err, domain.ErrOAuthConsentNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Consent not found")
+	case errors.Is(err, domain.ErrNoTenant):
+		return echo.NewHTTPError(http.StatusBadRequest, "Select an organization with the X-Organization-ID header")
+	case errors.Is(err, domain.ErrNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	case errors.Is(err, domain.ErrInvalidArgument):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrBatchAborted):
+		return echo.NewHTTPError(http.StatusFailedDependency, "Not applied because another operation in the atomic batch failed")
+	case errors.Is(err, domain.ErrConflict):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrUserSearchUnavailable):
+		return echo.NewHTTPError(http.StatusNotImplemented, "User search is unavailable while user names and emails are encrypted")
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, fallbackMsg+": "+err.Error())
+}
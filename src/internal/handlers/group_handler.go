@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// GroupHandler handles HTTP requests for user groups.
+type GroupHandler struct {
+	groupInteractor usecases.GroupInteractor
+}
+
+// NewGroupHandler creates a new GroupHandler.
+func NewGroupHandler(uc usecases.GroupInteractor) *GroupHandler {
+	return &GroupHandler{groupInteractor: uc}
+}
+
+func toAPIGroup(g *domain.Group) api.Group {
+	return api.Group{
+		Id:          uuid.MustParse(g.ID),
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
+
+func toAPIGroupSlice(groups []domain.Group) []api.Group {
+	out := make([]api.Group, len(groups))
+	for i := range groups {
+		out[i] = toAPIGroup(&groups[i])
+	}
+	return out
+}
+
+// GetGroups (corresponds to operationId: get-groups)
+// GET /v1/groups
+func (h *GroupHandler) GetGroups(c echo.Context) error {
+	groups, err := h.groupInteractor.ListGroups(c.Request().Context())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve groups")
+	}
+	return c.JSON(http.StatusOK, toAPIGroupSlice(groups))
+}
+
+// PostGroup (corresponds to operationId: post-group)
+// POST /v1/groups
+func (h *GroupHandler) PostGroup(c echo.Context) error {
+	var requestBody api.PostGroupJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	var description string
+	if requestBody.Description != nil {
+		description = *requestBody.Description
+	}
+
+	group, err := h.groupInteractor.CreateGroup(c.Request().Context(), requestBody.Name, description)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to create group")
+	}
+	return c.JSON(http.StatusCreated, toAPIGroup(group))
+}
+
+// DeleteGroup (corresponds to operationId: delete-group)
+// DELETE /v1/groups/{group_id}
+func (h *GroupHandler) DeleteGroup(c echo.Context, groupId openapi_types.UUID) error {
+	if err := h.groupInteractor.DeleteGroup(c.Request().Context(), groupId.String()); err != nil {
+		return toHTTPError(c, err, "Failed to delete group")
+	}
+	return c.JSON(http.StatusOK, map[string]string{})
+}
+
+// GetGroup (corresponds to operationId: get-group)
+// GET /v1/groups/{group_id}
+func (h *GroupHandler) GetGroup(c echo.Context, groupId openapi_types.UUID) error {
+	group, err := h.groupInteractor.GetGroup(c.Request().Context(), groupId.String())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve group")
+	}
+	return c.JSON(http.StatusOK, toAPIGroup(group))
+}
+
+// PatchGroup (corresponds to operationId: patch-group)
+// PATCH /v1/groups/{group_id}
+func (h *GroupHandler) PatchGroup(c echo.Context, groupId openapi_types.UUID) error {
+	var requestBody api.PatchGroupJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+
+	group, err := h.groupInteractor.UpdateGroup(c.Request().Context(), groupId.String(), requestBody.Name, requestBody.Description)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to update group")
+	}
+	return c.JSON(http.StatusOK, toAPIGroup(group))
+}
+
+// GetGroupMembers (corresponds to operationId: get-group-members)
+// GET /v1/groups/{group_id}/members
+func (h *GroupHandler) GetGroupMembers(c echo.Context, groupId openapi_types.UUID) error {
+	users, err := h.groupInteractor.ListGroupMembers(c.Request().Context(), groupId.String())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve group members")
+	}
+	return c.JSON(http.StatusOK, toAPIUserSlice(users))
+}
+
+// PostGroupMember (corresponds to operationId: post-group-member)
+// POST /v1/groups/{group_id}/members
+func (h *GroupHandler) PostGroupMember(c echo.Context, groupId openapi_types.UUID) error {
+	var requestBody api.PostGroupMemberJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+
+	if err := h.groupInteractor.AddGroupMember(c.Request().Context(), groupId.String(), requestBody.UserId.String()); err != nil {
+		return toHTTPError(c, err, "Failed to add group member")
+	}
+	return c.JSON(http.StatusCreated, map[string]string{})
+}
+
+// DeleteGroupMember (corresponds to operationId: delete-group-member)
+// DELETE /v1/groups/{group_id}/members/{user_id}
+func (h *GroupHandler) DeleteGroupMember(c echo.Context, groupId openapi_types.UUID, userId openapi_types.UUID) error {
+	if err := h.groupInteractor.RemoveGroupMember(c.Request().Context(), groupId.String(), userId.String()); err != nil {
+		return toHTTPError(c, err, "Failed to remove group member")
+	}
+	return c.JSON(http.StatusOK, map[string]string{})
+}
+
+// GetUserGroups (corresponds to operationId: get-user-groups)
+// GET /v1/users/{user_id}/groups
+func (h *GroupHandler) GetUserGroups(c echo.Context, userId openapi_types.UUID) error {
+	groups, err := h.groupInteractor.ListUserGroups(c.Request().Context(), userId.String())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve user groups")
+	}
+	return c.JSON(http.StatusOK, toAPIGroupSlice(groups))
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type groupTestEnv struct {
+	e      *echo.Echo
+	groups *mocks.MockGroupInteractor
+	token  string
+}
+
+func setupGroupTestEnv(t *testing.T) *groupTestEnv {
+	tokens := auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real())
+	env := &groupTestEnv{
+		e:      echo.New(),
+		groups: new(mocks.MockGroupInteractor),
+	}
+	var err error
+	env.token, _, err = tokens.Issue(&domain.User{ID: "admin-1", Role: domain.RoleAdmin}, true)
+	require.NoError(t, err)
+	env.e.Use(Authenticate(tokens, nil))
+	api.RegisterHandlers(env.e, &Server{GroupHandler: NewGroupHandler(env.groups)})
+	return env
+}
+
+func (env *groupTestEnv) do(method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+env.token)
+	rec := httptest.NewRecorder()
+	env.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGroupHandler_PostGroup(t *testing.T) {
+	env := setupGroupTestEnv(t)
+	groupID := uuid.NewString()
+	env.groups.On("CreateGroup", mock.Anything, "Ops", "").Return(&domain.Group{ID: groupID, Name: "Ops", CreatedAt: time.Now(), UpdatedAt: time.Now()}, nil).Once()
+
+	rec := env.do(http.MethodPost, "/v1/groups", `{"name":"Ops"}`)
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var got api.Group
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, groupID, got.Id.String())
+	assert.Equal(t, "Ops", got.Name)
+}
+
+func TestGroupHandler_GetGroup_NotFound(t *testing.T) {
+	env := setupGroupTestEnv(t)
+	env.groups.On("GetGroup", mock.Anything, mock.Anything).Return(nil, domain.ErrGroupNotFound).Once()
+
+	rec := env.do(http.MethodGet, "/v1/groups/"+uuid.NewString(), "")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Group not found")
+}
+
+func TestGroupHandler_PostGroupMember(t *testing.T) {
+	env := setupGroupTestEnv(t)
+	groupID, userID := uuid.NewString(), uuid.NewString()
+	env.groups.On("AddGroupMember", mock.Anything, groupID, userID).Return(nil).Once()
+	env.groups.On("AddGroupMember", mock.Anything, groupID, userID).Return(domain.ErrConflict).Once()
+
+	rec := env.do(http.MethodPost, "/v1/groups/"+groupID+"/members", `{"user_id":"`+userID+`"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	rec = env.do(http.MethodPost, "/v1/groups/"+groupID+"/members", `{"user_id":"`+userID+`"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestGroupHandler_DeleteGroupMember(t *testing.T) {
+	env := setupGroupTestEnv(t)
+	groupID, userID := uuid.NewString(), uuid.NewString()
+	env.groups.On("RemoveGroupMember", mock.Anything, groupID, userID).Return(nil).Once()
+
+	rec := env.do(http.MethodDelete, "/v1/groups/"+groupID+"/members/"+userID, "")
+
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	env.groups.AssertExpectations(t)
+}
+
+func TestGroupHandler_GetUserGroups(t *testing.T) {
+	env := setupGroupTestEnv(t)
+	userID := uuid.NewString()
+	env.groups.On("ListUserGroups", mock.Anything, userID).Return([]domain.Group{{ID: uuid.NewString(), Name: "Ops"}}, nil).Once()
+
+	rec := env.do(http.MethodGet, "/v1/users/"+userID+"/groups", "")
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var got []api.Group
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "Ops", got[0].Name)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// InvitationHandler handles HTTP requests for organization invitations.
+type InvitationHandler struct {
+	invitationInteractor usecases.InvitationInteractor
+}
+
+// NewInvitationHandler creates a new InvitationHandler.
+func NewInvitationHandler(uc usecases.InvitationInteractor) *InvitationHandler {
+	return &InvitationHandler{invitationInteractor: uc}
+}
+
+func toAPIInvitation(inv *domain.Invitation) api.Invitation {
+	out := api.Invitation{
+		Id:             uuid.MustParse(inv.ID),
+		OrganizationId: uuid.MustParse(inv.OrganizationID),
+		Email:          openapi_types.Email(inv.Email),
+		Role:           api.OrganizationRole(inv.Role),
+		Status:         api.InvitationStatus(inv.Status(time.Now())),
+		ExpiresAt:      inv.ExpiresAt,
+		CreatedAt:      inv.CreatedAt,
+	}
+	if inv.InvitedBy != "" {
+		invitedBy := uuid.MustParse(inv.InvitedBy)
+		out.InvitedBy = &invitedBy
+	}
+	return out
+}
+
+// PostInvitation (corresponds to operationId: post-invitation)
+// POST /v1/invitations
+func (h *InvitationHandler) PostInvitation(c echo.Context) error {
+	var requestBody api.PostInvitationJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	var role string
+	if requestBody.Role != nil {
+		role = string(*requestBody.Role)
+	}
+
+	inv, err := h.invitationInteractor.CreateInvitation(c.Request().Context(), requestBody.OrganizationId.String(), string(requestBody.Email), role)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to create invitation")
+	}
+	return c.JSON(http.StatusCreated, toAPIInvitation(inv))
+}
+
+// PostInvitationAccept (corresponds to operationId: post-invitation-accept)
+// POST /v1/invitations/accept
+func (h *InvitationHandler) PostInvitationAccept(c echo.Context) error {
+	var requestBody api.PostInvitationAcceptJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	var name, password string
+	if requestBody.Name != nil {
+		name = *requestBody.Name
+	}
+	if requestBody.Password != nil {
+		password = *requestBody.Password
+	}
+
+	member, err := h.invitationInteractor.AcceptInvitation(c.Request().Context(), requestBody.Token, name, password)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to accept invitation")
+	}
+	return c.JSON(http.StatusCreated, toAPIOrganizationMember(member))
+}
+
+// DeleteInvitation (corresponds to operationId: delete-invitation)
+// DELETE /v1/invitations/{invitation_id}
+func (h *InvitationHandler) DeleteInvitation(c echo.Context, invitationId openapi_types.UUID) error {
+	if err := h.invitationInteractor.RevokeInvitation(c.Request().Context(), invitationId.String()); err != nil {
+		return toHTTPError(c, err, "Failed to revoke invitation")
+	}
+	return c.JSON(http.StatusOK, map[string]string{})
+}
+
+// PostInvitationResend (corresponds to operationId: post-invitation-resend)
+// POST /v1/invitations/{invitation_id}/resend
+func (h *InvitationHandler) PostInvitationResend(c echo.Context, invitationId openapi_types.UUID) error {
+	inv, err := h.invitationInteractor.ResendInvitation(c.Request().Context(), invitationId.String())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to resend invitation")
+	}
+	return c.JSON(http.StatusOK, toAPIInvitation(inv))
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type invitationTestEnv struct {
+	e           *echo.Echo
+	invitations *mocks.MockInvitationInteractor
+	token       string
+}
+
+func setupInvitationTestEnv(t *testing.T) *invitationTestEnv {
+	tokens := auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real())
+	env := &invitationTestEnv{
+		e:           echo.New(),
+		invitations: new(mocks.MockInvitationInteractor),
+	}
+	var err error
+	env.token, _, err = tokens.Issue(&domain.User{ID: "user-1", Role: domain.RoleUser}, false)
+	require.NoError(t, err)
+	env.e.Use(Authenticate(tokens, nil))
+	api.RegisterHandlers(env.e, &Server{InvitationHandler: NewInvitationHandler(env.invitations)})
+	return env
+}
+
+func (env *invitationTestEnv) do(method, target, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	env.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestInvitationHandler_PostInvitation(t *testing.T) {
+	env := setupInvitationTestEnv(t)
+	invID, orgID := uuid.NewString(), uuid.NewString()
+	inv := &domain.Invitation{
+		ID:             invID,
+		OrganizationID: orgID,
+		Email:          "bob@example.com",
+		Role:           domain.OrgRoleAdmin,
+		InvitedBy:      "8f14e45f-ceea-467f-a0b6-1f5e1a8c9d2b",
+		ExpiresAt:      time.Now().Add(time.Hour),
+		CreatedAt:      time.Now(),
+	}
+	env.invitations.On("CreateInvitation", mock.Anything, orgID, "bob@example.com", domain.OrgRoleAdmin).Return(inv, nil).Once()
+
+	rec := env.do(http.MethodPost, "/v1/invitations", `{"organization_id":"`+orgID+`","email":"bob@example.com","role":"admin"}`, env.token)
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var got api.Invitation
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, invID, got.Id.String())
+	assert.Equal(t, api.Pending, got.Status)
+	require.NotNil(t, got.InvitedBy)
+	assert.Equal(t, inv.InvitedBy, got.InvitedBy.String())
+	assert.NotContains(t, rec.Body.String(), "token")
+}
+
+func TestInvitationHandler_PostInvitationAccept(t *testing.T) {
+	env := setupInvitationTestEnv(t)
+	userID := uuid.NewString()
+	member := &domain.OrganizationMember{User: domain.User{ID: userID, Name: "Bob", Email: "bob@example.com"}, Role: domain.OrgRoleMember, JoinedAt: time.Now()}
+	env.invitations.On("AcceptInvitation", mock.Anything, "the-token", "Bob", "secret").Return(member, nil).Once()
+
+	// Invitees without an account are not signed in
+	rec := env.do(http.MethodPost, "/v1/invitations/accept", `{"token":"the-token","name":"Bob","password":"secret"}`, "")
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var got api.OrganizationMember
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, userID, got.Id.String())
+	assert.Equal(t, api.Member, got.Role)
+}
+
+func TestInvitationHandler_PostInvitationAccept_Invalid(t *testing.T) {
+	env := setupInvitationTestEnv(t)
+	env.invitations.On("AcceptInvitation", mock.Anything, "stale", "", "").Return(nil, domain.ErrInvitationInvalid).Once()
+
+	rec := env.do(http.MethodPost, "/v1/invitations/accept", `{"token":"stale"}`, "")
+	assert.Equal(t, http.StatusGone, rec.Code)
+}
+
+func TestInvitationHandler_DeleteInvitation(t *testing.T) {
+	env := setupInvitationTestEnv(t)
+	invID := uuid.NewString()
+	env.invitations.On("RevokeInvitation", mock.Anything, invID).Return(nil).Once()
+	env.invitations.On("RevokeInvitation", mock.Anything, mock.Anything).Return(domain.ErrInvitationNotFound).Once()
+
+	rec := env.do(http.MethodDelete, "/v1/invitations/"+invID, "", env.token)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = env.do(http.MethodDelete, "/v1/invitations/"+uuid.NewString(), "", env.token)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestInvitationHandler_PostInvitationResend(t *testing.T) {
+	env := setupInvitationTestEnv(t)
+	invID := uuid.NewString()
+	env.invitations.On("ResendInvitation", mock.Anything, invID).Return(nil, domain.ErrConflict).Once()
+
+	rec := env.do(http.MethodPost, "/v1/invitations/"+invID+"/resend", "", env.token)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// JobHandler handles HTTP requests for scheduled jobs.
+type JobHandler struct {
+	jobInteractor usecases.JobInteractor
+}
+
+// NewJobHandler creates a new JobHandler.
+func NewJobHandler(uc usecases.JobInteractor) *JobHandler {
+	return &JobHandler{jobInteractor: uc}
+}
+
+// toAPIJob maps domain.JobStatus to api.Job.
+func toAPIJob(s *domain.JobStatus) api.Job {
+	out := api.Job{Name: s.Name, Schedule: s.Schedule}
+	if !s.NextRunAt.IsZero() {
+		next := s.NextRunAt
+		out.NextRunAt = &next
+	}
+	if r := s.LastRun; r != nil {
+		run := api.JobRun{
+			Id:          uuid.MustParse(r.ID),
+			ScheduledAt: r.ScheduledAt,
+			Instance:    r.Instance,
+			Status:      api.JobRunStatus(r.Status),
+			Error:       optionalString(r.Error),
+			StartedAt:   r.StartedAt,
+		}
+		if len(r.Result) > 0 {
+			result := r.Result
+			run.Result = &result
+		}
+		if !r.FinishedAt.IsZero() {
+			finished := r.FinishedAt
+			run.FinishedAt = &finished
+		}
+		out.LastRun = &run
+	}
+	return out
+}
+
+// GetJobs (corresponds to operationId: get-jobs)
+// GET /v1/jobs
+func (h *JobHandler) GetJobs(c echo.Context) error {
+	statuses, err := h.jobInteractor.ListJobs(c.Request().Context())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve jobs")
+	}
+	jobs := make([]api.Job, len(statuses))
+	for i := range statuses {
+		jobs[i] = toAPIJob(&statuses[i])
+	}
+	return c.JSON(http.StatusOK, jobs)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupJobTestEnv() (*echo.Echo, *mocks.MockJobInteractor, auth.TokenService) {
+	e := echo.New()
+	mockJobs := new(mocks.MockJobInteractor)
+	tokens := auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real())
+	e.Use(Authenticate(tokens, nil))
+	api.RegisterHandlers(e, &Server{JobHandler: NewJobHandler(mockJobs)})
+	return e, mockJobs, tokens
+}
+
+func TestJobHandler_GetJobs(t *testing.T) {
+	e, mockJobs, tokens := setupJobTestEnv()
+	runID := uuid.New()
+	next := time.Date(2026, 10, 20, 3, 0, 0, 0, time.UTC)
+	started := time.Date(2026, 10, 19, 3, 0, 1, 0, time.UTC)
+
+	mockJobs.On("ListJobs", mock.Anything).Return([]domain.JobStatus{
+		{ScheduledJob: domain.ScheduledJob{Name: "retention.audit_events", Schedule: "0 3 * * *", NextRunAt: next}},
+		{
+			ScheduledJob: domain.ScheduledJob{Name: "retention.outbox_events", Schedule: "0 3 * * *", NextRunAt: next},
+			LastRun: &domain.JobRun{
+				ID: runID.String(), JobName: "retention.outbox_events", ScheduledAt: next.AddDate(0, 0, -1),
+				Instance: "api-1", Status: domain.JobRunSucceeded, Result: map[string]int64{"deleted": 12},
+				StartedAt: started, FinishedAt: started.Add(time.Second),
+			},
+		},
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/jobs", nil)
+	req.Header.Set(echo.HeaderAuthorization, bearer(t, tokens, &domain.User{ID: uuid.NewString(), Role: domain.RoleAdmin}))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp []api.Job
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	if assert.Len(t, resp, 2) {
+		assert.Nil(t, resp[0].LastRun)
+		assert.Equal(t, next, *resp[0].NextRunAt)
+		run := resp[1].LastRun
+		if assert.NotNil(t, run) {
+			assert.Equal(t, runID, run.Id)
+			assert.Equal(t, api.JobRunStatusSucceeded, run.Status)
+			assert.Equal(t, map[string]int64{"deleted": 12}, *run.Result)
+			assert.Nil(t, run.Error)
+			assert.NotNil(t, run.FinishedAt)
+		}
+	}
+	mockJobs.AssertExpectations(t)
+}
+
+func TestJobHandler_GetJobs_Forbidden(t *testing.T) {
+	e, mockJobs, tokens := setupJobTestEnv()
+	mockJobs.On("ListJobs", mock.Anything).Return(nil, domain.ErrForbidden).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/jobs", nil)
+	req.Header.Set(echo.HeaderAuthorization, bearer(t, tokens, &domain.User{ID: uuid.NewString(), Role: domain.RoleUser}))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/auth"
+	"apiserver/internal/domain"
+	"apiserver/internal/tenant"
+	"apiserver/internal/usecases"
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAPIKey carries an API key as an alternative to "Authorization: Bearer".
+const HeaderAPIKey = "X-API-Key"
+
+// APIKeyAuthenticator resolves API keys to principals. It is satisfied by usecases.APIKeyInteractor.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, rawKey string) (*auth.Principal, error)
+}
+
+// Authenticate resolves the caller from an "Authorization: Bearer" header
+// (a JWT or an API key) or an "X-API-Key" header and stores the principal in
+// the request context. Requests without credentials continue anonymously;
+// operations that need a caller enforce that themselves. So do requests
+// carrying OAuth client credentials or OAuth access tokens, which only the
+// OpenID provider endpoints accept.
+func Authenticate(tokens auth.TokenService, apiKeys APIKeyAuthenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			apiKey := c.Request().Header.Get(HeaderAPIKey)
+			if header == "" && apiKey == "" {
+				return next(c)
+			}
+			if header != "" && apiKey != "" {
+				return echo.NewHTTPError(http.StatusBadRequest, "Send either an Authorization or an X-API-Key header, not both")
+			}
+
+			var principal *auth.Principal
+			var err error
+			if apiKey != "" {
+				principal, err = authenticateAPIKey(c, apiKeys, apiKey)
+			} else {
+				scheme, token, ok := strings.Cut(header, " ")
+				// OAuth clients authenticate to the token endpoint with Basic, and
+				// their access tokens are only good for the userinfo endpoint;
+				// both handlers check the credentials themselves
+				if ok && (strings.EqualFold(scheme, "Basic") || strings.EqualFold(scheme, "Bearer") && usecases.IsOAuthAccessToken(token)) {
+					return next(c)
+				}
+				if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+					return echo.NewHTTPError(http.StatusUnauthorized, "Invalid authorization header")
+				}
+				if usecases.IsAPIKey(token) {
+					principal, err = authenticateAPIKey(c, apiKeys, token)
+				} else if principal, err = tokens.Verify(token); err != nil {
+					err = echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			ctx := auth.WithPrincipal(c.Request().Context(), principal)
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+func authenticateAPIKey(c echo.Context, apiKeys APIKeyAuthenticator, key string) (*auth.Principal, error) {
+	if apiKeys == nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "API keys are not accepted")
+	}
+	principal, err := apiKeys.AuthenticateAPIKey(c.Request().Context(), key)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid, expired or revoked API key")
+		}
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to verify API key: "+err.Error())
+	}
+	return principal, nil
+}
+
+// RequestInfo stores the request ID and client IP in the request context so
+// audit events can be correlated with the request that caused them. It must
+// run after middleware.RequestID.
+func RequestInfo() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := audit.WithRequestInfo(c.Request().Context(), audit.RequestInfo{
+				RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+				IP:        c.RealIP(),
+			})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// HeaderOrganizationID selects the organization a request acts within.
+const HeaderOrganizationID = "X-Organization-ID"
+
+// TenantResolver checks that the caller belongs to an organization. It is
+// satisfied by usecases.OrganizationInteractor.
+type TenantResolver interface {
+	ResolveTenant(ctx context.Context, orgID string) (*tenant.Tenant, error)
+}
+
+// ResolveTenant selects the organization named by the "X-Organization-ID"
+// header or by the token's org claim, checks that the caller belongs to it and
+// stores it in the request context. Requests naming no organization continue
+// without a tenant; org-scoped operations refuse them. It must run after
+// Authenticate.
+func ResolveTenant(resolver TenantResolver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			orgID := c.Request().Header.Get(HeaderOrganizationID)
+			if p := auth.PrincipalFrom(c.Request().Context()); p != nil && p.OrgID != "" {
+				if orgID != "" && orgID != p.OrgID {
+					return echo.NewHTTPError(http.StatusBadRequest, "The X-Organization-ID header does not match the organization of the token")
+				}
+				orgID = p.OrgID
+			}
+			if orgID == "" {
+				return next(c)
+			}
+
+			t, err := resolver.ResolveTenant(c.Request().Context(), orgID)
+			if err != nil {
+				return toHTTPError(c, err, "Failed to resolve organization")
+			}
+			ctx := tenant.WithTenant(c.Request().Context(), t)
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// OAuthClientHandler handles HTTP requests for registering the internal
+// applications that sign users in with this service.
+type OAuthClientHandler struct {
+	oauthClientInteractor usecases.OAuthClientInteractor
+}
+
+// NewOAuthClientHandler creates a new OAuthClientHandler.
+func NewOAuthClientHandler(uc usecases.OAuthClientInteractor) *OAuthClientHandler {
+	return &OAuthClientHandler{oauthClientInteractor: uc}
+}
+
+// toAPIOauthClient maps domain.OAuthClient to api.OauthClient. The secret hash is never exposed.
+func toAPIOauthClient(c *domain.OAuthClient) api.OauthClient {
+	return api.OauthClient{
+		Id:           uuid.MustParse(c.ID),
+		Name:         c.Name,
+		RedirectUris: c.RedirectURIs,
+		Public:       c.IsPublic(),
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+// GetOauthClients (corresponds to operationId: get-oauth-clients)
+// GET /v1/oauth/clients
+func (h *OAuthClientHandler) GetOauthClients(c echo.Context) error {
+	clients, err := h.oauthClientInteractor.ListOAuthClients(c.Request().Context())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve OAuth clients")
+	}
+	out := make([]api.OauthClient, len(clients))
+	for i := range clients {
+		out[i] = toAPIOauthClient(&clients[i])
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// PostOauthClient (corresponds to operationId: post-oauth-client)
+// POST /v1/oauth/clients
+func (h *OAuthClientHandler) PostOauthClient(c echo.Context) error {
+	var requestBody api.PostOauthClientJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+
+	in := usecases.OAuthClientInput{
+		Name:         requestBody.Name,
+		RedirectURIs: requestBody.RedirectUris,
+	}
+	if requestBody.Public != nil {
+		in.Public = *requestBody.Public
+	}
+
+	client, secret, err := h.oauthClientInteractor.CreateOAuthClient(c.Request().Context(), in)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to create OAuth client")
+	}
+	return c.JSON(http.StatusCreated, api.CreatedOauthClient{
+		Client:       toAPIOauthClient(client),
+		ClientSecret: optionalString(secret),
+	})
+}
+
+// GetOauthClient (corresponds to operationId: get-oauth-client)
+// GET /v1/oauth/clients/{client_id}
+func (h *OAuthClientHandler) GetOauthClient(c echo.Context, clientId openapi_types.UUID) error {
+	client, err := h.oauthClientInteractor.GetOAuthClient(c.Request().Context(), clientId.String())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve OAuth client")
+	}
+	return c.JSON(http.StatusOK, toAPIOauthClient(client))
+}
+
+// DeleteOauthClient (corresponds to operationId: delete-oauth-client)
+// DELETE /v1/oauth/clients/{client_id}
+func (h *OAuthClientHandler) DeleteOauthClient(c echo.Context, clientId openapi_types.UUID) error {
+	if err := h.oauthClientInteractor.DeleteOAuthClient(c.Request().Context(), clientId.String()); err != nil {
+		return toHTTPError(c, err, "Failed to delete OAuth client")
+	}
+	return c.JSON(http.StatusOK, map[string]string{})
+}
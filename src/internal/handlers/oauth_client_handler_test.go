@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOAuthClientTestEnv() (*echo.Echo, *mocks.MockOAuthClientInteractor) {
+	e := echo.New()
+	mockClients := new(mocks.MockOAuthClientInteractor)
+	api.RegisterHandlers(e, &Server{OAuthClientHandler: NewOAuthClientHandler(mockClients)})
+	return e, mockClients
+}
+
+func TestOAuthClientHandler_PostOauthClient_Success(t *testing.T) {
+	e, mockClients := setupOAuthClientTestEnv()
+	clientID := uuid.New()
+
+	mockClients.On("CreateOAuthClient", mock.Anything, usecases.OAuthClientInput{
+		Name:         "Wiki",
+		RedirectURIs: []string{"https://wiki.example.com/cb"},
+	}).Return(&domain.OAuthClient{
+		ID:           clientID.String(),
+		Name:         "Wiki",
+		RedirectURIs: []string{"https://wiki.example.com/cb"},
+		SecretHash:   "stored-hash",
+		CreatedAt:    time.Now(),
+	}, "ucs_secret", nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/v1/oauth/clients", api.OauthClientInfo{Name: "Wiki", RedirectUris: []string{"https://wiki.example.com/cb"}}))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var created api.CreatedOauthClient
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	require.NotNil(t, created.ClientSecret)
+	assert.Equal(t, "ucs_secret", *created.ClientSecret)
+	assert.Equal(t, clientID, created.Client.Id)
+	assert.False(t, created.Client.Public)
+	assert.NotContains(t, rec.Body.String(), "stored-hash")
+	mockClients.AssertExpectations(t)
+}
+
+func TestOAuthClientHandler_PostOauthClient_Public(t *testing.T) {
+	e, mockClients := setupOAuthClientTestEnv()
+	public := true
+
+	mockClients.On("CreateOAuthClient", mock.Anything, usecases.OAuthClientInput{
+		Name:         "CLI",
+		RedirectURIs: []string{"http://127.0.0.1:7777/cb"},
+		Public:       true,
+	}).Return(&domain.OAuthClient{ID: uuid.NewString(), Name: "CLI", RedirectURIs: []string{"http://127.0.0.1:7777/cb"}}, "", nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/v1/oauth/clients", api.OauthClientInfo{Name: "CLI", RedirectUris: []string{"http://127.0.0.1:7777/cb"}, Public: &public}))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "client_secret")
+	assert.Contains(t, rec.Body.String(), `"public":true`)
+}
+
+func TestOAuthClientHandler_PostOauthClient_Forbidden(t *testing.T) {
+	e, mockClients := setupOAuthClientTestEnv()
+	mockClients.On("CreateOAuthClient", mock.Anything, mock.Anything).Return(nil, "", domain.ErrForbidden).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/v1/oauth/clients", api.OauthClientInfo{Name: "Wiki", RedirectUris: []string{"https://wiki.example.com/cb"}}))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestOAuthClientHandler_GetOauthClient_NotFound(t *testing.T) {
+	e, mockClients := setupOAuthClientTestEnv()
+	clientID := uuid.New()
+	mockClients.On("GetOAuthClient", mock.Anything, clientID.String()).Return(nil, domain.ErrOAuthClientNotFound).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/oauth/clients/"+clientID.String(), nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "OAuth client not found")
+}
+
+func TestOAuthClientHandler_DeleteOauthClient(t *testing.T) {
+	e, mockClients := setupOAuthClientTestEnv()
+	clientID := uuid.New()
+	mockClients.On("DeleteOAuthClient", mock.Anything, clientID.String()).Return(nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/v1/oauth/clients/"+clientID.String(), nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockClients.AssertExpectations(t)
+}
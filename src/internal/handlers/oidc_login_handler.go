@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/labstack/echo/v4"
+)
+
+// oidcStateCookie ties the callback of a sign-in at an identity provider to
+// the browser that started it, so that a callback URL carrying someone
+// else's code cannot sign the victim in to the attacker's account.
+const oidcStateCookie = "oidc_state"
+
+// OIDCLoginHandler handles HTTP requests for signing in through external
+// identity providers.
+type OIDCLoginHandler struct {
+	oidcLoginInteractor usecases.OIDCLoginInteractor
+}
+
+// NewOIDCLoginHandler creates a new OIDCLoginHandler.
+func NewOIDCLoginHandler(uc usecases.OIDCLoginInteractor) *OIDCLoginHandler {
+	return &OIDCLoginHandler{oidcLoginInteractor: uc}
+}
+
+// stateCookie returns the cookie holding value for the callback of provider.
+// An empty value deletes it.
+func stateCookie(c echo.Context, provider, value string) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    value,
+		Path:     "/v1/login/oidc/" + provider,
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode, // Sent on the top-level redirect back from the provider
+	}
+	if value == "" {
+		cookie.MaxAge = -1
+	}
+	return cookie
+}
+
+// GetLoginOidc (corresponds to operationId: get-login-oidc)
+// GET /v1/login/oidc/{provider}
+func (h *OIDCLoginHandler) GetLoginOidc(c echo.Context, provider string) error {
+	authURL, state, err := h.oidcLoginInteractor.BeginOIDCLogin(c.Request().Context(), provider)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to start signing in")
+	}
+	c.SetCookie(stateCookie(c, provider, state))
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+// GetLoginOidcCallback (corresponds to operationId: get-login-oidc-callback)
+// GET /v1/login/oidc/{provider}/callback
+func (h *OIDCLoginHandler) GetLoginOidcCallback(c echo.Context, provider string, params api.GetLoginOidcCallbackParams) error {
+	if params.Error != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "The identity provider refused the sign-in: "+*params.Error)
+	}
+	if params.Code == nil || params.State == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "code and state are required")
+	}
+	cookie, err := c.Cookie(oidcStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(*params.State)) != 1 {
+		return toHTTPError(c, domain.ErrExternalLoginFailed, "Failed to sign in")
+	}
+	c.SetCookie(stateCookie(c, provider, ""))
+
+	result, err := h.oidcLoginInteractor.CompleteOIDCLogin(c.Request().Context(), provider, *params.State, *params.Code)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to sign in")
+	}
+
+	return c.JSON(http.StatusOK, toAPILoginResult(result))
+}
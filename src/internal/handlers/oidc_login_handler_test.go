@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"apiserver/internal/usecases/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOIDCLoginTestEnv() (*echo.Echo, *mocks.MockOIDCLoginInteractor) {
+	e := echo.New()
+	mockInteractor := new(mocks.MockOIDCLoginInteractor)
+	tokens := auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real())
+	e.Use(Authenticate(tokens, nil))
+	api.RegisterHandlers(e, &Server{OIDCLoginHandler: NewOIDCLoginHandler(mockInteractor)})
+	return e, mockInteractor
+}
+
+func newOIDCCallbackRequest(query, cookieState string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/v1/login/oidc/corp/callback?"+query, nil)
+	if cookieState != "" {
+		req.AddCookie(&http.Cookie{Name: oidcStateCookie, Value: cookieState})
+	}
+	return req
+}
+
+func TestOIDCLoginHandler_GetLoginOidc(t *testing.T) {
+	e, mockInteractor := setupOIDCLoginTestEnv()
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("BeginOIDCLogin", mock.Anything, "corp").
+		Return("https://idp.example.com/authorize?state=state-1", "state-1", nil).Once()
+
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/login/oidc/corp", nil))
+
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://idp.example.com/authorize?state=state-1", rec.Header().Get(echo.HeaderLocation))
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, oidcStateCookie, cookies[0].Name)
+	assert.Equal(t, "state-1", cookies[0].Value)
+	assert.Equal(t, "/v1/login/oidc/corp", cookies[0].Path)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+}
+
+func TestOIDCLoginHandler_GetLoginOidc_UnknownProvider(t *testing.T) {
+	e, mockInteractor := setupOIDCLoginTestEnv()
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("BeginOIDCLogin", mock.Anything, "other").Return("", "", domain.ErrIdentityProviderNotFound).Once()
+
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/login/oidc/other", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestOIDCLoginHandler_GetLoginOidcCallback(t *testing.T) {
+	e, mockInteractor := setupOIDCLoginTestEnv()
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("CompleteOIDCLogin", mock.Anything, "corp", "state-1", "code-1").Return(&usecases.LoginResult{
+		User:        &domain.User{ID: "user-1"},
+		AccessToken: "token-value",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}, nil).Once()
+
+	e.ServeHTTP(rec, newOIDCCallbackRequest("code=code-1&state=state-1", "state-1"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var result api.LoginResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	if assert.NotNil(t, result.AccessToken) {
+		assert.Equal(t, "token-value", *result.AccessToken)
+	}
+	// The state cookie is spent
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, -1, cookies[0].MaxAge)
+}
+
+func TestOIDCLoginHandler_GetLoginOidcCallback_MFARequired(t *testing.T) {
+	e, mockInteractor := setupOIDCLoginTestEnv()
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("CompleteOIDCLogin", mock.Anything, "corp", "state-1", "code-1").Return(&usecases.LoginResult{
+		User:        &domain.User{ID: "user-1"},
+		MFARequired: true,
+		MFAToken:    "challenge",
+	}, nil).Once()
+
+	e.ServeHTTP(rec, newOIDCCallbackRequest("code=code-1&state=state-1", "state-1"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var result api.LoginResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.True(t, result.MfaRequired)
+	assert.Nil(t, result.AccessToken)
+}
+
+func TestOIDCLoginHandler_GetLoginOidcCallback_StateCookieMismatch(t *testing.T) {
+	for name, cookie := range map[string]string{"missing": "", "other browser": "state-2"} {
+		t.Run(name, func(t *testing.T) {
+			e, mockInteractor := setupOIDCLoginTestEnv()
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, newOIDCCallbackRequest("code=code-1&state=state-1", cookie))
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			mockInteractor.AssertNotCalled(t, "CompleteOIDCLogin", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOIDCLoginHandler_GetLoginOidcCallback_ProviderError(t *testing.T) {
+	e, mockInteractor := setupOIDCLoginTestEnv()
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, newOIDCCallbackRequest("error=access_denied&state=state-1", "state-1"))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "access_denied")
+	mockInteractor.AssertNotCalled(t, "CompleteOIDCLogin", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOIDCLoginHandler_GetLoginOidcCallback_NotLinked(t *testing.T) {
+	e, mockInteractor := setupOIDCLoginTestEnv()
+	rec := httptest.NewRecorder()
+
+	mockInteractor.On("CompleteOIDCLogin", mock.Anything, "corp", "state-1", "code-1").Return(nil, domain.ErrIdentityNotLinked).Once()
+
+	e.ServeHTTP(rec, newOIDCCallbackRequest("code=code-1&state=state-1", "state-1"))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/oidc"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// OIDCProviderHandler handles HTTP requests for acting as the OpenID provider
+// of internal applications.
+type OIDCProviderHandler struct {
+	oidcProviderInteractor usecases.OIDCProviderInteractor
+}
+
+// NewOIDCProviderHandler creates a new OIDCProviderHandler.
+func NewOIDCProviderHandler(uc usecases.OIDCProviderInteractor) *OIDCProviderHandler {
+	return &OIDCProviderHandler{oidcProviderInteractor: uc}
+}
+
+// stringValue returns the empty string for an omitted parameter.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func toAPIOauthConsent(c *domain.OAuthConsent) api.OauthConsent {
+	return api.OauthConsent{
+		ClientId:   uuid.MustParse(c.ClientID),
+		ClientName: c.ClientName,
+		Scopes:     c.Scopes,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+// oauthError writes an error response of the token endpoint (RFC 6749 §5.2).
+func oauthError(c echo.Context, e *domain.OAuthError) error {
+	status := http.StatusBadRequest
+	if e.Code == domain.OAuthErrInvalidClient {
+		status = http.StatusUnauthorized
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="oauth"`)
+	}
+	return c.JSON(status, api.OauthError{Error: e.Code, ErrorDescription: optionalString(e.Description)})
+}
+
+// GetOpenidConfiguration (corresponds to operationId: get-openid-configuration)
+// GET /.well-known/openid-configuration
+func (h *OIDCProviderHandler) GetOpenidConfiguration(c echo.Context) error {
+	m := h.oidcProviderInteractor.Discovery()
+	return c.JSON(http.StatusOK, api.OpenidConfiguration{
+		Issuer:                            m.Issuer,
+		AuthorizationEndpoint:             m.AuthorizationEndpoint,
+		TokenEndpoint:                     m.TokenEndpoint,
+		UserinfoEndpoint:                  m.UserinfoEndpoint,
+		JwksUri:                           m.JWKSURI,
+		ScopesSupported:                   m.ScopesSupported,
+		ResponseTypesSupported:            m.ResponseTypesSupported,
+		GrantTypesSupported:               m.GrantTypesSupported,
+		SubjectTypesSupported:             m.SubjectTypesSupported,
+		IdTokenSigningAlgValuesSupported:  m.IDTokenSigningAlgValuesSupported,
+		TokenEndpointAuthMethodsSupported: m.TokenEndpointAuthMethodsSupported,
+		CodeChallengeMethodsSupported:     m.CodeChallengeMethodsSupported,
+		ClaimsSupported:                   m.ClaimsSupported,
+	})
+}
+
+// GetJwks (corresponds to operationId: get-jwks)
+// GET /.well-known/jwks.json
+func (h *OIDCProviderHandler) GetJwks(c echo.Context) error {
+	keys, err := h.oidcProviderInteractor.PublicKeys(c.Request().Context())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve signing keys")
+	}
+	set := api.JwkSet{Keys: make([]api.Jwk, len(keys))}
+	for i, k := range keys {
+		set.Keys[i] = api.Jwk{
+			Kty: k.Kty,
+			Kid: k.Kid,
+			Use: optionalString(k.Use),
+			Alg: optionalString(k.Alg),
+			N:   optionalString(k.N),
+			E:   optionalString(k.E),
+			Crv: optionalString(k.Crv),
+			X:   optionalString(k.X),
+			Y:   optionalString(k.Y),
+		}
+	}
+	// New keys are published this long before they sign, so cached sets know them
+	c.Response().Header().Set(echo.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(oidc.JWKSMaxAge.Seconds())))
+	return c.JSON(http.StatusOK, set)
+}
+
+// GetOauthAuthorize (corresponds to operationId: get-oauth-authorize)
+// GET /v1/oauth/authorize
+func (h *OIDCProviderHandler) GetOauthAuthorize(c echo.Context, params api.GetOauthAuthorizeParams) error {
+	location, err := h.oidcProviderInteractor.BeginAuthorization(c.Request().Context(), usecases.AuthorizationRequest{
+		ResponseType:        stringValue(params.ResponseType),
+		ClientID:            stringValue(params.ClientId),
+		RedirectURI:         stringValue(params.RedirectUri),
+		Scope:               stringValue(params.Scope),
+		State:               stringValue(params.State),
+		Nonce:               stringValue(params.Nonce),
+		CodeChallenge:       stringValue(params.CodeChallenge),
+		CodeChallengeMethod: stringValue(params.CodeChallengeMethod),
+		Prompt:              stringValue(params.Prompt),
+	})
+	if err != nil {
+		return toHTTPError(c, err, "Failed to process the authorization request")
+	}
+	return c.Redirect(http.StatusFound, location)
+}
+
+// PostOauthAuthorize (corresponds to operationId: post-oauth-authorize)
+// POST /v1/oauth/authorize
+func (h *OIDCProviderHandler) PostOauthAuthorize(c echo.Context) error {
+	var requestBody api.PostOauthAuthorizeJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+
+	result, err := h.oidcProviderInteractor.Authorize(c.Request().Context(), usecases.AuthorizationRequest{
+		ResponseType:        requestBody.ResponseType,
+		ClientID:            requestBody.ClientId,
+		RedirectURI:         requestBody.RedirectUri,
+		Scope:               requestBody.Scope,
+		State:               stringValue(requestBody.State),
+		Nonce:               stringValue(requestBody.Nonce),
+		CodeChallenge:       stringValue(requestBody.CodeChallenge),
+		CodeChallengeMethod: stringValue(requestBody.CodeChallengeMethod),
+		Prompt:              stringValue(requestBody.Prompt),
+	}, requestBody.Approve)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to authorize the client")
+	}
+
+	out := api.OauthAuthorizationResult{
+		ConsentRequired: result.ConsentRequired,
+		RedirectTo:      optionalString(result.RedirectURI),
+	}
+	if result.Client != nil {
+		client := toAPIOauthClient(result.Client)
+		out.Client = &client
+	}
+	if result.Scopes != nil {
+		out.Scopes = &result.Scopes
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// PostOauthToken (corresponds to operationId: post-oauth-token)
+// POST /v1/oauth/token
+func (h *OIDCProviderHandler) PostOauthToken(c echo.Context) error {
+	// Tokens and errors alike must not be cached (RFC 6749 §5.1)
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	req := usecases.TokenRequest{
+		GrantType:    c.FormValue("grant_type"),
+		Code:         c.FormValue("code"),
+		RedirectURI:  c.FormValue("redirect_uri"),
+		CodeVerifier: c.FormValue("code_verifier"),
+		ClientID:     c.FormValue("client_id"),
+		ClientSecret: c.FormValue("client_secret"),
+	}
+	if id, secret, ok := c.Request().BasicAuth(); ok {
+		// Both halves are form-encoded before being joined (RFC 6749 §2.3.1)
+		var idErr, secretErr error
+		id, idErr = url.QueryUnescape(id)
+		secret, secretErr = url.QueryUnescape(secret)
+		if idErr != nil || secretErr != nil || req.ClientSecret != "" || (req.ClientID != "" && req.ClientID != id) {
+			return oauthError(c, &domain.OAuthError{Code: domain.OAuthErrInvalidRequest, Description: "use exactly one client authentication method"})
+		}
+		req.ClientID, req.ClientSecret = id, secret
+	}
+
+	result, err := h.oidcProviderInteractor.ExchangeCode(c.Request().Context(), req)
+	var oauthErr *domain.OAuthError
+	if errors.As(err, &oauthErr) {
+		return oauthError(c, oauthErr)
+	}
+	if err != nil {
+		return toHTTPError(c, err, "Failed to issue tokens")
+	}
+	return c.JSON(http.StatusOK, api.OauthToken{
+		AccessToken: result.AccessToken,
+		TokenType:   result.TokenType,
+		ExpiresIn:   result.ExpiresIn,
+		IdToken:     result.IDToken,
+		Scope:       strings.Join(result.Scopes, " "),
+	})
+}
+
+// GetOauthUserinfo (corresponds to operationId: get-oauth-userinfo)
+// GET /v1/oauth/userinfo
+func (h *OIDCProviderHandler) GetOauthUserinfo(c echo.Context) error {
+	scheme, token, _ := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		token = ""
+	}
+	claims, err := h.oidcProviderInteractor.UserInfo(c.Request().Context(), token)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			// RFC 6750 §3
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+		}
+		return toHTTPError(c, err, "Failed to retrieve user info")
+	}
+
+	out := api.OauthUserInfo{
+		Sub:      claims.Subject,
+		Name:     optionalString(claims.Name),
+		Picture:  optionalString(claims.Picture),
+		Locale:   optionalString(claims.Locale),
+		Zoneinfo: optionalString(claims.Zoneinfo),
+		Email:    optionalString(claims.Email),
+	}
+	if !claims.UpdatedAt.IsZero() {
+		updatedAt := claims.UpdatedAt.Unix()
+		out.UpdatedAt = &updatedAt
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// GetOauthConsents (corresponds to operationId: get-oauth-consents)
+// GET /v1/oauth/consents
+func (h *OIDCProviderHandler) GetOauthConsents(c echo.Context) error {
+	consents, err := h.oidcProviderInteractor.ListOAuthConsents(c.Request().Context())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve consents")
+	}
+	out := make([]api.OauthConsent, len(consents))
+	for i := range consents {
+		out[i] = toAPIOauthConsent(&consents[i])
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// DeleteOauthConsent (corresponds to operationId: delete-oauth-consent)
+// DELETE /v1/oauth/consents/{client_id}
+func (h *OIDCProviderHandler) DeleteOauthConsent(c echo.Context, clientId openapi_types.UUID) error {
+	if err := h.oidcProviderInteractor.RevokeOAuthConsent(c.Request().Context(), clientId.String()); err != nil {
+		return toHTTPError(c, err, "Failed to revoke consent")
+	}
+	return c.JSON(http.StatusOK, map[string]string{})
+}
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/oidc"
+	"apiserver/internal/usecases"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOIDCProviderTestEnv() (*echo.Echo, *mocks.MockOIDCProviderInteractor) {
+	e := echo.New()
+	mockInteractor := new(mocks.MockOIDCProviderInteractor)
+	tokens := auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real())
+	e.Use(Authenticate(tokens, nil))
+	api.RegisterHandlers(e, &Server{OIDCProviderHandler: NewOIDCProviderHandler(mockInteractor)})
+	return e, mockInteractor
+}
+
+func newTokenRequest(form url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/oauth/token", strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	return req
+}
+
+func TestOIDCProviderHandler_GetOpenidConfiguration(t *testing.T) {
+	e, mockInteractor := setupOIDCProviderTestEnv()
+	mockInteractor.On("Discovery").Return(&usecases.OIDCProviderMetadata{
+		Issuer:        "https://id.example.com",
+		TokenEndpoint: "https://id.example.com/v1/oauth/token",
+		JWKSURI:       "https://id.example.com/.well-known/jwks.json",
+	}).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/openid-configuration", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "https://id.example.com", body["issuer"])
+	assert.Equal(t, "https://id.example.com/.well-known/jwks.json", body["jwks_uri"])
+}
+
+func TestOIDCProviderHandler_GetJwks(t *testing.T) {
+	e, mockInteractor := setupOIDCProviderTestEnv()
+	mockInteractor.On("PublicKeys", mock.Anything).Return([]oidc.JWK{{Kty: "RSA", Kid: "key-1", Use: "sig", Alg: "RS256", N: "modulus", E: "AQAB"}}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"keys":[{"kty":"RSA","kid":"key-1","use":"sig","alg":"RS256","n":"modulus","e":"AQAB"}]}`, rec.Body.String())
+}
+
+func TestOIDCProviderHandler_GetOauthAuthorize(t *testing.T) {
+	e, mockInteractor := setupOIDCProviderTestEnv()
+	mockInteractor.On("BeginAuthorization", mock.Anything, usecases.AuthorizationRequest{
+		ResponseType: "code",
+		ClientID:     "client-1",
+		RedirectURI:  "https://wiki.example.com/cb",
+		Scope:        "openid email",
+		State:        "xyz",
+	}).Return("https://app.example.com/oauth/consent?client_id=client-1", nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/oauth/authorize?response_type=code&client_id=client-1&redirect_uri=https%3A%2F%2Fwiki.example.com%2Fcb&scope=openid+email&state=xyz", nil))
+
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://app.example.com/oauth/consent?client_id=client-1", rec.Header().Get(echo.HeaderLocation))
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestOIDCProviderHandler_GetOauthAuthorize_UnknownClient(t *testing.T) {
+	e, mockInteractor := setupOIDCProviderTestEnv()
+	mockInteractor.On("BeginAuthorization", mock.Anything, mock.Anything).Return("", domain.ErrInvalidArgument).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/oauth/authorize?client_id=nope", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderLocation))
+}
+
+func TestOIDCProviderHandler_PostOauthAuthorize_ConsentRequired(t *testing.T) {
+	e, mockInteractor := setupOIDCProviderTestEnv()
+	clientID := uuid.New()
+	mockInteractor.On("Authorize", mock.Anything, mock.MatchedBy(func(req usecases.AuthorizationRequest) bool {
+		return req.ClientID == clientID.String() && req.Nonce == "n-1"
+	}), (*bool)(nil)).Return(&usecases.AuthorizationResult{
+		ConsentRequired: true,
+		Client:          &domain.OAuthClient{ID: clientID.String(), Name: "Wiki", RedirectURIs: []string{"https://wiki.example.com/cb"}, SecretHash: "hash"},
+		Scopes:          []string{"openid", "email"},
+	}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	nonce := "n-1"
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/v1/oauth/authorize", api.OauthAuthorizationRequest{
+		ResponseType: "code",
+		ClientId:     clientID.String(),
+		RedirectUri:  "https://wiki.example.com/cb",
+		Scope:        "openid email",
+		Nonce:        &nonce,
+	}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var result api.OauthAuthorizationResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.True(t, result.ConsentRequired)
+	assert.Nil(t, result.RedirectTo)
+	require.NotNil(t, result.Client)
+	assert.Equal(t, "Wiki", result.Client.Name)
+	assert.Equal(t, []string{"openid", "email"}, *result.Scopes)
+	assert.NotContains(t, rec.Body.String(), "hash")
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestOIDCProviderHandler_PostOauthAuthorize_Approved(t *testing.T) {
+	e, mockInteractor := setupOIDCProviderTestEnv()
+	mockInteractor.On("Authorize", mock.Anything, mock.Anything, mock.MatchedBy(func(approve *bool) bool {
+		return approve != nil && *approve
+	})).Return(&usecases.AuthorizationResult{RedirectURI: "https://wiki.example.com/cb?code=abc"}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/v1/oauth/authorize", map[string]interface{}{
+		"response_type": "code", "client_id": "c", "redirect_uri": "https://wiki.example.com/cb", "scope": "openid", "approve": true,
+	}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"consent_required":false,"redirect_to":"https://wiki.example.com/cb?code=abc"}`, rec.Body.String())
+}
+
+func TestOIDCProviderHandler_PostOauthToken(t *testing.T) {
+	e, mockInteractor := setupOIDCProviderTestEnv()
+	mockInteractor.On("ExchangeCode", mock.Anything, usecases.TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         "code-1",
+		RedirectURI:  "https://wiki.example.com/cb",
+		ClientID:     "client-1",
+		ClientSecret: "s3cret/+",
+	}).Return(&usecases.TokenResult{
+		AccessToken: "uoa_token",
+		TokenType:   "Bearer",
+		ExpiresIn:   3600,
+		IDToken:     "id.token.value",
+		Scopes:      []string{"openid", "email"},
+	}, nil).Once()
+
+	req := newTokenRequest(url.Values{"grant_type": {"authorization_code"}, "code": {"code-1"}, "redirect_uri": {"https://wiki.example.com/cb"}})
+	req.SetBasicAuth("client-1", url.QueryEscape("s3cret/+"))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+	var token api.OauthToken
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &token))
+	assert.Equal(t, "uoa_token", token.AccessToken)
+	assert.Equal(t, "id.token.value", token.IdToken)
+	assert.Equal(t, "openid email", token.Scope)
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestOIDCProviderHandler_PostOauthToken_Errors(t *testing.T) {
+	tests := map[string]struct {
+		err        *domain.OAuthError
+		wantStatus int
+	}{
+		"invalid client": {&domain.OAuthError{Code: domain.OAuthErrInvalidClient, Description: "client authentication failed"}, http.StatusUnauthorized},
+		"invalid grant":  {&domain.OAuthError{Code: domain.OAuthErrInvalidGrant}, http.StatusBadRequest},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e, mockInteractor := setupOIDCProviderTestEnv()
+			mockInteractor.On("ExchangeCode", mock.Anything, mock.Anything).Return(nil, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, newTokenRequest(url.Values{"grant_type": {"authorization_code"}, "code": {"c"}, "client_id": {"client-1"}}))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+			var body api.OauthError
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.err.Code, body.Error)
+		})
+	}
+}
+
+func TestOIDCProviderHandler_PostOauthToken_TwoAuthMethods(t *testing.T) {
+	e, mockInteractor := setupOIDCProviderTestEnv()
+
+	req := newTokenRequest(url.Values{"grant_type": {"authorization_code"}, "code": {"c"}, "client_secret": {"other"}})
+	req.SetBasicAuth("client-1", "secret")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), domain.OAuthErrInvalidRequest)
+	mockInteractor.AssertNotCalled(t, "ExchangeCode", mock.Anything, mock.Anything)
+}
+
+func TestOIDCProviderHandler_GetOauthUserinfo(t *testing.T) {
+	e, mockInteractor := setupOIDCProviderTestEnv()
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockInteractor.On("UserInfo", mock.Anything, "uoa_token").Return(&usecases.UserClaims{
+		Subject:   "user-1",
+		Name:      "Alice",
+		UpdatedAt: updatedAt,
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/oauth/userinfo", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer uoa_token")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"sub":"user-1","name":"Alice","updated_at":1767323045}`, rec.Body.String())
+}
+
+func TestOIDCProviderHandler_GetOauthUserinfo_InvalidToken(t *testing.T) {
+	e, mockInteractor := setupOIDCProviderTestEnv()
+	mockInteractor.On("UserInfo", mock.Anything, "uoa_revoked").Return(nil, domain.ErrUnauthenticated).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/oauth/userinfo", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer uoa_revoked")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Bearer error="invalid_token"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+}
+
+func TestOIDCProviderHandler_GetOauthConsents(t *testing.T) {
+	e, mockInteractor := setupOIDCProviderTestEnv()
+	clientID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+	mockInteractor.On("ListOAuthConsents", mock.Anything).Return([]domain.OAuthConsent{
+		{UserID: "user-1", ClientID: clientID.String(), ClientName: "Wiki", Scopes: []string{"openid"}, CreatedAt: now, UpdatedAt: now},
+	}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/oauth/consents", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var consents []api.OauthConsent
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &consents))
+	require.Len(t, consents, 1)
+	assert.Equal(t, clientID, consents[0].ClientId)
+	assert.Equal(t, "Wiki", consents[0].ClientName)
+}
+
+func TestOIDCProviderHandler_DeleteOauthConsent_NotFound(t *testing.T) {
+	e, mockInteractor := setupOIDCProviderTestEnv()
+	clientID := uuid.New()
+	mockInteractor.On("RevokeOAuthConsent", mock.Anything, clientID.String()).Return(domain.ErrOAuthConsentNotFound).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/v1/oauth/consents/"+clientID.String(), nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockInteractor.AssertExpectations(t)
+}
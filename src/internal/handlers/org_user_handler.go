@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// OrgUserHandler handles HTTP requests for the users of the organization
+// selected for the request.
+type OrgUserHandler struct {
+	orgUserInteractor usecases.OrgUserInteractor
+}
+
+// NewOrgUserHandler creates a new OrgUserHandler.
+func NewOrgUserHandler(uc usecases.OrgUserInteractor) *OrgUserHandler {
+	return &OrgUserHandler{orgUserInteractor: uc}
+}
+
+func toAPIOrganizationMember(m *domain.OrganizationMember) api.OrganizationMember {
+	return api.OrganizationMember{
+		Id:       uuid.MustParse(m.User.ID),
+		Name:     m.User.Name,
+		Email:    openapi_types.Email(m.User.Email),
+		Role:     api.OrganizationRole(m.Role),
+		JoinedAt: m.JoinedAt,
+	}
+}
+
+// GetOrgUsers (corresponds to operationId: get-org-users)
+// GET /v1/org/users
+func (h *OrgUserHandler) GetOrgUsers(c echo.Context) error {
+	members, err := h.orgUserInteractor.ListOrgUsers(c.Request().Context())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve organization users")
+	}
+	out := make([]api.OrganizationMember, len(members))
+	for i := range members {
+		out[i] = toAPIOrganizationMember(&members[i])
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// PostOrgUser (corresponds to operationId: post-org-user)
+// POST /v1/org/users
+func (h *OrgUserHandler) PostOrgUser(c echo.Context) error {
+	var requestBody api.PostOrgUserJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	var role string
+	if requestBody.Role != nil {
+		role = string(*requestBody.Role)
+	}
+
+	member, err := h.orgUserInteractor.CreateOrgUser(c.Request().Context(), requestBody.Name, string(requestBody.Email), requestBody.Password, role)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to create organization user")
+	}
+	return c.JSON(http.StatusCreated, toAPIOrganizationMember(member))
+}
+
+// DeleteOrgUser (corresponds to operationId: delete-org-user)
+// DELETE /v1/org/users/{user_id}
+func (h *OrgUserHandler) DeleteOrgUser(c echo.Context, userId openapi_types.UUID) error {
+	if err := h.orgUserInteractor.RemoveOrgUser(c.Request().Context(), userId.String()); err != nil {
+		return toHTTPError(c, err, "Failed to remove organization user")
+	}
+	return c.JSON(http.StatusOK, map[string]string{})
+}
+
+// PatchOrgUser (corresponds to operationId: patch-org-user)
+// PATCH /v1/org/users/{user_id}
+func (h *OrgUserHandler) PatchOrgUser(c echo.Context, userId openapi_types.UUID) error {
+	var requestBody api.PatchOrgUserJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	var email, role *string
+	if requestBody.Email != nil {
+		e := string(*requestBody.Email)
+		email = &e
+	}
+	if requestBody.Role != nil {
+		r := string(*requestBody.Role)
+		role = &r
+	}
+
+	member, err := h.orgUserInteractor.UpdateOrgUser(c.Request().Context(), userId.String(), requestBody.Name, email, requestBody.Password, role)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to update organization user")
+	}
+	return c.JSON(http.StatusOK, toAPIOrganizationMember(member))
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// OrganizationHandler handles HTTP requests for organizations.
+type OrganizationHandler struct {
+	organizationInteractor usecases.OrganizationInteractor
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler.
+func NewOrganizationHandler(uc usecases.OrganizationInteractor) *OrganizationHandler {
+	return &OrganizationHandler{organizationInteractor: uc}
+}
+
+func toAPIOrganization(o *domain.Organization) api.Organization {
+	return api.Organization{
+		Id:        uuid.MustParse(o.ID),
+		Name:      o.Name,
+		Slug:      o.Slug,
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+	}
+}
+
+// GetOrganizations (corresponds to operationId: get-organizations)
+// GET /v1/organizations
+func (h *OrganizationHandler) GetOrganizations(c echo.Context) error {
+	memberships, err := h.organizationInteractor.ListMyOrganizations(c.Request().Context())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve organizations")
+	}
+	out := make([]api.OrganizationMembership, len(memberships))
+	for i, m := range memberships {
+		out[i] = api.OrganizationMembership{
+			Organization: toAPIOrganization(&m.Organization),
+			Role:         api.OrganizationRole(m.Role),
+			JoinedAt:     m.JoinedAt,
+		}
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// PostOrganization (corresponds to operationId: post-organization)
+// POST /v1/organizations
+func (h *OrganizationHandler) PostOrganization(c echo.Context) error {
+	var requestBody api.PostOrganizationJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	var ownerID string
+	if requestBody.OwnerId != nil {
+		ownerID = requestBody.OwnerId.String()
+	}
+
+	org, err := h.organizationInteractor.CreateOrganization(c.Request().Context(), requestBody.Name, requestBody.Slug, ownerID)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to create organization")
+	}
+	return c.JSON(http.StatusCreated, toAPIOrganization(org))
+}
+
+// PostOrganizationToken (corresponds to operationId: post-organization-token)
+// POST /v1/organizations/{organization_id}/token
+func (h *OrganizationHandler) PostOrganizationToken(c echo.Context, organizationId openapi_types.UUID) error {
+	token, expiresAt, err := h.organizationInteractor.IssueOrganizationToken(c.Request().Context(), organizationId.String())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to issue organization token")
+	}
+	return c.JSON(http.StatusOK, api.Token{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(time.Until(expiresAt).Seconds()),
+	})
+}
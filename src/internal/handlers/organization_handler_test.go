@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/tenant"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type organizationTestEnv struct {
+	e        *echo.Echo
+	orgs     *mocks.MockOrganizationInteractor
+	orgUsers *mocks.MockOrgUserInteractor
+	tokens   auth.TokenService
+	token    string
+}
+
+func setupOrganizationTestEnv(t *testing.T) *organizationTestEnv {
+	env := &organizationTestEnv{
+		e:        echo.New(),
+		orgs:     new(mocks.MockOrganizationInteractor),
+		orgUsers: new(mocks.MockOrgUserInteractor),
+		tokens:   auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real()),
+	}
+	var err error
+	env.token, _, err = env.tokens.Issue(&domain.User{ID: "user-1", Role: domain.RoleUser}, false)
+	require.NoError(t, err)
+	env.e.Use(Authenticate(env.tokens, nil))
+	env.e.Use(ResolveTenant(env.orgs))
+	api.RegisterHandlers(env.e, &Server{
+		OrganizationHandler: NewOrganizationHandler(env.orgs),
+		OrgUserHandler:      NewOrgUserHandler(env.orgUsers),
+	})
+	return env
+}
+
+func (env *organizationTestEnv) do(method, target, body, token, orgID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	if orgID != "" {
+		req.Header.Set(HeaderOrganizationID, orgID)
+	}
+	rec := httptest.NewRecorder()
+	env.e.ServeHTTP(rec, req)
+	return rec
+}
+
+// inTenant matches request contexts acting within orgID.
+func inTenant(orgID string) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		t := tenant.From(ctx)
+		return t != nil && t.OrganizationID == orgID
+	})
+}
+
+func TestResolveTenant_FromHeader(t *testing.T) {
+	env := setupOrganizationTestEnv(t)
+	orgID, userID := uuid.NewString(), uuid.New()
+	joined := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	env.orgs.On("ResolveTenant", mock.Anything, orgID).Return(&tenant.Tenant{OrganizationID: orgID, Role: domain.OrgRoleMember}, nil).Once()
+	env.orgUsers.On("ListOrgUsers", inTenant(orgID)).Return([]domain.OrganizationMember{
+		{User: domain.User{ID: userID.String(), Name: "Bob", Email: "bob@example.com"}, Role: domain.OrgRoleAdmin, JoinedAt: joined},
+	}, nil).Once()
+
+	rec := env.do(http.MethodGet, "/v1/org/users", "", env.token, orgID)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var members []api.OrganizationMember
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &members))
+	assert.Equal(t, []api.OrganizationMember{{Id: userID, Name: "Bob", Email: "bob@example.com", Role: api.Admin, JoinedAt: joined}}, members)
+	env.orgs.AssertExpectations(t)
+	env.orgUsers.AssertExpectations(t)
+}
+
+func TestResolveTenant_FromToken(t *testing.T) {
+	env := setupOrganizationTestEnv(t)
+	orgID := uuid.NewString()
+	p, err := env.tokens.Verify(env.token)
+	require.NoError(t, err)
+	orgToken, _, err := env.tokens.IssueForOrganization(p, orgID)
+	require.NoError(t, err)
+
+	env.orgs.On("ResolveTenant", mock.Anything, orgID).Return(&tenant.Tenant{OrganizationID: orgID, Role: domain.OrgRoleMember}, nil).Twice()
+	env.orgUsers.On("ListOrgUsers", inTenant(orgID)).Return([]domain.OrganizationMember{}, nil).Twice()
+
+	assert.Equal(t, http.StatusOK, env.do(http.MethodGet, "/v1/org/users", "", orgToken, "").Code)
+	assert.Equal(t, http.StatusOK, env.do(http.MethodGet, "/v1/org/users", "", orgToken, orgID).Code, "a matching header is accepted")
+	assert.Equal(t, http.StatusBadRequest, env.do(http.MethodGet, "/v1/org/users", "", orgToken, uuid.NewString()).Code)
+	env.orgUsers.AssertExpectations(t)
+}
+
+func TestResolveTenant_NotAMember(t *testing.T) {
+	env := setupOrganizationTestEnv(t)
+	orgID := uuid.NewString()
+	env.orgs.On("ResolveTenant", mock.Anything, orgID).Return(nil, domain.ErrForbidden).Once()
+
+	rec := env.do(http.MethodGet, "/v1/org/users", "", env.token, orgID)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	env.orgUsers.AssertNotCalled(t, "ListOrgUsers", mock.Anything)
+}
+
+func TestOrgUserHandler_NoTenant(t *testing.T) {
+	env := setupOrganizationTestEnv(t)
+	env.orgUsers.On("ListOrgUsers", mock.Anything).Return(nil, domain.ErrNoTenant).Once()
+
+	rec := env.do(http.MethodGet, "/v1/org/users", "", env.token, "")
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), HeaderOrganizationID)
+	env.orgs.AssertNotCalled(t, "ResolveTenant", mock.Anything, mock.Anything)
+}
+
+func TestOrgUserHandler_PatchOrgUser(t *testing.T) {
+	env := setupOrganizationTestEnv(t)
+	orgID, userID := uuid.NewString(), uuid.New()
+	env.orgs.On("ResolveTenant", mock.Anything, orgID).Return(&tenant.Tenant{OrganizationID: orgID, Role: domain.OrgRoleOwner}, nil)
+	env.orgUsers.On("UpdateOrgUser", inTenant(orgID), userID.String(), (*string)(nil), (*string)(nil), (*string)(nil), mock.MatchedBy(func(role *string) bool {
+		return role != nil && *role == domain.OrgRoleAdmin
+	})).Return(&domain.OrganizationMember{User: domain.User{ID: userID.String(), Email: "bob@example.com"}, Role: domain.OrgRoleAdmin}, nil).Once()
+	env.orgUsers.On("RemoveOrgUser", inTenant(orgID), userID.String()).Return(domain.ErrConflict).Once()
+
+	rec := env.do(http.MethodPatch, "/v1/org/users/"+userID.String(), `{"role":"admin"}`, env.token, orgID)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = env.do(http.MethodDelete, "/v1/org/users/"+userID.String(), "", env.token, orgID)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	env.orgUsers.AssertExpectations(t)
+}
+
+func TestOrganizationHandler_PostOrganization(t *testing.T) {
+	env := setupOrganizationTestEnv(t)
+	orgID := uuid.New()
+	env.orgs.On("CreateOrganization", mock.Anything, "Acme", "acme", "").
+		Return(&domain.Organization{ID: orgID.String(), Name: "Acme", Slug: "acme"}, nil).Once()
+	env.orgs.On("CreateOrganization", mock.Anything, "Acme", "acme", "").
+		Return(nil, domain.ErrConflict).Once()
+
+	rec := env.do(http.MethodPost, "/v1/organizations", `{"name":"Acme","slug":"acme"}`, env.token, "")
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var org api.Organization
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &org))
+	assert.Equal(t, orgID, org.Id)
+
+	rec = env.do(http.MethodPost, "/v1/organizations", `{"name":"Acme","slug":"acme"}`, env.token, "")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestOrganizationHandler_PostOrganizationToken(t *testing.T) {
+	env := setupOrganizationTestEnv(t)
+	orgID := uuid.New()
+	env.orgs.On("IssueOrganizationToken", mock.Anything, orgID.String()).Return("org-token", time.Now().Add(time.Hour), nil).Once()
+
+	rec := env.do(http.MethodPost, "/v1/organizations/"+orgID.String()+"/token", "", env.token, "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var token api.Token
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &token))
+	assert.Equal(t, "org-token", token.AccessToken)
+	assert.Equal(t, "Bearer", token.TokenType)
+	assert.Greater(t, token.ExpiresIn, 3500)
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// PrivacyHandler handles HTTP requests for data subject requests.
+type PrivacyHandler struct {
+	privacyInteractor usecases.PrivacyInteractor
+}
+
+// NewPrivacyHandler creates a new PrivacyHandler.
+func NewPrivacyHandler(uc usecases.PrivacyInteractor) *PrivacyHandler {
+	return &PrivacyHandler{privacyInteractor: uc}
+}
+
+// exportedUser is the user.json of a data export. Unlike api.User it holds
+// everything stored about the user except the password hash.
+type exportedUser struct {
+	api.User
+	Id         string    `json:"id"`
+	Email      string    `json:"email"`
+	Role       string    `json:"role"`
+	MfaEnabled bool      `json:"mfa_enabled"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// exportManifest is the manifest.json of a data export.
+type exportManifest struct {
+	UserId     string    `json:"user_id"`
+	ExportedAt time.Time `json:"exported_at"`
+	Files      []string  `json:"files"`
+}
+
+func toAPIErasureReceipt(r *domain.ErasureReceipt) api.ErasureReceipt {
+	out := api.ErasureReceipt{
+		Id:                        uuid.MustParse(r.ID),
+		UserId:                    uuid.MustParse(r.UserID),
+		Reason:                    r.Reason,
+		AuditEventsScrubbed:       r.AuditEventsScrubbed,
+		EventsScrubbed:            r.EventsScrubbed,
+		WebhookDeliveriesScrubbed: r.WebhookDeliveriesScrubbed,
+		ImportReportsScrubbed:     r.ImportReportsScrubbed,
+		InvitationsDeleted:        r.InvitationsDeleted,
+		ErasedAt:                  r.ErasedAt,
+	}
+	if id, err := uuid.Parse(r.RequestedBy); err == nil {
+		out.RequestedBy = &id
+	}
+	return out
+}
+
+// writeDataExport writes export as a ZIP of JSON files.
+func writeDataExport(export *domain.UserDataExport) ([]byte, error) {
+	apiKeys := make([]api.ApiKey, len(export.APIKeys))
+	for i := range export.APIKeys {
+		apiKeys[i] = toAPIKey(&export.APIKeys[i])
+	}
+	organizations := make([]api.OrganizationMembership, len(export.Organizations))
+	for i, m := range export.Organizations {
+		organizations[i] = api.OrganizationMembership{
+			Organization: toAPIOrganization(&m.Organization),
+			Role:         api.OrganizationRole(m.Role),
+			JoinedAt:     m.JoinedAt,
+		}
+	}
+	auditEvents := make([]api.AuditEvent, len(export.AuditEvents))
+	for i := range export.AuditEvents {
+		auditEvents[i] = toAPIAuditEvent(&export.AuditEvents[i])
+	}
+	files := []struct {
+		name string
+		v    any
+	}{
+		{"user.json", exportedUser{
+			User:       toAPIUser(&export.User),
+			Id:         export.User.ID,
+			Email:      export.User.Email,
+			Role:       export.User.Role,
+			MfaEnabled: export.MFAEnabled,
+			CreatedAt:  export.User.CreatedAt,
+			UpdatedAt:  export.User.UpdatedAt,
+		}},
+		{"api_keys.json", apiKeys},
+		{"groups.json", toAPIGroupSlice(export.Groups)},
+		{"organizations.json", organizations},
+		{"audit_events.json", auditEvents},
+	}
+	manifest := exportManifest{UserId: export.User.ID, ExportedAt: export.ExportedAt}
+	for _, f := range files {
+		manifest.Files = append(manifest.Files, f.name)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	write := func(name string, v any) error {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: export.ExportedAt})
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	if err := write("manifest.json", manifest); err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if err := write(f.name, f.v); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GetUserDataExport (corresponds to operationId: get-user-data-export)
+// GET /v1/users/{user_id}/data-export
+func (h *PrivacyHandler) GetUserDataExport(c echo.Context, userId openapi_types.UUID) error {
+	export, err := h.privacyInteractor.ExportUserData(c.Request().Context(), userId.String())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to export user data")
+	}
+	data, err := writeDataExport(export)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to export user data: "+err.Error())
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="user-`+userId.String()+`.zip"`)
+	return c.Blob(http.StatusOK, "application/zip", data)
+}
+
+// PostUserErasure (corresponds to operationId: post-user-erasure)
+// POST /v1/users/{user_id}/erasure
+func (h *PrivacyHandler) PostUserErasure(c echo.Context, userId openapi_types.UUID) error {
+	var requestBody api.PostUserErasureJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	reason := ""
+	if requestBody.Reason != nil {
+		reason = *requestBody.Reason
+	}
+
+	receipt, err := h.privacyInteractor.EraseUser(c.Request().Context(), userId.String(), reason)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to erase user")
+	}
+	return c.JSON(http.StatusCreated, toAPIErasureReceipt(receipt))
+}
+
+// GetUserErasure (corresponds to operationId: get-user-erasure)
+// GET /v1/users/{user_id}/erasure
+func (h *PrivacyHandler) GetUserErasure(c echo.Context, userId openapi_types.UUID) error {
+	receipt, err := h.privacyInteractor.GetErasureReceipt(c.Request().Context(), userId.String())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve erasure receipt")
+	}
+	return c.JSON(http.StatusOK, toAPIErasureReceipt(receipt))
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPrivacyTestEnv(t *testing.T) (*echo.Echo, *mocks.MockPrivacyInteractor, string) {
+	tokens := auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real())
+	token, _, err := tokens.Issue(&domain.User{ID: "admin-1", Role: domain.RoleAdmin}, true)
+	require.NoError(t, err)
+	privacy := new(mocks.MockPrivacyInteractor)
+	e := echo.New()
+	e.Use(Authenticate(tokens, nil))
+	api.RegisterHandlers(e, &Server{PrivacyHandler: NewPrivacyHandler(privacy)})
+	return e, privacy, token
+}
+
+func TestPrivacyHandler_GetUserDataExport(t *testing.T) {
+	e, privacy, token := setupPrivacyTestEnv(t)
+	userID := uuid.NewString()
+	exportedAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	privacy.On("ExportUserData", mock.Anything, userID).Return(&domain.UserDataExport{
+		User:        domain.User{ID: userID, Name: "Alice", Email: "alice@example.com", Role: domain.RoleUser, Password: "hash"},
+		MFAEnabled:  true,
+		AuditEvents: []domain.AuditEvent{{ID: 7, Action: domain.AuditActionUserCreated, TargetID: userID, OccurredAt: exportedAt}},
+		ExportedAt:  exportedAt,
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/"+userID+"/data-export", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/zip", rec.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "user-"+userID+".zip")
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	require.NoError(t, err)
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		files[f.Name], err = io.ReadAll(r)
+		require.NoError(t, err)
+	}
+	assert.Len(t, files, 6)
+
+	var manifest map[string]any
+	require.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+	assert.Equal(t, userID, manifest["user_id"])
+	var user map[string]any
+	require.NoError(t, json.Unmarshal(files["user.json"], &user))
+	assert.Equal(t, "alice@example.com", user["email"])
+	assert.Equal(t, "Alice", user["name"])
+	assert.Equal(t, true, user["mfa_enabled"])
+	assert.NotContains(t, string(files["user.json"]), "hash", "the password hash is never exported")
+	var events []api.AuditEvent
+	require.NoError(t, json.Unmarshal(files["audit_events.json"], &events))
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(7), events[0].Id)
+	assert.JSONEq(t, "[]", string(files["groups.json"]))
+}
+
+func TestPrivacyHandler_PostUserErasure(t *testing.T) {
+	e, privacy, token := setupPrivacyTestEnv(t)
+	userID := uuid.NewString()
+	erasedAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	privacy.On("EraseUser", mock.Anything, userID, "ticket 42").Return(&domain.ErasureReceipt{
+		ID:                  uuid.NewString(),
+		UserID:              userID,
+		RequestedBy:         "admin-1",
+		Reason:              "ticket 42",
+		AuditEventsScrubbed: 3,
+		ErasedAt:            erasedAt,
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/"+userID+"/erasure", strings.NewReader(`{"reason":"ticket 42"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var got api.ErasureReceipt
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, userID, got.UserId.String())
+	assert.Equal(t, 3, got.AuditEventsScrubbed)
+	assert.Nil(t, got.RequestedBy, "IDs that are not UUIDs are omitted")
+}
+
+func TestPrivacyHandler_PostUserErasure_AlreadyErased(t *testing.T) {
+	e, privacy, token := setupPrivacyTestEnv(t)
+	userID := uuid.NewString()
+	privacy.On("EraseUser", mock.Anything, userID, "").Return(nil, domain.ErrConflict).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/"+userID+"/erasure", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestPrivacyHandler_GetUserErasure_NotErased(t *testing.T) {
+	e, privacy, token := setupPrivacyTestEnv(t)
+	userID := uuid.NewString()
+	privacy.On("GetErasureReceipt", mock.Anything, userID).Return(nil, domain.ErrErasureReceiptNotFound).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/"+userID+"/erasure", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import "apiserver/internal/generated/api"
+
+// Server combines the per-resource handlers into the single api.ServerInterface
+// that oapi-codegen expects.
+type Server struct {
+	*UserHandler
+	*UserEventHandler
+	*AuthHandler
+	*OIDCLoginHandler
+	*OIDCProviderHandler
+	*OAuthClientHandler
+	*APIKeyHandler
+	*AuditHandler
+	*WebhookHandler
+	*UserImportHandler
+	*UserBatchHandler
+	*UserSearchHandler
+	*OrganizationHandler
+	*OrgUserHandler
+	*InvitationHandler
+	*GroupHandler
+	*AvatarHandler
+	*UserAttributeHandler
+	*PrivacyHandler
+	*JobHandler
+}
+
+var _ api.ServerInterface = (*Server)(nil)
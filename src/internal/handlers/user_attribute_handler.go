@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/labstack/echo/v4"
+)
+
+// UserAttributeHandler handles HTTP requests for custom user attribute
+// definitions.
+type UserAttributeHandler struct {
+	userAttributeInteractor usecases.UserAttributeInteractor
+}
+
+// NewUserAttributeHandler creates a new UserAttributeHandler.
+func NewUserAttributeHandler(uc usecases.UserAttributeInteractor) *UserAttributeHandler {
+	return &UserAttributeHandler{userAttributeInteractor: uc}
+}
+
+func toAPIAttributeDefinition(d *domain.AttributeDefinition) api.UserAttributeDefinition {
+	out := api.UserAttributeDefinition{
+		Key:         d.Key,
+		Type:        api.UserAttributeType(d.Type),
+		Required:    d.Required,
+		Description: d.Description,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+	if len(d.Enum) > 0 {
+		enum := d.Enum
+		out.Enum = &enum
+	}
+	return out
+}
+
+// GetUserAttributes (corresponds to operationId: get-user-attributes)
+// GET /v1/user-attributes
+func (h *UserAttributeHandler) GetUserAttributes(c echo.Context) error {
+	defs, err := h.userAttributeInteractor.ListAttributeDefinitions(c.Request().Context())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve attribute definitions")
+	}
+	out := make([]api.UserAttributeDefinition, len(defs))
+	for i := range defs {
+		out[i] = toAPIAttributeDefinition(&defs[i])
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// PostUserAttribute (corresponds to operationId: post-user-attribute)
+// POST /v1/user-attributes
+func (h *UserAttributeHandler) PostUserAttribute(c echo.Context) error {
+	var requestBody api.PostUserAttributeJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	def := domain.AttributeDefinition{Key: requestBody.Key, Type: string(requestBody.Type)}
+	if requestBody.Required != nil {
+		def.Required = *requestBody.Required
+	}
+	if requestBody.Enum != nil {
+		def.Enum = *requestBody.Enum
+	}
+	if requestBody.Description != nil {
+		def.Description = *requestBody.Description
+	}
+
+	created, err := h.userAttributeInteractor.CreateAttributeDefinition(c.Request().Context(), def)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to create attribute definition")
+	}
+	return c.JSON(http.StatusCreated, toAPIAttributeDefinition(created))
+}
+
+// DeleteUserAttribute (corresponds to operationId: delete-user-attribute)
+// DELETE /v1/user-attributes/{attribute_key}
+func (h *UserAttributeHandler) DeleteUserAttribute(c echo.Context, attributeKey string) error {
+	if err := h.userAttributeInteractor.DeleteAttributeDefinition(c.Request().Context(), attributeKey); err != nil {
+		return toHTTPError(c, err, "Failed to delete attribute definition")
+	}
+	return c.JSON(http.StatusOK, map[string]string{})
+}
+
+// PatchUserAttribute (corresponds to operationId: patch-user-attribute)
+// PATCH /v1/user-attributes/{attribute_key}
+func (h *UserAttributeHandler) PatchUserAttribute(c echo.Context, attributeKey string) error {
+	var requestBody api.PatchUserAttributeJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	patch := domain.AttributeDefinitionPatch{
+		Required:    requestBody.Required,
+		Enum:        requestBody.Enum,
+		Description: requestBody.Description,
+	}
+
+	updated, err := h.userAttributeInteractor.UpdateAttributeDefinition(c.Request().Context(), attributeKey, patch)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to update attribute definition")
+	}
+	return c.JSON(http.StatusOK, toAPIAttributeDefinition(updated))
+}
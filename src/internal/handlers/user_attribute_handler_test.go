@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type userAttributeTestEnv struct {
+	e     *echo.Echo
+	attrs *mocks.MockUserAttributeInteractor
+	users *mocks.MockUserInteractor
+	token string
+}
+
+func setupUserAttributeTestEnv(t *testing.T) *userAttributeTestEnv {
+	tokens := auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real())
+	env := &userAttributeTestEnv{
+		e:     echo.New(),
+		attrs: new(mocks.MockUserAttributeInteractor),
+		users: new(mocks.MockUserInteractor),
+	}
+	var err error
+	env.token, _, err = tokens.Issue(&domain.User{ID: "admin-1", Role: domain.RoleAdmin}, true)
+	require.NoError(t, err)
+	env.e.Use(Authenticate(tokens, nil))
+	api.RegisterHandlers(env.e, &Server{
+		UserHandler:          NewUserHandler(env.users),
+		UserAttributeHandler: NewUserAttributeHandler(env.attrs),
+	})
+	return env
+}
+
+func (env *userAttributeTestEnv) do(method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+env.token)
+	rec := httptest.NewRecorder()
+	env.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUserAttributeHandler_PostUserAttribute(t *testing.T) {
+	env := setupUserAttributeTestEnv(t)
+	env.attrs.On("CreateAttributeDefinition", mock.Anything, domain.AttributeDefinition{
+		Key: "department", Type: domain.AttributeTypeString, Required: true, Enum: []string{"sales", "engineering"},
+	}).Return(&domain.AttributeDefinition{
+		Key: "department", Type: domain.AttributeTypeString, Required: true, Enum: []string{"sales", "engineering"}, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}, nil).Once()
+
+	rec := env.do(http.MethodPost, "/v1/user-attributes", `{"key":"department","type":"string","required":true,"enum":["sales","engineering"]}`)
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var got api.UserAttributeDefinition
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, "department", got.Key)
+	assert.Equal(t, api.String, got.Type)
+	require.NotNil(t, got.Enum)
+	assert.Equal(t, []string{"sales", "engineering"}, *got.Enum)
+	env.attrs.AssertExpectations(t)
+}
+
+func TestUserAttributeHandler_PostUserAttribute_Conflict(t *testing.T) {
+	env := setupUserAttributeTestEnv(t)
+	env.attrs.On("CreateAttributeDefinition", mock.Anything, mock.Anything).Return(nil, domain.ErrConflict).Once()
+
+	rec := env.do(http.MethodPost, "/v1/user-attributes", `{"key":"remote","type":"boolean"}`)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestUserAttributeHandler_PatchUserAttribute_NotFound(t *testing.T) {
+	env := setupUserAttributeTestEnv(t)
+	description := "Where they work"
+	env.attrs.On("UpdateAttributeDefinition", mock.Anything, "missing", domain.AttributeDefinitionPatch{Description: &description}).Return(nil, domain.ErrAttributeDefinitionNotFound).Once()
+
+	rec := env.do(http.MethodPatch, "/v1/user-attributes/missing", `{"description":"Where they work"}`)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	env.attrs.AssertExpectations(t)
+}
+
+func TestUserAttributeHandler_DeleteUserAttribute(t *testing.T) {
+	env := setupUserAttributeTestEnv(t)
+	env.attrs.On("DeleteAttributeDefinition", mock.Anything, "remote").Return(nil).Once()
+
+	rec := env.do(http.MethodDelete, "/v1/user-attributes/remote", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	env.attrs.AssertExpectations(t)
+}
+
+func TestUserHandler_PathUser_PreferencesAndAttributes(t *testing.T) {
+	env := setupUserAttributeTestEnv(t)
+	userID := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	locale, digest := "ja-JP", domain.DigestDaily
+	env.users.On("PatchUser", mock.Anything, userID, domain.UserPatch{
+		Preferences: &domain.PreferencesPatch{Locale: &locale, NotificationsDigest: &digest},
+		Attributes:  map[string]interface{}{"department": "sales", "remote": nil},
+	}).Return(&domain.User{
+		ID:          userID,
+		Name:        "Alice",
+		Preferences: domain.UserPreferences{Locale: locale, Notifications: domain.NotificationPreferences{Email: true, Digest: digest}},
+		Attributes:  map[string]interface{}{"department": "sales"},
+	}, nil).Once()
+
+	rec := env.do(http.MethodPatch, "/v1/users/"+userID, `{"preferences":{"locale":"ja-JP","notifications":{"digest":"daily"}},"attributes":{"department":"sales","remote":null}}`)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{
+		"name":"Alice",
+		"preferences":{"locale":"ja-JP","notifications":{"email":true,"digest":"daily"}},
+		"attributes":{"department":"sales"}
+	}`, rec.Body.String())
+	env.users.AssertExpectations(t)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// batchSuccessStatus is the status each kind of operation reports when it
+// succeeds, matching the single-user endpoints.
+var batchSuccessStatus = map[api.UserBatchOp]int{
+	api.Create: http.StatusCreated,
+	api.Patch:  http.StatusOK,
+	api.Delete: http.StatusNoContent,
+}
+
+// UserBatchHandler handles HTTP requests for batches of user operations.
+type UserBatchHandler struct {
+	userBatchInteractor usecases.UserBatchInteractor
+}
+
+// NewUserBatchHandler creates a new UserBatchHandler.
+func NewUserBatchHandler(uc usecases.UserBatchInteractor) *UserBatchHandler {
+	return &UserBatchHandler{userBatchInteractor: uc}
+}
+
+// batchItemError returns the status and message an operation's error would
+// have produced as a request of its own.
+func batchItemError(c echo.Context, err error) (int, string) {
+	var he *echo.HTTPError
+	if !errors.As(toHTTPError(c, err, "Operation failed"), &he) {
+		return http.StatusInternalServerError, err.Error()
+	}
+	return he.Code, fmt.Sprint(he.Message)
+}
+
+// PostUsersBatch (corresponds to operationId: post-users-batch)
+// POST /v1/users:batch
+func (h *UserBatchHandler) PostUsersBatch(c echo.Context) error {
+	var req api.PostUsersBatchJSONRequestBody
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	mode := api.BestEffort
+	if req.Mode != nil {
+		mode = *req.Mode
+	}
+	if mode != api.Atomic && mode != api.BestEffort {
+		return echo.NewHTTPError(http.StatusBadRequest, "mode must be atomic or best_effort")
+	}
+
+	ops := make([]usecases.UserBatchOperation, len(req.Operations))
+	for i, op := range req.Operations {
+		ops[i] = usecases.UserBatchOperation{Op: string(op.Op), Name: op.Name, Password: op.Password}
+		if op.Id != nil {
+			ops[i].ID = op.Id.String()
+		}
+		if op.Email != nil {
+			email := string(*op.Email)
+			ops[i].Email = &email
+		}
+	}
+
+	results, err := h.userBatchInteractor.ExecuteUserBatch(c.Request().Context(), ops, mode == api.Atomic)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to run user batch")
+	}
+
+	res := api.UserBatchResponse{Mode: mode, Results: make([]api.UserBatchResult, len(results))}
+	for i, r := range results {
+		item := api.UserBatchResult{Index: i, Op: req.Operations[i].Op}
+		if r.Err != nil {
+			status, msg := batchItemError(c, r.Err)
+			item.Status, item.Error = status, &msg
+			res.Failed++
+		} else {
+			item.Status = batchSuccessStatus[item.Op]
+			item.Id = req.Operations[i].Id
+			res.Succeeded++
+		}
+		if r.User != nil {
+			user := toAPIUser(r.User)
+			item.User = &user
+			if id, err := uuid.Parse(r.User.ID); err == nil {
+				item.Id = &id
+			}
+		}
+		res.Results[i] = item
+	}
+	res.RolledBack = mode == api.Atomic && res.Failed > 0
+	return c.JSON(http.StatusOK, res)
+}
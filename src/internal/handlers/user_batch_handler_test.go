@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupUserBatchTestEnv() (*echo.Echo, *mocks.MockUserBatchInteractor) {
+	e := echo.New()
+	mockBatch := new(mocks.MockUserBatchInteractor)
+	api.RegisterHandlers(e, &Server{UserBatchHandler: NewUserBatchHandler(mockBatch)})
+	return e, mockBatch
+}
+
+func postUserBatch(e *echo.Echo, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/users:batch", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUserBatchHandler_PostUsersBatch_BestEffort(t *testing.T) {
+	e, mockBatch := setupUserBatchTestEnv()
+	createdID, patchedID, deletedID, missingID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	body := `{"operations":[
+		{"op":"create","name":"Alice","email":"alice@example.com","password":"secret"},
+		{"op":"patch","id":"` + patchedID.String() + `","name":"Bob"},
+		{"op":"delete","id":"` + deletedID.String() + `"},
+		{"op":"delete","id":"` + missingID.String() + `"}]}`
+
+	mockBatch.On("ExecuteUserBatch", mock.Anything, mock.MatchedBy(func(ops []usecases.UserBatchOperation) bool {
+		return len(ops) == 4 && ops[0].Op == usecases.BatchOpCreate && *ops[0].Email == "alice@example.com" &&
+			ops[1].ID == patchedID.String() && *ops[1].Name == "Bob" && ops[1].Email == nil
+	}), false).Return([]usecases.UserBatchResult{
+		{User: &domain.User{ID: createdID.String(), Name: "Alice"}},
+		{User: &domain.User{ID: patchedID.String(), Name: "Bob"}},
+		{},
+		{Err: domain.ErrNotFound},
+	}, nil).Once()
+
+	rec := postUserBatch(e, body)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res api.UserBatchResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, api.BestEffort, res.Mode)
+	assert.Equal(t, 3, res.Succeeded)
+	assert.Equal(t, 1, res.Failed)
+	assert.False(t, res.RolledBack)
+	if assert.Len(t, res.Results, 4) {
+		assert.Equal(t, http.StatusCreated, res.Results[0].Status)
+		assert.Equal(t, createdID, *res.Results[0].Id)
+		assert.Equal(t, "Alice", res.Results[0].User.Name)
+		assert.Equal(t, http.StatusOK, res.Results[1].Status)
+		assert.Equal(t, http.StatusNoContent, res.Results[2].Status)
+		assert.Equal(t, deletedID, *res.Results[2].Id)
+		assert.Nil(t, res.Results[2].User)
+		assert.Equal(t, http.StatusNotFound, res.Results[3].Status)
+		assert.Equal(t, 3, res.Results[3].Index)
+		assert.Equal(t, "User not found", *res.Results[3].Error)
+	}
+	mockBatch.AssertExpectations(t)
+}
+
+func TestUserBatchHandler_PostUsersBatch_AtomicRolledBack(t *testing.T) {
+	e, mockBatch := setupUserBatchTestEnv()
+	body := `{"mode":"atomic","operations":[
+		{"op":"create","name":"Alice","email":"alice@example.com","password":"secret"},
+		{"op":"patch","id":"` + uuid.NewString() + `"}]}`
+
+	mockBatch.On("ExecuteUserBatch", mock.Anything, mock.Anything, true).Return([]usecases.UserBatchResult{
+		{Err: domain.ErrBatchAborted},
+		{Err: domain.ErrInvalidArgument},
+	}, nil).Once()
+
+	rec := postUserBatch(e, body)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res api.UserBatchResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, api.Atomic, res.Mode)
+	assert.True(t, res.RolledBack)
+	assert.Equal(t, 0, res.Succeeded)
+	assert.Equal(t, 2, res.Failed)
+	assert.Equal(t, http.StatusFailedDependency, res.Results[0].Status)
+	assert.Nil(t, res.Results[0].Id)
+	assert.Equal(t, http.StatusBadRequest, res.Results[1].Status)
+	mockBatch.AssertExpectations(t)
+}
+
+func TestUserBatchHandler_PostUsersBatch_TooManyOperations(t *testing.T) {
+	e, mockBatch := setupUserBatchTestEnv()
+	mockBatch.On("ExecuteUserBatch", mock.Anything, mock.Anything, false).
+		Return(nil, domain.ErrInvalidArgument).Once()
+
+	rec := postUserBatch(e, `{"operations":[{"op":"delete","id":"`+uuid.NewString()+`"}]}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestUserBatchHandler_PostUsersBatch_InvalidBody(t *testing.T) {
+	e, mockBatch := setupUserBatchTestEnv()
+
+	rec := postUserBatch(e, `{"operations":`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = postUserBatch(e, `{"mode":"sometimes","operations":[]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "mode must be atomic or best_effort")
+
+	mockBatch.AssertNotCalled(t, "ExecuteUserBatch", mock.Anything, mock.Anything, mock.Anything)
+}
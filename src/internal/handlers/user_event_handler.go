@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"apiserver/internal/events"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/labstack/echo/v4"
+)
+
+// sseRetry is the reconnection delay suggested to EventSource clients.
+const sseRetry = 3 * time.Second
+
+// UserEventHandler streams user changes as Server-Sent Events.
+type UserEventHandler struct {
+	userEventInteractor usecases.UserEventInteractor
+	heartbeat           time.Duration // Interval of keep-alive comments on idle streams
+}
+
+// NewUserEventHandler creates a new UserEventHandler.
+func NewUserEventHandler(uc usecases.UserEventInteractor, heartbeat time.Duration) *UserEventHandler {
+	return &UserEventHandler{userEventInteractor: uc, heartbeat: heartbeat}
+}
+
+// writeSSE writes one event. data must not contain newlines.
+func writeSSE(w http.ResponseWriter, id int64, event string, data []byte) error {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event, data)
+	return err
+}
+
+// GetUserEvents (corresponds to operationId: get-user-events)
+// GET /v1/users/events
+func (h *UserEventHandler) GetUserEvents(c echo.Context, params api.GetUserEventsParams) error {
+	var lastEventID int64
+	if params.LastEventID != nil {
+		lastEventID = *params.LastEventID
+	}
+	var types []string
+	if params.Type != nil {
+		types = *params.Type
+	}
+
+	ctx := c.Request().Context()
+	sub, err := h.userEventInteractor.SubscribeUserEvents(ctx, lastEventID, types)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to subscribe to user events")
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set("X-Accel-Buffering", "no") // Keep nginx from buffering the stream
+	res.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(res, "retry: %d\n\n", sseRetry.Milliseconds()); err != nil {
+		return nil
+	}
+	res.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		batch := sub.Next()
+		if batch.Reset {
+			// Events were missed; the client should fetch the users again
+			if err := writeSSE(res, batch.Cursor, "reset", []byte("{}")); err != nil {
+				return nil
+			}
+		}
+		for _, m := range batch.Events {
+			data, err := events.Encode(m.Event)
+			if err != nil {
+				return nil
+			}
+			if err := writeSSE(res, m.Seq, m.Event.Type, data); err != nil {
+				return nil
+			}
+		}
+		if batch.Reset || len(batch.Events) > 0 {
+			res.Flush()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-batch.Wait:
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
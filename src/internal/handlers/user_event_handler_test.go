@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"apiserver/internal/usecases/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedSubscription returns its batches in turn, then waits forever.
+type scriptedSubscription struct {
+	batches []usecases.UserEventBatch
+}
+
+func (s *scriptedSubscription) Next() usecases.UserEventBatch {
+	if len(s.batches) == 0 {
+		return usecases.UserEventBatch{Wait: make(chan struct{})}
+	}
+	batch := s.batches[0]
+	s.batches = s.batches[1:]
+	return batch
+}
+
+func closedChan() <-chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+func setupUserEventTestEnv(t *testing.T, heartbeat time.Duration) (*httptest.Server, *mocks.MockUserEventInteractor) {
+	e := echo.New()
+	mockEvents := new(mocks.MockUserEventInteractor)
+	api.RegisterHandlers(e, &Server{UserEventHandler: NewUserEventHandler(mockEvents, heartbeat)})
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return srv, mockEvents
+}
+
+// readStream reads the response until it has seen want or the deadline passes.
+func readStream(t *testing.T, resp *http.Response, want string) string {
+	var sb strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		sb.WriteString(scanner.Text() + "\n")
+		if strings.Contains(sb.String(), want) {
+			return sb.String()
+		}
+	}
+	t.Fatalf("stream ended without %q:\n%s", want, sb.String())
+	return ""
+}
+
+func openStream(t *testing.T, url string, header http.Header) *http.Response {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestUserEventHandler_GetUserEvents_Streams(t *testing.T) {
+	srv, mockEvents := setupUserEventTestEnv(t, time.Hour)
+	occurredAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sub := &scriptedSubscription{batches: []usecases.UserEventBatch{
+		{Reset: true, Cursor: 40, Wait: closedChan()},
+		{Events: []domain.OutboxMessage{{Seq: 42, Event: domain.DomainEvent{
+			ID:          "e42",
+			Type:        domain.EventUserDeleted,
+			AggregateID: "user-1",
+			Payload:     []byte(`{"id":"user-1"}`),
+			OccurredAt:  occurredAt,
+		}}}, Cursor: 42, Wait: make(chan struct{})},
+	}}
+	mockEvents.On("SubscribeUserEvents", mock.Anything, int64(7), []string{domain.EventUserDeleted}).Return(sub, nil).Once()
+
+	resp := openStream(t, srv.URL+"/v1/users/events?type=user.deleted", http.Header{"Last-Event-Id": {"7"}})
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get(echo.HeaderContentType))
+	assert.Equal(t, "no-cache", resp.Header.Get(echo.HeaderCacheControl))
+	body := readStream(t, resp, "data: {\"id\":\"e42\"")
+	assert.Equal(t, "retry: 3000\n\n"+
+		"id: 40\nevent: reset\ndata: {}\n\n"+
+		"id: 42\nevent: user.deleted\n"+
+		`data: {"id":"e42","type":"user.deleted","aggregate_id":"user-1","occurred_at":"2026-01-01T00:00:00Z","data":{"id":"user-1"}}`+"\n", body)
+	mockEvents.AssertExpectations(t)
+}
+
+func TestUserEventHandler_GetUserEvents_Heartbeat(t *testing.T) {
+	srv, mockEvents := setupUserEventTestEnv(t, 10*time.Millisecond)
+	mockEvents.On("SubscribeUserEvents", mock.Anything, int64(0), []string(nil)).Return(&scriptedSubscription{}, nil).Once()
+
+	resp := openStream(t, srv.URL+"/v1/users/events", nil)
+
+	readStream(t, resp, ": heartbeat")
+}
+
+func TestUserEventHandler_GetUserEvents_Rejected(t *testing.T) {
+	srv, mockEvents := setupUserEventTestEnv(t, time.Hour)
+	mockEvents.On("SubscribeUserEvents", mock.Anything, int64(0), []string{"user.exploded"}).
+		Return(nil, fmt.Errorf("%w: unknown event type", domain.ErrInvalidArgument)).Once()
+
+	resp := openStream(t, srv.URL+"/v1/users/events?type=user.exploded", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = openStream(t, srv.URL+"/v1/users/events", http.Header{"Last-Event-Id": {"not-a-number"}})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	mockEvents.AssertExpectations(t)
+}
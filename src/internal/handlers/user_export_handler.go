@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/export"
+	"apiserver/internal/generated/api"
+	"github.com/labstack/echo/v4"
+)
+
+// userExportColumns are the columns an export can include, in their default
+// order. Password hashes are deliberately not among them.
+var userExportColumns = []string{"id", "name", "email", "role", "created_at", "updated_at"}
+
+// exportFormatsByMediaType maps Accept header media types to export formats.
+var exportFormatsByMediaType = map[string]string{
+	"text/csv":             export.FormatCSV,
+	"application/x-ndjson": export.FormatNDJSON,
+	"application/jsonl":    export.FormatNDJSON,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": export.FormatXLSX,
+	"*/*":    export.FormatCSV,
+	"text/*": export.FormatCSV,
+}
+
+// negotiateExportFormat picks the export format from the format parameter or,
+// without one, the first supported media type of the Accept header.
+func negotiateExportFormat(accept string, format *api.GetUsersExportParamsFormat) (string, error) {
+	if format != nil {
+		if export.ContentType(string(*format)) == "" {
+			return "", echo.NewHTTPError(http.StatusBadRequest, "format must be csv, ndjson or xlsx")
+		}
+		return string(*format), nil
+	}
+	if strings.TrimSpace(accept) == "" {
+		return export.FormatCSV, nil
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+		if f, ok := exportFormatsByMediaType[mediaType]; ok {
+			return f, nil
+		}
+	}
+	return "", echo.NewHTTPError(http.StatusNotAcceptable, "Accept must allow text/csv, application/x-ndjson or application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+}
+
+// toExportColumns validates the requested columns, defaulting to all of them.
+func toExportColumns(requested *[]string) ([]string, error) {
+	if requested == nil || len(*requested) == 0 {
+		return userExportColumns, nil
+	}
+	seen := make(map[string]bool, len(*requested))
+	for _, c := range *requested {
+		if !slices.Contains(userExportColumns, c) {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown column %q; columns must be among %s", c, strings.Join(userExportColumns, ", ")))
+		}
+		if seen[c] {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Column %q is given more than once", c))
+		}
+		seen[c] = true
+	}
+	return *requested, nil
+}
+
+// userExportValue returns the value of column for u.
+func userExportValue(u *domain.User, column string) string {
+	switch column {
+	case "id":
+		return u.ID
+	case "name":
+		return u.Name
+	case "email":
+		return u.Email
+	case "role":
+		return u.Role
+	case "created_at":
+		return u.CreatedAt.UTC().Format(time.RFC3339)
+	case "updated_at":
+		return u.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return ""
+}
+
+// GetUsersExport (corresponds to operationId: get-users-export)
+// GET /v1/users:export
+func (h *UserHandler) GetUsersExport(c echo.Context, params api.GetUsersExportParams) error {
+	format, err := negotiateExportFormat(c.Request().Header.Get(echo.HeaderAccept), params.Format)
+	if err != nil {
+		return err
+	}
+	columns, err := toExportColumns(params.Columns)
+	if err != nil {
+		return err
+	}
+	filter := toUserFilter(params.Role, params.CreatedFrom, params.CreatedTo)
+
+	// The response starts with the first row, so that errors raised before
+	// any user is read, such as a forbidden caller, still get a status code
+	res := c.Response()
+	var out export.Writer
+	start := func() error {
+		res.Header().Set(echo.HeaderContentType, export.ContentType(format))
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="users.%s"`, format))
+		res.WriteHeader(http.StatusOK)
+		var err error
+		out, err = export.NewWriter(format, res, columns)
+		return err
+	}
+	values := make([]string, len(columns))
+	err = h.userInteractor.ExportUsers(c.Request().Context(), filter, func(u *domain.User) error {
+		if out == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		for i, column := range columns {
+			values[i] = userExportValue(u, column)
+		}
+		return out.WriteRow(values)
+	})
+	if err != nil && out == nil {
+		return toHTTPError(c, err, "Failed to export users")
+	}
+	if err == nil && out == nil {
+		err = start() // No users matched; send the header row alone
+	}
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		// The status has been sent, so an error can no longer be reported.
+		// Abort the connection so the client sees a failed download rather
+		// than a file that silently stops short.
+		c.Logger().Errorf("user export aborted: %v", err)
+		panic(http.ErrAbortHandler)
+	}
+	return nil
+}
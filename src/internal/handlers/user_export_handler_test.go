@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"apiserver/internal/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var exportTestUsers = []domain.User{
+	{ID: "11111111-1111-1111-1111-111111111111", Name: "Alice", Email: "alice@example.com", Password: "$2a$10$hash", Role: domain.RoleAdmin,
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), UpdatedAt: time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC)},
+}
+
+func newExportRequest(target, accept string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if accept != "" {
+		req.Header.Set(echo.HeaderAccept, accept)
+	}
+	return req
+}
+
+func TestUserHandler_GetUsersExport_CSVByDefault(t *testing.T) {
+	e, mockInteractor, _ := setupTestEnv()
+	mockInteractor.On("ExportUsers", mock.Anything, domain.UserFilter{}).Return(exportTestUsers, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newExportRequest("/v1/users:export", ""))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="users.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "id,name,email,role,created_at,updated_at\n"+
+		"11111111-1111-1111-1111-111111111111,Alice,alice@example.com,admin,2026-01-02T03:04:05Z,2026-02-03T04:05:06Z\n", rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), "$2a$")
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestUserHandler_GetUsersExport_ColumnsAndFilters(t *testing.T) {
+	e, mockInteractor, _ := setupTestEnv()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockInteractor.On("ExportUsers", mock.Anything, domain.UserFilter{Role: domain.RoleAdmin, CreatedFrom: from}).Return(exportTestUsers, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newExportRequest("/v1/users:export?format=ndjson&columns=email,name&role=admin&created_from=2026-01-01T00:00:00Z", "text/csv"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `{"email":"alice@example.com","name":"Alice"}`+"\n", rec.Body.String())
+	mockInteractor.AssertExpectations(t)
+}
+
+func TestUserHandler_GetUsersExport_XLSXFromAccept(t *testing.T) {
+	e, mockInteractor, _ := setupTestEnv()
+	mockInteractor.On("ExportUsers", mock.Anything, domain.UserFilter{}).Return(exportTestUsers, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newExportRequest("/v1/users:export", "application/json;q=0.9, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `attachment; filename="users.xlsx"`, rec.Header().Get(echo.HeaderContentDisposition))
+	_, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	assert.NoError(t, err)
+}
+
+func TestUserHandler_GetUsersExport_NoMatches(t *testing.T) {
+	e, mockInteractor, _ := setupTestEnv()
+	mockInteractor.On("ExportUsers", mock.Anything, domain.UserFilter{}).Return(nil, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newExportRequest("/v1/users:export?columns=id", ""))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "id\n", rec.Body.String())
+}
+
+func TestUserHandler_GetUsersExport_RejectedRequests(t *testing.T) {
+	tests := map[string]struct {
+		target string
+		accept string
+		want   int
+	}{
+		"unknown column":     {"/v1/users:export?columns=id,password", "", http.StatusBadRequest},
+		"repeated column":    {"/v1/users:export?columns=id,id", "", http.StatusBadRequest},
+		"unknown format":     {"/v1/users:export?format=pdf", "", http.StatusBadRequest},
+		"unacceptable":       {"/v1/users:export", "application/pdf", http.StatusNotAcceptable},
+		"invalid created_to": {"/v1/users:export?created_to=yesterday", "", http.StatusBadRequest},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e, mockInteractor, _ := setupTestEnv()
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, newExportRequest(tt.target, tt.accept))
+
+			assert.Equal(t, tt.want, rec.Code)
+			mockInteractor.AssertNotCalled(t, "ExportUsers", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUserHandler_GetUsersExport_Forbidden(t *testing.T) {
+	e, mockInteractor, _ := setupTestEnv()
+	mockInteractor.On("ExportUsers", mock.Anything, domain.UserFilter{}).Return(nil, domain.ErrForbidden).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newExportRequest("/v1/users:export", ""))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+}
+
+func TestUserHandler_GetUsersExport_AbortsOnErrorMidStream(t *testing.T) {
+	e, mockInteractor, _ := setupTestEnv()
+	mockInteractor.On("ExportUsers", mock.Anything, domain.UserFilter{}).Return(exportTestUsers, assert.AnError).Once()
+
+	rec := httptest.NewRecorder()
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		e.ServeHTTP(rec, newExportRequest("/v1/users:export", ""))
+	})
+}
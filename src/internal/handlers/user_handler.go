@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strings" // For error checking
+	"time"
 
 	"apiserver/internal/domain"
 	"apiserver/internal/generated/api" // oapi-codegen generated package
@@ -12,14 +13,14 @@ import (
 )
 
 // UserHandler handles HTTP requests for user operations.
-// It implements the api.ServerInterface generated by oapi-codegen.
+// It implements the user operations of the api.ServerInterface generated by oapi-codegen.
 type UserHandler struct {
 	userInteractor usecases.UserInteractor
 }
 
 // NewUserHandler creates a new UserHandler.
-// It returns api.ServerInterface to make it explicit, but the concrete type is *UserHandler.
-func NewUserHandler(uc usecases.UserInteractor) api.ServerInterface {
+// Combine it with the other handlers in a Server to obtain an api.ServerInterface.
+func NewUserHandler(uc usecases.UserInteractor) *UserHandler {
 	return &UserHandler{userInteractor: uc}
 }
 
@@ -29,9 +30,56 @@ func toAPIUser(domainUser *domain.User) api.User {
 	if domainUser == nil {
 		return api.User{} // Return empty struct if domainUser is nil
 	}
-	return api.User{
-		Name: domainUser.Name,
+	out := api.User{
+		Name:               domainUser.Name,
+		AvatarUrl:          optionalString(domainUser.AvatarURL),
+		AvatarThumbnailUrl: optionalString(domainUser.AvatarThumbnailURL),
+		Preferences:        toAPIUserPreferences(domainUser.Preferences),
+		ErasedAt:           optionalTime(domainUser.ErasedAt),
 	}
+	if len(domainUser.Attributes) > 0 {
+		attrs := domainUser.Attributes
+		out.Attributes = &attrs
+	}
+	return out
+}
+
+func toAPIUserPreferences(p domain.UserPreferences) api.UserPreferences {
+	return api.UserPreferences{
+		Locale:   optionalString(p.Locale),
+		Timezone: optionalString(p.Timezone),
+		Notifications: api.NotificationPreferences{
+			Email:  p.Notifications.Email,
+			Digest: api.NotificationDigest(p.Notifications.Digest),
+		},
+	}
+}
+
+// toUserPatch maps a PATCH request body to domain.UserPatch. Empty names and
+// email addresses are treated as not provided.
+func toUserPatch(body api.UserPatch) domain.UserPatch {
+	var patch domain.UserPatch
+	if body.Name != nil && *body.Name != "" {
+		patch.Name = body.Name
+	}
+	if body.Email != nil && *body.Email != "" {
+		email := string(*body.Email)
+		patch.Email = &email
+	}
+	if p := body.Preferences; p != nil {
+		patch.Preferences = &domain.PreferencesPatch{Locale: p.Locale, Timezone: p.Timezone}
+		if n := p.Notifications; n != nil {
+			patch.Preferences.NotificationsEmail = n.Email
+			if n.Digest != nil {
+				digest := string(*n.Digest)
+				patch.Preferences.NotificationsDigest = &digest
+			}
+		}
+	}
+	if body.Attributes != nil {
+		patch.Attributes = *body.Attributes
+	}
+	return patch
 }
 
 func toAPIUserSlice(domainUsers []domain.User) []api.User {
@@ -44,13 +92,32 @@ func toAPIUserSlice(domainUsers []domain.User) []api.User {
 
 // --- Implement methods from api.ServerInterface ---
 
+// toUserFilter maps the filter query parameters shared by the user list and
+// export to domain.UserFilter.
+func toUserFilter(role *string, createdFrom, createdTo *time.Time) domain.UserFilter {
+	var filter domain.UserFilter
+	if role != nil {
+		filter.Role = *role
+	}
+	if createdFrom != nil {
+		filter.CreatedFrom = *createdFrom
+	}
+	if createdTo != nil {
+		filter.CreatedTo = *createdTo
+	}
+	return filter
+}
+
 // GetUsers (corresponds to operationId: getUsers)
 // GET /v1/users
-func (h *UserHandler) GetUsers(c echo.Context) error {
-	users, err := h.userInteractor.GetAllUsers(c.Request().Context())
+func (h *UserHandler) GetUsers(c echo.Context, params api.GetUsersParams) error {
+	filter := toUserFilter(params.Role, params.CreatedFrom, params.CreatedTo)
+	if params.GroupId != nil {
+		filter.GroupID = params.GroupId.String()
+	}
+	users, err := h.userInteractor.GetAllUsers(c.Request().Context(), filter)
 	if err != nil {
-		// TODO: Implement proper error DTO mapping as per OpenAPI spec for errors
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve users: "+err.Error())
+		return toHTTPError(c, err, "Failed to retrieve users")
 	}
 	// Response is an array of api.User
 	return c.JSON(http.StatusOK, toAPIUserSlice(users))
@@ -76,8 +143,7 @@ func (h *UserHandler) PostUser(c echo.Context) error {
 	// openapi_types.Email is an alias for string, so it can be used directly.
 	createdUser, err := h.userInteractor.CreateNewUser(c.Request().Context(), requestBody.Name, string(requestBody.Email), placeholderPassword)
 	if err != nil {
-		// TODO: Implement proper error DTO mapping
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create user: "+err.Error())
+		return toHTTPError(c, err, "Failed to create user")
 	}
 
 	// The spec for POST /v1/user response is an array of api.User. This is unconventional.
@@ -91,44 +157,20 @@ func (h *UserHandler) PostUser(c echo.Context) error {
 func (h *UserHandler) PathUser(c echo.Context, userId openapi_types.UUID) error {
 	idStr := userId.String() // openapi_types.UUID is github.com/google/uuid.UUID
 
-	// The oapi-codegen does not generate a specific request body type for PATCH in ServerInterface.
-	// We assume it will be similar to UserInfo or a partial update.
-	// Binding to api.UserInfo.
-	var updateReq api.UserInfo
+	var updateReq api.PathUserJSONRequestBody
 	if err := c.Bind(&updateReq); err != nil {
 		// TODO: Implement proper error DTO mapping
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body for patch: "+err.Error())
 	}
+	patch := toUserPatch(updateReq)
 
-	// Prepare optional fields for interactor.
-	// updateReq.Name is string, updateReq.Email is openapi_types.Email.
-	var name, email *string
-	var plainPassword *string // Password update is not supported by UserInfo DTO.
-
-	// Only set fields if they appear to be provided in the request.
-	// For JSON, if a field is missing, it will be its zero value (e.g., "" for string).
-	// A more robust way would be to use pointer fields in UserInfo DTO for partial updates,
-	// or check against zero values explicitly if that's the convention.
-	// Given UserInfo fields are not pointers, we check for non-empty.
-	if updateReq.Name != "" {
-		name = &updateReq.Name
-	}
-	if updateReq.Email != "" {
-		emailStr := string(updateReq.Email)
-		email = &emailStr
-	}
-	// Password cannot be updated with the current UserInfo DTO.
-	// If UserInfo had a *string Password field, it would be:
-	// if updateReq.Password != nil { plainPassword = updateReq.Password }
-
-	updatedUser, err := h.userInteractor.UpdateExistingUser(c.Request().Context(), idStr, name, email, plainPassword)
+	updatedUser, err := h.userInteractor.PatchUser(c.Request().Context(), idStr, patch)
 	if err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "not found") { // Basic error check
 			// TODO: Implement proper error DTO mapping
 			return echo.NewHTTPError(http.StatusNotFound, "User not found")
 		}
-		// TODO: Implement proper error DTO mapping
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update user: "+err.Error())
+		return toHTTPError(c, err, "Failed to update user")
 	}
 
 	// Response for PATCH is a single api.User object
@@ -146,8 +188,7 @@ func (h *UserHandler) DeleteUser(c echo.Context, userId openapi_types.UUID) erro
 			// TODO: Implement proper error DTO mapping
 			return echo.NewHTTPError(http.StatusNotFound, "User not found")
 		}
-		// TODO: Implement proper error DTO mapping
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete user: "+err.Error())
+		return toHTTPError(c, err, "Failed to delete user")
 	}
 	// Response for DELETE is 200 OK with empty content as per spec.
 	// Using http.StatusNoContent (204) is also common for DELETE success with no body.
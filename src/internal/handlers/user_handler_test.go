@@ -25,9 +25,9 @@ import (
 func setupTestEnv() (*echo.Echo, *mocks.MockUserInteractor, api.ServerInterface) {
 	e := echo.New()
 	mockInteractor := new(mocks.MockUserInteractor)
-	userHandler := NewUserHandler(mockInteractor) 
-	api.RegisterHandlers(e, userHandler)          
-	return e, mockInteractor, userHandler
+	server := &Server{UserHandler: NewUserHandler(mockInteractor)}
+	api.RegisterHandlers(e, server)
+	return e, mockInteractor, server
 }
 
 func TestUserHandler_GetUsers_Success(t *testing.T) {
@@ -42,7 +42,7 @@ func TestUserHandler_GetUsers_Success(t *testing.T) {
 	}
 	expectedAPIUsers := []api.User{{Name: "User One"}, {Name: "User Two"}}
 
-	mockInteractor.On("GetAllUsers", mock.Anything).Return(domainUsers, nil).Once()
+	mockInteractor.On("GetAllUsers", mock.Anything, domain.UserFilter{}).Return(domainUsers, nil).Once()
 
 	e.ServeHTTP(rec, req)
 
@@ -60,7 +60,7 @@ func TestUserHandler_GetUsers_Error(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
 	rec := httptest.NewRecorder()
 
-	mockInteractor.On("GetAllUsers", mock.Anything).Return(nil, assert.AnError).Once()
+	mockInteractor.On("GetAllUsers", mock.Anything, domain.UserFilter{}).Return(nil, assert.AnError).Once()
 
 	e.ServeHTTP(rec, req)
 
@@ -167,7 +167,8 @@ func TestUserHandler_PathUser_Success(t *testing.T) {
 	}
 	expectedAPIUserResponse := api.User{Name: updateName}
 
-	mockInteractor.On("UpdateExistingUser", mock.Anything, userID.String(), &updateName, mock.MatchedBy(func(email *string) bool { return *email == string(updateEmail) }), (*string)(nil)).Return(expectedDomainUser, nil).Once()
+	emailStr := string(updateEmail)
+	mockInteractor.On("PatchUser", mock.Anything, userID.String(), domain.UserPatch{Name: &updateName, Email: &emailStr}).Return(expectedDomainUser, nil).Once()
 
 	e.ServeHTTP(rec, req)
 
@@ -218,7 +219,7 @@ func TestUserHandler_PathUser_InteractorNotFound(t *testing.T) {
 	rec := httptest.NewRecorder()
 
 	// Reflecting observed behavior: handler seems to pass nil for name if email in request is empty.
-	mockInteractor.On("UpdateExistingUser", mock.Anything, userID.String(), (*string)(nil), (*string)(nil), (*string)(nil)).Return(nil, errors.New("user not found")).Once()
+	mockInteractor.On("PatchUser", mock.Anything, userID.String(), domain.UserPatch{}).Return(nil, errors.New("user not found")).Once()
 
 	e.ServeHTTP(rec, req)
 
@@ -238,7 +239,7 @@ func TestUserHandler_PathUser_InteractorError(t *testing.T) {
 	rec := httptest.NewRecorder()
 
 	// Reflecting observed behavior: handler seems to pass nil for name if email in request is empty.
-	mockInteractor.On("UpdateExistingUser", mock.Anything, userID.String(), (*string)(nil), (*string)(nil), (*string)(nil)).Return(nil, assert.AnError).Once()
+	mockInteractor.On("PatchUser", mock.Anything, userID.String(), domain.UserPatch{}).Return(nil, assert.AnError).Once()
 
 	e.ServeHTTP(rec, req)
 
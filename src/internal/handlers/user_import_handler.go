@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// importFormats maps the accepted upload media types to import formats.
+var importFormats = map[string]string{
+	"text/csv":             domain.ImportFormatCSV,
+	"application/x-ndjson": domain.ImportFormatNDJSON,
+	"application/jsonl":    domain.ImportFormatNDJSON,
+}
+
+// UserImportHandler handles HTTP requests for bulk user imports.
+type UserImportHandler struct {
+	userImportInteractor usecases.UserImportInteractor
+}
+
+// NewUserImportHandler creates a new UserImportHandler.
+func NewUserImportHandler(uc usecases.UserImportInteractor) *UserImportHandler {
+	return &UserImportHandler{userImportInteractor: uc}
+}
+
+func toAPIUserImportRows(rows []domain.UserImportRowResult) []api.UserImportRowResult {
+	out := make([]api.UserImportRowResult, len(rows))
+	for i, r := range rows {
+		out[i] = api.UserImportRowResult{
+			Row:   r.Row,
+			Email: optionalString(r.Email),
+			Error: optionalString(r.Error),
+		}
+		if r.UserID != "" {
+			id := uuid.MustParse(r.UserID)
+			out[i].UserId = &id
+		}
+	}
+	return out
+}
+
+func toAPIUserImportReport(r *domain.UserImportReport) api.UserImportReport {
+	return api.UserImportReport{
+		Total:   r.Total,
+		Created: r.Created,
+		Failed:  r.Failed,
+		Error:   optionalString(r.Error),
+		Rows:    toAPIUserImportRows(r.Rows),
+	}
+}
+
+func toAPIUserImportJob(j *domain.UserImportJob) api.UserImportJob {
+	out := api.UserImportJob{
+		Id:         uuid.MustParse(j.ID),
+		Format:     api.UserImportFormat(j.Format),
+		Status:     api.UserImportStatus(j.Status),
+		Total:      j.Report.Total,
+		Created:    j.Report.Created,
+		Failed:     j.Report.Failed,
+		Error:      optionalString(j.Report.Error),
+		CreatedAt:  j.CreatedAt,
+		UpdatedAt:  j.UpdatedAt,
+		FinishedAt: optionalTime(j.FinishedAt),
+	}
+	if j.Finished() {
+		rows := toAPIUserImportRows(j.Report.Rows)
+		out.Rows = &rows
+	}
+	return out
+}
+
+// PostUsersImport (corresponds to operationId: post-users-import)
+// POST /v1/users:import
+func (h *UserImportHandler) PostUsersImport(c echo.Context, params api.PostUsersImportParams) error {
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	format, ok := importFormats[mediaType]
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "Content-Type must be text/csv or application/x-ndjson")
+	}
+
+	// The body is read row by row, never held in memory as a whole
+	ctx := c.Request().Context()
+	upload := c.Request().Body
+	if params.Async != nil && *params.Async {
+		job, err := h.userImportInteractor.StartUserImport(ctx, format, upload)
+		if err != nil {
+			return toHTTPError(c, err, "Failed to start user import")
+		}
+		c.Response().Header().Set(echo.HeaderLocation, "/v1/users/imports/"+job.ID)
+		return c.JSON(http.StatusAccepted, toAPIUserImportJob(job))
+	}
+
+	report, err := h.userImportInteractor.ImportUsers(ctx, format, upload)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to import users")
+	}
+	return c.JSON(http.StatusOK, toAPIUserImportReport(report))
+}
+
+// GetUserImport (corresponds to operationId: get-user-import)
+// GET /v1/users/imports/{import_id}
+func (h *UserImportHandler) GetUserImport(c echo.Context, importId openapi_types.UUID) error {
+	job, err := h.userImportInteractor.GetUserImport(c.Request().Context(), importId.String())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve user import")
+	}
+	return c.JSON(http.StatusOK, toAPIUserImportJob(job))
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupUserImportTestEnv() (*echo.Echo, *mocks.MockUserImportInteractor) {
+	e := echo.New()
+	mockImports := new(mocks.MockUserImportInteractor)
+	api.RegisterHandlers(e, &Server{UserImportHandler: NewUserImportHandler(mockImports)})
+	return e, mockImports
+}
+
+func newUploadRequest(target, contentType, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, contentType)
+	return req
+}
+
+func TestUserImportHandler_PostUsersImport_Sync(t *testing.T) {
+	e, mockImports := setupUserImportTestEnv()
+	userID := uuid.New()
+	mockImports.On("ImportUsers", mock.Anything, domain.ImportFormatCSV, mock.Anything).Return(&domain.UserImportReport{
+		Total:   2,
+		Created: 1,
+		Failed:  1,
+		Rows: []domain.UserImportRowResult{
+			{Row: 1, Email: "alice@example.com", UserID: userID.String()},
+			{Row: 2, Email: "bob", Error: "email is not a valid address"},
+		},
+	}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newUploadRequest("/v1/users:import", "text/csv; charset=utf-8", "name,email,password\n"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var report api.UserImportReport
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.Equal(t, 1, report.Created)
+	if assert.Len(t, report.Rows, 2) {
+		assert.Equal(t, userID, *report.Rows[0].UserId)
+		assert.Nil(t, report.Rows[0].Error)
+		assert.Nil(t, report.Rows[1].UserId)
+		assert.Equal(t, "email is not a valid address", *report.Rows[1].Error)
+	}
+	mockImports.AssertExpectations(t)
+}
+
+func TestUserImportHandler_PostUsersImport_Async(t *testing.T) {
+	e, mockImports := setupUserImportTestEnv()
+	jobID := uuid.New()
+	mockImports.On("StartUserImport", mock.Anything, domain.ImportFormatNDJSON, mock.Anything).Return(&domain.UserImportJob{
+		ID:        jobID.String(),
+		Format:    domain.ImportFormatNDJSON,
+		Status:    domain.ImportStatusPending,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newUploadRequest("/v1/users:import?async=true", "application/x-ndjson", "{}\n"))
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "/v1/users/imports/"+jobID.String(), rec.Header().Get(echo.HeaderLocation))
+	var job api.UserImportJob
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	assert.Equal(t, api.UserImportStatusPending, job.Status)
+	assert.Nil(t, job.Rows)
+	mockImports.AssertExpectations(t)
+}
+
+func TestUserImportHandler_PostUsersImport_UnsupportedMediaType(t *testing.T) {
+	e, mockImports := setupUserImportTestEnv()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newUploadRequest("/v1/users:import", echo.MIMEApplicationJSON, "[]"))
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	mockImports.AssertNotCalled(t, "ImportUsers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserImportHandler_PostUsersImport_InvalidHeader(t *testing.T) {
+	e, mockImports := setupUserImportTestEnv()
+	mockImports.On("ImportUsers", mock.Anything, domain.ImportFormatCSV, mock.Anything).
+		Return(nil, domain.ErrInvalidArgument).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newUploadRequest("/v1/users:import", "text/csv", "email\n"))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestUserImportHandler_GetUserImport(t *testing.T) {
+	e, mockImports := setupUserImportTestEnv()
+	jobID := uuid.New()
+	mockImports.On("GetUserImport", mock.Anything, jobID.String()).Return(&domain.UserImportJob{
+		ID:         jobID.String(),
+		Format:     domain.ImportFormatCSV,
+		Status:     domain.ImportStatusCompleted,
+		Report:     domain.UserImportReport{Total: 1, Created: 1, Rows: []domain.UserImportRowResult{{Row: 1, UserID: uuid.NewString()}}},
+		FinishedAt: time.Now(),
+	}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users/imports/"+jobID.String(), nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var job api.UserImportJob
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	assert.Equal(t, api.UserImportStatusCompleted, job.Status)
+	if assert.NotNil(t, job.Rows) {
+		assert.Len(t, *job.Rows, 1)
+	}
+}
+
+func TestUserImportHandler_GetUserImport_NotFound(t *testing.T) {
+	e, mockImports := setupUserImportTestEnv()
+	jobID := uuid.New()
+	mockImports.On("GetUserImport", mock.Anything, jobID.String()).Return(nil, domain.ErrUserImportNotFound).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users/imports/"+jobID.String(), nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "User import not found")
+}
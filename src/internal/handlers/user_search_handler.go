@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"html"
+	"net/http"
+	"strings"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// UserSearchHandler handles HTTP requests for user search.
+type UserSearchHandler struct {
+	userSearchInteractor usecases.UserSearchInteractor
+}
+
+// NewUserSearchHandler creates a new UserSearchHandler.
+func NewUserSearchHandler(uc usecases.UserSearchInteractor) *UserSearchHandler {
+	return &UserSearchHandler{userSearchInteractor: uc}
+}
+
+// highlight wraps the matched fragments of text in <em> tags and escapes the
+// rest for HTML. It returns nil when nothing matched.
+func highlight(text string, matches []domain.TextRange) *string {
+	if len(matches) == 0 {
+		return nil
+	}
+	runes := []rune(text)
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(html.EscapeString(string(runes[last:m.Start])))
+		b.WriteString("<em>")
+		b.WriteString(html.EscapeString(string(runes[m.Start:m.End])))
+		b.WriteString("</em>")
+		last = m.End
+	}
+	b.WriteString(html.EscapeString(string(runes[last:])))
+	out := b.String()
+	return &out
+}
+
+func toAPIUserSearchHit(hit *domain.UserSearchHit) api.UserSearchHit {
+	return api.UserSearchHit{
+		Id:        uuid.MustParse(hit.User.ID),
+		User:      toAPIUser(&hit.User),
+		Email:     openapi_types.Email(hit.User.Email),
+		Relevance: hit.Relevance,
+		Highlights: api.UserSearchHighlights{
+			Name:  highlight(hit.User.Name, hit.NameMatches),
+			Email: highlight(hit.User.Email, hit.EmailMatches),
+		},
+	}
+}
+
+// GetUsersSearch (corresponds to operationId: get-users-search)
+// GET /v1/users/search
+func (h *UserSearchHandler) GetUsersSearch(c echo.Context, params api.GetUsersSearchParams) error {
+	limit := 0
+	if params.Limit != nil {
+		if *params.Limit < 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be at least 1")
+		}
+		limit = *params.Limit
+	}
+	cursor := ""
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	page, err := h.userSearchInteractor.SearchUsers(c.Request().Context(), params.Q, limit, cursor)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to search users")
+	}
+
+	resp := api.UserSearchResult{
+		Mode:       api.UserSearchMode(page.Mode),
+		Hits:       make([]api.UserSearchHit, len(page.Hits)),
+		NextCursor: optionalString(page.NextCursor),
+	}
+	for i := range page.Hits {
+		resp.Hits[i] = toAPIUserSearchHit(&page.Hits[i])
+	}
+	return c.JSON(http.StatusOK, resp)
+}
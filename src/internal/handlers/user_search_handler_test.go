@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupUserSearchTestEnv() (*echo.Echo, *mocks.MockUserSearchInteractor) {
+	e := echo.New()
+	mockSearch := new(mocks.MockUserSearchInteractor)
+	api.RegisterHandlers(e, &Server{UserSearchHandler: NewUserSearchHandler(mockSearch)})
+	return e, mockSearch
+}
+
+func TestUserSearchHandler_GetUsersSearch(t *testing.T) {
+	e, mockSearch := setupUserSearchTestEnv()
+	userID := uuid.New()
+	mockSearch.On("SearchUsers", mock.Anything, "山田", 10, "").Return(&domain.UserSearchPage{
+		Mode: domain.UserSearchFullText,
+		Hits: []domain.UserSearchHit{{
+			User:        domain.User{ID: userID.String(), Name: "<b>山田</b> 太郎", Email: "taro@example.com"},
+			Relevance:   1.25,
+			NameMatches: []domain.TextRange{{Start: 3, End: 5}},
+		}},
+		NextCursor: "next",
+	}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users/search?limit=10&q="+url.QueryEscape("山田"), nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res api.UserSearchResult
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, api.Fulltext, res.Mode)
+	assert.Equal(t, "next", *res.NextCursor)
+	if assert.Len(t, res.Hits, 1) {
+		hit := res.Hits[0]
+		assert.Equal(t, userID, hit.Id)
+		assert.Equal(t, "taro@example.com", string(hit.Email))
+		assert.Equal(t, 1.25, hit.Relevance)
+		assert.Equal(t, "&lt;b&gt;<em>山田</em>&lt;/b&gt; 太郎", *hit.Highlights.Name)
+		assert.Nil(t, hit.Highlights.Email)
+	}
+	mockSearch.AssertExpectations(t)
+}
+
+func TestUserSearchHandler_GetUsersSearch_LastPage(t *testing.T) {
+	e, mockSearch := setupUserSearchTestEnv()
+	mockSearch.On("SearchUsers", mock.Anything, "bob@", 0, "cursor-1").Return(&domain.UserSearchPage{
+		Mode: domain.UserSearchEmailPrefix,
+		Hits: []domain.UserSearchHit{{
+			User:         domain.User{ID: uuid.NewString(), Name: "Bob", Email: "bob@example.com"},
+			EmailMatches: []domain.TextRange{{Start: 0, End: 4}},
+		}},
+	}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users/search?q=bob%40&cursor=cursor-1", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res api.UserSearchResult
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, api.EmailPrefix, res.Mode)
+	assert.Nil(t, res.NextCursor)
+	assert.Equal(t, "<em>bob@</em>example.com", *res.Hits[0].Highlights.Email)
+}
+
+func TestUserSearchHandler_GetUsersSearch_BadRequest(t *testing.T) {
+	e, mockSearch := setupUserSearchTestEnv()
+	mockSearch.On("SearchUsers", mock.Anything, "alice", 0, "bogus").Return(nil, domain.ErrInvalidArgument).Once()
+
+	for _, target := range []string{
+		"/v1/users/search",
+		"/v1/users/search?q=alice&limit=0",
+		"/v1/users/search?q=alice&cursor=bogus",
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+	mockSearch.AssertExpectations(t)
+}
+
+func TestUserSearchHandler_GetUsersSearch_UnavailableWithPIIEncryption(t *testing.T) {
+	e, mockSearch := setupUserSearchTestEnv()
+	mockSearch.On("SearchUsers", mock.Anything, "alice", 0, "").Return(nil, domain.ErrUserSearchUnavailable).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users/search?q=alice", nil))
+
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	mockSearch.AssertExpectations(t)
+}
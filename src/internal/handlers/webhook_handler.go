@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"net/http"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// defaultWebhookDeliveryPageSize mirrors the usecase default so next_cursor
+// can be computed when the client does not send a limit.
+const defaultWebhookDeliveryPageSize = 50
+
+// WebhookHandler handles HTTP requests for outgoing webhook management.
+type WebhookHandler struct {
+	webhookInteractor usecases.WebhookInteractor
+}
+
+// NewWebhookHandler creates a new WebhookHandler.
+func NewWebhookHandler(uc usecases.WebhookInteractor) *WebhookHandler {
+	return &WebhookHandler{webhookInteractor: uc}
+}
+
+// toAPIWebhook maps domain.WebhookSubscription to api.Webhook. The secret is never exposed.
+func toAPIWebhook(s *domain.WebhookSubscription) api.Webhook {
+	eventTypes := make([]api.WebhookEventType, len(s.EventTypes))
+	for i, t := range s.EventTypes {
+		eventTypes[i] = api.WebhookEventType(t)
+	}
+	return api.Webhook{
+		Id:          uuid.MustParse(s.ID),
+		Url:         s.URL,
+		EventTypes:  eventTypes,
+		Description: s.Description,
+		Active:      s.Active,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+func toAPIWebhookDelivery(d *domain.WebhookDelivery) api.WebhookDelivery {
+	out := api.WebhookDelivery{
+		Id:            d.ID,
+		WebhookId:     uuid.MustParse(d.SubscriptionID),
+		EventId:       d.EventID,
+		EventType:     api.WebhookEventType(d.EventType),
+		Status:        api.WebhookDeliveryStatus(d.Status),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		LastAttemptAt: optionalTime(d.LastAttemptAt),
+		LastError:     optionalString(d.LastError),
+		DeliveredAt:   optionalTime(d.DeliveredAt),
+		CreatedAt:     d.CreatedAt,
+	}
+	if d.LastStatusCode != 0 {
+		code := d.LastStatusCode
+		out.LastStatusCode = &code
+	}
+	return out
+}
+
+func toEventTypeStrings(types []api.WebhookEventType) []string {
+	out := make([]string, len(types))
+	for i, t := range types {
+		out[i] = string(t)
+	}
+	return out
+}
+
+// GetWebhooks (corresponds to operationId: get-webhooks)
+// GET /v1/webhooks
+func (h *WebhookHandler) GetWebhooks(c echo.Context) error {
+	subs, err := h.webhookInteractor.ListWebhooks(c.Request().Context())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve webhooks")
+	}
+	webhooks := make([]api.Webhook, len(subs))
+	for i := range subs {
+		webhooks[i] = toAPIWebhook(&subs[i])
+	}
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+// PostWebhook (corresponds to operationId: post-webhook)
+// POST /v1/webhooks
+func (h *WebhookHandler) PostWebhook(c echo.Context) error {
+	var requestBody api.PostWebhookJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+
+	in := usecases.WebhookInput{
+		URL:        requestBody.Url,
+		EventTypes: toEventTypeStrings(requestBody.EventTypes),
+	}
+	if requestBody.Description != nil {
+		in.Description = *requestBody.Description
+	}
+	if requestBody.Secret != nil {
+		in.Secret = *requestBody.Secret
+	}
+
+	sub, secret, err := h.webhookInteractor.CreateWebhook(c.Request().Context(), in)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to create webhook")
+	}
+	return c.JSON(http.StatusCreated, api.CreatedWebhook{
+		Webhook: toAPIWebhook(sub),
+		Secret:  secret,
+	})
+}
+
+// DeleteWebhook (corresponds to operationId: delete-webhook)
+// DELETE /v1/webhooks/{webhook_id}
+func (h *WebhookHandler) DeleteWebhook(c echo.Context, webhookId openapi_types.UUID) error {
+	if err := h.webhookInteractor.DeleteWebhook(c.Request().Context(), webhookId.String()); err != nil {
+		return toHTTPError(c, err, "Failed to delete webhook")
+	}
+	return c.JSON(http.StatusOK, map[string]string{})
+}
+
+// GetWebhook (corresponds to operationId: get-webhook)
+// GET /v1/webhooks/{webhook_id}
+func (h *WebhookHandler) GetWebhook(c echo.Context, webhookId openapi_types.UUID) error {
+	sub, err := h.webhookInteractor.GetWebhook(c.Request().Context(), webhookId.String())
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve webhook")
+	}
+	return c.JSON(http.StatusOK, toAPIWebhook(sub))
+}
+
+// PatchWebhook (corresponds to operationId: patch-webhook)
+// PATCH /v1/webhooks/{webhook_id}
+func (h *WebhookHandler) PatchWebhook(c echo.Context, webhookId openapi_types.UUID) error {
+	var requestBody api.PatchWebhookJSONRequestBody
+	if err := c.Bind(&requestBody); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+
+	patch := usecases.WebhookPatch{
+		URL:         requestBody.Url,
+		Description: requestBody.Description,
+		Active:      requestBody.Active,
+		Secret:      requestBody.Secret,
+	}
+	if requestBody.EventTypes != nil {
+		patch.EventTypes = toEventTypeStrings(*requestBody.EventTypes)
+	}
+
+	sub, err := h.webhookInteractor.UpdateWebhook(c.Request().Context(), webhookId.String(), patch)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to update webhook")
+	}
+	return c.JSON(http.StatusOK, toAPIWebhook(sub))
+}
+
+// GetWebhookDeliveries (corresponds to operationId: get-webhook-deliveries)
+// GET /v1/webhooks/{webhook_id}/deliveries
+func (h *WebhookHandler) GetWebhookDeliveries(c echo.Context, webhookId openapi_types.UUID, params api.GetWebhookDeliveriesParams) error {
+	limit := defaultWebhookDeliveryPageSize
+	if params.Limit != nil {
+		if *params.Limit < 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be at least 1")
+		}
+		limit = *params.Limit
+	}
+	var beforeID int64
+	if params.Cursor != nil {
+		beforeID = *params.Cursor
+	}
+
+	deliveries, err := h.webhookInteractor.ListWebhookDeliveries(c.Request().Context(), webhookId.String(), beforeID, limit)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to retrieve webhook deliveries")
+	}
+
+	resp := api.WebhookDeliveryList{Deliveries: make([]api.WebhookDelivery, len(deliveries))}
+	for i := range deliveries {
+		resp.Deliveries[i] = toAPIWebhookDelivery(&deliveries[i])
+	}
+	// A full page means there may be more; the client continues from the oldest ID
+	if len(deliveries) == limit {
+		next := deliveries[len(deliveries)-1].ID
+		resp.NextCursor = &next
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// PostWebhookRedeliver (corresponds to operationId: post-webhook-redeliver)
+// POST /v1/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver
+func (h *WebhookHandler) PostWebhookRedeliver(c echo.Context, webhookId openapi_types.UUID, deliveryId int64) error {
+	delivery, err := h.webhookInteractor.RedeliverWebhook(c.Request().Context(), webhookId.String(), deliveryId)
+	if err != nil {
+		return toHTTPError(c, err, "Failed to redeliver webhook")
+	}
+	return c.JSON(http.StatusAccepted, toAPIWebhookDelivery(delivery))
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/generated/api"
+	"apiserver/internal/usecases"
+	"apiserver/internal/usecases/mocks"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupWebhookTestEnv() (*echo.Echo, *mocks.MockWebhookInteractor) {
+	e := echo.New()
+	mockWebhooks := new(mocks.MockWebhookInteractor)
+	api.RegisterHandlers(e, &Server{WebhookHandler: NewWebhookHandler(mockWebhooks)})
+	return e, mockWebhooks
+}
+
+func TestWebhookHandler_PostWebhook_Success(t *testing.T) {
+	e, mockWebhooks := setupWebhookTestEnv()
+	webhookID := uuid.New()
+
+	mockWebhooks.On("CreateWebhook", mock.Anything, usecases.WebhookInput{
+		URL:        "https://example.com/hook",
+		EventTypes: []string{domain.EventUserCreated},
+	}).Return(&domain.WebhookSubscription{
+		ID:               webhookID.String(),
+		URL:              "https://example.com/hook",
+		EventTypes:       []string{domain.EventUserCreated},
+		SecretCiphertext: []byte("ciphertext"),
+		Active:           true,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}, "whsec_test", nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/v1/webhooks", api.WebhookInfo{Url: "https://example.com/hook", EventTypes: []api.WebhookEventType{api.UserCreated}}))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var created api.CreatedWebhook
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, "whsec_test", created.Secret)
+	assert.Equal(t, webhookID, created.Webhook.Id)
+	assert.Equal(t, []api.WebhookEventType{api.UserCreated}, created.Webhook.EventTypes)
+	assert.NotContains(t, rec.Body.String(), "ciphertext")
+	mockWebhooks.AssertExpectations(t)
+}
+
+func TestWebhookHandler_PostWebhook_Forbidden(t *testing.T) {
+	e, mockWebhooks := setupWebhookTestEnv()
+	mockWebhooks.On("CreateWebhook", mock.Anything, mock.Anything).Return(nil, "", domain.ErrForbidden).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPost, "/v1/webhooks", api.WebhookInfo{Url: "https://example.com/hook", EventTypes: []api.WebhookEventType{api.UserCreated}}))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestWebhookHandler_PatchWebhook_PassesOnlyGivenFields(t *testing.T) {
+	e, mockWebhooks := setupWebhookTestEnv()
+	webhookID := uuid.New()
+	active := false
+
+	mockWebhooks.On("UpdateWebhook", mock.Anything, webhookID.String(), usecases.WebhookPatch{Active: &active}).
+		Return(&domain.WebhookSubscription{ID: webhookID.String(), URL: "https://example.com/hook"}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, newJSONRequest(http.MethodPatch, "/v1/webhooks/"+webhookID.String(), map[string]interface{}{"active": false}))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockWebhooks.AssertExpectations(t)
+}
+
+func TestWebhookHandler_GetWebhook_NotFound(t *testing.T) {
+	e, mockWebhooks := setupWebhookTestEnv()
+	webhookID := uuid.New()
+	mockWebhooks.On("GetWebhook", mock.Anything, webhookID.String()).Return(nil, domain.ErrWebhookNotFound).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/webhooks/"+webhookID.String(), nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Webhook not found")
+}
+
+func TestWebhookHandler_GetWebhookDeliveries_Pagination(t *testing.T) {
+	e, mockWebhooks := setupWebhookTestEnv()
+	webhookID := uuid.New()
+	deliveries := []domain.WebhookDelivery{
+		{ID: 12, SubscriptionID: webhookID.String(), EventID: "e2", EventType: domain.EventUserUpdated, Status: domain.DeliveryStatusDead, Attempts: 12, LastStatusCode: 500, LastError: "receiver responded with 500"},
+		{ID: 11, SubscriptionID: webhookID.String(), EventID: "e1", EventType: domain.EventUserCreated, Status: domain.DeliveryStatusSucceeded, Attempts: 1, LastStatusCode: 204},
+	}
+	mockWebhooks.On("ListWebhookDeliveries", mock.Anything, webhookID.String(), int64(20), 2).Return(deliveries, nil).Once()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/webhooks/%s/deliveries?limit=2&cursor=20", webhookID), nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var list api.WebhookDeliveryList
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Len(t, list.Deliveries, 2)
+	assert.Equal(t, api.WebhookDeliveryStatusDead, list.Deliveries[0].Status)
+	assert.Equal(t, 500, *list.Deliveries[0].LastStatusCode)
+	assert.Nil(t, list.Deliveries[1].LastError)
+	if assert.NotNil(t, list.NextCursor) {
+		assert.Equal(t, int64(11), *list.NextCursor)
+	}
+	mockWebhooks.AssertExpectations(t)
+}
+
+func TestWebhookHandler_GetWebhookDeliveries_InvalidLimit(t *testing.T) {
+	e, mockWebhooks := setupWebhookTestEnv()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/webhooks/"+uuid.NewString()+"/deliveries?limit=0", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockWebhooks.AssertNotCalled(t, "ListWebhookDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWebhookHandler_PostWebhookRedeliver(t *testing.T) {
+	e, mockWebhooks := setupWebhookTestEnv()
+	webhookID := uuid.New()
+	mockWebhooks.On("RedeliverWebhook", mock.Anything, webhookID.String(), int64(7)).
+		Return(&domain.WebhookDelivery{ID: 7, SubscriptionID: webhookID.String(), Status: domain.DeliveryStatusPending}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/webhooks/%s/deliveries/7/redeliver", webhookID), nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	mockWebhooks.AssertExpectations(t)
+}
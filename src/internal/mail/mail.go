@@ -0,0 +1,37 @@
+// Package mail sends transactional email such as invitations.
+package mail
+
+import (
+	"context"
+	"log"
+)
+
+// Message is a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers email. Send returns once the message has been handed to
+// the mail server; delivery to the mailbox may still fail later.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// logSender writes messages to a logger instead of sending them.
+type logSender struct {
+	logger *log.Logger
+}
+
+// NewLogSender creates a Sender that writes each message to logger. It is
+// meant for development, where no mail server is available. Messages may
+// contain secrets such as invitation links, so do not use it in production.
+func NewLogSender(logger *log.Logger) Sender {
+	return &logSender{logger: logger}
+}
+
+func (s *logSender) Send(ctx context.Context, msg Message) error {
+	s.logger.Printf("mail to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/mail"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSender struct {
+	mock.Mock
+}
+
+func (m *MockSender) Send(ctx context.Context, msg mail.Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
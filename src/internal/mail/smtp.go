@@ -0,0 +1,87 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPConfig configures an SMTP Sender.
+type SMTPConfig struct {
+	Addr     string // host:port of the mail server
+	Username string // Empty to send without authentication
+	Password string
+	From     string // Sender address, optionally with a display name
+}
+
+// smtpSender sends messages through an SMTP server.
+type smtpSender struct {
+	cfg  SMTPConfig
+	auth smtp.Auth
+	now  func() time.Time
+}
+
+// NewSMTPSender creates a Sender that submits messages to the server at
+// cfg.Addr, using STARTTLS when the server offers it. PLAIN authentication is
+// used when cfg.Username is set; net/smtp refuses it over unencrypted
+// connections to anything but localhost.
+func NewSMTPSender(cfg SMTPConfig) Sender {
+	s := &smtpSender{cfg: cfg, now: time.Now}
+	if cfg.Username != "" {
+		host, _, _ := net.SplitHostPort(cfg.Addr)
+		s.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, host)
+	}
+	return s
+}
+
+func (s *smtpSender) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	from, err := envelopeAddress(s.cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	to, err := envelopeAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+	return smtp.SendMail(s.cfg.Addr, s.auth, from, []string{to}, formatMessage(s.cfg.From, msg, s.now()))
+}
+
+// envelopeAddress returns the bare address of a header address such as
+// "Example <noreply@example.com>".
+func envelopeAddress(addr string) (string, error) {
+	if strings.ContainsAny(addr, "\r\n") {
+		return "", fmt.Errorf("address %q contains a line break", addr)
+	}
+	if i := strings.LastIndexByte(addr, '<'); i >= 0 && strings.HasSuffix(addr, ">") {
+		addr = addr[i+1 : len(addr)-1]
+	}
+	if !strings.Contains(addr, "@") {
+		return "", fmt.Errorf("address %q has no domain", addr)
+	}
+	return addr, nil
+}
+
+// formatMessage renders msg as a MIME message. Subjects are encoded so they
+// may hold non-ASCII text, and the body is sent as UTF-8 with CRLF line ends.
+func formatMessage(from string, msg Message, date time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	body := strings.ReplaceAll(msg.Body, "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return b.Bytes()
+}
@@ -0,0 +1,51 @@
+package mail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatMessage(t *testing.T) {
+	date := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	got := formatMessage("Example <noreply@example.com>", Message{
+		To:      "bob@example.com",
+		Subject: "招待",
+		Body:    "line 1\nline 2\r\n",
+	}, date)
+
+	assert.Equal(t, "From: Example <noreply@example.com>\r\n"+
+		"To: bob@example.com\r\n"+
+		"Subject: =?utf-8?q?=E6=8B=9B=E5=BE=85?=\r\n"+
+		"Date: Sun, 18 Oct 2026 09:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=utf-8\r\n"+
+		"Content-Transfer-Encoding: 8bit\r\n"+
+		"\r\n"+
+		"line 1\r\nline 2\r\n", string(got))
+}
+
+func TestEnvelopeAddress(t *testing.T) {
+	tests := map[string]struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		"bare address":      {in: "bob@example.com", want: "bob@example.com"},
+		"with display name": {in: "Example <noreply@example.com>", want: "noreply@example.com"},
+		"no domain":         {in: "bob", wantErr: true},
+		"header injection":  {in: "bob@example.com\r\nBcc: eve@example.com", wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := envelopeAddress(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
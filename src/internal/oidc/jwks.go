@@ -0,0 +1,193 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// minRefetchInterval stops tokens with made-up key IDs from making us fetch
+// the provider's keys on every request.
+const minRefetchInterval = time.Minute
+
+// JWKSMaxAge is how long clients may cache our key set. A new signing key is
+// published at least this long before it signs.
+const JWKSMaxAge = time.Hour
+
+// JWK is a JSON Web Key (RFC 7517) of the kinds used to sign ID tokens.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"` // RSA
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"` // EC
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// NewJWK returns the JWK publishing key under kid for verifying signatures
+// made with alg.
+func NewJWK(kid, alg string, key crypto.PublicKey) (JWK, error) {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: alg,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		return JWK{
+			Kty: "EC",
+			Kid: kid,
+			Use: "sig",
+			Alg: alg,
+			Crv: key.Curve.Params().Name,
+			X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, size))),
+			Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, size))),
+		}, nil
+	}
+	return JWK{}, fmt.Errorf("unsupported key type %T", key)
+}
+
+// keySet is the parsed JWKS of a provider, by key ID.
+type keySet struct {
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// lookup returns the key kid for alg. Tokens without a key ID are accepted
+// when the set has a single key of the right type.
+func (s *keySet) lookup(kid, alg string) crypto.PublicKey {
+	if kid != "" {
+		if key := s.keys[kid]; key != nil && keyFits(key, alg) {
+			return key
+		}
+		return nil
+	}
+	var found crypto.PublicKey
+	for _, key := range s.keys {
+		if keyFits(key, alg) {
+			if found != nil {
+				return nil // Ambiguous
+			}
+			found = key
+		}
+	}
+	return found
+}
+
+// keyFits reports whether key is of the type alg signs with.
+func keyFits(key crypto.PublicKey, alg string) bool {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")
+	case *ecdsa.PublicKey:
+		return strings.HasPrefix(alg, "ES")
+	}
+	return false
+}
+
+// key returns the provider's key kid for alg, fetching the JWKS when it has
+// not been fetched yet or does not have the key, as after a key rotation.
+func (p *Provider) key(ctx context.Context, meta *metadata, kid, alg string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keys != nil {
+		if key := p.keys.lookup(kid, alg); key != nil {
+			return key, nil
+		}
+		if p.clock.Now().Sub(p.keys.fetchedAt) < minRefetchInterval {
+			return nil, fmt.Errorf("no key %q for %s", kid, alg)
+		}
+	}
+
+	var doc struct {
+		Keys []JWK `json:"keys"`
+	}
+	if err := p.getJSON(ctx, meta.JWKSURI, &doc); err != nil {
+		return nil, fmt.Errorf("fetching keys: %w", err)
+	}
+	set := &keySet{keys: make(map[string]crypto.PublicKey), fetchedAt: p.clock.Now()}
+	for _, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			continue // Keys of other types are none of our business
+		}
+		set.keys[k.Kid] = key
+	}
+	p.keys = set
+	if key := set.lookup(kid, alg); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("no key %q for %s", kid, alg)
+}
+
+// publicKey decodes an RSA or EC public key.
+func (k JWK) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent is out of range")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("EC point is not on the curve")
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("malformed key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,256 @@
+// Package oidctest serves a mock OpenID Connect provider for tests.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"apiserver/internal/oidc"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// User is the account the mock provider signs users in as.
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// grant is an authorization code waiting to be exchanged.
+type grant struct {
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	user          User
+}
+
+// Server is a mock provider with one registered client. Every authorization
+// request signs in as the current user without any interaction.
+type Server struct {
+	ClientID     string
+	ClientSecret string // Empty for a public client
+	// Now is the provider's clock, used for the times in ID tokens.
+	Now func() time.Time
+	// ModifyIDToken, when set, may change the claims of ID tokens before they
+	// are signed, to produce tokens that must be rejected.
+	ModifyIDToken func(claims jwt.MapClaims)
+
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	key      *rsa.PrivateKey
+	kid      string
+	keyCount int
+	user     User
+	codes    map[string]grant
+	jwksHits int
+}
+
+// NewServer starts a mock provider for the client clientID.
+func NewServer(clientID, clientSecret string) *Server {
+	s := &Server{ClientID: clientID, ClientSecret: clientSecret, Now: time.Now, codes: make(map[string]grant)}
+	s.RotateKey()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("GET /jwks", s.jwks)
+	mux.HandleFunc("GET /authorize", s.authorize)
+	mux.HandleFunc("POST /token", s.token)
+	s.srv = httptest.NewServer(mux)
+	return s
+}
+
+// Issuer returns the issuer URL of the provider.
+func (s *Server) Issuer() string {
+	return s.srv.URL
+}
+
+// Close shuts the provider down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Config returns a client configuration for the provider, named name.
+func (s *Server) Config(name, redirectURL string) oidc.Config {
+	return oidc.Config{Name: name, Issuer: s.Issuer(), ClientID: s.ClientID, ClientSecret: s.ClientSecret, RedirectURL: redirectURL}
+}
+
+// SetUser sets the account later sign-ins are made as.
+func (s *Server) SetUser(u User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.user = u
+}
+
+// RotateKey replaces the signing key. The JWKS only lists the new key.
+func (s *Server) RotateKey() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keyCount++
+	s.key = key
+	s.kid = fmt.Sprintf("key-%d", s.keyCount)
+}
+
+// JWKSFetches returns how many times the JWKS has been requested.
+func (s *Server) JWKSFetches() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jwksHits
+}
+
+// Authorize plays the browser: it opens authURL, signs in as the current
+// user and returns the code and state the provider redirects back with.
+func (s *Server) Authorize(authURL string) (code, state string, err error) {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		return "", "", fmt.Errorf("authorize: status %d", resp.StatusCode)
+	}
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return "", "", err
+	}
+	q := location.Query()
+	if e := q.Get("error"); e != "" {
+		return "", "", errors.New("authorize: " + e)
+	}
+	return q.Get("code"), q.Get("state"), nil
+}
+
+// Sign returns claims as a token signed with the current key.
+func (s *Server) Sign(claims jwt.MapClaims) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sign(claims)
+}
+
+func (s *Server) sign(claims jwt.MapClaims) string {
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	t.Header["kid"] = s.kid
+	raw, err := t.SignedString(s.key)
+	if err != nil {
+		panic(err)
+	}
+	return raw
+}
+
+func (s *Server) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                s.Issuer(),
+		"authorization_endpoint":                s.Issuer() + "/authorize",
+		"token_endpoint":                        s.Issuer() + "/token",
+		"jwks_uri":                              s.Issuer() + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (s *Server) jwks(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jwksHits++
+	key, err := oidc.NewJWK(s.kid, "RS256", &s.key.PublicKey)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string][]oidc.JWK{"keys": {key}})
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || q.Get("client_id") != s.ClientID {
+		http.Error(w, "unknown client", http.StatusBadRequest)
+		return
+	}
+	back := redirectURI.Query()
+	back.Set("state", q.Get("state"))
+	if q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		back.Set("error", "invalid_request")
+	} else {
+		code, _ := oidc.NewRandomString()
+		s.mu.Lock()
+		s.codes[code] = grant{redirectURI: q.Get("redirect_uri"), codeChallenge: q.Get("code_challenge"), nonce: q.Get("nonce"), user: s.user}
+		s.mu.Unlock()
+		back.Set("code", code)
+	}
+	redirectURI.RawQuery = back.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	clientID, secret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		clientID = r.PostForm.Get("client_id")
+	}
+	if clientID != s.ClientID || secret != s.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	code := r.PostForm.Get("code")
+	g, ok := s.codes[code]
+	delete(s.codes, code) // Codes are single-use
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("redirect_uri") != g.redirectURI ||
+		oidc.CodeChallenge(r.PostForm.Get("code_verifier")) != g.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := s.Now()
+	claims := jwt.MapClaims{
+		"iss":            s.Issuer(),
+		"sub":            g.user.Subject,
+		"aud":            s.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          g.nonce,
+		"email":          g.user.Email,
+		"email_verified": g.user.EmailVerified,
+		"name":           g.user.Name,
+	}
+	if s.ModifyIDToken != nil {
+		s.ModifyIDToken(claims)
+	}
+	accessToken, _ := oidc.NewRandomString()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     s.sign(claims),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
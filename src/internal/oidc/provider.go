@@ -0,0 +1,298 @@
+// Package oidc signs users in through external OpenID Connect identity
+// providers with the authorization code flow and PKCE.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"apiserver/internal/clock"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// ErrCodeRejected is returned when the provider refuses to exchange an
+	// authorization code, because it is unknown, used or expired, or because
+	// the PKCE verifier does not match.
+	ErrCodeRejected = errors.New("oidc: authorization code rejected")
+	// ErrInvalidIDToken is returned when an ID token fails verification.
+	ErrInvalidIDToken = errors.New("oidc: invalid ID token")
+)
+
+// DefaultScopes are requested when a provider is configured without scopes.
+var DefaultScopes = []string{"openid", "email", "profile"}
+
+// clockSkew is how far the provider's clock may be off from ours when
+// checking the times in ID tokens.
+const clockSkew = time.Minute
+
+// maxResponseSize bounds the documents read from a provider.
+const maxResponseSize = 1 << 20
+
+// signingMethods are the ID token algorithms accepted. Symmetric algorithms
+// are not, since they would make the client secret a signing key.
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// Config describes an identity provider registered for this server.
+type Config struct {
+	Name         string // Identifies the provider in URLs and linked identities, such as "okta"
+	Issuer       string // Issuer URL; the discovery document is served below it
+	ClientID     string
+	ClientSecret string // Empty for public clients
+	RedirectURL  string // Where the provider sends the user back with the code
+	Scopes       []string
+}
+
+// Claims are the verified claims of an ID token that sign-in relies on.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// metadata is the part of the discovery document the client uses.
+type metadata struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	SigningAlgs           []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// Provider is the client of one identity provider. Its endpoints are
+// discovered on first use, and its signing keys are fetched again when a
+// token is signed with a key not seen before.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+	clock  clock.Clock
+
+	mu   sync.Mutex
+	meta *metadata
+	keys *keySet
+}
+
+// NewProvider creates a Provider for cfg. client may be nil to use
+// http.DefaultClient.
+func NewProvider(cfg Config, client *http.Client, clk clock.Clock) *Provider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = DefaultScopes
+	}
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+	return &Provider{cfg: cfg, client: client, clock: clk}
+}
+
+// Name returns the name the provider was configured with.
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// discover returns the provider's metadata, fetching it the first time.
+// Failures are not cached, so a provider that was down is retried.
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.meta != nil {
+		return p.meta, nil
+	}
+	var meta metadata
+	if err := p.getJSON(ctx, p.cfg.Issuer+"/.well-known/openid-configuration", &meta); err != nil {
+		return nil, fmt.Errorf("oidc: discovering %s: %w", p.cfg.Name, err)
+	}
+	// A document naming another issuer could make us accept its tokens
+	if strings.TrimSuffix(meta.Issuer, "/") != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc: discovering %s: issuer %q does not match %q", p.cfg.Name, meta.Issuer, p.cfg.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc: discovering %s: endpoints are missing", p.cfg.Name)
+	}
+	p.meta = &meta
+	return p.meta, nil
+}
+
+// AuthCodeURL returns the URL to send the user to for signing in. state is
+// returned with the code, nonce is embedded in the ID token, and
+// codeChallenge is CodeChallenge of the verifier later passed to Exchange.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("oidc: authorization endpoint of %s: %w", p.cfg.Name, err)
+	}
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// tokenResponse is the successful or error response of the token endpoint.
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange redeems an authorization code at the token endpoint and returns
+// the verified claims of the ID token, which must carry nonce.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Claims, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	if p.cfg.ClientSecret == "" {
+		form.Set("client_id", p.cfg.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		// client_secret_basic encodes both parts before joining them (RFC 6749 2.3.1)
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: exchanging code with %s: %w", p.cfg.Name, err)
+	}
+	defer resp.Body.Close()
+
+	var tok tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&tok); err != nil {
+		return nil, fmt.Errorf("oidc: exchanging code with %s: status %d: %w", p.cfg.Name, resp.StatusCode, err)
+	}
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("%w: %s %s", ErrCodeRejected, tok.Error, tok.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: exchanging code with %s: status %d", p.cfg.Name, resp.StatusCode)
+	}
+	if tok.IDToken == "" {
+		return nil, fmt.Errorf("%w: the token response has no id_token", ErrInvalidIDToken)
+	}
+	return p.VerifyIDToken(ctx, tok.IDToken, nonce)
+}
+
+// idTokenClaims are the claims of an ID token.
+type idTokenClaims struct {
+	Nonce         string    `json:"nonce"`
+	AuthorizedBy  string    `json:"azp"`
+	Email         string    `json:"email"`
+	EmailVerified boolClaim `json:"email_verified"`
+	Name          string    `json:"name"`
+	jwt.RegisteredClaims
+}
+
+// boolClaim accepts the strings "true" and "false" as well as booleans, as
+// some providers send email_verified as a string.
+type boolClaim bool
+
+func (b *boolClaim) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*b = boolClaim(v)
+	case string:
+		*b = boolClaim(v == "true")
+	}
+	return nil
+}
+
+// VerifyIDToken checks the signature of raw against the provider's keys and
+// its issuer, audience, lifetime and nonce (OpenID Connect Core 3.1.3.7).
+func (p *Provider) VerifyIDToken(ctx context.Context, raw, nonce string) (*Claims, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	methods := signingMethods
+	if len(meta.SigningAlgs) > 0 {
+		methods = slices.DeleteFunc(slices.Clone(meta.SigningAlgs), func(alg string) bool {
+			return !slices.Contains(signingMethods, alg)
+		})
+	}
+
+	var c idTokenClaims
+	_, err = jwt.ParseWithClaims(raw, &c, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.key(ctx, meta, kid, t.Method.Alg())
+	},
+		jwt.WithValidMethods(methods),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(p.clock.Now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	if c.Subject == "" {
+		return nil, fmt.Errorf("%w: sub is missing", ErrInvalidIDToken)
+	}
+	// A token issued to several clients must name us as the one it was for
+	if (len(c.Audience) > 1 || c.AuthorizedBy != "") && c.AuthorizedBy != p.cfg.ClientID {
+		return nil, fmt.Errorf("%w: azp %q is not this client", ErrInvalidIDToken, c.AuthorizedBy)
+	}
+	if nonce == "" || c.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce does not match", ErrInvalidIDToken)
+	}
+	return &Claims{
+		Subject:       c.Subject,
+		Email:         c.Email,
+		EmailVerified: bool(c.EmailVerified),
+		Name:          c.Name,
+	}, nil
+}
+
+// getJSON fetches rawURL and decodes the JSON response into v.
+func (p *Provider) getJSON(ctx context.Context, rawURL string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", rawURL, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v)
+}
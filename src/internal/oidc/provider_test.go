@@ -0,0 +1,200 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/oidc"
+	"apiserver/internal/oidc/oidctest"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "https://app.example.com/v1/login/oidc/mock/callback"
+
+var alice = oidctest.User{Subject: "alice-sub", Email: "alice@example.com", EmailVerified: true, Name: "Alice"}
+
+// newProvider starts a mock provider signed in as alice and a client for it
+// whose clock the provider follows.
+func newProvider(t *testing.T, clientSecret string) (*oidctest.Server, *oidc.Provider, *clock.Fake) {
+	t.Helper()
+	srv := oidctest.NewServer("client-1", clientSecret)
+	t.Cleanup(srv.Close)
+	srv.SetUser(alice)
+	clk := clock.NewFake(time.Now())
+	srv.Now = clk.Now
+	return srv, oidc.NewProvider(srv.Config("mock", redirectURL), nil, clk), clk
+}
+
+// signIn runs the browser part of the flow and exchanges the code.
+func signIn(t *testing.T, srv *oidctest.Server, p *oidc.Provider) (*oidc.Claims, error) {
+	t.Helper()
+	verifier, err := oidc.NewRandomString()
+	require.NoError(t, err)
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", oidc.CodeChallenge(verifier))
+	require.NoError(t, err)
+	code, state, err := srv.Authorize(authURL)
+	require.NoError(t, err)
+	require.Equal(t, "state-1", state)
+	return p.Exchange(context.Background(), code, verifier, "nonce-1")
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	srv, p, _ := newProvider(t, "secret")
+
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", "challenge")
+	require.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, srv.Issuer()+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	q := u.Query()
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "client-1", q.Get("client_id"))
+	assert.Equal(t, redirectURL, q.Get("redirect_uri"))
+	assert.Equal(t, "openid email profile", q.Get("scope"))
+	assert.Equal(t, "state-1", q.Get("state"))
+	assert.Equal(t, "nonce-1", q.Get("nonce"))
+	assert.Equal(t, "challenge", q.Get("code_challenge"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	for name, secret := range map[string]string{"confidential client": "s3cret/+&", "public client": ""} {
+		t.Run(name, func(t *testing.T) {
+			srv, p, _ := newProvider(t, secret)
+
+			claims, err := signIn(t, srv, p)
+
+			require.NoError(t, err)
+			assert.Equal(t, &oidc.Claims{Subject: "alice-sub", Email: "alice@example.com", EmailVerified: true, Name: "Alice"}, claims)
+		})
+	}
+}
+
+func TestProvider_Exchange_WrongVerifier(t *testing.T) {
+	srv, p, _ := newProvider(t, "secret")
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", oidc.CodeChallenge("the-real-verifier"))
+	require.NoError(t, err)
+	code, _, err := srv.Authorize(authURL)
+	require.NoError(t, err)
+
+	_, err = p.Exchange(context.Background(), code, "a-guessed-verifier", "nonce-1")
+
+	assert.ErrorIs(t, err, oidc.ErrCodeRejected)
+}
+
+func TestProvider_Exchange_WrongNonce(t *testing.T) {
+	srv, p, _ := newProvider(t, "secret")
+	verifier, _ := oidc.NewRandomString()
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1", oidc.CodeChallenge(verifier))
+	require.NoError(t, err)
+	code, _, err := srv.Authorize(authURL)
+	require.NoError(t, err)
+
+	_, err = p.Exchange(context.Background(), code, verifier, "nonce-2")
+
+	assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+}
+
+func TestProvider_Exchange_RejectsBadIDTokens(t *testing.T) {
+	tests := map[string]func(c jwt.MapClaims){
+		"other audience":    func(c jwt.MapClaims) { c["aud"] = "client-2" },
+		"other issuer":      func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"expired":           func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-2 * time.Minute).Unix() },
+		"no expiry":         func(c jwt.MapClaims) { delete(c, "exp") },
+		"issued in future":  func(c jwt.MapClaims) { c["iat"] = time.Now().Add(10 * time.Minute).Unix() },
+		"no subject":        func(c jwt.MapClaims) { delete(c, "sub") },
+		"azp of other":      func(c jwt.MapClaims) { c["azp"] = "client-2" },
+		"several audiences": func(c jwt.MapClaims) { c["aud"] = []string{"client-1", "client-2"} },
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv, p, _ := newProvider(t, "secret")
+			srv.ModifyIDToken = modify
+
+			_, err := signIn(t, srv, p)
+
+			assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+		})
+	}
+}
+
+func TestProvider_VerifyIDToken_Accepts(t *testing.T) {
+	srv, p, _ := newProvider(t, "secret")
+	raw := srv.Sign(jwt.MapClaims{
+		"iss":            srv.Issuer(),
+		"sub":            "bob-sub",
+		"aud":            []string{"client-1", "client-2"},
+		"azp":            "client-1",
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"nonce":          "n",
+		"email":          "bob@example.com",
+		"email_verified": "true",
+	})
+
+	claims, err := p.VerifyIDToken(context.Background(), raw, "n")
+
+	require.NoError(t, err)
+	assert.Equal(t, &oidc.Claims{Subject: "bob-sub", Email: "bob@example.com", EmailVerified: true}, claims)
+}
+
+func TestProvider_VerifyIDToken_RejectsSymmetricSignature(t *testing.T) {
+	srv, p, _ := newProvider(t, "secret")
+	raw, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss":   srv.Issuer(),
+		"sub":   "alice-sub",
+		"aud":   "client-1",
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"nonce": "n",
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	_, err = p.VerifyIDToken(context.Background(), raw, "n")
+
+	assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+}
+
+func TestProvider_KeyRotation(t *testing.T) {
+	srv, p, clk := newProvider(t, "secret")
+	_, err := signIn(t, srv, p)
+	require.NoError(t, err)
+	require.Equal(t, 1, srv.JWKSFetches())
+
+	// Keys are cached between sign-ins
+	_, err = signIn(t, srv, p)
+	require.NoError(t, err)
+	assert.Equal(t, 1, srv.JWKSFetches())
+
+	// A key seen right after fetching is not looked for again yet
+	srv.RotateKey()
+	_, err = signIn(t, srv, p)
+	assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+	assert.Equal(t, 1, srv.JWKSFetches())
+
+	clk.Advance(2 * time.Minute)
+	_, err = signIn(t, srv, p)
+	require.NoError(t, err)
+	assert.Equal(t, 2, srv.JWKSFetches())
+}
+
+func TestProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	impostor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issuer":"https://idp.example.com","authorization_endpoint":"https://idp.example.com/a","token_endpoint":"https://idp.example.com/t","jwks_uri":"https://idp.example.com/k"}`))
+	}))
+	defer impostor.Close()
+	p := oidc.NewProvider(oidc.Config{Name: "impostor", Issuer: impostor.URL, ClientID: "client-1"}, nil, clock.Real())
+
+	_, err := p.AuthCodeURL(context.Background(), "s", "n", "c")
+
+	assert.ErrorContains(t, err, "does not match")
+}
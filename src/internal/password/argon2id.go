@@ -0,0 +1,132 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// maxArgon2idLength bounds the passwords accepted for Argon2id, which has no
+// length limit of its own.
+const maxArgon2idLength = 1024
+
+// Argon2idParams are the cost parameters of Argon2id.
+type Argon2idParams struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32 // Bytes
+	KeyLength   uint32 // Bytes
+}
+
+// DefaultArgon2idParams returns the parameters recommended by the
+// x/crypto/argon2 documentation: one pass over 64 MiB.
+func DefaultArgon2idParams() Argon2idParams {
+	return Argon2idParams{
+		Memory:      64 * 1024,
+		Iterations:  1,
+		Parallelism: 4,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
+// argon2idHasher hashes passwords with Argon2id in the PHC string format,
+// $argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>, with unpadded base64.
+type argon2idHasher struct {
+	params Argon2idParams
+}
+
+// NewArgon2id creates a Hasher for Argon2id with the given parameters.
+func NewArgon2id(params Argon2idParams) (Hasher, error) {
+	return newArgon2id(params)
+}
+
+func newArgon2id(params Argon2idParams) (*argon2idHasher, error) {
+	switch {
+	case params.Memory < 8*uint32(params.Parallelism):
+		return nil, fmt.Errorf("argon2id memory must be at least 8 KiB per thread")
+	case params.Iterations < 1:
+		return nil, fmt.Errorf("argon2id iterations must be at least 1")
+	case params.Parallelism < 1:
+		return nil, fmt.Errorf("argon2id parallelism must be at least 1")
+	case params.SaltLength < 8:
+		return nil, fmt.Errorf("argon2id salt must be at least 8 bytes")
+	case params.KeyLength < 16:
+		return nil, fmt.Errorf("argon2id key must be at least 16 bytes")
+	}
+	return &argon2idHasher{params: params}, nil
+}
+
+func (h *argon2idHasher) recognizes(hash string) bool {
+	return strings.HasPrefix(hash, "$argon2id$")
+}
+
+func (h *argon2idHasher) Hash(plain string) (string, error) {
+	if len(plain) > maxArgon2idLength {
+		return "", ErrTooLong
+	}
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	p := h.params
+	key := argon2.IDKey([]byte(plain), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *argon2idHasher) Verify(hash, plain string) (bool, error) {
+	p, salt, key, err := parseArgon2id(hash)
+	if err != nil {
+		return false, err
+	}
+	if len(plain) > maxArgon2idLength {
+		return false, nil
+	}
+	derived := argon2.IDKey([]byte(plain), salt, p.Iterations, p.Memory, p.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(derived, key) == 1, nil
+}
+
+func (h *argon2idHasher) NeedsRehash(hash string) bool {
+	p, _, _, err := parseArgon2id(hash)
+	return err != nil || p != h.params
+}
+
+func (h *argon2idHasher) MaxLength() int {
+	return maxArgon2idLength
+}
+
+// parseArgon2id splits a PHC string into its parameters, salt and key. The
+// returned parameters carry the salt and key lengths found in the hash.
+func parseArgon2id(hash string) (Argon2idParams, []byte, []byte, error) {
+	var p Argon2idParams
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return p, nil, nil, ErrUnsupportedHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, ErrUnsupportedHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return p, nil, nil, ErrUnsupportedHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return p, nil, nil, ErrUnsupportedHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, ErrUnsupportedHash
+	}
+	if p.Iterations < 1 || p.Parallelism < 1 {
+		return p, nil, nil, ErrUnsupportedHash
+	}
+	p.SaltLength, p.KeyLength = uint32(len(salt)), uint32(len(key))
+	return p, salt, key, nil
+}
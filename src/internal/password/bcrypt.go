@@ -0,0 +1,65 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultBcryptCost is the bcrypt work factor used unless configured otherwise.
+const DefaultBcryptCost = bcrypt.DefaultCost
+
+// maxBcryptLength is where bcrypt stops reading the password.
+const maxBcryptLength = 72
+
+// bcryptHasher hashes passwords with bcrypt, in the usual $2a$ format.
+type bcryptHasher struct {
+	cost int
+}
+
+// NewBcrypt creates a Hasher for bcrypt with the given work factor. Bcrypt
+// ignores everything past the 72nd byte, so longer passwords are refused.
+func NewBcrypt(cost int) (Hasher, error) {
+	return newBcrypt(cost)
+}
+
+func newBcrypt(cost int) (*bcryptHasher, error) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("bcrypt cost must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, cost)
+	}
+	return &bcryptHasher{cost: cost}, nil
+}
+
+func (h *bcryptHasher) recognizes(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func (h *bcryptHasher) Hash(plain string) (string, error) {
+	if len(plain) > maxBcryptLength {
+		return "", ErrTooLong
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(plain), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h *bcryptHasher) Verify(hash, plain string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(plain))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (h *bcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.cost
+}
+
+func (h *bcryptHasher) MaxLength() int {
+	return maxBcryptLength
+}
@@ -0,0 +1,121 @@
+package password
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// BreachChecker tells whether a password is known from data breaches.
+type BreachChecker interface {
+	// Breached reports whether plain appears in the breach corpus often
+	// enough to be refused.
+	Breached(ctx context.Context, plain string) (bool, error)
+}
+
+// RangeSource serves the Pwned Passwords range format: for a prefix of five
+// upper-case hex digits of a SHA-1 hash, the lines "SUFFIX:COUNT" of every
+// breached password whose hash starts with it. Only the prefix leaves the
+// server, so the password stays private (k-anonymity).
+type RangeSource interface {
+	Range(ctx context.Context, prefix string) (io.ReadCloser, error)
+}
+
+// rangeChecker implements BreachChecker over a RangeSource.
+type rangeChecker struct {
+	source   RangeSource
+	minCount int
+}
+
+// NewBreachChecker creates a BreachChecker that refuses passwords seen at
+// least minCount times in source.
+func NewBreachChecker(source RangeSource, minCount int) BreachChecker {
+	return &rangeChecker{source: source, minCount: max(minCount, 1)}
+}
+
+func (c *rangeChecker) Breached(ctx context.Context, plain string) (bool, error) {
+	sum := sha1.Sum([]byte(plain))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := digest[:5], digest[5:]
+
+	body, err := c.source.Range(ctx, prefix)
+	if err != nil {
+		return false, err
+	}
+	defer body.Close()
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		s, countText, ok := strings.Cut(line, ":")
+		if !ok || !strings.EqualFold(s, suffix) {
+			continue
+		}
+		// Padding entries added by the API have a count of zero
+		count, err := strconv.Atoi(countText)
+		if err != nil {
+			return false, fmt.Errorf("breach range %s: invalid count in %q", prefix, line)
+		}
+		return count >= c.minCount, nil
+	}
+	return false, scanner.Err()
+}
+
+// dirRangeSource reads ranges from a local copy of the dataset.
+type dirRangeSource struct {
+	dir string
+}
+
+// NewDirRangeSource creates a RangeSource that reads the file <prefix>.txt in
+// dir for each prefix, as written by the Pwned Passwords downloader. A missing
+// file is an error rather than an empty range, so that an incomplete copy of
+// the dataset is noticed.
+func NewDirRangeSource(dir string) RangeSource {
+	return &dirRangeSource{dir: dir}
+}
+
+func (s *dirRangeSource) Range(ctx context.Context, prefix string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(s.dir, prefix+".txt"))
+	if err != nil {
+		return nil, fmt.Errorf("breach range %s: %w", prefix, err)
+	}
+	return f, nil
+}
+
+// httpRangeSource queries a range API such as https://api.pwnedpasswords.com.
+type httpRangeSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPRangeSource creates a RangeSource that requests baseURL/range/<prefix>.
+// Responses are padded with fake entries so that their size does not hint at
+// the prefix.
+func NewHTTPRangeSource(baseURL string, client *http.Client) RangeSource {
+	return &httpRangeSource{baseURL: strings.TrimRight(baseURL, "/"), client: client}
+}
+
+func (s *httpRangeSource) Range(ctx context.Context, prefix string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Add-Padding", "true")
+	req.Header.Set("User-Agent", "apiserver")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("breach range %s: %w", prefix, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("breach range %s: unexpected status %s", prefix, resp.Status)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,73 @@
+package password
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8.
+const passwordRange = "003D68EB55068C33ACE09247EE4C639306B:3\r\n" +
+	"1E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\r\n" +
+	"1F2B668E8AABEF1C59E9EC6F82E3F3CD786:0\r\n"
+
+func writeRange(t *testing.T, dir, prefix, body string) {
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, prefix+".txt"), []byte(body), 0o600))
+}
+
+func TestBreachChecker_DirRangeSource(t *testing.T) {
+	dir := t.TempDir()
+	writeRange(t, dir, "5BAA6", passwordRange)
+	checker := NewBreachChecker(NewDirRangeSource(dir), 1)
+
+	breached, err := checker.Breached(context.Background(), "password")
+	assert.NoError(t, err)
+	assert.True(t, breached)
+
+	// SHA-1 of "password1" is E38AD214943DAAD1D64C102FAEC29DE4AFE9DA3D
+	writeRange(t, dir, "E38AD", passwordRange)
+	breached, err = checker.Breached(context.Background(), "password1")
+	assert.NoError(t, err)
+	assert.False(t, breached)
+
+	// No file for the prefix of "hunter2" (F3BBBD66A63D4BF1747940578EC3D0103530E21D)
+	_, err = checker.Breached(context.Background(), "hunter2")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestBreachChecker_MinCount(t *testing.T) {
+	dir := t.TempDir()
+	writeRange(t, dir, "5BAA6", passwordRange)
+
+	breached, err := NewBreachChecker(NewDirRangeSource(dir), 5_000_000).Breached(context.Background(), "password")
+	assert.NoError(t, err)
+	assert.False(t, breached)
+}
+
+func TestBreachChecker_HTTPRangeSource(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+		if r.URL.Path != "/range/5BAA6" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(passwordRange))
+	}))
+	defer srv.Close()
+	checker := NewBreachChecker(NewHTTPRangeSource(srv.URL+"/", srv.Client()), 1)
+
+	breached, err := checker.Breached(context.Background(), "password")
+	assert.NoError(t, err)
+	assert.True(t, breached)
+	assert.Equal(t, []string{"/range/5BAA6"}, paths, "only the prefix is sent")
+
+	_, err = checker.Breached(context.Background(), "hunter2")
+	assert.ErrorContains(t, err, "503")
+}
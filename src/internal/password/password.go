@@ -0,0 +1,112 @@
+// Package password hashes passwords for storage. Every hash names the
+// algorithm and parameters that produced it, so that hashes from older
+// settings keep verifying and can be replaced once the password is known.
+package password
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Algorithms new hashes can be created with.
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+var (
+	// ErrTooLong is returned when a password is longer than the algorithm
+	// takes into account.
+	ErrTooLong = errors.New("password is too long")
+	// ErrUnsupportedHash is returned for a stored hash in no known format.
+	ErrUnsupportedHash = errors.New("unsupported password hash format")
+)
+
+// Hasher hashes passwords and verifies them against stored hashes.
+type Hasher interface {
+	// Hash returns the hash to store for plain.
+	Hash(plain string) (string, error)
+	// Verify reports whether plain matches hash. A mismatch is not an error.
+	Verify(hash, plain string) (bool, error)
+	// NeedsRehash reports whether hash was created with another algorithm or
+	// other parameters than Hash uses now. It is meant to be checked after a
+	// successful Verify, when the password can be hashed again.
+	NeedsRehash(hash string) bool
+	// MaxLength is the longest password, in bytes, that Hash accepts.
+	MaxLength() int
+}
+
+// algorithm is a Hasher for one hash format.
+type algorithm interface {
+	Hasher
+	// recognizes reports whether hash is in the format of the algorithm.
+	recognizes(hash string) bool
+}
+
+// Config selects how passwords are hashed.
+type Config struct {
+	Algorithm  string // Used for new hashes: AlgorithmArgon2id or AlgorithmBcrypt
+	BcryptCost int
+	Argon2id   Argon2idParams
+}
+
+// DefaultConfig returns the settings used unless configured otherwise.
+func DefaultConfig() Config {
+	return Config{
+		Algorithm:  AlgorithmArgon2id,
+		BcryptCost: DefaultBcryptCost,
+		Argon2id:   DefaultArgon2idParams(),
+	}
+}
+
+// multiHasher hashes with the configured algorithm and verifies hashes of
+// every supported one.
+type multiHasher struct {
+	primary    algorithm
+	algorithms []algorithm
+}
+
+// New creates a Hasher from cfg. Hashes of any supported algorithm verify,
+// whichever is configured, and report that they need rehashing unless they
+// match the configuration.
+func New(cfg Config) (Hasher, error) {
+	bcryptHasher, err := newBcrypt(cfg.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+	argon2idHasher, err := newArgon2id(cfg.Argon2id)
+	if err != nil {
+		return nil, err
+	}
+	h := &multiHasher{algorithms: []algorithm{bcryptHasher, argon2idHasher}}
+	switch cfg.Algorithm {
+	case AlgorithmBcrypt:
+		h.primary = bcryptHasher
+	case AlgorithmArgon2id:
+		h.primary = argon2idHasher
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm %q: use %s or %s", cfg.Algorithm, AlgorithmArgon2id, AlgorithmBcrypt)
+	}
+	return h, nil
+}
+
+func (h *multiHasher) Hash(plain string) (string, error) {
+	return h.primary.Hash(plain)
+}
+
+func (h *multiHasher) Verify(hash, plain string) (bool, error) {
+	for _, a := range h.algorithms {
+		if a.recognizes(hash) {
+			return a.Verify(hash, plain)
+		}
+	}
+	return false, ErrUnsupportedHash
+}
+
+func (h *multiHasher) NeedsRehash(hash string) bool {
+	return !h.primary.recognizes(hash) || h.primary.NeedsRehash(hash)
+}
+
+func (h *multiHasher) MaxLength() int {
+	return h.primary.MaxLength()
+}
@@ -0,0 +1,142 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2idParams keeps the tests fast.
+var testArgon2idParams = Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func testConfig(algorithm string) Config {
+	return Config{Algorithm: algorithm, BcryptCost: bcrypt.MinCost, Argon2id: testArgon2idParams}
+}
+
+func TestHasher_RoundTrip(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmArgon2id, AlgorithmBcrypt} {
+		t.Run(algorithm, func(t *testing.T) {
+			h, err := New(testConfig(algorithm))
+			assert.NoError(t, err)
+
+			hash, err := h.Hash("correct horse")
+			assert.NoError(t, err)
+			ok, err := h.Verify(hash, "correct horse")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			ok, err = h.Verify(hash, "wrong horse")
+			assert.NoError(t, err)
+			assert.False(t, ok)
+			assert.False(t, h.NeedsRehash(hash))
+
+			again, err := h.Hash("correct horse")
+			assert.NoError(t, err)
+			assert.NotEqual(t, hash, again, "salted")
+		})
+	}
+}
+
+func TestArgon2id_Format(t *testing.T) {
+	h, err := NewArgon2id(testArgon2idParams)
+	assert.NoError(t, err)
+	hash, err := h.Hash("secret")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"), hash)
+	assert.Len(t, strings.Split(hash, "$"), 6)
+}
+
+func TestHasher_VerifiesEveryAlgorithm(t *testing.T) {
+	legacy, err := New(testConfig(AlgorithmBcrypt))
+	assert.NoError(t, err)
+	bcryptHash, err := legacy.Hash("secret")
+	assert.NoError(t, err)
+
+	h, err := New(testConfig(AlgorithmArgon2id))
+	assert.NoError(t, err)
+	ok, err := h.Verify(bcryptHash, "secret")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, h.NeedsRehash(bcryptHash), "another algorithm")
+
+	argonHash, err := h.Hash("secret")
+	assert.NoError(t, err)
+	ok, err = legacy.Verify(argonHash, "secret")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, legacy.NeedsRehash(argonHash))
+}
+
+func TestHasher_NeedsRehashWhenParametersChange(t *testing.T) {
+	h, err := New(testConfig(AlgorithmArgon2id))
+	assert.NoError(t, err)
+	hash, err := h.Hash("secret")
+	assert.NoError(t, err)
+
+	stronger := testConfig(AlgorithmArgon2id)
+	stronger.Argon2id.Iterations = 2
+	upgraded, err := New(stronger)
+	assert.NoError(t, err)
+	ok, err := upgraded.Verify(hash, "secret")
+	assert.NoError(t, err)
+	assert.True(t, ok, "old parameters still verify")
+	assert.True(t, upgraded.NeedsRehash(hash))
+
+	b, err := New(testConfig(AlgorithmBcrypt))
+	assert.NoError(t, err)
+	bcryptHash, err := b.Hash("secret")
+	assert.NoError(t, err)
+	costlier := testConfig(AlgorithmBcrypt)
+	costlier.BcryptCost = bcrypt.MinCost + 1
+	b, err = New(costlier)
+	assert.NoError(t, err)
+	assert.True(t, b.NeedsRehash(bcryptHash))
+}
+
+func TestHasher_MaxLength(t *testing.T) {
+	b, err := New(testConfig(AlgorithmBcrypt))
+	assert.NoError(t, err)
+	assert.Equal(t, 72, b.MaxLength())
+	_, err = b.Hash(strings.Repeat("a", 73))
+	assert.ErrorIs(t, err, ErrTooLong)
+
+	// Argon2id reads the whole password, so passwords sharing the first 72 bytes differ
+	a, err := New(testConfig(AlgorithmArgon2id))
+	assert.NoError(t, err)
+	long := strings.Repeat("a", 100)
+	hash, err := a.Hash(long)
+	assert.NoError(t, err)
+	ok, err := a.Verify(hash, long[:72])
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	_, err = a.Hash(strings.Repeat("a", a.MaxLength()+1))
+	assert.ErrorIs(t, err, ErrTooLong)
+}
+
+func TestHasher_UnsupportedHash(t *testing.T) {
+	h, err := New(testConfig(AlgorithmArgon2id))
+	assert.NoError(t, err)
+	for _, hash := range []string{"", "plaintext", "$argon2i$v=19$m=64,t=1,p=1$c2FsdA$a2V5", "$argon2id$v=19$m=64,t=1,p=1$!!$a2V5"} {
+		ok, err := h.Verify(hash, "secret")
+		assert.ErrorIs(t, err, ErrUnsupportedHash, hash)
+		assert.False(t, ok)
+		assert.True(t, h.NeedsRehash(hash))
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	cfg := testConfig("scrypt")
+	_, err := New(cfg)
+	assert.Error(t, err)
+
+	cfg = testConfig(AlgorithmBcrypt)
+	cfg.BcryptCost = 40
+	_, err = New(cfg)
+	assert.Error(t, err)
+
+	cfg = testConfig(AlgorithmArgon2id)
+	cfg.Argon2id.Iterations = 0
+	_, err = New(cfg)
+	assert.Error(t, err)
+}
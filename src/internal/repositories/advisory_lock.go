@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	db "apiserver/internal/db/sqlc"
+)
+
+// AdvisoryLocker takes named locks that every server sharing the database
+// sees, using MySQL's GET_LOCK. A lock belongs to the connection that took
+// it, so it is released by MySQL if the server dies while holding it.
+type AdvisoryLocker interface {
+	// TryLock takes the lock name without waiting. acquired is false when it
+	// is held elsewhere; otherwise release must be called to give it up.
+	// Names are limited to 64 characters.
+	TryLock(ctx context.Context, name string) (release func(), acquired bool, err error)
+}
+
+// mysqlAdvisoryLocker implements AdvisoryLocker with GET_LOCK.
+type mysqlAdvisoryLocker struct {
+	dbConn *sql.DB
+}
+
+// NewAdvisoryLocker creates a new instance of AdvisoryLocker. Each held lock
+// keeps a connection out of the pool.
+func NewAdvisoryLocker(conn *sql.DB) AdvisoryLocker {
+	return &mysqlAdvisoryLocker{dbConn: conn}
+}
+
+func (l *mysqlAdvisoryLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	// The lock must be released on the connection that took it
+	conn, err := l.dbConn.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	q := db.New(conn)
+	acquired, err := q.GetLock(ctx, name)
+	if err != nil || !acquired {
+		conn.Close()
+		return nil, false, err
+	}
+	release := func() {
+		if err := q.ReleaseLock(context.Background(), name); err != nil {
+			// Closing the connection below frees the lock in any case
+			log.Printf("releasing lock %s: %v", name, err)
+		}
+		conn.Close()
+	}
+	return release, true, nil
+}
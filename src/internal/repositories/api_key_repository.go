@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// APIKeyRepository defines the interface for API key data operations.
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key *domain.APIKey) (*domain.APIKey, error)
+	GetAPIKeyByID(ctx context.Context, id string) (*domain.APIKey, error)         // Returns nil, nil when not found
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) // Returns nil, nil when not found
+	ListAPIKeysByUser(ctx context.Context, userID string) ([]domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string, at time.Time) error
+	TouchAPIKey(ctx context.Context, id string, at time.Time) error // Records the last time the key was used
+}
+
+// sqlcAPIKeyRepository implements APIKeyRepository using sqlc generated code.
+type sqlcAPIKeyRepository struct {
+	querier db.Querier
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository.
+func NewAPIKeyRepository(conn *sql.DB) APIKeyRepository {
+	return &sqlcAPIKeyRepository{querier: db.New(conn)}
+}
+
+// toDomainAPIKey converts a sqlc row to domain.APIKey. Scopes are stored space separated.
+func toDomainAPIKey(k db.ApiKey) *domain.APIKey {
+	key := &domain.APIKey{
+		ID:         k.ID.String(),
+		UserID:     k.UserID.String(),
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		SecretHash: k.SecretHash,
+		Scopes:     strings.Fields(k.Scopes),
+		CreatedAt:  k.CreatedAt,
+	}
+	if k.ExpiresAt.Valid {
+		key.ExpiresAt = k.ExpiresAt.Time
+	}
+	if k.LastUsedAt.Valid {
+		key.LastUsedAt = k.LastUsedAt.Time
+	}
+	if k.RevokedAt.Valid {
+		key.RevokedAt = k.RevokedAt.Time
+	}
+	return key
+}
+
+func (r *sqlcAPIKeyRepository) CreateAPIKey(ctx context.Context, key *domain.APIKey) (*domain.APIKey, error) {
+	userID, err := uuid.Parse(key.UserID)
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.querier.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		ID:         keyID,
+		UserID:     userID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		SecretHash: key.SecretHash,
+		Scopes:     strings.Join(key.Scopes, " "),
+		ExpiresAt:  sql.NullTime{Time: key.ExpiresAt, Valid: !key.ExpiresAt.IsZero()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetAPIKeyByID(ctx, keyID.String())
+}
+
+func (r *sqlcAPIKeyRepository) GetAPIKeyByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	keyID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	k, err := r.querier.GetAPIKeyByID(ctx, keyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainAPIKey(k), nil
+}
+
+func (r *sqlcAPIKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	k, err := r.querier.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainAPIKey(k), nil
+}
+
+func (r *sqlcAPIKeyRepository) ListAPIKeysByUser(ctx context.Context, userID string) ([]domain.APIKey, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.querier.ListAPIKeysByUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]domain.APIKey, len(rows))
+	for i, k := range rows {
+		keys[i] = *toDomainAPIKey(k)
+	}
+	return keys, nil
+}
+
+func (r *sqlcAPIKeyRepository) RevokeAPIKey(ctx context.Context, id string, at time.Time) error {
+	keyID, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.querier.RevokeAPIKey(ctx, db.RevokeAPIKeyParams{RevokedAt: sql.NullTime{Time: at, Valid: true}, ID: keyID})
+	return err
+}
+
+func (r *sqlcAPIKeyRepository) TouchAPIKey(ctx context.Context, id string, at time.Time) error {
+	keyID, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+	_, err = r.querier.TouchAPIKey(ctx, db.TouchAPIKeyParams{LastUsedAt: sql.NullTime{Time: at, Valid: true}, ID: keyID})
+	return err
+}
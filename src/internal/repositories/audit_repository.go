@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+)
+
+// AuditRepository defines the interface for the append-only audit log.
+type AuditRepository interface {
+	AppendAuditEvent(ctx context.Context, event domain.AuditEvent) error // Joins the transaction in ctx, if any
+	ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error)
+}
+
+// sqlcAuditRepository implements AuditRepository using sqlc generated code.
+type sqlcAuditRepository struct {
+	querier db.Querier
+}
+
+// NewAuditRepository creates a new instance of AuditRepository.
+func NewAuditRepository(conn *sql.DB) AuditRepository {
+	return &sqlcAuditRepository{querier: db.New(conn)}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// marshalOptional returns nil for empty values so the column is stored as NULL.
+func marshalOptional(v interface{}, empty bool) (json.RawMessage, error) {
+	if empty {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+func (r *sqlcAuditRepository) AppendAuditEvent(ctx context.Context, event domain.AuditEvent) error {
+	changes, err := marshalOptional(event.Changes, len(event.Changes) == 0)
+	if err != nil {
+		return err
+	}
+	metadata, err := marshalOptional(event.Metadata, len(event.Metadata) == 0)
+	if err != nil {
+		return err
+	}
+	_, err = querierFrom(ctx, r.querier).CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		Action:     event.Action,
+		ActorID:    nullString(event.ActorID),
+		TargetID:   nullString(event.TargetID),
+		Changes:    changes,
+		Metadata:   metadata,
+		RequestID:  nullString(event.RequestID),
+		Ip:         nullString(event.IP),
+		OccurredAt: event.OccurredAt,
+	})
+	return err
+}
+
+func (r *sqlcAuditRepository) ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	rows, err := r.querier.ListAuditEvents(ctx, db.ListAuditEventsParams{
+		ActorID:      nullString(filter.ActorID),
+		TargetID:     nullString(filter.TargetID),
+		Action:       nullString(filter.Action),
+		OccurredFrom: sql.NullTime{Time: filter.From, Valid: !filter.From.IsZero()},
+		OccurredTo:   sql.NullTime{Time: filter.To, Valid: !filter.To.IsZero()},
+		BeforeID:     sql.NullInt64{Int64: filter.BeforeID, Valid: filter.BeforeID > 0},
+		Limit:        int32(filter.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainAuditEvents(rows)
+}
+
+func toDomainAuditEvents(rows []db.AuditEvent) ([]domain.AuditEvent, error) {
+	events := make([]domain.AuditEvent, len(rows))
+	for i, row := range rows {
+		events[i] = domain.AuditEvent{
+			ID:         row.ID,
+			Action:     row.Action,
+			ActorID:    row.ActorID.String,
+			TargetID:   row.TargetID.String,
+			RequestID:  row.RequestID.String,
+			IP:         row.Ip.String,
+			OccurredAt: row.OccurredAt,
+		}
+		if len(row.Changes) > 0 {
+			if err := json.Unmarshal(row.Changes, &events[i].Changes); err != nil {
+				return nil, err
+			}
+		}
+		if len(row.Metadata) > 0 {
+			if err := json.Unmarshal(row.Metadata, &events[i].Metadata); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return events, nil
+}
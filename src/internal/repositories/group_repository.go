@@ -0,0 +1,212 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// GroupRepository defines the interface for user groups and their members.
+// Memberships are removed with the group or the user they belong to.
+type GroupRepository interface {
+	CreateGroup(ctx context.Context, group *domain.Group) (*domain.Group, error)
+	GetGroup(ctx context.Context, id string) (*domain.Group, error)         // Returns nil, nil when not found
+	GetGroupByName(ctx context.Context, name string) (*domain.Group, error) // Returns nil, nil when not found
+	ListGroups(ctx context.Context) ([]domain.Group, error)
+	UpdateGroup(ctx context.Context, group *domain.Group) (*domain.Group, error) // Returns nil, nil when not found
+	DeleteGroup(ctx context.Context, id string) error
+
+	// AddGroupMember adds userID to groupID. It returns false when the user
+	// already belongs to the group.
+	AddGroupMember(ctx context.Context, groupID, userID string) (bool, error)
+	// RemoveGroupMember removes userID from groupID. It returns false when
+	// the user did not belong to the group.
+	RemoveGroupMember(ctx context.Context, groupID, userID string) (bool, error)
+	ListGroupMembers(ctx context.Context, groupID string) ([]domain.User, error) // In name order
+	ListUserGroups(ctx context.Context, userID string) ([]domain.Group, error)
+}
+
+// sqlcGroupRepository implements GroupRepository using sqlc generated code.
+type sqlcGroupRepository struct {
+	querier db.Querier
+	pii     PIIConfig // Decrypts member names and emails
+}
+
+// NewGroupRepository creates a new instance of GroupRepository.
+func NewGroupRepository(conn *sql.DB, pii PIIConfig) GroupRepository {
+	return &sqlcGroupRepository{querier: db.New(conn), pii: pii}
+}
+
+func toDomainGroup(g db.UserGroup) *domain.Group {
+	return &domain.Group{
+		ID:          g.ID.String(),
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
+
+func toDomainGroupSlice(rows []db.UserGroup) []domain.Group {
+	groups := make([]domain.Group, len(rows))
+	for i, g := range rows {
+		groups[i] = *toDomainGroup(g)
+	}
+	return groups
+}
+
+func (r *sqlcGroupRepository) CreateGroup(ctx context.Context, group *domain.Group) (*domain.Group, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	_, err = querierFrom(ctx, r.querier).CreateGroup(ctx, db.CreateGroupParams{
+		ID:          id,
+		Name:        group.Name,
+		Description: group.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetGroup(ctx, id.String())
+}
+
+func (r *sqlcGroupRepository) GetGroup(ctx context.Context, id string) (*domain.Group, error) {
+	groupID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	g, err := querierFrom(ctx, r.querier).GetGroup(ctx, groupID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainGroup(g), nil
+}
+
+func (r *sqlcGroupRepository) GetGroupByName(ctx context.Context, name string) (*domain.Group, error) {
+	g, err := querierFrom(ctx, r.querier).GetGroupByName(ctx, name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainGroup(g), nil
+}
+
+func (r *sqlcGroupRepository) ListGroups(ctx context.Context) ([]domain.Group, error) {
+	rows, err := querierFrom(ctx, r.querier).ListGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainGroupSlice(rows), nil
+}
+
+func (r *sqlcGroupRepository) UpdateGroup(ctx context.Context, group *domain.Group) (*domain.Group, error) {
+	id, err := uuid.Parse(group.ID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = querierFrom(ctx, r.querier).UpdateGroup(ctx, db.UpdateGroupParams{
+		Name:        group.Name,
+		Description: group.Description,
+		ID:          id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetGroup(ctx, group.ID)
+}
+
+func (r *sqlcGroupRepository) DeleteGroup(ctx context.Context, id string) error {
+	groupID, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+	_, err = querierFrom(ctx, r.querier).DeleteGroup(ctx, groupID)
+	return err
+}
+
+// groupMemberKey parses the IDs identifying a membership.
+func groupMemberKey(groupID, userID string) (uuid.UUID, uuid.UUID, error) {
+	group, err := uuid.Parse(groupID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return group, user, nil
+}
+
+func (r *sqlcGroupRepository) AddGroupMember(ctx context.Context, groupID, userID string) (bool, error) {
+	group, user, err := groupMemberKey(groupID, userID)
+	if err != nil {
+		return false, err
+	}
+	res, err := querierFrom(ctx, r.querier).AddGroupMember(ctx, db.AddGroupMemberParams{GroupID: group, UserID: user})
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *sqlcGroupRepository) RemoveGroupMember(ctx context.Context, groupID, userID string) (bool, error) {
+	group, user, err := groupMemberKey(groupID, userID)
+	if err != nil {
+		return false, err
+	}
+	res, err := querierFrom(ctx, r.querier).RemoveGroupMember(ctx, db.RemoveGroupMemberParams{GroupID: group, UserID: user})
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *sqlcGroupRepository) ListGroupMembers(ctx context.Context, groupID string) ([]domain.User, error) {
+	id, err := uuid.Parse(groupID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := querierFrom(ctx, r.querier).ListGroupMembers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]domain.User, len(rows))
+	for i, row := range rows {
+		user := db.User{ID: row.ID, Name: row.Name, Email: row.Email, NameEncrypted: row.NameEncrypted, EmailEncrypted: row.EmailEncrypted, Role: row.Role, CreatedAt: row.CreatedAt, Updatedat: row.Updatedat, AvatarUrl: row.AvatarUrl, AvatarThumbnailUrl: row.AvatarThumbnailUrl, Preferences: row.Preferences, Attributes: row.Attributes}
+		if err := r.pii.openUser(&user); err != nil {
+			return nil, err
+		}
+		users[i] = *toDomainUser(user)
+	}
+	r.pii.sortUsersByName(users)
+	return users, nil
+}
+
+func (r *sqlcGroupRepository) ListUserGroups(ctx context.Context, userID string) ([]domain.Group, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := querierFrom(ctx, r.querier).ListUserGroups(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainGroupSlice(rows), nil
+}
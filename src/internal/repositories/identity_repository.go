@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// IdentityRepository defines the interface for links between users and
+// external identity providers, and for the sign-ins in progress at them.
+type IdentityRepository interface {
+	GetIdentity(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) // Returns nil, nil when the account is not linked
+	CreateIdentity(ctx context.Context, identity *domain.UserIdentity) error                 // Assigns the ID
+	TouchIdentity(ctx context.Context, id string, at time.Time) error                        // Records a sign-in
+	CreateLoginState(ctx context.Context, state *domain.OIDCLoginState) error
+	// ConsumeLoginState deletes the state stored under stateHash and returns
+	// it. It returns nil, nil when there is none, including when a concurrent
+	// request consumed it first.
+	ConsumeLoginState(ctx context.Context, stateHash string) (*domain.OIDCLoginState, error)
+}
+
+// sqlcIdentityRepository implements IdentityRepository using sqlc generated code.
+type sqlcIdentityRepository struct {
+	querier db.Querier
+}
+
+// NewIdentityRepository creates a new instance of IdentityRepository.
+func NewIdentityRepository(conn *sql.DB) IdentityRepository {
+	return &sqlcIdentityRepository{querier: db.New(conn)}
+}
+
+func (r *sqlcIdentityRepository) GetIdentity(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	row, err := querierFrom(ctx, r.querier).GetUserIdentity(ctx, db.GetUserIdentityParams{Provider: provider, Subject: subject})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	identity := &domain.UserIdentity{
+		ID:        row.ID.String(),
+		UserID:    row.UserID.String(),
+		Provider:  row.Provider,
+		Subject:   row.Subject,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.LastLoginAt.Valid {
+		identity.LastLoginAt = row.LastLoginAt.Time
+	}
+	return identity, nil
+}
+
+func (r *sqlcIdentityRepository) CreateIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	userID, err := uuid.Parse(identity.UserID)
+	if err != nil {
+		return err
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	err = querierFrom(ctx, r.querier).CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+		ID:          id,
+		UserID:      userID,
+		Provider:    identity.Provider,
+		Subject:     identity.Subject,
+		LastLoginAt: sql.NullTime{Time: identity.LastLoginAt, Valid: !identity.LastLoginAt.IsZero()},
+	})
+	if err != nil {
+		return err
+	}
+	identity.ID = id.String()
+	return nil
+}
+
+func (r *sqlcIdentityRepository) TouchIdentity(ctx context.Context, id string, at time.Time) error {
+	identityID, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+	return querierFrom(ctx, r.querier).TouchUserIdentity(ctx, db.TouchUserIdentityParams{
+		LastLoginAt: sql.NullTime{Time: at, Valid: true},
+		ID:          identityID,
+	})
+}
+
+func (r *sqlcIdentityRepository) CreateLoginState(ctx context.Context, state *domain.OIDCLoginState) error {
+	return querierFrom(ctx, r.querier).CreateOIDCLoginState(ctx, db.CreateOIDCLoginStateParams{
+		StateHash:    state.StateHash,
+		Provider:     state.Provider,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+		ExpiresAt:    state.ExpiresAt,
+	})
+}
+
+func (r *sqlcIdentityRepository) ConsumeLoginState(ctx context.Context, stateHash string) (*domain.OIDCLoginState, error) {
+	q := querierFrom(ctx, r.querier)
+	row, err := q.GetOIDCLoginState(ctx, stateHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// Only the request whose delete removes the row may use it
+	res, err := q.DeleteOIDCLoginState(ctx, stateHash)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return nil, err
+	}
+	return &domain.OIDCLoginState{
+		StateHash:    row.StateHash,
+		Provider:     row.Provider,
+		Nonce:        row.Nonce,
+		CodeVerifier: row.CodeVerifier,
+		ExpiresAt:    row.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// InvitationRepository defines the interface for invitation data operations.
+// The conditional updates report false when the invitation was no longer
+// pending, so concurrent accepts, resends and revokes cannot both succeed.
+type InvitationRepository interface {
+	CreateInvitation(ctx context.Context, inv *domain.Invitation, tokenHash string) (*domain.Invitation, error)
+	GetInvitation(ctx context.Context, id string) (*domain.Invitation, error)                                 // Returns nil, nil when not found
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error)               // Returns nil, nil when not found
+	GetPendingInvitation(ctx context.Context, orgID, email string, now time.Time) (*domain.Invitation, error) // Returns nil, nil when there is none
+	AcceptInvitation(ctx context.Context, id, userID string, at time.Time) (bool, error)
+	RenewInvitation(ctx context.Context, id, tokenHash string, expiresAt, at time.Time) (bool, error) // Replaces the token and extends the expiry
+	RevokeInvitation(ctx context.Context, id string, at time.Time) (bool, error)
+}
+
+// sqlcInvitationRepository implements InvitationRepository using sqlc generated code.
+type sqlcInvitationRepository struct {
+	querier db.Querier
+}
+
+// NewInvitationRepository creates a new instance of InvitationRepository.
+func NewInvitationRepository(conn *sql.DB) InvitationRepository {
+	return &sqlcInvitationRepository{querier: db.New(conn)}
+}
+
+func toDomainInvitation(i db.Invitation) *domain.Invitation {
+	inv := &domain.Invitation{
+		ID:             i.ID.String(),
+		OrganizationID: i.OrganizationID.String(),
+		Email:          i.Email,
+		Role:           i.Role,
+		InvitedBy:      i.InvitedBy.String,
+		ExpiresAt:      i.ExpiresAt,
+		AcceptedBy:     i.AcceptedBy.String,
+		CreatedAt:      i.CreatedAt,
+		UpdatedAt:      i.UpdatedAt,
+	}
+	if i.AcceptedAt.Valid {
+		inv.AcceptedAt = i.AcceptedAt.Time
+	}
+	if i.RevokedAt.Valid {
+		inv.RevokedAt = i.RevokedAt.Time
+	}
+	return inv
+}
+
+// getInvitation wraps a single-row query, mapping sql.ErrNoRows to nil, nil.
+func getInvitation(i db.Invitation, err error) (*domain.Invitation, error) {
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainInvitation(i), nil
+}
+
+func (r *sqlcInvitationRepository) CreateInvitation(ctx context.Context, inv *domain.Invitation, tokenHash string) (*domain.Invitation, error) {
+	orgID, err := uuid.Parse(inv.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	_, err = querierFrom(ctx, r.querier).CreateInvitation(ctx, db.CreateInvitationParams{
+		ID:             id,
+		OrganizationID: orgID,
+		Email:          inv.Email,
+		Role:           inv.Role,
+		TokenHash:      tokenHash,
+		InvitedBy:      sql.NullString{String: inv.InvitedBy, Valid: inv.InvitedBy != ""},
+		ExpiresAt:      inv.ExpiresAt,
+		CreatedAt:      inv.CreatedAt,
+		UpdatedAt:      inv.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetInvitation(ctx, id.String())
+}
+
+func (r *sqlcInvitationRepository) GetInvitation(ctx context.Context, id string) (*domain.Invitation, error) {
+	invID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return getInvitation(querierFrom(ctx, r.querier).GetInvitation(ctx, invID))
+}
+
+func (r *sqlcInvitationRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	return getInvitation(querierFrom(ctx, r.querier).GetInvitationByTokenHash(ctx, tokenHash))
+}
+
+func (r *sqlcInvitationRepository) GetPendingInvitation(ctx context.Context, orgID, email string, now time.Time) (*domain.Invitation, error) {
+	org, err := uuid.Parse(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return getInvitation(querierFrom(ctx, r.querier).GetPendingInvitation(ctx, db.GetPendingInvitationParams{OrganizationID: org, Email: email, ExpiresAt: now}))
+}
+
+func (r *sqlcInvitationRepository) AcceptInvitation(ctx context.Context, id, userID string, at time.Time) (bool, error) {
+	invID, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+	res, err := querierFrom(ctx, r.querier).AcceptInvitation(ctx, db.AcceptInvitationParams{
+		AcceptedAt: sql.NullTime{Time: at, Valid: true},
+		AcceptedBy: sql.NullString{String: userID, Valid: true},
+		UpdatedAt:  at,
+		ID:         invID,
+	})
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (r *sqlcInvitationRepository) RenewInvitation(ctx context.Context, id, tokenHash string, expiresAt, at time.Time) (bool, error) {
+	invID, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+	res, err := querierFrom(ctx, r.querier).RenewInvitation(ctx, db.RenewInvitationParams{TokenHash: tokenHash, ExpiresAt: expiresAt, UpdatedAt: at, ID: invID})
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (r *sqlcInvitationRepository) RevokeInvitation(ctx context.Context, id string, at time.Time) (bool, error) {
+	invID, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+	res, err := querierFrom(ctx, r.querier).RevokeInvitation(ctx, db.RevokeInvitationParams{RevokedAt: sql.NullTime{Time: at, Valid: true}, UpdatedAt: at, ID: invID})
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// JobRunRepository defines the interface for the history of scheduled jobs.
+type JobRunRepository interface {
+	// StartJobRun stores run and assigns its ID. It returns false, storing
+	// nothing, when the job already has a run for run.ScheduledAt.
+	StartJobRun(ctx context.Context, run *domain.JobRun) (bool, error)
+	FinishJobRun(ctx context.Context, run *domain.JobRun) error
+	ListLatestJobRuns(ctx context.Context) ([]domain.JobRun, error) // The most recent run of each job
+}
+
+// sqlcJobRunRepository implements JobRunRepository using sqlc generated code.
+type sqlcJobRunRepository struct {
+	querier db.Querier
+}
+
+// NewJobRunRepository creates a new instance of JobRunRepository.
+func NewJobRunRepository(conn *sql.DB) JobRunRepository {
+	return &sqlcJobRunRepository{querier: db.New(conn)}
+}
+
+func (r *sqlcJobRunRepository) StartJobRun(ctx context.Context, run *domain.JobRun) (bool, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return false, err
+	}
+	res, err := querierFrom(ctx, r.querier).CreateJobRun(ctx, db.CreateJobRunParams{
+		ID:          id,
+		JobName:     run.JobName,
+		ScheduledAt: run.ScheduledAt,
+		Instance:    run.Instance,
+		Status:      run.Status,
+		StartedAt:   run.StartedAt,
+	})
+	if err != nil {
+		return false, err
+	}
+	// INSERT IGNORE skips the row when the unique key on the job and
+	// scheduled time is taken
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	run.ID = id.String()
+	return true, nil
+}
+
+func (r *sqlcJobRunRepository) FinishJobRun(ctx context.Context, run *domain.JobRun) error {
+	id, err := uuid.Parse(run.ID)
+	if err != nil {
+		return err
+	}
+	result, err := marshalOptional(run.Result, len(run.Result) == 0)
+	if err != nil {
+		return err
+	}
+	return querierFrom(ctx, r.querier).FinishJobRun(ctx, db.FinishJobRunParams{
+		Status:     run.Status,
+		Result:     result,
+		Error:      nullString(truncateError(run.Error)),
+		FinishedAt: sql.NullTime{Time: run.FinishedAt, Valid: !run.FinishedAt.IsZero()},
+		ID:         id,
+	})
+}
+
+func (r *sqlcJobRunRepository) ListLatestJobRuns(ctx context.Context) ([]domain.JobRun, error) {
+	rows, err := querierFrom(ctx, r.querier).ListLatestJobRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]domain.JobRun, len(rows))
+	for i, row := range rows {
+		runs[i] = domain.JobRun{
+			ID:          row.ID.String(),
+			JobName:     row.JobName,
+			ScheduledAt: row.ScheduledAt,
+			Instance:    row.Instance,
+			Status:      row.Status,
+			Error:       row.Error.String,
+			StartedAt:   row.StartedAt,
+			FinishedAt:  row.FinishedAt.Time,
+		}
+		if len(row.Result) > 0 {
+			if err := json.Unmarshal(row.Result, &runs[i].Result); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return runs, nil
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// MFARepository defines the interface for two-factor authentication data operations.
+type MFARepository interface {
+	GetMFASetting(ctx context.Context, userID string) (*domain.MFASetting, error)    // Returns nil, nil when the user never enrolled
+	SaveMFASecret(ctx context.Context, userID string, secretCiphertext []byte) error // Starts (or restarts) an unconfirmed enrollment
+	EnableMFA(ctx context.Context, userID string, step int64, confirmedAt time.Time, recoveryCodeHashes []string) error
+	AdvanceStep(ctx context.Context, userID string, step int64) (bool, error) // False when step is not newer than the last accepted one
+	UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) (bool, error)
+}
+
+// sqlcMFARepository implements MFARepository using sqlc generated code.
+type sqlcMFARepository struct {
+	querier db.Querier
+	dbConn  *sql.DB // Needed to run EnableMFA in a transaction
+}
+
+// NewMFARepository creates a new instance of MFARepository.
+func NewMFARepository(conn *sql.DB) MFARepository {
+	return &sqlcMFARepository{
+		querier: db.New(conn),
+		dbConn:  conn,
+	}
+}
+
+func (r *sqlcMFARepository) GetMFASetting(ctx context.Context, userID string) (*domain.MFASetting, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := r.querier.GetUserMFASetting(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	setting := &domain.MFASetting{
+		UserID:           s.UserID.String(),
+		SecretCiphertext: s.SecretCiphertext,
+		Enabled:          s.Enabled,
+		LastUsedStep:     s.LastUsedStep,
+	}
+	if s.ConfirmedAt.Valid {
+		setting.ConfirmedAt = s.ConfirmedAt.Time
+	}
+	return setting, nil
+}
+
+func (r *sqlcMFARepository) SaveMFASecret(ctx context.Context, userID string, secretCiphertext []byte) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+	_, err = r.querier.UpsertUserMFASecret(ctx, db.UpsertUserMFASecretParams{UserID: id, SecretCiphertext: secretCiphertext})
+	return err
+}
+
+func (r *sqlcMFARepository) EnableMFA(ctx context.Context, userID string, step int64, confirmedAt time.Time, recoveryCodeHashes []string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // No-op after a successful commit
+	q := db.New(r.dbConn).WithTx(tx)
+
+	if _, err := q.EnableUserMFA(ctx, db.EnableUserMFAParams{
+		ConfirmedAt:  sql.NullTime{Time: confirmedAt, Valid: true},
+		LastUsedStep: step,
+		UserID:       id,
+	}); err != nil {
+		return err
+	}
+	// Replace any codes left over from a previous enrollment
+	if _, err := q.DeleteUserRecoveryCodes(ctx, id); err != nil {
+		return err
+	}
+	for _, h := range recoveryCodeHashes {
+		if _, err := q.CreateUserRecoveryCode(ctx, db.CreateUserRecoveryCodeParams{UserID: id, CodeHash: h}); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *sqlcMFARepository) AdvanceStep(ctx context.Context, userID string, step int64) (bool, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return false, err
+	}
+	res, err := r.querier.AdvanceUserMFAStep(ctx, db.AdvanceUserMFAStepParams{LastUsedStep: step, UserID: id, LastUsedStep_2: step})
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (r *sqlcMFARepository) UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) (bool, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return false, err
+	}
+	res, err := r.querier.UseUserRecoveryCode(ctx, db.UseUserRecoveryCodeParams{
+		UsedAt:   sql.NullTime{Time: at, Valid: true},
+		UserID:   id,
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
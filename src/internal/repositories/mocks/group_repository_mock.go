@@ -0,0 +1,83 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGroupRepository struct {
+	mock.Mock
+}
+
+func (m *MockGroupRepository) CreateGroup(ctx context.Context, group *domain.Group) (*domain.Group, error) {
+	args := m.Called(ctx, group)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) GetGroup(ctx context.Context, id string) (*domain.Group, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) GetGroupByName(ctx context.Context, name string) (*domain.Group, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) ListGroups(ctx context.Context) ([]domain.Group, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) UpdateGroup(ctx context.Context, group *domain.Group) (*domain.Group, error) {
+	args := m.Called(ctx, group)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) DeleteGroup(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) AddGroupMember(ctx context.Context, groupID, userID string) (bool, error) {
+	args := m.Called(ctx, groupID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGroupRepository) RemoveGroupMember(ctx context.Context, groupID, userID string) (bool, error) {
+	args := m.Called(ctx, groupID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGroupRepository) ListGroupMembers(ctx context.Context, groupID string) ([]domain.User, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockGroupRepository) ListUserGroups(ctx context.Context, userID string) ([]domain.Group, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Group), args.Error(1)
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdentityRepository) GetIdentity(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	args := m.Called(ctx, provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserIdentity), args.Error(1)
+}
+
+func (m *MockIdentityRepository) CreateIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	args := m.Called(ctx, identity)
+	return args.Error(0)
+}
+
+func (m *MockIdentityRepository) TouchIdentity(ctx context.Context, id string, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockIdentityRepository) CreateLoginState(ctx context.Context, state *domain.OIDCLoginState) error {
+	args := m.Called(ctx, state)
+	return args.Error(0)
+}
+
+func (m *MockIdentityRepository) ConsumeLoginState(ctx context.Context, stateHash string) (*domain.OIDCLoginState, error) {
+	args := m.Called(ctx, stateHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OIDCLoginState), args.Error(1)
+}
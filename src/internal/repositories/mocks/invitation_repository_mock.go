@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInvitationRepository struct {
+	mock.Mock
+}
+
+func (m *MockInvitationRepository) CreateInvitation(ctx context.Context, inv *domain.Invitation, tokenHash string) (*domain.Invitation, error) {
+	args := m.Called(ctx, inv, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) GetInvitation(ctx context.Context, id string) (*domain.Invitation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) GetPendingInvitation(ctx context.Context, orgID, email string, now time.Time) (*domain.Invitation, error) {
+	args := m.Called(ctx, orgID, email, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) AcceptInvitation(ctx context.Context, id, userID string, at time.Time) (bool, error) {
+	args := m.Called(ctx, id, userID, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockInvitationRepository) RenewInvitation(ctx context.Context, id, tokenHash string, expiresAt, at time.Time) (bool, error) {
+	args := m.Called(ctx, id, tokenHash, expiresAt, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockInvitationRepository) RevokeInvitation(ctx context.Context, id string, at time.Time) (bool, error) {
+	args := m.Called(ctx, id, at)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobRunRepository struct {
+	mock.Mock
+}
+
+func (m *MockJobRunRepository) StartJobRun(ctx context.Context, run *domain.JobRun) (bool, error) {
+	args := m.Called(ctx, run)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockJobRunRepository) FinishJobRun(ctx context.Context, run *domain.JobRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *MockJobRunRepository) ListLatestJobRuns(ctx context.Context) ([]domain.JobRun, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.JobRun), args.Error(1)
+}
@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLoginThrottleRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginThrottleRepository) GetThrottle(ctx context.Context, scope domain.ThrottleScope, subject string) (*domain.LoginThrottle, error) {
+	args := m.Called(ctx, scope, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LoginThrottle), args.Error(1)
+}
+
+func (m *MockLoginThrottleRepository) RecordFailure(ctx context.Context, scope domain.ThrottleScope, subject string, at time.Time) (*domain.LoginThrottle, error) {
+	args := m.Called(ctx, scope, subject, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LoginThrottle), args.Error(1)
+}
+
+func (m *MockLoginThrottleRepository) Lock(ctx context.Context, scope domain.ThrottleScope, subject string, until time.Time) error {
+	args := m.Called(ctx, scope, subject, until)
+	return args.Error(0)
+}
+
+func (m *MockLoginThrottleRepository) Reset(ctx context.Context, scope domain.ThrottleScope, subject string) error {
+	args := m.Called(ctx, scope, subject)
+	return args.Error(0)
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMFARepository struct {
+	mock.Mock
+}
+
+func (m *MockMFARepository) GetMFASetting(ctx context.Context, userID string) (*domain.MFASetting, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MFASetting), args.Error(1)
+}
+
+func (m *MockMFARepository) SaveMFASecret(ctx context.Context, userID string, secretCiphertext []byte) error {
+	args := m.Called(ctx, userID, secretCiphertext)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) EnableMFA(ctx context.Context, userID string, step int64, confirmedAt time.Time, recoveryCodeHashes []string) error {
+	args := m.Called(ctx, userID, step, confirmedAt, recoveryCodeHashes)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) AdvanceStep(ctx context.Context, userID string, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARepository) UseRecoveryCode(ctx context.Context, userID, codeHash string, at time.Time) (bool, error) {
+	args := m.Called(ctx, userID, codeHash, at)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOAuthClientRepository struct {
+	mock.Mock
+}
+
+func (m *MockOAuthClientRepository) CreateClient(ctx context.Context, client *domain.OAuthClient) error {
+	args := m.Called(ctx, client)
+	return args.Error(0)
+}
+
+func (m *MockOAuthClientRepository) GetClient(ctx context.Context, id string) (*domain.OAuthClient, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OAuthClient), args.Error(1)
+}
+
+func (m *MockOAuthClientRepository) ListClients(ctx context.Context) ([]domain.OAuthClient, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OAuthClient), args.Error(1)
+}
+
+func (m *MockOAuthClientRepository) DeleteClient(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,70 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOAuthGrantRepository struct {
+	mock.Mock
+}
+
+func (m *MockOAuthGrantRepository) GetConsent(ctx context.Context, userID, clientID string) (*domain.OAuthConsent, error) {
+	args := m.Called(ctx, userID, clientID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OAuthConsent), args.Error(1)
+}
+
+func (m *MockOAuthGrantRepository) SaveConsent(ctx context.Context, consent *domain.OAuthConsent) error {
+	args := m.Called(ctx, consent)
+	return args.Error(0)
+}
+
+func (m *MockOAuthGrantRepository) ListConsents(ctx context.Context, userID string) ([]domain.OAuthConsent, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OAuthConsent), args.Error(1)
+}
+
+func (m *MockOAuthGrantRepository) DeleteConsent(ctx context.Context, userID, clientID string) (bool, error) {
+	args := m.Called(ctx, userID, clientID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOAuthGrantRepository) CreateAuthorizationCode(ctx context.Context, code *domain.OAuthAuthorizationCode) error {
+	args := m.Called(ctx, code)
+	return args.Error(0)
+}
+
+func (m *MockOAuthGrantRepository) ConsumeAuthorizationCode(ctx context.Context, codeHash string, usedAt time.Time) (*domain.OAuthAuthorizationCode, bool, error) {
+	args := m.Called(ctx, codeHash, usedAt)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(*domain.OAuthAuthorizationCode), args.Bool(1), args.Error(2)
+}
+
+func (m *MockOAuthGrantRepository) CreateAccessToken(ctx context.Context, token *domain.OAuthAccessToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockOAuthGrantRepository) GetAccessToken(ctx context.Context, tokenHash string) (*domain.OAuthAccessToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OAuthAccessToken), args.Error(1)
+}
+
+func (m *MockOAuthGrantRepository) DeleteAccessTokens(ctx context.Context, userID, clientID string) error {
+	args := m.Called(ctx, userID, clientID)
+	return args.Error(0)
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrgUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrgUserRepository) ListOrgUsers(ctx context.Context) ([]domain.OrganizationMember, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrgUserRepository) GetOrgUser(ctx context.Context, userID string) (*domain.OrganizationMember, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrgUserRepository) AddOrgUser(ctx context.Context, userID, role string) error {
+	args := m.Called(ctx, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockOrgUserRepository) UpdateOrgUserRole(ctx context.Context, userID, role string) error {
+	args := m.Called(ctx, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockOrgUserRepository) RemoveOrgUser(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockOrgUserRepository) CountOrgOwners(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOrgUserRepository) CountUserOrgs(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
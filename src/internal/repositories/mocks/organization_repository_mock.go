@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationRepository) CreateOrganization(ctx context.Context, org *domain.Organization, ownerID string) (*domain.Organization, error) {
+	args := m.Called(ctx, org, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetOrganization(ctx context.Context, id string) (*domain.Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetOrganizationBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetMemberRole(ctx context.Context, orgID, userID string) (string, error) {
+	args := m.Called(ctx, orgID, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) ListUserOrganizations(ctx context.Context, userID string) ([]domain.OrganizationMembership, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrganizationMembership), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) AddMember(ctx context.Context, orgID, userID, role string) error {
+	args := m.Called(ctx, orgID, userID, role)
+	return args.Error(0)
+}
@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) AppendOutboxEvent(ctx context.Context, event domain.DomainEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) ClaimDueOutboxEvents(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.OutboxMessage, error) {
+	args := m.Called(ctx, now, leaseUntil, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OutboxMessage), args.Error(1)
+}
+
+func (m *MockOutboxRepository) MarkOutboxEventPublished(ctx context.Context, seq int64, at time.Time) error {
+	args := m.Called(ctx, seq, at)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) MarkOutboxEventFailed(ctx context.Context, seq int64, nextAttemptAt time.Time, reason string) error {
+	args := m.Called(ctx, seq, nextAttemptAt, reason)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) ListOutboxEventsAfter(ctx context.Context, afterSeq int64, limit int) ([]domain.OutboxMessage, error) {
+	args := m.Called(ctx, afterSeq, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OutboxMessage), args.Error(1)
+}
+
+func (m *MockOutboxRepository) ListLatestOutboxEvents(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OutboxMessage), args.Error(1)
+}
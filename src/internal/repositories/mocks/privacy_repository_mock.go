@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPrivacyRepository struct {
+	mock.Mock
+}
+
+func (m *MockPrivacyRepository) ListUserAuditEvents(ctx context.Context, userID string) ([]domain.AuditEvent, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AuditEvent), args.Error(1)
+}
+
+func (m *MockPrivacyRepository) EraseUser(ctx context.Context, userID string, at time.Time) (bool, error) {
+	args := m.Called(ctx, userID, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPrivacyRepository) ScrubAuditEvents(ctx context.Context, userID string, at time.Time) (int, error) {
+	args := m.Called(ctx, userID, at)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPrivacyRepository) ScrubEvents(ctx context.Context, userID string) (int, int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockPrivacyRepository) ScrubImportReports(ctx context.Context, userID, email string) (int, error) {
+	args := m.Called(ctx, userID, email)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPrivacyRepository) DeleteUnacceptedInvitations(ctx context.Context, email string) (int, error) {
+	args := m.Called(ctx, email)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPrivacyRepository) CreateErasureReceipt(ctx context.Context, receipt *domain.ErasureReceipt) (*domain.ErasureReceipt, error) {
+	args := m.Called(ctx, receipt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ErasureReceipt), args.Error(1)
+}
+
+func (m *MockPrivacyRepository) GetErasureReceipt(ctx context.Context, userID string) (*domain.ErasureReceipt, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ErasureReceipt), args.Error(1)
+}
@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRetentionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRetentionRepository) PurgeAuditEvents(ctx context.Context, before time.Time, limit int) (int, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRetentionRepository) PurgeErasedUsers(ctx context.Context, before time.Time, limit int) (int, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRetentionRepository) PurgeInvitations(ctx context.Context, before time.Time, limit int) (int, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRetentionRepository) PurgeAPIKeys(ctx context.Context, before time.Time, limit int) (int, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRetentionRepository) PurgeOutboxEvents(ctx context.Context, before time.Time, limit int) (int, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRetentionRepository) PurgeLoginThrottles(ctx context.Context, before time.Time, limit int) (int, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRetentionRepository) PurgeOIDCLoginStates(ctx context.Context, before time.Time, limit int) (int, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRetentionRepository) PurgeOAuthCodes(ctx context.Context, before time.Time, limit int) (int, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRetentionRepository) PurgeOAuthTokens(ctx context.Context, before time.Time, limit int) (int, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Int(0), args.Error(1)
+}
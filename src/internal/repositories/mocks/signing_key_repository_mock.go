@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSigningKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockSigningKeyRepository) ListSigningKeys(ctx context.Context, retiredAfter time.Time) ([]domain.SigningKey, error) {
+	args := m.Called(ctx, retiredAfter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SigningKey), args.Error(1)
+}
+
+func (m *MockSigningKeyRepository) CreateSigningKey(ctx context.Context, key *domain.SigningKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockSigningKeyRepository) RetireSigningKeys(ctx context.Context, exceptID string, at time.Time) error {
+	args := m.Called(ctx, exceptID, at)
+	return args.Error(0)
+}
+
+func (m *MockSigningKeyRepository) DeleteSigningKeys(ctx context.Context, retiredBefore time.Time) (int, error) {
+	args := m.Called(ctx, retiredBefore)
+	return args.Int(0), args.Error(1)
+}
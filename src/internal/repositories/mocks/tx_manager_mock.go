@@ -0,0 +1,13 @@
+package mocks
+
+import "context"
+
+// InlineTxManager runs the unit of work directly, without a transaction.
+type InlineTxManager struct {
+	Calls int
+}
+
+func (m *InlineTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Calls++
+	return fn(ctx)
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserAttributeRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserAttributeRepository) ListAttributeDefinitions(ctx context.Context) ([]domain.AttributeDefinition, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockUserAttributeRepository) GetAttributeDefinition(ctx context.Context, key string) (*domain.AttributeDefinition, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockUserAttributeRepository) CreateAttributeDefinition(ctx context.Context, def *domain.AttributeDefinition) (*domain.AttributeDefinition, error) {
+	args := m.Called(ctx, def)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockUserAttributeRepository) UpdateAttributeDefinition(ctx context.Context, def *domain.AttributeDefinition) (*domain.AttributeDefinition, error) {
+	args := m.Called(ctx, def)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockUserAttributeRepository) DeleteAttributeDefinition(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserImportRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserImportRepository) CreateUserImportJob(ctx context.Context, job *domain.UserImportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockUserImportRepository) GetUserImportJob(ctx context.Context, id string) (*domain.UserImportJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserImportJob), args.Error(1)
+}
+
+func (m *MockUserImportRepository) UpdateUserImportJob(ctx context.Context, job *domain.UserImportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
@@ -18,6 +18,14 @@ func (m *MockUserRepository) CreateUser(ctx context.Context, user *domain.User,
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) CreateUsers(ctx context.Context, users []domain.User) ([]string, error) {
+	args := m.Called(ctx, users)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *MockUserRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -26,14 +34,52 @@ func (m *MockUserRepository) GetUserByID(ctx context.Context, id string) (*domai
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
-func (m *MockUserRepository) ListUsers(ctx context.Context) ([]domain.User, error) {
-	args := m.Called(ctx)
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ListExistingUserEmails(ctx context.Context, emails []string) ([]string, error) {
+	args := m.Called(ctx, emails)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserRepository) ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ListUsersAfter(ctx context.Context, afterID string, limit int) ([]domain.User, error) {
+	args := m.Called(ctx, afterID, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.User), args.Error(1)
 }
 
+// StreamUsers passes each user of the configured slice to fn, then returns the
+// configured error.
+func (m *MockUserRepository) StreamUsers(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error {
+	args := m.Called(ctx, filter)
+	if users, ok := args.Get(0).([]domain.User); ok {
+		for i := range users {
+			if err := fn(&users[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 func (m *MockUserRepository) UpdateUser(ctx context.Context, id string, user *domain.User, hashedPassword *string) (*domain.User, error) {
 	args := m.Called(ctx, id, user, hashedPassword)
 	if args.Get(0) == nil {
@@ -42,7 +88,36 @@ func (m *MockUserRepository) UpdateUser(ctx context.Context, id string, user *do
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) UpdateUserAvatar(ctx context.Context, id, avatarURL, thumbnailURL string) (*domain.User, error) {
+	args := m.Called(ctx, id, avatarURL, thumbnailURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserPasswordHash(ctx context.Context, id, oldHash, newHash string) (bool, error) {
+	args := m.Called(ctx, id, oldHash, newHash)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockUserRepository) DeleteUser(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockUserRepository) SearchUsers(ctx context.Context, query string, limit, offset int) ([]domain.UserSearchHit, error) {
+	args := m.Called(ctx, query, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.UserSearchHit), args.Error(1)
+}
+
+func (m *MockUserRepository) SearchUsersByEmailPrefix(ctx context.Context, prefix string, limit, offset int) ([]domain.User, error) {
+	args := m.Called(ctx, prefix, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
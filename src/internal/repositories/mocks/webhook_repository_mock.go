@@ -0,0 +1,97 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) CreateWebhookSubscription(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, sub)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetWebhookSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListActiveWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateWebhookSubscription(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, sub)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) EnqueueWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetWebhookDelivery(ctx context.Context, id int64) (*domain.WebhookDelivery, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListWebhookDeliveries(ctx context.Context, subscriptionID string, beforeID int64, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, subscriptionID, beforeID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ClaimDueWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, now, leaseUntil, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) RecordWebhookDeliveryAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) ResetWebhookDelivery(ctx context.Context, id int64, nextAttemptAt time.Time) error {
+	args := m.Called(ctx, id, nextAttemptAt)
+	return args.Error(0)
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// OAuthClientRepository defines the interface for the applications that
+// use this service as their OpenID provider.
+type OAuthClientRepository interface {
+	CreateClient(ctx context.Context, client *domain.OAuthClient) error    // Assigns the ID
+	GetClient(ctx context.Context, id string) (*domain.OAuthClient, error) // Returns nil, nil when not found
+	ListClients(ctx context.Context) ([]domain.OAuthClient, error)         // Newest first
+	// DeleteClient deletes the client along with its consents, codes and
+	// tokens. It returns false when the client does not exist.
+	DeleteClient(ctx context.Context, id string) (bool, error)
+}
+
+// sqlcOAuthClientRepository implements OAuthClientRepository using sqlc generated code.
+type sqlcOAuthClientRepository struct {
+	querier db.Querier
+}
+
+// NewOAuthClientRepository creates a new instance of OAuthClientRepository.
+func NewOAuthClientRepository(conn *sql.DB) OAuthClientRepository {
+	return &sqlcOAuthClientRepository{querier: db.New(conn)}
+}
+
+func toDomainOAuthClient(c db.OauthClient) domain.OAuthClient {
+	return domain.OAuthClient{
+		ID:           c.ID.String(),
+		Name:         c.Name,
+		RedirectURIs: strings.Fields(c.RedirectUris),
+		SecretHash:   c.SecretHash.String,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+func (r *sqlcOAuthClientRepository) CreateClient(ctx context.Context, client *domain.OAuthClient) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	err = querierFrom(ctx, r.querier).CreateOAuthClient(ctx, db.CreateOAuthClientParams{
+		ID:           id,
+		Name:         client.Name,
+		RedirectUris: strings.Join(client.RedirectURIs, " "),
+		SecretHash:   nullString(client.SecretHash),
+	})
+	if err != nil {
+		return err
+	}
+	client.ID = id.String()
+	return nil
+}
+
+func (r *sqlcOAuthClientRepository) GetClient(ctx context.Context, id string) (*domain.OAuthClient, error) {
+	clientID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	row, err := querierFrom(ctx, r.querier).GetOAuthClient(ctx, clientID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	client := toDomainOAuthClient(row)
+	return &client, nil
+}
+
+func (r *sqlcOAuthClientRepository) ListClients(ctx context.Context) ([]domain.OAuthClient, error) {
+	rows, err := querierFrom(ctx, r.querier).ListOAuthClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clients := make([]domain.OAuthClient, len(rows))
+	for i, row := range rows {
+		clients[i] = toDomainOAuthClient(row)
+	}
+	return clients, nil
+}
+
+func (r *sqlcOAuthClientRepository) DeleteClient(ctx context.Context, id string) (bool, error) {
+	clientID, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+	res, err := querierFrom(ctx, r.querier).DeleteOAuthClient(ctx, clientID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
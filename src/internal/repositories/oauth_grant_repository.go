@@ -0,0 +1,219 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// OAuthGrantRepository defines the interface for what users grant OAuth
+// clients: their consents, and the authorization codes and access tokens
+// issued under them.
+type OAuthGrantRepository interface {
+	GetConsent(ctx context.Context, userID, clientID string) (*domain.OAuthConsent, error) // Returns nil, nil when the user has not consented
+	SaveConsent(ctx context.Context, consent *domain.OAuthConsent) error                   // Replaces the scopes of an existing consent
+	ListConsents(ctx context.Context, userID string) ([]domain.OAuthConsent, error)        // Sets the client names
+	DeleteConsent(ctx context.Context, userID, clientID string) (bool, error)              // Returns false when there was no consent
+	CreateAuthorizationCode(ctx context.Context, code *domain.OAuthAuthorizationCode) error
+	// ConsumeAuthorizationCode marks the code stored under codeHash used at
+	// usedAt and returns it. redeemed is false when the code was used before,
+	// including by a concurrent request. It returns nil, false, nil when
+	// there is no code.
+	ConsumeAuthorizationCode(ctx context.Context, codeHash string, usedAt time.Time) (code *domain.OAuthAuthorizationCode, redeemed bool, err error)
+	CreateAccessToken(ctx context.Context, token *domain.OAuthAccessToken) error
+	GetAccessToken(ctx context.Context, tokenHash string) (*domain.OAuthAccessToken, error) // Returns nil, nil when not found
+	DeleteAccessTokens(ctx context.Context, userID, clientID string) error                  // Revokes the tokens a client holds for a user
+}
+
+// sqlcOAuthGrantRepository implements OAuthGrantRepository using sqlc generated code.
+type sqlcOAuthGrantRepository struct {
+	querier db.Querier
+}
+
+// NewOAuthGrantRepository creates a new instance of OAuthGrantRepository.
+func NewOAuthGrantRepository(conn *sql.DB) OAuthGrantRepository {
+	return &sqlcOAuthGrantRepository{querier: db.New(conn)}
+}
+
+// parseUserClient parses the IDs of a user and a client.
+func parseUserClient(userID, clientID string) (uuid.UUID, uuid.UUID, error) {
+	u, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	c, err := uuid.Parse(clientID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return u, c, nil
+}
+
+func (r *sqlcOAuthGrantRepository) GetConsent(ctx context.Context, userID, clientID string) (*domain.OAuthConsent, error) {
+	u, c, err := parseUserClient(userID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := querierFrom(ctx, r.querier).GetOAuthConsent(ctx, db.GetOAuthConsentParams{UserID: u, ClientID: c})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &domain.OAuthConsent{
+		UserID:    row.UserID.String(),
+		ClientID:  row.ClientID.String(),
+		Scopes:    strings.Fields(row.Scopes),
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}, nil
+}
+
+func (r *sqlcOAuthGrantRepository) SaveConsent(ctx context.Context, consent *domain.OAuthConsent) error {
+	u, c, err := parseUserClient(consent.UserID, consent.ClientID)
+	if err != nil {
+		return err
+	}
+	return querierFrom(ctx, r.querier).UpsertOAuthConsent(ctx, db.UpsertOAuthConsentParams{
+		UserID:   u,
+		ClientID: c,
+		Scopes:   strings.Join(consent.Scopes, " "),
+	})
+}
+
+func (r *sqlcOAuthGrantRepository) ListConsents(ctx context.Context, userID string) ([]domain.OAuthConsent, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := querierFrom(ctx, r.querier).ListUserOAuthConsents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	consents := make([]domain.OAuthConsent, len(rows))
+	for i, row := range rows {
+		consents[i] = domain.OAuthConsent{
+			UserID:     row.UserID.String(),
+			ClientID:   row.ClientID.String(),
+			ClientName: row.ClientName,
+			Scopes:     strings.Fields(row.Scopes),
+			CreatedAt:  row.CreatedAt,
+			UpdatedAt:  row.UpdatedAt,
+		}
+	}
+	return consents, nil
+}
+
+func (r *sqlcOAuthGrantRepository) DeleteConsent(ctx context.Context, userID, clientID string) (bool, error) {
+	u, c, err := parseUserClient(userID, clientID)
+	if err != nil {
+		return false, err
+	}
+	res, err := querierFrom(ctx, r.querier).DeleteOAuthConsent(ctx, db.DeleteOAuthConsentParams{UserID: u, ClientID: c})
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r *sqlcOAuthGrantRepository) CreateAuthorizationCode(ctx context.Context, code *domain.OAuthAuthorizationCode) error {
+	u, c, err := parseUserClient(code.UserID, code.ClientID)
+	if err != nil {
+		return err
+	}
+	return querierFrom(ctx, r.querier).CreateOAuthAuthorizationCode(ctx, db.CreateOAuthAuthorizationCodeParams{
+		CodeHash:      code.CodeHash,
+		ClientID:      c,
+		UserID:        u,
+		RedirectUri:   code.RedirectURI,
+		Scopes:        strings.Join(code.Scopes, " "),
+		Nonce:         code.Nonce,
+		CodeChallenge: code.CodeChallenge,
+		ExpiresAt:     code.ExpiresAt,
+	})
+}
+
+func (r *sqlcOAuthGrantRepository) ConsumeAuthorizationCode(ctx context.Context, codeHash string, usedAt time.Time) (*domain.OAuthAuthorizationCode, bool, error) {
+	q := querierFrom(ctx, r.querier)
+	row, err := q.GetOAuthAuthorizationCode(ctx, codeHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	code := &domain.OAuthAuthorizationCode{
+		CodeHash:      row.CodeHash,
+		ClientID:      row.ClientID.String(),
+		UserID:        row.UserID.String(),
+		RedirectURI:   row.RedirectUri,
+		Scopes:        strings.Fields(row.Scopes),
+		Nonce:         row.Nonce,
+		CodeChallenge: row.CodeChallenge,
+		UsedAt:        row.UsedAt.Time,
+		ExpiresAt:     row.ExpiresAt,
+	}
+	if row.UsedAt.Valid {
+		return code, false, nil
+	}
+	// Only the request whose update marks the row may redeem the code
+	res, err := q.UseOAuthAuthorizationCode(ctx, db.UseOAuthAuthorizationCodeParams{
+		UsedAt:   sql.NullTime{Time: usedAt, Valid: true},
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+	// When no row changed, a concurrent request redeemed the code first
+	code.UsedAt = usedAt
+	return code, n > 0, nil
+}
+
+func (r *sqlcOAuthGrantRepository) CreateAccessToken(ctx context.Context, token *domain.OAuthAccessToken) error {
+	u, c, err := parseUserClient(token.UserID, token.ClientID)
+	if err != nil {
+		return err
+	}
+	return querierFrom(ctx, r.querier).CreateOAuthAccessToken(ctx, db.CreateOAuthAccessTokenParams{
+		TokenHash: token.TokenHash,
+		ClientID:  c,
+		UserID:    u,
+		Scopes:    strings.Join(token.Scopes, " "),
+		ExpiresAt: token.ExpiresAt,
+	})
+}
+
+func (r *sqlcOAuthGrantRepository) GetAccessToken(ctx context.Context, tokenHash string) (*domain.OAuthAccessToken, error) {
+	row, err := querierFrom(ctx, r.querier).GetOAuthAccessToken(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &domain.OAuthAccessToken{
+		TokenHash: row.TokenHash,
+		ClientID:  row.ClientID.String(),
+		UserID:    row.UserID.String(),
+		Scopes:    strings.Fields(row.Scopes),
+		ExpiresAt: row.ExpiresAt,
+	}, nil
+}
+
+func (r *sqlcOAuthGrantRepository) DeleteAccessTokens(ctx context.Context, userID, clientID string) error {
+	u, c, err := parseUserClient(userID, clientID)
+	if err != nil {
+		return err
+	}
+	return querierFrom(ctx, r.querier).DeleteOAuthAccessTokens(ctx, db.DeleteOAuthAccessTokensParams{UserID: u, ClientID: c})
+}
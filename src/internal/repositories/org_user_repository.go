@@ -0,0 +1,167 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"apiserver/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// OrgUserRepository gives access to the users of the tenant selected in ctx.
+// Every method takes the organization from tenant.From(ctx) and fails with
+// domain.ErrNoTenant when there is none, so callers cannot reach the users of
+// another organization by passing the wrong ID.
+type OrgUserRepository interface {
+	ListOrgUsers(ctx context.Context) ([]domain.OrganizationMember, error)             // In name order
+	GetOrgUser(ctx context.Context, userID string) (*domain.OrganizationMember, error) // Returns nil, nil when the user is not a member
+	AddOrgUser(ctx context.Context, userID, role string) error
+	UpdateOrgUserRole(ctx context.Context, userID, role string) error
+	RemoveOrgUser(ctx context.Context, userID string) error // Removes the membership; the user remains
+	CountOrgOwners(ctx context.Context) (int, error)
+	// CountUserOrgs returns how many organizations a member of the tenant's
+	// organization belongs to, this one included.
+	CountUserOrgs(ctx context.Context, userID string) (int, error)
+}
+
+// sqlcOrgUserRepository implements OrgUserRepository using sqlc generated code.
+type sqlcOrgUserRepository struct {
+	querier db.Querier
+	pii     PIIConfig // Decrypts member names and emails
+}
+
+// NewOrgUserRepository creates a new instance of OrgUserRepository.
+func NewOrgUserRepository(conn *sql.DB, pii PIIConfig) OrgUserRepository {
+	return &sqlcOrgUserRepository{querier: db.New(conn), pii: pii}
+}
+
+// tenantID returns the organization selected in ctx.
+func tenantID(ctx context.Context) (uuid.UUID, error) {
+	t := tenant.From(ctx)
+	if t == nil {
+		return uuid.Nil, domain.ErrNoTenant
+	}
+	return uuid.Parse(t.OrganizationID)
+}
+
+// toDomainOrgMember converts a membership row joined with its user.
+func (r *sqlcOrgUserRepository) toDomainOrgMember(row db.GetOrganizationMemberRow) (*domain.OrganizationMember, error) {
+	user := db.User{ID: row.ID, Name: row.Name, Email: row.Email, NameEncrypted: row.NameEncrypted, EmailEncrypted: row.EmailEncrypted, Role: row.Role, CreatedAt: row.CreatedAt, Updatedat: row.Updatedat}
+	if err := r.pii.openUser(&user); err != nil {
+		return nil, err
+	}
+	return &domain.OrganizationMember{User: *toDomainUser(user), Role: row.MemberRole, JoinedAt: row.JoinedAt}, nil
+}
+
+func (r *sqlcOrgUserRepository) ListOrgUsers(ctx context.Context) ([]domain.OrganizationMember, error) {
+	org, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := querierFrom(ctx, r.querier).ListOrganizationMembers(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]domain.OrganizationMember, len(rows))
+	for i, row := range rows {
+		member, err := r.toDomainOrgMember(db.GetOrganizationMemberRow(row))
+		if err != nil {
+			return nil, err
+		}
+		members[i] = *member
+	}
+	if r.pii.enabled() {
+		// Names are encrypted, so SQL could not sort by them
+		sort.SliceStable(members, func(i, j int) bool {
+			a, b := strings.ToLower(members[i].User.Name), strings.ToLower(members[j].User.Name)
+			if a != b {
+				return a < b
+			}
+			return members[i].User.ID < members[j].User.ID
+		})
+	}
+	return members, nil
+}
+
+func (r *sqlcOrgUserRepository) GetOrgUser(ctx context.Context, userID string) (*domain.OrganizationMember, error) {
+	org, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := querierFrom(ctx, r.querier).GetOrganizationMember(ctx, db.GetOrganizationMemberParams{OrganizationID: org, UserID: user})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.toDomainOrgMember(row)
+}
+
+func (r *sqlcOrgUserRepository) AddOrgUser(ctx context.Context, userID, role string) error {
+	org, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+	_, err = querierFrom(ctx, r.querier).AddOrganizationMember(ctx, db.AddOrganizationMemberParams{OrganizationID: org, UserID: user, Role: role})
+	return err
+}
+
+func (r *sqlcOrgUserRepository) UpdateOrgUserRole(ctx context.Context, userID, role string) error {
+	org, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+	_, err = querierFrom(ctx, r.querier).UpdateOrganizationMemberRole(ctx, db.UpdateOrganizationMemberRoleParams{Role: role, OrganizationID: org, UserID: user})
+	return err
+}
+
+func (r *sqlcOrgUserRepository) RemoveOrgUser(ctx context.Context, userID string) error {
+	org, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+	_, err = querierFrom(ctx, r.querier).RemoveOrganizationMember(ctx, db.RemoveOrganizationMemberParams{OrganizationID: org, UserID: user})
+	return err
+}
+
+func (r *sqlcOrgUserRepository) CountOrgOwners(ctx context.Context) (int, error) {
+	org, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n, err := querierFrom(ctx, r.querier).CountOrganizationOwners(ctx, org)
+	return int(n), err
+}
+
+func (r *sqlcOrgUserRepository) CountUserOrgs(ctx context.Context, userID string) (int, error) {
+	if _, err := tenantID(ctx); err != nil {
+		return 0, err
+	}
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := querierFrom(ctx, r.querier).CountUserOrganizations(ctx, user)
+	return int(n), err
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// OrganizationRepository defines the interface for organization data
+// operations. It is not tenant scoped: it creates organizations, resolves
+// which of them a user belongs to and admits invited users. The users of an
+// organization are reached through OrgUserRepository instead.
+type OrganizationRepository interface {
+	// CreateOrganization stores org and makes ownerID its owner. Call it
+	// within a transaction so both are stored or neither is.
+	CreateOrganization(ctx context.Context, org *domain.Organization, ownerID string) (*domain.Organization, error)
+	GetOrganization(ctx context.Context, id string) (*domain.Organization, error)         // Returns nil, nil when not found
+	GetOrganizationBySlug(ctx context.Context, slug string) (*domain.Organization, error) // Returns nil, nil when not found
+	GetMemberRole(ctx context.Context, orgID, userID string) (string, error)              // Returns "" when the user is not a member
+	ListUserOrganizations(ctx context.Context, userID string) ([]domain.OrganizationMembership, error)
+	AddMember(ctx context.Context, orgID, userID, role string) error
+}
+
+// sqlcOrganizationRepository implements OrganizationRepository using sqlc generated code.
+type sqlcOrganizationRepository struct {
+	querier db.Querier
+}
+
+// NewOrganizationRepository creates a new instance of OrganizationRepository.
+func NewOrganizationRepository(conn *sql.DB) OrganizationRepository {
+	return &sqlcOrganizationRepository{querier: db.New(conn)}
+}
+
+func toDomainOrganization(o db.Organization) *domain.Organization {
+	return &domain.Organization{
+		ID:        o.ID.String(),
+		Name:      o.Name,
+		Slug:      o.Slug,
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+	}
+}
+
+func (r *sqlcOrganizationRepository) CreateOrganization(ctx context.Context, org *domain.Organization, ownerID string) (*domain.Organization, error) {
+	owner, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	orgID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	q := querierFrom(ctx, r.querier)
+	if _, err := q.CreateOrganization(ctx, db.CreateOrganizationParams{ID: orgID, Name: org.Name, Slug: org.Slug}); err != nil {
+		return nil, err
+	}
+	if _, err := q.AddOrganizationMember(ctx, db.AddOrganizationMemberParams{OrganizationID: orgID, UserID: owner, Role: domain.OrgRoleOwner}); err != nil {
+		return nil, err
+	}
+	return r.GetOrganization(ctx, orgID.String())
+}
+
+func (r *sqlcOrganizationRepository) GetOrganization(ctx context.Context, id string) (*domain.Organization, error) {
+	orgID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	o, err := querierFrom(ctx, r.querier).GetOrganization(ctx, orgID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainOrganization(o), nil
+}
+
+func (r *sqlcOrganizationRepository) GetOrganizationBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	o, err := querierFrom(ctx, r.querier).GetOrganizationBySlug(ctx, slug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainOrganization(o), nil
+}
+
+func (r *sqlcOrganizationRepository) GetMemberRole(ctx context.Context, orgID, userID string) (string, error) {
+	org, err := uuid.Parse(orgID)
+	if err != nil {
+		return "", err
+	}
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return "", err
+	}
+	row, err := querierFrom(ctx, r.querier).GetOrganizationMember(ctx, db.GetOrganizationMemberParams{OrganizationID: org, UserID: user})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return row.MemberRole, nil
+}
+
+func (r *sqlcOrganizationRepository) ListUserOrganizations(ctx context.Context, userID string) ([]domain.OrganizationMembership, error) {
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := querierFrom(ctx, r.querier).ListUserOrganizations(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	memberships := make([]domain.OrganizationMembership, len(rows))
+	for i, row := range rows {
+		memberships[i] = domain.OrganizationMembership{
+			Organization: *toDomainOrganization(db.Organization{ID: row.ID, Name: row.Name, Slug: row.Slug, CreatedAt: row.CreatedAt, UpdatedAt: row.UpdatedAt}),
+			Role:         row.Role,
+			JoinedAt:     row.JoinedAt,
+		}
+	}
+	return memberships, nil
+}
+
+func (r *sqlcOrganizationRepository) AddMember(ctx context.Context, orgID, userID, role string) error {
+	org, err := uuid.Parse(orgID)
+	if err != nil {
+		return err
+	}
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+	_, err = querierFrom(ctx, r.querier).AddOrganizationMember(ctx, db.AddOrganizationMemberParams{OrganizationID: org, UserID: user, Role: role})
+	return err
+}
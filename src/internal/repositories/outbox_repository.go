@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+)
+
+// maxLastErrorLength matches the size of the last_error columns.
+const maxLastErrorLength = 1024
+
+// truncateError shortens an error message to fit a last_error column.
+func truncateError(s string) string {
+	if len(s) <= maxLastErrorLength {
+		return s
+	}
+	// Drop a rune split by the cut so MySQL accepts the value
+	return strings.ToValidUTF8(s[:maxLastErrorLength], "")
+}
+
+// OutboxRepository defines the interface for the transactional outbox. All
+// methods join the transaction in ctx, if any.
+type OutboxRepository interface {
+	AppendOutboxEvent(ctx context.Context, event domain.DomainEvent) error
+	// ClaimDueOutboxEvents takes up to limit unpublished events that are due at
+	// now and leases them until leaseUntil by moving their next attempt, so
+	// other relays skip them once the transaction in ctx commits. It must run
+	// in a transaction, which only needs to last as long as the claim.
+	ClaimDueOutboxEvents(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.OutboxMessage, error)
+	MarkOutboxEventPublished(ctx context.Context, seq int64, at time.Time) error
+	// MarkOutboxEventFailed schedules the next attempt of an event, unless a
+	// relay that claimed it after the lease ran out has published it since.
+	MarkOutboxEventFailed(ctx context.Context, seq int64, nextAttemptAt time.Time, reason string) error
+
+	// ListOutboxEventsAfter returns up to limit events stored after afterSeq,
+	// published or not, in sequence order.
+	ListOutboxEventsAfter(ctx context.Context, afterSeq int64, limit int) ([]domain.OutboxMessage, error)
+	// ListLatestOutboxEvents returns the newest limit events in sequence order.
+	ListLatestOutboxEvents(ctx context.Context, limit int) ([]domain.OutboxMessage, error)
+}
+
+// sqlcOutboxRepository implements OutboxRepository using sqlc generated code.
+type sqlcOutboxRepository struct {
+	querier db.Querier
+	pii     PIIConfig
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository. When pii
+// enables encryption, user events are stored without the name and email, which
+// would otherwise be kept in plaintext here and in webhook deliveries;
+// subscribers read the user to get them.
+func NewOutboxRepository(conn *sql.DB, pii PIIConfig) OutboxRepository {
+	return &sqlcOutboxRepository{querier: db.New(conn), pii: pii}
+}
+
+// withoutPII removes the name and email from a user event payload.
+func withoutPII(payload json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, err
+	}
+	if _, hasName := fields["name"]; !hasName {
+		if _, hasEmail := fields["email"]; !hasEmail {
+			return payload, nil
+		}
+	}
+	delete(fields, "name")
+	delete(fields, "email")
+	return json.Marshal(fields)
+}
+
+// toDomainOutboxMessage converts a sqlc row to domain.OutboxMessage.
+func toDomainOutboxMessage(row db.OutboxEvent) domain.OutboxMessage {
+	return domain.OutboxMessage{
+		Seq: row.ID,
+		Event: domain.DomainEvent{
+			ID:          row.EventID,
+			Type:        row.EventType,
+			AggregateID: row.AggregateID,
+			Payload:     row.Payload,
+			OccurredAt:  row.OccurredAt,
+		},
+		Attempts: int(row.Attempts),
+	}
+}
+
+func (r *sqlcOutboxRepository) AppendOutboxEvent(ctx context.Context, event domain.DomainEvent) error {
+	payload := event.Payload
+	if r.pii.enabled() && domain.IsUserEventType(event.Type) {
+		var err error
+		if payload, err = withoutPII(payload); err != nil {
+			return err
+		}
+	}
+	return querierFrom(ctx, r.querier).CreateOutboxEvent(ctx, db.CreateOutboxEventParams{
+		EventID:       event.ID,
+		EventType:     event.Type,
+		AggregateID:   event.AggregateID,
+		Payload:       payload,
+		OccurredAt:    event.OccurredAt,
+		NextAttemptAt: event.OccurredAt,
+	})
+}
+
+func (r *sqlcOutboxRepository) ClaimDueOutboxEvents(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.OutboxMessage, error) {
+	q := querierFrom(ctx, r.querier)
+	rows, err := q.ListDueOutboxEvents(ctx, db.ListDueOutboxEventsParams{
+		NextAttemptAt: now,
+		Limit:         int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]domain.OutboxMessage, len(rows))
+	for i, row := range rows {
+		if err := q.LeaseOutboxEvent(ctx, db.LeaseOutboxEventParams{NextAttemptAt: leaseUntil, ID: row.ID}); err != nil {
+			return nil, err
+		}
+		messages[i] = toDomainOutboxMessage(row)
+	}
+	return messages, nil
+}
+
+func (r *sqlcOutboxRepository) MarkOutboxEventPublished(ctx context.Context, seq int64, at time.Time) error {
+	return querierFrom(ctx, r.querier).MarkOutboxEventPublished(ctx, db.MarkOutboxEventPublishedParams{
+		PublishedAt: sql.NullTime{Time: at, Valid: true},
+		ID:          seq,
+	})
+}
+
+func (r *sqlcOutboxRepository) MarkOutboxEventFailed(ctx context.Context, seq int64, nextAttemptAt time.Time, reason string) error {
+	return querierFrom(ctx, r.querier).MarkOutboxEventFailed(ctx, db.MarkOutboxEventFailedParams{
+		NextAttemptAt: nextAttemptAt,
+		LastError:     nullString(truncateError(reason)),
+		ID:            seq,
+	})
+}
+
+func (r *sqlcOutboxRepository) ListOutboxEventsAfter(ctx context.Context, afterSeq int64, limit int) ([]domain.OutboxMessage, error) {
+	rows, err := querierFrom(ctx, r.querier).ListOutboxEventsAfter(ctx, db.ListOutboxEventsAfterParams{
+		ID:    afterSeq,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]domain.OutboxMessage, len(rows))
+	for i, row := range rows {
+		messages[i] = toDomainOutboxMessage(row)
+	}
+	return messages, nil
+}
+
+func (r *sqlcOutboxRepository) ListLatestOutboxEvents(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	rows, err := querierFrom(ctx, r.querier).ListLatestOutboxEvents(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows come newest first
+	messages := make([]domain.OutboxMessage, len(rows))
+	for i, row := range rows {
+		messages[len(rows)-1-i] = toDomainOutboxMessage(row)
+	}
+	return messages, nil
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// outboxQuerier records the outbox events stored.
+type outboxQuerier struct {
+	db.Querier
+	created []db.CreateOutboxEventParams
+}
+
+func (q *outboxQuerier) CreateOutboxEvent(ctx context.Context, arg db.CreateOutboxEventParams) error {
+	q.created = append(q.created, arg)
+	return nil
+}
+
+func TestOutboxRepository_AppendOutboxEvent_OmitsPIIWithEncryption(t *testing.T) {
+	payload := json.RawMessage(`{"id":"user-1","name":"Alice","email":"alice@example.com","role":"user","changed_fields":["email"]}`)
+	event := domain.DomainEvent{ID: "e1", Type: domain.EventUserUpdated, AggregateID: "user-1", Payload: payload}
+
+	plain := &outboxQuerier{}
+	require.NoError(t, (&sqlcOutboxRepository{querier: plain}).AppendOutboxEvent(context.Background(), event))
+	assert.JSONEq(t, string(payload), string(plain.created[0].Payload))
+
+	encrypted := &outboxQuerier{}
+	require.NoError(t, (&sqlcOutboxRepository{querier: encrypted, pii: testPIIConfig(t)}).AppendOutboxEvent(context.Background(), event))
+	assert.JSONEq(t, `{"id":"user-1","role":"user","changed_fields":["email"]}`, string(encrypted.created[0].Payload))
+}
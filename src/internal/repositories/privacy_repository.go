@@ -0,0 +1,250 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// PrivacyRepository defines the interface for data subject requests: reading
+// what is stored about a user and erasing it. Call the erasure methods within
+// one transaction so that a user is never left half erased.
+type PrivacyRepository interface {
+	ListUserAuditEvents(ctx context.Context, userID string) ([]domain.AuditEvent, error) // Performed by or targeting the user, oldest first
+	// EraseUser clears the name, email, password, avatar, preferences and
+	// attributes of a user, removes their second factor, their links to
+	// identity providers and what they granted OAuth clients, revokes their API
+	// keys and clears their email from accepted invitations. The row is kept, marked as erased, so that
+	// references to the user stay valid. It returns false when the user does
+	// not exist or was already erased.
+	EraseUser(ctx context.Context, userID string, at time.Time) (bool, error)
+	// ScrubAuditEvents clears the changes, metadata and IP of events targeting
+	// the user and the IP of events they performed, and returns how many
+	// events were scrubbed. Actions, actors, targets and times are kept.
+	ScrubAuditEvents(ctx context.Context, userID string, at time.Time) (int, error)
+	// ScrubEvents removes the name and email from the payloads of the user's
+	// events in the outbox and of the webhook deliveries made from them, and
+	// returns how many of each were scrubbed.
+	ScrubEvents(ctx context.Context, userID string) (events, deliveries int, err error)
+	// ScrubImportReports removes email from the rows of import reports that
+	// are about the user or carry that email, and returns how many reports
+	// were changed. email must be normalized.
+	ScrubImportReports(ctx context.Context, userID, email string) (int, error)
+	// DeleteUnacceptedInvitations deletes the invitations sent to email that
+	// were never accepted, and returns how many were deleted.
+	DeleteUnacceptedInvitations(ctx context.Context, email string) (int, error)
+	CreateErasureReceipt(ctx context.Context, receipt *domain.ErasureReceipt) (*domain.ErasureReceipt, error)
+	GetErasureReceipt(ctx context.Context, userID string) (*domain.ErasureReceipt, error) // Returns nil, nil when the user was not erased
+}
+
+// sqlcPrivacyRepository implements PrivacyRepository using sqlc generated code.
+type sqlcPrivacyRepository struct {
+	querier db.Querier
+}
+
+// NewPrivacyRepository creates a new instance of PrivacyRepository.
+func NewPrivacyRepository(conn *sql.DB) PrivacyRepository {
+	return &sqlcPrivacyRepository{querier: db.New(conn)}
+}
+
+func (r *sqlcPrivacyRepository) ListUserAuditEvents(ctx context.Context, userID string) ([]domain.AuditEvent, error) {
+	rows, err := querierFrom(ctx, r.querier).ListUserAuditEvents(ctx, nullString(userID))
+	if err != nil {
+		return nil, err
+	}
+	return toDomainAuditEvents(rows)
+}
+
+func (r *sqlcPrivacyRepository) EraseUser(ctx context.Context, userID string, at time.Time) (bool, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return false, err
+	}
+	q := querierFrom(ctx, r.querier)
+	res, err := q.EraseUser(ctx, db.EraseUserParams{ErasedAt: sql.NullTime{Time: at, Valid: true}, ID: id})
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	if err := q.DeleteUserMFASetting(ctx, id); err != nil {
+		return false, err
+	}
+	if _, err := q.DeleteUserRecoveryCodes(ctx, id); err != nil {
+		return false, err
+	}
+	if err := q.DeleteUserIdentities(ctx, id); err != nil {
+		return false, err
+	}
+	if err := q.DeleteUserOAuthConsents(ctx, id); err != nil {
+		return false, err
+	}
+	if err := q.DeleteUserOAuthAuthorizationCodes(ctx, id); err != nil {
+		return false, err
+	}
+	if err := q.DeleteUserOAuthAccessTokens(ctx, id); err != nil {
+		return false, err
+	}
+	if err := q.RevokeUserAPIKeys(ctx, db.RevokeUserAPIKeysParams{RevokedAt: sql.NullTime{Time: at, Valid: true}, UserID: id}); err != nil {
+		return false, err
+	}
+	if err := q.ClearAcceptedInvitationEmails(ctx, nullString(userID)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *sqlcPrivacyRepository) ScrubAuditEvents(ctx context.Context, userID string, at time.Time) (int, error) {
+	q := querierFrom(ctx, r.querier)
+	scrubbedAt := sql.NullTime{Time: at, Valid: true}
+	// Events targeting the user go first, so that those the user also
+	// performed lose their whole payload rather than just the IP
+	res, err := q.ScrubAuditEventsByTarget(ctx, db.ScrubAuditEventsByTargetParams{ScrubbedAt: scrubbedAt, TargetID: nullString(userID)})
+	if err != nil {
+		return 0, err
+	}
+	byTarget, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	res, err = q.ScrubAuditEventsByActor(ctx, db.ScrubAuditEventsByActorParams{ScrubbedAt: scrubbedAt, ActorID: nullString(userID)})
+	if err != nil {
+		return 0, err
+	}
+	byActor, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(byTarget + byActor), nil
+}
+
+func (r *sqlcPrivacyRepository) ScrubEvents(ctx context.Context, userID string) (int, int, error) {
+	q := querierFrom(ctx, r.querier)
+	res, err := q.ScrubUserOutboxEvents(ctx, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	events, err := res.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+	// Deliveries carry the event in its envelope, under data
+	res, err = q.ScrubUserWebhookDeliveries(ctx, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	deliveries, err := res.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(events), int(deliveries), nil
+}
+
+func (r *sqlcPrivacyRepository) ScrubImportReports(ctx context.Context, userID, email string) (int, error) {
+	q := querierFrom(ctx, r.querier)
+	// The query only narrows the reports down; rows are matched here
+	rows, err := q.ListUserImportReports(ctx, db.ListUserImportReportsParams{UserID: userID, Email: email})
+	if err != nil {
+		return 0, err
+	}
+	scrubbed := 0
+	for _, row := range rows {
+		var results []domain.UserImportRowResult
+		if err := json.Unmarshal(row.Report, &results); err != nil {
+			return 0, err
+		}
+		changed := false
+		for i := range results {
+			if results[i].Email == "" {
+				continue
+			}
+			if results[i].UserID == userID || strings.EqualFold(strings.TrimSpace(results[i].Email), email) {
+				results[i].Email = ""
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		report, err := json.Marshal(results)
+		if err != nil {
+			return 0, err
+		}
+		if err := q.UpdateUserImportReport(ctx, db.UpdateUserImportReportParams{Report: report, ID: row.ID}); err != nil {
+			return 0, err
+		}
+		scrubbed++
+	}
+	return scrubbed, nil
+}
+
+func (r *sqlcPrivacyRepository) DeleteUnacceptedInvitations(ctx context.Context, email string) (int, error) {
+	res, err := querierFrom(ctx, r.querier).DeleteUnacceptedInvitations(ctx, email)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (r *sqlcPrivacyRepository) CreateErasureReceipt(ctx context.Context, receipt *domain.ErasureReceipt) (*domain.ErasureReceipt, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(receipt.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = querierFrom(ctx, r.querier).CreateErasureReceipt(ctx, db.CreateErasureReceiptParams{
+		ID:                        id,
+		UserID:                    userID,
+		RequestedBy:               nullString(receipt.RequestedBy),
+		Reason:                    receipt.Reason,
+		AuditEventsScrubbed:       int32(receipt.AuditEventsScrubbed),
+		EventsScrubbed:            int32(receipt.EventsScrubbed),
+		WebhookDeliveriesScrubbed: int32(receipt.WebhookDeliveriesScrubbed),
+		ImportReportsScrubbed:     int32(receipt.ImportReportsScrubbed),
+		InvitationsDeleted:        int32(receipt.InvitationsDeleted),
+		ErasedAt:                  receipt.ErasedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	created := *receipt
+	created.ID = id.String()
+	return &created, nil
+}
+
+func (r *sqlcPrivacyRepository) GetErasureReceipt(ctx context.Context, userID string) (*domain.ErasureReceipt, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := querierFrom(ctx, r.querier).GetErasureReceiptByUser(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &domain.ErasureReceipt{
+		ID:                        row.ID.String(),
+		UserID:                    row.UserID.String(),
+		RequestedBy:               row.RequestedBy.String,
+		Reason:                    row.Reason,
+		AuditEventsScrubbed:       int(row.AuditEventsScrubbed),
+		EventsScrubbed:            int(row.EventsScrubbed),
+		WebhookDeliveriesScrubbed: int(row.WebhookDeliveriesScrubbed),
+		ImportReportsScrubbed:     int(row.ImportReportsScrubbed),
+		InvitationsDeleted:        int(row.InvitationsDeleted),
+		ErasedAt:                  row.ErasedAt,
+	}, nil
+}
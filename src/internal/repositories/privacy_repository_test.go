@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// importReportQuerier serves import reports and records the ones rewritten.
+type importReportQuerier struct {
+	db.Querier
+	reports []db.ListUserImportReportsRow
+	updated map[uuid.UUID][]domain.UserImportRowResult
+}
+
+func (q *importReportQuerier) ListUserImportReports(ctx context.Context, arg db.ListUserImportReportsParams) ([]db.ListUserImportReportsRow, error) {
+	return q.reports, nil
+}
+
+func (q *importReportQuerier) UpdateUserImportReport(ctx context.Context, arg db.UpdateUserImportReportParams) error {
+	var rows []domain.UserImportRowResult
+	if err := json.Unmarshal(arg.Report, &rows); err != nil {
+		return err
+	}
+	q.updated[arg.ID] = rows
+	return nil
+}
+
+func TestPrivacyRepository_ScrubImportReports(t *testing.T) {
+	userID := uuid.NewString()
+	mentioned, unrelated := uuid.New(), uuid.New()
+	report := func(rows ...domain.UserImportRowResult) json.RawMessage {
+		b, err := json.Marshal(rows)
+		require.NoError(t, err)
+		return b
+	}
+	q := &importReportQuerier{
+		reports: []db.ListUserImportReportsRow{
+			{ID: mentioned, Report: report(
+				domain.UserImportRowResult{Row: 1, Email: "alice@example.com", UserID: userID},
+				domain.UserImportRowResult{Row: 2, Email: " Alice@Example.com", Error: "email already exists"},
+				domain.UserImportRowResult{Row: 3, Email: "bob@example.com", UserID: uuid.NewString()},
+			)},
+			// Matched by the query on a substring only
+			{ID: unrelated, Report: report(domain.UserImportRowResult{Row: 1, Email: "malice@example.com"})},
+		},
+		updated: map[uuid.UUID][]domain.UserImportRowResult{},
+	}
+	repo := &sqlcPrivacyRepository{querier: q}
+
+	n, err := repo.ScrubImportReports(context.Background(), userID, "alice@example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []domain.UserImportRowResult{
+		{Row: 1, UserID: userID},
+		{Row: 2, Error: "email already exists"},
+		{Row: 3, Email: "bob@example.com", UserID: q.updated[mentioned][2].UserID},
+	}, q.updated[mentioned])
+	assert.NotContains(t, q.updated, unrelated)
+}
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "apiserver/internal/db/sqlc"
+)
+
+// RetentionRepository defines the interface for purging records that have
+// outlived their retention period. Each method deletes up to limit rows
+// older than before and returns how many it deleted; callers repeat until
+// fewer than limit come back, keeping each batch's locks short.
+type RetentionRepository interface {
+	// PurgeAuditEvents deletes audit events that occurred before before. The
+	// audit log refuses deletes otherwise, so it must run within a transaction.
+	PurgeAuditEvents(ctx context.Context, before time.Time, limit int) (int, error)
+	// PurgeErasedUsers deletes the anonymized rows of users erased before
+	// before, along with their memberships. Erasure receipts are kept.
+	PurgeErasedUsers(ctx context.Context, before time.Time, limit int) (int, error)
+	PurgeInvitations(ctx context.Context, before time.Time, limit int) (int, error) // Expired or revoked before before
+	PurgeAPIKeys(ctx context.Context, before time.Time, limit int) (int, error)     // Expired or revoked before before
+	PurgeOutboxEvents(ctx context.Context, before time.Time, limit int) (int, error)
+	// PurgeLoginThrottles deletes throttles with no failure or lockout since before.
+	PurgeLoginThrottles(ctx context.Context, before time.Time, limit int) (int, error)
+	// PurgeOIDCLoginStates deletes sign-ins at identity providers that expired
+	// before before without being completed.
+	PurgeOIDCLoginStates(ctx context.Context, before time.Time, limit int) (int, error)
+	// PurgeOAuthCodes deletes authorization codes that expired before before
+	// without being redeemed.
+	PurgeOAuthCodes(ctx context.Context, before time.Time, limit int) (int, error)
+	PurgeOAuthTokens(ctx context.Context, before time.Time, limit int) (int, error) // Expired before before
+}
+
+// sqlcRetentionRepository implements RetentionRepository using sqlc generated code.
+type sqlcRetentionRepository struct {
+	querier db.Querier
+}
+
+// NewRetentionRepository creates a new instance of RetentionRepository.
+func NewRetentionRepository(conn *sql.DB) RetentionRepository {
+	return &sqlcRetentionRepository{querier: db.New(conn)}
+}
+
+// rowsDeleted returns how many rows the delete in res removed.
+func rowsDeleted(res sql.Result, err error) (int, error) {
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (r *sqlcRetentionRepository) PurgeAuditEvents(ctx context.Context, before time.Time, limit int) (int, error) {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if !ok {
+		return 0, errors.New("purging audit events needs a transaction")
+	}
+	// The delete trigger lets through rows older than this session variable.
+	// It is cleared again before the connection goes back to the pool.
+	if _, err := tx.ExecContext(ctx, "SET @audit_events_purge_before = ?", before); err != nil {
+		return 0, err
+	}
+	defer tx.ExecContext(context.WithoutCancel(ctx), "SET @audit_events_purge_before = NULL")
+	return rowsDeleted(db.New(tx).PurgeAuditEvents(ctx, db.PurgeAuditEventsParams{OccurredAt: before, Limit: int32(limit)}))
+}
+
+func (r *sqlcRetentionRepository) PurgeErasedUsers(ctx context.Context, before time.Time, limit int) (int, error) {
+	return rowsDeleted(querierFrom(ctx, r.querier).PurgeErasedUsers(ctx, db.PurgeErasedUsersParams{
+		ErasedAt: sql.NullTime{Time: before, Valid: true},
+		Limit:    int32(limit),
+	}))
+}
+
+func (r *sqlcRetentionRepository) PurgeInvitations(ctx context.Context, before time.Time, limit int) (int, error) {
+	return rowsDeleted(querierFrom(ctx, r.querier).PurgeInvitations(ctx, db.PurgeInvitationsParams{Before: before, Limit: int32(limit)}))
+}
+
+func (r *sqlcRetentionRepository) PurgeAPIKeys(ctx context.Context, before time.Time, limit int) (int, error) {
+	return rowsDeleted(querierFrom(ctx, r.querier).PurgeAPIKeys(ctx, db.PurgeAPIKeysParams{
+		Before: sql.NullTime{Time: before, Valid: true},
+		Limit:  int32(limit),
+	}))
+}
+
+func (r *sqlcRetentionRepository) PurgeOutboxEvents(ctx context.Context, before time.Time, limit int) (int, error) {
+	return rowsDeleted(querierFrom(ctx, r.querier).PurgeOutboxEvents(ctx, db.PurgeOutboxEventsParams{
+		PublishedAt: sql.NullTime{Time: before, Valid: true},
+		Limit:       int32(limit),
+	}))
+}
+
+func (r *sqlcRetentionRepository) PurgeLoginThrottles(ctx context.Context, before time.Time, limit int) (int, error) {
+	return rowsDeleted(querierFrom(ctx, r.querier).PurgeLoginThrottles(ctx, db.PurgeLoginThrottlesParams{
+		Before: sql.NullTime{Time: before, Valid: true},
+		Limit:  int32(limit),
+	}))
+}
+
+func (r *sqlcRetentionRepository) PurgeOIDCLoginStates(ctx context.Context, before time.Time, limit int) (int, error) {
+	return rowsDeleted(querierFrom(ctx, r.querier).PurgeOIDCLoginStates(ctx, db.PurgeOIDCLoginStatesParams{ExpiresAt: before, Limit: int32(limit)}))
+}
+
+func (r *sqlcRetentionRepository) PurgeOAuthCodes(ctx context.Context, before time.Time, limit int) (int, error) {
+	return rowsDeleted(querierFrom(ctx, r.querier).PurgeOAuthAuthorizationCodes(ctx, db.PurgeOAuthAuthorizationCodesParams{ExpiresAt: before, Limit: int32(limit)}))
+}
+
+func (r *sqlcRetentionRepository) PurgeOAuthTokens(ctx context.Context, before time.Time, limit int) (int, error) {
+	return rowsDeleted(querierFrom(ctx, r.querier).PurgeOAuthAccessTokens(ctx, db.PurgeOAuthAccessTokensParams{ExpiresAt: before, Limit: int32(limit)}))
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+)
+
+// SigningKeyRepository defines the interface for the keys that sign ID tokens.
+type SigningKeyRepository interface {
+	// ListSigningKeys returns the keys that have no retirement date or retire
+	// after retiredAfter, newest first.
+	ListSigningKeys(ctx context.Context, retiredAfter time.Time) ([]domain.SigningKey, error)
+	CreateSigningKey(ctx context.Context, key *domain.SigningKey) error
+	RetireSigningKeys(ctx context.Context, exceptID string, at time.Time) error  // Sets the retirement date of every key but exceptID that has none
+	DeleteSigningKeys(ctx context.Context, retiredBefore time.Time) (int, error) // Returns how many keys were deleted
+}
+
+// sqlcSigningKeyRepository implements SigningKeyRepository using sqlc generated code.
+type sqlcSigningKeyRepository struct {
+	querier db.Querier
+}
+
+// NewSigningKeyRepository creates a new instance of SigningKeyRepository.
+func NewSigningKeyRepository(conn *sql.DB) SigningKeyRepository {
+	return &sqlcSigningKeyRepository{querier: db.New(conn)}
+}
+
+func (r *sqlcSigningKeyRepository) ListSigningKeys(ctx context.Context, retiredAfter time.Time) ([]domain.SigningKey, error) {
+	rows, err := querierFrom(ctx, r.querier).ListOIDCSigningKeys(ctx, sql.NullTime{Time: retiredAfter, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]domain.SigningKey, len(rows))
+	for i, row := range rows {
+		keys[i] = domain.SigningKey{
+			ID:                   row.ID,
+			PublicKey:            row.PublicKey,
+			PrivateKeyCiphertext: row.PrivateKeyCiphertext,
+			CreatedAt:            row.CreatedAt,
+			ActivatedAt:          row.ActivatedAt,
+		}
+		if row.RetiredAt.Valid {
+			keys[i].RetiredAt = row.RetiredAt.Time
+		}
+	}
+	return keys, nil
+}
+
+func (r *sqlcSigningKeyRepository) CreateSigningKey(ctx context.Context, key *domain.SigningKey) error {
+	return querierFrom(ctx, r.querier).CreateOIDCSigningKey(ctx, db.CreateOIDCSigningKeyParams{
+		ID:                   key.ID,
+		PublicKey:            key.PublicKey,
+		PrivateKeyCiphertext: key.PrivateKeyCiphertext,
+		CreatedAt:            key.CreatedAt,
+		ActivatedAt:          key.ActivatedAt,
+	})
+}
+
+func (r *sqlcSigningKeyRepository) RetireSigningKeys(ctx context.Context, exceptID string, at time.Time) error {
+	return querierFrom(ctx, r.querier).RetireOIDCSigningKeys(ctx, db.RetireOIDCSigningKeysParams{
+		RetiredAt: sql.NullTime{Time: at, Valid: true},
+		ID:        exceptID,
+	})
+}
+
+func (r *sqlcSigningKeyRepository) DeleteSigningKeys(ctx context.Context, retiredBefore time.Time) (int, error) {
+	return rowsDeleted(querierFrom(ctx, r.querier).DeleteOIDCSigningKeys(ctx, sql.NullTime{Time: retiredBefore, Valid: true}))
+}
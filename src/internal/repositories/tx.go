@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	db "apiserver/internal/db/sqlc"
+)
+
+// TxManager runs a unit of work in a single database transaction. Repositories
+// called with the context passed to fn take part in that transaction.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+// sqlTxManager implements TxManager on top of database/sql.
+type sqlTxManager struct {
+	dbConn *sql.DB
+}
+
+// NewTxManager creates a new instance of TxManager.
+func NewTxManager(conn *sql.DB) TxManager {
+	return &sqlTxManager{dbConn: conn}
+}
+
+func (m *sqlTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Join the caller's transaction instead of nesting
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // No-op after a successful commit
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// querierFrom returns a querier bound to the transaction in ctx, or fallback
+// when ctx carries none.
+func querierFrom(ctx context.Context, fallback db.Querier) db.Querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return db.New(tx)
+	}
+	return fallback
+}
+
+// dbtxFrom returns the transaction in ctx, or fallback when ctx carries none.
+// It is for statements sqlc cannot generate, such as multi-row inserts.
+func dbtxFrom(ctx context.Context, fallback db.DBTX) db.DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return fallback
+}
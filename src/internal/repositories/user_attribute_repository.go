@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+)
+
+// UserAttributeRepository defines the interface for custom user attribute
+// definitions. The values live on the users.
+type UserAttributeRepository interface {
+	ListAttributeDefinitions(ctx context.Context) ([]domain.AttributeDefinition, error)          // In key order
+	GetAttributeDefinition(ctx context.Context, key string) (*domain.AttributeDefinition, error) // Returns nil, nil when not found
+	CreateAttributeDefinition(ctx context.Context, def *domain.AttributeDefinition) (*domain.AttributeDefinition, error)
+	// UpdateAttributeDefinition saves the required flag, enum and description
+	// of def. It returns nil, nil when the definition does not exist.
+	UpdateAttributeDefinition(ctx context.Context, def *domain.AttributeDefinition) (*domain.AttributeDefinition, error)
+	// DeleteAttributeDefinition deletes the definition and removes its values
+	// from every user. It must run in a transaction.
+	DeleteAttributeDefinition(ctx context.Context, key string) error
+}
+
+// sqlcUserAttributeRepository implements UserAttributeRepository using sqlc
+// generated code.
+type sqlcUserAttributeRepository struct {
+	querier db.Querier
+}
+
+// NewUserAttributeRepository creates a new instance of UserAttributeRepository.
+func NewUserAttributeRepository(conn *sql.DB) UserAttributeRepository {
+	return &sqlcUserAttributeRepository{querier: db.New(conn)}
+}
+
+func toDomainAttributeDefinition(d db.UserAttributeDefinition) (*domain.AttributeDefinition, error) {
+	def := &domain.AttributeDefinition{
+		Key:         d.AttributeKey,
+		Type:        d.Type,
+		Required:    d.Required,
+		Description: d.Description,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+	if len(d.EnumValues) > 0 {
+		if err := json.Unmarshal(d.EnumValues, &def.Enum); err != nil {
+			return nil, err
+		}
+	}
+	return def, nil
+}
+
+// encodeEnum stores an empty enum as NULL.
+func encodeEnum(values []string) (json.RawMessage, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(values)
+}
+
+func (r *sqlcUserAttributeRepository) ListAttributeDefinitions(ctx context.Context) ([]domain.AttributeDefinition, error) {
+	rows, err := querierFrom(ctx, r.querier).ListUserAttributeDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defs := make([]domain.AttributeDefinition, len(rows))
+	for i, row := range rows {
+		def, err := toDomainAttributeDefinition(row)
+		if err != nil {
+			return nil, err
+		}
+		defs[i] = *def
+	}
+	return defs, nil
+}
+
+func (r *sqlcUserAttributeRepository) GetAttributeDefinition(ctx context.Context, key string) (*domain.AttributeDefinition, error) {
+	row, err := querierFrom(ctx, r.querier).GetUserAttributeDefinition(ctx, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainAttributeDefinition(row)
+}
+
+func (r *sqlcUserAttributeRepository) CreateAttributeDefinition(ctx context.Context, def *domain.AttributeDefinition) (*domain.AttributeDefinition, error) {
+	enum, err := encodeEnum(def.Enum)
+	if err != nil {
+		return nil, err
+	}
+	err = querierFrom(ctx, r.querier).CreateUserAttributeDefinition(ctx, db.CreateUserAttributeDefinitionParams{
+		AttributeKey: def.Key,
+		Type:         def.Type,
+		Required:     def.Required,
+		EnumValues:   enum,
+		Description:  def.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetAttributeDefinition(ctx, def.Key)
+}
+
+func (r *sqlcUserAttributeRepository) UpdateAttributeDefinition(ctx context.Context, def *domain.AttributeDefinition) (*domain.AttributeDefinition, error) {
+	enum, err := encodeEnum(def.Enum)
+	if err != nil {
+		return nil, err
+	}
+	_, err = querierFrom(ctx, r.querier).UpdateUserAttributeDefinition(ctx, db.UpdateUserAttributeDefinitionParams{
+		Required:     def.Required,
+		EnumValues:   enum,
+		Description:  def.Description,
+		AttributeKey: def.Key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetAttributeDefinition(ctx, def.Key)
+}
+
+func (r *sqlcUserAttributeRepository) DeleteAttributeDefinition(ctx context.Context, key string) error {
+	q := querierFrom(ctx, r.querier)
+	if _, err := q.DeleteUserAttributeDefinition(ctx, key); err != nil {
+		return err
+	}
+	return q.RemoveUserAttribute(ctx, key)
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// UserImportRepository defines the interface for user import jobs.
+type UserImportRepository interface {
+	// CreateUserImportJob stores a new job and assigns its ID.
+	CreateUserImportJob(ctx context.Context, job *domain.UserImportJob) error
+	GetUserImportJob(ctx context.Context, id string) (*domain.UserImportJob, error) // Returns nil, nil when not found
+	// UpdateUserImportJob stores the status, counts and, once the job has
+	// finished, the per-row report of job.
+	UpdateUserImportJob(ctx context.Context, job *domain.UserImportJob) error
+}
+
+// sqlcUserImportRepository implements UserImportRepository using sqlc generated code.
+type sqlcUserImportRepository struct {
+	querier db.Querier
+}
+
+// NewUserImportRepository creates a new instance of UserImportRepository.
+func NewUserImportRepository(conn *sql.DB) UserImportRepository {
+	return &sqlcUserImportRepository{querier: db.New(conn)}
+}
+
+// toDomainUserImportJob converts a sqlc row to domain.UserImportJob. The
+// report column holds the per-row results as a JSON array.
+func toDomainUserImportJob(j db.UserImportJob) (*domain.UserImportJob, error) {
+	job := &domain.UserImportJob{
+		ID:     j.ID.String(),
+		Format: j.Format,
+		Status: j.Status,
+		Report: domain.UserImportReport{
+			Total:   int(j.TotalRows),
+			Created: int(j.CreatedRows),
+			Failed:  int(j.FailedRows),
+			Error:   j.Error.String,
+		},
+		CreatedBy:  j.CreatedBy.String,
+		CreatedAt:  j.CreatedAt,
+		UpdatedAt:  j.UpdatedAt,
+		FinishedAt: j.FinishedAt.Time,
+	}
+	if len(j.Report) > 0 {
+		if err := json.Unmarshal(j.Report, &job.Report.Rows); err != nil {
+			return nil, err
+		}
+	}
+	return job, nil
+}
+
+func (r *sqlcUserImportRepository) CreateUserImportJob(ctx context.Context, job *domain.UserImportJob) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	_, err = querierFrom(ctx, r.querier).CreateUserImportJob(ctx, db.CreateUserImportJobParams{
+		ID:        id,
+		Format:    job.Format,
+		Status:    job.Status,
+		CreatedBy: sql.NullString{String: job.CreatedBy, Valid: job.CreatedBy != ""},
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	job.ID = id.String()
+	return nil
+}
+
+func (r *sqlcUserImportRepository) GetUserImportJob(ctx context.Context, id string) (*domain.UserImportJob, error) {
+	jobID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	j, err := querierFrom(ctx, r.querier).GetUserImportJob(ctx, jobID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainUserImportJob(j)
+}
+
+func (r *sqlcUserImportRepository) UpdateUserImportJob(ctx context.Context, job *domain.UserImportJob) error {
+	jobID, err := uuid.Parse(job.ID)
+	if err != nil {
+		return err
+	}
+	var report json.RawMessage
+	if job.Finished() {
+		if report, err = json.Marshal(job.Report.Rows); err != nil {
+			return err
+		}
+	}
+	_, err = querierFrom(ctx, r.querier).UpdateUserImportJob(ctx, db.UpdateUserImportJobParams{
+		Status:      job.Status,
+		TotalRows:   int32(job.Report.Total),
+		CreatedRows: int32(job.Report.Created),
+		FailedRows:  int32(job.Report.Failed),
+		Error:       sql.NullString{String: job.Report.Error, Valid: job.Report.Error != ""},
+		Report:      report,
+		UpdatedAt:   job.UpdatedAt,
+		FinishedAt:  sql.NullTime{Time: job.FinishedAt, Valid: !job.FinishedAt.IsZero()},
+		ID:          jobID,
+	})
+	return err
+}
@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"apiserver/internal/encryption"
+	"github.com/google/uuid"
+)
+
+// errPIIKeyringMissing is returned when a row holds encrypted names or emails
+// but the repository was built without a keyring.
+var errPIIKeyringMissing = errors.New("user PII is encrypted but no keyring is configured")
+
+// PIIConfig controls how user names and emails are stored. With both fields
+// set they are written to the encrypted columns, with a blind index of the
+// email for lookups and uniqueness, and the plaintext columns are cleared.
+// The zero value keeps writing plaintext. Rows are read correctly either way
+// as long as the keyring holds the keys they were encrypted with, so
+// encryption can be turned on before existing rows are migrated.
+type PIIConfig struct {
+	Keyring    *encryption.Keyring
+	BlindIndex *encryption.BlindIndex
+}
+
+func (c PIIConfig) enabled() bool {
+	return c.Keyring != nil && c.BlindIndex != nil
+}
+
+// piiColumns are the stored forms of a user's name and email.
+type piiColumns struct {
+	Name           sql.NullString
+	Email          sql.NullString
+	NameEncrypted  []byte
+	EmailEncrypted []byte
+	EmailIndex     sql.NullString
+	KeyVersion     sql.NullInt32
+}
+
+// normalizeEmailForIndex folds the differences email lookups ignore.
+func normalizeEmailForIndex(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// emailIndex returns the blind index of email, or NULL when encryption is off.
+func (c PIIConfig) emailIndex(email string) sql.NullString {
+	if !c.enabled() || email == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: hex.EncodeToString(c.BlindIndex.Sum([]byte(normalizeEmailForIndex(email)))), Valid: true}
+}
+
+// piiAAD binds an encrypted value to its row and column, so that it cannot
+// be copied into another user.
+func piiAAD(id uuid.UUID, column string) []byte {
+	return append(id[:], column...)
+}
+
+// seal returns the columns to store name and email of user id under.
+func (c PIIConfig) seal(id uuid.UUID, name, email string) (piiColumns, error) {
+	if !c.enabled() {
+		return piiColumns{Name: nullString(name), Email: nullString(email)}, nil
+	}
+	cols := piiColumns{
+		EmailIndex: c.emailIndex(email),
+		KeyVersion: sql.NullInt32{Int32: int32(c.Keyring.PrimaryVersion()), Valid: true},
+	}
+	var err error
+	if name != "" {
+		if cols.NameEncrypted, err = c.Keyring.Encrypt([]byte(name), piiAAD(id, "name")); err != nil {
+			return piiColumns{}, err
+		}
+	}
+	if email != "" {
+		if cols.EmailEncrypted, err = c.Keyring.Encrypt([]byte(email), piiAAD(id, "email")); err != nil {
+			return piiColumns{}, err
+		}
+	}
+	return cols, nil
+}
+
+// open returns the name and email of user id, preferring the encrypted
+// columns over plaintext left from before encryption was enabled.
+func (c PIIConfig) open(id uuid.UUID, name, email sql.NullString, nameEncrypted, emailEncrypted []byte) (sql.NullString, sql.NullString, error) {
+	var err error
+	if nameEncrypted != nil {
+		if name, err = c.decrypt(id, "name", nameEncrypted); err != nil {
+			return name, email, err
+		}
+	}
+	if emailEncrypted != nil {
+		if email, err = c.decrypt(id, "email", emailEncrypted); err != nil {
+			return name, email, err
+		}
+	}
+	return name, email, nil
+}
+
+func (c PIIConfig) decrypt(id uuid.UUID, column string, envelope []byte) (sql.NullString, error) {
+	if c.Keyring == nil {
+		return sql.NullString{}, errPIIKeyringMissing
+	}
+	plaintext, err := c.Keyring.Decrypt(envelope, piiAAD(id, column))
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("decrypting %s of user %s: %w", column, id, err)
+	}
+	return sql.NullString{String: string(plaintext), Valid: true}, nil
+}
+
+// openUser replaces the name and email of u with their decrypted values.
+func (c PIIConfig) openUser(u *db.User) error {
+	var err error
+	u.Name, u.Email, err = c.open(u.ID, u.Name, u.Email, u.NameEncrypted, u.EmailEncrypted)
+	return err
+}
+
+// sortUsersByName restores the name order that SQL cannot give once names
+// are encrypted.
+func (c PIIConfig) sortUsersByName(users []domain.User) {
+	if !c.enabled() {
+		return
+	}
+	sort.SliceStable(users, func(i, j int) bool {
+		a, b := strings.ToLower(users[i].Name), strings.ToLower(users[j].Name)
+		if a != b {
+			return a < b
+		}
+		return users[i].ID < users[j].ID
+	})
+}
+
+// UserPIIRepository migrates the stored names and emails of users to the
+// primary key of the keyring.
+type UserPIIRepository interface {
+	// ReencryptUsers rewrites up to limit users after afterID, in ID order,
+	// whose name and email are in plaintext or under an older key. It returns
+	// the ID of the last user rewritten, to continue from, and how many were
+	// rewritten; fewer than limit means none are left. Rows are locked until
+	// the transaction in ctx ends, so callers keep batches small.
+	ReencryptUsers(ctx context.Context, afterID string, limit int) (string, int, error)
+}
+
+// sqlcUserPIIRepository implements UserPIIRepository using sqlc generated code.
+type sqlcUserPIIRepository struct {
+	querier db.Querier
+	pii     PIIConfig
+}
+
+// NewUserPIIRepository creates a new instance of UserPIIRepository. Both the
+// keyring and the blind index must be configured.
+func NewUserPIIRepository(conn *sql.DB, pii PIIConfig) (UserPIIRepository, error) {
+	if !pii.enabled() {
+		return nil, errors.New("re-encrypting users needs a keyring and a blind index key")
+	}
+	return &sqlcUserPIIRepository{querier: db.New(conn), pii: pii}, nil
+}
+
+func (r *sqlcUserPIIRepository) ReencryptUsers(ctx context.Context, afterID string, limit int) (string, int, error) {
+	after := uuid.Nil
+	if afterID != "" {
+		var err error
+		if after, err = uuid.Parse(afterID); err != nil {
+			return "", 0, err
+		}
+	}
+	q := querierFrom(ctx, r.querier)
+	rows, err := q.ListUsersWithStalePII(ctx, db.ListUsersWithStalePIIParams{
+		ID:            after,
+		PiiKeyVersion: sql.NullInt32{Int32: int32(r.pii.Keyring.PrimaryVersion()), Valid: true},
+		Limit:         int32(limit),
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	for _, row := range rows {
+		name, email, err := r.pii.open(row.ID, row.Name, row.Email, row.NameEncrypted, row.EmailEncrypted)
+		if err != nil {
+			return "", 0, err
+		}
+		cols, err := r.pii.seal(row.ID, name.String, email.String)
+		if err != nil {
+			return "", 0, err
+		}
+		err = q.UpdateUserPII(ctx, db.UpdateUserPIIParams{
+			Name:           cols.Name,
+			Email:          cols.Email,
+			NameEncrypted:  cols.NameEncrypted,
+			EmailEncrypted: cols.EmailEncrypted,
+			EmailIndex:     cols.EmailIndex,
+			PiiKeyVersion:  cols.KeyVersion,
+			ID:             row.ID,
+		})
+		if err != nil {
+			// Most likely two plaintext rows whose emails differ only in case
+			return "", 0, fmt.Errorf("re-encrypting user %s: %w", row.ID, err)
+		}
+	}
+	if len(rows) == 0 {
+		return afterID, 0, nil
+	}
+	return rows[len(rows)-1].ID.String(), len(rows), nil
+}
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/encryption"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPIIConfig(t *testing.T) PIIConfig {
+	keyring, err := encryption.NewKeyring(map[uint32][]byte{1: bytes.Repeat([]byte{1}, 32)}, 1)
+	require.NoError(t, err)
+	index, err := encryption.NewBlindIndex(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	return PIIConfig{Keyring: keyring, BlindIndex: index}
+}
+
+func TestPIIConfig_SealAndOpen(t *testing.T) {
+	pii := testPIIConfig(t)
+	id := uuid.New()
+
+	cols, err := pii.seal(id, "Alice", "Alice@Example.com")
+	require.NoError(t, err)
+	assert.False(t, cols.Name.Valid, "plaintext is not stored")
+	assert.False(t, cols.Email.Valid, "plaintext is not stored")
+	assert.Equal(t, sql.NullInt32{Int32: 1, Valid: true}, cols.KeyVersion)
+	assert.Equal(t, pii.emailIndex(" alice@example.COM"), cols.EmailIndex, "the index ignores case and spaces")
+
+	name, email, err := pii.open(id, cols.Name, cols.Email, cols.NameEncrypted, cols.EmailEncrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", name.String)
+	assert.Equal(t, "Alice@Example.com", email.String)
+
+	// Values cannot be moved to another user or column
+	_, _, err = pii.open(uuid.New(), cols.Name, cols.Email, cols.NameEncrypted, nil)
+	assert.ErrorIs(t, err, encryption.ErrDecrypt)
+	_, _, err = pii.open(id, cols.Name, cols.Email, cols.EmailEncrypted, nil)
+	assert.ErrorIs(t, err, encryption.ErrDecrypt)
+}
+
+func TestPIIConfig_Plaintext(t *testing.T) {
+	var pii PIIConfig
+	id := uuid.New()
+
+	cols, err := pii.seal(id, "Alice", "alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, piiColumns{Name: nullString("Alice"), Email: nullString("alice@example.com")}, cols)
+
+	// Rows encrypted earlier cannot be read without the keyring
+	encrypted, err := testPIIConfig(t).seal(id, "Alice", "alice@example.com")
+	require.NoError(t, err)
+	_, _, err = pii.open(id, encrypted.Name, encrypted.Email, encrypted.NameEncrypted, encrypted.EmailEncrypted)
+	assert.ErrorIs(t, err, errPIIKeyringMissing)
+}
+
+func TestUserRepository_SearchUnavailableWithPIIEncryption(t *testing.T) {
+	// The querier is never reached, so none is configured
+	repo := &sqlcUserRepository{pii: testPIIConfig(t)}
+
+	_, err := repo.SearchUsers(context.Background(), "alice", 20, 0)
+	assert.ErrorIs(t, err, domain.ErrUserSearchUnavailable)
+	_, err = repo.SearchUsersByEmailPrefix(context.Background(), "alice@example.com", 20, 0)
+	assert.ErrorIs(t, err, domain.ErrUserSearchUnavailable)
+}
@@ -3,6 +3,8 @@ package repositories
 import (
 	"context"
 	"database/sql" // For sql.Result, and potentially for db connection if not abstracted by sqlc Querier fully
+	"encoding/json"
+	"strings"
 
 	"apiserver/internal/domain"       // Our domain model
 	db "apiserver/internal/db/sqlc" // sqlc generated package, aliased to db
@@ -12,9 +14,38 @@ import (
 // UserRepository defines the interface for user data operations.
 type UserRepository interface {
 	CreateUser(ctx context.Context, user *domain.User, hashedPassword string) (*domain.User, error)
+	// CreateUsers inserts users, whose Password fields hold password hashes,
+	// with a single statement and returns their new IDs in order.
+	CreateUsers(ctx context.Context, users []domain.User) ([]string, error)
 	GetUserByID(ctx context.Context, id string) (*domain.User, error)
-	ListUsers(ctx context.Context) ([]domain.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*domain.User, error) // Includes the password hash, for credential checks only
+	ListExistingUserEmails(ctx context.Context, emails []string) ([]string, error) // Returns the given emails that are registered, as stored
+	ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) // In name order
+	ListUsersAfter(ctx context.Context, afterID string, limit int) ([]domain.User, error) // In ID order; an empty afterID starts at the beginning
+	// SearchUsers runs a full-text search over names and emails and returns up
+	// to limit hits after skipping offset, most relevant first. Match ranges
+	// are left for the caller to fill in. It fails with
+	// domain.ErrUserSearchUnavailable while PII encryption is on, as encrypted
+	// names and emails are not indexed.
+	SearchUsers(ctx context.Context, query string, limit, offset int) ([]domain.UserSearchHit, error)
+	// SearchUsersByEmailPrefix returns users whose email starts with prefix, in
+	// email order. Like SearchUsers, it fails while PII encryption is on.
+	SearchUsersByEmailPrefix(ctx context.Context, prefix string, limit, offset int) ([]domain.User, error)
+	// StreamUsers calls fn for every user matching filter, in ID order, reading
+	// rows from the connection as it goes rather than loading them all.
+	// Password hashes are never read. An error from fn stops the stream and is
+	// returned.
+	StreamUsers(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error
+	// UpdateUser saves the non-empty name and email of user. Preferences and
+	// attributes are always replaced, so callers start from the stored user.
 	UpdateUser(ctx context.Context, id string, user *domain.User, hashedPassword *string) (*domain.User, error) // hashedPassword is a pointer to allow optional update
+	// UpdateUserAvatar sets the avatar URLs and returns the updated user, or
+	// nil if it does not exist.
+	UpdateUserAvatar(ctx context.Context, id, avatarURL, thumbnailURL string) (*domain.User, error)
+	// UpdateUserPasswordHash replaces the password hash of id with newHash if
+	// it is still oldHash, and reports whether it did, so that a password
+	// changed in the meantime is not overwritten.
+	UpdateUserPasswordHash(ctx context.Context, id, oldHash, newHash string) (bool, error)
 	DeleteUser(ctx context.Context, id string) error
 }
 
@@ -22,13 +53,16 @@ type UserRepository interface {
 type sqlcUserRepository struct {
 	querier db.Querier // sqlc generated Querier interface
 	dbConn  *sql.DB    // The underlying DB connection, needed for Querier usually.
+	pii     PIIConfig
 }
 
-// NewUserRepository creates a new instance of UserRepository.
-func NewUserRepository(conn *sql.DB) UserRepository {
+// NewUserRepository creates a new instance of UserRepository. pii selects
+// whether names and emails are encrypted.
+func NewUserRepository(conn *sql.DB, pii PIIConfig) UserRepository {
 	return &sqlcUserRepository{
 		querier: db.New(conn), // db.New(conn) is the typical constructor for sqlc's Queries struct which implements Querier
 		dbConn:  conn,
+		pii:     pii,
 	}
 }
 
@@ -37,6 +71,7 @@ func toDomainUser(sqlcUser db.User) *domain.User {
 	domainUser := &domain.User{
 		ID:        sqlcUser.ID.String(),
 		Password:  "", // Password is not exposed from DB to domain generally
+		Role:      sqlcUser.Role,
 		CreatedAt: sqlcUser.CreatedAt,
 		UpdatedAt: sqlcUser.Updatedat, // Note: sqlc generated 'Updatedat'
 	}
@@ -46,10 +81,74 @@ func toDomainUser(sqlcUser db.User) *domain.User {
 	if sqlcUser.Email.Valid {
 		domainUser.Email = sqlcUser.Email.String
 	}
+	domainUser.AvatarURL = sqlcUser.AvatarUrl.String
+	domainUser.AvatarThumbnailURL = sqlcUser.AvatarThumbnailUrl.String
+	domainUser.Preferences = decodePreferences(sqlcUser.Preferences)
+	domainUser.Attributes = decodeAttributes(sqlcUser.Attributes)
+	domainUser.ErasedAt = sqlcUser.ErasedAt.Time
 	// sqlcUser.Password.String could be assigned if needed, but typically not to domain model
 	return domainUser
 }
 
+// preferencesRecord is how domain.UserPreferences are stored in the
+// preferences column.
+type preferencesRecord struct {
+	Locale        string `json:"locale,omitempty"`
+	Timezone      string `json:"timezone,omitempty"`
+	Notifications struct {
+		Email  bool   `json:"email"`
+		Digest string `json:"digest"`
+	} `json:"notifications"`
+}
+
+func newPreferencesRecord(prefs domain.UserPreferences) preferencesRecord {
+	var rec preferencesRecord
+	rec.Locale = prefs.Locale
+	rec.Timezone = prefs.Timezone
+	rec.Notifications.Email = prefs.Notifications.Email
+	rec.Notifications.Digest = prefs.Notifications.Digest
+	return rec
+}
+
+func (rec preferencesRecord) toDomain() domain.UserPreferences {
+	return domain.UserPreferences{
+		Locale:        rec.Locale,
+		Timezone:      rec.Timezone,
+		Notifications: domain.NotificationPreferences{Email: rec.Notifications.Email, Digest: rec.Notifications.Digest},
+	}
+}
+
+// decodePreferences reads the preferences column. Settings missing from the
+// column, which is NULL until a user changes anything, keep their defaults.
+func decodePreferences(raw json.RawMessage) domain.UserPreferences {
+	rec := newPreferencesRecord(domain.DefaultUserPreferences())
+	if len(raw) > 0 && json.Unmarshal(raw, &rec) != nil {
+		return domain.DefaultUserPreferences()
+	}
+	return rec.toDomain()
+}
+
+func encodePreferences(prefs domain.UserPreferences) (json.RawMessage, error) {
+	return json.Marshal(newPreferencesRecord(prefs))
+}
+
+// decodeAttributes reads the attributes column; NULL reads as no attributes.
+func decodeAttributes(raw json.RawMessage) map[string]interface{} {
+	var attrs map[string]interface{}
+	if len(raw) > 0 && json.Unmarshal(raw, &attrs) == nil {
+		return attrs
+	}
+	return nil
+}
+
+// encodeAttributes stores no attributes as NULL.
+func encodeAttributes(attrs map[string]interface{}) (json.RawMessage, error) {
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(attrs)
+}
+
 // Helper to convert a slice of sqlc.User to a slice of domain.User
 func toDomainUserSlice(sqlcUsers []db.User) []domain.User {
     domainUsers := make([]domain.User, len(sqlcUsers))
@@ -59,20 +158,38 @@ func toDomainUserSlice(sqlcUsers []db.User) []domain.User {
     return domainUsers
 }
 
+// openUsers decrypts the names and emails of rows in place.
+func (r *sqlcUserRepository) openUsers(rows []db.User) error {
+	for i := range rows {
+		if err := r.pii.openUser(&rows[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *sqlcUserRepository) CreateUser(ctx context.Context, user *domain.User, hashedPassword string) (*domain.User, error) {
 	userID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
+	pii, err := r.pii.seal(userID, user.Name, user.Email)
+	if err != nil {
+		return nil, err
+	}
 	params := db.CreateUserParams{
-		ID:       userID,
-		Name:     sql.NullString{String: user.Name, Valid: user.Name != ""},
-		Email:    sql.NullString{String: user.Email, Valid: user.Email != ""},
-		Password: sql.NullString{String: hashedPassword, Valid: hashedPassword != ""},
+		ID:             userID,
+		Name:           pii.Name,
+		Email:          pii.Email,
+		NameEncrypted:  pii.NameEncrypted,
+		EmailEncrypted: pii.EmailEncrypted,
+		EmailIndex:     pii.EmailIndex,
+		PiiKeyVersion:  pii.KeyVersion,
+		Password:       sql.NullString{String: hashedPassword, Valid: hashedPassword != ""},
 	}
 
-	_, err = r.querier.CreateUser(ctx, params)
+	_, err = querierFrom(ctx, r.querier).CreateUser(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -81,43 +198,246 @@ func (r *sqlcUserRepository) CreateUser(ctx context.Context, user *domain.User,
 	return r.GetUserByID(ctx, userID.String())
 }
 
+func (r *sqlcUserRepository) CreateUsers(ctx context.Context, users []domain.User) ([]string, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(users))
+	args := make([]interface{}, 0, len(users)*9)
+	for i, u := range users {
+		userID, err := uuid.NewRandom()
+		if err != nil {
+			return nil, err
+		}
+		role := u.Role
+		if role == "" {
+			role = domain.RoleUser
+		}
+		pii, err := r.pii.seal(userID, u.Name, u.Email)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = userID.String()
+		args = append(args, userID, pii.Name, pii.Email, pii.NameEncrypted, pii.EmailEncrypted, pii.EmailIndex, pii.KeyVersion, u.Password, role)
+	}
+	query := "INSERT INTO Users (id, name, email, name_encrypted, email_encrypted, email_index, pii_key_version, password, role) VALUES " +
+		strings.Repeat(",(?, ?, ?, ?, ?, ?, ?, ?, ?)", len(users))[1:]
+	if _, err := dbtxFrom(ctx, r.dbConn).ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 func (r *sqlcUserRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
 	userID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, err // Invalid UUID format
 	}
 
-	sqlcUser, err := r.querier.GetUserByID(ctx, userID)
+	sqlcUser, err := querierFrom(ctx, r.querier).GetUserByID(ctx, userID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // Or a custom domain.ErrNotFound
 		}
 		return nil, err
 	}
+	if err := r.pii.openUser(&sqlcUser); err != nil {
+		return nil, err
+	}
 	return toDomainUser(sqlcUser), nil
 }
 
-func (r *sqlcUserRepository) ListUsers(ctx context.Context) ([]domain.User, error) {
-	sqlcUsers, err := r.querier.ListUsers(ctx)
+func (r *sqlcUserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	// Encrypted rows are found by the blind index, plaintext ones by email
+	sqlcUser, err := querierFrom(ctx, r.querier).GetUserByEmail(ctx, db.GetUserByEmailParams{
+		EmailIndex: r.pii.emailIndex(email),
+		Email:      sql.NullString{String: email, Valid: true},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := r.pii.openUser(&sqlcUser); err != nil {
+		return nil, err
+	}
+	user := toDomainUser(sqlcUser)
+	user.Password = sqlcUser.Password.String // Needed by the caller to verify credentials
+	return user, nil
+}
+
+func (r *sqlcUserRepository) ListExistingUserEmails(ctx context.Context, emails []string) ([]string, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	var params db.ListExistingUserEmailsParams
+	for _, e := range emails {
+		params.Emails = append(params.Emails, sql.NullString{String: e, Valid: true})
+		if index := r.pii.emailIndex(e); index.Valid {
+			params.EmailIndexes = append(params.EmailIndexes, index)
+		}
+	}
+	rows, err := querierFrom(ctx, r.querier).ListExistingUserEmails(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	existing := make([]string, 0, len(rows))
+	for _, row := range rows {
+		_, email, err := r.pii.open(row.ID, sql.NullString{}, row.Email, nil, row.EmailEncrypted)
+		if err != nil {
+			return nil, err
+		}
+		existing = append(existing, email.String)
+	}
+	return existing, nil
+}
+
+// nullGroupID converts the group filter to a nullable UUID.
+func nullGroupID(groupID string) (uuid.NullUUID, error) {
+	if groupID == "" {
+		return uuid.NullUUID{}, nil
+	}
+	id, err := uuid.Parse(groupID)
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	return uuid.NullUUID{UUID: id, Valid: true}, nil
+}
+
+func (r *sqlcUserRepository) ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	groupID, err := nullGroupID(filter.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	sqlcUsers, err := querierFrom(ctx, r.querier).ListUsers(ctx, db.ListUsersParams{
+		Role:        nullString(filter.Role),
+		CreatedFrom: sql.NullTime{Time: filter.CreatedFrom, Valid: !filter.CreatedFrom.IsZero()},
+		CreatedTo:   sql.NullTime{Time: filter.CreatedTo, Valid: !filter.CreatedTo.IsZero()},
+		GroupID:     groupID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.openUsers(sqlcUsers); err != nil {
+		return nil, err
+	}
+	users := toDomainUserSlice(sqlcUsers)
+	r.pii.sortUsersByName(users)
+	return users, nil
+}
+
+func (r *sqlcUserRepository) ListUsersAfter(ctx context.Context, afterID string, limit int) ([]domain.User, error) {
+	after := uuid.Nil
+	if afterID != "" {
+		var err error
+		if after, err = uuid.Parse(afterID); err != nil {
+			return nil, err // Invalid UUID format
+		}
+	}
+	sqlcUsers, err := querierFrom(ctx, r.querier).ListUsersAfter(ctx, db.ListUsersAfterParams{ID: after, Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.openUsers(sqlcUsers); err != nil {
+		return nil, err
+	}
+	return toDomainUserSlice(sqlcUsers), nil
+}
+
+func (r *sqlcUserRepository) SearchUsers(ctx context.Context, query string, limit, offset int) ([]domain.UserSearchHit, error) {
+	if r.pii.enabled() {
+		return nil, domain.ErrUserSearchUnavailable
+	}
+	rows, err := querierFrom(ctx, r.querier).SearchUsers(ctx, db.SearchUsersParams{Query: query, Limit: int32(limit), Offset: int32(offset)})
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]domain.UserSearchHit, len(rows))
+	for i, row := range rows {
+		user := toDomainUser(db.User{ID: row.ID, Name: row.Name, Email: row.Email, Role: row.Role, CreatedAt: row.CreatedAt, Updatedat: row.Updatedat, AvatarUrl: row.AvatarUrl, AvatarThumbnailUrl: row.AvatarThumbnailUrl, Preferences: row.Preferences, Attributes: row.Attributes})
+		hits[i] = domain.UserSearchHit{User: *user, Relevance: row.Relevance}
+	}
+	return hits, nil
+}
+
+// likeEscaper escapes the LIKE wildcards, using MySQL's default escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *sqlcUserRepository) SearchUsersByEmailPrefix(ctx context.Context, prefix string, limit, offset int) ([]domain.User, error) {
+	if r.pii.enabled() {
+		// Users not yet migrated would still match, hiding the encrypted ones
+		return nil, domain.ErrUserSearchUnavailable
+	}
+	sqlcUsers, err := querierFrom(ctx, r.querier).SearchUsersByEmailPrefix(ctx, db.SearchUsersByEmailPrefixParams{
+		Email:  sql.NullString{String: likeEscaper.Replace(prefix) + "%", Valid: true},
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
 	if err != nil {
 		return nil, err
 	}
+	if err := r.openUsers(sqlcUsers); err != nil {
+		return nil, err
+	}
 	return toDomainUserSlice(sqlcUsers), nil
 }
 
+// streamUsersQuery is written by hand because sqlc collects every row of a
+// :many query into a slice. It applies the same filters as ListUsers.
+const streamUsersQuery = `SELECT id, name, email, name_encrypted, email_encrypted, role, created_at, UpdatedAt FROM Users
+WHERE (? IS NULL OR role = ?)
+  AND (? IS NULL OR created_at >= ?)
+  AND (? IS NULL OR created_at < ?)
+  AND (? IS NULL OR id IN (
+    SELECT user_id FROM user_group_members WHERE group_id = ?
+  ))
+ORDER BY id`
+
+func (r *sqlcUserRepository) StreamUsers(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error {
+	role := nullString(filter.Role)
+	from := sql.NullTime{Time: filter.CreatedFrom, Valid: !filter.CreatedFrom.IsZero()}
+	to := sql.NullTime{Time: filter.CreatedTo, Valid: !filter.CreatedTo.IsZero()}
+	groupID, err := nullGroupID(filter.GroupID)
+	if err != nil {
+		return err
+	}
+	rows, err := dbtxFrom(ctx, r.dbConn).QueryContext(ctx, streamUsersQuery, role, role, from, from, to, to, groupID, groupID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row db.User
+		if err := rows.Scan(&row.ID, &row.Name, &row.Email, &row.NameEncrypted, &row.EmailEncrypted, &row.Role, &row.CreatedAt, &row.Updatedat); err != nil {
+			return err
+		}
+		if err := r.pii.openUser(&row); err != nil {
+			return err
+		}
+		if err := fn(toDomainUser(row)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 func (r *sqlcUserRepository) UpdateUser(ctx context.Context, id string, user *domain.User, hashedPassword *string) (*domain.User, error) {
 	userID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, err // Invalid UUID format
 	}
 
-	currentUser, err := r.querier.GetUserByID(ctx, userID)
+	currentUser, err := querierFrom(ctx, r.querier).GetUserByID(ctx, userID)
     if err != nil {
         if err == sql.ErrNoRows {
             return nil, nil // Or a custom domain.ErrNotFound indicating user to update not found
         }
         return nil, err // Other DB error
     }
+	if err := r.pii.openUser(&currentUser); err != nil {
+		return nil, err
+	}
 
     // Prepare params with current values, then overwrite with new ones if provided
 	params := db.UpdateUserParams{
@@ -137,19 +457,67 @@ func (r *sqlcUserRepository) UpdateUser(ctx context.Context, id string, user *do
 	if hashedPassword != nil {
 		params.Password = sql.NullString{String: *hashedPassword, Valid: *hashedPassword != ""}
 	}
+	// Name and email are rewritten under the current settings even when unchanged
+	pii, err := r.pii.seal(userID, params.Name.String, params.Email.String)
+	if err != nil {
+		return nil, err
+	}
+	params.Name, params.Email = pii.Name, pii.Email
+	params.NameEncrypted, params.EmailEncrypted = pii.NameEncrypted, pii.EmailEncrypted
+	params.EmailIndex, params.PiiKeyVersion = pii.EmailIndex, pii.KeyVersion
+	// Preferences and attributes are always written whole
+	if params.Preferences, err = encodePreferences(user.Preferences); err != nil {
+		return nil, err
+	}
+	if params.Attributes, err = encodeAttributes(user.Attributes); err != nil {
+		return nil, err
+	}
 
-	_, err = r.querier.UpdateUser(ctx, params)
+	_, err = querierFrom(ctx, r.querier).UpdateUser(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 	return r.GetUserByID(ctx, id) // Return the updated user
 }
 
+func (r *sqlcUserRepository) UpdateUserAvatar(ctx context.Context, id, avatarURL, thumbnailURL string) (*domain.User, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	_, err = querierFrom(ctx, r.querier).UpdateUserAvatar(ctx, db.UpdateUserAvatarParams{
+		AvatarUrl:          nullString(avatarURL),
+		AvatarThumbnailUrl: nullString(thumbnailURL),
+		ID:                 userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetUserByID(ctx, id)
+}
+
+func (r *sqlcUserRepository) UpdateUserPasswordHash(ctx context.Context, id, oldHash, newHash string) (bool, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+	res, err := querierFrom(ctx, r.querier).UpdateUserPassword(ctx, db.UpdateUserPasswordParams{
+		NewPassword: sql.NullString{String: newHash, Valid: true},
+		ID:          userID,
+		OldPassword: sql.NullString{String: oldHash, Valid: true},
+	})
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
 func (r *sqlcUserRepository) DeleteUser(ctx context.Context, id string) error {
 	userID, err := uuid.Parse(id)
 	if err != nil {
 		return err // Invalid UUID format
 	}
-	_, err = r.querier.DeleteUser(ctx, userID)
+	_, err = querierFrom(ctx, r.querier).DeleteUser(ctx, userID)
 	return err
 }
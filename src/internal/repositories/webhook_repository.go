@@ -0,0 +1,261 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	db "apiserver/internal/db/sqlc"
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// WebhookRepository defines the interface for webhook subscriptions and their
+// delivery queue. Methods used by the background workers join the transaction
+// in ctx, if any.
+type WebhookRepository interface {
+	CreateWebhookSubscription(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) // Returns nil, nil when not found
+	ListWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	ListActiveWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, id string) error
+
+	// EnqueueWebhookDelivery queues an event for a subscription. Enqueuing the
+	// same event twice for a subscription is a no-op.
+	EnqueueWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetWebhookDelivery(ctx context.Context, id int64) (*domain.WebhookDelivery, error) // Returns nil, nil when not found
+	// ListWebhookDeliveries returns a subscription's deliveries, newest first,
+	// older than beforeID when it is positive.
+	ListWebhookDeliveries(ctx context.Context, subscriptionID string, beforeID int64, limit int) ([]domain.WebhookDelivery, error)
+	// ClaimDueWebhookDeliveries takes up to limit pending deliveries that are
+	// due at now and leases them until leaseUntil by moving their next attempt,
+	// so other workers skip them once the transaction in ctx commits. It must
+	// run in a transaction, which only needs to last as long as the claim.
+	ClaimDueWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error)
+	// RecordWebhookDeliveryAttempt stores the status, attempt count and last
+	// attempt details of delivery. It is a no-op once the delivery is no
+	// longer pending, e.g. when a worker that claimed it after the lease ran
+	// out has delivered it since.
+	RecordWebhookDeliveryAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error
+	// ResetWebhookDelivery makes a delivery pending again with a fresh retry budget.
+	ResetWebhookDelivery(ctx context.Context, id int64, nextAttemptAt time.Time) error
+}
+
+// sqlcWebhookRepository implements WebhookRepository using sqlc generated code.
+type sqlcWebhookRepository struct {
+	querier db.Querier
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository.
+func NewWebhookRepository(conn *sql.DB) WebhookRepository {
+	return &sqlcWebhookRepository{querier: db.New(conn)}
+}
+
+// toDomainWebhookSubscription converts a sqlc row to domain.WebhookSubscription.
+// Event types are stored space separated.
+func toDomainWebhookSubscription(s db.WebhookSubscription) *domain.WebhookSubscription {
+	return &domain.WebhookSubscription{
+		ID:               s.ID.String(),
+		URL:              s.Url,
+		EventTypes:       strings.Fields(s.EventTypes),
+		Description:      s.Description,
+		SecretCiphertext: s.SecretCiphertext,
+		Active:           s.Active,
+		CreatedAt:        s.CreatedAt,
+		UpdatedAt:        s.UpdatedAt,
+	}
+}
+
+func toDomainWebhookDelivery(d db.WebhookDelivery) *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID.String(),
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       int(d.Attempts),
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt.Time,
+		LastStatusCode: int(d.LastStatusCode.Int32),
+		LastError:      d.LastError.String,
+		DeliveredAt:    d.DeliveredAt.Time,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+func (r *sqlcWebhookRepository) CreateWebhookSubscription(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	_, err = querierFrom(ctx, r.querier).CreateWebhookSubscription(ctx, db.CreateWebhookSubscriptionParams{
+		ID:               id,
+		Url:              sub.URL,
+		EventTypes:       strings.Join(sub.EventTypes, " "),
+		Description:      sub.Description,
+		SecretCiphertext: sub.SecretCiphertext,
+		Active:           sub.Active,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetWebhookSubscription(ctx, id.String())
+}
+
+func (r *sqlcWebhookRepository) GetWebhookSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	subID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	s, err := querierFrom(ctx, r.querier).GetWebhookSubscription(ctx, subID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainWebhookSubscription(s), nil
+}
+
+func (r *sqlcWebhookRepository) ListWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	rows, err := r.querier.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subs := make([]domain.WebhookSubscription, len(rows))
+	for i, s := range rows {
+		subs[i] = *toDomainWebhookSubscription(s)
+	}
+	return subs, nil
+}
+
+func (r *sqlcWebhookRepository) ListActiveWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	rows, err := querierFrom(ctx, r.querier).ListActiveWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subs := make([]domain.WebhookSubscription, len(rows))
+	for i, s := range rows {
+		subs[i] = *toDomainWebhookSubscription(s)
+	}
+	return subs, nil
+}
+
+func (r *sqlcWebhookRepository) UpdateWebhookSubscription(ctx context.Context, sub *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	id, err := uuid.Parse(sub.ID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = querierFrom(ctx, r.querier).UpdateWebhookSubscription(ctx, db.UpdateWebhookSubscriptionParams{
+		Url:              sub.URL,
+		EventTypes:       strings.Join(sub.EventTypes, " "),
+		Description:      sub.Description,
+		SecretCiphertext: sub.SecretCiphertext,
+		Active:           sub.Active,
+		ID:               id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetWebhookSubscription(ctx, sub.ID)
+}
+
+func (r *sqlcWebhookRepository) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	subID, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+	_, err = querierFrom(ctx, r.querier).DeleteWebhookSubscription(ctx, subID)
+	return err
+}
+
+func (r *sqlcWebhookRepository) EnqueueWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	subID, err := uuid.Parse(delivery.SubscriptionID)
+	if err != nil {
+		return err
+	}
+	_, err = querierFrom(ctx, r.querier).CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+		SubscriptionID: subID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		CreatedAt:      delivery.CreatedAt,
+	})
+	return err
+}
+
+func (r *sqlcWebhookRepository) GetWebhookDelivery(ctx context.Context, id int64) (*domain.WebhookDelivery, error) {
+	d, err := querierFrom(ctx, r.querier).GetWebhookDelivery(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainWebhookDelivery(d), nil
+}
+
+func (r *sqlcWebhookRepository) ListWebhookDeliveries(ctx context.Context, subscriptionID string, beforeID int64, limit int) ([]domain.WebhookDelivery, error) {
+	subID, err := uuid.Parse(subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.querier.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		SubscriptionID: subID,
+		BeforeID:       sql.NullInt64{Int64: beforeID, Valid: beforeID > 0},
+		Limit:          int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	deliveries := make([]domain.WebhookDelivery, len(rows))
+	for i, d := range rows {
+		deliveries[i] = *toDomainWebhookDelivery(d)
+	}
+	return deliveries, nil
+}
+
+func (r *sqlcWebhookRepository) ClaimDueWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	q := querierFrom(ctx, r.querier)
+	rows, err := q.ListDueWebhookDeliveries(ctx, db.ListDueWebhookDeliveriesParams{
+		NextAttemptAt: now,
+		Limit:         int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	deliveries := make([]domain.WebhookDelivery, len(rows))
+	for i, d := range rows {
+		if err := q.LeaseWebhookDelivery(ctx, db.LeaseWebhookDeliveryParams{NextAttemptAt: leaseUntil, ID: d.ID}); err != nil {
+			return nil, err
+		}
+		deliveries[i] = *toDomainWebhookDelivery(d)
+	}
+	return deliveries, nil
+}
+
+func (r *sqlcWebhookRepository) RecordWebhookDeliveryAttempt(ctx context.Context, d *domain.WebhookDelivery) error {
+	_, err := querierFrom(ctx, r.querier).RecordWebhookDeliveryAttempt(ctx, db.RecordWebhookDeliveryAttemptParams{
+		Status:         d.Status,
+		Attempts:       int32(d.Attempts),
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  sql.NullTime{Time: d.LastAttemptAt, Valid: !d.LastAttemptAt.IsZero()},
+		LastStatusCode: sql.NullInt32{Int32: int32(d.LastStatusCode), Valid: d.LastStatusCode != 0},
+		LastError:      nullString(truncateError(d.LastError)),
+		DeliveredAt:    sql.NullTime{Time: d.DeliveredAt, Valid: !d.DeliveredAt.IsZero()},
+		ID:             d.ID,
+	})
+	return err
+}
+
+func (r *sqlcWebhookRepository) ResetWebhookDelivery(ctx context.Context, id int64, nextAttemptAt time.Time) error {
+	_, err := querierFrom(ctx, r.querier).ResetWebhookDelivery(ctx, db.ResetWebhookDeliveryParams{
+		NextAttemptAt: nextAttemptAt,
+		ID:            id,
+	})
+	return err
+}
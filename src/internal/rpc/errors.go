@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"apiserver/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps domain errors to gRPC status errors, like toHTTPError does for
+// the HTTP API. Anything unrecognised becomes Internal.
+func toStatus(err error) error {
+	var lockedErr *domain.LockedError
+	switch {
+	case errors.As(err, &lockedErr):
+		return status.Error(codes.ResourceExhausted, "Too many failed login attempts")
+	case errors.Is(err, domain.ErrInvalidCredentials), errors.Is(err, domain.ErrUnauthenticated), errors.Is(err, domain.ErrInvalidMFACode):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrMFARequired), errors.Is(err, domain.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
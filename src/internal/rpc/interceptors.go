@@ -0,0 +1,158 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/auth"
+	"apiserver/internal/domain"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys read from and written to calls.
+const (
+	metadataAuthorization = "authorization"
+	metadataAPIKey        = "x-api-key"
+	metadataRequestID     = "x-request-id"
+)
+
+// APIKeyAuthenticator resolves API keys to principals. It is satisfied by usecases.APIKeyInteractor.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, rawKey string) (*auth.Principal, error)
+}
+
+// interceptor does for gRPC calls what the Echo middleware does for HTTP
+// requests: recover from panics, log the call, store the request information
+// used by audit events and resolve the caller.
+type interceptor struct {
+	tokens  auth.TokenService
+	apiKeys APIKeyAuthenticator
+	logger  *log.Logger
+}
+
+func (i *interceptor) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer i.observe(info.FullMethod, time.Now(), &err)
+	if ctx, err = i.prepare(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (i *interceptor) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer i.observe(info.FullMethod, time.Now(), &err)
+	ctx, err := i.prepare(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// observe turns a panic into an Internal error and logs the outcome of the
+// call. It must be deferred.
+func (i *interceptor) observe(method string, start time.Time, err *error) {
+	if r := recover(); r != nil {
+		i.logger.Printf("grpc: panic in %s: %v", method, r)
+		*err = status.Error(codes.Internal, "internal error")
+	}
+	i.logger.Printf("grpc: %s %s %s", method, status.Code(*err), time.Since(start))
+}
+
+// prepare stores the request information and the caller in ctx.
+func (i *interceptor) prepare(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := first(md, metadataRequestID)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	// Echo the ID like middleware.RequestID does; a failure here is not worth failing the call
+	_ = grpc.SetHeader(ctx, metadata.Pairs(metadataRequestID, requestID))
+	info := audit.RequestInfo{RequestID: requestID}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		info.IP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(info.IP); err == nil {
+			info.IP = host
+		}
+	}
+	ctx = audit.WithRequestInfo(ctx, info)
+
+	principal, err := i.authenticate(ctx, first(md, metadataAuthorization), first(md, metadataAPIKey))
+	if err != nil {
+		return nil, err
+	}
+	if principal != nil {
+		ctx = auth.WithPrincipal(ctx, principal)
+	}
+	return ctx, nil
+}
+
+// authenticate resolves the caller from a bearer token (a JWT or an API key)
+// or an API key. Calls without credentials continue anonymously; operations
+// that need a caller enforce that themselves.
+func (i *interceptor) authenticate(ctx context.Context, header, apiKey string) (*auth.Principal, error) {
+	if header == "" && apiKey == "" {
+		return nil, nil
+	}
+	if header != "" && apiKey != "" {
+		return nil, status.Error(codes.InvalidArgument, "Send either authorization or x-api-key metadata, not both")
+	}
+	if apiKey != "" {
+		return i.authenticateAPIKey(ctx, apiKey)
+	}
+
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "Invalid authorization metadata")
+	}
+	if usecases.IsAPIKey(token) {
+		return i.authenticateAPIKey(ctx, token)
+	}
+	principal, err := i.tokens.Verify(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "Invalid or expired token")
+	}
+	return principal, nil
+}
+
+func (i *interceptor) authenticateAPIKey(ctx context.Context, key string) (*auth.Principal, error) {
+	if i.apiKeys == nil {
+		return nil, status.Error(codes.Unauthenticated, "API keys are not accepted")
+	}
+	principal, err := i.apiKeys.AuthenticateAPIKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			return nil, status.Error(codes.Unauthenticated, "Invalid, expired or revoked API key")
+		}
+		return nil, status.Error(codes.Internal, "Failed to verify API key: "+err.Error())
+	}
+	return principal, nil
+}
+
+// first returns the first value of key in md, or "".
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"log"
+
+	"apiserver/internal/auth"
+	usersv1 "apiserver/internal/generated/grpc/users/v1"
+	"apiserver/internal/usecases"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewServer creates a gRPC server exposing the user service together with the
+// standard health and reflection services.
+func NewServer(users usecases.UserInteractor, tokens auth.TokenService, apiKeys APIKeyAuthenticator, logger *log.Logger) *grpc.Server {
+	i := &interceptor{tokens: tokens, apiKeys: apiKeys, logger: logger}
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(i.unary),
+		grpc.StreamInterceptor(i.stream),
+	)
+	usersv1.RegisterUserServiceServer(srv, NewUserService(users))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(usersv1.UserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthServer)
+	reflection.Register(srv)
+	return srv
+}
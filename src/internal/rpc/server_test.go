@@ -0,0 +1,245 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"testing"
+	"time"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	usersv1 "apiserver/internal/generated/grpc/users/v1"
+	"apiserver/internal/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testUserID = "3f0e9a4c-1b2d-4c5e-8f70-9a1b2c3d4e5f"
+
+type stubAPIKeys struct {
+	keys map[string]*auth.Principal
+}
+
+func (s *stubAPIKeys) AuthenticateAPIKey(ctx context.Context, rawKey string) (*auth.Principal, error) {
+	if p, ok := s.keys[rawKey]; ok {
+		return p, nil
+	}
+	return nil, domain.ErrUnauthenticated
+}
+
+type testEnv struct {
+	users  *mocks.MockUserInteractor
+	tokens auth.TokenService
+	client usersv1.UserServiceClient
+	conn   *grpc.ClientConn
+}
+
+func setupTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	env := &testEnv{
+		users:  new(mocks.MockUserInteractor),
+		tokens: auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real()),
+	}
+	apiKeys := &stubAPIKeys{keys: map[string]*auth.Principal{
+		"ak_test": {UserID: testUserID, Role: domain.RoleUser, APIKeyID: "key-1", Scopes: []string{"users:read"}},
+	}}
+
+	lis := bufconn.Listen(1 << 20)
+	srv := NewServer(env.users, env.tokens, apiKeys, log.New(io.Discard, "", 0))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	env.conn = conn
+	env.client = usersv1.NewUserServiceClient(conn)
+	return env
+}
+
+func testUser() *domain.User {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &domain.User{ID: testUserID, Name: "Alice", Email: "alice@example.com", Role: domain.RoleUser, CreatedAt: now, UpdatedAt: now}
+}
+
+func TestCreateUser(t *testing.T) {
+	env := setupTestEnv(t)
+	env.users.On("CreateNewUser", mock.Anything, "Alice", "alice@example.com", "secret123").Return(testUser(), nil)
+
+	resp, err := env.client.CreateUser(context.Background(), &usersv1.CreateUserRequest{Name: "Alice", Email: "alice@example.com", Password: "secret123"})
+
+	require.NoError(t, err)
+	assert.Equal(t, testUserID, resp.GetUser().GetId())
+	assert.Equal(t, "alice@example.com", resp.GetUser().GetEmail())
+	assert.Equal(t, testUser().CreatedAt, resp.GetUser().GetCreatedAt().AsTime())
+}
+
+func TestGetUser_NotFound(t *testing.T) {
+	env := setupTestEnv(t)
+	env.users.On("FindUserByID", mock.Anything, testUserID).Return(nil, nil)
+
+	_, err := env.client.GetUser(context.Background(), &usersv1.GetUserRequest{Id: testUserID})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGetUser_InvalidID(t *testing.T) {
+	env := setupTestEnv(t)
+
+	_, err := env.client.GetUser(context.Background(), &usersv1.GetUserRequest{Id: "not-a-uuid"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	env.users.AssertNotCalled(t, "FindUserByID", mock.Anything, mock.Anything)
+}
+
+func TestListUsers_Streams(t *testing.T) {
+	env := setupTestEnv(t)
+	second := *testUser()
+	second.ID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	env.users.On("ListUsersPage", mock.Anything, "", listUsersPageSize).Return(&domain.UserPage{Users: []domain.User{*testUser()}, HasMore: true}, nil).Once()
+	env.users.On("ListUsersPage", mock.Anything, testUserID, listUsersPageSize).Return(&domain.UserPage{Users: []domain.User{second}}, nil).Once()
+
+	stream, err := env.client.ListUsers(context.Background(), &usersv1.ListUsersRequest{})
+	require.NoError(t, err)
+	var ids []string
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		ids = append(ids, resp.GetUser().GetId())
+	}
+
+	assert.Equal(t, []string{testUserID, second.ID}, ids)
+	env.users.AssertExpectations(t)
+}
+
+func TestUpdateUser_PassesOptionalFields(t *testing.T) {
+	env := setupTestEnv(t)
+	name := "Bob"
+	env.users.On("UpdateExistingUser", mock.Anything, testUserID, &name, (*string)(nil), (*string)(nil)).Return(testUser(), nil)
+
+	_, err := env.client.UpdateUser(context.Background(), &usersv1.UpdateUserRequest{Id: testUserID, Name: &name})
+
+	require.NoError(t, err)
+	env.users.AssertExpectations(t)
+}
+
+func TestDeleteUser_MapsDomainErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"unauthenticated", domain.ErrUnauthenticated, codes.Unauthenticated},
+		{"forbidden", domain.ErrForbidden, codes.PermissionDenied},
+		{"mfa required", domain.ErrMFARequired, codes.PermissionDenied},
+		{"not found", domain.ErrNotFound, codes.NotFound},
+		{"invalid argument", domain.ErrInvalidArgument, codes.InvalidArgument},
+		{"conflict", domain.ErrConflict, codes.FailedPrecondition},
+		{"unknown", errors.New("db down"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := setupTestEnv(t)
+			env.users.On("RemoveUser", mock.Anything, testUserID).Return(tt.err)
+
+			_, err := env.client.DeleteUser(context.Background(), &usersv1.DeleteUserRequest{Id: testUserID})
+
+			assert.Equal(t, tt.want, status.Code(err))
+		})
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	token, _, err := auth.NewJWTTokenService([]byte("test-secret"), time.Hour, clock.Real()).Issue(testUser(), false)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		md            metadata.MD
+		wantCode      codes.Code
+		wantPrincipal *auth.Principal
+	}{
+		{"anonymous", metadata.MD{}, codes.OK, nil},
+		{"bearer token", metadata.Pairs("authorization", "Bearer "+token), codes.OK, &auth.Principal{UserID: testUserID, Role: domain.RoleUser}},
+		{"api key header", metadata.Pairs("x-api-key", "ak_test"), codes.OK, &auth.Principal{UserID: testUserID, APIKeyID: "key-1"}},
+		{"invalid token", metadata.Pairs("authorization", "Bearer nope"), codes.Unauthenticated, nil},
+		{"malformed authorization", metadata.Pairs("authorization", token), codes.Unauthenticated, nil},
+		{"unknown api key", metadata.Pairs("x-api-key", "ak_unknown"), codes.Unauthenticated, nil},
+		{"both credentials", metadata.Pairs("authorization", "Bearer "+token, "x-api-key", "ak_test"), codes.InvalidArgument, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := setupTestEnv(t)
+			var got *auth.Principal
+			var info audit.RequestInfo
+			env.users.On("FindUserByID", mock.Anything, testUserID).Run(func(args mock.Arguments) {
+				ctx := args.Get(0).(context.Context)
+				got = auth.PrincipalFrom(ctx)
+				info = audit.RequestInfoFrom(ctx)
+			}).Return(testUser(), nil).Maybe()
+
+			var header metadata.MD
+			ctx := metadata.NewOutgoingContext(context.Background(), tt.md)
+			_, err := env.client.GetUser(ctx, &usersv1.GetUserRequest{Id: testUserID}, grpc.Header(&header))
+
+			require.Equal(t, tt.wantCode, status.Code(err), err)
+			if tt.wantCode != codes.OK {
+				env.users.AssertNotCalled(t, "FindUserByID", mock.Anything, mock.Anything)
+				return
+			}
+			if tt.wantPrincipal == nil {
+				assert.Nil(t, got)
+			} else {
+				require.NotNil(t, got)
+				assert.Equal(t, tt.wantPrincipal.UserID, got.UserID)
+				assert.Equal(t, tt.wantPrincipal.APIKeyID, got.APIKeyID)
+			}
+			assert.NotEmpty(t, info.RequestID)
+			assert.Equal(t, []string{info.RequestID}, header.Get("x-request-id"))
+		})
+	}
+}
+
+func TestRequestIDIsPropagated(t *testing.T) {
+	env := setupTestEnv(t)
+	var info audit.RequestInfo
+	env.users.On("FindUserByID", mock.Anything, testUserID).Run(func(args mock.Arguments) {
+		info = audit.RequestInfoFrom(args.Get(0).(context.Context))
+	}).Return(testUser(), nil)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-123")
+	_, err := env.client.GetUser(ctx, &usersv1.GetUserRequest{Id: testUserID})
+
+	require.NoError(t, err)
+	assert.Equal(t, "req-123", info.RequestID)
+}
+
+func TestHealth(t *testing.T) {
+	env := setupTestEnv(t)
+	client := healthpb.NewHealthClient(env.conn)
+
+	for _, service := range []string{"", usersv1.UserService_ServiceDesc.ServiceName} {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	}
+}
@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	usersv1 "apiserver/internal/generated/grpc/users/v1"
+	"apiserver/internal/usecases"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// listUsersPageSize is the number of users ListUsers reads at a time.
+const listUsersPageSize = 100
+
+// UserService implements usersv1.UserServiceServer on top of
+// usecases.UserInteractor, so gRPC callers get the same authorization, audit
+// events and domain events as HTTP callers.
+type UserService struct {
+	usersv1.UnimplementedUserServiceServer
+	userInteractor usecases.UserInteractor
+}
+
+// NewUserService creates a new UserService.
+func NewUserService(uc usecases.UserInteractor) *UserService {
+	return &UserService{userInteractor: uc}
+}
+
+// toProtoUser maps domain.User to usersv1.User. The password hash is never exposed.
+func toProtoUser(u *domain.User) *usersv1.User {
+	return &usersv1.User{
+		Id:        u.ID,
+		Name:      u.Name,
+		Email:     u.Email,
+		Role:      u.Role,
+		CreatedAt: timestamppb.New(u.CreatedAt),
+		UpdatedAt: timestamppb.New(u.UpdatedAt),
+	}
+}
+
+// validateUserID rejects IDs that are not UUIDs, as the HTTP router does.
+func validateUserID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return status.Error(codes.InvalidArgument, "id must be a UUID")
+	}
+	return nil
+}
+
+func (s *UserService) CreateUser(ctx context.Context, req *usersv1.CreateUserRequest) (*usersv1.CreateUserResponse, error) {
+	user, err := s.userInteractor.CreateNewUser(ctx, req.GetName(), req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &usersv1.CreateUserResponse{User: toProtoUser(user)}, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, req *usersv1.GetUserRequest) (*usersv1.GetUserResponse, error) {
+	if err := validateUserID(req.GetId()); err != nil {
+		return nil, err
+	}
+	user, err := s.userInteractor.FindUserByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+	return &usersv1.GetUserResponse{User: toProtoUser(user)}, nil
+}
+
+// ListUsers streams the directory a page at a time, so memory stays flat
+// however many users there are.
+func (s *UserService) ListUsers(req *usersv1.ListUsersRequest, stream usersv1.UserService_ListUsersServer) error {
+	afterID := ""
+	for {
+		page, err := s.userInteractor.ListUsersPage(stream.Context(), afterID, listUsersPageSize)
+		if err != nil {
+			return toStatus(err)
+		}
+		for i := range page.Users {
+			if err := stream.Send(&usersv1.ListUsersResponse{User: toProtoUser(&page.Users[i])}); err != nil {
+				return err
+			}
+		}
+		if !page.HasMore || len(page.Users) == 0 {
+			return nil
+		}
+		afterID = page.Users[len(page.Users)-1].ID
+	}
+}
+
+func (s *UserService) UpdateUser(ctx context.Context, req *usersv1.UpdateUserRequest) (*usersv1.UpdateUserResponse, error) {
+	if err := validateUserID(req.GetId()); err != nil {
+		return nil, err
+	}
+	user, err := s.userInteractor.UpdateExistingUser(ctx, req.GetId(), req.Name, req.Email, req.Password)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &usersv1.UpdateUserResponse{User: toProtoUser(user)}, nil
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, req *usersv1.DeleteUserRequest) (*usersv1.DeleteUserResponse, error) {
+	if err := validateUserID(req.GetId()); err != nil {
+		return nil, err
+	}
+	if err := s.userInteractor.RemoveUser(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &usersv1.DeleteUserResponse{}, nil
+}
@@ -0,0 +1,213 @@
+package scheduler
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression. It is evaluated in UTC, so that every
+// instance agrees on when a job is due.
+type Schedule struct {
+	spec                          string
+	minute, hour, dom, month, dow uint64 // Bit n is set when value n matches
+	domStar, dowStar              bool   // The field was "*", which changes how dom and dow combine
+}
+
+// field describes one of the five fields of a cron expression.
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Both 0 and 7 are Sunday
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// descriptors are the shorthands accepted in place of five fields.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses a standard five-field cron expression (minute, hour,
+// day of month, month, day of week) or one of the @daily style shorthands.
+// Fields accept *, numbers, names of months and weekdays, ranges, lists and
+// steps such as */15 or 1-5. As in cron, a job whose day of month and day of
+// week are both restricted runs when either matches.
+func ParseSchedule(spec string) (*Schedule, error) {
+	expr := strings.TrimSpace(spec)
+	if d, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = d
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields", spec)
+	}
+	s := &Schedule{spec: spec}
+	var err error
+	if s.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if s.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if s.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// MustParseSchedule is like ParseSchedule but panics on an invalid expression.
+func MustParseSchedule(spec string) *Schedule {
+	s, err := ParseSchedule(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// parse returns the bit set of the values matched by a comma-separated list.
+func (f field) parse(expr string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(expr, ",") {
+		matched, err := f.parseRange(part)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q: %w", f.name, expr, err)
+		}
+		set |= matched
+	}
+	return set, nil
+}
+
+// parseRange parses one list element: *, a value or a range, with an
+// optional step.
+func (f field) parseRange(expr string) (uint64, error) {
+	rangeExpr, stepExpr, hasStep := strings.Cut(expr, "/")
+	step := 1
+	if hasStep {
+		var err error
+		if step, err = strconv.Atoi(stepExpr); err != nil || step < 1 {
+			return 0, fmt.Errorf("step must be a positive number")
+		}
+	}
+
+	lo, hi := f.min, f.max
+	if rangeExpr != "*" {
+		loExpr, hiExpr, isRange := strings.Cut(rangeExpr, "-")
+		var err error
+		if lo, err = f.value(loExpr); err != nil {
+			return 0, err
+		}
+		hi = lo
+		if isRange {
+			if hi, err = f.value(hiExpr); err != nil {
+				return 0, err
+			}
+		} else if hasStep {
+			// "5/15" means from 5 to the end in steps of 15
+			hi = f.max
+		}
+		if lo > hi {
+			return 0, fmt.Errorf("range %d-%d is reversed", lo, hi)
+		}
+	}
+
+	var set uint64
+	for v := lo; v <= hi; v += step {
+		set |= 1 << v
+	}
+	return set, nil
+}
+
+// value parses a number or name within the bounds of the field.
+func (f field) value(expr string) (int, error) {
+	if v, ok := f.names[strings.ToLower(expr)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", expr)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%d is outside %d-%d", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// maxSearchYears bounds the search for the next match. Expressions such as
+// "0 0 30 2 *" never match.
+const maxSearchYears = 5
+
+// Next returns the first time after t, to the minute, that matches the
+// schedule, or the zero time when there is none.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			// Jump straight to the next matching minute within the hour
+			rest := s.minute >> uint(t.Minute())
+			if rest == 0 {
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+			} else {
+				t = t.Add(time.Duration(bits.TrailingZeros64(rest)) * time.Minute)
+			}
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches applies the cron rule for combining day of month and day of
+// week: when both are restricted, either may match.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	// A Wednesday
+	from := time.Date(2026, 10, 21, 10, 17, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 21, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 21, 10, 30, 0, 0, time.UTC)},
+		{"5 * * * *", time.Date(2026, 10, 21, 11, 5, 0, 0, time.UTC)},
+		{"30 3 * * *", time.Date(2026, 10, 22, 3, 30, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 10, 21, 11, 0, 0, 0, time.UTC)},
+		{"0 0 * * sun", time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2026, 10, 22, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan,jul *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"10-20/5 10 * * *", time.Date(2026, 10, 21, 10, 20, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2026, 10, 21, 10, 25, 0, 0, time.UTC)},
+		// Day of month and day of week both restricted: either matches
+		{"0 0 1 * fri", time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := ParseSchedule(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(from))
+		})
+	}
+}
+
+func TestSchedule_NextIsUTC(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	s := MustParseSchedule("0 3 * * *")
+
+	// 10:00 in Tokyo is 01:00 UTC
+	got := s.Next(time.Date(2026, 10, 21, 10, 0, 0, 0, tokyo))
+
+	assert.Equal(t, time.Date(2026, 10, 21, 3, 0, 0, 0, time.UTC), got)
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"a * * * *",
+		"@every 5m",
+	} {
+		_, err := ParseSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,239 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+)
+
+// lockPrefix namespaces the advisory locks taken for jobs.
+const lockPrefix = "apiserver.job."
+
+// maxWait bounds how long the scheduler sleeps, so that it notices when the
+// wall clock jumps.
+const maxWait = time.Minute
+
+// lockRetryInterval is how often RunExclusive tries again for a job's lock
+// that another instance holds.
+const lockRetryInterval = time.Second
+
+// Locker grants locks shared by every instance. It is satisfied by
+// repositories.AdvisoryLocker.
+type Locker interface {
+	// TryLock takes the lock name without waiting. acquired is false when
+	// another holder has it; otherwise release must be called to give it up.
+	TryLock(ctx context.Context, name string) (release func(), acquired bool, err error)
+}
+
+// RunStore records job runs. It is satisfied by repositories.JobRunRepository.
+type RunStore interface {
+	// StartJobRun stores a new run and reports false, storing nothing, when
+	// the job already ran for the same scheduled time.
+	StartJobRun(ctx context.Context, run *domain.JobRun) (bool, error)
+	FinishJobRun(ctx context.Context, run *domain.JobRun) error
+}
+
+// JobFunc does the work of a job and returns counts to record with the run,
+// such as how many rows it deleted. Counts are recorded even on error.
+type JobFunc func(ctx context.Context) (map[string]int64, error)
+
+type job struct {
+	name     string
+	schedule *Schedule
+	run      JobFunc
+	next     time.Time
+	running  bool // On this instance
+}
+
+// Scheduler runs jobs on cron schedules. Every instance of the server runs a
+// scheduler with the same jobs, and each scheduled run happens once: the
+// instance that takes the job's lock first records the run, and the others
+// skip it. A run that takes longer than the interval makes the next one be
+// skipped rather than overlap.
+type Scheduler struct {
+	locker   Locker
+	store    RunStore
+	clock    clock.Clock
+	instance string
+	logger   *log.Logger
+
+	mu   sync.Mutex
+	jobs []*job
+	wg   sync.WaitGroup
+}
+
+// New creates a Scheduler. instance identifies this server in the recorded
+// runs, typically by host name.
+func New(locker Locker, store RunStore, clk clock.Clock, instance string, logger *log.Logger) *Scheduler {
+	return &Scheduler{locker: locker, store: store, clock: clk, instance: instance, logger: logger}
+}
+
+// Add registers a job. Names must be unique and at most 50 characters.
+func (s *Scheduler) Add(name string, schedule *Schedule, run JobFunc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if name == "" || len(name) > 50 {
+		return fmt.Errorf("job name %q must be 1 to 50 characters", name)
+	}
+	for _, j := range s.jobs {
+		if j.name == name {
+			return fmt.Errorf("job %q is already registered", name)
+		}
+	}
+	s.jobs = append(s.jobs, &job{name: name, schedule: schedule, run: run, next: schedule.Next(s.clock.Now())})
+	return nil
+}
+
+// Jobs returns the registered jobs by name, with when each runs next.
+func (s *Scheduler) Jobs() []domain.ScheduledJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]domain.ScheduledJob, len(s.jobs))
+	for i, j := range s.jobs {
+		out[i] = domain.ScheduledJob{Name: j.name, Schedule: j.schedule.String(), NextRunAt: j.next}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Run starts jobs as they become due until ctx is cancelled, then waits for
+// the running ones to return.
+func (s *Scheduler) Run(ctx context.Context) {
+	defer s.wg.Wait()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		timer.Reset(s.dispatch(ctx))
+	}
+}
+
+// RunExclusive calls fn while holding the lock of the job name, waiting for
+// any instance running that job to finish, so that fn and the job never run
+// at the same time on different instances. Nothing is recorded, as fn is not
+// a scheduled run. It suits work that must happen once at startup.
+func (s *Scheduler) RunExclusive(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	for {
+		release, acquired, err := s.locker.TryLock(ctx, lockPrefix+name)
+		if err != nil {
+			return err
+		}
+		if acquired {
+			defer release()
+			return fn(ctx)
+		}
+		timer := time.NewTimer(lockRetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// dispatch starts the jobs that are due and returns how long to wait for the
+// next one.
+func (s *Scheduler) dispatch(ctx context.Context) time.Duration {
+	now := s.clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wait := maxWait
+	for _, j := range s.jobs {
+		if j.next.IsZero() {
+			continue // The schedule never matches
+		}
+		if !j.next.After(now) {
+			scheduledAt := j.next
+			j.next = j.schedule.Next(now)
+			if j.running {
+				s.logger.Printf("scheduler: skipping %s at %s, the previous run is still going", j.name, scheduledAt.Format(time.RFC3339))
+			} else {
+				j.running = true
+				s.wg.Add(1)
+				go s.runJob(ctx, j, scheduledAt)
+			}
+		}
+		if d := j.next.Sub(now); !j.next.IsZero() && d < wait {
+			wait = d
+		}
+	}
+	return wait
+}
+
+// runJob executes j for scheduledAt in the background.
+func (s *Scheduler) runJob(ctx context.Context, j *job, scheduledAt time.Time) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		j.running = false
+		s.mu.Unlock()
+	}()
+	run, err := s.execute(ctx, j, scheduledAt)
+	switch {
+	case err != nil:
+		s.logger.Printf("scheduler: %s: %v", j.name, err)
+	case run != nil && run.Status == domain.JobRunFailed:
+		s.logger.Printf("scheduler: %s failed: %s", j.name, run.Error)
+	}
+}
+
+// execute runs j for scheduledAt unless another instance holds the job or
+// already ran it for that time, in which case it returns nil.
+func (s *Scheduler) execute(ctx context.Context, j *job, scheduledAt time.Time) (*domain.JobRun, error) {
+	release, acquired, err := s.locker.TryLock(ctx, lockPrefix+j.name)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, nil
+	}
+	defer release()
+
+	run := &domain.JobRun{
+		JobName:     j.name,
+		ScheduledAt: scheduledAt,
+		Instance:    s.instance,
+		Status:      domain.JobRunRunning,
+		StartedAt:   s.clock.Now(),
+	}
+	started, err := s.store.StartJobRun(ctx, run)
+	if err != nil || !started {
+		return nil, err
+	}
+
+	result, err := call(ctx, j.run)
+	run.Result = result
+	run.FinishedAt = s.clock.Now()
+	run.Status = domain.JobRunSucceeded
+	if err != nil {
+		run.Status = domain.JobRunFailed
+		run.Error = err.Error()
+	}
+	// Record the outcome even when the job stopped because ctx was cancelled
+	if err := s.store.FinishJobRun(context.WithoutCancel(ctx), run); err != nil {
+		return run, err
+	}
+	return run, nil
+}
+
+// call runs fn, turning a panic into an error so that one job cannot bring
+// down the server.
+func call(ctx context.Context, fn JobFunc) (result map[string]int64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
@@ -0,0 +1,185 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryLocker is a Locker shared by the schedulers of a test, standing in
+// for the database.
+type memoryLocker struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+func (l *memoryLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held == nil {
+		l.held = map[string]bool{}
+	}
+	if l.held[name] {
+		return nil, false, nil
+	}
+	l.held[name] = true
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, name)
+	}, true, nil
+}
+
+// memoryRunStore is a RunStore keeping runs unique by job and scheduled time.
+type memoryRunStore struct {
+	mu   sync.Mutex
+	runs []domain.JobRun
+}
+
+func (s *memoryRunStore) StartJobRun(ctx context.Context, run *domain.JobRun) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.runs {
+		if r.JobName == run.JobName && r.ScheduledAt.Equal(run.ScheduledAt) {
+			return false, nil
+		}
+	}
+	s.runs = append(s.runs, *run)
+	return true, nil
+}
+
+func (s *memoryRunStore) FinishJobRun(ctx context.Context, run *domain.JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, r := range s.runs {
+		if r.JobName == run.JobName && r.ScheduledAt.Equal(run.ScheduledAt) {
+			s.runs[i] = *run
+			return nil
+		}
+	}
+	return errors.New("run not started")
+}
+
+func newTestScheduler(locker Locker, store RunStore, clk clock.Clock, instance string) *Scheduler {
+	return New(locker, store, clk, instance, log.New(io.Discard, "", 0))
+}
+
+func TestScheduler_RunsDueJobOnce(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 10, 21, 2, 59, 30, 0, time.UTC))
+	locker, store := &memoryLocker{}, &memoryRunStore{}
+	var calls int
+	var mu sync.Mutex
+	run := func(ctx context.Context) (map[string]int64, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return map[string]int64{"deleted": 3}, nil
+	}
+	// Two instances with the same job
+	a := newTestScheduler(locker, store, clk, "a")
+	b := newTestScheduler(locker, store, clk, "b")
+	require.NoError(t, a.Add("purge", MustParseSchedule("0 3 * * *"), run))
+	require.NoError(t, b.Add("purge", MustParseSchedule("0 3 * * *"), run))
+
+	assert.Equal(t, 30*time.Second, a.dispatch(context.Background()), "sleeps until the job is due")
+	clk.Advance(30 * time.Second)
+	a.dispatch(context.Background())
+	a.wg.Wait()
+	b.dispatch(context.Background())
+	b.wg.Wait()
+
+	assert.Equal(t, 1, calls)
+	require.Len(t, store.runs, 1)
+	got := store.runs[0]
+	assert.Equal(t, "a", got.Instance)
+	assert.Equal(t, domain.JobRunSucceeded, got.Status)
+	assert.Equal(t, time.Date(2026, 10, 21, 3, 0, 0, 0, time.UTC), got.ScheduledAt)
+	assert.Equal(t, map[string]int64{"deleted": 3}, got.Result)
+	assert.Equal(t, time.Date(2026, 10, 22, 3, 0, 0, 0, time.UTC), a.Jobs()[0].NextRunAt)
+}
+
+func TestScheduler_SkipsWhileLocked(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 10, 21, 3, 0, 0, 0, time.UTC))
+	locker, store := &memoryLocker{}, &memoryRunStore{}
+	s := newTestScheduler(locker, store, clk, "a")
+	j := &job{name: "purge", schedule: MustParseSchedule("@hourly"), run: func(ctx context.Context) (map[string]int64, error) {
+		t.Fatal("ran while another instance held the lock")
+		return nil, nil
+	}}
+	release, _, _ := locker.TryLock(context.Background(), lockPrefix+"purge")
+	defer release()
+
+	run, err := s.execute(context.Background(), j, clk.Now())
+
+	assert.NoError(t, err)
+	assert.Nil(t, run)
+	assert.Empty(t, store.runs)
+}
+
+func TestScheduler_RunExclusive(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 10, 21, 3, 0, 0, 0, time.UTC))
+	locker, store := &memoryLocker{}, &memoryRunStore{}
+	s := newTestScheduler(locker, store, clk, "a")
+	var calls int
+	fn := func(ctx context.Context) error {
+		calls++
+		_, acquired, _ := locker.TryLock(ctx, lockPrefix+"rotate")
+		assert.False(t, acquired, "the job's lock is held while fn runs")
+		return errors.New("no key")
+	}
+
+	// Waits while another instance runs the job
+	release, _, _ := locker.TryLock(context.Background(), lockPrefix+"rotate")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.RunExclusive(ctx, "rotate", fn), context.DeadlineExceeded)
+	assert.Zero(t, calls)
+	release()
+
+	assert.EqualError(t, s.RunExclusive(context.Background(), "rotate", fn), "no key")
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, store.runs, "nothing is recorded")
+	_, acquired, _ := locker.TryLock(context.Background(), lockPrefix+"rotate")
+	assert.True(t, acquired, "the lock is released")
+}
+
+func TestScheduler_RecordsFailures(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 10, 21, 3, 0, 0, 0, time.UTC))
+	store := &memoryRunStore{}
+	s := newTestScheduler(&memoryLocker{}, store, clk, "a")
+
+	failing := &job{name: "failing", run: func(ctx context.Context) (map[string]int64, error) {
+		return map[string]int64{"deleted": 1}, errors.New("database unavailable")
+	}}
+	run, err := s.execute(context.Background(), failing, clk.Now())
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobRunFailed, run.Status)
+	assert.Equal(t, "database unavailable", run.Error)
+	assert.Equal(t, map[string]int64{"deleted": 1}, run.Result, "partial results are kept")
+
+	panicking := &job{name: "panicking", run: func(ctx context.Context) (map[string]int64, error) {
+		panic("boom")
+	}}
+	run, err = s.execute(context.Background(), panicking, clk.Now())
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobRunFailed, run.Status)
+	assert.Equal(t, "panic: boom", run.Error)
+}
+
+func TestScheduler_Add_Validation(t *testing.T) {
+	s := newTestScheduler(&memoryLocker{}, &memoryRunStore{}, clock.Real(), "a")
+	run := func(ctx context.Context) (map[string]int64, error) { return nil, nil }
+
+	require.NoError(t, s.Add("purge", MustParseSchedule("@daily"), run))
+	assert.Error(t, s.Add("purge", MustParseSchedule("@daily"), run))
+	assert.Error(t, s.Add("", MustParseSchedule("@daily"), run))
+}
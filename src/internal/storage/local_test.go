@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore_PutAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	store := NewLocalBlobStore(dir, "/blobs/")
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "avatars/u1/256.png", []byte("v1"), "image/png"))
+	require.NoError(t, store.Put(ctx, "avatars/u1/256.png", []byte("v2"), "image/png"))
+	got, err := os.ReadFile(filepath.Join(dir, "avatars", "u1", "256.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(got))
+	assert.Equal(t, "/blobs/avatars/u1/256.png", store.URL("avatars/u1/256.png"))
+
+	require.NoError(t, store.Delete(ctx, "avatars/u1/256.png"))
+	// Deleting again is not an error
+	require.NoError(t, store.Delete(ctx, "avatars/u1/256.png"))
+	_, err = os.Stat(filepath.Join(dir, "avatars", "u1", "256.png"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	store := NewLocalBlobStore(t.TempDir(), "/blobs")
+	for _, key := range []string{"", "/etc/passwd", "../outside", "a/../../b", "a//b"} {
+		assert.Error(t, store.Put(context.Background(), key, []byte("x"), "text/plain"), key)
+	}
+}
@@ -0,0 +1,178 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"apiserver/internal/clock"
+)
+
+// S3Config configures a BlobStore backed by an S3-compatible service such as
+// Amazon S3 or MinIO.
+type S3Config struct {
+	// Endpoint is the service URL, e.g. https://s3.ap-northeast-1.amazonaws.com.
+	// Buckets are addressed path-style, which every compatible service accepts.
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PublicURL is where objects are served from, e.g. a CDN in front of the
+	// bucket. It defaults to the bucket URL, which then must allow public reads.
+	PublicURL string
+}
+
+// s3Store talks to the S3 REST API directly, signing requests with AWS
+// Signature Version 4.
+type s3Store struct {
+	cfg    S3Config
+	client *http.Client
+	clock  clock.Clock
+}
+
+// NewS3BlobStore returns a BlobStore that stores objects in an S3 bucket.
+func NewS3BlobStore(cfg S3Config, clk clock.Clock) BlobStore {
+	return &s3Store{cfg: cfg, client: &http.Client{Timeout: 30 * time.Second}, clock: clk}
+}
+
+func (s *s3Store) bucketURL() string {
+	return joinURL(s.cfg.Endpoint, s.cfg.Bucket)
+}
+
+func (s *s3Store) objectURL(key string) string {
+	return joinURL(s.bucketURL(), encodePath(key))
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	sum := sha256.Sum256(data)
+	return s.do(req, hex.EncodeToString(sum[:]), http.StatusOK)
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	// S3 answers 204 whether or not the object existed
+	return s.do(req, emptyPayloadHash, http.StatusNoContent, http.StatusNotFound)
+}
+
+func (s *s3Store) URL(key string) string {
+	if s.cfg.PublicURL != "" {
+		return joinURL(s.cfg.PublicURL, encodePath(key))
+	}
+	return s.objectURL(key)
+}
+
+// do signs and sends req and fails unless the response status is one of ok.
+func (s *s3Store) do(req *http.Request, payloadHash string, ok ...int) error {
+	s.sign(req, payloadHash, s.clock.Now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	for _, code := range ok {
+		if resp.StatusCode == code {
+			return nil
+		}
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("storage: %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, bytes.TrimSpace(body))
+}
+
+// emptyPayloadHash is the SHA-256 of an empty body.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// sign adds AWS Signature Version 4 headers to req. Every header already set
+// on req is signed, along with the host.
+func (s *s3Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		encodePath(req.URL.Path),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.cfg.AccessKeyID+"/"+scope+
+		",SignedHeaders="+signedHeaders+",Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	m := hmac.New(sha256.New, key)
+	m.Write([]byte(data))
+	return m.Sum(nil)
+}
+
+// encodePath percent-encodes a path as SigV4 requires: everything but
+// unreserved characters and the slashes separating segments.
+func encodePath(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,34 @@
+// Package storage stores binary objects such as user avatars outside the
+// database.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// BlobStore stores objects under slash-separated keys and serves them from a
+// public URL. Putting a key that exists replaces the object.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Delete removes the object. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL of the object stored under key.
+	URL(key string) string
+}
+
+// validateKey refuses keys that are empty, absolute or escape the store
+// with "..", so that every implementation accepts the same keys.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+	return nil
+}
+
+// joinURL appends key to base with exactly one slash between them.
+func joinURL(base, key string) string {
+	return strings.TrimSuffix(base, "/") + "/" + key
+}
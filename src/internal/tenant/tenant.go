@@ -0,0 +1,24 @@
+// Package tenant carries the organization a request acts within.
+package tenant
+
+import "context"
+
+// Tenant is the organization selected for a request, after the caller's
+// membership in it has been verified.
+type Tenant struct {
+	OrganizationID string
+	Role           string // The caller's role in the organization
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx carrying t.
+func WithTenant(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// From returns the tenant stored in ctx, or nil when no organization was selected.
+func From(ctx context.Context) *Tenant {
+	t, _ := ctx.Value(tenantKey{}).(*Tenant)
+	return t
+}
@@ -0,0 +1,71 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Secret "12345678901234567890" from the RFC 6238 test vectors
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	cases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tc := range cases {
+		code, err := Code(rfcSecret, Step(time.Unix(tc.unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.code, code, "unix time %d", tc.unix)
+	}
+}
+
+func TestValidate_ToleratesOneStepOfSkew(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	code, _ := Code(rfcSecret, Step(now))
+
+	step, ok := Validate(rfcSecret, code, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	_, ok = Validate(rfcSecret, code, now.Add(Period*time.Second), 1)
+	assert.True(t, ok, "code from the previous step should be accepted")
+
+	_, ok = Validate(rfcSecret, code, now.Add(-Period*time.Second), 1)
+	assert.True(t, ok, "code from the next step should be accepted")
+
+	_, ok = Validate(rfcSecret, code, now.Add(2*Period*time.Second), 1)
+	assert.False(t, ok, "code two steps old should be rejected")
+}
+
+func TestValidate_RejectsMalformedCodes(t *testing.T) {
+	now := time.Unix(59, 0)
+	_, ok := Validate(rfcSecret, "28708", now, 1)
+	assert.False(t, ok)
+	_, ok = Validate("not base32!", "287082", now, 1)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecretAndKeyURI(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	uri, err := url.Parse(KeyURI("echo_tutrial", "user@example.com", secret))
+	assert.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.True(t, strings.HasSuffix(uri.Path, "echo_tutrial:user@example.com"))
+	assert.Equal(t, secret, uri.Query().Get("secret"))
+	assert.Equal(t, "echo_tutrial", uri.Query().Get("issuer"))
+}
@@ -0,0 +1,312 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+)
+
+const (
+	// apiKeyTag starts every key so leaked keys are easy to recognise and grep for.
+	apiKeyTag = "uak_"
+	// apiKeyPrefixLength is the length of the stored, non-secret prefix including apiKeyTag.
+	apiKeyPrefixLength = len(apiKeyTag) + 8
+	// apiKeySecretBytes is the amount of randomness in the secret part of a key.
+	apiKeySecretBytes = 32
+	// apiKeyLastUsedResolution limits how often last-used timestamps are written.
+	apiKeyLastUsedResolution = time.Minute
+)
+
+const apiKeyPrefixAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// validScopes lists the scopes an API key can be granted.
+var validScopes = map[string]bool{
+	domain.ScopeUsersRead:  true,
+	domain.ScopeUsersWrite: true,
+	domain.ScopeUsersAdmin: true,
+}
+
+// APIKeyInteractor defines the interface for API key business logic.
+type APIKeyInteractor interface {
+	// CreateAPIKey issues a key acting as userID (the caller when empty). The
+	// returned secret is the full key and cannot be retrieved again.
+	CreateAPIKey(ctx context.Context, userID, name string, scopes []string, expiresAt time.Time) (key *domain.APIKey, secret string, err error)
+	ListAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+	// AuthenticateAPIKey resolves a presented key to the principal it acts as.
+	AuthenticateAPIKey(ctx context.Context, rawKey string) (*auth.Principal, error)
+}
+
+// apiKeyInteractor implements APIKeyInteractor.
+type apiKeyInteractor struct {
+	keyRepo  repositories.APIKeyRepository
+	userRepo repositories.UserRepository
+	auditor  audit.Recorder
+	clock    clock.Clock
+}
+
+// NewAPIKeyInteractor creates a new instance of APIKeyInteractor.
+func NewAPIKeyInteractor(keyRepo repositories.APIKeyRepository, userRepo repositories.UserRepository, auditor audit.Recorder, clk clock.Clock) APIKeyInteractor {
+	return &apiKeyInteractor{
+		keyRepo:  keyRepo,
+		userRepo: userRepo,
+		auditor:  auditor,
+		clock:    clk,
+	}
+}
+
+// generateAPIKey returns a new key formatted as "uak_<8 chars>_<64 hex chars>"
+// together with its prefix.
+func generateAPIKey() (key, prefix string, err error) {
+	var sb strings.Builder
+	sb.WriteString(apiKeyTag)
+	max := big.NewInt(int64(len(apiKeyPrefixAlphabet)))
+	for sb.Len() < apiKeyPrefixLength {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", "", err
+		}
+		sb.WriteByte(apiKeyPrefixAlphabet[n.Int64()])
+	}
+	prefix = sb.String()
+
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	return prefix + "_" + hex.EncodeToString(secret), prefix, nil
+}
+
+// IsAPIKey reports whether a bearer credential is an API key rather than a JWT.
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, apiKeyTag)
+}
+
+// parseAPIKey returns the prefix of a well-formed key.
+func parseAPIKey(key string) (prefix string, ok bool) {
+	if len(key) != apiKeyPrefixLength+1+2*apiKeySecretBytes || !strings.HasPrefix(key, apiKeyTag) || key[apiKeyPrefixLength] != '_' {
+		return "", false
+	}
+	return key[:apiKeyPrefixLength], true
+}
+
+// hashAPIKey returns the stored form of a key. Keys carry 256 bits of
+// randomness, so a plain SHA-256 is sufficient and keeps verification cheap.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeScopes validates scopes and removes duplicates.
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", domain.ErrInvalidArgument)
+	}
+	seen := make(map[string]bool, len(scopes))
+	out := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		if !validScopes[s] {
+			return nil, fmt.Errorf("%w: unknown scope %q", domain.ErrInvalidArgument, s)
+		}
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (uc *apiKeyInteractor) CreateAPIKey(ctx context.Context, userID, name string, scopes []string, expiresAt time.Time) (*domain.APIKey, string, error) {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: name is required", domain.ErrInvalidArgument)
+	}
+	scopes, err = normalizeScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	now := uc.clock.Now()
+	if !expiresAt.IsZero() && !expiresAt.After(now) {
+		return nil, "", fmt.Errorf("%w: expires_at must be in the future", domain.ErrInvalidArgument)
+	}
+
+	// Keys for other users (typically service accounts) and admin-scoped keys
+	// may only be issued by an administrator with a second factor.
+	if userID == "" {
+		userID = principal.UserID
+	}
+	if userID != principal.UserID || containsScope(scopes, domain.ScopeUsersAdmin) {
+		if _, err := requireAdmin(ctx); err != nil {
+			return nil, "", err
+		}
+	}
+	owner, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if owner == nil {
+		return nil, "", domain.ErrNotFound
+	}
+	if containsScope(scopes, domain.ScopeUsersAdmin) && owner.Role != domain.RoleAdmin {
+		return nil, "", fmt.Errorf("%w: the users:admin scope can only be granted to admin users", domain.ErrInvalidArgument)
+	}
+
+	secret, prefix, err := generateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := uc.keyRepo.CreateAPIKey(ctx, &domain.APIKey{
+		UserID:     owner.ID,
+		Name:       name,
+		Prefix:     prefix,
+		SecretHash: hashAPIKey(secret),
+		Scopes:     scopes,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := uc.auditor.Record(ctx, domain.AuditEvent{
+		Action:   domain.AuditActionAPIKeyCreated,
+		ActorID:  principal.UserID,
+		TargetID: owner.ID,
+		Metadata: map[string]string{
+			"api_key_id": key.ID,
+			"prefix":     key.Prefix,
+			"scopes":     strings.Join(key.Scopes, " "),
+		},
+		OccurredAt: now,
+	}); err != nil {
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+func (uc *apiKeyInteractor) ListAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error) {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if userID == "" {
+		userID = principal.UserID
+	}
+	if userID != principal.UserID {
+		if _, err := requireAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return uc.keyRepo.ListAPIKeysByUser(ctx, userID)
+}
+
+func (uc *apiKeyInteractor) RevokeAPIKey(ctx context.Context, id string) error {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return err
+	}
+	if id == "" {
+		return errors.New("API key ID is required")
+	}
+
+	key, err := uc.keyRepo.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return domain.ErrAPIKeyNotFound
+	}
+	if key.UserID != principal.UserID {
+		if _, err := requireAdmin(ctx); err != nil {
+			// Do not reveal that someone else's key exists
+			if errors.Is(err, domain.ErrForbidden) {
+				return domain.ErrAPIKeyNotFound
+			}
+			return err
+		}
+	}
+	if !key.RevokedAt.IsZero() {
+		return nil
+	}
+
+	now := uc.clock.Now()
+	if err := uc.keyRepo.RevokeAPIKey(ctx, key.ID, now); err != nil {
+		return err
+	}
+	return uc.auditor.Record(ctx, domain.AuditEvent{
+		Action:   domain.AuditActionAPIKeyRevoked,
+		ActorID:  principal.UserID,
+		TargetID: key.UserID,
+		Metadata: map[string]string{
+			"api_key_id": key.ID,
+			"prefix":     key.Prefix,
+		},
+		OccurredAt: now,
+	})
+}
+
+func (uc *apiKeyInteractor) AuthenticateAPIKey(ctx context.Context, rawKey string) (*auth.Principal, error) {
+	prefix, ok := parseAPIKey(rawKey)
+	if !ok {
+		return nil, domain.ErrUnauthenticated
+	}
+	key, err := uc.keyRepo.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || subtle.ConstantTimeCompare([]byte(hashAPIKey(rawKey)), []byte(key.SecretHash)) != 1 {
+		return nil, domain.ErrUnauthenticated
+	}
+	now := uc.clock.Now()
+	if !key.IsActive(now) {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	// The role is read on every request so demoting the owner takes effect immediately
+	user, err := uc.userRepo.GetUserByID(ctx, key.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	if now.Sub(key.LastUsedAt) >= apiKeyLastUsedResolution {
+		if err := uc.keyRepo.TouchAPIKey(ctx, key.ID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return &auth.Principal{
+		UserID:   user.ID,
+		Role:     user.Role,
+		APIKeyID: key.ID,
+		Scopes:   key.Scopes,
+	}, nil
+}
+
+// containsScope reports whether scope is in scopes.
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type apiKeyTestEnv struct {
+	keyRepo    *mocks.MockAPIKeyRepository
+	userRepo   *mocks.MockUserRepository
+	auditor    *auditmocks.MockRecorder
+	clock      *clock.Fake
+	interactor APIKeyInteractor
+}
+
+func setupAPIKeyTestEnv() *apiKeyTestEnv {
+	env := &apiKeyTestEnv{
+		keyRepo:  new(mocks.MockAPIKeyRepository),
+		userRepo: new(mocks.MockUserRepository),
+		auditor:  new(auditmocks.MockRecorder),
+		clock:    clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	env.interactor = NewAPIKeyInteractor(env.keyRepo, env.userRepo, env.auditor, env.clock)
+	return env
+}
+
+func sessionContext(userID, role string, mfa bool) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{UserID: userID, Role: role, MFA: mfa})
+}
+
+func TestGenerateAPIKey_RoundTrip(t *testing.T) {
+	key, prefix, err := generateAPIKey()
+	assert.NoError(t, err)
+	assert.Len(t, prefix, apiKeyPrefixLength)
+
+	parsed, ok := parseAPIKey(key)
+	assert.True(t, ok)
+	assert.Equal(t, prefix, parsed)
+
+	for _, bad := range []string{"", prefix, "xyz" + key[3:], key[:len(key)-1]} {
+		_, ok := parseAPIKey(bad)
+		assert.False(t, ok, bad)
+	}
+}
+
+func TestAPIKeyInteractor_CreateAPIKey_Success(t *testing.T) {
+	env := setupAPIKeyTestEnv()
+	ctx := sessionContext("user-1", domain.RoleUser, false)
+	var stored *domain.APIKey
+
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", Role: domain.RoleUser}, nil).Once()
+	env.keyRepo.On("CreateAPIKey", mock.Anything, mock.AnythingOfType("*domain.APIKey")).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.APIKey)
+	}).Return(&domain.APIKey{ID: "key-1", UserID: "user-1", Scopes: []string{domain.ScopeUsersRead}}, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionAPIKeyCreated && e.ActorID == "user-1" && e.Metadata["api_key_id"] == "key-1"
+	})).Return(nil).Once()
+
+	key, secret, err := env.interactor.CreateAPIKey(ctx, "", " batch job ", []string{domain.ScopeUsersRead, domain.ScopeUsersRead}, time.Time{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "key-1", key.ID)
+	assert.Equal(t, "batch job", stored.Name)
+	assert.Equal(t, []string{domain.ScopeUsersRead}, stored.Scopes)
+	prefix, ok := parseAPIKey(secret)
+	assert.True(t, ok)
+	assert.Equal(t, prefix, stored.Prefix)
+	assert.Equal(t, hashAPIKey(secret), stored.SecretHash)
+	assert.NotContains(t, stored.SecretHash, secret[apiKeyPrefixLength+1:])
+	env.keyRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestAPIKeyInteractor_CreateAPIKey_Validation(t *testing.T) {
+	env := setupAPIKeyTestEnv()
+	ctx := sessionContext("user-1", domain.RoleUser, false)
+
+	_, _, err := env.interactor.CreateAPIKey(ctx, "", "job", []string{"users:delete"}, time.Time{})
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, _, err = env.interactor.CreateAPIKey(ctx, "", "job", nil, time.Time{})
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, _, err = env.interactor.CreateAPIKey(ctx, "", "job", []string{domain.ScopeUsersRead}, env.clock.Now().Add(-time.Second))
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	env.keyRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyInteractor_CreateAPIKey_Authorization(t *testing.T) {
+	cases := map[string]struct {
+		ctx    context.Context
+		userID string
+		scopes []string
+		want   error
+	}{
+		"anonymous":                {context.Background(), "", []string{domain.ScopeUsersRead}, domain.ErrUnauthenticated},
+		"api key caller":           {auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "admin-1", Role: domain.RoleAdmin, APIKeyID: "key-1", Scopes: []string{domain.ScopeUsersAdmin}}), "", []string{domain.ScopeUsersRead}, domain.ErrForbidden},
+		"other user as non-admin":  {sessionContext("user-1", domain.RoleUser, false), "user-2", []string{domain.ScopeUsersRead}, domain.ErrForbidden},
+		"admin scope as non-admin": {sessionContext("user-1", domain.RoleUser, false), "", []string{domain.ScopeUsersAdmin}, domain.ErrForbidden},
+		"admin scope without mfa":  {sessionContext("admin-1", domain.RoleAdmin, false), "", []string{domain.ScopeUsersAdmin}, domain.ErrMFARequired},
+		"other user admin no mfa":  {sessionContext("admin-1", domain.RoleAdmin, false), "svc-1", []string{domain.ScopeUsersRead}, domain.ErrMFARequired},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := setupAPIKeyTestEnv()
+
+			_, _, err := env.interactor.CreateAPIKey(tc.ctx, tc.userID, "job", tc.scopes, time.Time{})
+
+			assert.ErrorIs(t, err, tc.want)
+			env.keyRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAPIKeyInteractor_CreateAPIKey_AdminScopeRequiresAdminOwner(t *testing.T) {
+	env := setupAPIKeyTestEnv()
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+
+	env.userRepo.On("GetUserByID", mock.Anything, "svc-1").Return(&domain.User{ID: "svc-1", Role: domain.RoleUser}, nil).Once()
+
+	_, _, err := env.interactor.CreateAPIKey(ctx, "svc-1", "job", []string{domain.ScopeUsersAdmin}, time.Time{})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	env.keyRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyInteractor_ListAPIKeys_OtherUserRequiresAdmin(t *testing.T) {
+	env := setupAPIKeyTestEnv()
+
+	_, err := env.interactor.ListAPIKeys(sessionContext("user-1", domain.RoleUser, false), "user-2")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	env.keyRepo.On("ListAPIKeysByUser", mock.Anything, "user-1").Return([]domain.APIKey{{ID: "key-1"}}, nil).Once()
+	keys, err := env.interactor.ListAPIKeys(sessionContext("user-1", domain.RoleUser, false), "")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	env.keyRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyInteractor_RevokeAPIKey(t *testing.T) {
+	env := setupAPIKeyTestEnv()
+	ctx := sessionContext("user-1", domain.RoleUser, false)
+
+	env.keyRepo.On("GetAPIKeyByID", mock.Anything, "key-1").Return(&domain.APIKey{ID: "key-1", UserID: "user-1", Prefix: "uak_abcdefgh"}, nil).Once()
+	env.keyRepo.On("RevokeAPIKey", mock.Anything, "key-1", env.clock.Now()).Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionAPIKeyRevoked && e.Metadata["api_key_id"] == "key-1"
+	})).Return(nil).Once()
+
+	assert.NoError(t, env.interactor.RevokeAPIKey(ctx, "key-1"))
+	env.keyRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestAPIKeyInteractor_RevokeAPIKey_OtherUsersKeyIsHidden(t *testing.T) {
+	env := setupAPIKeyTestEnv()
+	ctx := sessionContext("user-1", domain.RoleUser, false)
+
+	env.keyRepo.On("GetAPIKeyByID", mock.Anything, "key-2").Return(&domain.APIKey{ID: "key-2", UserID: "user-2"}, nil).Once()
+
+	err := env.interactor.RevokeAPIKey(ctx, "key-2")
+
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+	env.keyRepo.AssertNotCalled(t, "RevokeAPIKey", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyInteractor_AuthenticateAPIKey_Success(t *testing.T) {
+	env := setupAPIKeyTestEnv()
+	raw, prefix, _ := generateAPIKey()
+	key := &domain.APIKey{ID: "key-1", UserID: "svc-1", Prefix: prefix, SecretHash: hashAPIKey(raw), Scopes: []string{domain.ScopeUsersRead}}
+
+	env.keyRepo.On("GetAPIKeyByPrefix", mock.Anything, prefix).Return(key, nil).Once()
+	env.userRepo.On("GetUserByID", mock.Anything, "svc-1").Return(&domain.User{ID: "svc-1", Role: domain.RoleUser}, nil).Once()
+	env.keyRepo.On("TouchAPIKey", mock.Anything, "key-1", env.clock.Now()).Return(nil).Once()
+
+	principal, err := env.interactor.AuthenticateAPIKey(context.Background(), raw)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &auth.Principal{UserID: "svc-1", Role: domain.RoleUser, APIKeyID: "key-1", Scopes: []string{domain.ScopeUsersRead}}, principal)
+	env.keyRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyInteractor_AuthenticateAPIKey_RecentlyUsedIsNotTouched(t *testing.T) {
+	env := setupAPIKeyTestEnv()
+	raw, prefix, _ := generateAPIKey()
+	key := &domain.APIKey{ID: "key-1", UserID: "svc-1", Prefix: prefix, SecretHash: hashAPIKey(raw), LastUsedAt: env.clock.Now().Add(-10 * time.Second)}
+
+	env.keyRepo.On("GetAPIKeyByPrefix", mock.Anything, prefix).Return(key, nil).Once()
+	env.userRepo.On("GetUserByID", mock.Anything, "svc-1").Return(&domain.User{ID: "svc-1", Role: domain.RoleUser}, nil).Once()
+
+	_, err := env.interactor.AuthenticateAPIKey(context.Background(), raw)
+
+	assert.NoError(t, err)
+	env.keyRepo.AssertNotCalled(t, "TouchAPIKey", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyInteractor_AuthenticateAPIKey_Rejected(t *testing.T) {
+	raw, prefix, _ := generateAPIKey()
+	other, _, _ := generateAPIKey()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		presented string
+		stored    *domain.APIKey
+	}{
+		"unknown prefix": {raw, nil},
+		"wrong secret":   {prefix + other[apiKeyPrefixLength:], &domain.APIKey{ID: "key-1", UserID: "svc-1", SecretHash: hashAPIKey(raw)}},
+		"revoked":        {raw, &domain.APIKey{ID: "key-1", UserID: "svc-1", SecretHash: hashAPIKey(raw), RevokedAt: now.Add(-time.Hour)}},
+		"expired":        {raw, &domain.APIKey{ID: "key-1", UserID: "svc-1", SecretHash: hashAPIKey(raw), ExpiresAt: now}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := setupAPIKeyTestEnv()
+			env.keyRepo.On("GetAPIKeyByPrefix", mock.Anything, prefix).Return(tc.stored, nil).Once()
+
+			_, err := env.interactor.AuthenticateAPIKey(context.Background(), tc.presented)
+
+			assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+			env.userRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+		})
+	}
+
+	env := setupAPIKeyTestEnv()
+	_, err := env.interactor.AuthenticateAPIKey(context.Background(), "not-a-key")
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	env.keyRepo.AssertNotCalled(t, "GetAPIKeyByPrefix", mock.Anything, mock.Anything)
+}
+
+func TestRequireAdmin_APIKey(t *testing.T) {
+	withKey := func(role string, scopes ...string) context.Context {
+		return auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "u", Role: role, APIKeyID: "key-1", Scopes: scopes})
+	}
+
+	_, err := requireAdmin(withKey(domain.RoleAdmin, domain.ScopeUsersAdmin))
+	assert.NoError(t, err)
+	_, err = requireAdmin(withKey(domain.RoleAdmin, domain.ScopeUsersWrite))
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = requireAdmin(withKey(domain.RoleUser, domain.ScopeUsersAdmin))
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
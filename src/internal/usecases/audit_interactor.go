@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+)
+
+const (
+	// defaultAuditPageSize is used when the caller does not ask for a page size.
+	defaultAuditPageSize = 100
+	// maxAuditPageSize caps a single page of audit events.
+	maxAuditPageSize = 500
+)
+
+// AuditInteractor defines the interface for reading the audit log.
+type AuditInteractor interface {
+	// ListAuditEvents returns matching events, newest first.
+	ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error)
+}
+
+// auditInteractor implements AuditInteractor.
+type auditInteractor struct {
+	auditRepo repositories.AuditRepository
+}
+
+// NewAuditInteractor creates a new instance of AuditInteractor.
+func NewAuditInteractor(repo repositories.AuditRepository) AuditInteractor {
+	return &auditInteractor{auditRepo: repo}
+}
+
+func (uc *auditInteractor) ListAuditEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageSize
+	}
+	if filter.Limit > maxAuditPageSize {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", domain.ErrInvalidArgument, maxAuditPageSize)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", domain.ErrInvalidArgument)
+	}
+	return uc.auditRepo.ListAuditEvents(ctx, filter)
+}
@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditInteractor_ListAuditEvents_DefaultsLimit(t *testing.T) {
+	repo := new(mocks.MockAuditRepository)
+	interactor := NewAuditInteractor(repo)
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+
+	repo.On("ListAuditEvents", mock.Anything, domain.AuditFilter{TargetID: "user-1", Limit: defaultAuditPageSize}).Return([]domain.AuditEvent{{ID: 1}}, nil).Once()
+
+	events, err := interactor.ListAuditEvents(ctx, domain.AuditFilter{TargetID: "user-1"})
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	repo.AssertExpectations(t)
+}
+
+func TestAuditInteractor_ListAuditEvents_Rejected(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	admin := sessionContext("admin-1", domain.RoleAdmin, true)
+
+	cases := map[string]struct {
+		ctx    context.Context
+		filter domain.AuditFilter
+		want   error
+	}{
+		"anonymous":      {context.Background(), domain.AuditFilter{}, domain.ErrUnauthenticated},
+		"non-admin":      {sessionContext("user-1", domain.RoleUser, false), domain.AuditFilter{}, domain.ErrForbidden},
+		"limit too high": {admin, domain.AuditFilter{Limit: maxAuditPageSize + 1}, domain.ErrInvalidArgument},
+		"inverted range": {admin, domain.AuditFilter{From: now, To: now.Add(-time.Hour)}, domain.ErrInvalidArgument},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			repo := new(mocks.MockAuditRepository)
+
+			_, err := NewAuditInteractor(repo).ListAuditEvents(tc.ctx, tc.filter)
+
+			assert.ErrorIs(t, err, tc.want)
+			repo.AssertNotCalled(t, "ListAuditEvents", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/encryption"
+	"apiserver/internal/password"
+	"apiserver/internal/repositories"
+)
+
+// LockoutPolicy configures how repeated login failures lock accounts and client IPs.
+type LockoutPolicy struct {
+	AccountThreshold int           // Failures before an account is locked; 0 disables account lockout
+	IPThreshold      int           // Failures before a client IP is locked; 0 disables IP lockout
+	BaseDuration     time.Duration // Length of the first lockout, doubled for each subsequent one
+	MaxDuration      time.Duration // Upper bound for a single lockout
+}
+
+// DefaultLockoutPolicy returns the policy used when nothing is configured.
+func DefaultLockoutPolicy() LockoutPolicy {
+	return LockoutPolicy{
+		AccountThreshold: 5,
+		IPThreshold:      20,
+		BaseDuration:     time.Minute,
+		MaxDuration:      time.Hour,
+	}
+}
+
+// lockoutDuration returns the exponential back-off for a subject that has
+// already been locked previousLockouts times.
+func (p LockoutPolicy) lockoutDuration(previousLockouts int) time.Duration {
+	d := p.BaseDuration
+	for i := 0; i < previousLockouts && d < p.MaxDuration; i++ {
+		d *= 2
+	}
+	if d > p.MaxDuration {
+		d = p.MaxDuration
+	}
+	return d
+}
+
+// AuthSettings configures AuthInteractor.
+type AuthSettings struct {
+	Lockout   LockoutPolicy
+	MFAIssuer string // Issuer name shown in authenticator apps
+}
+
+// LoginResult is returned by a successful login. When the user has two-factor
+// authentication enabled, the password step only yields an MFA challenge token
+// that must be exchanged through CompleteMFALogin.
+type LoginResult struct {
+	User        *domain.User
+	AccessToken string
+	ExpiresAt   time.Time
+	MFARequired bool
+	MFAToken    string
+}
+
+// MFAEnrollment is the pending TOTP secret handed to the user for their authenticator app.
+type MFAEnrollment struct {
+	Secret string
+	KeyURI string // otpauth:// URI, usually rendered as a QR code
+}
+
+// AuthInteractor defines the interface for authentication business logic.
+type AuthInteractor interface {
+	Login(ctx context.Context, email, plainPassword, clientIP string) (*LoginResult, error)
+	CompleteMFALogin(ctx context.Context, mfaToken, code, clientIP string) (*LoginResult, error)
+	BeginMFAEnrollment(ctx context.Context) (*MFAEnrollment, error)
+	ConfirmMFAEnrollment(ctx context.Context, code string) (recoveryCodes []string, err error)
+	UnlockUser(ctx context.Context, id string) error
+}
+
+// authInteractor implements AuthInteractor.
+type authInteractor struct {
+	userRepo     repositories.UserRepository
+	throttleRepo repositories.LoginThrottleRepository
+	mfaRepo      repositories.MFARepository
+	passwords    password.Hasher
+	tokens       auth.TokenService
+	secrets      encryption.Cipher // Encrypts TOTP secrets at rest
+	auditor      audit.Recorder
+	settings     AuthSettings
+	clock        clock.Clock
+	logger       *log.Logger
+
+	dummyHashOnce sync.Once
+	dummyHash     string
+}
+
+// NewAuthInteractor creates a new instance of AuthInteractor. Failures that do
+// not fail a login, such as upgrading a password hash, are written to logger.
+func NewAuthInteractor(userRepo repositories.UserRepository, throttleRepo repositories.LoginThrottleRepository, mfaRepo repositories.MFARepository, passwords password.Hasher, tokens auth.TokenService, secrets encryption.Cipher, auditor audit.Recorder, settings AuthSettings, clk clock.Clock, logger *log.Logger) AuthInteractor {
+	return &authInteractor{
+		userRepo:     userRepo,
+		throttleRepo: throttleRepo,
+		mfaRepo:      mfaRepo,
+		passwords:    passwords,
+		tokens:       tokens,
+		secrets:      secrets,
+		auditor:      auditor,
+		settings:     settings,
+		clock:        clk,
+		logger:       logger,
+	}
+}
+
+// dummyPasswordHash returns a hash that is compared against when the email is
+// unknown, so that response timing does not reveal which accounts exist. It
+// is made with the current settings, like the hashes of active accounts.
+func (uc *authInteractor) dummyPasswordHash() string {
+	uc.dummyHashOnce.Do(func() {
+		uc.dummyHash, _ = uc.passwords.Hash("dummy-password-for-timing")
+	})
+	return uc.dummyHash
+}
+
+// normalizeEmail returns the key account throttles are tracked under.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (uc *authInteractor) Login(ctx context.Context, email, plainPassword, clientIP string) (*LoginResult, error) {
+	if email == "" || plainPassword == "" {
+		return nil, errors.New("email and password are required")
+	}
+
+	now := uc.clock.Now()
+	accountKey := normalizeEmail(email)
+
+	// Refuse locked subjects before touching the password so a locked account
+	// cannot be probed any further.
+	if err := uc.checkLockouts(ctx, accountKey, clientIP, now); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetUserByEmail(ctx, accountKey)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := uc.dummyPasswordHash()
+	if user != nil && user.Password != "" {
+		hash = user.Password
+	}
+	// A hash in an unknown format counts as a wrong password
+	if ok, _ := uc.passwords.Verify(hash, plainPassword); !ok || user == nil {
+		targetID := ""
+		if user != nil {
+			targetID = user.ID
+		}
+		if err := uc.recordFailures(ctx, accountKey, targetID, clientIP, now); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidCredentials
+	}
+	if uc.passwords.NeedsRehash(hash) {
+		// The old hash still works, so it is upgraded at a later login
+		if err := uc.rehashPassword(ctx, user.ID, hash, plainPassword); err != nil {
+			uc.logger.Printf("login: upgrading the password hash of user %s: %v", user.ID, err)
+		}
+	}
+	user.Password = "" // Never hand the hash back to callers
+
+	mfa, err := uc.mfaRepo.GetMFASetting(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa != nil && mfa.Enabled {
+		// The account throttle is only reset once the second factor succeeds,
+		// so knowing the password does not allow unlimited code guesses.
+		challenge, expiresAt, err := uc.tokens.IssueMFAChallenge(user)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{User: user, MFARequired: true, MFAToken: challenge, ExpiresAt: expiresAt}, nil
+	}
+
+	return uc.completeLogin(ctx, user, accountKey, false)
+}
+
+// rehashPassword replaces the stored hash of a password that has just been
+// verified with one made with the current settings, moving accounts to a new
+// algorithm or cost as their users sign in.
+func (uc *authInteractor) rehashPassword(ctx context.Context, userID, oldHash, plainPassword string) error {
+	if len(plainPassword) > uc.passwords.MaxLength() {
+		return nil // Kept on the old hash until the password is changed
+	}
+	newHash, err := uc.passwords.Hash(plainPassword)
+	if err != nil {
+		return err
+	}
+	// Not updated when the password was changed since it was read, which is fine
+	_, err = uc.userRepo.UpdateUserPasswordHash(ctx, userID, oldHash, newHash)
+	return err
+}
+
+// completeLogin clears the account's failure count and issues an access token.
+func (uc *authInteractor) completeLogin(ctx context.Context, user *domain.User, accountKey string, mfa bool) (*LoginResult, error) {
+	if err := uc.throttleRepo.Reset(ctx, domain.ThrottleScopeAccount, accountKey); err != nil {
+		return nil, err
+	}
+	token, expiresAt, err := uc.tokens.Issue(user, mfa)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{User: user, AccessToken: token, ExpiresAt: expiresAt}, nil
+}
+
+// checkLockouts returns a LockedError when either the client IP or the account is locked.
+func (uc *authInteractor) checkLockouts(ctx context.Context, accountKey, clientIP string, now time.Time) error {
+	if err := uc.checkLocked(ctx, domain.ThrottleScopeIP, clientIP, now); err != nil {
+		return err
+	}
+	return uc.checkLocked(ctx, domain.ThrottleScopeAccount, accountKey, now)
+}
+
+func (uc *authInteractor) checkLocked(ctx context.Context, scope domain.ThrottleScope, subject string, now time.Time) error {
+	if subject == "" {
+		return nil
+	}
+	throttle, err := uc.throttleRepo.GetThrottle(ctx, scope, subject)
+	if err != nil {
+		return err
+	}
+	if throttle.IsLocked(now) {
+		return &domain.LockedError{Until: throttle.LockedUntil}
+	}
+	return nil
+}
+
+// recordFailures counts a failed attempt against both the account and the client IP.
+func (uc *authInteractor) recordFailures(ctx context.Context, accountKey, targetID, clientIP string, now time.Time) error {
+	if err := uc.recordFailure(ctx, domain.ThrottleScopeAccount, accountKey, uc.settings.Lockout.AccountThreshold, targetID, clientIP, now); err != nil {
+		return err
+	}
+	return uc.recordFailure(ctx, domain.ThrottleScopeIP, clientIP, uc.settings.Lockout.IPThreshold, targetID, clientIP, now)
+}
+
+func (uc *authInteractor) recordFailure(ctx context.Context, scope domain.ThrottleScope, subject string, threshold int, targetID, clientIP string, now time.Time) error {
+	if subject == "" || threshold <= 0 {
+		return nil
+	}
+	throttle, err := uc.throttleRepo.RecordFailure(ctx, scope, subject, now)
+	if err != nil {
+		return err
+	}
+	if throttle == nil || throttle.FailedCount < threshold {
+		return nil
+	}
+
+	until := now.Add(uc.settings.Lockout.lockoutDuration(throttle.LockoutCount))
+	if err := uc.throttleRepo.Lock(ctx, scope, subject, until); err != nil {
+		return err
+	}
+	return uc.auditor.Record(ctx, domain.AuditEvent{
+		Action:   domain.AuditActionLoginLockout,
+		TargetID: targetID,
+		IP:       clientIP,
+		Metadata: map[string]string{
+			"scope":           string(scope),
+			"subject":         subject,
+			"failed_attempts": strconv.Itoa(throttle.FailedCount),
+			"lockout_count":   strconv.Itoa(throttle.LockoutCount + 1),
+			"locked_until":    until.UTC().Format(time.RFC3339),
+		},
+		OccurredAt: now,
+	})
+}
+
+func (uc *authInteractor) UnlockUser(ctx context.Context, id string) error {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+	if id == "" {
+		return errors.New("user ID is required")
+	}
+
+	user, err := uc.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrNotFound
+	}
+
+	if err := uc.throttleRepo.Reset(ctx, domain.ThrottleScopeAccount, normalizeEmail(user.Email)); err != nil {
+		return err
+	}
+	return uc.auditor.Record(ctx, domain.AuditEvent{
+		Action:     domain.AuditActionUserUnlocked,
+		ActorID:    principal.UserID,
+		TargetID:   user.ID,
+		OccurredAt: uc.clock.Now(),
+	})
+}
@@ -0,0 +1,328 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/encryption"
+	"apiserver/internal/password"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type authTestEnv struct {
+	userRepo     *mocks.MockUserRepository
+	throttleRepo *mocks.MockLoginThrottleRepository
+	mfaRepo      *mocks.MockMFARepository
+	auditor      *auditmocks.MockRecorder
+	tokens       auth.TokenService
+	secrets      encryption.Cipher
+	clock        *clock.Fake
+	logs         bytes.Buffer
+	interactor   AuthInteractor
+}
+
+func setupAuthTestEnv() *authTestEnv {
+	env := &authTestEnv{
+		userRepo:     new(mocks.MockUserRepository),
+		throttleRepo: new(mocks.MockLoginThrottleRepository),
+		mfaRepo:      new(mocks.MockMFARepository),
+		auditor:      new(auditmocks.MockRecorder),
+		clock:        clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	env.tokens = auth.NewJWTTokenService([]byte("test-secret"), time.Hour, env.clock)
+	env.secrets, _ = encryption.NewAESGCM(bytes.Repeat([]byte{7}, 32))
+	settings := AuthSettings{
+		Lockout:   LockoutPolicy{AccountThreshold: 3, IPThreshold: 10, BaseDuration: time.Minute, MaxDuration: 10 * time.Minute},
+		MFAIssuer: "echo_tutrial",
+	}
+	env.interactor = NewAuthInteractor(env.userRepo, env.throttleRepo, env.mfaRepo, testPasswords, env.tokens, env.secrets, env.auditor, settings, env.clock, log.New(&env.logs, "", 0))
+	return env
+}
+
+// testPasswords hashes with bcrypt at the minimum cost, like hashPassword, so
+// that tests stay fast and their hashes are not due for rehashing.
+var testPasswords, _ = password.New(password.Config{
+	Algorithm:  password.AlgorithmBcrypt,
+	BcryptCost: bcrypt.MinCost,
+	Argon2id:   password.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+})
+
+func hashPassword(t *testing.T, plain string) string {
+	h, err := bcrypt.GenerateFromPassword([]byte(plain), bcrypt.MinCost)
+	assert.NoError(t, err)
+	return string(h)
+}
+
+func TestLockoutPolicy_LockoutDuration(t *testing.T) {
+	policy := LockoutPolicy{BaseDuration: time.Minute, MaxDuration: 10 * time.Minute}
+
+	assert.Equal(t, time.Minute, policy.lockoutDuration(0))
+	assert.Equal(t, 2*time.Minute, policy.lockoutDuration(1))
+	assert.Equal(t, 8*time.Minute, policy.lockoutDuration(3))
+	assert.Equal(t, 10*time.Minute, policy.lockoutDuration(4))
+	assert.Equal(t, 10*time.Minute, policy.lockoutDuration(100))
+}
+
+func TestAuthInteractor_Login_Success(t *testing.T) {
+	env := setupAuthTestEnv()
+	user := &domain.User{ID: "user-1", Email: "user@example.com", Role: domain.RoleUser, Password: hashPassword(t, "password123")}
+
+	env.throttleRepo.On("GetThrottle", mock.Anything, domain.ThrottleScopeIP, "192.0.2.1").Return(nil, nil).Once()
+	env.throttleRepo.On("GetThrottle", mock.Anything, domain.ThrottleScopeAccount, "user@example.com").Return(nil, nil).Once()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(nil, nil).Once()
+	env.throttleRepo.On("Reset", mock.Anything, domain.ThrottleScopeAccount, "user@example.com").Return(nil).Once()
+
+	result, err := env.interactor.Login(context.Background(), " User@Example.com ", "password123", "192.0.2.1")
+
+	assert.NoError(t, err)
+	assert.False(t, result.MFARequired)
+	assert.Equal(t, "user-1", result.User.ID)
+	assert.Empty(t, result.User.Password)
+	assert.NotEmpty(t, result.AccessToken)
+	assert.Equal(t, env.clock.Now().Add(time.Hour), result.ExpiresAt)
+	env.throttleRepo.AssertExpectations(t)
+	env.userRepo.AssertExpectations(t)
+}
+
+func TestAuthInteractor_Login_RehashesOutdatedHash(t *testing.T) {
+	env := setupAuthTestEnv()
+	// Move from the bcrypt hashes of hashPassword to Argon2id
+	passwords, err := password.New(password.Config{
+		Algorithm:  password.AlgorithmArgon2id,
+		BcryptCost: bcrypt.MinCost,
+		Argon2id:   password.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+	})
+	assert.NoError(t, err)
+	env.interactor = NewAuthInteractor(env.userRepo, env.throttleRepo, env.mfaRepo, passwords, env.tokens, env.secrets, env.auditor, AuthSettings{}, env.clock, log.New(&env.logs, "", 0))
+	oldHash := hashPassword(t, "password123")
+	user := &domain.User{ID: "user-1", Email: "user@example.com", Password: oldHash}
+	var newHash string
+
+	env.throttleRepo.On("GetThrottle", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	env.userRepo.On("UpdateUserPasswordHash", mock.Anything, "user-1", oldHash, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { newHash = args.String(3) }).Return(true, nil).Once()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(nil, nil).Once()
+	env.throttleRepo.On("Reset", mock.Anything, domain.ThrottleScopeAccount, "user@example.com").Return(nil).Once()
+
+	result, err := env.interactor.Login(context.Background(), "user@example.com", "password123", "192.0.2.1")
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.User.Password)
+	assert.Contains(t, newHash, "$argon2id$")
+	ok, err := passwords.Verify(newHash, "password123")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, passwords.NeedsRehash(newHash))
+	env.userRepo.AssertExpectations(t)
+}
+
+func TestAuthInteractor_Login_RehashFailureDoesNotFailLogin(t *testing.T) {
+	env := setupAuthTestEnv()
+	passwords, err := password.New(password.Config{Algorithm: password.AlgorithmBcrypt, BcryptCost: bcrypt.MinCost + 1, Argon2id: password.DefaultArgon2idParams()})
+	assert.NoError(t, err)
+	env.interactor = NewAuthInteractor(env.userRepo, env.throttleRepo, env.mfaRepo, passwords, env.tokens, env.secrets, env.auditor, AuthSettings{}, env.clock, log.New(&env.logs, "", 0))
+	user := &domain.User{ID: "user-1", Email: "user@example.com", Password: hashPassword(t, "password123")}
+
+	env.throttleRepo.On("GetThrottle", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	env.userRepo.On("UpdateUserPasswordHash", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(false, errors.New("database is read-only")).Once()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(nil, nil).Once()
+	env.throttleRepo.On("Reset", mock.Anything, domain.ThrottleScopeAccount, "user@example.com").Return(nil).Once()
+
+	result, err := env.interactor.Login(context.Background(), "user@example.com", "password123", "192.0.2.1")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.AccessToken)
+	assert.Contains(t, env.logs.String(), "database is read-only")
+	env.userRepo.AssertExpectations(t)
+}
+
+func TestAuthInteractor_Login_WrongPasswordDoesNotRehash(t *testing.T) {
+	env := setupAuthTestEnv()
+	passwords, err := password.New(password.Config{Algorithm: password.AlgorithmBcrypt, BcryptCost: bcrypt.MinCost + 1, Argon2id: password.DefaultArgon2idParams()})
+	assert.NoError(t, err)
+	env.interactor = NewAuthInteractor(env.userRepo, env.throttleRepo, env.mfaRepo, passwords, env.tokens, env.secrets, env.auditor, AuthSettings{}, env.clock, log.New(&env.logs, "", 0))
+	user := &domain.User{ID: "user-1", Email: "user@example.com", Password: hashPassword(t, "password123")}
+
+	env.throttleRepo.On("GetThrottle", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	env.throttleRepo.On("RecordFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&domain.LoginThrottle{FailedCount: 1}, nil).Twice()
+
+	_, err = env.interactor.Login(context.Background(), "user@example.com", "wrong", "192.0.2.1")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	env.userRepo.AssertNotCalled(t, "UpdateUserPasswordHash", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthInteractor_Login_WrongPassword(t *testing.T) {
+	env := setupAuthTestEnv()
+	user := &domain.User{ID: "user-1", Email: "user@example.com", Password: hashPassword(t, "password123")}
+	now := env.clock.Now()
+
+	env.throttleRepo.On("GetThrottle", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	env.throttleRepo.On("RecordFailure", mock.Anything, domain.ThrottleScopeAccount, "user@example.com", now).
+		Return(&domain.LoginThrottle{FailedCount: 1}, nil).Once()
+	env.throttleRepo.On("RecordFailure", mock.Anything, domain.ThrottleScopeIP, "192.0.2.1", now).
+		Return(&domain.LoginThrottle{FailedCount: 1}, nil).Once()
+
+	_, err := env.interactor.Login(context.Background(), "user@example.com", "wrong", "192.0.2.1")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	env.throttleRepo.AssertExpectations(t)
+	env.throttleRepo.AssertNotCalled(t, "Lock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthInteractor_Login_UnknownEmailComparesDummyHash(t *testing.T) {
+	env := setupAuthTestEnv()
+	now := env.clock.Now()
+
+	env.throttleRepo.On("GetThrottle", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "nobody@example.com").Return(nil, nil).Once()
+	env.throttleRepo.On("RecordFailure", mock.Anything, domain.ThrottleScopeAccount, "nobody@example.com", now).
+		Return(&domain.LoginThrottle{FailedCount: 1}, nil).Once()
+	env.throttleRepo.On("RecordFailure", mock.Anything, domain.ThrottleScopeIP, "192.0.2.1", now).
+		Return(&domain.LoginThrottle{FailedCount: 1}, nil).Once()
+
+	_, err := env.interactor.Login(context.Background(), "nobody@example.com", "dummy-password-for-timing", "192.0.2.1")
+
+	// Even the dummy hash's own password must not authenticate an unknown email
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	env.throttleRepo.AssertExpectations(t)
+}
+
+func TestAuthInteractor_Login_LocksAccountAtThreshold(t *testing.T) {
+	env := setupAuthTestEnv()
+	user := &domain.User{ID: "user-1", Email: "user@example.com", Password: hashPassword(t, "password123")}
+	now := env.clock.Now()
+
+	env.throttleRepo.On("GetThrottle", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	env.throttleRepo.On("RecordFailure", mock.Anything, domain.ThrottleScopeAccount, "user@example.com", now).
+		Return(&domain.LoginThrottle{FailedCount: 3, LockoutCount: 2}, nil).Once()
+	env.throttleRepo.On("RecordFailure", mock.Anything, domain.ThrottleScopeIP, "192.0.2.1", now).
+		Return(&domain.LoginThrottle{FailedCount: 3}, nil).Once()
+	// Third lockout: 1m doubled twice
+	env.throttleRepo.On("Lock", mock.Anything, domain.ThrottleScopeAccount, "user@example.com", now.Add(4*time.Minute)).Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionLoginLockout && e.TargetID == "user-1" && e.IP == "192.0.2.1" &&
+			e.Metadata["scope"] == "account" && e.Metadata["lockout_count"] == "3"
+	})).Return(nil).Once()
+
+	_, err := env.interactor.Login(context.Background(), "user@example.com", "wrong", "192.0.2.1")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	env.throttleRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestAuthInteractor_Login_AccountLocked(t *testing.T) {
+	env := setupAuthTestEnv()
+	until := env.clock.Now().Add(time.Minute)
+
+	env.throttleRepo.On("GetThrottle", mock.Anything, domain.ThrottleScopeIP, "192.0.2.1").Return(nil, nil).Once()
+	env.throttleRepo.On("GetThrottle", mock.Anything, domain.ThrottleScopeAccount, "user@example.com").
+		Return(&domain.LoginThrottle{LockedUntil: until}, nil).Once()
+
+	_, err := env.interactor.Login(context.Background(), "user@example.com", "password123", "192.0.2.1")
+
+	var lockedErr *domain.LockedError
+	assert.True(t, errors.As(err, &lockedErr))
+	assert.Equal(t, until, lockedErr.Until)
+	env.userRepo.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+}
+
+func TestAuthInteractor_Login_IPLocked(t *testing.T) {
+	env := setupAuthTestEnv()
+	until := env.clock.Now().Add(time.Minute)
+
+	env.throttleRepo.On("GetThrottle", mock.Anything, domain.ThrottleScopeIP, "192.0.2.1").
+		Return(&domain.LoginThrottle{LockedUntil: until}, nil).Once()
+
+	_, err := env.interactor.Login(context.Background(), "user@example.com", "password123", "192.0.2.1")
+
+	var lockedErr *domain.LockedError
+	assert.True(t, errors.As(err, &lockedErr))
+	env.throttleRepo.AssertExpectations(t)
+}
+
+func TestAuthInteractor_Login_ExpiredLockAllowsLogin(t *testing.T) {
+	env := setupAuthTestEnv()
+	user := &domain.User{ID: "user-1", Email: "user@example.com", Password: hashPassword(t, "password123")}
+
+	env.throttleRepo.On("GetThrottle", mock.Anything, domain.ThrottleScopeIP, "192.0.2.1").Return(nil, nil).Once()
+	env.throttleRepo.On("GetThrottle", mock.Anything, domain.ThrottleScopeAccount, "user@example.com").
+		Return(&domain.LoginThrottle{LockedUntil: env.clock.Now().Add(-time.Second)}, nil).Once()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(nil, nil).Once()
+	env.throttleRepo.On("Reset", mock.Anything, domain.ThrottleScopeAccount, "user@example.com").Return(nil).Once()
+
+	_, err := env.interactor.Login(context.Background(), "user@example.com", "password123", "192.0.2.1")
+
+	assert.NoError(t, err)
+}
+
+func TestAuthInteractor_Login_Error_Validation(t *testing.T) {
+	env := setupAuthTestEnv()
+
+	_, err := env.interactor.Login(context.Background(), "", "password123", "192.0.2.1")
+	assert.Error(t, err)
+	assert.Equal(t, "email and password are required", err.Error())
+}
+
+func TestAuthInteractor_UnlockUser_Success(t *testing.T) {
+	env := setupAuthTestEnv()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "admin-1", Role: domain.RoleAdmin, MFA: true})
+
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", Email: "User@example.com"}, nil).Once()
+	env.throttleRepo.On("Reset", mock.Anything, domain.ThrottleScopeAccount, "user@example.com").Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionUserUnlocked && e.ActorID == "admin-1" && e.TargetID == "user-1"
+	})).Return(nil).Once()
+
+	err := env.interactor.UnlockUser(ctx, "user-1")
+
+	assert.NoError(t, err)
+	env.userRepo.AssertExpectations(t)
+	env.throttleRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestAuthInteractor_UnlockUser_Forbidden(t *testing.T) {
+	env := setupAuthTestEnv()
+
+	err := env.interactor.UnlockUser(context.Background(), "user-1")
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "user-2", Role: domain.RoleUser, MFA: true})
+	err = env.interactor.UnlockUser(ctx, "user-1")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	ctx = auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "admin-1", Role: domain.RoleAdmin})
+	err = env.interactor.UnlockUser(ctx, "user-1")
+	assert.ErrorIs(t, err, domain.ErrMFARequired)
+}
+
+func TestAuthInteractor_UnlockUser_NotFound(t *testing.T) {
+	env := setupAuthTestEnv()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "admin-1", Role: domain.RoleAdmin, MFA: true})
+
+	env.userRepo.On("GetUserByID", mock.Anything, "missing").Return(nil, nil).Once()
+
+	err := env.interactor.UnlockUser(ctx, "missing")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"context"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/domain"
+	"apiserver/internal/tenant"
+)
+
+// requireUser returns the authenticated caller.
+func requireUser(ctx context.Context) (*auth.Principal, error) {
+	p := auth.PrincipalFrom(ctx)
+	if p == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+	return p, nil
+}
+
+// requireSession returns the caller when it signed in interactively. Operations
+// that manage credentials cannot be performed with an API key.
+func requireSession(ctx context.Context) (*auth.Principal, error) {
+	p, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsAPIKey() {
+		return nil, domain.ErrForbidden
+	}
+	return p, nil
+}
+
+// requireAdmin returns the caller when it is an administrator who signed in
+// with a second factor. Admin operations are refused for password-only sessions.
+// API keys carry no second factor; they need the users:admin scope instead,
+// which can only be granted from an MFA session.
+func requireAdmin(ctx context.Context) (*auth.Principal, error) {
+	p, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !p.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
+	if p.IsAPIKey() {
+		if !p.HasScope(domain.ScopeUsersAdmin) {
+			return nil, domain.ErrForbidden
+		}
+		return p, nil
+	}
+	if !p.MFA {
+		return nil, domain.ErrMFARequired
+	}
+	return p, nil
+}
+
+// requireDirectoryRead returns the caller when it may read the global user
+// directory, which spans every organization: administrators, or API keys of
+// administrators, with the users:read scope. Members of an organization read
+// its users through the org-scoped operations instead.
+func requireDirectoryRead(ctx context.Context) (*auth.Principal, error) {
+	p, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !p.IsAdmin() || !p.HasScope(domain.ScopeUsersRead) {
+		return nil, domain.ErrForbidden
+	}
+	return p, nil
+}
+
+// userWriteGrantKey marks a context in which another interactor of this
+// package has already authorized changing one user's record, e.g. an
+// organization admin updating a member. Other packages cannot set it.
+type userWriteGrantKey struct{}
+
+// withUserWriteGrant lets the user operations change the record of userID on
+// behalf of a caller that was authorized by the interactor granting it.
+func withUserWriteGrant(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userWriteGrantKey{}, userID)
+}
+
+// authorizeUserWrite allows changing the global record of userID, which is
+// shared by every organization the user belongs to, to administrators and to
+// the user itself when signed in interactively, unless the write was granted
+// by withUserWriteGrant. Organization roles confer nothing here, and API keys
+// need the users:admin scope even for their owner's record.
+func authorizeUserWrite(ctx context.Context, userID string) error {
+	if err := checkScope(ctx, domain.ScopeUsersWrite); err != nil {
+		return err
+	}
+	p, err := requireUser(ctx)
+	if err != nil {
+		return err
+	}
+	if granted, _ := ctx.Value(userWriteGrantKey{}).(string); granted != "" && granted == userID {
+		return nil
+	}
+	if p.UserID == userID && !p.IsAPIKey() {
+		return nil
+	}
+	_, err = requireAdmin(ctx)
+	return err
+}
+
+// checkScope refuses API key callers whose key was not granted scope. Sessions
+// and anonymous callers are not restricted by scopes.
+func checkScope(ctx context.Context, scope string) error {
+	p := auth.PrincipalFrom(ctx)
+	if p != nil && !p.HasScope(scope) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// orgRoleRank orders organization roles from least to most privileged.
+var orgRoleRank = map[string]int{
+	domain.OrgRoleMember: 1,
+	domain.OrgRoleAdmin:  2,
+	domain.OrgRoleOwner:  3,
+}
+
+// requireOrgRole returns the tenant selected for the request when the caller
+// holds at least role in it.
+func requireOrgRole(ctx context.Context, role string) (*tenant.Tenant, error) {
+	if _, err := requireUser(ctx); err != nil {
+		return nil, err
+	}
+	t := tenant.From(ctx)
+	if t == nil {
+		return nil, domain.ErrNoTenant
+	}
+	if orgRoleRank[t.Role] < orgRoleRank[role] {
+		return nil, domain.ErrForbidden
+	}
+	return t, nil
+}
@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif" // Decoders for avatarTypes
+	_ "image/jpeg"
+	"image/png"
+	"net/http"
+
+	"apiserver/internal/domain"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Avatars are stored as square PNGs in these sizes, in pixels.
+const (
+	avatarSize          = 256
+	avatarThumbnailSize = 64
+)
+
+// maxAvatarPixels bounds the dimensions of an upload. A small compressed file
+// can still decode to an image too large to hold in memory.
+const maxAvatarPixels = 4096 * 4096
+
+// avatarTypes are the sniffed media types accepted as avatars.
+var avatarTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// decodeAvatar checks that data holds a supported image of acceptable
+// dimensions and decodes it. The type is sniffed from the content; the name
+// and media type of the upload are not trusted.
+func decodeAvatar(data []byte) (image.Image, error) {
+	if !avatarTypes[http.DetectContentType(data)] {
+		return nil, fmt.Errorf("%w: avatar must be a JPEG, PNG, GIF or WebP image", domain.ErrInvalidArgument)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: avatar image is corrupt", domain.ErrInvalidArgument)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxAvatarPixels {
+		return nil, fmt.Errorf("%w: avatar must not exceed %d pixels", domain.ErrInvalidArgument, maxAvatarPixels)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: avatar image is corrupt", domain.ErrInvalidArgument)
+	}
+	return img, nil
+}
+
+// squareThumbnail crops the largest centered square from src and scales it
+// to size×size PNG.
+func squareThumbnail(src image.Image, size int) ([]byte, error) {
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+	crop := image.Rect(x0, y0, x0+side, y0+side)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Src, nil)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
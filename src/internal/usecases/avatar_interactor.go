@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+	"apiserver/internal/storage"
+	"github.com/google/uuid"
+)
+
+// AvatarSettings configures avatar uploads.
+type AvatarSettings struct {
+	MaxBytes int64 // Largest accepted upload
+}
+
+// DefaultAvatarSettings returns the settings used when none are configured.
+func DefaultAvatarSettings() AvatarSettings {
+	return AvatarSettings{MaxBytes: 5 << 20}
+}
+
+// AvatarInteractor defines the interface for user avatars.
+type AvatarInteractor interface {
+	// UploadAvatar replaces the avatar of userID with the image read from r
+	// and returns the updated user. Users may change their own avatar and
+	// admins anyone's.
+	UploadAvatar(ctx context.Context, userID string, r io.Reader) (*domain.User, error)
+}
+
+// avatarInteractor implements AvatarInteractor.
+type avatarInteractor struct {
+	userRepo repositories.UserRepository
+	store    storage.BlobStore
+	tx       repositories.TxManager
+	auditor  audit.Recorder
+	clock    clock.Clock
+	settings AvatarSettings
+}
+
+// NewAvatarInteractor creates a new instance of AvatarInteractor. Images are
+// written to store, which must serve them publicly.
+func NewAvatarInteractor(userRepo repositories.UserRepository, store storage.BlobStore, tx repositories.TxManager, auditor audit.Recorder, clk clock.Clock, settings AvatarSettings) AvatarInteractor {
+	if settings.MaxBytes <= 0 {
+		settings.MaxBytes = DefaultAvatarSettings().MaxBytes
+	}
+	return &avatarInteractor{userRepo: userRepo, store: store, tx: tx, auditor: auditor, clock: clk, settings: settings}
+}
+
+func (uc *avatarInteractor) UploadAvatar(ctx context.Context, userID string, r io.Reader) (*domain.User, error) {
+	if err := checkScope(ctx, domain.ScopeUsersWrite); err != nil {
+		return nil, err
+	}
+	principal, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if principal.UserID != userID {
+		if _, err := requireAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, domain.ErrNotFound
+	}
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, uc.settings.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > uc.settings.MaxBytes {
+		return nil, fmt.Errorf("%w: avatar must not exceed %d bytes", domain.ErrInvalidArgument, uc.settings.MaxBytes)
+	}
+	img, err := decodeAvatar(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each size is stored under a fixed key, so a new upload replaces the old
+	// files. The version in the URL keeps caches from serving the old image.
+	version := strconv.FormatInt(uc.clock.Now().UnixMilli(), 10)
+	urls := make(map[int]string, 2)
+	for _, size := range []int{avatarSize, avatarThumbnailSize} {
+		encoded, err := squareThumbnail(img, size)
+		if err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("avatars/%s/%d.png", user.ID, size)
+		if err := uc.store.Put(ctx, key, encoded, "image/png"); err != nil {
+			return nil, err
+		}
+		urls[size] = uc.store.URL(key) + "?v=" + version
+	}
+
+	var updated *domain.User
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if updated, err = uc.userRepo.UpdateUserAvatar(ctx, user.ID, urls[avatarSize], urls[avatarThumbnailSize]); err != nil {
+			return err
+		}
+		if updated == nil {
+			return domain.ErrNotFound
+		}
+		return uc.auditor.Record(ctx, domain.AuditEvent{
+			Action:     domain.AuditActionUserAvatarUpdated,
+			ActorID:    principal.UserID,
+			TargetID:   user.ID,
+			Changes:    []domain.FieldChange{{Field: "avatar_url", Old: user.AvatarURL, New: updated.AvatarURL}},
+			OccurredAt: uc.clock.Now(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"apiserver/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testAvatarUserID = "0b5c2f3e-8f8e-4a52-9d2c-6f0f6c1f2a11"
+
+type avatarTestEnv struct {
+	userRepo   *mocks.MockUserRepository
+	auditor    *auditmocks.MockRecorder
+	dir        string
+	interactor AvatarInteractor
+}
+
+func setupAvatarTestEnv(t *testing.T) *avatarTestEnv {
+	env := &avatarTestEnv{
+		userRepo: new(mocks.MockUserRepository),
+		auditor:  new(auditmocks.MockRecorder),
+		dir:      t.TempDir(),
+	}
+	store := storage.NewLocalBlobStore(env.dir, "https://cdn.example.com")
+	env.interactor = NewAvatarInteractor(env.userRepo, store, new(mocks.InlineTxManager), env.auditor, clock.NewFake(time.UnixMilli(1700000000000)), AvatarSettings{MaxBytes: 64 << 10})
+	return env
+}
+
+// testPNG returns a w×h PNG whose left half is red and right half blue.
+func testPNG(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// pngHeader returns the start of a w×h PNG, which is enough for
+// image.DecodeConfig, without encoding the pixels.
+func pngHeader(w, h int) []byte {
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], uint32(w))
+	binary.BigEndian.PutUint32(ihdr[8:], uint32(h))
+	ihdr[12], ihdr[13] = 8, 6 // 8-bit RGBA
+	out := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0d")
+	out = append(out, ihdr...)
+	return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestAvatarInteractor_UploadAvatar(t *testing.T) {
+	env := setupAvatarTestEnv(t)
+	avatarURL := "https://cdn.example.com/avatars/" + testAvatarUserID + "/256.png?v=1700000000000"
+	thumbnailURL := "https://cdn.example.com/avatars/" + testAvatarUserID + "/64.png?v=1700000000000"
+	env.userRepo.On("GetUserByID", mock.Anything, testAvatarUserID).Return(&domain.User{ID: testAvatarUserID}, nil).Once()
+	env.userRepo.On("UpdateUserAvatar", mock.Anything, testAvatarUserID, avatarURL, thumbnailURL).
+		Return(&domain.User{ID: testAvatarUserID, AvatarURL: avatarURL, AvatarThumbnailURL: thumbnailURL}, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionUserAvatarUpdated && e.TargetID == testAvatarUserID && e.Changes[0].New == avatarURL
+	})).Return(nil).Once()
+
+	got, err := env.interactor.UploadAvatar(sessionContext(testAvatarUserID, domain.RoleUser, false), testAvatarUserID, bytes.NewReader(testPNG(t, 300, 200)))
+
+	require.NoError(t, err)
+	assert.Equal(t, avatarURL, got.AvatarURL)
+	for _, size := range []int{256, 64} {
+		data, err := os.ReadFile(filepath.Join(env.dir, "avatars", testAvatarUserID, strconv.Itoa(size)+".png"))
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		// The centered square keeps both halves of the source
+		assert.Equal(t, image.Rect(0, 0, size, size), img.Bounds())
+		r, _, _, _ := img.At(0, size/2).RGBA()
+		_, _, b, _ := img.At(size-1, size/2).RGBA()
+		assert.Equal(t, uint32(0xffff), r)
+		assert.Equal(t, uint32(0xffff), b)
+	}
+	env.userRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestAvatarInteractor_UploadAvatar_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"not an image", []byte("%PDF-1.4 not an image"), "must be a JPEG, PNG, GIF or WebP image"},
+		{"too large", append(testPNG(t, 8, 8), make([]byte, 64<<10)...), "must not exceed 65536 bytes"},
+		{"corrupt", testPNG(t, 8, 8)[:40], "corrupt"},
+		{"too many pixels", pngHeader(5000, 4000), "pixels"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := setupAvatarTestEnv(t)
+			env.userRepo.On("GetUserByID", mock.Anything, testAvatarUserID).Return(&domain.User{ID: testAvatarUserID}, nil).Once()
+
+			_, err := env.interactor.UploadAvatar(sessionContext(testAvatarUserID, domain.RoleUser, false), testAvatarUserID, bytes.NewReader(tt.data))
+
+			assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+			assert.ErrorContains(t, err, tt.want)
+			env.userRepo.AssertNotCalled(t, "UpdateUserAvatar", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			entries, _ := os.ReadDir(env.dir)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestAvatarInteractor_UploadAvatar_OtherUserRequiresAdmin(t *testing.T) {
+	env := setupAvatarTestEnv(t)
+
+	_, err := env.interactor.UploadAvatar(sessionContext("someone-else", domain.RoleUser, false), testAvatarUserID, bytes.NewReader(testPNG(t, 8, 8)))
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	env.userRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,315 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+	"github.com/google/uuid"
+)
+
+// maxGroupDescriptionLength matches user_groups.description.
+const maxGroupDescriptionLength = 1000
+
+// GroupInteractor defines the interface for managing user groups. Groups and
+// their members are managed by administrators; users may also list the groups
+// they belong to.
+type GroupInteractor interface {
+	CreateGroup(ctx context.Context, name, description string) (*domain.Group, error)
+	ListGroups(ctx context.Context) ([]domain.Group, error)
+	GetGroup(ctx context.Context, id string) (*domain.Group, error)
+	// UpdateGroup renames a group or changes its description. Nil fields are
+	// left unchanged.
+	UpdateGroup(ctx context.Context, id string, name, description *string) (*domain.Group, error)
+	// DeleteGroup deletes a group and its memberships. The users are kept.
+	DeleteGroup(ctx context.Context, id string) error
+	AddGroupMember(ctx context.Context, groupID, userID string) error
+	RemoveGroupMember(ctx context.Context, groupID, userID string) error
+	ListGroupMembers(ctx context.Context, groupID string) ([]domain.User, error)
+	// ListUserGroups returns the groups userID belongs to.
+	ListUserGroups(ctx context.Context, userID string) ([]domain.Group, error)
+}
+
+// groupInteractor implements GroupInteractor.
+type groupInteractor struct {
+	groupRepo repositories.GroupRepository
+	userRepo  repositories.UserRepository
+	tx        repositories.TxManager
+	auditor   audit.Recorder
+	clock     clock.Clock
+}
+
+// NewGroupInteractor creates a new instance of GroupInteractor.
+func NewGroupInteractor(groupRepo repositories.GroupRepository, userRepo repositories.UserRepository, tx repositories.TxManager, auditor audit.Recorder, clk clock.Clock) GroupInteractor {
+	return &groupInteractor{groupRepo: groupRepo, userRepo: userRepo, tx: tx, auditor: auditor, clock: clk}
+}
+
+// normalizeGroupName trims name and checks that it fits user_groups.name.
+func normalizeGroupName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 255 {
+		return "", fmt.Errorf("%w: name is required and must not exceed 255 bytes", domain.ErrInvalidArgument)
+	}
+	return name, nil
+}
+
+func normalizeGroupDescription(description string) (string, error) {
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > maxGroupDescriptionLength {
+		return "", fmt.Errorf("%w: description must not exceed %d characters", domain.ErrInvalidArgument, maxGroupDescriptionLength)
+	}
+	return description, nil
+}
+
+func (uc *groupInteractor) CreateGroup(ctx context.Context, name, description string) (*domain.Group, error) {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if name, err = normalizeGroupName(name); err != nil {
+		return nil, err
+	}
+	if description, err = normalizeGroupDescription(description); err != nil {
+		return nil, err
+	}
+
+	var created *domain.Group
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.checkNameAvailable(ctx, name); err != nil {
+			return err
+		}
+		if created, err = uc.groupRepo.CreateGroup(ctx, &domain.Group{Name: name, Description: description}); err != nil {
+			return err
+		}
+		return uc.recordGroupChange(ctx, principal.UserID, domain.AuditActionGroupCreated, created, "", nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (uc *groupInteractor) ListGroups(ctx context.Context) ([]domain.Group, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return uc.groupRepo.ListGroups(ctx)
+}
+
+func (uc *groupInteractor) GetGroup(ctx context.Context, id string) (*domain.Group, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return uc.getGroup(ctx, id)
+}
+
+// getGroup returns the group or ErrGroupNotFound.
+func (uc *groupInteractor) getGroup(ctx context.Context, id string) (*domain.Group, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, domain.ErrGroupNotFound
+	}
+	group, err := uc.groupRepo.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, domain.ErrGroupNotFound
+	}
+	return group, nil
+}
+
+// checkNameAvailable refuses names already used by another group.
+func (uc *groupInteractor) checkNameAvailable(ctx context.Context, name string) error {
+	existing, err := uc.groupRepo.GetGroupByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("%w: a group named %q already exists", domain.ErrConflict, name)
+	}
+	return nil
+}
+
+func (uc *groupInteractor) UpdateGroup(ctx context.Context, id string, name, description *string) (*domain.Group, error) {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *domain.Group
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := uc.getGroup(ctx, id)
+		if err != nil {
+			return err
+		}
+		var changes []domain.FieldChange
+		if name != nil {
+			newName, err := normalizeGroupName(*name)
+			if err != nil {
+				return err
+			}
+			if newName != group.Name {
+				if err := uc.checkNameAvailable(ctx, newName); err != nil {
+					return err
+				}
+				changes = append(changes, domain.FieldChange{Field: "name", Old: group.Name, New: newName})
+				group.Name = newName
+			}
+		}
+		if description != nil {
+			newDescription, err := normalizeGroupDescription(*description)
+			if err != nil {
+				return err
+			}
+			if newDescription != group.Description {
+				changes = append(changes, domain.FieldChange{Field: "description", Old: group.Description, New: newDescription})
+				group.Description = newDescription
+			}
+		}
+		if len(changes) == 0 {
+			updated = group
+			return nil
+		}
+
+		if updated, err = uc.groupRepo.UpdateGroup(ctx, group); err != nil {
+			return err
+		}
+		if updated == nil {
+			return domain.ErrGroupNotFound
+		}
+		return uc.recordGroupChange(ctx, principal.UserID, domain.AuditActionGroupUpdated, updated, "", changes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (uc *groupInteractor) DeleteGroup(ctx context.Context, id string) error {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := uc.getGroup(ctx, id)
+		if err != nil {
+			return err
+		}
+		// Memberships are removed with the group
+		if err := uc.groupRepo.DeleteGroup(ctx, group.ID); err != nil {
+			return err
+		}
+		return uc.recordGroupChange(ctx, principal.UserID, domain.AuditActionGroupDeleted, group, "", nil)
+	})
+}
+
+func (uc *groupInteractor) AddGroupMember(ctx context.Context, groupID, userID string) error {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return fmt.Errorf("%w: invalid user ID", domain.ErrInvalidArgument)
+	}
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := uc.getGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		user, err := uc.userRepo.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return domain.ErrNotFound
+		}
+		added, err := uc.groupRepo.AddGroupMember(ctx, group.ID, user.ID)
+		if err != nil {
+			return err
+		}
+		if !added {
+			return fmt.Errorf("%w: the user already belongs to the group", domain.ErrConflict)
+		}
+		return uc.recordGroupChange(ctx, principal.UserID, domain.AuditActionGroupMemberAdded, group, user.ID, nil)
+	})
+}
+
+func (uc *groupInteractor) RemoveGroupMember(ctx context.Context, groupID, userID string) error {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return domain.ErrNotFound
+	}
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := uc.getGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		removed, err := uc.groupRepo.RemoveGroupMember(ctx, group.ID, userID)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return domain.ErrNotFound
+		}
+		return uc.recordGroupChange(ctx, principal.UserID, domain.AuditActionGroupMemberRemoved, group, userID, nil)
+	})
+}
+
+func (uc *groupInteractor) ListGroupMembers(ctx context.Context, groupID string) ([]domain.User, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	group, err := uc.getGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.groupRepo.ListGroupMembers(ctx, group.ID)
+}
+
+func (uc *groupInteractor) ListUserGroups(ctx context.Context, userID string) ([]domain.Group, error) {
+	if err := checkScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+	principal, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Users may list their own groups
+	if principal.UserID != userID {
+		if _, err := requireAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, domain.ErrNotFound
+	}
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrNotFound
+	}
+	return uc.groupRepo.ListUserGroups(ctx, userID)
+}
+
+// recordGroupChange appends an audit event for a change to group. targetID is
+// the user added or removed, if any.
+func (uc *groupInteractor) recordGroupChange(ctx context.Context, actorID, action string, group *domain.Group, targetID string, changes []domain.FieldChange) error {
+	return uc.auditor.Record(ctx, domain.AuditEvent{
+		Action:     action,
+		ActorID:    actorID,
+		TargetID:   targetID,
+		Changes:    changes,
+		Metadata:   map[string]string{"group_id": group.ID, "group_name": group.Name},
+		OccurredAt: uc.clock.Now(),
+	})
+}
@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testGroupID  = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	testMemberID = "16fd2706-8baf-433b-82eb-8c7fada847da"
+)
+
+type groupTestEnv struct {
+	groupRepo  *mocks.MockGroupRepository
+	userRepo   *mocks.MockUserRepository
+	auditor    *auditmocks.MockRecorder
+	interactor GroupInteractor
+}
+
+func setupGroupTestEnv() *groupTestEnv {
+	env := &groupTestEnv{
+		groupRepo: new(mocks.MockGroupRepository),
+		userRepo:  new(mocks.MockUserRepository),
+		auditor:   new(auditmocks.MockRecorder),
+	}
+	env.interactor = NewGroupInteractor(env.groupRepo, env.userRepo, new(mocks.InlineTxManager), env.auditor, clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	return env
+}
+
+func TestGroupInteractor_CreateGroup(t *testing.T) {
+	env := setupGroupTestEnv()
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+	env.groupRepo.On("GetGroupByName", mock.Anything, "Ops").Return(nil, nil).Once()
+	env.groupRepo.On("CreateGroup", mock.Anything, &domain.Group{Name: "Ops", Description: "On-call"}).Return(&domain.Group{ID: testGroupID, Name: "Ops", Description: "On-call"}, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionGroupCreated && e.ActorID == "admin-1" && e.Metadata["group_id"] == testGroupID
+	})).Return(nil).Once()
+
+	got, err := env.interactor.CreateGroup(ctx, "  Ops ", " On-call ")
+
+	require.NoError(t, err)
+	assert.Equal(t, testGroupID, got.ID)
+	env.groupRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestGroupInteractor_CreateGroup_DuplicateName(t *testing.T) {
+	env := setupGroupTestEnv()
+	env.groupRepo.On("GetGroupByName", mock.Anything, "Ops").Return(&domain.Group{ID: testGroupID, Name: "Ops"}, nil).Once()
+
+	_, err := env.interactor.CreateGroup(sessionContext("admin-1", domain.RoleAdmin, true), "Ops", "")
+
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	env.groupRepo.AssertNotCalled(t, "CreateGroup", mock.Anything, mock.Anything)
+}
+
+func TestGroupInteractor_CreateGroup_RequiresAdmin(t *testing.T) {
+	env := setupGroupTestEnv()
+
+	_, err := env.interactor.CreateGroup(sessionContext("user-1", domain.RoleUser, false), "Ops", "")
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestGroupInteractor_UpdateGroup_RecordsChanges(t *testing.T) {
+	env := setupGroupTestEnv()
+	name := "Platform"
+	env.groupRepo.On("GetGroup", mock.Anything, testGroupID).Return(&domain.Group{ID: testGroupID, Name: "Ops", Description: "On-call"}, nil).Once()
+	env.groupRepo.On("GetGroupByName", mock.Anything, "Platform").Return(nil, nil).Once()
+	env.groupRepo.On("UpdateGroup", mock.Anything, &domain.Group{ID: testGroupID, Name: "Platform", Description: "On-call"}).Return(&domain.Group{ID: testGroupID, Name: "Platform", Description: "On-call"}, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionGroupUpdated && len(e.Changes) == 1 &&
+			e.Changes[0] == domain.FieldChange{Field: "name", Old: "Ops", New: "Platform"}
+	})).Return(nil).Once()
+
+	got, err := env.interactor.UpdateGroup(sessionContext("admin-1", domain.RoleAdmin, true), testGroupID, &name, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Platform", got.Name)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestGroupInteractor_DeleteGroup_NotFound(t *testing.T) {
+	env := setupGroupTestEnv()
+	env.groupRepo.On("GetGroup", mock.Anything, testGroupID).Return(nil, nil).Once()
+
+	err := env.interactor.DeleteGroup(sessionContext("admin-1", domain.RoleAdmin, true), testGroupID)
+
+	assert.ErrorIs(t, err, domain.ErrGroupNotFound)
+	env.groupRepo.AssertNotCalled(t, "DeleteGroup", mock.Anything, mock.Anything)
+}
+
+func TestGroupInteractor_AddGroupMember(t *testing.T) {
+	env := setupGroupTestEnv()
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+	env.groupRepo.On("GetGroup", mock.Anything, testGroupID).Return(&domain.Group{ID: testGroupID, Name: "Ops"}, nil)
+	env.userRepo.On("GetUserByID", mock.Anything, testMemberID).Return(&domain.User{ID: testMemberID}, nil)
+	env.groupRepo.On("AddGroupMember", mock.Anything, testGroupID, testMemberID).Return(true, nil).Once()
+	env.groupRepo.On("AddGroupMember", mock.Anything, testGroupID, testMemberID).Return(false, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionGroupMemberAdded && e.TargetID == testMemberID
+	})).Return(nil).Once()
+
+	require.NoError(t, env.interactor.AddGroupMember(ctx, testGroupID, testMemberID))
+
+	// Adding the same user again is a conflict
+	err := env.interactor.AddGroupMember(ctx, testGroupID, testMemberID)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestGroupInteractor_RemoveGroupMember_NotMember(t *testing.T) {
+	env := setupGroupTestEnv()
+	env.groupRepo.On("GetGroup", mock.Anything, testGroupID).Return(&domain.Group{ID: testGroupID, Name: "Ops"}, nil).Once()
+	env.groupRepo.On("RemoveGroupMember", mock.Anything, testGroupID, testMemberID).Return(false, nil).Once()
+
+	err := env.interactor.RemoveGroupMember(sessionContext("admin-1", domain.RoleAdmin, true), testGroupID, testMemberID)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	env.auditor.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
+
+func TestGroupInteractor_ListUserGroups(t *testing.T) {
+	env := setupGroupTestEnv()
+	env.userRepo.On("GetUserByID", mock.Anything, testMemberID).Return(&domain.User{ID: testMemberID}, nil).Once()
+	env.groupRepo.On("ListUserGroups", mock.Anything, testMemberID).Return([]domain.Group{{ID: testGroupID, Name: "Ops"}}, nil).Once()
+
+	// Users may list their own groups but not someone else's
+	got, err := env.interactor.ListUserGroups(sessionContext(testMemberID, domain.RoleUser, false), testMemberID)
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	_, err = env.interactor.ListUserGroups(sessionContext("user-2", domain.RoleUser, false), testMemberID)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
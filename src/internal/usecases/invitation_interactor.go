@@ -0,0 +1,403 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	mailer "apiserver/internal/mail"
+	"apiserver/internal/repositories"
+)
+
+// invitationTokenBytes is the amount of randomness in an invitation token.
+const invitationTokenBytes = 32
+
+// InvitationSettings configures invitations.
+type InvitationSettings struct {
+	TTL       time.Duration // How long an invitation link can be used
+	AcceptURL string        // Page that accepts invitations; the token is added as the "token" query parameter
+}
+
+// DefaultInvitationSettings returns the settings used when none are configured.
+func DefaultInvitationSettings() InvitationSettings {
+	return InvitationSettings{
+		TTL:       7 * 24 * time.Hour,
+		AcceptURL: "http://localhost:3000/invitations/accept",
+	}
+}
+
+// InvitationInteractor defines the interface for inviting users to
+// organizations by email. Organization admins and owners may invite, resend
+// and revoke; only owners may do so for the owner role.
+type InvitationInteractor interface {
+	// CreateInvitation invites email to join orgID with role, or as a member
+	// when role is empty, and emails a link to accept it.
+	CreateInvitation(ctx context.Context, orgID, email, role string) (*domain.Invitation, error)
+	// ResendInvitation emails a new link for a pending or expired invitation.
+	// The previous link stops working and the expiry starts over.
+	ResendInvitation(ctx context.Context, id string) (*domain.Invitation, error)
+	RevokeInvitation(ctx context.Context, id string) error
+	// AcceptInvitation accepts the invitation token was sent with. Invitees
+	// without an account choose a name and password and an account is created
+	// for them. Invitees with one must be signed in to it and join as they are.
+	AcceptInvitation(ctx context.Context, token, name, plainPassword string) (*domain.OrganizationMember, error)
+}
+
+// invitationInteractor implements InvitationInteractor.
+type invitationInteractor struct {
+	invRepo  repositories.InvitationRepository
+	orgRepo  repositories.OrganizationRepository
+	userRepo repositories.UserRepository
+	users    UserInteractor
+	tx       repositories.TxManager
+	mail     mailer.Sender
+	auditor  audit.Recorder
+	clock    clock.Clock
+	settings InvitationSettings
+}
+
+// NewInvitationInteractor creates a new instance of InvitationInteractor.
+// Accounts for new invitees are created through users, so they are validated
+// and audited like any other.
+func NewInvitationInteractor(invRepo repositories.InvitationRepository, orgRepo repositories.OrganizationRepository, userRepo repositories.UserRepository, users UserInteractor, tx repositories.TxManager, sender mailer.Sender, auditor audit.Recorder, clk clock.Clock, settings InvitationSettings) InvitationInteractor {
+	defaults := DefaultInvitationSettings()
+	if settings.TTL <= 0 {
+		settings.TTL = defaults.TTL
+	}
+	if settings.AcceptURL == "" {
+		settings.AcceptURL = defaults.AcceptURL
+	}
+	return &invitationInteractor{
+		invRepo:  invRepo,
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+		users:    users,
+		tx:       tx,
+		mail:     sender,
+		auditor:  auditor,
+		clock:    clk,
+		settings: settings,
+	}
+}
+
+// generateInvitationToken returns a new random token and its hash.
+func generateInvitationToken() (token, hash string, err error) {
+	b := make([]byte, invitationTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, hashInvitationToken(token), nil
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (uc *invitationInteractor) CreateInvitation(ctx context.Context, orgID, email, role string) (*domain.Invitation, error) {
+	if role == "" {
+		role = domain.OrgRoleMember
+	}
+	email = strings.TrimSpace(email)
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email || len(email) > 255 {
+		return nil, fmt.Errorf("%w: email is not a valid address", domain.ErrInvalidArgument)
+	}
+	principal, err := uc.authorize(ctx, orgID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *domain.Invitation
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		now := uc.clock.Now()
+		existing, err := uc.userRepo.GetUserByEmail(ctx, email)
+		if err != nil {
+			return err
+		}
+		var targetID string
+		if existing != nil {
+			targetID = existing.ID
+			memberRole, err := uc.orgRepo.GetMemberRole(ctx, orgID, existing.ID)
+			if err != nil {
+				return err
+			}
+			if memberRole != "" {
+				return fmt.Errorf("%w: %s is already a member of the organization", domain.ErrConflict, email)
+			}
+		}
+		pending, err := uc.invRepo.GetPendingInvitation(ctx, orgID, email, now)
+		if err != nil {
+			return err
+		}
+		if pending != nil {
+			return fmt.Errorf("%w: %s has already been invited; resend the invitation instead", domain.ErrConflict, email)
+		}
+
+		token, hash, err := generateInvitationToken()
+		if err != nil {
+			return err
+		}
+		created, err = uc.invRepo.CreateInvitation(ctx, &domain.Invitation{
+			OrganizationID: orgID,
+			Email:          email,
+			Role:           role,
+			InvitedBy:      principal.UserID,
+			ExpiresAt:      now.Add(uc.settings.TTL),
+			CreatedAt:      now,
+		}, hash)
+		if err != nil {
+			return err
+		}
+		if err := uc.recordInvitation(ctx, domain.AuditActionInvitationCreated, targetID, created); err != nil {
+			return err
+		}
+		// Sent last, so a failure rolls the invitation back
+		return uc.sendInvitation(ctx, principal, created, token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (uc *invitationInteractor) ResendInvitation(ctx context.Context, id string) (*domain.Invitation, error) {
+	var renewed *domain.Invitation
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		inv, principal, err := uc.getForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		now := uc.clock.Now()
+		token, hash, err := generateInvitationToken()
+		if err != nil {
+			return err
+		}
+		ok, err := uc.invRepo.RenewInvitation(ctx, inv.ID, hash, now.Add(uc.settings.TTL), now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: the invitation is no longer pending", domain.ErrConflict)
+		}
+		if renewed, err = uc.invRepo.GetInvitation(ctx, inv.ID); err != nil {
+			return err
+		}
+		if err := uc.recordInvitation(ctx, domain.AuditActionInvitationResent, "", renewed); err != nil {
+			return err
+		}
+		return uc.sendInvitation(ctx, principal, renewed, token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return renewed, nil
+}
+
+func (uc *invitationInteractor) RevokeInvitation(ctx context.Context, id string) error {
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		inv, _, err := uc.getForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		ok, err := uc.invRepo.RevokeInvitation(ctx, inv.ID, uc.clock.Now())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: the invitation is no longer pending", domain.ErrConflict)
+		}
+		return uc.recordInvitation(ctx, domain.AuditActionInvitationRevoked, "", inv)
+	})
+}
+
+func (uc *invitationInteractor) AcceptInvitation(ctx context.Context, token, name, plainPassword string) (*domain.OrganizationMember, error) {
+	if token == "" {
+		return nil, domain.ErrInvitationInvalid
+	}
+
+	var member *domain.OrganizationMember
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		now := uc.clock.Now()
+		inv, err := uc.invRepo.GetInvitationByTokenHash(ctx, hashInvitationToken(token))
+		if err != nil {
+			return err
+		}
+		if inv == nil || inv.Status(now) != domain.InvitationPending {
+			return domain.ErrInvitationInvalid
+		}
+
+		user, err := uc.userRepo.GetUserByEmail(ctx, inv.Email)
+		if err != nil {
+			return err
+		}
+		if user != nil {
+			// Joining with an existing account needs its owner's consent
+			principal, err := requireSession(ctx)
+			if err != nil {
+				return err
+			}
+			if principal.UserID != user.ID {
+				return fmt.Errorf("%w: sign in as the invited user to accept", domain.ErrForbidden)
+			}
+			memberRole, err := uc.orgRepo.GetMemberRole(ctx, inv.OrganizationID, user.ID)
+			if err != nil {
+				return err
+			}
+			if memberRole != "" {
+				return fmt.Errorf("%w: already a member of the organization", domain.ErrConflict)
+			}
+		} else {
+			if strings.TrimSpace(name) == "" || plainPassword == "" {
+				return fmt.Errorf("%w: name and password are required", domain.ErrInvalidArgument)
+			}
+			if user, err = uc.users.CreateNewUser(ctx, name, inv.Email, plainPassword); err != nil {
+				return err
+			}
+		}
+
+		ok, err := uc.invRepo.AcceptInvitation(ctx, inv.ID, user.ID, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return domain.ErrInvitationInvalid
+		}
+		if err := uc.orgRepo.AddMember(ctx, inv.OrganizationID, user.ID, inv.Role); err != nil {
+			return err
+		}
+		if err := uc.auditor.Record(ctx, domain.AuditEvent{
+			Action:   domain.AuditActionInvitationAccepted,
+			ActorID:  user.ID,
+			TargetID: user.ID,
+			Metadata: map[string]string{
+				"invitation_id":   inv.ID,
+				"organization_id": inv.OrganizationID,
+				"role":            inv.Role,
+				"invited_by":      inv.InvitedBy,
+			},
+			OccurredAt: now,
+		}); err != nil {
+			return err
+		}
+		member = &domain.OrganizationMember{User: *user, Role: inv.Role, JoinedAt: now}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// authorize checks that the caller may manage invitations to role in orgID.
+func (uc *invitationInteractor) authorize(ctx context.Context, orgID, role string) (*auth.Principal, error) {
+	if err := checkScope(ctx, domain.ScopeUsersWrite); err != nil {
+		return nil, err
+	}
+	principal, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := orgRoleRank[role]; !ok {
+		return nil, fmt.Errorf("%w: role must be one of owner, admin or member", domain.ErrInvalidArgument)
+	}
+	callerRole, err := uc.orgRepo.GetMemberRole(ctx, orgID, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if orgRoleRank[callerRole] < orgRoleRank[domain.OrgRoleAdmin] {
+		return nil, domain.ErrForbidden
+	}
+	if role == domain.OrgRoleOwner && callerRole != domain.OrgRoleOwner {
+		return nil, domain.ErrForbidden
+	}
+	return principal, nil
+}
+
+// getForUpdate loads invitation id for a resend or revoke by the caller.
+func (uc *invitationInteractor) getForUpdate(ctx context.Context, id string) (*domain.Invitation, *auth.Principal, error) {
+	if _, err := requireUser(ctx); err != nil {
+		return nil, nil, err
+	}
+	inv, err := uc.invRepo.GetInvitation(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if inv == nil {
+		return nil, nil, domain.ErrInvitationNotFound
+	}
+	principal, err := uc.authorize(ctx, inv.OrganizationID, inv.Role)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch inv.Status(uc.clock.Now()) {
+	case domain.InvitationAccepted, domain.InvitationRevoked:
+		return nil, nil, fmt.Errorf("%w: the invitation is no longer pending", domain.ErrConflict)
+	}
+	return inv, principal, nil
+}
+
+// recordInvitation appends an audit event for a change to inv by the caller.
+// It must run inside the change's transaction.
+func (uc *invitationInteractor) recordInvitation(ctx context.Context, action, targetID string, inv *domain.Invitation) error {
+	event := domain.AuditEvent{
+		Action:   action,
+		TargetID: targetID,
+		Metadata: map[string]string{
+			"invitation_id":   inv.ID,
+			"organization_id": inv.OrganizationID,
+			"role":            inv.Role,
+			"email":           maskEmail(inv.Email),
+		},
+		OccurredAt: uc.clock.Now(),
+	}
+	if p := auth.PrincipalFrom(ctx); p != nil {
+		event.ActorID = p.UserID
+		if p.IsAPIKey() {
+			event.Metadata["api_key_id"] = p.APIKeyID
+		}
+	}
+	return uc.auditor.Record(ctx, event)
+}
+
+// sendInvitation emails the link for inv, which carries token.
+func (uc *invitationInteractor) sendInvitation(ctx context.Context, inviter *auth.Principal, inv *domain.Invitation, token string) error {
+	org, err := uc.orgRepo.GetOrganization(ctx, inv.OrganizationID)
+	if err != nil {
+		return err
+	}
+	if org == nil {
+		return domain.ErrForbidden
+	}
+	inviterName := "A member"
+	if u, err := uc.userRepo.GetUserByID(ctx, inviter.UserID); err == nil && u != nil && u.Name != "" {
+		inviterName = u.Name
+	}
+	link, err := url.Parse(uc.settings.AcceptURL)
+	if err != nil {
+		return fmt.Errorf("invalid invitation accept URL: %w", err)
+	}
+	q := link.Query()
+	q.Set("token", token)
+	link.RawQuery = q.Encode()
+
+	return uc.mail.Send(ctx, mailer.Message{
+		To:      inv.Email,
+		Subject: fmt.Sprintf("You are invited to join %s", org.Name),
+		Body: fmt.Sprintf("%s invited you to join %s as %s.\n\n"+
+			"Accept the invitation:\n%s\n\n"+
+			"The link expires on %s. If you were not expecting this invitation, you can ignore this email.\n",
+			inviterName, org.Name, inv.Role, link, inv.ExpiresAt.UTC().Format("2006-01-02 15:04 MST")),
+	})
+}
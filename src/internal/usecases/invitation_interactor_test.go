@@ -0,0 +1,295 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/mail"
+	mailmocks "apiserver/internal/mail/mocks"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var invitationNow = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type invitationTestEnv struct {
+	invRepo    *mocks.MockInvitationRepository
+	orgRepo    *mocks.MockOrganizationRepository
+	userRepo   *mocks.MockUserRepository
+	mail       *mailmocks.MockSender
+	auditor    *auditmocks.MockRecorder
+	interactor InvitationInteractor
+}
+
+func setupInvitationTestEnv() *invitationTestEnv {
+	env := &invitationTestEnv{
+		invRepo:  new(mocks.MockInvitationRepository),
+		orgRepo:  new(mocks.MockOrganizationRepository),
+		userRepo: new(mocks.MockUserRepository),
+		mail:     new(mailmocks.MockSender),
+		auditor:  new(auditmocks.MockRecorder),
+	}
+	settings := InvitationSettings{TTL: 48 * time.Hour, AcceptURL: "https://app.example.com/accept?lang=ja"}
+	env.interactor = NewInvitationInteractor(env.invRepo, env.orgRepo, env.userRepo, newTestUserInteractor(env.userRepo), new(mocks.InlineTxManager), env.mail, env.auditor, clock.NewFake(invitationNow), settings)
+	return env
+}
+
+func pendingInvitation() *domain.Invitation {
+	return &domain.Invitation{
+		ID:             "inv-1",
+		OrganizationID: "org-1",
+		Email:          "bob@example.com",
+		Role:           domain.OrgRoleMember,
+		InvitedBy:      "user-1",
+		ExpiresAt:      invitationNow.Add(time.Hour),
+		CreatedAt:      invitationNow.Add(-time.Hour),
+	}
+}
+
+func TestInvitationInteractor_CreateInvitation_Success(t *testing.T) {
+	env := setupInvitationTestEnv()
+	var tokenHash string
+	env.orgRepo.On("GetMemberRole", mock.Anything, "org-1", "user-1").Return(domain.OrgRoleAdmin, nil).Once()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "bob@example.com").Return(nil, nil).Once()
+	env.invRepo.On("GetPendingInvitation", mock.Anything, "org-1", "bob@example.com", invitationNow).Return(nil, nil).Once()
+	env.invRepo.On("CreateInvitation", mock.Anything, mock.MatchedBy(func(inv *domain.Invitation) bool {
+		return inv.InvitedBy == "user-1" && inv.Role == domain.OrgRoleMember && inv.ExpiresAt.Equal(invitationNow.Add(48*time.Hour))
+	}), mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+		tokenHash = args.String(2)
+	}).Return(pendingInvitation(), nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionInvitationCreated && e.ActorID == "user-1" &&
+			e.Metadata["invitation_id"] == "inv-1" && e.Metadata["email"] == "b***@example.com"
+	})).Return(nil).Once()
+	env.orgRepo.On("GetOrganization", mock.Anything, "org-1").Return(&domain.Organization{ID: "org-1", Name: "Acme"}, nil).Once()
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", Name: "Alice"}, nil).Once()
+	var sent mail.Message
+	env.mail.On("Send", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(1).(mail.Message)
+	}).Return(nil).Once()
+
+	got, err := env.interactor.CreateInvitation(sessionContext("user-1", domain.RoleUser, false), "org-1", "bob@example.com", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "inv-1", got.ID)
+	assert.Equal(t, "bob@example.com", sent.To)
+	assert.Contains(t, sent.Subject, "Acme")
+	assert.Contains(t, sent.Body, "Alice invited you")
+
+	// The link carries the token whose hash was stored
+	i := strings.Index(sent.Body, "token=")
+	require.NotEqual(t, -1, i)
+	token := strings.Fields(sent.Body[i+len("token="):])[0]
+	assert.Equal(t, tokenHash, hashInvitationToken(token))
+	assert.Contains(t, sent.Body, "https://app.example.com/accept?lang=ja&token=")
+	env.auditor.AssertExpectations(t)
+}
+
+func TestInvitationInteractor_CreateInvitation_Rejected(t *testing.T) {
+	tests := map[string]struct {
+		callerRole string
+		email      string
+		role       string
+		wantErr    error
+	}{
+		"member cannot invite":      {domain.OrgRoleMember, "bob@example.com", "", domain.ErrForbidden},
+		"non-member cannot invite":  {"", "bob@example.com", "", domain.ErrForbidden},
+		"admin cannot invite owner": {domain.OrgRoleAdmin, "bob@example.com", domain.OrgRoleOwner, domain.ErrForbidden},
+		"unknown role":              {domain.OrgRoleOwner, "bob@example.com", "superuser", domain.ErrInvalidArgument},
+		"invalid email":             {domain.OrgRoleOwner, "Bob <bob@example.com>", "", domain.ErrInvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := setupInvitationTestEnv()
+			env.orgRepo.On("GetMemberRole", mock.Anything, "org-1", "user-1").Return(tt.callerRole, nil).Maybe()
+
+			_, err := env.interactor.CreateInvitation(sessionContext("user-1", domain.RoleUser, false), "org-1", tt.email, tt.role)
+			assert.ErrorIs(t, err, tt.wantErr)
+			env.invRepo.AssertNotCalled(t, "CreateInvitation", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestInvitationInteractor_CreateInvitation_Conflict(t *testing.T) {
+	t.Run("already a member", func(t *testing.T) {
+		env := setupInvitationTestEnv()
+		env.orgRepo.On("GetMemberRole", mock.Anything, "org-1", "user-1").Return(domain.OrgRoleOwner, nil).Once()
+		env.userRepo.On("GetUserByEmail", mock.Anything, "bob@example.com").Return(&domain.User{ID: "user-2"}, nil).Once()
+		env.orgRepo.On("GetMemberRole", mock.Anything, "org-1", "user-2").Return(domain.OrgRoleMember, nil).Once()
+
+		_, err := env.interactor.CreateInvitation(sessionContext("user-1", domain.RoleUser, false), "org-1", "bob@example.com", "")
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+
+	t.Run("already invited", func(t *testing.T) {
+		env := setupInvitationTestEnv()
+		env.orgRepo.On("GetMemberRole", mock.Anything, "org-1", "user-1").Return(domain.OrgRoleOwner, nil).Once()
+		env.userRepo.On("GetUserByEmail", mock.Anything, "bob@example.com").Return(nil, nil).Once()
+		env.invRepo.On("GetPendingInvitation", mock.Anything, "org-1", "bob@example.com", invitationNow).Return(pendingInvitation(), nil).Once()
+
+		_, err := env.interactor.CreateInvitation(sessionContext("user-1", domain.RoleUser, false), "org-1", "bob@example.com", "")
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		env.invRepo.AssertNotCalled(t, "CreateInvitation", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestInvitationInteractor_CreateInvitation_SendFailure(t *testing.T) {
+	env := setupInvitationTestEnv()
+	sendErr := errors.New("smtp unavailable")
+	env.orgRepo.On("GetMemberRole", mock.Anything, "org-1", "user-1").Return(domain.OrgRoleOwner, nil).Once()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "bob@example.com").Return(nil, nil).Once()
+	env.invRepo.On("GetPendingInvitation", mock.Anything, "org-1", "bob@example.com", invitationNow).Return(nil, nil).Once()
+	env.invRepo.On("CreateInvitation", mock.Anything, mock.Anything, mock.Anything).Return(pendingInvitation(), nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+	env.orgRepo.On("GetOrganization", mock.Anything, "org-1").Return(&domain.Organization{ID: "org-1", Name: "Acme"}, nil).Once()
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(nil, nil).Once()
+	env.mail.On("Send", mock.Anything, mock.Anything).Return(sendErr).Once()
+
+	// The error reaches the transaction, which rolls the invitation back
+	_, err := env.interactor.CreateInvitation(sessionContext("user-1", domain.RoleUser, false), "org-1", "bob@example.com", "")
+	assert.ErrorIs(t, err, sendErr)
+}
+
+func TestInvitationInteractor_ResendInvitation(t *testing.T) {
+	t.Run("expired invitations get a new link", func(t *testing.T) {
+		env := setupInvitationTestEnv()
+		expired := pendingInvitation()
+		expired.ExpiresAt = invitationNow.Add(-time.Minute)
+		renewed := pendingInvitation()
+		renewed.ExpiresAt = invitationNow.Add(48 * time.Hour)
+		env.invRepo.On("GetInvitation", mock.Anything, "inv-1").Return(expired, nil).Once()
+		env.orgRepo.On("GetMemberRole", mock.Anything, "org-1", "user-1").Return(domain.OrgRoleAdmin, nil).Once()
+		env.invRepo.On("RenewInvitation", mock.Anything, "inv-1", mock.AnythingOfType("string"), invitationNow.Add(48*time.Hour), invitationNow).Return(true, nil).Once()
+		env.invRepo.On("GetInvitation", mock.Anything, "inv-1").Return(renewed, nil).Once()
+		env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+			return e.Action == domain.AuditActionInvitationResent && e.ActorID == "user-1"
+		})).Return(nil).Once()
+		env.orgRepo.On("GetOrganization", mock.Anything, "org-1").Return(&domain.Organization{ID: "org-1", Name: "Acme"}, nil).Once()
+		env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", Name: "Alice"}, nil).Once()
+		env.mail.On("Send", mock.Anything, mock.Anything).Return(nil).Once()
+
+		got, err := env.interactor.ResendInvitation(sessionContext("user-1", domain.RoleUser, false), "inv-1")
+		require.NoError(t, err)
+		assert.Equal(t, renewed, got)
+		env.mail.AssertExpectations(t)
+	})
+
+	t.Run("accepted invitations cannot be resent", func(t *testing.T) {
+		env := setupInvitationTestEnv()
+		accepted := pendingInvitation()
+		accepted.AcceptedAt = invitationNow
+		env.invRepo.On("GetInvitation", mock.Anything, "inv-1").Return(accepted, nil).Once()
+		env.orgRepo.On("GetMemberRole", mock.Anything, "org-1", "user-1").Return(domain.OrgRoleAdmin, nil).Once()
+
+		_, err := env.interactor.ResendInvitation(sessionContext("user-1", domain.RoleUser, false), "inv-1")
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		env.mail.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestInvitationInteractor_RevokeInvitation(t *testing.T) {
+	env := setupInvitationTestEnv()
+	env.invRepo.On("GetInvitation", mock.Anything, "inv-1").Return(pendingInvitation(), nil).Once()
+	env.orgRepo.On("GetMemberRole", mock.Anything, "org-1", "user-1").Return(domain.OrgRoleOwner, nil).Once()
+	env.invRepo.On("RevokeInvitation", mock.Anything, "inv-1", invitationNow).Return(true, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionInvitationRevoked && e.Metadata["invitation_id"] == "inv-1"
+	})).Return(nil).Once()
+
+	err := env.interactor.RevokeInvitation(sessionContext("user-1", domain.RoleUser, false), "inv-1")
+	assert.NoError(t, err)
+	env.invRepo.AssertExpectations(t)
+
+	env.invRepo.On("GetInvitation", mock.Anything, "inv-2").Return(nil, nil).Once()
+	err = env.interactor.RevokeInvitation(sessionContext("user-1", domain.RoleUser, false), "inv-2")
+	assert.ErrorIs(t, err, domain.ErrInvitationNotFound)
+}
+
+func TestInvitationInteractor_AcceptInvitation_NewUser(t *testing.T) {
+	env := setupInvitationTestEnv()
+	inv := pendingInvitation()
+	env.invRepo.On("GetInvitationByTokenHash", mock.Anything, hashInvitationToken("the-token")).Return(inv, nil).Once()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "bob@example.com").Return(nil, nil).Once()
+	env.userRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Name == "Bob" && u.Email == "bob@example.com"
+	}), mock.AnythingOfType("string")).Return(&domain.User{ID: "user-2", Name: "Bob", Email: "bob@example.com"}, nil).Once()
+	env.invRepo.On("AcceptInvitation", mock.Anything, "inv-1", "user-2", invitationNow).Return(true, nil).Once()
+	env.orgRepo.On("AddMember", mock.Anything, "org-1", "user-2", domain.OrgRoleMember).Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionInvitationAccepted && e.ActorID == "user-2" && e.Metadata["invited_by"] == "user-1"
+	})).Return(nil).Once()
+
+	member, err := env.interactor.AcceptInvitation(context.Background(), "the-token", "Bob", "secret")
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-2", member.User.ID)
+	assert.Equal(t, domain.OrgRoleMember, member.Role)
+	env.invRepo.AssertExpectations(t)
+	env.orgRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestInvitationInteractor_AcceptInvitation_ExistingUser(t *testing.T) {
+	env := setupInvitationTestEnv()
+	existing := &domain.User{ID: "user-2", Name: "Bob", Email: "bob@example.com"}
+	env.invRepo.On("GetInvitationByTokenHash", mock.Anything, mock.Anything).Return(pendingInvitation(), nil)
+	env.userRepo.On("GetUserByEmail", mock.Anything, "bob@example.com").Return(existing, nil)
+
+	_, err := env.interactor.AcceptInvitation(context.Background(), "the-token", "", "")
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	_, err = env.interactor.AcceptInvitation(sessionContext("user-3", domain.RoleUser, false), "the-token", "", "")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	env.orgRepo.On("GetMemberRole", mock.Anything, "org-1", "user-2").Return("", nil).Once()
+	env.invRepo.On("AcceptInvitation", mock.Anything, "inv-1", "user-2", invitationNow).Return(true, nil).Once()
+	env.orgRepo.On("AddMember", mock.Anything, "org-1", "user-2", domain.OrgRoleMember).Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+
+	member, err := env.interactor.AcceptInvitation(sessionContext("user-2", domain.RoleUser, false), "the-token", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "user-2", member.User.ID)
+	env.userRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInvitationInteractor_AcceptInvitation_Invalid(t *testing.T) {
+	expired := pendingInvitation()
+	expired.ExpiresAt = invitationNow
+	revoked := pendingInvitation()
+	revoked.RevokedAt = invitationNow
+	accepted := pendingInvitation()
+	accepted.AcceptedAt = invitationNow
+
+	tests := map[string]*domain.Invitation{
+		"unknown token": nil,
+		"expired":       expired,
+		"revoked":       revoked,
+		"accepted":      accepted,
+	}
+	for name, inv := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := setupInvitationTestEnv()
+			env.invRepo.On("GetInvitationByTokenHash", mock.Anything, mock.Anything).Return(inv, nil).Once()
+
+			_, err := env.interactor.AcceptInvitation(context.Background(), "the-token", "Bob", "secret")
+			assert.ErrorIs(t, err, domain.ErrInvitationInvalid)
+			env.userRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestInvitationInteractor_AcceptInvitation_RequiresNameAndPassword(t *testing.T) {
+	env := setupInvitationTestEnv()
+	env.invRepo.On("GetInvitationByTokenHash", mock.Anything, mock.Anything).Return(pendingInvitation(), nil).Once()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "bob@example.com").Return(nil, nil).Once()
+
+	_, err := env.interactor.AcceptInvitation(context.Background(), "the-token", "Bob", "")
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
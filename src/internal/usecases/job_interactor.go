@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+)
+
+// JobCatalog lists the jobs registered with the scheduler. It is satisfied
+// by *scheduler.Scheduler.
+type JobCatalog interface {
+	Jobs() []domain.ScheduledJob
+}
+
+// JobInteractor defines the interface for monitoring scheduled jobs.
+type JobInteractor interface {
+	// ListJobs returns the registered jobs by name with their latest run,
+	// whichever instance performed it. Only admins may list jobs.
+	ListJobs(ctx context.Context) ([]domain.JobStatus, error)
+}
+
+// jobInteractor implements JobInteractor.
+type jobInteractor struct {
+	catalog    JobCatalog
+	jobRunRepo repositories.JobRunRepository
+}
+
+// NewJobInteractor creates a new instance of JobInteractor.
+func NewJobInteractor(catalog JobCatalog, jobRunRepo repositories.JobRunRepository) JobInteractor {
+	return &jobInteractor{catalog: catalog, jobRunRepo: jobRunRepo}
+}
+
+func (uc *jobInteractor) ListJobs(ctx context.Context) ([]domain.JobStatus, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	runs, err := uc.jobRunRepo.ListLatestJobRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[string]*domain.JobRun, len(runs))
+	for i := range runs {
+		latest[runs[i].JobName] = &runs[i]
+	}
+	jobs := uc.catalog.Jobs()
+	statuses := make([]domain.JobStatus, len(jobs))
+	for i, j := range jobs {
+		statuses[i] = domain.JobStatus{ScheduledJob: j, LastRun: latest[j.Name]}
+	}
+	return statuses, nil
+}
@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type staticJobCatalog []domain.ScheduledJob
+
+func (c staticJobCatalog) Jobs() []domain.ScheduledJob { return c }
+
+func TestJobInteractor_ListJobs(t *testing.T) {
+	next := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	catalog := staticJobCatalog{
+		{Name: "retention.audit_events", Schedule: "0 3 * * *", NextRunAt: next},
+		{Name: "retention.outbox_events", Schedule: "0 3 * * *", NextRunAt: next},
+	}
+	jobRunRepo := new(mocks.MockJobRunRepository)
+	interactor := NewJobInteractor(catalog, jobRunRepo)
+	run := domain.JobRun{ID: "run-1", JobName: "retention.outbox_events", Status: domain.JobRunSucceeded, Result: map[string]int64{"deleted": 7}}
+
+	jobRunRepo.On("ListLatestJobRuns", mock.Anything).Return([]domain.JobRun{
+		run,
+		{ID: "run-0", JobName: "retention.removed_job", Status: domain.JobRunFailed},
+	}, nil).Once()
+
+	statuses, err := interactor.ListJobs(sessionContext("admin-1", domain.RoleAdmin, true))
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "retention.audit_events", statuses[0].Name)
+	assert.Nil(t, statuses[0].LastRun)
+	assert.Equal(t, &run, statuses[1].LastRun)
+	assert.Equal(t, next, statuses[1].NextRunAt)
+}
+
+func TestJobInteractor_ListJobs_RequiresAdmin(t *testing.T) {
+	jobRunRepo := new(mocks.MockJobRunRepository)
+	interactor := NewJobInteractor(staticJobCatalog{}, jobRunRepo)
+
+	_, err := interactor.ListJobs(sessionContext("user-1", domain.RoleUser, true))
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = interactor.ListJobs(sessionContext("admin-1", domain.RoleAdmin, false))
+	assert.ErrorIs(t, err, domain.ErrMFARequired)
+	jobRunRepo.AssertNotCalled(t, "ListLatestJobRuns", mock.Anything)
+}
@@ -0,0 +1,232 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/totp"
+)
+
+const (
+	// totpSkew is the number of time steps either side of now a code is accepted for.
+	totpSkew = 1
+	// recoveryCodeCount is how many single-use recovery codes are issued on enrollment.
+	recoveryCodeCount = 10
+)
+
+// recoveryCodeAlphabet avoids characters that are easily confused when read aloud or copied.
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// generateRecoveryCode returns a random code formatted as "xxxxx-xxxxx".
+func generateRecoveryCode() (string, error) {
+	var sb strings.Builder
+	max := big.NewInt(int64(len(recoveryCodeAlphabet)))
+	for i := 0; i < 10; i++ {
+		if i == 5 {
+			sb.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(recoveryCodeAlphabet[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// normalizeRecoveryCode makes recovery codes tolerant of case, spaces and dashes.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// hashRecoveryCode returns the stored form of a recovery code. Codes carry ~49
+// bits of entropy and are single-use, so an unsalted SHA-256 is sufficient and
+// allows lookup by hash.
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// isTOTPCode reports whether code looks like a TOTP code rather than a recovery code.
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (uc *authInteractor) decryptSecret(setting *domain.MFASetting) (string, error) {
+	secret, err := uc.secrets.Decrypt(setting.SecretCiphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
+
+func (uc *authInteractor) CompleteMFALogin(ctx context.Context, mfaToken, code, clientIP string) (*LoginResult, error) {
+	if mfaToken == "" || code == "" {
+		return nil, errors.New("mfa token and code are required")
+	}
+	userID, err := uc.tokens.VerifyMFAChallenge(mfaToken)
+	if err != nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	now := uc.clock.Now()
+	accountKey := normalizeEmail(user.Email)
+	if err := uc.checkLockouts(ctx, accountKey, clientIP, now); err != nil {
+		return nil, err
+	}
+
+	setting, err := uc.mfaRepo.GetMFASetting(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil || !setting.Enabled {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	code = strings.TrimSpace(code)
+	var ok bool
+	if isTOTPCode(code) {
+		secret, err := uc.decryptSecret(setting)
+		if err != nil {
+			return nil, err
+		}
+		if step, valid := totp.Validate(secret, code, now, totpSkew); valid {
+			// Only succeeds when step is newer than the last accepted code, so an
+			// intercepted code cannot be replayed within its validity window.
+			if ok, err = uc.mfaRepo.AdvanceStep(ctx, user.ID, step); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if ok, err = uc.mfaRepo.UseRecoveryCode(ctx, user.ID, hashRecoveryCode(code), now); err != nil {
+			return nil, err
+		}
+		if ok {
+			if err := uc.auditor.Record(ctx, domain.AuditEvent{
+				Action:     domain.AuditActionRecoveryUsed,
+				ActorID:    user.ID,
+				TargetID:   user.ID,
+				IP:         clientIP,
+				OccurredAt: now,
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !ok {
+		if err := uc.recordFailures(ctx, accountKey, user.ID, clientIP, now); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidMFACode
+	}
+	return uc.completeLogin(ctx, user, accountKey, true)
+}
+
+func (uc *authInteractor) BeginMFAEnrollment(ctx context.Context) (*MFAEnrollment, error) {
+	principal, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.GetUserByID(ctx, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	existing, err := uc.mfaRepo.GetMFASetting(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Enabled {
+		return nil, domain.ErrConflict
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := uc.secrets.Encrypt([]byte(secret))
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.mfaRepo.SaveMFASecret(ctx, user.ID, ciphertext); err != nil {
+		return nil, err
+	}
+	return &MFAEnrollment{
+		Secret: secret,
+		KeyURI: totp.KeyURI(uc.settings.MFAIssuer, user.Email, secret),
+	}, nil
+}
+
+func (uc *authInteractor) ConfirmMFAEnrollment(ctx context.Context, code string) ([]string, error) {
+	principal, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	setting, err := uc.mfaRepo.GetMFASetting(ctx, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil {
+		return nil, domain.ErrNotFound
+	}
+	if setting.Enabled {
+		return nil, domain.ErrConflict
+	}
+
+	secret, err := uc.decryptSecret(setting)
+	if err != nil {
+		return nil, err
+	}
+	now := uc.clock.Now()
+	step, ok := totp.Validate(secret, strings.TrimSpace(code), now, totpSkew)
+	if !ok {
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		if codes[i], err = generateRecoveryCode(); err != nil {
+			return nil, err
+		}
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	if err := uc.mfaRepo.EnableMFA(ctx, principal.UserID, step, now, hashes); err != nil {
+		return nil, err
+	}
+	if err := uc.auditor.Record(ctx, domain.AuditEvent{
+		Action:     domain.AuditActionMFAEnabled,
+		ActorID:    principal.UserID,
+		TargetID:   principal.UserID,
+		OccurredAt: now,
+	}); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
@@ -0,0 +1,226 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/domain"
+	"apiserver/internal/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func (env *authTestEnv) enabledMFASetting(t *testing.T, userID string) *domain.MFASetting {
+	ciphertext, err := env.secrets.Encrypt([]byte(testTOTPSecret))
+	assert.NoError(t, err)
+	return &domain.MFASetting{UserID: userID, SecretCiphertext: ciphertext, Enabled: true}
+}
+
+func (env *authTestEnv) currentCode(t *testing.T, offsetSteps int64) string {
+	code, err := totp.Code(testTOTPSecret, totp.Step(env.clock.Now())+offsetSteps)
+	assert.NoError(t, err)
+	return code
+}
+
+func (env *authTestEnv) mfaChallenge(t *testing.T, user *domain.User) string {
+	challenge, _, err := env.tokens.IssueMFAChallenge(user)
+	assert.NoError(t, err)
+	return challenge
+}
+
+func TestAuthInteractor_Login_MFAEnabledReturnsChallenge(t *testing.T) {
+	env := setupAuthTestEnv()
+	user := &domain.User{ID: "user-1", Email: "user@example.com", Password: hashPassword(t, "password123")}
+
+	env.throttleRepo.On("GetThrottle", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(env.enabledMFASetting(t, "user-1"), nil).Once()
+
+	result, err := env.interactor.Login(context.Background(), "user@example.com", "password123", "192.0.2.1")
+
+	assert.NoError(t, err)
+	assert.True(t, result.MFARequired)
+	assert.Empty(t, result.AccessToken)
+	userID, err := env.tokens.VerifyMFAChallenge(result.MFAToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+	// The failure count must survive until the second factor succeeds
+	env.throttleRepo.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthInteractor_CompleteMFALogin_TOTP(t *testing.T) {
+	for name, offset := range map[string]int64{"current step": 0, "previous step": -1, "next step": 1} {
+		t.Run(name, func(t *testing.T) {
+			env := setupAuthTestEnv()
+			user := &domain.User{ID: "user-1", Email: "user@example.com", Role: domain.RoleAdmin}
+			step := totp.Step(env.clock.Now()) + offset
+
+			env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(user, nil).Once()
+			env.throttleRepo.On("GetThrottle", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+			env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(env.enabledMFASetting(t, "user-1"), nil).Once()
+			env.mfaRepo.On("AdvanceStep", mock.Anything, "user-1", step).Return(true, nil).Once()
+			env.throttleRepo.On("Reset", mock.Anything, domain.ThrottleScopeAccount, "user@example.com").Return(nil).Once()
+
+			result, err := env.interactor.CompleteMFALogin(context.Background(), env.mfaChallenge(t, user), env.currentCode(t, offset), "192.0.2.1")
+
+			assert.NoError(t, err)
+			principal, err := env.tokens.Verify(result.AccessToken)
+			assert.NoError(t, err)
+			assert.True(t, principal.MFA)
+			assert.True(t, principal.IsAdmin())
+			env.mfaRepo.AssertExpectations(t)
+			env.throttleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthInteractor_CompleteMFALogin_RejectsReplayedCode(t *testing.T) {
+	env := setupAuthTestEnv()
+	user := &domain.User{ID: "user-1", Email: "user@example.com"}
+	now := env.clock.Now()
+
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(user, nil).Once()
+	env.throttleRepo.On("GetThrottle", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(env.enabledMFASetting(t, "user-1"), nil).Once()
+	env.mfaRepo.On("AdvanceStep", mock.Anything, "user-1", totp.Step(now)).Return(false, nil).Once()
+	env.throttleRepo.On("RecordFailure", mock.Anything, domain.ThrottleScopeAccount, "user@example.com", now).
+		Return(&domain.LoginThrottle{FailedCount: 1}, nil).Once()
+	env.throttleRepo.On("RecordFailure", mock.Anything, domain.ThrottleScopeIP, "192.0.2.1", now).
+		Return(&domain.LoginThrottle{FailedCount: 1}, nil).Once()
+
+	_, err := env.interactor.CompleteMFALogin(context.Background(), env.mfaChallenge(t, user), env.currentCode(t, 0), "192.0.2.1")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+	env.throttleRepo.AssertExpectations(t)
+}
+
+func TestAuthInteractor_CompleteMFALogin_CodeOutsideSkew(t *testing.T) {
+	env := setupAuthTestEnv()
+	user := &domain.User{ID: "user-1", Email: "user@example.com"}
+
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(user, nil).Once()
+	env.throttleRepo.On("GetThrottle", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(env.enabledMFASetting(t, "user-1"), nil).Once()
+	env.throttleRepo.On("RecordFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&domain.LoginThrottle{FailedCount: 1}, nil).Twice()
+
+	_, err := env.interactor.CompleteMFALogin(context.Background(), env.mfaChallenge(t, user), env.currentCode(t, -2), "192.0.2.1")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+	env.mfaRepo.AssertNotCalled(t, "AdvanceStep", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthInteractor_CompleteMFALogin_RecoveryCode(t *testing.T) {
+	env := setupAuthTestEnv()
+	user := &domain.User{ID: "user-1", Email: "user@example.com"}
+	now := env.clock.Now()
+
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(user, nil).Once()
+	env.throttleRepo.On("GetThrottle", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(env.enabledMFASetting(t, "user-1"), nil).Once()
+	env.mfaRepo.On("UseRecoveryCode", mock.Anything, "user-1", hashRecoveryCode("abcde-fghjk"), now).Return(true, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionRecoveryUsed && e.TargetID == "user-1"
+	})).Return(nil).Once()
+	env.throttleRepo.On("Reset", mock.Anything, domain.ThrottleScopeAccount, "user@example.com").Return(nil).Once()
+
+	// Codes are accepted regardless of case and separators
+	result, err := env.interactor.CompleteMFALogin(context.Background(), env.mfaChallenge(t, user), "ABCDE FGHJK", "192.0.2.1")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.AccessToken)
+	env.mfaRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestAuthInteractor_CompleteMFALogin_InvalidChallenge(t *testing.T) {
+	env := setupAuthTestEnv()
+	access, _, _ := env.tokens.Issue(&domain.User{ID: "user-1"}, false)
+
+	_, err := env.interactor.CompleteMFALogin(context.Background(), access, "123456", "192.0.2.1")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	env.userRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+}
+
+func TestAuthInteractor_BeginMFAEnrollment(t *testing.T) {
+	env := setupAuthTestEnv()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "user-1"})
+	var saved []byte
+
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", Email: "user@example.com"}, nil).Once()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(nil, nil).Once()
+	env.mfaRepo.On("SaveMFASecret", mock.Anything, "user-1", mock.AnythingOfType("[]uint8")).Run(func(args mock.Arguments) {
+		saved = args.Get(2).([]byte)
+	}).Return(nil).Once()
+
+	enrollment, err := env.interactor.BeginMFAEnrollment(ctx)
+
+	assert.NoError(t, err)
+	assert.Contains(t, enrollment.KeyURI, "otpauth://totp/echo_tutrial:user@example.com")
+	assert.NotContains(t, string(saved), enrollment.Secret, "secret must be stored encrypted")
+	plaintext, err := env.secrets.Decrypt(saved)
+	assert.NoError(t, err)
+	assert.Equal(t, enrollment.Secret, string(plaintext))
+}
+
+func TestAuthInteractor_BeginMFAEnrollment_AlreadyEnabled(t *testing.T) {
+	env := setupAuthTestEnv()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "user-1"})
+
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1"}, nil).Once()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(env.enabledMFASetting(t, "user-1"), nil).Once()
+
+	_, err := env.interactor.BeginMFAEnrollment(ctx)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	_, err = env.interactor.BeginMFAEnrollment(context.Background())
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+}
+
+func TestAuthInteractor_ConfirmMFAEnrollment(t *testing.T) {
+	env := setupAuthTestEnv()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "user-1"})
+	pending := env.enabledMFASetting(t, "user-1")
+	pending.Enabled = false
+	var storedHashes []string
+
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(pending, nil).Once()
+	env.mfaRepo.On("EnableMFA", mock.Anything, "user-1", totp.Step(env.clock.Now()), env.clock.Now(), mock.Anything).Run(func(args mock.Arguments) {
+		storedHashes = args.Get(4).([]string)
+	}).Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionMFAEnabled && e.ActorID == "user-1"
+	})).Return(nil).Once()
+
+	codes, err := env.interactor.ConfirmMFAEnrollment(ctx, env.currentCode(t, 0))
+
+	assert.NoError(t, err)
+	assert.Len(t, codes, recoveryCodeCount)
+	assert.Len(t, storedHashes, recoveryCodeCount)
+	for i, code := range codes {
+		assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, code)
+		assert.Equal(t, hashRecoveryCode(code), storedHashes[i])
+		assert.NotContains(t, storedHashes[i], code)
+	}
+	env.mfaRepo.AssertExpectations(t)
+}
+
+func TestAuthInteractor_ConfirmMFAEnrollment_WrongCode(t *testing.T) {
+	env := setupAuthTestEnv()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "user-1"})
+	pending := env.enabledMFASetting(t, "user-1")
+	pending.Enabled = false
+
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(pending, nil).Once()
+
+	env.clock.Advance(5 * time.Minute)
+	_, err := env.interactor.ConfirmMFAEnrollment(ctx, "000000")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+	env.mfaRepo.AssertNotCalled(t, "EnableMFA", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
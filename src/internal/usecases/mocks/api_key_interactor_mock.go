@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeyInteractor struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyInteractor) CreateAPIKey(ctx context.Context, userID, name string, scopes []string, expiresAt time.Time) (*domain.APIKey, string, error) {
+	args := m.Called(ctx, userID, name, scopes, expiresAt)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*domain.APIKey), args.String(1), args.Error(2)
+}
+
+func (m *MockAPIKeyInteractor) ListAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyInteractor) RevokeAPIKey(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyInteractor) AuthenticateAPIKey(ctx context.Context, rawKey string) (*auth.Principal, error) {
+	args := m.Called(ctx, rawKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Principal), args.Error(1)
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/usecases"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuthInteractor struct {
+	mock.Mock
+}
+
+func (m *MockAuthInteractor) Login(ctx context.Context, email, plainPassword, clientIP string) (*usecases.LoginResult, error) {
+	args := m.Called(ctx, email, plainPassword, clientIP)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecases.LoginResult), args.Error(1)
+}
+
+func (m *MockAuthInteractor) CompleteMFALogin(ctx context.Context, mfaToken, code, clientIP string) (*usecases.LoginResult, error) {
+	args := m.Called(ctx, mfaToken, code, clientIP)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecases.LoginResult), args.Error(1)
+}
+
+func (m *MockAuthInteractor) BeginMFAEnrollment(ctx context.Context) (*usecases.MFAEnrollment, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecases.MFAEnrollment), args.Error(1)
+}
+
+func (m *MockAuthInteractor) ConfirmMFAEnrollment(ctx context.Context, code string) ([]string, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockAuthInteractor) UnlockUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
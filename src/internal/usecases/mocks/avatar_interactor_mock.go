@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+	"io"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAvatarInteractor struct {
+	mock.Mock
+}
+
+// UploadAvatar reads r fully before recording the call, so expectations can
+// match on the uploaded bytes.
+func (m *MockAvatarInteractor) UploadAvatar(ctx context.Context, userID string, r io.Reader) (*domain.User, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	args := m.Called(ctx, userID, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
@@ -0,0 +1,75 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGroupInteractor struct {
+	mock.Mock
+}
+
+func (m *MockGroupInteractor) CreateGroup(ctx context.Context, name, description string) (*domain.Group, error) {
+	args := m.Called(ctx, name, description)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Group), args.Error(1)
+}
+
+func (m *MockGroupInteractor) ListGroups(ctx context.Context) ([]domain.Group, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Group), args.Error(1)
+}
+
+func (m *MockGroupInteractor) GetGroup(ctx context.Context, id string) (*domain.Group, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Group), args.Error(1)
+}
+
+func (m *MockGroupInteractor) UpdateGroup(ctx context.Context, id string, name, description *string) (*domain.Group, error) {
+	args := m.Called(ctx, id, name, description)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Group), args.Error(1)
+}
+
+func (m *MockGroupInteractor) DeleteGroup(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockGroupInteractor) AddGroupMember(ctx context.Context, groupID, userID string) error {
+	args := m.Called(ctx, groupID, userID)
+	return args.Error(0)
+}
+
+func (m *MockGroupInteractor) RemoveGroupMember(ctx context.Context, groupID, userID string) error {
+	args := m.Called(ctx, groupID, userID)
+	return args.Error(0)
+}
+
+func (m *MockGroupInteractor) ListGroupMembers(ctx context.Context, groupID string) ([]domain.User, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockGroupInteractor) ListUserGroups(ctx context.Context, userID string) ([]domain.Group, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Group), args.Error(1)
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInvitationInteractor struct {
+	mock.Mock
+}
+
+func (m *MockInvitationInteractor) CreateInvitation(ctx context.Context, orgID, email, role string) (*domain.Invitation, error) {
+	args := m.Called(ctx, orgID, email, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationInteractor) ResendInvitation(ctx context.Context, id string) (*domain.Invitation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationInteractor) RevokeInvitation(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockInvitationInteractor) AcceptInvitation(ctx context.Context, token, name, plainPassword string) (*domain.OrganizationMember, error) {
+	args := m.Called(ctx, token, name, plainPassword)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrganizationMember), args.Error(1)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobInteractor struct {
+	mock.Mock
+}
+
+func (m *MockJobInteractor) ListJobs(ctx context.Context) ([]domain.JobStatus, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.JobStatus), args.Error(1)
+}
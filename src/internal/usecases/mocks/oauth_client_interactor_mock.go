@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/usecases"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOAuthClientInteractor struct {
+	mock.Mock
+}
+
+func (m *MockOAuthClientInteractor) CreateOAuthClient(ctx context.Context, in usecases.OAuthClientInput) (*domain.OAuthClient, string, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*domain.OAuthClient), args.String(1), args.Error(2)
+}
+
+func (m *MockOAuthClientInteractor) ListOAuthClients(ctx context.Context) ([]domain.OAuthClient, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OAuthClient), args.Error(1)
+}
+
+func (m *MockOAuthClientInteractor) GetOAuthClient(ctx context.Context, id string) (*domain.OAuthClient, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OAuthClient), args.Error(1)
+}
+
+func (m *MockOAuthClientInteractor) DeleteOAuthClient(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
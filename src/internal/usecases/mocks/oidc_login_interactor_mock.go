@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/usecases"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOIDCLoginInteractor struct {
+	mock.Mock
+}
+
+func (m *MockOIDCLoginInteractor) BeginOIDCLogin(ctx context.Context, provider string) (string, string, error) {
+	args := m.Called(ctx, provider)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockOIDCLoginInteractor) CompleteOIDCLogin(ctx context.Context, provider, state, code string) (*usecases.LoginResult, error) {
+	args := m.Called(ctx, provider, state, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecases.LoginResult), args.Error(1)
+}
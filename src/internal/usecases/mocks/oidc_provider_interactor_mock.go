@@ -0,0 +1,79 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/oidc"
+	"apiserver/internal/usecases"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOIDCProviderInteractor struct {
+	mock.Mock
+}
+
+func (m *MockOIDCProviderInteractor) Discovery() *usecases.OIDCProviderMetadata {
+	args := m.Called()
+	return args.Get(0).(*usecases.OIDCProviderMetadata)
+}
+
+func (m *MockOIDCProviderInteractor) PublicKeys(ctx context.Context) ([]oidc.JWK, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]oidc.JWK), args.Error(1)
+}
+
+func (m *MockOIDCProviderInteractor) BeginAuthorization(ctx context.Context, req usecases.AuthorizationRequest) (string, error) {
+	args := m.Called(ctx, req)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOIDCProviderInteractor) Authorize(ctx context.Context, req usecases.AuthorizationRequest, approve *bool) (*usecases.AuthorizationResult, error) {
+	args := m.Called(ctx, req, approve)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecases.AuthorizationResult), args.Error(1)
+}
+
+func (m *MockOIDCProviderInteractor) ExchangeCode(ctx context.Context, req usecases.TokenRequest) (*usecases.TokenResult, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecases.TokenResult), args.Error(1)
+}
+
+func (m *MockOIDCProviderInteractor) UserInfo(ctx context.Context, accessToken string) (*usecases.UserClaims, error) {
+	args := m.Called(ctx, accessToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecases.UserClaims), args.Error(1)
+}
+
+func (m *MockOIDCProviderInteractor) ListOAuthConsents(ctx context.Context) ([]domain.OAuthConsent, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OAuthConsent), args.Error(1)
+}
+
+func (m *MockOIDCProviderInteractor) RevokeOAuthConsent(ctx context.Context, clientID string) error {
+	args := m.Called(ctx, clientID)
+	return args.Error(0)
+}
+
+func (m *MockOIDCProviderInteractor) RotateSigningKey(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOIDCProviderInteractor) EnsureSigningKey(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
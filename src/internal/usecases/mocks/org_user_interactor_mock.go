@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrgUserInteractor struct {
+	mock.Mock
+}
+
+func (m *MockOrgUserInteractor) ListOrgUsers(ctx context.Context) ([]domain.OrganizationMember, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrgUserInteractor) CreateOrgUser(ctx context.Context, name, email, plainPassword, role string) (*domain.OrganizationMember, error) {
+	args := m.Called(ctx, name, email, plainPassword, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrgUserInteractor) UpdateOrgUser(ctx context.Context, id string, name, email, plainPassword, role *string) (*domain.OrganizationMember, error) {
+	args := m.Called(ctx, id, name, email, plainPassword, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrganizationMember), args.Error(1)
+}
+
+func (m *MockOrgUserInteractor) RemoveOrgUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
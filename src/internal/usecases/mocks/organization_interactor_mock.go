@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/tenant"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrganizationInteractor struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationInteractor) CreateOrganization(ctx context.Context, name, slug, ownerID string) (*domain.Organization, error) {
+	args := m.Called(ctx, name, slug, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationInteractor) ListMyOrganizations(ctx context.Context) ([]domain.OrganizationMembership, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrganizationMembership), args.Error(1)
+}
+
+func (m *MockOrganizationInteractor) ResolveTenant(ctx context.Context, orgID string) (*tenant.Tenant, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tenant.Tenant), args.Error(1)
+}
+
+func (m *MockOrganizationInteractor) IssueOrganizationToken(ctx context.Context, orgID string) (string, time.Time, error) {
+	args := m.Called(ctx, orgID)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
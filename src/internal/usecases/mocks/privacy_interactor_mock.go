@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPrivacyInteractor struct {
+	mock.Mock
+}
+
+func (m *MockPrivacyInteractor) ExportUserData(ctx context.Context, userID string) (*domain.UserDataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserDataExport), args.Error(1)
+}
+
+func (m *MockPrivacyInteractor) EraseUser(ctx context.Context, userID, reason string) (*domain.ErasureReceipt, error) {
+	args := m.Called(ctx, userID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ErasureReceipt), args.Error(1)
+}
+
+func (m *MockPrivacyInteractor) GetErasureReceipt(ctx context.Context, userID string) (*domain.ErasureReceipt, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ErasureReceipt), args.Error(1)
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserAttributeInteractor struct {
+	mock.Mock
+}
+
+func (m *MockUserAttributeInteractor) ListAttributeDefinitions(ctx context.Context) ([]domain.AttributeDefinition, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockUserAttributeInteractor) CreateAttributeDefinition(ctx context.Context, def domain.AttributeDefinition) (*domain.AttributeDefinition, error) {
+	args := m.Called(ctx, def)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockUserAttributeInteractor) UpdateAttributeDefinition(ctx context.Context, key string, patch domain.AttributeDefinitionPatch) (*domain.AttributeDefinition, error) {
+	args := m.Called(ctx, key, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockUserAttributeInteractor) DeleteAttributeDefinition(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/usecases"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserBatchInteractor struct {
+	mock.Mock
+}
+
+func (m *MockUserBatchInteractor) ExecuteUserBatch(ctx context.Context, ops []usecases.UserBatchOperation, atomic bool) ([]usecases.UserBatchResult, error) {
+	args := m.Called(ctx, ops, atomic)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecases.UserBatchResult), args.Error(1)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/usecases"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserEventInteractor struct {
+	mock.Mock
+}
+
+func (m *MockUserEventInteractor) SubscribeUserEvents(ctx context.Context, lastEventID int64, types []string) (usecases.UserEventSubscription, error) {
+	args := m.Called(ctx, lastEventID, types)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(usecases.UserEventSubscription), args.Error(1)
+}
@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+	"io"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserImportInteractor struct {
+	mock.Mock
+}
+
+func (m *MockUserImportInteractor) ImportUsers(ctx context.Context, format string, upload io.Reader) (*domain.UserImportReport, error) {
+	args := m.Called(ctx, format, upload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserImportReport), args.Error(1)
+}
+
+func (m *MockUserImportInteractor) StartUserImport(ctx context.Context, format string, upload io.Reader) (*domain.UserImportJob, error) {
+	args := m.Called(ctx, format, upload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserImportJob), args.Error(1)
+}
+
+func (m *MockUserImportInteractor) GetUserImport(ctx context.Context, id string) (*domain.UserImportJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserImportJob), args.Error(1)
+}
@@ -26,14 +26,36 @@ func (m *MockUserInteractor) FindUserByID(ctx context.Context, id string) (*doma
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
-func (m *MockUserInteractor) GetAllUsers(ctx context.Context) ([]domain.User, error) {
-	args := m.Called(ctx)
+func (m *MockUserInteractor) GetAllUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.User), args.Error(1)
 }
 
+// ExportUsers passes each user of the configured slice to fn, then returns the
+// configured error.
+func (m *MockUserInteractor) ExportUsers(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error {
+	args := m.Called(ctx, filter)
+	if users, ok := args.Get(0).([]domain.User); ok {
+		for i := range users {
+			if err := fn(&users[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockUserInteractor) ListUsersPage(ctx context.Context, afterID string, limit int) (*domain.UserPage, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserPage), args.Error(1)
+}
+
 func (m *MockUserInteractor) UpdateExistingUser(ctx context.Context, id string, name, email *string, plainPassword *string) (*domain.User, error) {
 	args := m.Called(ctx, id, name, email, plainPassword)
 	if args.Get(0) == nil {
@@ -42,6 +64,14 @@ func (m *MockUserInteractor) UpdateExistingUser(ctx context.Context, id string,
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserInteractor) PatchUser(ctx context.Context, id string, patch domain.UserPatch) (*domain.User, error) {
+	args := m.Called(ctx, id, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
 func (m *MockUserInteractor) RemoveUser(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserSearchInteractor struct {
+	mock.Mock
+}
+
+func (m *MockUserSearchInteractor) SearchUsers(ctx context.Context, query string, limit int, cursor string) (*domain.UserSearchPage, error) {
+	args := m.Called(ctx, query, limit, cursor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserSearchPage), args.Error(1)
+}
@@ -0,0 +1,66 @@
+package mocks
+
+import (
+	"context"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/usecases"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookInteractor struct {
+	mock.Mock
+}
+
+func (m *MockWebhookInteractor) CreateWebhook(ctx context.Context, in usecases.WebhookInput) (*domain.WebhookSubscription, string, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*domain.WebhookSubscription), args.String(1), args.Error(2)
+}
+
+func (m *MockWebhookInteractor) ListWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookInteractor) GetWebhook(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookInteractor) UpdateWebhook(ctx context.Context, id string, patch usecases.WebhookPatch) (*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, id, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookInteractor) DeleteWebhook(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookInteractor) ListWebhookDeliveries(ctx context.Context, id string, beforeID int64, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, id, beforeID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookInteractor) RedeliverWebhook(ctx context.Context, id string, deliveryID int64) (*domain.WebhookDelivery, error) {
+	args := m.Called(ctx, id, deliveryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookDelivery), args.Error(1)
+}
@@ -0,0 +1,228 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+	"github.com/google/uuid"
+)
+
+const (
+	// oauthClientSecretTag starts every generated client secret.
+	oauthClientSecretTag = "ucs_"
+	// oauthClientSecretBytes is the amount of randomness in a client secret.
+	oauthClientSecretBytes = 32
+	// maxOAuthClientNameLength matches oauth_clients.name.
+	maxOAuthClientNameLength = 255
+	// maxRedirectURILength matches oauth_authorization_codes.redirect_uri.
+	maxRedirectURILength = 2048
+	// maxRedirectURIs bounds the redirect URIs of a client.
+	maxRedirectURIs = 10
+)
+
+// OAuthClientInput holds the fields of a new OAuth client.
+type OAuthClientInput struct {
+	Name         string
+	RedirectURIs []string
+	// Public registers a client that cannot keep a secret, such as a
+	// single-page app. It gets no secret and must use PKCE.
+	Public bool
+}
+
+// OAuthClientInteractor defines the interface for registering the internal
+// applications that sign users in with this service. All operations require
+// an administrator.
+type OAuthClientInteractor interface {
+	// CreateOAuthClient registers a client and returns it with its secret,
+	// which cannot be retrieved again. Public clients get no secret.
+	CreateOAuthClient(ctx context.Context, in OAuthClientInput) (client *domain.OAuthClient, secret string, err error)
+	ListOAuthClients(ctx context.Context) ([]domain.OAuthClient, error)
+	GetOAuthClient(ctx context.Context, id string) (*domain.OAuthClient, error)
+	// DeleteOAuthClient deletes a client. The consents users gave it and the
+	// tokens it holds go with it.
+	DeleteOAuthClient(ctx context.Context, id string) error
+}
+
+// oauthClientInteractor implements OAuthClientInteractor.
+type oauthClientInteractor struct {
+	clientRepo repositories.OAuthClientRepository
+	auditor    audit.Recorder
+	clock      clock.Clock
+}
+
+// NewOAuthClientInteractor creates a new instance of OAuthClientInteractor.
+func NewOAuthClientInteractor(clientRepo repositories.OAuthClientRepository, auditor audit.Recorder, clk clock.Clock) OAuthClientInteractor {
+	return &oauthClientInteractor{clientRepo: clientRepo, auditor: auditor, clock: clk}
+}
+
+// generateOAuthClientSecret returns a new secret formatted as "ucs_<base64url>".
+func generateOAuthClientSecret() (string, error) {
+	b := make([]byte, oauthClientSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return oauthClientSecretTag + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashOAuthSecret returns the stored form of a client secret, authorization
+// code or access token. They carry 256 bits of randomness, so a plain
+// SHA-256 is sufficient.
+func hashOAuthSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// validateRedirectURI accepts absolute https URLs, and http URLs on the
+// loopback interface for native apps (RFC 8252 §7.3). Fragments are not
+// allowed, since the code is sent in the query.
+func validateRedirectURI(raw string) error {
+	if len(raw) > maxRedirectURILength {
+		return fmt.Errorf("%w: redirect URIs must not exceed %d characters", domain.ErrInvalidArgument, maxRedirectURILength)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || strings.ContainsAny(raw, " \t\r\n") {
+		return fmt.Errorf("%w: redirect URI %q is not an absolute URL", domain.ErrInvalidArgument, raw)
+	}
+	if u.Fragment != "" || strings.Contains(raw, "#") {
+		return fmt.Errorf("%w: redirect URI %q must not contain a fragment", domain.ErrInvalidArgument, raw)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: redirect URI %q must not contain credentials", domain.ErrInvalidArgument, raw)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if host := u.Hostname(); host == "localhost" || net.ParseIP(host).IsLoopback() {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: redirect URI %q must use https, or http on a loopback address", domain.ErrInvalidArgument, raw)
+}
+
+// normalizeRedirectURIs validates redirect URIs and removes duplicates.
+func normalizeRedirectURIs(uris []string) ([]string, error) {
+	if len(uris) == 0 {
+		return nil, fmt.Errorf("%w: at least one redirect URI is required", domain.ErrInvalidArgument)
+	}
+	if len(uris) > maxRedirectURIs {
+		return nil, fmt.Errorf("%w: at most %d redirect URIs are allowed", domain.ErrInvalidArgument, maxRedirectURIs)
+	}
+	seen := make(map[string]bool, len(uris))
+	out := make([]string, 0, len(uris))
+	for _, u := range uris {
+		if err := validateRedirectURI(u); err != nil {
+			return nil, err
+		}
+		if !seen[u] {
+			seen[u] = true
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+func (uc *oauthClientInteractor) CreateOAuthClient(ctx context.Context, in OAuthClientInput) (*domain.OAuthClient, string, error) {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	name := strings.TrimSpace(in.Name)
+	if name == "" || len(name) > maxOAuthClientNameLength {
+		return nil, "", fmt.Errorf("%w: name must be 1 to %d characters", domain.ErrInvalidArgument, maxOAuthClientNameLength)
+	}
+	redirectURIs, err := normalizeRedirectURIs(in.RedirectURIs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	client := &domain.OAuthClient{Name: name, RedirectURIs: redirectURIs}
+	var secret string
+	if !in.Public {
+		if secret, err = generateOAuthClientSecret(); err != nil {
+			return nil, "", err
+		}
+		client.SecretHash = hashOAuthSecret(secret)
+	}
+	if err := uc.clientRepo.CreateClient(ctx, client); err != nil {
+		return nil, "", err
+	}
+	client.CreatedAt = uc.clock.Now()
+	if err := uc.recordClientChange(ctx, principal.UserID, domain.AuditActionOAuthClientCreated, client); err != nil {
+		return nil, "", err
+	}
+	return client, secret, nil
+}
+
+func (uc *oauthClientInteractor) ListOAuthClients(ctx context.Context) ([]domain.OAuthClient, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return uc.clientRepo.ListClients(ctx)
+}
+
+func (uc *oauthClientInteractor) GetOAuthClient(ctx context.Context, id string) (*domain.OAuthClient, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return getOAuthClient(ctx, uc.clientRepo, id)
+}
+
+func (uc *oauthClientInteractor) DeleteOAuthClient(ctx context.Context, id string) error {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+	client, err := getOAuthClient(ctx, uc.clientRepo, id)
+	if err != nil {
+		return err
+	}
+	deleted, err := uc.clientRepo.DeleteClient(ctx, client.ID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return domain.ErrOAuthClientNotFound
+	}
+	return uc.recordClientChange(ctx, principal.UserID, domain.AuditActionOAuthClientDeleted, client)
+}
+
+// getOAuthClient returns the client or ErrOAuthClientNotFound.
+func getOAuthClient(ctx context.Context, repo repositories.OAuthClientRepository, id string) (*domain.OAuthClient, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, domain.ErrOAuthClientNotFound
+	}
+	client, err := repo.GetClient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, domain.ErrOAuthClientNotFound
+	}
+	return client, nil
+}
+
+func (uc *oauthClientInteractor) recordClientChange(ctx context.Context, actorID, action string, client *domain.OAuthClient) error {
+	return uc.auditor.Record(ctx, domain.AuditEvent{
+		Action:  action,
+		ActorID: actorID,
+		Metadata: map[string]string{
+			"client_id":     client.ID,
+			"name":          client.Name,
+			"redirect_uris": strings.Join(client.RedirectURIs, " "),
+			"public":        fmt.Sprint(client.IsPublic()),
+		},
+		OccurredAt: uc.clock.Now(),
+	})
+}
@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type oauthClientTestEnv struct {
+	repo       *mocks.MockOAuthClientRepository
+	auditor    *auditmocks.MockRecorder
+	clock      *clock.Fake
+	interactor OAuthClientInteractor
+}
+
+func setupOAuthClientTestEnv() *oauthClientTestEnv {
+	env := &oauthClientTestEnv{
+		repo:    new(mocks.MockOAuthClientRepository),
+		auditor: new(auditmocks.MockRecorder),
+		clock:   clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	env.interactor = NewOAuthClientInteractor(env.repo, env.auditor, env.clock)
+	return env
+}
+
+const testClientID = "3f0e8a6c-2d5b-4c8e-9a41-7b2f6d1e0c93"
+
+func TestOAuthClientInteractor_CreateOAuthClient_Confidential(t *testing.T) {
+	env := setupOAuthClientTestEnv()
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+	var stored *domain.OAuthClient
+
+	env.repo.On("CreateClient", mock.Anything, mock.AnythingOfType("*domain.OAuthClient")).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.OAuthClient)
+		stored.ID = testClientID
+	}).Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionOAuthClientCreated && e.ActorID == "admin-1" &&
+			e.Metadata["client_id"] == testClientID && e.Metadata["public"] == "false"
+	})).Return(nil).Once()
+
+	client, secret, err := env.interactor.CreateOAuthClient(ctx, OAuthClientInput{
+		Name:         " Wiki ",
+		RedirectURIs: []string{"https://wiki.example.com/callback", "https://wiki.example.com/callback", "http://127.0.0.1:8000/cb"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, testClientID, client.ID)
+	assert.Equal(t, "Wiki", stored.Name)
+	assert.Equal(t, []string{"https://wiki.example.com/callback", "http://127.0.0.1:8000/cb"}, stored.RedirectURIs)
+	assert.True(t, strings.HasPrefix(secret, oauthClientSecretTag))
+	assert.Equal(t, hashOAuthSecret(secret), stored.SecretHash)
+	assert.False(t, client.IsPublic())
+	env.auditor.AssertExpectations(t)
+}
+
+func TestOAuthClientInteractor_CreateOAuthClient_Public(t *testing.T) {
+	env := setupOAuthClientTestEnv()
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+
+	env.repo.On("CreateClient", mock.Anything, mock.AnythingOfType("*domain.OAuthClient")).Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+
+	client, secret, err := env.interactor.CreateOAuthClient(ctx, OAuthClientInput{
+		Name:         "CLI",
+		RedirectURIs: []string{"http://localhost:7777/callback"},
+		Public:       true,
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, secret)
+	assert.True(t, client.IsPublic())
+}
+
+func TestOAuthClientInteractor_CreateOAuthClient_Rejected(t *testing.T) {
+	env := setupOAuthClientTestEnv()
+	admin := sessionContext("admin-1", domain.RoleAdmin, true)
+
+	tests := map[string]struct {
+		ctx     context.Context
+		in      OAuthClientInput
+		wantErr error
+	}{
+		"not admin":         {sessionContext("user-1", domain.RoleUser, true), OAuthClientInput{Name: "Wiki", RedirectURIs: []string{"https://wiki.example.com/cb"}}, domain.ErrForbidden},
+		"admin without mfa": {sessionContext("admin-1", domain.RoleAdmin, false), OAuthClientInput{Name: "Wiki", RedirectURIs: []string{"https://wiki.example.com/cb"}}, domain.ErrMFARequired},
+		"no name":           {admin, OAuthClientInput{Name: " ", RedirectURIs: []string{"https://wiki.example.com/cb"}}, domain.ErrInvalidArgument},
+		"no redirect uris":  {admin, OAuthClientInput{Name: "Wiki"}, domain.ErrInvalidArgument},
+		"plain http":        {admin, OAuthClientInput{Name: "Wiki", RedirectURIs: []string{"http://wiki.example.com/cb"}}, domain.ErrInvalidArgument},
+		"fragment":          {admin, OAuthClientInput{Name: "Wiki", RedirectURIs: []string{"https://wiki.example.com/cb#x"}}, domain.ErrInvalidArgument},
+		"relative":          {admin, OAuthClientInput{Name: "Wiki", RedirectURIs: []string{"/cb"}}, domain.ErrInvalidArgument},
+		"custom scheme":     {admin, OAuthClientInput{Name: "Wiki", RedirectURIs: []string{"javascript://wiki.example.com/cb"}}, domain.ErrInvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := env.interactor.CreateOAuthClient(tt.ctx, tt.in)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+	env.repo.AssertNotCalled(t, "CreateClient", mock.Anything, mock.Anything)
+}
+
+func TestOAuthClientInteractor_GetOAuthClient_NotFound(t *testing.T) {
+	env := setupOAuthClientTestEnv()
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+
+	env.repo.On("GetClient", mock.Anything, testClientID).Return(nil, nil).Once()
+
+	_, err := env.interactor.GetOAuthClient(ctx, testClientID)
+	assert.ErrorIs(t, err, domain.ErrOAuthClientNotFound)
+
+	// Malformed IDs never reach the database
+	_, err = env.interactor.GetOAuthClient(ctx, "not-a-uuid")
+	assert.ErrorIs(t, err, domain.ErrOAuthClientNotFound)
+	env.repo.AssertExpectations(t)
+}
+
+func TestOAuthClientInteractor_DeleteOAuthClient(t *testing.T) {
+	env := setupOAuthClientTestEnv()
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+	client := &domain.OAuthClient{ID: testClientID, Name: "Wiki", RedirectURIs: []string{"https://wiki.example.com/cb"}}
+
+	env.repo.On("GetClient", mock.Anything, testClientID).Return(client, nil).Once()
+	env.repo.On("DeleteClient", mock.Anything, testClientID).Return(true, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionOAuthClientDeleted && e.Metadata["client_id"] == testClientID
+	})).Return(nil).Once()
+
+	err := env.interactor.DeleteOAuthClient(ctx, testClientID)
+
+	assert.NoError(t, err)
+	env.repo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
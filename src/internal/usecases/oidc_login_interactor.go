@@ -0,0 +1,259 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/oidc"
+	"apiserver/internal/repositories"
+)
+
+// IdentityProvider is an external OpenID Connect provider users may sign in with.
+type IdentityProvider struct {
+	Provider *oidc.Provider
+	// Provision creates an account on first sign-in when the provider vouches
+	// for an email that no user has yet.
+	Provision bool
+}
+
+// OIDCSettings configures OIDCLoginInteractor.
+type OIDCSettings struct {
+	LoginTTL time.Duration // How long a user has to sign in at the provider and come back
+}
+
+// DefaultOIDCSettings returns the settings used when none are configured.
+func DefaultOIDCSettings() OIDCSettings {
+	return OIDCSettings{LoginTTL: 10 * time.Minute}
+}
+
+// OIDCLoginInteractor defines the interface for signing in through external
+// identity providers with the authorization code flow and PKCE.
+type OIDCLoginInteractor interface {
+	// BeginOIDCLogin starts a sign-in at provider. The user is sent to
+	// authURL, and comes back with state and a code for CompleteOIDCLogin.
+	BeginOIDCLogin(ctx context.Context, provider string) (authURL, state string, err error)
+	// CompleteOIDCLogin redeems the code the provider sent the user back
+	// with and signs in the user linked to their provider account. An
+	// unlinked account gets a new user when the provider provisions and
+	// vouches for an email no user has yet; it is never linked to an
+	// existing user by email.
+	// As with a password, users with two-factor authentication enabled get
+	// an MFA challenge rather than an access token.
+	CompleteOIDCLogin(ctx context.Context, provider, state, code string) (*LoginResult, error)
+}
+
+// oidcLoginInteractor implements OIDCLoginInteractor.
+type oidcLoginInteractor struct {
+	providers    map[string]IdentityProvider
+	identityRepo repositories.IdentityRepository
+	userRepo     repositories.UserRepository
+	mfaRepo      repositories.MFARepository
+	users        UserInteractor // Provisions accounts
+	tokens       auth.TokenService
+	tx           repositories.TxManager
+	auditor      audit.Recorder
+	settings     OIDCSettings
+	clock        clock.Clock
+}
+
+// NewOIDCLoginInteractor creates a new instance of OIDCLoginInteractor.
+func NewOIDCLoginInteractor(providers []IdentityProvider, identityRepo repositories.IdentityRepository, userRepo repositories.UserRepository, mfaRepo repositories.MFARepository, users UserInteractor, tokens auth.TokenService, tx repositories.TxManager, auditor audit.Recorder, settings OIDCSettings, clk clock.Clock) OIDCLoginInteractor {
+	byName := make(map[string]IdentityProvider, len(providers))
+	for _, p := range providers {
+		byName[p.Provider.Name()] = p
+	}
+	return &oidcLoginInteractor{
+		providers:    byName,
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		mfaRepo:      mfaRepo,
+		users:        users,
+		tokens:       tokens,
+		tx:           tx,
+		auditor:      auditor,
+		settings:     settings,
+		clock:        clk,
+	}
+}
+
+// hashLoginState returns the stored form of a state parameter, so that the
+// table of sign-ins in progress cannot be used to complete them.
+func hashLoginState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
+
+func (uc *oidcLoginInteractor) provider(name string) (IdentityProvider, error) {
+	p, ok := uc.providers[name]
+	if !ok {
+		return IdentityProvider{}, domain.ErrIdentityProviderNotFound
+	}
+	return p, nil
+}
+
+func (uc *oidcLoginInteractor) BeginOIDCLogin(ctx context.Context, provider string) (string, string, error) {
+	p, err := uc.provider(provider)
+	if err != nil {
+		return "", "", err
+	}
+	var secrets [3]string // State, nonce and code verifier
+	for i := range secrets {
+		if secrets[i], err = oidc.NewRandomString(); err != nil {
+			return "", "", err
+		}
+	}
+	state, nonce, verifier := secrets[0], secrets[1], secrets[2]
+
+	authURL, err := p.Provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(verifier))
+	if err != nil {
+		return "", "", err
+	}
+	err = uc.identityRepo.CreateLoginState(ctx, &domain.OIDCLoginState{
+		StateHash:    hashLoginState(state),
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    uc.clock.Now().Add(uc.settings.LoginTTL),
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return authURL, state, nil
+}
+
+func (uc *oidcLoginInteractor) CompleteOIDCLogin(ctx context.Context, provider, state, code string) (*LoginResult, error) {
+	p, err := uc.provider(provider)
+	if err != nil {
+		return nil, err
+	}
+	if state == "" || code == "" {
+		return nil, fmt.Errorf("%w: state and code are required", domain.ErrInvalidArgument)
+	}
+
+	// The state is used up even when the sign-in fails, so that a code
+	// cannot be tried twice
+	login, err := uc.identityRepo.ConsumeLoginState(ctx, hashLoginState(state))
+	if err != nil {
+		return nil, err
+	}
+	if login == nil || login.Provider != provider || !uc.clock.Now().Before(login.ExpiresAt) {
+		return nil, domain.ErrExternalLoginFailed
+	}
+	claims, err := p.Provider.Exchange(ctx, code, login.CodeVerifier, login.Nonce)
+	if errors.Is(err, oidc.ErrCodeRejected) || errors.Is(err, oidc.ErrInvalidIDToken) {
+		return nil, fmt.Errorf("%w: %v", domain.ErrExternalLoginFailed, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var user *domain.User
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = uc.linkedUser(ctx, p, provider, claims)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	user.Password = "" // Never hand the hash back to callers
+
+	mfa, err := uc.mfaRepo.GetMFASetting(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa != nil && mfa.Enabled {
+		challenge, expiresAt, err := uc.tokens.IssueMFAChallenge(user)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{User: user, MFARequired: true, MFAToken: challenge, ExpiresAt: expiresAt}, nil
+	}
+	token, expiresAt, err := uc.tokens.Issue(user, false)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{User: user, AccessToken: token, ExpiresAt: expiresAt}, nil
+}
+
+// linkedUser returns the user linked to the provider account claims are
+// about, provisioning one when there is none yet.
+func (uc *oidcLoginInteractor) linkedUser(ctx context.Context, p IdentityProvider, provider string, claims *oidc.Claims) (*domain.User, error) {
+	now := uc.clock.Now()
+	identity, err := uc.identityRepo.GetIdentity(ctx, provider, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		user, err := uc.userRepo.GetUserByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil || !user.ErasedAt.IsZero() {
+			return nil, domain.ErrIdentityNotLinked
+		}
+		return user, uc.identityRepo.TouchIdentity(ctx, identity.ID, now)
+	}
+
+	// Emails the provider has not verified could belong to anyone
+	email := strings.TrimSpace(claims.Email)
+	if email == "" || !claims.EmailVerified {
+		return nil, domain.ErrIdentityNotLinked
+	}
+	user, err := uc.userRepo.GetUserByEmail(ctx, normalizeEmail(email))
+	if err != nil {
+		return nil, err
+	}
+	// Local emails are never verified, so a user with this one may have been
+	// registered by someone else in advance to take over the account
+	if user != nil || !p.Provision {
+		return nil, domain.ErrIdentityNotLinked
+	}
+	if user, err = uc.provisionUser(ctx, claims.Name, email); err != nil {
+		return nil, err
+	}
+
+	identity = &domain.UserIdentity{UserID: user.ID, Provider: provider, Subject: claims.Subject, LastLoginAt: now}
+	if err := uc.identityRepo.CreateIdentity(ctx, identity); err != nil {
+		return nil, err
+	}
+	err = uc.auditor.Record(ctx, domain.AuditEvent{
+		Action:   domain.AuditActionIdentityLinked,
+		ActorID:  user.ID,
+		TargetID: user.ID,
+		Metadata: map[string]string{
+			"provider":    provider,
+			"identity_id": identity.ID,
+			"provisioned": "true", // Accounts are only linked when they are created
+		},
+		OccurredAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// provisionUser creates a user for an email first seen from a provider. The
+// user gets a random password that nobody knows, so they can only sign in
+// through identity providers.
+func (uc *oidcLoginInteractor) provisionUser(ctx context.Context, name, email string) (*domain.User, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+	plainPassword, err := oidc.NewRandomString()
+	if err != nil {
+		return nil, err
+	}
+	return uc.users.CreateNewUser(ctx, name, email, plainPassword)
+}
@@ -0,0 +1,263 @@
+package usecases
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/oidc"
+	"apiserver/internal/oidc/oidctest"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type oidcLoginTestEnv struct {
+	idp          *oidctest.Server
+	identityRepo *mocks.MockIdentityRepository
+	userRepo     *mocks.MockUserRepository
+	mfaRepo      *mocks.MockMFARepository
+	auditor      *auditmocks.MockRecorder
+	tokens       auth.TokenService
+	clock        *clock.Fake
+	interactor   OIDCLoginInteractor
+}
+
+// setupOIDCLoginTestEnv serves a mock provider named "corp" whose clock
+// follows the interactor's.
+func setupOIDCLoginTestEnv(t *testing.T, provision bool) *oidcLoginTestEnv {
+	env := &oidcLoginTestEnv{
+		idp:          oidctest.NewServer("api-server", "secret"),
+		identityRepo: new(mocks.MockIdentityRepository),
+		userRepo:     new(mocks.MockUserRepository),
+		mfaRepo:      new(mocks.MockMFARepository),
+		auditor:      new(auditmocks.MockRecorder),
+		clock:        clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	t.Cleanup(env.idp.Close)
+	env.idp.Now = env.clock.Now
+	env.tokens = auth.NewJWTTokenService([]byte("test-secret"), time.Hour, env.clock)
+	provider := oidc.NewProvider(env.idp.Config("corp", "https://api.example.com/v1/login/oidc/corp/callback"), nil, env.clock)
+	env.interactor = NewOIDCLoginInteractor(
+		[]IdentityProvider{{Provider: provider, Provision: provision}},
+		env.identityRepo, env.userRepo, env.mfaRepo, newTestUserInteractor(env.userRepo), env.tokens, new(mocks.InlineTxManager), env.auditor,
+		DefaultOIDCSettings(), env.clock,
+	)
+	return env
+}
+
+// signIn begins a sign-in, has the provider sign in as user and returns the
+// state and code it redirected back with. The stored login state is handed
+// back when the state is consumed.
+func (env *oidcLoginTestEnv) signIn(t *testing.T, user oidctest.User) (state, code string) {
+	t.Helper()
+	var stored *domain.OIDCLoginState
+	env.identityRepo.On("CreateLoginState", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.OIDCLoginState)
+	}).Return(nil).Once()
+
+	authURL, state, err := env.interactor.BeginOIDCLogin(context.Background(), "corp")
+	require.NoError(t, err)
+	env.idp.SetUser(user)
+	code, returnedState, err := env.idp.Authorize(authURL)
+	require.NoError(t, err)
+	require.Equal(t, state, returnedState)
+
+	env.identityRepo.On("ConsumeLoginState", mock.Anything, hashLoginState(state)).Return(stored, nil).Once()
+	return state, code
+}
+
+var corpAlice = oidctest.User{Subject: "00u-alice", Email: "Alice@Example.com", EmailVerified: true, Name: "Alice"}
+
+func TestOIDCLoginInteractor_BeginOIDCLogin(t *testing.T) {
+	env := setupOIDCLoginTestEnv(t, false)
+	var stored *domain.OIDCLoginState
+	env.identityRepo.On("CreateLoginState", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.OIDCLoginState)
+	}).Return(nil).Once()
+
+	authURL, state, err := env.interactor.BeginOIDCLogin(context.Background(), "corp")
+
+	require.NoError(t, err)
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	q := u.Query()
+	assert.Equal(t, state, q.Get("state"))
+	assert.Equal(t, hashLoginState(state), stored.StateHash)
+	assert.Equal(t, "corp", stored.Provider)
+	assert.Equal(t, stored.Nonce, q.Get("nonce"))
+	assert.Equal(t, oidc.CodeChallenge(stored.CodeVerifier), q.Get("code_challenge"))
+	assert.Equal(t, env.clock.Now().Add(10*time.Minute), stored.ExpiresAt)
+}
+
+func TestOIDCLoginInteractor_BeginOIDCLogin_UnknownProvider(t *testing.T) {
+	env := setupOIDCLoginTestEnv(t, false)
+
+	_, _, err := env.interactor.BeginOIDCLogin(context.Background(), "other")
+
+	assert.ErrorIs(t, err, domain.ErrIdentityProviderNotFound)
+}
+
+func TestOIDCLoginInteractor_CompleteOIDCLogin_LinkedIdentity(t *testing.T) {
+	env := setupOIDCLoginTestEnv(t, false)
+	state, code := env.signIn(t, corpAlice)
+	user := &domain.User{ID: "user-1", Email: "alice@example.com", Role: domain.RoleUser, Password: "hash"}
+
+	env.identityRepo.On("GetIdentity", mock.Anything, "corp", "00u-alice").
+		Return(&domain.UserIdentity{ID: "identity-1", UserID: "user-1", Provider: "corp", Subject: "00u-alice"}, nil).Once()
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(user, nil).Once()
+	env.identityRepo.On("TouchIdentity", mock.Anything, "identity-1", env.clock.Now()).Return(nil).Once()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(nil, nil).Once()
+
+	result, err := env.interactor.CompleteOIDCLogin(context.Background(), "corp", state, code)
+
+	require.NoError(t, err)
+	assert.False(t, result.MFARequired)
+	assert.Empty(t, result.User.Password)
+	principal, err := env.tokens.Verify(result.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", principal.UserID)
+	env.identityRepo.AssertExpectations(t)
+	env.userRepo.AssertExpectations(t)
+}
+
+func TestOIDCLoginInteractor_CompleteOIDCLogin_PreRegisteredEmailIsNotLinked(t *testing.T) {
+	env := setupOIDCLoginTestEnv(t, true)
+	state, code := env.signIn(t, corpAlice)
+	// Anyone could have registered alice's address before her first sign-in
+	squatter := &domain.User{ID: "user-1", Email: "alice@example.com", Role: domain.RoleUser}
+
+	env.identityRepo.On("GetIdentity", mock.Anything, "corp", "00u-alice").Return(nil, nil).Once()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "alice@example.com").Return(squatter, nil).Once()
+
+	_, err := env.interactor.CompleteOIDCLogin(context.Background(), "corp", state, code)
+
+	assert.ErrorIs(t, err, domain.ErrIdentityNotLinked)
+	env.identityRepo.AssertNotCalled(t, "CreateIdentity", mock.Anything, mock.Anything)
+	env.userRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+	env.mfaRepo.AssertNotCalled(t, "GetMFASetting", mock.Anything, mock.Anything)
+}
+
+func TestOIDCLoginInteractor_CompleteOIDCLogin_UnverifiedEmailIsNotLinked(t *testing.T) {
+	env := setupOIDCLoginTestEnv(t, true)
+	unverified := corpAlice
+	unverified.EmailVerified = false
+	state, code := env.signIn(t, unverified)
+
+	env.identityRepo.On("GetIdentity", mock.Anything, "corp", "00u-alice").Return(nil, nil).Once()
+
+	_, err := env.interactor.CompleteOIDCLogin(context.Background(), "corp", state, code)
+
+	assert.ErrorIs(t, err, domain.ErrIdentityNotLinked)
+	env.userRepo.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+	env.identityRepo.AssertNotCalled(t, "CreateIdentity", mock.Anything, mock.Anything)
+}
+
+func TestOIDCLoginInteractor_CompleteOIDCLogin_Provisions(t *testing.T) {
+	env := setupOIDCLoginTestEnv(t, true)
+	state, code := env.signIn(t, corpAlice)
+	created := &domain.User{ID: "user-2", Name: "Alice", Email: "Alice@Example.com", Role: domain.RoleUser}
+
+	env.identityRepo.On("GetIdentity", mock.Anything, "corp", "00u-alice").Return(nil, nil).Once()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "alice@example.com").Return(nil, nil).Once()
+	env.userRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Name == "Alice" && u.Email == "Alice@Example.com"
+	}), mock.Anything).Return(created, nil).Once()
+	env.identityRepo.On("CreateIdentity", mock.Anything, mock.MatchedBy(func(i *domain.UserIdentity) bool {
+		return i.UserID == "user-2"
+	})).Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionIdentityLinked && e.Metadata["provisioned"] == "true"
+	})).Return(nil).Once()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-2").Return(nil, nil).Once()
+
+	result, err := env.interactor.CompleteOIDCLogin(context.Background(), "corp", state, code)
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-2", result.User.ID)
+	env.userRepo.AssertExpectations(t)
+	env.identityRepo.AssertExpectations(t)
+}
+
+func TestOIDCLoginInteractor_CompleteOIDCLogin_ProvisioningDisabled(t *testing.T) {
+	env := setupOIDCLoginTestEnv(t, false)
+	state, code := env.signIn(t, corpAlice)
+
+	env.identityRepo.On("GetIdentity", mock.Anything, "corp", "00u-alice").Return(nil, nil).Once()
+	env.userRepo.On("GetUserByEmail", mock.Anything, "alice@example.com").Return(nil, nil).Once()
+
+	_, err := env.interactor.CompleteOIDCLogin(context.Background(), "corp", state, code)
+
+	assert.ErrorIs(t, err, domain.ErrIdentityNotLinked)
+	env.userRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOIDCLoginInteractor_CompleteOIDCLogin_MFAChallenge(t *testing.T) {
+	env := setupOIDCLoginTestEnv(t, false)
+	state, code := env.signIn(t, corpAlice)
+	user := &domain.User{ID: "user-1", Email: "alice@example.com", Role: domain.RoleAdmin}
+
+	env.identityRepo.On("GetIdentity", mock.Anything, "corp", "00u-alice").
+		Return(&domain.UserIdentity{ID: "identity-1", UserID: "user-1"}, nil).Once()
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(user, nil).Once()
+	env.identityRepo.On("TouchIdentity", mock.Anything, "identity-1", env.clock.Now()).Return(nil).Once()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, "user-1").Return(&domain.MFASetting{UserID: "user-1", Enabled: true}, nil).Once()
+
+	result, err := env.interactor.CompleteOIDCLogin(context.Background(), "corp", state, code)
+
+	require.NoError(t, err)
+	assert.True(t, result.MFARequired)
+	assert.Empty(t, result.AccessToken)
+	userID, err := env.tokens.VerifyMFAChallenge(result.MFAToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+}
+
+func TestOIDCLoginInteractor_CompleteOIDCLogin_ErasedUser(t *testing.T) {
+	env := setupOIDCLoginTestEnv(t, false)
+	state, code := env.signIn(t, corpAlice)
+
+	env.identityRepo.On("GetIdentity", mock.Anything, "corp", "00u-alice").
+		Return(&domain.UserIdentity{ID: "identity-1", UserID: "user-1"}, nil).Once()
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", ErasedAt: env.clock.Now()}, nil).Once()
+
+	_, err := env.interactor.CompleteOIDCLogin(context.Background(), "corp", state, code)
+
+	assert.ErrorIs(t, err, domain.ErrIdentityNotLinked)
+}
+
+func TestOIDCLoginInteractor_CompleteOIDCLogin_UnknownState(t *testing.T) {
+	env := setupOIDCLoginTestEnv(t, false)
+	env.identityRepo.On("ConsumeLoginState", mock.Anything, hashLoginState("forged")).Return(nil, nil).Once()
+
+	_, err := env.interactor.CompleteOIDCLogin(context.Background(), "corp", "forged", "code")
+
+	assert.ErrorIs(t, err, domain.ErrExternalLoginFailed)
+}
+
+func TestOIDCLoginInteractor_CompleteOIDCLogin_ExpiredState(t *testing.T) {
+	env := setupOIDCLoginTestEnv(t, false)
+	state, code := env.signIn(t, corpAlice)
+	env.clock.Advance(11 * time.Minute)
+
+	_, err := env.interactor.CompleteOIDCLogin(context.Background(), "corp", state, code)
+
+	assert.ErrorIs(t, err, domain.ErrExternalLoginFailed)
+	env.identityRepo.AssertNotCalled(t, "GetIdentity", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOIDCLoginInteractor_CompleteOIDCLogin_RejectedCode(t *testing.T) {
+	env := setupOIDCLoginTestEnv(t, false)
+	state, _ := env.signIn(t, corpAlice)
+
+	_, err := env.interactor.CompleteOIDCLogin(context.Background(), "corp", state, "made-up-code")
+
+	assert.ErrorIs(t, err, domain.ErrExternalLoginFailed)
+	env.identityRepo.AssertNotCalled(t, "GetIdentity", mock.Anything, mock.Anything, mock.Anything)
+}
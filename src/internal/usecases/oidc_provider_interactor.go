@@ -0,0 +1,678 @@
+package usecases
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/encryption"
+	"apiserver/internal/oidc"
+	"apiserver/internal/repositories"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// oauthAccessTokenTag starts every access token issued to an OAuth client,
+	// telling them apart from the JWTs and API keys of this API.
+	oauthAccessTokenTag = "uoa_"
+	// codeChallengeLength is the length of an S256 code challenge.
+	codeChallengeLength = 43
+	// maxNonceLength matches oauth_authorization_codes.nonce.
+	maxNonceLength = 255
+)
+
+// OIDCProviderSettings configures OIDCProviderInteractor.
+type OIDCProviderSettings struct {
+	Issuer string // Base URL of this API as clients see it, without a trailing slash
+	// ConsentURL is the page of the web app that signs the user in and asks
+	// for consent. The authorization request is passed on in its query.
+	ConsentURL     string
+	CodeTTL        time.Duration // How long a client has to redeem an authorization code
+	AccessTokenTTL time.Duration
+	IDTokenTTL     time.Duration
+	// KeyGracePeriod is how long a retired signing key stays published, so
+	// that ID tokens it signed can still be verified. It should exceed IDTokenTTL.
+	KeyGracePeriod time.Duration
+	// KeyPublishAhead is how long a new signing key is published before it
+	// starts signing, so that clients caching the key set know it by then.
+	// It should be at least oidc.JWKSMaxAge.
+	KeyPublishAhead time.Duration
+}
+
+// DefaultOIDCProviderSettings returns the settings used when none are configured.
+func DefaultOIDCProviderSettings() OIDCProviderSettings {
+	return OIDCProviderSettings{
+		Issuer:          "http://localhost:8080",
+		ConsentURL:      "http://localhost:3000/oauth/consent",
+		CodeTTL:         time.Minute,
+		AccessTokenTTL:  time.Hour,
+		IDTokenTTL:      time.Hour,
+		KeyGracePeriod:  7 * 24 * time.Hour,
+		KeyPublishAhead: oidc.JWKSMaxAge,
+	}
+}
+
+// OIDCProviderMetadata describes this service as an OpenID provider
+// (OpenID Connect Discovery 1.0 §3).
+type OIDCProviderMetadata struct {
+	Issuer                            string
+	AuthorizationEndpoint             string
+	TokenEndpoint                     string
+	UserinfoEndpoint                  string
+	JWKSURI                           string
+	ScopesSupported                   []string
+	ResponseTypesSupported            []string
+	GrantTypesSupported               []string
+	SubjectTypesSupported             []string
+	IDTokenSigningAlgValuesSupported  []string
+	TokenEndpointAuthMethodsSupported []string
+	CodeChallengeMethodsSupported     []string
+	ClaimsSupported                   []string
+}
+
+// AuthorizationRequest holds the parameters a client sends to the
+// authorization endpoint (OpenID Connect Core §3.1.2.1).
+type AuthorizationRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string // Space-separated
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Prompt              string // Space-separated; "none" and "consent" are honored
+}
+
+// AuthorizationResult is the outcome of an authorization request made on
+// behalf of a signed-in user.
+type AuthorizationResult struct {
+	// RedirectURI is where to send the user back to the client, with either
+	// an authorization code or an error. Empty when consent is required.
+	RedirectURI string
+	// ConsentRequired is set when the user has to approve Scopes for Client
+	// before the request can be repeated with their answer.
+	ConsentRequired bool
+	Client          *domain.OAuthClient
+	Scopes          []string
+}
+
+// TokenRequest holds the parameters of a token request (RFC 6749 §4.1.3).
+// The client authenticates with ClientSecret unless it is public.
+type TokenRequest struct {
+	GrantType    string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	ClientID     string
+	ClientSecret string
+}
+
+// TokenResult holds the tokens issued for an authorization code.
+type TokenResult struct {
+	AccessToken string
+	TokenType   string
+	ExpiresIn   int // Seconds
+	IDToken     string
+	Scopes      []string
+}
+
+// UserClaims are the claims about a user released to a client. Claims of
+// scopes the user did not grant are left empty.
+type UserClaims struct {
+	Subject   string
+	Name      string
+	Picture   string
+	Locale    string
+	Zoneinfo  string
+	UpdatedAt time.Time
+	Email     string
+}
+
+// OIDCProviderInteractor defines the interface for acting as the OpenID
+// provider of internal applications with the authorization code flow.
+type OIDCProviderInteractor interface {
+	Discovery() *OIDCProviderMetadata
+	// PublicKeys returns the keys ID tokens are verified with: the active
+	// key, the next one while it waits to be activated and those retired
+	// within the grace period.
+	PublicKeys(ctx context.Context) ([]oidc.JWK, error)
+	// BeginAuthorization checks an authorization request and returns where
+	// to send the user: the consent page, or back to the client with an
+	// error. Requests naming an unknown client or an unregistered redirect
+	// URI cannot be sent back and fail with ErrInvalidArgument.
+	BeginAuthorization(ctx context.Context, req AuthorizationRequest) (string, error)
+	// Authorize answers an authorization request for the signed-in caller.
+	// approve carries the user's answer to the consent page; when it is nil,
+	// a code is only issued if the user already consented to the scopes.
+	Authorize(ctx context.Context, req AuthorizationRequest, approve *bool) (*AuthorizationResult, error)
+	// ExchangeCode redeems an authorization code for an access token and an
+	// ID token. Protocol failures are returned as *domain.OAuthError.
+	ExchangeCode(ctx context.Context, req TokenRequest) (*TokenResult, error)
+	// UserInfo returns the claims an access token grants. It fails with
+	// ErrUnauthenticated when the token is unknown or expired.
+	UserInfo(ctx context.Context, accessToken string) (*UserClaims, error)
+	ListOAuthConsents(ctx context.Context) ([]domain.OAuthConsent, error) // The caller's own
+	// RevokeOAuthConsent withdraws the caller's consent to a client and
+	// revokes the access tokens the client holds for them.
+	RevokeOAuthConsent(ctx context.Context, clientID string) error
+	// RotateSigningKey creates a signing key that is activated after
+	// KeyPublishAhead, when the others retire, and deletes keys retired
+	// before the grace period. It returns how many it deleted.
+	RotateSigningKey(ctx context.Context) (int, error)
+	// EnsureSigningKey creates a key that signs right away when there is no
+	// active key this instance can use, such as on first start or after the
+	// key encryption key changed. Instances should not call it concurrently.
+	EnsureSigningKey(ctx context.Context) error
+}
+
+// oidcProviderInteractor implements OIDCProviderInteractor.
+type oidcProviderInteractor struct {
+	clientRepo repositories.OAuthClientRepository
+	grantRepo  repositories.OAuthGrantRepository
+	keyRepo    repositories.SigningKeyRepository
+	userRepo   repositories.UserRepository
+	keyCipher  encryption.Cipher // Encrypts private signing keys at rest
+	tx         repositories.TxManager
+	auditor    audit.Recorder
+	settings   OIDCProviderSettings
+	clock      clock.Clock
+}
+
+// NewOIDCProviderInteractor creates a new instance of OIDCProviderInteractor.
+func NewOIDCProviderInteractor(clientRepo repositories.OAuthClientRepository, grantRepo repositories.OAuthGrantRepository, keyRepo repositories.SigningKeyRepository, userRepo repositories.UserRepository, keyCipher encryption.Cipher, tx repositories.TxManager, auditor audit.Recorder, settings OIDCProviderSettings, clk clock.Clock) OIDCProviderInteractor {
+	settings.Issuer = strings.TrimRight(settings.Issuer, "/")
+	return &oidcProviderInteractor{
+		clientRepo: clientRepo,
+		grantRepo:  grantRepo,
+		keyRepo:    keyRepo,
+		userRepo:   userRepo,
+		keyCipher:  keyCipher,
+		tx:         tx,
+		auditor:    auditor,
+		settings:   settings,
+		clock:      clk,
+	}
+}
+
+// IsOAuthAccessToken reports whether a bearer credential is an access token
+// issued to an OAuth client. Such tokens are only accepted by the userinfo
+// endpoint.
+func IsOAuthAccessToken(credential string) bool {
+	return strings.HasPrefix(credential, oauthAccessTokenTag)
+}
+
+func (uc *oidcProviderInteractor) Discovery() *OIDCProviderMetadata {
+	issuer := uc.settings.Issuer
+	return &OIDCProviderMetadata{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/v1/oauth/authorize",
+		TokenEndpoint:                     issuer + "/v1/oauth/token",
+		UserinfoEndpoint:                  issuer + "/v1/oauth/userinfo",
+		JWKSURI:                           issuer + "/.well-known/jwks.json",
+		ScopesSupported:                   domain.OAuthScopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{jwt.SigningMethodRS256.Alg()},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "nonce", "name", "picture", "locale", "zoneinfo", "updated_at", "email"},
+	}
+}
+
+// query returns the parameters of the request, for passing it on.
+func (r AuthorizationRequest) query() url.Values {
+	q := url.Values{}
+	for _, p := range []struct{ name, value string }{
+		{"response_type", r.ResponseType},
+		{"client_id", r.ClientID},
+		{"redirect_uri", r.RedirectURI},
+		{"scope", r.Scope},
+		{"state", r.State},
+		{"nonce", r.Nonce},
+		{"code_challenge", r.CodeChallenge},
+		{"code_challenge_method", r.CodeChallengeMethod},
+		{"prompt", r.Prompt},
+	} {
+		if p.value != "" {
+			q.Set(p.name, p.value)
+		}
+	}
+	return q
+}
+
+// hasPrompt reports whether the request asks for prompt value.
+func (r AuthorizationRequest) hasPrompt(value string) bool {
+	return containsScope(strings.Fields(r.Prompt), value)
+}
+
+// checkAuthorizationRequest returns the client and the granted scopes of a
+// request. Problems the client has to hear about are returned as
+// *domain.OAuthError; those that rule out redirecting to the client are
+// ErrInvalidArgument.
+func (uc *oidcProviderInteractor) checkAuthorizationRequest(ctx context.Context, req AuthorizationRequest) (*domain.OAuthClient, []string, error) {
+	if _, err := uuid.Parse(req.ClientID); err != nil {
+		return nil, nil, fmt.Errorf("%w: unknown client_id", domain.ErrInvalidArgument)
+	}
+	client, err := uc.clientRepo.GetClient(ctx, req.ClientID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if client == nil {
+		return nil, nil, fmt.Errorf("%w: unknown client_id", domain.ErrInvalidArgument)
+	}
+	// Sending the user to an unregistered URI would leak the code
+	if !client.AllowsRedirectURI(req.RedirectURI) {
+		return nil, nil, fmt.Errorf("%w: redirect_uri is not registered for the client", domain.ErrInvalidArgument)
+	}
+
+	if req.ResponseType != "code" {
+		return nil, nil, &domain.OAuthError{Code: domain.OAuthErrUnsupportedResponseType, Description: "only the code response type is supported"}
+	}
+	requested := strings.Fields(req.Scope)
+	if !containsScope(requested, domain.OAuthScopeOpenID) {
+		return nil, nil, &domain.OAuthError{Code: domain.OAuthErrInvalidScope, Description: "the openid scope is required"}
+	}
+	// Unknown scopes are ignored, as RFC 6749 §3.3 allows
+	var scopes []string
+	for _, s := range domain.OAuthScopes {
+		if containsScope(requested, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	if req.CodeChallenge != "" {
+		if req.CodeChallengeMethod != "S256" {
+			return nil, nil, &domain.OAuthError{Code: domain.OAuthErrInvalidRequest, Description: "code_challenge_method must be S256"}
+		}
+		if len(req.CodeChallenge) != codeChallengeLength {
+			return nil, nil, &domain.OAuthError{Code: domain.OAuthErrInvalidRequest, Description: "code_challenge is malformed"}
+		}
+	} else if client.IsPublic() {
+		return nil, nil, &domain.OAuthError{Code: domain.OAuthErrInvalidRequest, Description: "public clients must use PKCE"}
+	}
+	if len(req.Nonce) > maxNonceLength {
+		return nil, nil, &domain.OAuthError{Code: domain.OAuthErrInvalidRequest, Description: fmt.Sprintf("nonce must not exceed %d characters", maxNonceLength)}
+	}
+	return client, scopes, nil
+}
+
+// clientRedirect returns the redirect URI of req with params added, along
+// with the state and the issuer (RFC 9207).
+func (uc *oidcProviderInteractor) clientRedirect(req AuthorizationRequest, params url.Values) string {
+	u, err := url.Parse(req.RedirectURI)
+	if err != nil {
+		return req.RedirectURI // Registered URIs were validated
+	}
+	q := u.Query()
+	for k, v := range params {
+		q[k] = v
+	}
+	if req.State != "" {
+		q.Set("state", req.State)
+	}
+	q.Set("iss", uc.settings.Issuer)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// errorRedirect returns the redirect URI of req reporting e.
+func (uc *oidcProviderInteractor) errorRedirect(req AuthorizationRequest, e *domain.OAuthError) string {
+	params := url.Values{"error": {e.Code}}
+	if e.Description != "" {
+		params.Set("error_description", e.Description)
+	}
+	return uc.clientRedirect(req, params)
+}
+
+func (uc *oidcProviderInteractor) BeginAuthorization(ctx context.Context, req AuthorizationRequest) (string, error) {
+	_, _, err := uc.checkAuthorizationRequest(ctx, req)
+	var oauthErr *domain.OAuthError
+	if errors.As(err, &oauthErr) {
+		return uc.errorRedirect(req, oauthErr), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	consent, err := url.Parse(uc.settings.ConsentURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid OIDC provider consent URL: %w", err)
+	}
+	consent.RawQuery = req.query().Encode()
+	return consent.String(), nil
+}
+
+func (uc *oidcProviderInteractor) Authorize(ctx context.Context, req AuthorizationRequest, approve *bool) (*AuthorizationResult, error) {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, scopes, err := uc.checkAuthorizationRequest(ctx, req)
+	var oauthErr *domain.OAuthError
+	if errors.As(err, &oauthErr) {
+		return &AuthorizationResult{RedirectURI: uc.errorRedirect(req, oauthErr)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if approve != nil && !*approve {
+		return &AuthorizationResult{RedirectURI: uc.errorRedirect(req, &domain.OAuthError{Code: domain.OAuthErrAccessDenied, Description: "the user denied the request"})}, nil
+	}
+	consent, err := uc.grantRepo.GetConsent(ctx, principal.UserID, client.ID)
+	if err != nil {
+		return nil, err
+	}
+	if approve == nil {
+		if consent == nil || !coversScopes(consent.Scopes, scopes) || req.hasPrompt("consent") {
+			if req.hasPrompt("none") {
+				return &AuthorizationResult{RedirectURI: uc.errorRedirect(req, &domain.OAuthError{Code: domain.OAuthErrConsentRequired, Description: "the user has not consented to the requested scopes"})}, nil
+			}
+			return &AuthorizationResult{ConsentRequired: true, Client: client, Scopes: scopes}, nil
+		}
+	}
+
+	code, err := oidc.NewRandomString()
+	if err != nil {
+		return nil, err
+	}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if approve != nil {
+			if err := uc.saveConsent(ctx, principal.UserID, client, consent, scopes); err != nil {
+				return err
+			}
+		}
+		return uc.grantRepo.CreateAuthorizationCode(ctx, &domain.OAuthAuthorizationCode{
+			CodeHash:      hashOAuthSecret(code),
+			ClientID:      client.ID,
+			UserID:        principal.UserID,
+			RedirectURI:   req.RedirectURI,
+			Scopes:        scopes,
+			Nonce:         req.Nonce,
+			CodeChallenge: req.CodeChallenge,
+			ExpiresAt:     uc.clock.Now().Add(uc.settings.CodeTTL),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AuthorizationResult{RedirectURI: uc.clientRedirect(req, url.Values{"code": {code}})}, nil
+}
+
+// coversScopes reports whether granted includes every scope in requested.
+func coversScopes(granted, requested []string) bool {
+	for _, s := range requested {
+		if !containsScope(granted, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// saveConsent records that the user approved scopes for client, keeping the
+// scopes they approved before.
+func (uc *oidcProviderInteractor) saveConsent(ctx context.Context, userID string, client *domain.OAuthClient, previous *domain.OAuthConsent, scopes []string) error {
+	var granted []string
+	for _, s := range domain.OAuthScopes {
+		if containsScope(scopes, s) || (previous != nil && containsScope(previous.Scopes, s)) {
+			granted = append(granted, s)
+		}
+	}
+	if err := uc.grantRepo.SaveConsent(ctx, &domain.OAuthConsent{UserID: userID, ClientID: client.ID, Scopes: granted}); err != nil {
+		return err
+	}
+	return uc.auditor.Record(ctx, domain.AuditEvent{
+		Action:   domain.AuditActionOAuthConsentGiven,
+		ActorID:  userID,
+		TargetID: userID,
+		Metadata: map[string]string{
+			"client_id": client.ID,
+			"scopes":    strings.Join(granted, " "),
+		},
+		OccurredAt: uc.clock.Now(),
+	})
+}
+
+// authenticateClient returns the client a token request comes from.
+func (uc *oidcProviderInteractor) authenticateClient(ctx context.Context, req TokenRequest) (*domain.OAuthClient, error) {
+	invalid := &domain.OAuthError{Code: domain.OAuthErrInvalidClient, Description: "client authentication failed"}
+	if _, err := uuid.Parse(req.ClientID); err != nil {
+		return nil, invalid
+	}
+	client, err := uc.clientRepo.GetClient(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, invalid
+	}
+	if !client.IsPublic() && subtle.ConstantTimeCompare([]byte(hashOAuthSecret(req.ClientSecret)), []byte(client.SecretHash)) != 1 {
+		return nil, invalid
+	}
+	return client, nil
+}
+
+func (uc *oidcProviderInteractor) ExchangeCode(ctx context.Context, req TokenRequest) (*TokenResult, error) {
+	if req.GrantType != "authorization_code" {
+		return nil, &domain.OAuthError{Code: domain.OAuthErrUnsupportedGrantType, Description: "only the authorization_code grant is supported"}
+	}
+	client, err := uc.authenticateClient(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Code == "" {
+		return nil, &domain.OAuthError{Code: domain.OAuthErrInvalidRequest, Description: "code is required"}
+	}
+
+	// The code is used up even when the exchange fails, so it cannot be tried twice
+	invalid := &domain.OAuthError{Code: domain.OAuthErrInvalidGrant, Description: "the code is invalid or expired"}
+	now := uc.clock.Now()
+	code, redeemed, err := uc.grantRepo.ConsumeAuthorizationCode(ctx, hashOAuthSecret(req.Code), now)
+	if err != nil {
+		return nil, err
+	}
+	if code != nil && !redeemed {
+		if err := uc.revokeReplayedCode(ctx, code, client); err != nil {
+			return nil, err
+		}
+		return nil, invalid
+	}
+	if code == nil || code.ClientID != client.ID || !now.Before(code.ExpiresAt) || code.RedirectURI != req.RedirectURI {
+		return nil, invalid
+	}
+	if code.CodeChallenge != "" {
+		if subtle.ConstantTimeCompare([]byte(oidc.CodeChallenge(req.CodeVerifier)), []byte(code.CodeChallenge)) != 1 {
+			return nil, invalid
+		}
+	} else if req.CodeVerifier != "" {
+		// A verifier without a challenge hints at a downgrade attack
+		return nil, invalid
+	}
+
+	user, err := uc.userRepo.GetUserByID(ctx, code.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.ErasedAt.IsZero() {
+		return nil, invalid
+	}
+	// The user may have revoked consent since the code was issued
+	consent, err := uc.grantRepo.GetConsent(ctx, user.ID, client.ID)
+	if err != nil {
+		return nil, err
+	}
+	if consent == nil || !coversScopes(consent.Scopes, code.Scopes) {
+		return nil, invalid
+	}
+
+	idToken, err := uc.signIDToken(ctx, client, user, code, now)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := oidc.NewRandomString()
+	if err != nil {
+		return nil, err
+	}
+	accessToken := oauthAccessTokenTag + secret
+	err = uc.grantRepo.CreateAccessToken(ctx, &domain.OAuthAccessToken{
+		TokenHash: hashOAuthSecret(accessToken),
+		ClientID:  client.ID,
+		UserID:    user.ID,
+		Scopes:    code.Scopes,
+		ExpiresAt: now.Add(uc.settings.AccessTokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &TokenResult{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(uc.settings.AccessTokenTTL.Seconds()),
+		IDToken:     idToken,
+		Scopes:      code.Scopes,
+	}, nil
+}
+
+// revokeReplayedCode revokes the access tokens the code's client holds for
+// its user, as a code presented twice may have leaked (RFC 6749 §4.1.2).
+// Tokens are not tied to the code they came from, so all of them go.
+func (uc *oidcProviderInteractor) revokeReplayedCode(ctx context.Context, code *domain.OAuthAuthorizationCode, presentedBy *domain.OAuthClient) error {
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.grantRepo.DeleteAccessTokens(ctx, code.UserID, code.ClientID); err != nil {
+			return err
+		}
+		return uc.auditor.Record(ctx, domain.AuditEvent{
+			Action:   domain.AuditActionOAuthCodeReplayed,
+			TargetID: code.UserID,
+			Metadata: map[string]string{
+				"client_id":    code.ClientID,
+				"presented_by": presentedBy.ID,
+			},
+			OccurredAt: uc.clock.Now(),
+		})
+	})
+}
+
+// idTokenClaims are the claims of an ID token issued to a client.
+type idTokenClaims struct {
+	Nonce     string `json:"nonce,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Picture   string `json:"picture,omitempty"`
+	Locale    string `json:"locale,omitempty"`
+	Zoneinfo  string `json:"zoneinfo,omitempty"`
+	UpdatedAt int64  `json:"updated_at,omitempty"`
+	Email     string `json:"email,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (uc *oidcProviderInteractor) signIDToken(ctx context.Context, client *domain.OAuthClient, user *domain.User, code *domain.OAuthAuthorizationCode, now time.Time) (string, error) {
+	key, signer, err := uc.activeSigningKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	claims := userClaims(user, code.Scopes)
+	c := idTokenClaims{
+		Nonce:    code.Nonce,
+		Name:     claims.Name,
+		Picture:  claims.Picture,
+		Locale:   claims.Locale,
+		Zoneinfo: claims.Zoneinfo,
+		Email:    claims.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    uc.settings.Issuer,
+			Subject:   user.ID,
+			Audience:  jwt.ClaimStrings{client.ID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(uc.settings.IDTokenTTL)),
+		},
+	}
+	if !claims.UpdatedAt.IsZero() {
+		c.UpdatedAt = claims.UpdatedAt.Unix()
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, c)
+	token.Header["kid"] = key.ID
+	return token.SignedString(signer)
+}
+
+// userClaims returns the claims about user that scopes release.
+func userClaims(user *domain.User, scopes []string) *UserClaims {
+	claims := &UserClaims{Subject: user.ID}
+	if containsScope(scopes, domain.OAuthScopeProfile) {
+		claims.Name = user.Name
+		claims.Picture = user.AvatarURL
+		claims.Locale = user.Preferences.Locale
+		claims.Zoneinfo = user.Preferences.Timezone
+		claims.UpdatedAt = user.UpdatedAt
+	}
+	// Emails are not verified by this service, so email_verified is left out
+	if containsScope(scopes, domain.OAuthScopeEmail) {
+		claims.Email = user.Email
+	}
+	return claims
+}
+
+func (uc *oidcProviderInteractor) UserInfo(ctx context.Context, accessToken string) (*UserClaims, error) {
+	if !IsOAuthAccessToken(accessToken) {
+		return nil, domain.ErrUnauthenticated
+	}
+	token, err := uc.grantRepo.GetAccessToken(ctx, hashOAuthSecret(accessToken))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || !uc.clock.Now().Before(token.ExpiresAt) {
+		return nil, domain.ErrUnauthenticated
+	}
+	user, err := uc.userRepo.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.ErasedAt.IsZero() {
+		return nil, domain.ErrUnauthenticated
+	}
+	return userClaims(user, token.Scopes), nil
+}
+
+func (uc *oidcProviderInteractor) ListOAuthConsents(ctx context.Context) ([]domain.OAuthConsent, error) {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return uc.grantRepo.ListConsents(ctx, principal.UserID)
+}
+
+func (uc *oidcProviderInteractor) RevokeOAuthConsent(ctx context.Context, clientID string) error {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(clientID); err != nil {
+		return domain.ErrOAuthConsentNotFound
+	}
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		deleted, err := uc.grantRepo.DeleteConsent(ctx, principal.UserID, clientID)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return domain.ErrOAuthConsentNotFound
+		}
+		if err := uc.grantRepo.DeleteAccessTokens(ctx, principal.UserID, clientID); err != nil {
+			return err
+		}
+		return uc.auditor.Record(ctx, domain.AuditEvent{
+			Action:     domain.AuditActionOAuthConsentRevoked,
+			ActorID:    principal.UserID,
+			TargetID:   principal.UserID,
+			Metadata:   map[string]string{"client_id": clientID},
+			OccurredAt: uc.clock.Now(),
+		})
+	})
+}
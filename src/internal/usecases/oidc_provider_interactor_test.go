@@ -0,0 +1,523 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/encryption"
+	"apiserver/internal/oidc"
+	"apiserver/internal/repositories/mocks"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type oidcProviderTestEnv struct {
+	clientRepo *mocks.MockOAuthClientRepository
+	grantRepo  *mocks.MockOAuthGrantRepository
+	keyRepo    *mocks.MockSigningKeyRepository
+	userRepo   *mocks.MockUserRepository
+	auditor    *auditmocks.MockRecorder
+	clock      *clock.Fake
+	interactor OIDCProviderInteractor
+}
+
+func setupOIDCProviderTestEnv(t *testing.T) *oidcProviderTestEnv {
+	cipher, err := encryption.NewAESGCM(bytes.Repeat([]byte{9}, 32))
+	require.NoError(t, err)
+	env := &oidcProviderTestEnv{
+		clientRepo: new(mocks.MockOAuthClientRepository),
+		grantRepo:  new(mocks.MockOAuthGrantRepository),
+		keyRepo:    new(mocks.MockSigningKeyRepository),
+		userRepo:   new(mocks.MockUserRepository),
+		auditor:    new(auditmocks.MockRecorder),
+		clock:      clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	settings := DefaultOIDCProviderSettings()
+	settings.Issuer = "https://id.example.com/"
+	settings.ConsentURL = "https://app.example.com/oauth/consent"
+	env.interactor = NewOIDCProviderInteractor(env.clientRepo, env.grantRepo, env.keyRepo, env.userRepo, cipher, new(mocks.InlineTxManager), env.auditor, settings, env.clock)
+	return env
+}
+
+// withSigningKey creates the first key and has the repository list it from
+// then on.
+func (env *oidcProviderTestEnv) withSigningKey(t *testing.T) *domain.SigningKey {
+	t.Helper()
+	env.keyRepo.On("ListSigningKeys", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	key := env.expectCreateSigningKey(env.clock.Now())
+	require.NoError(t, env.interactor.EnsureSigningKey(context.Background()))
+	env.keyRepo.On("ListSigningKeys", mock.Anything, mock.Anything).Return([]domain.SigningKey{*key}, nil)
+	return key
+}
+
+// expectCreateSigningKey expects a key to be stored that retires the others
+// at activateAt. The returned key is filled in once it is stored.
+func (env *oidcProviderTestEnv) expectCreateSigningKey(activateAt time.Time) *domain.SigningKey {
+	key := new(domain.SigningKey)
+	env.keyRepo.On("CreateSigningKey", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*key = *args.Get(1).(*domain.SigningKey)
+	}).Return(nil).Once()
+	env.keyRepo.On("RetireSigningKeys", mock.Anything, mock.Anything, activateAt).Return(nil).Once()
+	env.keyRepo.On("DeleteSigningKeys", mock.Anything, env.clock.Now().Add(-7*24*time.Hour)).Return(0, nil).Once()
+	return key
+}
+
+var (
+	wikiClient = &domain.OAuthClient{
+		ID:           testClientID,
+		Name:         "Wiki",
+		RedirectURIs: []string{"https://wiki.example.com/callback"},
+		SecretHash:   hashOAuthSecret("ucs_wiki-secret"),
+	}
+	testVerifier = "dBjftJeZ4CVP-mJ92K9mqHb6ho7RJuL3s-r2eUb5r1g"
+)
+
+func wikiRequest() AuthorizationRequest {
+	return AuthorizationRequest{
+		ResponseType:        "code",
+		ClientID:            testClientID,
+		RedirectURI:         "https://wiki.example.com/callback",
+		Scope:               "openid email profile offline_access",
+		State:               "st-1",
+		Nonce:               "n-1",
+		CodeChallenge:       oidc.CodeChallenge(testVerifier),
+		CodeChallengeMethod: "S256",
+	}
+}
+
+func TestOIDCProviderInteractor_Discovery(t *testing.T) {
+	env := setupOIDCProviderTestEnv(t)
+
+	meta := env.interactor.Discovery()
+
+	assert.Equal(t, "https://id.example.com", meta.Issuer)
+	assert.Equal(t, "https://id.example.com/v1/oauth/token", meta.TokenEndpoint)
+	assert.Equal(t, "https://id.example.com/.well-known/jwks.json", meta.JWKSURI)
+	assert.Equal(t, []string{"RS256"}, meta.IDTokenSigningAlgValuesSupported)
+}
+
+func TestOIDCProviderInteractor_BeginAuthorization(t *testing.T) {
+	env := setupOIDCProviderTestEnv(t)
+	env.clientRepo.On("GetClient", mock.Anything, testClientID).Return(wikiClient, nil)
+
+	next, err := env.interactor.BeginAuthorization(context.Background(), wikiRequest())
+
+	require.NoError(t, err)
+	u, err := url.Parse(next)
+	require.NoError(t, err)
+	assert.Equal(t, "app.example.com", u.Host)
+	assert.Equal(t, "/oauth/consent", u.Path)
+	assert.Equal(t, testClientID, u.Query().Get("client_id"))
+	assert.Equal(t, "st-1", u.Query().Get("state"))
+}
+
+func TestOIDCProviderInteractor_BeginAuthorization_Errors(t *testing.T) {
+	env := setupOIDCProviderTestEnv(t)
+	public := &domain.OAuthClient{ID: "8c1b2a3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", RedirectURIs: []string{"http://127.0.0.1:7777/cb"}}
+	env.clientRepo.On("GetClient", mock.Anything, testClientID).Return(wikiClient, nil)
+	env.clientRepo.On("GetClient", mock.Anything, public.ID).Return(public, nil)
+	env.clientRepo.On("GetClient", mock.Anything, "00000000-0000-4000-8000-000000000000").Return(nil, nil)
+
+	t.Run("not redirectable", func(t *testing.T) {
+		for name, mutate := range map[string]func(r *AuthorizationRequest){
+			"unknown client":           func(r *AuthorizationRequest) { r.ClientID = "00000000-0000-4000-8000-000000000000" },
+			"malformed client":         func(r *AuthorizationRequest) { r.ClientID = "wiki" },
+			"unregistered redirect":    func(r *AuthorizationRequest) { r.RedirectURI = "https://evil.example.com/callback" },
+			"redirect with extra path": func(r *AuthorizationRequest) { r.RedirectURI += "/x" },
+		} {
+			req := wikiRequest()
+			mutate(&req)
+			_, err := env.interactor.BeginAuthorization(context.Background(), req)
+			assert.ErrorIs(t, err, domain.ErrInvalidArgument, name)
+		}
+	})
+
+	t.Run("redirected", func(t *testing.T) {
+		tests := map[string]struct {
+			mutate  func(r *AuthorizationRequest)
+			wantErr string
+		}{
+			"token response type": {func(r *AuthorizationRequest) { r.ResponseType = "token" }, domain.OAuthErrUnsupportedResponseType},
+			"no openid scope":     {func(r *AuthorizationRequest) { r.Scope = "email" }, domain.OAuthErrInvalidScope},
+			"plain pkce":          {func(r *AuthorizationRequest) { r.CodeChallengeMethod = "plain" }, domain.OAuthErrInvalidRequest},
+			"public without pkce": {func(r *AuthorizationRequest) {
+				r.ClientID, r.RedirectURI, r.CodeChallenge, r.CodeChallengeMethod = public.ID, public.RedirectURIs[0], "", ""
+			}, domain.OAuthErrInvalidRequest},
+		}
+		for name, tt := range tests {
+			req := wikiRequest()
+			tt.mutate(&req)
+			next, err := env.interactor.BeginAuthorization(context.Background(), req)
+			require.NoError(t, err, name)
+			u, err := url.Parse(next)
+			require.NoError(t, err, name)
+			assert.Equal(t, req.RedirectURI, u.Scheme+"://"+u.Host+u.Path, name)
+			assert.Equal(t, tt.wantErr, u.Query().Get("error"), name)
+			assert.Equal(t, "st-1", u.Query().Get("state"), name)
+			assert.Equal(t, "https://id.example.com", u.Query().Get("iss"), name)
+		}
+	})
+}
+
+func TestOIDCProviderInteractor_Authorize_ConsentRequired(t *testing.T) {
+	env := setupOIDCProviderTestEnv(t)
+	ctx := sessionContext("user-1", domain.RoleUser, false)
+	env.clientRepo.On("GetClient", mock.Anything, testClientID).Return(wikiClient, nil)
+	// Consent to fewer scopes than requested does not cover the request
+	env.grantRepo.On("GetConsent", mock.Anything, "user-1", testClientID).Return(&domain.OAuthConsent{Scopes: []string{"openid"}}, nil)
+
+	res, err := env.interactor.Authorize(ctx, wikiRequest(), nil)
+
+	require.NoError(t, err)
+	assert.True(t, res.ConsentRequired)
+	assert.Empty(t, res.RedirectURI)
+	assert.Equal(t, "Wiki", res.Client.Name)
+	assert.Equal(t, []string{"openid", "profile", "email"}, res.Scopes)
+
+	req := wikiRequest()
+	req.Prompt = "none"
+	res, err = env.interactor.Authorize(ctx, req, nil)
+
+	require.NoError(t, err)
+	u, err := url.Parse(res.RedirectURI)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OAuthErrConsentRequired, u.Query().Get("error"))
+	env.grantRepo.AssertNotCalled(t, "CreateAuthorizationCode", mock.Anything, mock.Anything)
+}
+
+func TestOIDCProviderInteractor_Authorize_Denied(t *testing.T) {
+	env := setupOIDCProviderTestEnv(t)
+	ctx := sessionContext("user-1", domain.RoleUser, false)
+	env.clientRepo.On("GetClient", mock.Anything, testClientID).Return(wikiClient, nil)
+	deny := false
+
+	res, err := env.interactor.Authorize(ctx, wikiRequest(), &deny)
+
+	require.NoError(t, err)
+	u, err := url.Parse(res.RedirectURI)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OAuthErrAccessDenied, u.Query().Get("error"))
+	env.grantRepo.AssertNotCalled(t, "SaveConsent", mock.Anything, mock.Anything)
+}
+
+func TestOIDCProviderInteractor_Authorize_RequiresSession(t *testing.T) {
+	env := setupOIDCProviderTestEnv(t)
+
+	_, err := env.interactor.Authorize(context.Background(), wikiRequest(), nil)
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+}
+
+// authorize approves wikiRequest as user-1 and returns the code issued
+// along with what was stored for it.
+func (env *oidcProviderTestEnv) authorize(t *testing.T) (string, *domain.OAuthAuthorizationCode) {
+	t.Helper()
+	env.clientRepo.On("GetClient", mock.Anything, testClientID).Return(wikiClient, nil)
+	env.grantRepo.On("GetConsent", mock.Anything, "user-1", testClientID).Return(nil, nil).Once()
+	env.grantRepo.On("SaveConsent", mock.Anything, mock.MatchedBy(func(c *domain.OAuthConsent) bool {
+		return c.UserID == "user-1" && c.ClientID == testClientID
+	})).Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionOAuthConsentGiven && e.Metadata["scopes"] == "openid profile email"
+	})).Return(nil).Once()
+	var stored *domain.OAuthAuthorizationCode
+	env.grantRepo.On("CreateAuthorizationCode", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.OAuthAuthorizationCode)
+	}).Return(nil).Once()
+
+	approve := true
+	res, err := env.interactor.Authorize(sessionContext("user-1", domain.RoleUser, false), wikiRequest(), &approve)
+	require.NoError(t, err)
+	u, err := url.Parse(res.RedirectURI)
+	require.NoError(t, err)
+	require.Equal(t, "st-1", u.Query().Get("state"))
+	code := u.Query().Get("code")
+	require.NotEmpty(t, code)
+	require.Equal(t, hashOAuthSecret(code), stored.CodeHash)
+	return code, stored
+}
+
+func TestOIDCProviderInteractor_AuthorizationCodeFlow(t *testing.T) {
+	env := setupOIDCProviderTestEnv(t)
+	env.withSigningKey(t)
+	code, stored := env.authorize(t)
+	assert.Equal(t, []string{"openid", "profile", "email"}, stored.Scopes)
+	assert.Equal(t, env.clock.Now().Add(time.Minute), stored.ExpiresAt)
+
+	user := &domain.User{
+		ID:          "user-1",
+		Name:        "Alice",
+		Email:       "alice@example.com",
+		AvatarURL:   "https://cdn.example.com/alice.png",
+		UpdatedAt:   time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+		Preferences: domain.UserPreferences{Locale: "ja-JP", Timezone: "Asia/Tokyo"},
+	}
+	env.grantRepo.On("ConsumeAuthorizationCode", mock.Anything, stored.CodeHash, env.clock.Now()).Return(stored, true, nil).Once()
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(user, nil)
+	env.grantRepo.On("GetConsent", mock.Anything, "user-1", testClientID).Return(&domain.OAuthConsent{Scopes: stored.Scopes}, nil).Once()
+	var token *domain.OAuthAccessToken
+	env.grantRepo.On("CreateAccessToken", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		token = args.Get(1).(*domain.OAuthAccessToken)
+	}).Return(nil).Once()
+
+	res, err := env.interactor.ExchangeCode(context.Background(), TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         code,
+		RedirectURI:  "https://wiki.example.com/callback",
+		CodeVerifier: testVerifier,
+		ClientID:     testClientID,
+		ClientSecret: "ucs_wiki-secret",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", res.TokenType)
+	assert.Equal(t, 3600, res.ExpiresIn)
+	assert.True(t, IsOAuthAccessToken(res.AccessToken))
+	assert.Equal(t, hashOAuthSecret(res.AccessToken), token.TokenHash)
+
+	// The ID token verifies against the published keys
+	jwks, err := env.interactor.PublicKeys(context.Background())
+	require.NoError(t, err)
+	require.Len(t, jwks, 1)
+	var claims idTokenClaims
+	_, err = jwt.ParseWithClaims(res.IDToken, &claims, func(tok *jwt.Token) (any, error) {
+		key, err := x509.ParsePKIXPublicKey(env.mustListKeys(t)[0].PublicKey)
+		require.Equal(t, jwks[0].Kid, tok.Header["kid"])
+		return key.(*rsa.PublicKey), err
+	}, jwt.WithValidMethods([]string{"RS256"}), jwt.WithTimeFunc(env.clock.Now), jwt.WithIssuer("https://id.example.com"), jwt.WithAudience(testClientID))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, "n-1", claims.Nonce)
+	assert.Equal(t, "alice@example.com", claims.Email)
+	assert.Equal(t, "Alice", claims.Name)
+
+	env.grantRepo.On("GetAccessToken", mock.Anything, token.TokenHash).Return(token, nil).Once()
+	info, err := env.interactor.UserInfo(context.Background(), res.AccessToken)
+
+	require.NoError(t, err)
+	assert.Equal(t, &UserClaims{
+		Subject:   "user-1",
+		Name:      "Alice",
+		Picture:   "https://cdn.example.com/alice.png",
+		Locale:    "ja-JP",
+		Zoneinfo:  "Asia/Tokyo",
+		UpdatedAt: user.UpdatedAt,
+		Email:     "alice@example.com",
+	}, info)
+}
+
+func (env *oidcProviderTestEnv) mustListKeys(t *testing.T) []domain.SigningKey {
+	keys, err := env.keyRepo.ListSigningKeys(context.Background(), env.clock.Now())
+	require.NoError(t, err)
+	return keys
+}
+
+func TestOIDCProviderInteractor_ExchangeCode_Rejected(t *testing.T) {
+	valid := TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         "the-code",
+		RedirectURI:  "https://wiki.example.com/callback",
+		CodeVerifier: testVerifier,
+		ClientID:     testClientID,
+		ClientSecret: "ucs_wiki-secret",
+	}
+	code := func() *domain.OAuthAuthorizationCode {
+		return &domain.OAuthAuthorizationCode{
+			CodeHash:      hashOAuthSecret("the-code"),
+			ClientID:      testClientID,
+			UserID:        "user-1",
+			RedirectURI:   "https://wiki.example.com/callback",
+			Scopes:        []string{"openid"},
+			CodeChallenge: oidc.CodeChallenge(testVerifier),
+			ExpiresAt:     time.Date(2026, 1, 1, 0, 1, 0, 0, time.UTC),
+		}
+	}
+
+	tests := map[string]struct {
+		mutate   func(r *TokenRequest, c *domain.OAuthAuthorizationCode)
+		consent  *domain.OAuthConsent
+		wantCode string
+	}{
+		"wrong grant type": {func(r *TokenRequest, _ *domain.OAuthAuthorizationCode) { r.GrantType = "password" }, nil, domain.OAuthErrUnsupportedGrantType},
+		"wrong secret":     {func(r *TokenRequest, _ *domain.OAuthAuthorizationCode) { r.ClientSecret = "ucs_guess" }, nil, domain.OAuthErrInvalidClient},
+		"unknown client":   {func(r *TokenRequest, _ *domain.OAuthAuthorizationCode) { r.ClientID = "wiki" }, nil, domain.OAuthErrInvalidClient},
+		"missing code":     {func(r *TokenRequest, _ *domain.OAuthAuthorizationCode) { r.Code = "" }, nil, domain.OAuthErrInvalidRequest},
+		"expired": {func(_ *TokenRequest, c *domain.OAuthAuthorizationCode) {
+			c.ExpiresAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		}, nil, domain.OAuthErrInvalidGrant},
+		"other client's code": {func(_ *TokenRequest, c *domain.OAuthAuthorizationCode) {
+			c.ClientID = "8c1b2a3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+		}, nil, domain.OAuthErrInvalidGrant},
+		"redirect uri mismatch": {func(r *TokenRequest, _ *domain.OAuthAuthorizationCode) {
+			r.RedirectURI = "https://wiki.example.com/other"
+		}, nil, domain.OAuthErrInvalidGrant},
+		"wrong verifier":          {func(r *TokenRequest, _ *domain.OAuthAuthorizationCode) { r.CodeVerifier = "x" + testVerifier[1:] }, nil, domain.OAuthErrInvalidGrant},
+		"verifier without pkce":   {func(_ *TokenRequest, c *domain.OAuthAuthorizationCode) { c.CodeChallenge = "" }, nil, domain.OAuthErrInvalidGrant},
+		"consent revoked":         {func(*TokenRequest, *domain.OAuthAuthorizationCode) {}, nil, domain.OAuthErrInvalidGrant},
+		"consent lacks the scope": {func(*TokenRequest, *domain.OAuthAuthorizationCode) {}, &domain.OAuthConsent{Scopes: []string{"email"}}, domain.OAuthErrInvalidGrant},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := setupOIDCProviderTestEnv(t)
+			req, c := valid, code()
+			tt.mutate(&req, c)
+			env.clientRepo.On("GetClient", mock.Anything, testClientID).Return(wikiClient, nil)
+			env.grantRepo.On("ConsumeAuthorizationCode", mock.Anything, hashOAuthSecret("the-code"), env.clock.Now()).Return(c, true, nil)
+			env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1"}, nil)
+			env.grantRepo.On("GetConsent", mock.Anything, "user-1", testClientID).Return(tt.consent, nil)
+
+			_, err := env.interactor.ExchangeCode(context.Background(), req)
+
+			var oauthErr *domain.OAuthError
+			require.True(t, errors.As(err, &oauthErr), "got %v", err)
+			assert.Equal(t, tt.wantCode, oauthErr.Code)
+			env.grantRepo.AssertNotCalled(t, "CreateAccessToken", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOIDCProviderInteractor_ExchangeCode_ReplayRevokesTokens(t *testing.T) {
+	env := setupOIDCProviderTestEnv(t)
+	used := &domain.OAuthAuthorizationCode{
+		CodeHash:    hashOAuthSecret("the-code"),
+		ClientID:    testClientID,
+		UserID:      "user-1",
+		RedirectURI: "https://wiki.example.com/callback",
+		Scopes:      []string{"openid"},
+		UsedAt:      env.clock.Now().Add(-10 * time.Second),
+		ExpiresAt:   env.clock.Now().Add(time.Minute),
+	}
+	env.clientRepo.On("GetClient", mock.Anything, testClientID).Return(wikiClient, nil)
+	env.grantRepo.On("ConsumeAuthorizationCode", mock.Anything, used.CodeHash, env.clock.Now()).Return(used, false, nil).Once()
+	env.grantRepo.On("DeleteAccessTokens", mock.Anything, "user-1", testClientID).Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionOAuthCodeReplayed && e.TargetID == "user-1" && e.Metadata["client_id"] == testClientID
+	})).Return(nil).Once()
+
+	_, err := env.interactor.ExchangeCode(context.Background(), TokenRequest{
+		GrantType:    "authorization_code",
+		Code:         "the-code",
+		RedirectURI:  "https://wiki.example.com/callback",
+		ClientID:     testClientID,
+		ClientSecret: "ucs_wiki-secret",
+	})
+
+	var oauthErr *domain.OAuthError
+	require.True(t, errors.As(err, &oauthErr), "got %v", err)
+	assert.Equal(t, domain.OAuthErrInvalidGrant, oauthErr.Code)
+	env.grantRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+	env.grantRepo.AssertNotCalled(t, "CreateAccessToken", mock.Anything, mock.Anything)
+}
+
+func TestOIDCProviderInteractor_UserInfo_Rejected(t *testing.T) {
+	env := setupOIDCProviderTestEnv(t)
+	expired := &domain.OAuthAccessToken{UserID: "user-1", Scopes: []string{"openid"}, ExpiresAt: env.clock.Now()}
+	env.grantRepo.On("GetAccessToken", mock.Anything, hashOAuthSecret("uoa_expired")).Return(expired, nil)
+	env.grantRepo.On("GetAccessToken", mock.Anything, hashOAuthSecret("uoa_unknown")).Return(nil, nil)
+
+	for _, token := range []string{"uoa_expired", "uoa_unknown", "uak_12345678_abc"} {
+		_, err := env.interactor.UserInfo(context.Background(), token)
+		assert.ErrorIs(t, err, domain.ErrUnauthenticated, token)
+	}
+}
+
+func TestOIDCProviderInteractor_UserInfo_OnlyGrantedClaims(t *testing.T) {
+	env := setupOIDCProviderTestEnv(t)
+	token := &domain.OAuthAccessToken{UserID: "user-1", Scopes: []string{"openid", "email"}, ExpiresAt: env.clock.Now().Add(time.Hour)}
+	env.grantRepo.On("GetAccessToken", mock.Anything, hashOAuthSecret("uoa_token")).Return(token, nil)
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", Name: "Alice", Email: "alice@example.com"}, nil)
+
+	info, err := env.interactor.UserInfo(context.Background(), "uoa_token")
+
+	require.NoError(t, err)
+	assert.Equal(t, &UserClaims{Subject: "user-1", Email: "alice@example.com"}, info)
+}
+
+func TestOIDCProviderInteractor_RevokeOAuthConsent(t *testing.T) {
+	env := setupOIDCProviderTestEnv(t)
+	ctx := sessionContext("user-1", domain.RoleUser, false)
+	env.grantRepo.On("DeleteConsent", mock.Anything, "user-1", testClientID).Return(true, nil).Once()
+	env.grantRepo.On("DeleteAccessTokens", mock.Anything, "user-1", testClientID).Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionOAuthConsentRevoked && e.Metadata["client_id"] == testClientID
+	})).Return(nil).Once()
+
+	err := env.interactor.RevokeOAuthConsent(ctx, testClientID)
+
+	assert.NoError(t, err)
+	env.grantRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+
+	env.grantRepo.On("DeleteConsent", mock.Anything, "user-1", testClientID).Return(false, nil).Once()
+	assert.ErrorIs(t, env.interactor.RevokeOAuthConsent(ctx, testClientID), domain.ErrOAuthConsentNotFound)
+}
+
+func TestOIDCProviderInteractor_EnsureSigningKey(t *testing.T) {
+	// Nothing can sign yet, so the first key is activated right away
+	env := setupOIDCProviderTestEnv(t)
+	env.keyRepo.On("ListSigningKeys", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	key := env.expectCreateSigningKey(env.clock.Now())
+
+	require.NoError(t, env.interactor.EnsureSigningKey(context.Background()))
+	env.keyRepo.AssertExpectations(t)
+	assert.Equal(t, env.clock.Now(), key.ActivatedAt)
+
+	// A usable key is kept
+	env = setupOIDCProviderTestEnv(t)
+	env.withSigningKey(t)
+	require.NoError(t, env.interactor.EnsureSigningKey(context.Background()))
+	env.keyRepo.AssertNumberOfCalls(t, "CreateSigningKey", 1)
+
+	// So is a key this instance cannot decrypt, which is replaced
+	env = setupOIDCProviderTestEnv(t)
+	env.keyRepo.On("ListSigningKeys", mock.Anything, mock.Anything).Return([]domain.SigningKey{{ID: "old", PrivateKeyCiphertext: []byte("garbage")}}, nil).Once()
+	env.expectCreateSigningKey(env.clock.Now())
+
+	require.NoError(t, env.interactor.EnsureSigningKey(context.Background()))
+	env.keyRepo.AssertExpectations(t)
+}
+
+func TestOIDCProviderInteractor_RotateSigningKey_PublishesAhead(t *testing.T) {
+	env := setupOIDCProviderTestEnv(t)
+	env.keyRepo.On("ListSigningKeys", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	current := env.expectCreateSigningKey(env.clock.Now())
+	require.NoError(t, env.interactor.EnsureSigningKey(context.Background()))
+
+	// The current key keeps signing until clients caching the key set have
+	// had time to fetch the next one
+	activateAt := env.clock.Now().Add(oidc.JWKSMaxAge)
+	next := env.expectCreateSigningKey(activateAt)
+	_, err := env.interactor.RotateSigningKey(context.Background())
+	require.NoError(t, err)
+	env.keyRepo.AssertExpectations(t)
+	assert.Equal(t, activateAt, next.ActivatedAt)
+
+	retiring := *current
+	retiring.RetiredAt = activateAt
+	env.keyRepo.On("ListSigningKeys", mock.Anything, mock.Anything).Return([]domain.SigningKey{*next, retiring}, nil)
+	jwks, err := env.interactor.PublicKeys(context.Background())
+	require.NoError(t, err)
+	require.Len(t, jwks, 2)
+	assert.Equal(t, next.ID, jwks[0].Kid)
+
+	uc := env.interactor.(*oidcProviderInteractor)
+	signing, _, err := uc.activeSigningKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, current.ID, signing.ID)
+
+	env.clock.Advance(oidc.JWKSMaxAge)
+	signing, _, err = uc.activeSigningKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, next.ID, signing.ID)
+}
@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/oidc"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signingKeyBits is the size of the RSA keys that sign ID tokens.
+const signingKeyBits = 2048
+
+// errNoSigningKey is returned when there is no active key this instance can
+// sign with.
+var errNoSigningKey = errors.New("no usable signing key")
+
+func (uc *oidcProviderInteractor) PublicKeys(ctx context.Context) ([]oidc.JWK, error) {
+	keys, err := uc.keyRepo.ListSigningKeys(ctx, uc.clock.Now().Add(-uc.settings.KeyGracePeriod))
+	if err != nil {
+		return nil, err
+	}
+	jwks := make([]oidc.JWK, 0, len(keys))
+	for _, k := range keys {
+		pub, err := x509.ParsePKIXPublicKey(k.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %s: %w", k.ID, err)
+		}
+		jwk, err := oidc.NewJWK(k.ID, jwt.SigningMethodRS256.Alg(), pub)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %s: %w", k.ID, err)
+		}
+		jwks = append(jwks, jwk)
+	}
+	return jwks, nil
+}
+
+// activeSigningKey returns the newest key that is activated and not retired,
+// with its decrypted private key.
+func (uc *oidcProviderInteractor) activeSigningKey(ctx context.Context) (*domain.SigningKey, *rsa.PrivateKey, error) {
+	now := uc.clock.Now()
+	keys, err := uc.keyRepo.ListSigningKeys(ctx, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range keys {
+		if keys[i].ActivatedAt.After(now) {
+			continue // Published, but clients may not have fetched it yet
+		}
+		plaintext, err := uc.keyCipher.Decrypt(keys[i].PrivateKeyCiphertext)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: decrypting key %s: %v", errNoSigningKey, keys[i].ID, err)
+		}
+		parsed, err := x509.ParsePKCS8PrivateKey(plaintext)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: key %s: %v", errNoSigningKey, keys[i].ID, err)
+		}
+		priv, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: key %s is not an RSA key", errNoSigningKey, keys[i].ID)
+		}
+		return &keys[i], priv, nil
+	}
+	return nil, nil, errNoSigningKey
+}
+
+func (uc *oidcProviderInteractor) RotateSigningKey(ctx context.Context) (int, error) {
+	return uc.createSigningKey(ctx, uc.clock.Now().Add(uc.settings.KeyPublishAhead))
+}
+
+func (uc *oidcProviderInteractor) EnsureSigningKey(ctx context.Context) error {
+	_, _, err := uc.activeSigningKey(ctx)
+	if !errors.Is(err, errNoSigningKey) {
+		return err
+	}
+	// Nothing can sign, so the new key cannot wait for clients to fetch it
+	_, err = uc.createSigningKey(ctx, uc.clock.Now())
+	return err
+}
+
+// createSigningKey stores a new key activated at activateAt, when the other
+// keys retire, and deletes keys retired before the grace period. It returns
+// how many it deleted.
+func (uc *oidcProviderInteractor) createSigningKey(ctx context.Context, activateAt time.Time) (int, error) {
+	priv, err := rsa.GenerateKey(rand.Reader, signingKeyBits)
+	if err != nil {
+		return 0, err
+	}
+	pub, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return 0, err
+	}
+	ciphertext, err := uc.keyCipher.Encrypt(der)
+	if err != nil {
+		return 0, err
+	}
+	kid, err := oidc.NewRandomString()
+	if err != nil {
+		return 0, err
+	}
+
+	now := uc.clock.Now()
+	key := &domain.SigningKey{ID: kid, PublicKey: pub, PrivateKeyCiphertext: ciphertext, CreatedAt: now, ActivatedAt: activateAt}
+	var deleted int
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.keyRepo.CreateSigningKey(ctx, key); err != nil {
+			return err
+		}
+		// The current key signs until the new one takes over. Retired keys
+		// stay published for the grace period, so tokens they signed keep
+		// verifying until they expire.
+		if err := uc.keyRepo.RetireSigningKeys(ctx, key.ID, activateAt); err != nil {
+			return err
+		}
+		var err error
+		deleted, err = uc.keyRepo.DeleteSigningKeys(ctx, now.Add(-uc.settings.KeyGracePeriod))
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
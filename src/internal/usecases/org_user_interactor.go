@@ -0,0 +1,238 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+	"apiserver/internal/tenant"
+)
+
+// OrgUserInteractor defines the interface for managing the users of the
+// organization selected for the request. Members may read; organization
+// admins and owners may write, and only owners may grant or revoke ownership.
+type OrgUserInteractor interface {
+	ListOrgUsers(ctx context.Context) ([]domain.OrganizationMember, error)
+	// CreateOrgUser creates a user and adds it to the organization with role,
+	// or as a member when role is empty.
+	CreateOrgUser(ctx context.Context, name, email, plainPassword, role string) (*domain.OrganizationMember, error)
+	// UpdateOrgUser updates the profile and role of a member. Users outside
+	// the organization are reported as not found. Email and password are
+	// shared by every organization of the user, so they may only be changed
+	// for members of this organization alone who are not administrators.
+	UpdateOrgUser(ctx context.Context, id string, name, email, plainPassword, role *string) (*domain.OrganizationMember, error)
+	// RemoveOrgUser removes a user from the organization. The user itself,
+	// and its memberships of other organizations, remain.
+	RemoveOrgUser(ctx context.Context, id string) error
+}
+
+// orgUserInteractor implements OrgUserInteractor.
+type orgUserInteractor struct {
+	users    UserInteractor
+	orgUsers repositories.OrgUserRepository
+	tx       repositories.TxManager
+	auditor  audit.Recorder
+	clock    clock.Clock
+}
+
+// NewOrgUserInteractor creates a new instance of OrgUserInteractor. Profiles
+// are changed through users, so they are validated and audited as usual.
+func NewOrgUserInteractor(users UserInteractor, orgUsers repositories.OrgUserRepository, tx repositories.TxManager, auditor audit.Recorder, clk clock.Clock) OrgUserInteractor {
+	return &orgUserInteractor{users: users, orgUsers: orgUsers, tx: tx, auditor: auditor, clock: clk}
+}
+
+func (uc *orgUserInteractor) ListOrgUsers(ctx context.Context) ([]domain.OrganizationMember, error) {
+	if err := checkScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+	if _, err := requireOrgRole(ctx, domain.OrgRoleMember); err != nil {
+		return nil, err
+	}
+	return uc.orgUsers.ListOrgUsers(ctx)
+}
+
+func (uc *orgUserInteractor) CreateOrgUser(ctx context.Context, name, email, plainPassword, role string) (*domain.OrganizationMember, error) {
+	if role == "" {
+		role = domain.OrgRoleMember
+	}
+	t, err := uc.authorizeWrite(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	var member *domain.OrganizationMember
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		user, err := uc.users.CreateNewUser(ctx, name, email, plainPassword)
+		if err != nil {
+			return err
+		}
+		if err := uc.orgUsers.AddOrgUser(ctx, user.ID, role); err != nil {
+			return err
+		}
+		if member, err = uc.orgUsers.GetOrgUser(ctx, user.ID); err != nil {
+			return err
+		}
+		return uc.recordMemberChange(ctx, t, domain.AuditActionOrgMemberAdded, user.ID, []domain.FieldChange{{Field: "role", New: role}})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (uc *orgUserInteractor) UpdateOrgUser(ctx context.Context, id string, name, email, plainPassword, role *string) (*domain.OrganizationMember, error) {
+	newRole := domain.OrgRoleMember
+	if role != nil {
+		newRole = *role
+	}
+	t, err := uc.authorizeWrite(ctx, newRole)
+	if err != nil {
+		return nil, err
+	}
+	if name == nil && email == nil && plainPassword == nil && role == nil {
+		return nil, fmt.Errorf("%w: no update data provided", domain.ErrInvalidArgument)
+	}
+
+	var member *domain.OrganizationMember
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := uc.orgUsers.GetOrgUser(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return domain.ErrNotFound
+		}
+		// Only owners may change the credentials of another owner
+		if before.Role == domain.OrgRoleOwner && t.Role != domain.OrgRoleOwner {
+			return domain.ErrForbidden
+		}
+		if email != nil || plainPassword != nil {
+			if err := uc.checkCredentialChange(ctx, before); err != nil {
+				return err
+			}
+		}
+		if name != nil || email != nil || plainPassword != nil {
+			if _, err := uc.users.UpdateExistingUser(withUserWriteGrant(ctx, id), id, name, email, plainPassword); err != nil {
+				return err
+			}
+		}
+		if role != nil && *role != before.Role {
+			if err := uc.checkOwnershipChange(ctx, t, before.Role); err != nil {
+				return err
+			}
+			if err := uc.orgUsers.UpdateOrgUserRole(ctx, id, *role); err != nil {
+				return err
+			}
+			change := []domain.FieldChange{{Field: "role", Old: before.Role, New: *role}}
+			if err := uc.recordMemberChange(ctx, t, domain.AuditActionOrgMemberUpdated, id, change); err != nil {
+				return err
+			}
+		}
+		member, err = uc.orgUsers.GetOrgUser(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (uc *orgUserInteractor) RemoveOrgUser(ctx context.Context, id string) error {
+	t, err := uc.authorizeWrite(ctx, domain.OrgRoleMember)
+	if err != nil {
+		return err
+	}
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := uc.orgUsers.GetOrgUser(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return domain.ErrNotFound
+		}
+		if err := uc.checkOwnershipChange(ctx, t, before.Role); err != nil {
+			return err
+		}
+		if err := uc.orgUsers.RemoveOrgUser(ctx, id); err != nil {
+			return err
+		}
+		return uc.recordMemberChange(ctx, t, domain.AuditActionOrgMemberRemoved, id, []domain.FieldChange{{Field: "role", Old: before.Role}})
+	})
+}
+
+// authorizeWrite checks that the caller may manage the organization's users
+// and grant role.
+func (uc *orgUserInteractor) authorizeWrite(ctx context.Context, role string) (*tenant.Tenant, error) {
+	if err := checkScope(ctx, domain.ScopeUsersWrite); err != nil {
+		return nil, err
+	}
+	if _, ok := orgRoleRank[role]; !ok {
+		return nil, fmt.Errorf("%w: role must be one of owner, admin or member", domain.ErrInvalidArgument)
+	}
+	t, err := requireOrgRole(ctx, domain.OrgRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if role == domain.OrgRoleOwner && t.Role != domain.OrgRoleOwner {
+		return nil, domain.ErrForbidden
+	}
+	return t, nil
+}
+
+// checkCredentialChange refuses to change the sign-in credentials of a member
+// who also belongs to another organization, or who administers the service.
+func (uc *orgUserInteractor) checkCredentialChange(ctx context.Context, member *domain.OrganizationMember) error {
+	if member.User.Role == domain.RoleAdmin {
+		return domain.ErrForbidden
+	}
+	orgs, err := uc.orgUsers.CountUserOrgs(ctx, member.User.ID)
+	if err != nil {
+		return err
+	}
+	if orgs > 1 {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// checkOwnershipChange refuses to take the owner role away from a member
+// unless the caller is an owner and another owner remains.
+func (uc *orgUserInteractor) checkOwnershipChange(ctx context.Context, t *tenant.Tenant, oldRole string) error {
+	if oldRole != domain.OrgRoleOwner {
+		return nil
+	}
+	if t.Role != domain.OrgRoleOwner {
+		return domain.ErrForbidden
+	}
+	owners, err := uc.orgUsers.CountOrgOwners(ctx)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return fmt.Errorf("%w: an organization needs at least one owner", domain.ErrConflict)
+	}
+	return nil
+}
+
+// recordMemberChange appends an audit event for a membership change in the
+// tenant's organization. It must run inside the change's transaction.
+func (uc *orgUserInteractor) recordMemberChange(ctx context.Context, t *tenant.Tenant, action, targetID string, changes []domain.FieldChange) error {
+	event := domain.AuditEvent{
+		Action:     action,
+		TargetID:   targetID,
+		Changes:    changes,
+		Metadata:   map[string]string{"organization_id": t.OrganizationID},
+		OccurredAt: uc.clock.Now(),
+	}
+	if p := auth.PrincipalFrom(ctx); p != nil {
+		event.ActorID = p.UserID
+		if p.IsAPIKey() {
+			event.Metadata["api_key_id"] = p.APIKeyID
+		}
+	}
+	return uc.auditor.Record(ctx, event)
+}
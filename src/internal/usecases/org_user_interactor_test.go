@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"apiserver/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type orgUserTestEnv struct {
+	userRepo   *mocks.MockUserRepository
+	orgUsers   *mocks.MockOrgUserRepository
+	auditor    *auditmocks.MockRecorder
+	interactor OrgUserInteractor
+}
+
+func setupOrgUserTestEnv() *orgUserTestEnv {
+	env := &orgUserTestEnv{
+		userRepo: new(mocks.MockUserRepository),
+		orgUsers: new(mocks.MockOrgUserRepository),
+		auditor:  new(auditmocks.MockRecorder),
+	}
+	env.interactor = NewOrgUserInteractor(newTestUserInteractor(env.userRepo), env.orgUsers, new(mocks.InlineTxManager), env.auditor, clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	return env
+}
+
+// orgContext returns a session for user-1 acting as role in org-1.
+func orgContext(role string) context.Context {
+	return tenant.WithTenant(sessionContext("user-1", domain.RoleUser, false), &tenant.Tenant{OrganizationID: "org-1", Role: role})
+}
+
+func TestOrgUserInteractor_ListOrgUsers(t *testing.T) {
+	env := setupOrgUserTestEnv()
+	members := []domain.OrganizationMember{{User: domain.User{ID: "user-2"}, Role: domain.OrgRoleMember}}
+	env.orgUsers.On("ListOrgUsers", mock.Anything).Return(members, nil).Once()
+
+	got, err := env.interactor.ListOrgUsers(orgContext(domain.OrgRoleMember))
+	assert.NoError(t, err)
+	assert.Equal(t, members, got)
+
+	_, err = env.interactor.ListOrgUsers(sessionContext("user-1", domain.RoleUser, false))
+	assert.ErrorIs(t, err, domain.ErrNoTenant)
+}
+
+func TestOrgUserInteractor_CreateOrgUser_Success(t *testing.T) {
+	env := setupOrgUserTestEnv()
+	member := &domain.OrganizationMember{User: domain.User{ID: "user-2", Name: "Bob"}, Role: domain.OrgRoleMember}
+
+	env.userRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User"), mock.AnythingOfType("string")).
+		Return(&domain.User{ID: "user-2", Name: "Bob"}, nil).Once()
+	env.orgUsers.On("AddOrgUser", mock.Anything, "user-2", domain.OrgRoleMember).Return(nil).Once()
+	env.orgUsers.On("GetOrgUser", mock.Anything, "user-2").Return(member, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionOrgMemberAdded && e.TargetID == "user-2" && e.Metadata["organization_id"] == "org-1"
+	})).Return(nil).Once()
+
+	got, err := env.interactor.CreateOrgUser(orgContext(domain.OrgRoleAdmin), "Bob", "bob@example.com", "secret", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, member, got)
+	env.orgUsers.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestOrgUserInteractor_CreateOrgUser_Rejected(t *testing.T) {
+	tests := map[string]struct {
+		callerRole string
+		role       string
+		wantErr    error
+	}{
+		"member cannot add users":  {domain.OrgRoleMember, domain.OrgRoleMember, domain.ErrForbidden},
+		"admin cannot grant owner": {domain.OrgRoleAdmin, domain.OrgRoleOwner, domain.ErrForbidden},
+		"unknown role":             {domain.OrgRoleOwner, "superuser", domain.ErrInvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := setupOrgUserTestEnv()
+			_, err := env.interactor.CreateOrgUser(orgContext(tt.callerRole), "Bob", "bob@example.com", "secret", tt.role)
+			assert.ErrorIs(t, err, tt.wantErr)
+			env.userRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrgUserInteractor_UpdateOrgUser_NotAMember(t *testing.T) {
+	env := setupOrgUserTestEnv()
+	id := uuid.NewString()
+	env.orgUsers.On("GetOrgUser", mock.Anything, id).Return(nil, nil).Once()
+
+	_, err := env.interactor.UpdateOrgUser(orgContext(domain.OrgRoleAdmin), id, strPtr("Mallory"), nil, nil, nil)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	env.userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrgUserInteractor_UpdateOrgUser_AdminCannotEditOwner(t *testing.T) {
+	env := setupOrgUserTestEnv()
+	env.orgUsers.On("GetOrgUser", mock.Anything, "user-2").Return(&domain.OrganizationMember{User: domain.User{ID: "user-2"}, Role: domain.OrgRoleOwner}, nil).Once()
+
+	_, err := env.interactor.UpdateOrgUser(orgContext(domain.OrgRoleAdmin), "user-2", nil, nil, strPtr("new-password"), nil)
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	env.userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrgUserInteractor_UpdateOrgUser_CredentialsOfSharedUsers(t *testing.T) {
+	tests := map[string]struct {
+		member *domain.OrganizationMember
+		orgs   int
+	}{
+		"member of another organization": {&domain.OrganizationMember{User: domain.User{ID: "user-2", Role: domain.RoleUser}, Role: domain.OrgRoleMember}, 2},
+		"service administrator":          {&domain.OrganizationMember{User: domain.User{ID: "user-2", Role: domain.RoleAdmin}, Role: domain.OrgRoleMember}, 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := setupOrgUserTestEnv()
+			env.orgUsers.On("GetOrgUser", mock.Anything, "user-2").Return(tt.member, nil)
+			env.orgUsers.On("CountUserOrgs", mock.Anything, "user-2").Return(tt.orgs, nil).Maybe()
+
+			_, err := env.interactor.UpdateOrgUser(orgContext(domain.OrgRoleAdmin), "user-2", nil, strPtr("mallory@example.com"), nil, nil)
+			assert.ErrorIs(t, err, domain.ErrForbidden)
+			_, err = env.interactor.UpdateOrgUser(orgContext(domain.OrgRoleAdmin), "user-2", nil, nil, strPtr("new-password"), nil)
+			assert.ErrorIs(t, err, domain.ErrForbidden)
+			env.userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrgUserInteractor_UpdateOrgUser_DemoteOwner(t *testing.T) {
+	env := setupOrgUserTestEnv()
+	owner := &domain.OrganizationMember{User: domain.User{ID: "user-2"}, Role: domain.OrgRoleOwner}
+	demoted := &domain.OrganizationMember{User: domain.User{ID: "user-2"}, Role: domain.OrgRoleAdmin}
+
+	env.orgUsers.On("GetOrgUser", mock.Anything, "user-2").Return(owner, nil).Once()
+	env.orgUsers.On("CountOrgOwners", mock.Anything).Return(2, nil).Once()
+	env.orgUsers.On("UpdateOrgUserRole", mock.Anything, "user-2", domain.OrgRoleAdmin).Return(nil).Once()
+	env.orgUsers.On("GetOrgUser", mock.Anything, "user-2").Return(demoted, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionOrgMemberUpdated && e.ActorID == "user-1" &&
+			len(e.Changes) == 1 && e.Changes[0].Old == domain.OrgRoleOwner && e.Changes[0].New == domain.OrgRoleAdmin
+	})).Return(nil).Once()
+
+	got, err := env.interactor.UpdateOrgUser(orgContext(domain.OrgRoleOwner), "user-2", nil, nil, nil, strPtr(domain.OrgRoleAdmin))
+
+	assert.NoError(t, err)
+	assert.Equal(t, demoted, got)
+	env.orgUsers.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestOrgUserInteractor_LastOwnerIsKept(t *testing.T) {
+	env := setupOrgUserTestEnv()
+	owner := &domain.OrganizationMember{User: domain.User{ID: "user-1"}, Role: domain.OrgRoleOwner}
+	env.orgUsers.On("GetOrgUser", mock.Anything, "user-1").Return(owner, nil)
+	env.orgUsers.On("CountOrgOwners", mock.Anything).Return(1, nil)
+	ctx := orgContext(domain.OrgRoleOwner)
+
+	err := env.interactor.RemoveOrgUser(ctx, "user-1")
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	_, err = env.interactor.UpdateOrgUser(ctx, "user-1", nil, nil, nil, strPtr(domain.OrgRoleMember))
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	env.orgUsers.AssertNotCalled(t, "RemoveOrgUser", mock.Anything, mock.Anything)
+	env.orgUsers.AssertNotCalled(t, "UpdateOrgUserRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrgUserInteractor_RemoveOrgUser(t *testing.T) {
+	env := setupOrgUserTestEnv()
+	env.orgUsers.On("GetOrgUser", mock.Anything, "user-2").Return(&domain.OrganizationMember{User: domain.User{ID: "user-2"}, Role: domain.OrgRoleMember}, nil).Once()
+	env.orgUsers.On("RemoveOrgUser", mock.Anything, "user-2").Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionOrgMemberRemoved && e.TargetID == "user-2"
+	})).Return(nil).Once()
+
+	err := env.interactor.RemoveOrgUser(orgContext(domain.OrgRoleAdmin), "user-2")
+
+	assert.NoError(t, err)
+	env.orgUsers.AssertExpectations(t)
+	env.userRepo.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,147 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+	"apiserver/internal/tenant"
+	"github.com/google/uuid"
+)
+
+// orgSlugPattern matches slugs: lowercase letters, digits and inner hyphens.
+var orgSlugPattern = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// OrganizationInteractor defines the interface for organization business
+// logic and for selecting the organization a request acts within.
+type OrganizationInteractor interface {
+	// CreateOrganization creates an organization owned by ownerID, or by the
+	// caller when ownerID is empty. Only administrators may create organizations.
+	CreateOrganization(ctx context.Context, name, slug, ownerID string) (*domain.Organization, error)
+	// ListMyOrganizations returns the organizations the caller belongs to.
+	ListMyOrganizations(ctx context.Context) ([]domain.OrganizationMembership, error)
+	// ResolveTenant returns orgID as the tenant of the request when the caller
+	// belongs to it. Unknown organizations are refused like foreign ones.
+	ResolveTenant(ctx context.Context, orgID string) (*tenant.Tenant, error)
+	// IssueOrganizationToken reissues the caller's access token for orgID, so
+	// that later requests act within it without naming it.
+	IssueOrganizationToken(ctx context.Context, orgID string) (token string, expiresAt time.Time, err error)
+}
+
+// organizationInteractor implements OrganizationInteractor.
+type organizationInteractor struct {
+	orgRepo  repositories.OrganizationRepository
+	userRepo repositories.UserRepository
+	tx       repositories.TxManager
+	tokens   auth.TokenService
+	auditor  audit.Recorder
+	clock    clock.Clock
+}
+
+// NewOrganizationInteractor creates a new instance of OrganizationInteractor.
+func NewOrganizationInteractor(orgRepo repositories.OrganizationRepository, userRepo repositories.UserRepository, tx repositories.TxManager, tokens auth.TokenService, auditor audit.Recorder, clk clock.Clock) OrganizationInteractor {
+	return &organizationInteractor{
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+		tx:       tx,
+		tokens:   tokens,
+		auditor:  auditor,
+		clock:    clk,
+	}
+}
+
+func (uc *organizationInteractor) CreateOrganization(ctx context.Context, name, slug, ownerID string) (*domain.Organization, error) {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 255 {
+		return nil, fmt.Errorf("%w: name is required and must not exceed 255 bytes", domain.ErrInvalidArgument)
+	}
+	if !orgSlugPattern.MatchString(slug) {
+		return nil, fmt.Errorf("%w: slug must be 1 to 63 lowercase letters, digits or inner hyphens", domain.ErrInvalidArgument)
+	}
+	if ownerID == "" {
+		ownerID = principal.UserID
+	}
+	if _, err := uuid.Parse(ownerID); err != nil {
+		return nil, fmt.Errorf("%w: invalid owner ID", domain.ErrInvalidArgument)
+	}
+
+	var created *domain.Organization
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		owner, err := uc.userRepo.GetUserByID(ctx, ownerID)
+		if err != nil {
+			return err
+		}
+		if owner == nil {
+			return fmt.Errorf("%w: the owner does not exist", domain.ErrInvalidArgument)
+		}
+		existing, err := uc.orgRepo.GetOrganizationBySlug(ctx, slug)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("%w: slug %q is already taken", domain.ErrConflict, slug)
+		}
+		if created, err = uc.orgRepo.CreateOrganization(ctx, &domain.Organization{Name: name, Slug: slug}, ownerID); err != nil {
+			return err
+		}
+		return uc.auditor.Record(ctx, domain.AuditEvent{
+			Action:     domain.AuditActionOrgCreated,
+			ActorID:    principal.UserID,
+			TargetID:   ownerID,
+			Metadata:   map[string]string{"organization_id": created.ID, "slug": slug},
+			OccurredAt: uc.clock.Now(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (uc *organizationInteractor) ListMyOrganizations(ctx context.Context) ([]domain.OrganizationMembership, error) {
+	principal, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return uc.orgRepo.ListUserOrganizations(ctx, principal.UserID)
+}
+
+func (uc *organizationInteractor) ResolveTenant(ctx context.Context, orgID string) (*tenant.Tenant, error) {
+	principal, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(orgID); err != nil {
+		return nil, fmt.Errorf("%w: invalid organization ID", domain.ErrInvalidArgument)
+	}
+	role, err := uc.orgRepo.GetMemberRole(ctx, orgID, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" {
+		return nil, domain.ErrForbidden
+	}
+	return &tenant.Tenant{OrganizationID: orgID, Role: role}, nil
+}
+
+func (uc *organizationInteractor) IssueOrganizationToken(ctx context.Context, orgID string) (string, time.Time, error) {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if _, err := uc.ResolveTenant(ctx, orgID); err != nil {
+		return "", time.Time{}, err
+	}
+	return uc.tokens.IssueForOrganization(principal, orgID)
+}
@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"apiserver/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type organizationTestEnv struct {
+	orgRepo    *mocks.MockOrganizationRepository
+	userRepo   *mocks.MockUserRepository
+	auditor    *auditmocks.MockRecorder
+	tokens     auth.TokenService
+	clock      *clock.Fake
+	interactor OrganizationInteractor
+}
+
+func setupOrganizationTestEnv() *organizationTestEnv {
+	env := &organizationTestEnv{
+		orgRepo:  new(mocks.MockOrganizationRepository),
+		userRepo: new(mocks.MockUserRepository),
+		auditor:  new(auditmocks.MockRecorder),
+		clock:    clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	env.tokens = auth.NewJWTTokenService([]byte("test-secret"), time.Hour, env.clock)
+	env.interactor = NewOrganizationInteractor(env.orgRepo, env.userRepo, new(mocks.InlineTxManager), env.tokens, env.auditor, env.clock)
+	return env
+}
+
+func TestOrganizationInteractor_CreateOrganization_Success(t *testing.T) {
+	env := setupOrganizationTestEnv()
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+	ownerID := uuid.NewString()
+
+	env.userRepo.On("GetUserByID", mock.Anything, ownerID).Return(&domain.User{ID: ownerID}, nil).Once()
+	env.orgRepo.On("GetOrganizationBySlug", mock.Anything, "acme").Return(nil, nil).Once()
+	env.orgRepo.On("CreateOrganization", mock.Anything, &domain.Organization{Name: "Acme", Slug: "acme"}, ownerID).
+		Return(&domain.Organization{ID: "org-1", Name: "Acme", Slug: "acme"}, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionOrgCreated && e.ActorID == "admin-1" && e.TargetID == ownerID && e.Metadata["organization_id"] == "org-1"
+	})).Return(nil).Once()
+
+	org, err := env.interactor.CreateOrganization(ctx, " Acme ", "acme", ownerID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "org-1", org.ID)
+	env.orgRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestOrganizationInteractor_CreateOrganization_SlugTaken(t *testing.T) {
+	env := setupOrganizationTestEnv()
+	ctx := sessionContext(uuid.NewString(), domain.RoleAdmin, true)
+
+	env.userRepo.On("GetUserByID", mock.Anything, mock.Anything).Return(&domain.User{}, nil).Once()
+	env.orgRepo.On("GetOrganizationBySlug", mock.Anything, "acme").Return(&domain.Organization{ID: "org-1"}, nil).Once()
+
+	_, err := env.interactor.CreateOrganization(ctx, "Acme", "acme", "")
+
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	env.orgRepo.AssertNotCalled(t, "CreateOrganization", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrganizationInteractor_CreateOrganization_Rejected(t *testing.T) {
+	tests := map[string]struct {
+		ctx     context.Context
+		name    string
+		slug    string
+		wantErr error
+	}{
+		"not an admin":     {sessionContext("user-1", domain.RoleUser, true), "Acme", "acme", domain.ErrForbidden},
+		"no second factor": {sessionContext("admin-1", domain.RoleAdmin, false), "Acme", "acme", domain.ErrMFARequired},
+		"blank name":       {sessionContext("admin-1", domain.RoleAdmin, true), " ", "acme", domain.ErrInvalidArgument},
+		"uppercase slug":   {sessionContext("admin-1", domain.RoleAdmin, true), "Acme", "Acme", domain.ErrInvalidArgument},
+		"trailing hyphen":  {sessionContext("admin-1", domain.RoleAdmin, true), "Acme", "acme-", domain.ErrInvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := setupOrganizationTestEnv()
+			_, err := env.interactor.CreateOrganization(tt.ctx, tt.name, tt.slug, uuid.NewString())
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestOrganizationInteractor_ResolveTenant(t *testing.T) {
+	env := setupOrganizationTestEnv()
+	ctx := sessionContext("user-1", domain.RoleUser, false)
+	member, stranger := uuid.NewString(), uuid.NewString()
+
+	env.orgRepo.On("GetMemberRole", mock.Anything, member, "user-1").Return(domain.OrgRoleAdmin, nil).Once()
+	env.orgRepo.On("GetMemberRole", mock.Anything, stranger, "user-1").Return("", nil).Once()
+
+	got, err := env.interactor.ResolveTenant(ctx, member)
+	assert.NoError(t, err)
+	assert.Equal(t, &tenant.Tenant{OrganizationID: member, Role: domain.OrgRoleAdmin}, got)
+
+	_, err = env.interactor.ResolveTenant(ctx, stranger)
+	assert.ErrorIs(t, err, domain.ErrForbidden, "organizations the caller does not belong to are not revealed")
+
+	_, err = env.interactor.ResolveTenant(ctx, "not-a-uuid")
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, err = env.interactor.ResolveTenant(context.Background(), member)
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+}
+
+func TestOrganizationInteractor_IssueOrganizationToken(t *testing.T) {
+	env := setupOrganizationTestEnv()
+	orgID := uuid.NewString()
+	expiresAt := env.clock.Now().Add(30 * time.Minute)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "user-1", Role: domain.RoleUser, ExpiresAt: expiresAt})
+
+	env.orgRepo.On("GetMemberRole", mock.Anything, orgID, "user-1").Return(domain.OrgRoleMember, nil).Once()
+
+	token, gotExpiry, err := env.interactor.IssueOrganizationToken(ctx, orgID)
+	require.NoError(t, err)
+	assert.True(t, gotExpiry.Equal(expiresAt), "the token expires with the one it replaces")
+
+	p, err := env.tokens.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, orgID, p.OrgID)
+	assert.Equal(t, "user-1", p.UserID)
+}
+
+func TestOrganizationInteractor_IssueOrganizationToken_APIKeyRefused(t *testing.T) {
+	env := setupOrganizationTestEnv()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "svc-1", Role: domain.RoleUser, APIKeyID: "key-1"})
+
+	_, _, err := env.interactor.IssueOrganizationToken(ctx, uuid.NewString())
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	env.orgRepo.AssertNotCalled(t, "GetMemberRole", mock.Anything, mock.Anything, mock.Anything)
+}
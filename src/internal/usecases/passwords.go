@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"apiserver/internal/domain"
+)
+
+// newPasswordHash checks a password chosen at registration or in a password
+// change and hashes it for storage. Passwords longer than the hasher takes
+// into account are refused rather than silently truncated, and so are
+// passwords known from data breaches when a breach checker is configured.
+func (uc *userInteractor) newPasswordHash(ctx context.Context, plain string) (string, error) {
+	if len(plain) > uc.passwords.MaxLength() {
+		return "", fmt.Errorf("%w: password must be at most %d bytes", domain.ErrInvalidArgument, uc.passwords.MaxLength())
+	}
+	if uc.breaches != nil {
+		breached, err := uc.breaches.Breached(ctx, plain)
+		if err != nil {
+			return "", fmt.Errorf("checking the password against breaches: %w", err)
+		}
+		if breached {
+			return "", domain.ErrPasswordBreached
+		}
+	}
+	return uc.passwords.Hash(plain)
+}
@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// breachList is a BreachChecker that knows the passwords in it.
+type breachList struct {
+	passwords map[string]bool
+	err       error
+	mu        sync.Mutex
+	checked   []string
+}
+
+func (b *breachList) Breached(ctx context.Context, plain string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.checked = append(b.checked, plain)
+	return b.passwords[plain], b.err
+}
+
+func newBreachCheckedUserInteractor(repo *mocks.MockUserRepository, breaches *breachList) UserInteractor {
+	auditor := new(auditmocks.MockRecorder)
+	auditor.On("Record", mock.Anything, mock.Anything).Return(nil).Maybe()
+	outbox := new(mocks.MockOutboxRepository)
+	outbox.On("AppendOutboxEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return NewUserInteractor(repo, new(mocks.MockUserAttributeRepository), testPasswords, breaches, newMemoryBlobStore(), new(mocks.InlineTxManager), auditor, outbox, clock.Real())
+}
+
+func TestUserInteractor_CreateNewUser_BreachedPassword(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	breaches := &breachList{passwords: map[string]bool{"password123": true}}
+	interactor := newBreachCheckedUserInteractor(repo, breaches)
+
+	_, err := interactor.CreateNewUser(context.Background(), "Test User", "test@example.com", "password123")
+
+	assert.ErrorIs(t, err, domain.ErrPasswordBreached)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	repo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserInteractor_CreateNewUser_UnbreachedPassword(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	breaches := &breachList{passwords: map[string]bool{"password123": true}}
+	interactor := newBreachCheckedUserInteractor(repo, breaches)
+
+	repo.On("CreateUser", mock.Anything, mock.Anything, mock.AnythingOfType("string")).
+		Return(&domain.User{ID: "user-1", Name: "Test User", Email: "test@example.com"}, nil).Once()
+
+	_, err := interactor.CreateNewUser(context.Background(), "Test User", "test@example.com", "a quiet brass lantern")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a quiet brass lantern"}, breaches.checked)
+	repo.AssertExpectations(t)
+}
+
+func TestUserInteractor_PatchUser_BreachedPassword(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	breaches := &breachList{passwords: map[string]bool{"qwerty": true}}
+	interactor := newBreachCheckedUserInteractor(repo, breaches)
+	newPassword := "qwerty"
+
+	_, err := interactor.PatchUser(sessionContext("admin-1", domain.RoleAdmin, true), "user-1", domain.UserPatch{Password: &newPassword})
+
+	assert.ErrorIs(t, err, domain.ErrPasswordBreached)
+	repo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserInteractor_CreateNewUser_BreachCheckFails(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	interactor := newBreachCheckedUserInteractor(repo, &breachList{err: errors.New("range file missing")})
+
+	_, err := interactor.CreateNewUser(context.Background(), "Test User", "test@example.com", "password123")
+
+	// The password is not accepted unchecked
+	assert.ErrorContains(t, err, "range file missing")
+	assert.NotErrorIs(t, err, domain.ErrInvalidArgument)
+	repo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+}
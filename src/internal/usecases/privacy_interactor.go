@@ -0,0 +1,263 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+	"apiserver/internal/storage"
+	"github.com/google/uuid"
+)
+
+// maxErasureReasonLength matches the reason column of erasure receipts.
+const maxErasureReasonLength = 255
+
+// PrivacyInteractor defines the interface for data subject requests.
+type PrivacyInteractor interface {
+	// ExportUserData gathers everything stored about userID. Users may export
+	// their own data and admins anyone's.
+	ExportUserData(ctx context.Context, userID string) (*domain.UserDataExport, error)
+	// EraseUser anonymizes userID and scrubs their personal data from the
+	// audit log, the event payloads in the outbox and webhook deliveries and
+	// the import reports, deletes the invitations sent to them that were never
+	// accepted, and returns the receipt recording it. Unlike RemoveUser the
+	// row is kept, so that audit events and memberships still refer to a
+	// user. Only admins may erase users, and a user can be erased only once.
+	EraseUser(ctx context.Context, userID, reason string) (*domain.ErasureReceipt, error)
+	// GetErasureReceipt returns the receipt recorded when userID was erased.
+	GetErasureReceipt(ctx context.Context, userID string) (*domain.ErasureReceipt, error)
+}
+
+// privacyInteractor implements PrivacyInteractor.
+type privacyInteractor struct {
+	userRepo     repositories.UserRepository
+	privacyRepo  repositories.PrivacyRepository
+	mfaRepo      repositories.MFARepository
+	apiKeyRepo   repositories.APIKeyRepository
+	groupRepo    repositories.GroupRepository
+	orgRepo      repositories.OrganizationRepository
+	throttleRepo repositories.LoginThrottleRepository
+	store        storage.BlobStore
+	tx           repositories.TxManager
+	auditor      audit.Recorder
+	outbox       repositories.OutboxRepository
+	clock        clock.Clock
+}
+
+// NewPrivacyInteractor creates a new instance of PrivacyInteractor. Avatars of
+// erased users are deleted from store.
+func NewPrivacyInteractor(userRepo repositories.UserRepository, privacyRepo repositories.PrivacyRepository, mfaRepo repositories.MFARepository, apiKeyRepo repositories.APIKeyRepository, groupRepo repositories.GroupRepository, orgRepo repositories.OrganizationRepository, throttleRepo repositories.LoginThrottleRepository, store storage.BlobStore, tx repositories.TxManager, auditor audit.Recorder, outbox repositories.OutboxRepository, clk clock.Clock) PrivacyInteractor {
+	return &privacyInteractor{
+		userRepo:     userRepo,
+		privacyRepo:  privacyRepo,
+		mfaRepo:      mfaRepo,
+		apiKeyRepo:   apiKeyRepo,
+		groupRepo:    groupRepo,
+		orgRepo:      orgRepo,
+		throttleRepo: throttleRepo,
+		store:        store,
+		tx:           tx,
+		auditor:      auditor,
+		outbox:       outbox,
+		clock:        clk,
+	}
+}
+
+func (uc *privacyInteractor) ExportUserData(ctx context.Context, userID string) (*domain.UserDataExport, error) {
+	if err := checkScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+	principal, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if principal.UserID != userID {
+		if _, err := requireAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &domain.UserDataExport{User: *user, ExportedAt: uc.clock.Now()}
+	setting, err := uc.mfaRepo.GetMFASetting(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	export.MFAEnabled = setting != nil && setting.Enabled
+	if export.APIKeys, err = uc.apiKeyRepo.ListAPIKeysByUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.Groups, err = uc.groupRepo.ListUserGroups(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.Organizations, err = uc.orgRepo.ListUserOrganizations(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.AuditEvents, err = uc.privacyRepo.ListUserAuditEvents(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	err = uc.auditor.Record(ctx, domain.AuditEvent{
+		Action:     domain.AuditActionUserDataExported,
+		ActorID:    principal.UserID,
+		TargetID:   userID,
+		OccurredAt: export.ExportedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+func (uc *privacyInteractor) EraseUser(ctx context.Context, userID, reason string) (*domain.ErasureReceipt, error) {
+	if err := checkScope(ctx, domain.ScopeUsersWrite); err != nil {
+		return nil, err
+	}
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len([]rune(reason)) > maxErasureReasonLength {
+		return nil, fmt.Errorf("%w: reason must not exceed %d characters", domain.ErrInvalidArgument, maxErasureReasonLength)
+	}
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.ErasedAt.IsZero() {
+		return nil, fmt.Errorf("%w: user was already erased", domain.ErrConflict)
+	}
+
+	// Blobs are not covered by the transaction. Deleting them first means a
+	// failed erasure leaves at worst a user without an avatar, and a retry
+	// finishes the job.
+	for _, size := range []int{avatarSize, avatarThumbnailSize} {
+		if err := uc.store.Delete(ctx, fmt.Sprintf("avatars/%s/%d.png", userID, size)); err != nil {
+			return nil, err
+		}
+	}
+
+	now := uc.clock.Now()
+	var receipt *domain.ErasureReceipt
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		erased, err := uc.privacyRepo.EraseUser(ctx, userID, now)
+		if err != nil {
+			return err
+		}
+		if !erased {
+			// Erased or deleted since it was read
+			return fmt.Errorf("%w: user was already erased", domain.ErrConflict)
+		}
+		scrubbed, err := uc.privacyRepo.ScrubAuditEvents(ctx, userID, now)
+		if err != nil {
+			return err
+		}
+		events, deliveries, err := uc.privacyRepo.ScrubEvents(ctx, userID)
+		if err != nil {
+			return err
+		}
+		// Throttles, import reports and invitations are keyed by email, which
+		// must not outlive the user
+		email := normalizeEmail(user.Email)
+		if err := uc.throttleRepo.Reset(ctx, domain.ThrottleScopeAccount, email); err != nil {
+			return err
+		}
+		reports, err := uc.privacyRepo.ScrubImportReports(ctx, userID, email)
+		if err != nil {
+			return err
+		}
+		invitations, err := uc.privacyRepo.DeleteUnacceptedInvitations(ctx, email)
+		if err != nil {
+			return err
+		}
+		receipt, err = uc.privacyRepo.CreateErasureReceipt(ctx, &domain.ErasureReceipt{
+			UserID:                    userID,
+			RequestedBy:               principal.UserID,
+			Reason:                    reason,
+			AuditEventsScrubbed:       scrubbed,
+			EventsScrubbed:            events,
+			WebhookDeliveriesScrubbed: deliveries,
+			ImportReportsScrubbed:     reports,
+			InvitationsDeleted:        invitations,
+			ErasedAt:                  now,
+		})
+		if err != nil {
+			return err
+		}
+		// The event of the erasure itself carries no personal data
+		err = uc.auditor.Record(ctx, domain.AuditEvent{
+			Action:     domain.AuditActionUserErased,
+			ActorID:    principal.UserID,
+			TargetID:   userID,
+			Metadata:   map[string]string{"receipt_id": receipt.ID},
+			OccurredAt: now,
+		})
+		if err != nil {
+			return err
+		}
+		// Subscribers are told the user is gone, as for a deletion
+		return uc.appendUserDeleted(ctx, userID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+func (uc *privacyInteractor) GetErasureReceipt(ctx context.Context, userID string) (*domain.ErasureReceipt, error) {
+	if err := checkScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, domain.ErrErasureReceiptNotFound
+	}
+	receipt, err := uc.privacyRepo.GetErasureReceipt(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, domain.ErrErasureReceiptNotFound
+	}
+	return receipt, nil
+}
+
+// getUser returns userID, or ErrNotFound when there is no such user.
+func (uc *privacyInteractor) getUser(ctx context.Context, userID string) (*domain.User, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, domain.ErrNotFound
+	}
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrNotFound
+	}
+	return user, nil
+}
+
+// appendUserDeleted stores a user.deleted event for userID in the outbox.
+func (uc *privacyInteractor) appendUserDeleted(ctx context.Context, userID string, at time.Time) error {
+	b, err := json.Marshal(domain.UserEventPayload{ID: userID})
+	if err != nil {
+		return err
+	}
+	return uc.outbox.AppendOutboxEvent(ctx, domain.DomainEvent{
+		ID:          uuid.NewString(),
+		Type:        domain.EventUserDeleted,
+		AggregateID: userID,
+		Payload:     b,
+		OccurredAt:  at,
+	})
+}
@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"apiserver/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPrivacyUserID  = "5f0c6c1e-2a7b-4b8e-9c55-3d1e8a0b7c21"
+	testPrivacyAdminID = "9a3e1b7d-6c2f-4e0a-8b51-7f4d2c9e1a30"
+)
+
+type privacyTestEnv struct {
+	userRepo     *mocks.MockUserRepository
+	privacyRepo  *mocks.MockPrivacyRepository
+	mfaRepo      *mocks.MockMFARepository
+	apiKeyRepo   *mocks.MockAPIKeyRepository
+	groupRepo    *mocks.MockGroupRepository
+	orgRepo      *mocks.MockOrganizationRepository
+	throttleRepo *mocks.MockLoginThrottleRepository
+	outbox       *mocks.MockOutboxRepository
+	auditor      *auditmocks.MockRecorder
+	store        storage.BlobStore
+	dir          string
+	clock        *clock.Fake
+	interactor   PrivacyInteractor
+}
+
+func setupPrivacyTestEnv(t *testing.T) *privacyTestEnv {
+	env := &privacyTestEnv{
+		userRepo:     new(mocks.MockUserRepository),
+		privacyRepo:  new(mocks.MockPrivacyRepository),
+		mfaRepo:      new(mocks.MockMFARepository),
+		apiKeyRepo:   new(mocks.MockAPIKeyRepository),
+		groupRepo:    new(mocks.MockGroupRepository),
+		orgRepo:      new(mocks.MockOrganizationRepository),
+		throttleRepo: new(mocks.MockLoginThrottleRepository),
+		outbox:       new(mocks.MockOutboxRepository),
+		auditor:      new(auditmocks.MockRecorder),
+		dir:          t.TempDir(),
+		clock:        clock.NewFake(time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)),
+	}
+	env.store = storage.NewLocalBlobStore(env.dir, "https://cdn.example.com")
+	env.interactor = NewPrivacyInteractor(env.userRepo, env.privacyRepo, env.mfaRepo, env.apiKeyRepo, env.groupRepo, env.orgRepo, env.throttleRepo, env.store, new(mocks.InlineTxManager), env.auditor, env.outbox, env.clock)
+	return env
+}
+
+func TestPrivacyInteractor_ExportUserData_Self(t *testing.T) {
+	env := setupPrivacyTestEnv(t)
+	user := &domain.User{ID: testPrivacyUserID, Name: "Alice", Email: "alice@example.com"}
+	events := []domain.AuditEvent{{ID: 1, Action: domain.AuditActionUserCreated, TargetID: testPrivacyUserID}}
+	env.userRepo.On("GetUserByID", mock.Anything, testPrivacyUserID).Return(user, nil).Once()
+	env.mfaRepo.On("GetMFASetting", mock.Anything, testPrivacyUserID).Return(&domain.MFASetting{Enabled: true}, nil).Once()
+	env.apiKeyRepo.On("ListAPIKeysByUser", mock.Anything, testPrivacyUserID).Return([]domain.APIKey{{ID: "k1"}}, nil).Once()
+	env.groupRepo.On("ListUserGroups", mock.Anything, testPrivacyUserID).Return([]domain.Group{{ID: "g1"}}, nil).Once()
+	env.orgRepo.On("ListUserOrganizations", mock.Anything, testPrivacyUserID).Return([]domain.OrganizationMembership(nil), nil).Once()
+	env.privacyRepo.On("ListUserAuditEvents", mock.Anything, testPrivacyUserID).Return(events, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionUserDataExported && e.ActorID == testPrivacyUserID && e.TargetID == testPrivacyUserID
+	})).Return(nil).Once()
+
+	got, err := env.interactor.ExportUserData(sessionContext(testPrivacyUserID, domain.RoleUser, false), testPrivacyUserID)
+
+	require.NoError(t, err)
+	assert.Equal(t, *user, got.User)
+	assert.True(t, got.MFAEnabled)
+	assert.Len(t, got.APIKeys, 1)
+	assert.Len(t, got.Groups, 1)
+	assert.Equal(t, events, got.AuditEvents)
+	assert.Equal(t, env.clock.Now(), got.ExportedAt)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestPrivacyInteractor_ExportUserData_OtherUserNeedsAdmin(t *testing.T) {
+	env := setupPrivacyTestEnv(t)
+
+	_, err := env.interactor.ExportUserData(sessionContext("someone-else", domain.RoleUser, false), testPrivacyUserID)
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	env.userRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+}
+
+func TestPrivacyInteractor_EraseUser(t *testing.T) {
+	env := setupPrivacyTestEnv(t)
+	now := env.clock.Now()
+	for _, key := range []string{"avatars/" + testPrivacyUserID + "/256.png", "avatars/" + testPrivacyUserID + "/64.png"} {
+		require.NoError(t, env.store.Put(context.Background(), key, []byte("png"), "image/png"))
+	}
+	env.userRepo.On("GetUserByID", mock.Anything, testPrivacyUserID).Return(&domain.User{ID: testPrivacyUserID, Email: " Alice@Example.com"}, nil).Once()
+	env.privacyRepo.On("EraseUser", mock.Anything, testPrivacyUserID, now).Return(true, nil).Once()
+	env.privacyRepo.On("ScrubAuditEvents", mock.Anything, testPrivacyUserID, now).Return(4, nil).Once()
+	env.privacyRepo.On("ScrubEvents", mock.Anything, testPrivacyUserID).Return(3, 2, nil).Once()
+	env.throttleRepo.On("Reset", mock.Anything, domain.ThrottleScopeAccount, "alice@example.com").Return(nil).Once()
+	env.privacyRepo.On("ScrubImportReports", mock.Anything, testPrivacyUserID, "alice@example.com").Return(1, nil).Once()
+	env.privacyRepo.On("DeleteUnacceptedInvitations", mock.Anything, "alice@example.com").Return(1, nil).Once()
+	env.privacyRepo.On("CreateErasureReceipt", mock.Anything, &domain.ErasureReceipt{
+		UserID:                    testPrivacyUserID,
+		RequestedBy:               testPrivacyAdminID,
+		Reason:                    "ticket 42",
+		AuditEventsScrubbed:       4,
+		EventsScrubbed:            3,
+		WebhookDeliveriesScrubbed: 2,
+		ImportReportsScrubbed:     1,
+		InvitationsDeleted:        1,
+		ErasedAt:                  now,
+	}).Return(&domain.ErasureReceipt{ID: "r1", UserID: testPrivacyUserID, AuditEventsScrubbed: 4, ErasedAt: now}, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionUserErased && e.TargetID == testPrivacyUserID && e.Metadata["receipt_id"] == "r1" && len(e.Changes) == 0
+	})).Return(nil).Once()
+	env.outbox.On("AppendOutboxEvent", mock.Anything, mock.MatchedBy(func(e domain.DomainEvent) bool {
+		return e.Type == domain.EventUserDeleted && string(e.Payload) == `{"id":"`+testPrivacyUserID+`"}`
+	})).Return(nil).Once()
+
+	got, err := env.interactor.EraseUser(sessionContext(testPrivacyAdminID, domain.RoleAdmin, true), testPrivacyUserID, "ticket 42")
+
+	require.NoError(t, err)
+	assert.Equal(t, "r1", got.ID)
+	env.privacyRepo.AssertExpectations(t)
+	env.throttleRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+	env.outbox.AssertExpectations(t)
+	_, err = os.Stat(filepath.Join(env.dir, "avatars", testPrivacyUserID, "256.png"))
+	assert.True(t, os.IsNotExist(err), "avatar deleted")
+	_, err = os.Stat(filepath.Join(env.dir, "avatars", testPrivacyUserID, "64.png"))
+	assert.True(t, os.IsNotExist(err), "thumbnail deleted")
+}
+
+func TestPrivacyInteractor_EraseUser_AlreadyErased(t *testing.T) {
+	env := setupPrivacyTestEnv(t)
+	env.userRepo.On("GetUserByID", mock.Anything, testPrivacyUserID).Return(&domain.User{ID: testPrivacyUserID, ErasedAt: env.clock.Now()}, nil).Once()
+
+	_, err := env.interactor.EraseUser(sessionContext(testPrivacyAdminID, domain.RoleAdmin, true), testPrivacyUserID, "")
+
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	env.privacyRepo.AssertNotCalled(t, "EraseUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPrivacyInteractor_EraseUser_RequiresAdmin(t *testing.T) {
+	env := setupPrivacyTestEnv(t)
+
+	_, err := env.interactor.EraseUser(sessionContext(testPrivacyUserID, domain.RoleUser, true), testPrivacyUserID, "")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	_, err = env.interactor.EraseUser(sessionContext(testPrivacyAdminID, domain.RoleAdmin, false), testPrivacyUserID, "")
+	assert.ErrorIs(t, err, domain.ErrMFARequired)
+	env.userRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+}
+
+func TestPrivacyInteractor_GetErasureReceipt_NotErased(t *testing.T) {
+	env := setupPrivacyTestEnv(t)
+	env.privacyRepo.On("GetErasureReceipt", mock.Anything, testPrivacyUserID).Return(nil, nil).Once()
+
+	_, err := env.interactor.GetErasureReceipt(sessionContext(testPrivacyAdminID, domain.RoleAdmin, true), testPrivacyUserID)
+
+	assert.ErrorIs(t, err, domain.ErrErasureReceiptNotFound)
+}
@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+)
+
+// RetentionPolicies sets how long each kind of record is kept once it is no
+// longer in use. A zero duration keeps the records forever.
+type RetentionPolicies struct {
+	AuditEvents     time.Duration // Since the event occurred
+	ErasedUsers     time.Duration // Since the user was erased; the erasure receipt is kept
+	Invitations     time.Duration // Since the invitation expired or was revoked
+	APIKeys         time.Duration // Since the key expired or was revoked
+	OutboxEvents    time.Duration // Since the event was published
+	LoginThrottles  time.Duration // Since the last failed sign-in or lockout
+	OIDCLoginStates time.Duration // Since an unfinished sign-in at an identity provider expired
+	OAuthCodes      time.Duration // Since an authorization code expired; until then a used one catches replays
+	OAuthTokens     time.Duration // Since an access token issued to an OAuth client expired
+	BatchSize       int           // Rows deleted per transaction
+}
+
+// DefaultRetentionPolicies returns the policies used unless configured
+// otherwise. Erased users are kept, since their rows are what audit events
+// and erasure receipts refer to.
+func DefaultRetentionPolicies() RetentionPolicies {
+	return RetentionPolicies{
+		AuditEvents:     365 * 24 * time.Hour,
+		Invitations:     30 * 24 * time.Hour,
+		APIKeys:         90 * 24 * time.Hour,
+		OutboxEvents:    7 * 24 * time.Hour,
+		LoginThrottles:  30 * 24 * time.Hour,
+		OIDCLoginStates: 24 * time.Hour,
+		OAuthCodes:      24 * time.Hour,
+		OAuthTokens:     24 * time.Hour,
+		BatchSize:       1000,
+	}
+}
+
+// RetentionInteractor defines the interface for purging records that have
+// outlived their retention policy. It runs as scheduled jobs rather than on
+// behalf of a caller, so it does no authorization.
+type RetentionInteractor interface {
+	// Policies returns the names of the enabled policies, such as
+	// domain.RetentionAuditEvents.
+	Policies() []string
+	// Purge deletes the records older than the named policy allows and
+	// returns how many it deleted. Rows are deleted in batches, each in its
+	// own transaction, so an error or cancellation keeps the batches done.
+	Purge(ctx context.Context, policy string) (int64, error)
+}
+
+// retentionInteractor implements RetentionInteractor.
+type retentionInteractor struct {
+	retentionRepo repositories.RetentionRepository
+	tx            repositories.TxManager
+	clock         clock.Clock
+	policies      RetentionPolicies
+}
+
+// NewRetentionInteractor creates a new instance of RetentionInteractor.
+func NewRetentionInteractor(retentionRepo repositories.RetentionRepository, tx repositories.TxManager, clk clock.Clock, policies RetentionPolicies) RetentionInteractor {
+	if policies.BatchSize <= 0 {
+		policies.BatchSize = DefaultRetentionPolicies().BatchSize
+	}
+	return &retentionInteractor{retentionRepo: retentionRepo, tx: tx, clock: clk, policies: policies}
+}
+
+// retentionPolicy pairs a policy's age with the repository method purging it.
+type retentionPolicy struct {
+	name   string
+	maxAge time.Duration
+	purge  func(ctx context.Context, before time.Time, limit int) (int, error)
+}
+
+func (uc *retentionInteractor) all() []retentionPolicy {
+	return []retentionPolicy{
+		{domain.RetentionAuditEvents, uc.policies.AuditEvents, uc.retentionRepo.PurgeAuditEvents},
+		{domain.RetentionErasedUsers, uc.policies.ErasedUsers, uc.retentionRepo.PurgeErasedUsers},
+		{domain.RetentionInvitations, uc.policies.Invitations, uc.retentionRepo.PurgeInvitations},
+		{domain.RetentionAPIKeys, uc.policies.APIKeys, uc.retentionRepo.PurgeAPIKeys},
+		{domain.RetentionOutboxEvents, uc.policies.OutboxEvents, uc.retentionRepo.PurgeOutboxEvents},
+		{domain.RetentionLoginThrottles, uc.policies.LoginThrottles, uc.retentionRepo.PurgeLoginThrottles},
+		{domain.RetentionOIDCLoginStates, uc.policies.OIDCLoginStates, uc.retentionRepo.PurgeOIDCLoginStates},
+		{domain.RetentionOAuthCodes, uc.policies.OAuthCodes, uc.retentionRepo.PurgeOAuthCodes},
+		{domain.RetentionOAuthTokens, uc.policies.OAuthTokens, uc.retentionRepo.PurgeOAuthTokens},
+	}
+}
+
+func (uc *retentionInteractor) Policies() []string {
+	var names []string
+	for _, p := range uc.all() {
+		if p.maxAge > 0 {
+			names = append(names, p.name)
+		}
+	}
+	return names
+}
+
+func (uc *retentionInteractor) Purge(ctx context.Context, policy string) (int64, error) {
+	var p *retentionPolicy
+	for _, candidate := range uc.all() {
+		if candidate.name == policy {
+			p = &candidate
+			break
+		}
+	}
+	if p == nil || p.maxAge <= 0 {
+		return 0, fmt.Errorf("retention policy %q is not enabled", policy)
+	}
+
+	// The cutoff is fixed up front, so rows aging out while the purge runs
+	// wait for the next one instead of keeping it going.
+	before := uc.clock.Now().Add(-p.maxAge)
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		var n int
+		err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			n, err = p.purge(ctx, before, uc.policies.BatchSize)
+			return err
+		})
+		if err != nil {
+			return total, fmt.Errorf("purging %s: %w", p.name, err)
+		}
+		total += int64(n)
+		if n < uc.policies.BatchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type retentionTestEnv struct {
+	retentionRepo *mocks.MockRetentionRepository
+	tx            *mocks.InlineTxManager
+	clock         *clock.Fake
+	interactor    RetentionInteractor
+}
+
+func setupRetentionTestEnv(policies RetentionPolicies) *retentionTestEnv {
+	env := &retentionTestEnv{
+		retentionRepo: new(mocks.MockRetentionRepository),
+		tx:            new(mocks.InlineTxManager),
+		clock:         clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	env.interactor = NewRetentionInteractor(env.retentionRepo, env.tx, env.clock, policies)
+	return env
+}
+
+func TestRetentionInteractor_Policies(t *testing.T) {
+	env := setupRetentionTestEnv(DefaultRetentionPolicies())
+
+	assert.Equal(t, []string{
+		domain.RetentionAuditEvents,
+		domain.RetentionInvitations,
+		domain.RetentionAPIKeys,
+		domain.RetentionOutboxEvents,
+		domain.RetentionLoginThrottles,
+		domain.RetentionOIDCLoginStates,
+		domain.RetentionOAuthCodes,
+		domain.RetentionOAuthTokens,
+	}, env.interactor.Policies())
+}
+
+func TestRetentionInteractor_Purge_Batches(t *testing.T) {
+	env := setupRetentionTestEnv(RetentionPolicies{AuditEvents: 24 * time.Hour, BatchSize: 100})
+	before := env.clock.Now().Add(-24 * time.Hour)
+
+	env.retentionRepo.On("PurgeAuditEvents", mock.Anything, before, 100).Return(100, nil).Twice()
+	env.retentionRepo.On("PurgeAuditEvents", mock.Anything, before, 100).Return(42, nil).Once()
+
+	n, err := env.interactor.Purge(context.Background(), domain.RetentionAuditEvents)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(242), n)
+	assert.Equal(t, 3, env.tx.Calls)
+	env.retentionRepo.AssertExpectations(t)
+}
+
+func TestRetentionInteractor_Purge_ErrorKeepsCount(t *testing.T) {
+	env := setupRetentionTestEnv(RetentionPolicies{OutboxEvents: time.Hour, BatchSize: 10})
+
+	env.retentionRepo.On("PurgeOutboxEvents", mock.Anything, mock.Anything, 10).Return(10, nil).Once()
+	env.retentionRepo.On("PurgeOutboxEvents", mock.Anything, mock.Anything, 10).Return(0, errors.New("lock wait timeout")).Once()
+
+	n, err := env.interactor.Purge(context.Background(), domain.RetentionOutboxEvents)
+	assert.ErrorContains(t, err, "purging outbox_events: lock wait timeout")
+	assert.Equal(t, int64(10), n)
+}
+
+func TestRetentionInteractor_Purge_Disabled(t *testing.T) {
+	env := setupRetentionTestEnv(DefaultRetentionPolicies())
+
+	_, err := env.interactor.Purge(context.Background(), domain.RetentionErasedUsers)
+	assert.Error(t, err)
+	_, err = env.interactor.Purge(context.Background(), "sessions")
+	assert.Error(t, err)
+	env.retentionRepo.AssertNotCalled(t, "PurgeErasedUsers", mock.Anything, mock.Anything, mock.Anything)
+}
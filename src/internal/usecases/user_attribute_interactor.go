@@ -0,0 +1,229 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+)
+
+const (
+	// maxAttributeDescriptionLength matches user_attribute_definitions.description.
+	maxAttributeDescriptionLength = 255
+	// maxAttributeEnumValues caps the allowed values of one attribute.
+	maxAttributeEnumValues = 100
+)
+
+// UserAttributeInteractor defines the interface for managing the definitions
+// of custom user attributes. Any signed-in user may list the definitions;
+// only administrators may change them.
+type UserAttributeInteractor interface {
+	ListAttributeDefinitions(ctx context.Context) ([]domain.AttributeDefinition, error)
+	CreateAttributeDefinition(ctx context.Context, def domain.AttributeDefinition) (*domain.AttributeDefinition, error)
+	// UpdateAttributeDefinition changes the required flag, enum or description
+	// of an attribute. Stored values are not revalidated; they are checked
+	// again when a user's attributes are next written.
+	UpdateAttributeDefinition(ctx context.Context, key string, patch domain.AttributeDefinitionPatch) (*domain.AttributeDefinition, error)
+	// DeleteAttributeDefinition deletes a definition and the attribute's value
+	// from every user.
+	DeleteAttributeDefinition(ctx context.Context, key string) error
+}
+
+// userAttributeInteractor implements UserAttributeInteractor.
+type userAttributeInteractor struct {
+	attrRepo repositories.UserAttributeRepository
+	tx       repositories.TxManager
+	auditor  audit.Recorder
+	clock    clock.Clock
+}
+
+// NewUserAttributeInteractor creates a new instance of UserAttributeInteractor.
+func NewUserAttributeInteractor(attrRepo repositories.UserAttributeRepository, tx repositories.TxManager, auditor audit.Recorder, clk clock.Clock) UserAttributeInteractor {
+	return &userAttributeInteractor{attrRepo: attrRepo, tx: tx, auditor: auditor, clock: clk}
+}
+
+func normalizeAttributeDescription(description string) (string, error) {
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > maxAttributeDescriptionLength {
+		return "", fmt.Errorf("%w: description must not exceed %d characters", domain.ErrInvalidArgument, maxAttributeDescriptionLength)
+	}
+	return description, nil
+}
+
+// checkAttributeEnum checks the allowed values of an attribute of type typ.
+func checkAttributeEnum(typ string, enum []string) error {
+	if len(enum) == 0 {
+		return nil
+	}
+	if typ != domain.AttributeTypeString {
+		return fmt.Errorf("%w: only string attributes can have an enum", domain.ErrInvalidArgument)
+	}
+	if len(enum) > maxAttributeEnumValues {
+		return fmt.Errorf("%w: an enum must not have more than %d values", domain.ErrInvalidArgument, maxAttributeEnumValues)
+	}
+	seen := make(map[string]bool, len(enum))
+	for _, v := range enum {
+		if v == "" || len(v) > maxAttributeStringLength {
+			return fmt.Errorf("%w: enum values must be between 1 and %d bytes", domain.ErrInvalidArgument, maxAttributeStringLength)
+		}
+		if seen[v] {
+			return fmt.Errorf("%w: duplicate enum value %q", domain.ErrInvalidArgument, v)
+		}
+		seen[v] = true
+	}
+	return nil
+}
+
+func (uc *userAttributeInteractor) ListAttributeDefinitions(ctx context.Context) ([]domain.AttributeDefinition, error) {
+	if err := checkScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
+	if _, err := requireUser(ctx); err != nil {
+		return nil, err
+	}
+	return uc.attrRepo.ListAttributeDefinitions(ctx)
+}
+
+func (uc *userAttributeInteractor) CreateAttributeDefinition(ctx context.Context, def domain.AttributeDefinition) (*domain.AttributeDefinition, error) {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !attributeKeyPattern.MatchString(def.Key) {
+		return nil, fmt.Errorf("%w: key must start with a lowercase letter and contain only lowercase letters, digits and underscores (at most 64)", domain.ErrInvalidArgument)
+	}
+	switch def.Type {
+	case domain.AttributeTypeString, domain.AttributeTypeNumber, domain.AttributeTypeInteger, domain.AttributeTypeBoolean, domain.AttributeTypeDate:
+	default:
+		return nil, fmt.Errorf("%w: type must be one of string, number, integer, boolean, date", domain.ErrInvalidArgument)
+	}
+	if err := checkAttributeEnum(def.Type, def.Enum); err != nil {
+		return nil, err
+	}
+	if def.Description, err = normalizeAttributeDescription(def.Description); err != nil {
+		return nil, err
+	}
+
+	var created *domain.AttributeDefinition
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := uc.attrRepo.GetAttributeDefinition(ctx, def.Key)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("%w: attribute %q already exists", domain.ErrConflict, def.Key)
+		}
+		if created, err = uc.attrRepo.CreateAttributeDefinition(ctx, &def); err != nil {
+			return err
+		}
+		return uc.recordAttributeChange(ctx, principal.UserID, domain.AuditActionAttributeCreated, created, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (uc *userAttributeInteractor) UpdateAttributeDefinition(ctx context.Context, key string, patch domain.AttributeDefinitionPatch) (*domain.AttributeDefinition, error) {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *domain.AttributeDefinition
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		def, err := uc.getAttributeDefinition(ctx, key)
+		if err != nil {
+			return err
+		}
+		var changes []domain.FieldChange
+		if patch.Required != nil && *patch.Required != def.Required {
+			changes = append(changes, domain.FieldChange{Field: "required", Old: strconv.FormatBool(def.Required), New: strconv.FormatBool(*patch.Required)})
+			def.Required = *patch.Required
+		}
+		if patch.Enum != nil {
+			if err := checkAttributeEnum(def.Type, *patch.Enum); err != nil {
+				return err
+			}
+			if old, cur := strings.Join(def.Enum, ","), strings.Join(*patch.Enum, ","); old != cur {
+				changes = append(changes, domain.FieldChange{Field: "enum", Old: old, New: cur})
+				def.Enum = *patch.Enum
+			}
+		}
+		if patch.Description != nil {
+			description, err := normalizeAttributeDescription(*patch.Description)
+			if err != nil {
+				return err
+			}
+			if description != def.Description {
+				changes = append(changes, domain.FieldChange{Field: "description", Old: def.Description, New: description})
+				def.Description = description
+			}
+		}
+		if len(changes) == 0 {
+			updated = def
+			return nil
+		}
+
+		if updated, err = uc.attrRepo.UpdateAttributeDefinition(ctx, def); err != nil {
+			return err
+		}
+		if updated == nil {
+			return domain.ErrAttributeDefinitionNotFound
+		}
+		return uc.recordAttributeChange(ctx, principal.UserID, domain.AuditActionAttributeUpdated, updated, changes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (uc *userAttributeInteractor) DeleteAttributeDefinition(ctx context.Context, key string) error {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		def, err := uc.getAttributeDefinition(ctx, key)
+		if err != nil {
+			return err
+		}
+		if err := uc.attrRepo.DeleteAttributeDefinition(ctx, def.Key); err != nil {
+			return err
+		}
+		return uc.recordAttributeChange(ctx, principal.UserID, domain.AuditActionAttributeDeleted, def, nil)
+	})
+}
+
+// getAttributeDefinition returns the definition or ErrAttributeDefinitionNotFound.
+func (uc *userAttributeInteractor) getAttributeDefinition(ctx context.Context, key string) (*domain.AttributeDefinition, error) {
+	if !attributeKeyPattern.MatchString(key) {
+		return nil, domain.ErrAttributeDefinitionNotFound
+	}
+	def, err := uc.attrRepo.GetAttributeDefinition(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if def == nil {
+		return nil, domain.ErrAttributeDefinitionNotFound
+	}
+	return def, nil
+}
+
+// recordAttributeChange appends an audit event for a change to def.
+func (uc *userAttributeInteractor) recordAttributeChange(ctx context.Context, actorID, action string, def *domain.AttributeDefinition, changes []domain.FieldChange) error {
+	return uc.auditor.Record(ctx, domain.AuditEvent{
+		Action:     action,
+		ActorID:    actorID,
+		Changes:    changes,
+		Metadata:   map[string]string{"attribute_key": def.Key, "attribute_type": def.Type},
+		OccurredAt: uc.clock.Now(),
+	})
+}
@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type userAttributeTestEnv struct {
+	attrRepo   *mocks.MockUserAttributeRepository
+	auditor    *auditmocks.MockRecorder
+	interactor UserAttributeInteractor
+}
+
+func setupUserAttributeTestEnv() *userAttributeTestEnv {
+	env := &userAttributeTestEnv{
+		attrRepo: new(mocks.MockUserAttributeRepository),
+		auditor:  new(auditmocks.MockRecorder),
+	}
+	env.interactor = NewUserAttributeInteractor(env.attrRepo, new(mocks.InlineTxManager), env.auditor, clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	return env
+}
+
+func TestUserAttributeInteractor_ListAttributeDefinitions(t *testing.T) {
+	env := setupUserAttributeTestEnv()
+	env.attrRepo.On("ListAttributeDefinitions", mock.Anything).Return(testAttributeDefinitions(), nil).Once()
+
+	got, err := env.interactor.ListAttributeDefinitions(sessionContext("user-1", domain.RoleUser, false))
+
+	require.NoError(t, err)
+	assert.Len(t, got, 5)
+}
+
+func TestUserAttributeInteractor_CreateAttributeDefinition(t *testing.T) {
+	env := setupUserAttributeTestEnv()
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+	def := &domain.AttributeDefinition{Key: "department", Type: domain.AttributeTypeString, Required: true, Enum: []string{"sales"}, Description: "Department"}
+	env.attrRepo.On("GetAttributeDefinition", mock.Anything, "department").Return(nil, nil).Once()
+	env.attrRepo.On("CreateAttributeDefinition", mock.Anything, def).Return(def, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionAttributeCreated && e.ActorID == "admin-1" && e.Metadata["attribute_key"] == "department"
+	})).Return(nil).Once()
+
+	got, err := env.interactor.CreateAttributeDefinition(ctx, domain.AttributeDefinition{
+		Key: "department", Type: domain.AttributeTypeString, Required: true, Enum: []string{"sales"}, Description: " Department ",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, def, got)
+	env.attrRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestUserAttributeInteractor_CreateAttributeDefinition_Invalid(t *testing.T) {
+	tests := map[string]domain.AttributeDefinition{
+		"key with capitals": {Key: "Department", Type: domain.AttributeTypeString},
+		"key with dots":     {Key: "a.b", Type: domain.AttributeTypeString},
+		"unknown type":      {Key: "department", Type: "array"},
+		"enum on number":    {Key: "score", Type: domain.AttributeTypeNumber, Enum: []string{"1"}},
+		"duplicate enum":    {Key: "department", Type: domain.AttributeTypeString, Enum: []string{"sales", "sales"}},
+		"empty enum value":  {Key: "department", Type: domain.AttributeTypeString, Enum: []string{""}},
+	}
+	for name, def := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := setupUserAttributeTestEnv()
+
+			_, err := env.interactor.CreateAttributeDefinition(sessionContext("admin-1", domain.RoleAdmin, true), def)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+			env.attrRepo.AssertNotCalled(t, "CreateAttributeDefinition", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUserAttributeInteractor_CreateAttributeDefinition_Duplicate(t *testing.T) {
+	env := setupUserAttributeTestEnv()
+	env.attrRepo.On("GetAttributeDefinition", mock.Anything, "remote").Return(&domain.AttributeDefinition{Key: "remote", Type: domain.AttributeTypeBoolean}, nil).Once()
+
+	_, err := env.interactor.CreateAttributeDefinition(sessionContext("admin-1", domain.RoleAdmin, true), domain.AttributeDefinition{Key: "remote", Type: domain.AttributeTypeBoolean})
+
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
+
+func TestUserAttributeInteractor_CreateAttributeDefinition_RequiresAdmin(t *testing.T) {
+	env := setupUserAttributeTestEnv()
+
+	_, err := env.interactor.CreateAttributeDefinition(sessionContext("user-1", domain.RoleUser, false), domain.AttributeDefinition{Key: "remote", Type: domain.AttributeTypeBoolean})
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestUserAttributeInteractor_UpdateAttributeDefinition(t *testing.T) {
+	env := setupUserAttributeTestEnv()
+	required := true
+	enum := []string{"sales", "engineering"}
+	env.attrRepo.On("GetAttributeDefinition", mock.Anything, "department").Return(&domain.AttributeDefinition{Key: "department", Type: domain.AttributeTypeString, Enum: []string{"sales"}}, nil).Once()
+	want := &domain.AttributeDefinition{Key: "department", Type: domain.AttributeTypeString, Required: true, Enum: enum}
+	env.attrRepo.On("UpdateAttributeDefinition", mock.Anything, want).Return(want, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionAttributeUpdated && assert.ObjectsAreEqual([]domain.FieldChange{
+			{Field: "required", Old: "false", New: "true"},
+			{Field: "enum", Old: "sales", New: "sales,engineering"},
+		}, e.Changes)
+	})).Return(nil).Once()
+
+	got, err := env.interactor.UpdateAttributeDefinition(sessionContext("admin-1", domain.RoleAdmin, true), "department", domain.AttributeDefinitionPatch{Required: &required, Enum: &enum})
+
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestUserAttributeInteractor_UpdateAttributeDefinition_NotFound(t *testing.T) {
+	env := setupUserAttributeTestEnv()
+	env.attrRepo.On("GetAttributeDefinition", mock.Anything, "missing").Return(nil, nil).Once()
+
+	_, err := env.interactor.UpdateAttributeDefinition(sessionContext("admin-1", domain.RoleAdmin, true), "missing", domain.AttributeDefinitionPatch{})
+
+	assert.ErrorIs(t, err, domain.ErrAttributeDefinitionNotFound)
+}
+
+func TestUserAttributeInteractor_DeleteAttributeDefinition(t *testing.T) {
+	env := setupUserAttributeTestEnv()
+	env.attrRepo.On("GetAttributeDefinition", mock.Anything, "remote").Return(&domain.AttributeDefinition{Key: "remote", Type: domain.AttributeTypeBoolean}, nil).Once()
+	env.attrRepo.On("DeleteAttributeDefinition", mock.Anything, "remote").Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionAttributeDeleted && e.Metadata["attribute_key"] == "remote"
+	})).Return(nil).Once()
+
+	err := env.interactor.DeleteAttributeDefinition(sessionContext("admin-1", domain.RoleAdmin, true), "remote")
+
+	require.NoError(t, err)
+	env.attrRepo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
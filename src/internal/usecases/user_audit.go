@@ -0,0 +1,128 @@
+package usecases
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/domain"
+)
+
+// recordUserChange appends an audit event for a user mutation, attributed to
+// the caller in ctx. It must run inside the mutation's transaction.
+func (uc *userInteractor) recordUserChange(ctx context.Context, action, targetID string, changes []domain.FieldChange) error {
+	event := domain.AuditEvent{
+		Action:     action,
+		TargetID:   targetID,
+		Changes:    changes,
+		OccurredAt: uc.clock.Now(),
+	}
+	if p := auth.PrincipalFrom(ctx); p != nil {
+		event.ActorID = p.UserID
+		if p.IsAPIKey() {
+			event.Metadata = map[string]string{"api_key_id": p.APIKeyID}
+		}
+	}
+	return uc.auditor.Record(ctx, event)
+}
+
+// userChanges returns the field-level diff between two versions of a user.
+// before is nil for creations and after is nil for deletions. Password hashes
+// and names are never recorded, only the fact that they changed, and email
+// addresses are partially masked, so the audit log keeps no PII that user
+// encryption protects.
+func userChanges(before, after *domain.User, passwordChanged bool) []domain.FieldChange {
+	var old, cur domain.User
+	if before != nil {
+		old = *before
+	}
+	if after != nil {
+		cur = *after
+	}
+
+	var changes []domain.FieldChange
+	if old.Name != cur.Name {
+		changes = append(changes, domain.FieldChange{Field: "name", Old: maskValue(old.Name), New: maskValue(cur.Name)})
+	}
+	if old.Email != cur.Email {
+		changes = append(changes, domain.FieldChange{Field: "email", Old: maskEmail(old.Email), New: maskEmail(cur.Email)})
+	}
+	if old.Role != cur.Role {
+		changes = append(changes, domain.FieldChange{Field: "role", Old: old.Role, New: cur.Role})
+	}
+	if passwordChanged {
+		changes = append(changes, domain.FieldChange{Field: "password", Old: domain.MaskedValue, New: domain.MaskedValue})
+	}
+	if before != nil && after != nil {
+		changes = append(changes, profileChanges(old, cur)...)
+	}
+	return changes
+}
+
+// profileChanges diffs the preferences and custom attributes of an updated
+// user. Attribute values may be personal data, so only the keys are recorded.
+func profileChanges(old, cur domain.User) []domain.FieldChange {
+	var changes []domain.FieldChange
+	diff := func(field, o, n string) {
+		if o != n {
+			changes = append(changes, domain.FieldChange{Field: field, Old: o, New: n})
+		}
+	}
+	diff("preferences.locale", old.Preferences.Locale, cur.Preferences.Locale)
+	diff("preferences.timezone", old.Preferences.Timezone, cur.Preferences.Timezone)
+	diff("preferences.notifications.email", strconv.FormatBool(old.Preferences.Notifications.Email), strconv.FormatBool(cur.Preferences.Notifications.Email))
+	diff("preferences.notifications.digest", old.Preferences.Notifications.Digest, cur.Preferences.Notifications.Digest)
+
+	keys := make([]string, 0, len(old.Attributes)+len(cur.Attributes))
+	for k := range old.Attributes {
+		keys = append(keys, k)
+	}
+	for k := range cur.Attributes {
+		if _, ok := old.Attributes[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		o, hadOld := old.Attributes[k]
+		n, hasNew := cur.Attributes[k]
+		if hadOld == hasNew && reflect.DeepEqual(o, n) {
+			continue
+		}
+		change := domain.FieldChange{Field: "attributes." + k}
+		if hadOld {
+			change.Old = domain.MaskedValue
+		}
+		if hasNew {
+			change.New = domain.MaskedValue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// maskValue masks a value that is set, keeping whether it was set.
+func maskValue(v string) string {
+	if v == "" {
+		return ""
+	}
+	return domain.MaskedValue
+}
+
+// maskEmail keeps the first character of the local part and the domain,
+// e.g. "alice@example.com" becomes "a***@example.com".
+func maskEmail(email string) string {
+	if email == "" {
+		return ""
+	}
+	local, host, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		return domain.MaskedValue
+	}
+	_, size := utf8.DecodeRuneInString(local)
+	return local[:size] + domain.MaskedValue + "@" + host
+}
@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type userAuditTestEnv struct {
+	userRepo   *mocks.MockUserRepository
+	tx         *mocks.InlineTxManager
+	auditor    *auditmocks.MockRecorder
+	outbox     *mocks.MockOutboxRepository
+	clock      *clock.Fake
+	interactor UserInteractor
+}
+
+func setupUserAuditTestEnv() *userAuditTestEnv {
+	env := &userAuditTestEnv{
+		userRepo: new(mocks.MockUserRepository),
+		tx:       new(mocks.InlineTxManager),
+		auditor:  new(auditmocks.MockRecorder),
+		outbox:   new(mocks.MockOutboxRepository),
+		clock:    clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	env.interactor = NewUserInteractor(env.userRepo, new(mocks.MockUserAttributeRepository), testPasswords, nil, newMemoryBlobStore(), env.tx, env.auditor, env.outbox, env.clock)
+	return env
+}
+
+func TestUserChanges(t *testing.T) {
+	before := &domain.User{Name: "Alice", Email: "alice@example.com", Role: domain.RoleUser}
+	after := &domain.User{Name: "Alice", Email: "bob@example.org", Role: domain.RoleAdmin}
+
+	assert.Equal(t, []domain.FieldChange{
+		{Field: "email", Old: "a***@example.com", New: "b***@example.org"},
+		{Field: "role", Old: domain.RoleUser, New: domain.RoleAdmin},
+		{Field: "password", Old: domain.MaskedValue, New: domain.MaskedValue},
+	}, userChanges(before, after, true))
+
+	assert.Equal(t, []domain.FieldChange{
+		{Field: "name", Old: domain.MaskedValue},
+		{Field: "email", Old: "a***@example.com"},
+		{Field: "role", Old: domain.RoleUser},
+	}, userChanges(before, nil, false))
+}
+
+func TestUserInteractor_UpdateExistingUser_RecordsAuditEvent(t *testing.T) {
+	env := setupUserAuditTestEnv()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "admin-1", Role: domain.RoleAdmin, MFA: true})
+	newName := "New Name"
+
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", Name: "Old Name", Email: "a@example.com"}, nil).Once()
+	env.userRepo.On("UpdateUser", mock.Anything, "user-1", mock.Anything, (*string)(nil)).Return(&domain.User{ID: "user-1", Name: newName, Email: "a@example.com"}, nil).Once()
+	env.auditor.On("Record", mock.Anything, domain.AuditEvent{
+		Action:     domain.AuditActionUserUpdated,
+		ActorID:    "admin-1",
+		TargetID:   "user-1",
+		Changes:    []domain.FieldChange{{Field: "name", Old: domain.MaskedValue, New: domain.MaskedValue}},
+		OccurredAt: env.clock.Now(),
+	}).Return(nil).Once()
+	env.outbox.On("AppendOutboxEvent", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := env.interactor.UpdateExistingUser(ctx, "user-1", &newName, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, env.tx.Calls)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestUserInteractor_CreateNewUser_AuditFailureFailsTheMutation(t *testing.T) {
+	env := setupUserAuditTestEnv()
+	auditErr := errors.New("audit insert failed")
+
+	env.userRepo.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).Return(&domain.User{ID: "user-1", Name: "Alice", Email: "alice@example.com"}, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionUserCreated && e.ActorID == "" && e.TargetID == "user-1"
+	})).Return(auditErr).Once()
+
+	_, err := env.interactor.CreateNewUser(context.Background(), "Alice", "alice@example.com", "password123")
+
+	// The error propagates out of the transaction so the insert is rolled back
+	assert.ErrorIs(t, err, auditErr)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestUserInteractor_RemoveUser_NotFound(t *testing.T) {
+	env := setupUserAuditTestEnv()
+
+	env.userRepo.On("GetUserByID", mock.Anything, "missing").Return(nil, nil).Once()
+
+	err := env.interactor.RemoveUser(sessionContext("admin-1", domain.RoleAdmin, true), "missing")
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	env.userRepo.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything)
+	env.auditor.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
+
+func TestUserInteractor_RemoveUser_RecordsAPIKeyActor(t *testing.T) {
+	env := setupUserAuditTestEnv()
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "svc-1", Role: domain.RoleAdmin, APIKeyID: "key-1", Scopes: []string{domain.ScopeUsersWrite, domain.ScopeUsersAdmin}})
+
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", Name: "Alice"}, nil).Once()
+	env.userRepo.On("DeleteUser", mock.Anything, "user-1").Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionUserDeleted && e.ActorID == "svc-1" && e.Metadata["api_key_id"] == "key-1"
+	})).Return(nil).Once()
+	env.outbox.On("AppendOutboxEvent", mock.Anything, mock.Anything).Return(nil).Once()
+
+	assert.NoError(t, env.interactor.RemoveUser(ctx, "user-1"))
+	env.auditor.AssertExpectations(t)
+}
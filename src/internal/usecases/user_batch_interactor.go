@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+	"github.com/google/uuid"
+)
+
+// Kinds of batch operation.
+const (
+	BatchOpCreate = "create"
+	BatchOpPatch  = "patch"
+	BatchOpDelete = "delete"
+)
+
+// UserBatchOperation is one operation of a batch. A create needs Name, Email
+// and Password; a patch needs ID and any of them; a delete needs ID only.
+type UserBatchOperation struct {
+	Op       string
+	ID       string
+	Name     *string
+	Email    *string
+	Password *string
+}
+
+// UserBatchResult is the outcome of one operation. User is set by successful
+// creates and patches.
+type UserBatchResult struct {
+	User *domain.User
+	Err  error
+}
+
+// UserBatchSettings tunes batches.
+type UserBatchSettings struct {
+	MaxOperations int // Operations accepted in one batch
+}
+
+// DefaultUserBatchSettings returns the settings used when none are configured.
+func DefaultUserBatchSettings() UserBatchSettings {
+	return UserBatchSettings{MaxOperations: 100}
+}
+
+// UserBatchInteractor defines the interface for applying many user changes in
+// one request.
+type UserBatchInteractor interface {
+	// ExecuteUserBatch applies ops in order and returns one result per
+	// operation. Each operation is authorized and validated like the
+	// corresponding single-user call. In atomic mode the batch runs in one
+	// transaction and stops at the first failure; every other operation then
+	// fails with domain.ErrBatchAborted. Otherwise each operation is committed
+	// on its own and failures do not affect the rest.
+	ExecuteUserBatch(ctx context.Context, ops []UserBatchOperation, atomic bool) ([]UserBatchResult, error)
+}
+
+// userBatchInteractor implements UserBatchInteractor on top of UserInteractor,
+// whose mutations join the batch transaction in atomic mode.
+type userBatchInteractor struct {
+	users    UserInteractor
+	tx       repositories.TxManager
+	settings UserBatchSettings
+}
+
+// NewUserBatchInteractor creates a new instance of UserBatchInteractor.
+func NewUserBatchInteractor(users UserInteractor, tx repositories.TxManager, settings UserBatchSettings) UserBatchInteractor {
+	if settings.MaxOperations <= 0 {
+		settings.MaxOperations = DefaultUserBatchSettings().MaxOperations
+	}
+	return &userBatchInteractor{users: users, tx: tx, settings: settings}
+}
+
+func (uc *userBatchInteractor) ExecuteUserBatch(ctx context.Context, ops []UserBatchOperation, atomic bool) ([]UserBatchResult, error) {
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("%w: a batch needs at least one operation", domain.ErrInvalidArgument)
+	}
+	if len(ops) > uc.settings.MaxOperations {
+		return nil, fmt.Errorf("%w: a batch holds at most %d operations", domain.ErrInvalidArgument, uc.settings.MaxOperations)
+	}
+
+	results := make([]UserBatchResult, len(ops))
+	if !atomic {
+		for i, op := range ops {
+			results[i].User, results[i].Err = uc.apply(ctx, op)
+		}
+		return results, nil
+	}
+
+	failed := -1
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for i, op := range ops {
+			user, err := uc.apply(ctx, op)
+			if err != nil {
+				failed = i
+				results[i].Err = err
+				return err
+			}
+			results[i].User = user
+		}
+		return nil
+	})
+	if err != nil && failed < 0 {
+		return nil, err // Every operation succeeded but the commit did not
+	}
+	if err != nil {
+		for i := range results {
+			if i != failed {
+				results[i] = UserBatchResult{Err: domain.ErrBatchAborted}
+			}
+		}
+	}
+	return results, nil
+}
+
+// apply runs one operation. Arguments are checked here so that a malformed
+// operation is reported as invalid rather than as an internal error.
+func (uc *userBatchInteractor) apply(ctx context.Context, op UserBatchOperation) (*domain.User, error) {
+	switch op.Op {
+	case BatchOpCreate:
+		if op.Name == nil || *op.Name == "" || op.Email == nil || *op.Email == "" || op.Password == nil || *op.Password == "" {
+			return nil, fmt.Errorf("%w: create needs name, email and password", domain.ErrInvalidArgument)
+		}
+		return uc.users.CreateNewUser(ctx, *op.Name, *op.Email, *op.Password)
+	case BatchOpPatch:
+		if err := validateBatchUserID(op.ID); err != nil {
+			return nil, err
+		}
+		if op.Name == nil && op.Email == nil && op.Password == nil {
+			return nil, fmt.Errorf("%w: patch needs at least one of name, email and password", domain.ErrInvalidArgument)
+		}
+		if op.Password != nil && *op.Password == "" {
+			return nil, fmt.Errorf("%w: password must not be empty", domain.ErrInvalidArgument)
+		}
+		return uc.users.UpdateExistingUser(ctx, op.ID, op.Name, op.Email, op.Password)
+	case BatchOpDelete:
+		if err := validateBatchUserID(op.ID); err != nil {
+			return nil, err
+		}
+		return nil, uc.users.RemoveUser(ctx, op.ID)
+	}
+	return nil, fmt.Errorf("%w: op must be create, patch or delete", domain.ErrInvalidArgument)
+}
+
+func validateBatchUserID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return fmt.Errorf("%w: id must be a user ID", domain.ErrInvalidArgument)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"testing"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestUserBatchInteractor(repo *mocks.MockUserRepository, tx *mocks.InlineTxManager, maxOps int) UserBatchInteractor {
+	return NewUserBatchInteractor(newTestUserInteractor(repo), tx, UserBatchSettings{MaxOperations: maxOps})
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestUserBatchInteractor_BestEffort(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	tx := new(mocks.InlineTxManager)
+	uc := newTestUserBatchInteractor(mockRepo, tx, 10)
+	missing, existing := uuid.NewString(), uuid.NewString()
+
+	mockRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User"), mock.AnythingOfType("string")).
+		Return(&domain.User{ID: "new-id", Name: "Alice", Email: "alice@example.com"}, nil).Once()
+	mockRepo.On("GetUserByID", mock.Anything, missing).Return(nil, nil).Once()
+	mockRepo.On("GetUserByID", mock.Anything, existing).Return(&domain.User{ID: existing}, nil).Once()
+	mockRepo.On("DeleteUser", mock.Anything, existing).Return(nil).Once()
+
+	results, err := uc.ExecuteUserBatch(sessionContext("admin-1", domain.RoleAdmin, true), []UserBatchOperation{
+		{Op: BatchOpCreate, Name: strPtr("Alice"), Email: strPtr("alice@example.com"), Password: strPtr("secret")},
+		{Op: BatchOpPatch, ID: missing, Name: strPtr("Bob")},
+		{Op: BatchOpDelete, ID: existing},
+		{Op: "rename", ID: existing},
+	}, false)
+
+	assert.NoError(t, err)
+	if assert.Len(t, results, 4) {
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, "new-id", results[0].User.ID)
+		assert.ErrorIs(t, results[1].Err, domain.ErrNotFound)
+		assert.NoError(t, results[2].Err)
+		assert.ErrorIs(t, results[3].Err, domain.ErrInvalidArgument)
+	}
+	assert.Zero(t, tx.Calls, "best-effort operations do not share a transaction")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserBatchInteractor_Atomic_RollsBackOnFailure(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	tx := new(mocks.InlineTxManager)
+	uc := newTestUserBatchInteractor(mockRepo, tx, 10)
+	missing, untouched := uuid.NewString(), uuid.NewString()
+
+	mockRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User"), mock.AnythingOfType("string")).
+		Return(&domain.User{ID: "new-id"}, nil).Once()
+	mockRepo.On("GetUserByID", mock.Anything, missing).Return(nil, nil).Once()
+
+	results, err := uc.ExecuteUserBatch(sessionContext("admin-1", domain.RoleAdmin, true), []UserBatchOperation{
+		{Op: BatchOpCreate, Name: strPtr("Alice"), Email: strPtr("alice@example.com"), Password: strPtr("secret")},
+		{Op: BatchOpDelete, ID: missing},
+		{Op: BatchOpDelete, ID: untouched},
+	}, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tx.Calls)
+	if assert.Len(t, results, 3) {
+		assert.ErrorIs(t, results[0].Err, domain.ErrBatchAborted)
+		assert.Nil(t, results[0].User, "a rolled back create reports no user")
+		assert.ErrorIs(t, results[1].Err, domain.ErrNotFound)
+		assert.ErrorIs(t, results[2].Err, domain.ErrBatchAborted)
+	}
+	mockRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, untouched)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserBatchInteractor_Atomic_Success(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	tx := new(mocks.InlineTxManager)
+	uc := newTestUserBatchInteractor(mockRepo, tx, 10)
+	id := uuid.NewString()
+
+	mockRepo.On("GetUserByID", mock.Anything, id).Return(&domain.User{ID: id, Name: "Old"}, nil).Once()
+	mockRepo.On("UpdateUser", mock.Anything, id, &domain.User{Name: "New"}, (*string)(nil)).Return(&domain.User{ID: id, Name: "New"}, nil).Once()
+
+	results, err := uc.ExecuteUserBatch(sessionContext("admin-1", domain.RoleAdmin, true), []UserBatchOperation{{Op: BatchOpPatch, ID: id, Name: strPtr("New")}}, true)
+
+	assert.NoError(t, err)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "New", results[0].User.Name)
+	assert.Equal(t, 1, tx.Calls, "the batch runs in one transaction")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserBatchInteractor_InvalidBatch(t *testing.T) {
+	uc := newTestUserBatchInteractor(new(mocks.MockUserRepository), new(mocks.InlineTxManager), 2)
+	del := UserBatchOperation{Op: BatchOpDelete, ID: uuid.NewString()}
+
+	_, err := uc.ExecuteUserBatch(sessionContext("admin-1", domain.RoleAdmin, true), nil, false)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, err = uc.ExecuteUserBatch(sessionContext("admin-1", domain.RoleAdmin, true), []UserBatchOperation{del, del, del}, false)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	assert.Contains(t, err.Error(), "at most 2 operations")
+}
+
+func TestUserBatchInteractor_InvalidOperations(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	uc := newTestUserBatchInteractor(mockRepo, new(mocks.InlineTxManager), 10)
+
+	results, err := uc.ExecuteUserBatch(sessionContext("admin-1", domain.RoleAdmin, true), []UserBatchOperation{
+		{Op: BatchOpCreate, Name: strPtr("Alice")},
+		{Op: BatchOpPatch, ID: uuid.NewString()},
+		{Op: BatchOpPatch, ID: uuid.NewString(), Password: strPtr("")},
+		{Op: BatchOpDelete, ID: "not-a-uuid"},
+	}, false)
+
+	assert.NoError(t, err)
+	for i, r := range results {
+		assert.ErrorIs(t, r.Err, domain.ErrInvalidArgument, "operation %d", i)
+	}
+	mockRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"apiserver/internal/domain"
+)
+
+// EventFeed is the in-memory log of recently committed domain events that
+// user event subscriptions read from.
+type EventFeed interface {
+	// Since returns the events after seq and a channel that is closed when
+	// more arrive. ok is false when events after seq are no longer held.
+	Since(seq int64) (events []domain.OutboxMessage, wait <-chan struct{}, ok bool)
+	// Latest returns the sequence number of the newest event.
+	Latest() int64
+}
+
+// UserEventBatch is what a subscription has to deliver at one point in time.
+type UserEventBatch struct {
+	Events []domain.OutboxMessage // Matching events, oldest first
+	Reset  bool                   // Events were missed; the client should reload the users
+	Cursor int64                  // Sequence number the subscription has reached
+	Wait   <-chan struct{}        // Closed when more events may be available
+}
+
+// UserEventSubscription is a position in the stream of user events.
+type UserEventSubscription interface {
+	// Next returns the events after the current position and moves past them.
+	Next() UserEventBatch
+}
+
+// UserEventInteractor defines the interface for following user changes as
+// they are committed. Every mutation path in UserInteractor feeds it through
+// the outbox.
+type UserEventInteractor interface {
+	// SubscribeUserEvents starts a subscription after lastEventID, or after
+	// the newest event when it is zero. types restricts the event types; all
+	// user events are delivered when it is empty. Events cover users of every
+	// organization, so only administrators may subscribe.
+	SubscribeUserEvents(ctx context.Context, lastEventID int64, types []string) (UserEventSubscription, error)
+}
+
+// userEventInteractor implements UserEventInteractor.
+type userEventInteractor struct {
+	feed EventFeed
+}
+
+// NewUserEventInteractor creates a new instance of UserEventInteractor.
+func NewUserEventInteractor(feed EventFeed) UserEventInteractor {
+	return &userEventInteractor{feed: feed}
+}
+
+func (uc *userEventInteractor) SubscribeUserEvents(ctx context.Context, lastEventID int64, types []string) (UserEventSubscription, error) {
+	if _, err := requireDirectoryRead(ctx); err != nil {
+		return nil, err
+	}
+	if len(types) == 0 {
+		types = domain.UserEventTypes
+	}
+	wanted := make(map[string]bool, len(types))
+	for _, t := range types {
+		if !domain.IsUserEventType(t) {
+			return nil, fmt.Errorf("%w: unknown event type %q", domain.ErrInvalidArgument, t)
+		}
+		wanted[t] = true
+	}
+	if lastEventID <= 0 {
+		lastEventID = uc.feed.Latest()
+	}
+	return &userEventSubscription{feed: uc.feed, cursor: lastEventID, types: wanted}, nil
+}
+
+// userEventSubscription implements UserEventSubscription. It is not safe for
+// concurrent use.
+type userEventSubscription struct {
+	feed   EventFeed
+	cursor int64
+	types  map[string]bool
+}
+
+func (s *userEventSubscription) Next() UserEventBatch {
+	messages, wait, ok := s.feed.Since(s.cursor)
+	if !ok {
+		// Skip to the newest event; the client reloads instead of replaying
+		s.cursor = s.feed.Latest()
+		return UserEventBatch{Reset: true, Cursor: s.cursor, Wait: wait}
+	}
+
+	batch := UserEventBatch{Wait: wait}
+	for _, m := range messages {
+		if s.types[m.Event.Type] {
+			batch.Events = append(batch.Events, m)
+		}
+		s.cursor = m.Seq
+	}
+	batch.Cursor = s.cursor
+	return batch
+}
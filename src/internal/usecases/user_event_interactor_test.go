@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"apiserver/internal/auth"
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubFeed holds the events with sequence numbers above floor.
+type stubFeed struct {
+	events []domain.OutboxMessage
+	floor  int64
+	wait   chan struct{}
+}
+
+func (f *stubFeed) Since(seq int64) ([]domain.OutboxMessage, <-chan struct{}, bool) {
+	if seq < f.floor {
+		return nil, f.wait, false
+	}
+	var out []domain.OutboxMessage
+	for _, m := range f.events {
+		if m.Seq > seq {
+			out = append(out, m)
+		}
+	}
+	return out, f.wait, true
+}
+
+func (f *stubFeed) Latest() int64 {
+	if len(f.events) == 0 {
+		return f.floor
+	}
+	return f.events[len(f.events)-1].Seq
+}
+
+func newStubFeed(floor int64, types ...string) *stubFeed {
+	f := &stubFeed{floor: floor, wait: make(chan struct{})}
+	for i, t := range types {
+		f.events = append(f.events, domain.OutboxMessage{Seq: floor + int64(i) + 1, Event: domain.DomainEvent{Type: t}})
+	}
+	return f
+}
+
+func TestUserEventInteractor_SubscribeUserEvents_StartsAtNewest(t *testing.T) {
+	feed := newStubFeed(10, domain.EventUserCreated, domain.EventUserUpdated)
+	sub, err := NewUserEventInteractor(feed).SubscribeUserEvents(sessionContext("admin-1", domain.RoleAdmin, false), 0, nil)
+	assert.NoError(t, err)
+
+	batch := sub.Next()
+	assert.Empty(t, batch.Events)
+	assert.False(t, batch.Reset)
+	assert.Equal(t, int64(12), batch.Cursor)
+
+	feed.events = append(feed.events, domain.OutboxMessage{Seq: 13, Event: domain.DomainEvent{Type: domain.EventUserDeleted}})
+	batch = sub.Next()
+	assert.Len(t, batch.Events, 1)
+	assert.Equal(t, int64(13), batch.Cursor)
+}
+
+func TestUserEventInteractor_SubscribeUserEvents_ResumesAndFilters(t *testing.T) {
+	feed := newStubFeed(10, domain.EventUserCreated, domain.EventUserUpdated, "organization.created", domain.EventUserCreated)
+	sub, err := NewUserEventInteractor(feed).SubscribeUserEvents(sessionContext("admin-1", domain.RoleAdmin, false), 11, []string{domain.EventUserCreated})
+	assert.NoError(t, err)
+
+	batch := sub.Next()
+	assert.Len(t, batch.Events, 1)
+	assert.Equal(t, int64(14), batch.Events[0].Seq)
+	// Skipped events still move the cursor
+	assert.Equal(t, int64(14), batch.Cursor)
+	assert.Empty(t, sub.Next().Events)
+}
+
+func TestUserEventInteractor_SubscribeUserEvents_ResetsWhenEventsWereDropped(t *testing.T) {
+	feed := newStubFeed(10, domain.EventUserCreated)
+	sub, err := NewUserEventInteractor(feed).SubscribeUserEvents(sessionContext("admin-1", domain.RoleAdmin, false), 5, nil)
+	assert.NoError(t, err)
+
+	batch := sub.Next()
+	assert.True(t, batch.Reset)
+	assert.Empty(t, batch.Events)
+	assert.Equal(t, int64(11), batch.Cursor)
+	assert.False(t, sub.Next().Reset)
+}
+
+func TestUserEventInteractor_SubscribeUserEvents_Rejected(t *testing.T) {
+	interactor := NewUserEventInteractor(newStubFeed(0))
+	writeOnly := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "svc-1", APIKeyID: "key-1", Scopes: []string{domain.ScopeUsersWrite}})
+
+	tests := map[string]struct {
+		ctx     context.Context
+		types   []string
+		wantErr error
+	}{
+		"anonymous":             {context.Background(), nil, domain.ErrUnauthenticated},
+		"api key without scope": {writeOnly, nil, domain.ErrForbidden},
+		"not an admin":          {sessionContext("user-1", domain.RoleUser, false), nil, domain.ErrForbidden},
+		"unknown event type":    {sessionContext("admin-1", domain.RoleAdmin, false), []string{"user.exploded"}, domain.ErrInvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := interactor.SubscribeUserEvents(tt.ctx, 0, tt.types)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+
+	"apiserver/internal/domain"
+	"github.com/google/uuid"
+)
+
+// emitUserEvent stores a user lifecycle event in the outbox. It must run
+// inside the mutation's transaction so the event is published if and only if
+// the change is committed.
+func (uc *userInteractor) emitUserEvent(ctx context.Context, eventType string, payload domain.UserEventPayload) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return uc.outbox.AppendOutboxEvent(ctx, domain.DomainEvent{
+		ID:          uuid.NewString(),
+		Type:        eventType,
+		AggregateID: payload.ID,
+		Payload:     b,
+		OccurredAt:  uc.clock.Now(),
+	})
+}
+
+// userEventPayload builds the event payload for u.
+func userEventPayload(u *domain.User) domain.UserEventPayload {
+	return domain.UserEventPayload{ID: u.ID, Name: u.Name, Email: u.Email, Role: u.Role}
+}
+
+// changedFields returns the names of the fields in changes.
+func changedFields(changes []domain.FieldChange) []string {
+	fields := make([]string, len(changes))
+	for i, c := range changes {
+		fields[i] = c.Field
+	}
+	return fields
+}
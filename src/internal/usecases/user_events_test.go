@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"apiserver/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// captureOutbox records the events appended to env's outbox.
+func captureOutbox(env *userAuditTestEnv, err error) *[]domain.DomainEvent {
+	var events []domain.DomainEvent
+	env.outbox.On("AppendOutboxEvent", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		events = append(events, args.Get(1).(domain.DomainEvent))
+	}).Return(err)
+	return &events
+}
+
+func decodeUserPayload(t *testing.T, event domain.DomainEvent) domain.UserEventPayload {
+	var payload domain.UserEventPayload
+	assert.NoError(t, json.Unmarshal(event.Payload, &payload))
+	return payload
+}
+
+func TestUserInteractor_CreateNewUser_EmitsEvent(t *testing.T) {
+	env := setupUserAuditTestEnv()
+	events := captureOutbox(env, nil)
+	env.userRepo.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).Return(&domain.User{ID: "user-1", Name: "Alice", Email: "alice@example.com", Role: domain.RoleUser, Password: "hash"}, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := env.interactor.CreateNewUser(context.Background(), "Alice", "alice@example.com", "password123")
+
+	assert.NoError(t, err)
+	if assert.Len(t, *events, 1) {
+		event := (*events)[0]
+		assert.NotEmpty(t, event.ID)
+		assert.Equal(t, domain.EventUserCreated, event.Type)
+		assert.Equal(t, "user-1", event.AggregateID)
+		assert.Equal(t, env.clock.Now(), event.OccurredAt)
+		assert.Equal(t, domain.UserEventPayload{ID: "user-1", Name: "Alice", Email: "alice@example.com", Role: domain.RoleUser}, decodeUserPayload(t, event))
+		assert.NotContains(t, string(event.Payload), "hash")
+	}
+}
+
+func TestUserInteractor_UpdateExistingUser_EmitsChangedFields(t *testing.T) {
+	env := setupUserAuditTestEnv()
+	events := captureOutbox(env, nil)
+	newEmail, newPassword := "new@example.com", "newPassword123"
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", Name: "Alice", Email: "old@example.com"}, nil).Once()
+	env.userRepo.On("UpdateUser", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(&domain.User{ID: "user-1", Name: "Alice", Email: newEmail}, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := env.interactor.UpdateExistingUser(sessionContext("admin-1", domain.RoleAdmin, true), "user-1", nil, &newEmail, &newPassword)
+
+	assert.NoError(t, err)
+	if assert.Len(t, *events, 1) {
+		assert.Equal(t, domain.EventUserUpdated, (*events)[0].Type)
+		payload := decodeUserPayload(t, (*events)[0])
+		assert.Equal(t, newEmail, payload.Email)
+		assert.Equal(t, []string{"email", "password"}, payload.ChangedFields)
+	}
+}
+
+func TestUserInteractor_RemoveUser_EmitsEvent(t *testing.T) {
+	env := setupUserAuditTestEnv()
+	events := captureOutbox(env, nil)
+	env.userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", Name: "Alice", Email: "alice@example.com"}, nil).Once()
+	env.userRepo.On("DeleteUser", mock.Anything, "user-1").Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+
+	assert.NoError(t, env.interactor.RemoveUser(sessionContext("admin-1", domain.RoleAdmin, true), "user-1"))
+
+	if assert.Len(t, *events, 1) {
+		assert.Equal(t, domain.EventUserDeleted, (*events)[0].Type)
+		assert.Equal(t, domain.UserEventPayload{ID: "user-1"}, decodeUserPayload(t, (*events)[0]))
+	}
+}
+
+func TestUserInteractor_CreateNewUser_OutboxFailureFailsTheMutation(t *testing.T) {
+	env := setupUserAuditTestEnv()
+	outboxErr := errors.New("outbox insert failed")
+	captureOutbox(env, outboxErr)
+	env.userRepo.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).Return(&domain.User{ID: "user-1", Name: "Alice", Email: "alice@example.com"}, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := env.interactor.CreateNewUser(context.Background(), "Alice", "alice@example.com", "password123")
+
+	// The error propagates out of the transaction so the insert is rolled back
+	assert.ErrorIs(t, err, outboxErr)
+}
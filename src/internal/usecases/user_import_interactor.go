@@ -0,0 +1,335 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/password"
+	"apiserver/internal/repositories"
+)
+
+// UserImportSettings tunes user imports.
+type UserImportSettings struct {
+	ChunkSize   int    // Rows inserted per statement and transaction
+	HashWorkers int    // Passwords hashed in parallel
+	SpoolDir    string // Where background imports keep their upload; empty for the OS default
+}
+
+// DefaultUserImportSettings returns the settings used when none are configured.
+func DefaultUserImportSettings() UserImportSettings {
+	return UserImportSettings{
+		ChunkSize:   500,
+		HashWorkers: runtime.GOMAXPROCS(0),
+	}
+}
+
+// UserImportInteractor defines the interface for importing users in bulk.
+// Imports are limited to administrators.
+type UserImportInteractor interface {
+	// ImportUsers creates a user for every valid row of an upload in format
+	// and reports the outcome of each row. Rows are committed a chunk at a
+	// time, so an import that stops early keeps the rows before it.
+	ImportUsers(ctx context.Context, format string, upload io.Reader) (*domain.UserImportReport, error)
+	// StartUserImport saves the upload and imports it in the background.
+	StartUserImport(ctx context.Context, format string, upload io.Reader) (*domain.UserImportJob, error)
+	GetUserImport(ctx context.Context, id string) (*domain.UserImportJob, error)
+}
+
+// userImportInteractor implements UserImportInteractor. It shares the audit
+// and event helpers of userInteractor, so imported users are recorded exactly
+// like users created one at a time.
+type userImportInteractor struct {
+	userInteractor
+	imports  repositories.UserImportRepository
+	settings UserImportSettings
+	logger   *log.Logger
+}
+
+// NewUserImportInteractor creates a new instance of UserImportInteractor. Rows
+// whose password breaches reports are refused; it may be nil to accept any.
+func NewUserImportInteractor(users repositories.UserRepository, imports repositories.UserImportRepository, passwords password.Hasher, breaches password.BreachChecker, tx repositories.TxManager, auditor audit.Recorder, outbox repositories.OutboxRepository, clk clock.Clock, settings UserImportSettings, logger *log.Logger) UserImportInteractor {
+	if settings.ChunkSize <= 0 {
+		settings.ChunkSize = DefaultUserImportSettings().ChunkSize
+	}
+	return &userImportInteractor{
+		userInteractor: userInteractor{userRepo: users, passwords: passwords, breaches: breaches, tx: tx, auditor: auditor, outbox: outbox, clock: clk},
+		imports:        imports,
+		settings:       settings,
+		logger:         logger,
+	}
+}
+
+func (uc *userImportInteractor) ImportUsers(ctx context.Context, format string, upload io.Reader) (*domain.UserImportReport, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := newRowReader(format, upload)
+	if err != nil {
+		return nil, err
+	}
+	report := &domain.UserImportReport{}
+	uc.run(ctx, rows, report, nil)
+	return report, nil
+}
+
+func (uc *userImportInteractor) StartUserImport(ctx context.Context, format string, upload io.Reader) (*domain.UserImportJob, error) {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The request body is gone once the response is sent, so keep a copy
+	spool, err := os.CreateTemp(uc.settings.SpoolDir, "user-import-*")
+	if err != nil {
+		return nil, err
+	}
+	discard := func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}
+	if _, err := io.Copy(spool, upload); err != nil {
+		discard()
+		return nil, fmt.Errorf("%w: reading the upload: %v", domain.ErrInvalidArgument, err)
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		discard()
+		return nil, err
+	}
+	// Reject a bad header now rather than in the job
+	rows, err := newRowReader(format, spool)
+	if err != nil {
+		discard()
+		return nil, err
+	}
+
+	now := uc.clock.Now()
+	job := &domain.UserImportJob{
+		Format:    format,
+		Status:    domain.ImportStatusPending,
+		CreatedBy: principal.UserID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.imports.CreateUserImportJob(ctx, job); err != nil {
+		discard()
+		return nil, err
+	}
+	started := *job
+	// The job outlives the request but keeps its caller for the audit trail
+	go func() {
+		defer discard()
+		uc.runJob(context.WithoutCancel(ctx), job, rows)
+	}()
+	return &started, nil
+}
+
+func (uc *userImportInteractor) GetUserImport(ctx context.Context, id string) (*domain.UserImportJob, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	job, err := uc.imports.GetUserImportJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, domain.ErrUserImportNotFound
+	}
+	return job, nil
+}
+
+// runJob imports rows for job, storing its progress after every chunk.
+func (uc *userImportInteractor) runJob(ctx context.Context, job *domain.UserImportJob, rows rowReader) {
+	job.Status = domain.ImportStatusRunning
+	uc.saveJob(ctx, job)
+	uc.run(ctx, rows, &job.Report, func() { uc.saveJob(ctx, job) })
+
+	job.Status = domain.ImportStatusCompleted
+	if job.Report.Error != "" {
+		job.Status = domain.ImportStatusFailed
+	}
+	job.FinishedAt = uc.clock.Now()
+	uc.saveJob(ctx, job)
+}
+
+// saveJob stores job. A failure only loses progress information, so it is
+// logged rather than stopping the import.
+func (uc *userImportInteractor) saveJob(ctx context.Context, job *domain.UserImportJob) {
+	job.UpdatedAt = uc.clock.Now()
+	if err := uc.imports.UpdateUserImportJob(ctx, job); err != nil {
+		uc.logger.Printf("user import %s: saving progress: %v", job.ID, err)
+	}
+}
+
+// run imports rows chunk by chunk into report, calling progress after each
+// chunk when it is not nil. It stops at the first error reading the upload
+// or writing a chunk and records it in report.Error.
+func (uc *userImportInteractor) run(ctx context.Context, rows rowReader, report *domain.UserImportReport, progress func()) {
+	seen := make(map[string]bool) // Lower-cased emails of the rows accepted so far
+	chunk := make([]importRow, 0, uc.settings.ChunkSize)
+	for {
+		row, readErr := rows.next()
+		if readErr == nil {
+			chunk = append(chunk, row)
+		}
+		if len(chunk) > 0 && (len(chunk) == uc.settings.ChunkSize || readErr != nil) {
+			if err := uc.importChunk(ctx, chunk, seen, report); err != nil {
+				report.Error = fmt.Sprintf("import stopped at row %d: %v", chunk[0].row, err)
+				return
+			}
+			chunk = chunk[:0]
+			if progress != nil {
+				progress()
+			}
+		}
+		if readErr != nil {
+			if !errors.Is(readErr, io.EOF) {
+				report.Error = fmt.Sprintf("reading the upload: %v", readErr)
+			}
+			return
+		}
+	}
+}
+
+// importChunk validates chunk, creates its valid rows in one transaction and
+// appends the outcome of every row to report. Nothing is appended when the
+// transaction fails.
+func (uc *userImportInteractor) importChunk(ctx context.Context, chunk []importRow, seen map[string]bool, report *domain.UserImportReport) error {
+	results := make([]domain.UserImportRowResult, len(chunk))
+	var valid []int
+	for i, row := range chunk {
+		results[i] = domain.UserImportRowResult{Row: row.row, Email: row.email}
+		reason := validateImportRow(row, uc.passwords.MaxLength())
+		if reason == "" && seen[strings.ToLower(row.email)] {
+			reason = "email appears earlier in the upload"
+		}
+		if reason != "" {
+			results[i].Error = reason
+			continue
+		}
+		seen[strings.ToLower(row.email)] = true
+		valid = append(valid, i)
+	}
+
+	hashes, err := uc.hashPasswords(ctx, chunk, valid)
+	if err != nil {
+		return err
+	}
+	checked := valid[:0]
+	for _, i := range valid {
+		if hashes[i] == "" {
+			results[i].Error = "password has appeared in a data breach"
+			continue
+		}
+		checked = append(checked, i)
+	}
+	valid = checked
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		emails := make([]string, len(valid))
+		for k, i := range valid {
+			emails[k] = chunk[i].email
+		}
+		existing, err := uc.userRepo.ListExistingUserEmails(ctx, emails)
+		if err != nil {
+			return err
+		}
+		registered := make(map[string]bool, len(existing))
+		for _, e := range existing {
+			registered[strings.ToLower(e)] = true
+		}
+
+		var users []domain.User
+		var created []int
+		for _, i := range valid {
+			if registered[strings.ToLower(chunk[i].email)] {
+				results[i].Error = "email is already registered"
+				continue
+			}
+			role := chunk[i].role
+			if role == "" {
+				role = domain.RoleUser
+			}
+			users = append(users, domain.User{Name: chunk[i].name, Email: chunk[i].email, Password: hashes[i], Role: role})
+			created = append(created, i)
+		}
+		ids, err := uc.userRepo.CreateUsers(ctx, users)
+		if err != nil {
+			return err
+		}
+		for k, id := range ids {
+			users[k].ID = id
+			results[created[k]].UserID = id
+			if err := uc.recordUserChange(ctx, domain.AuditActionUserCreated, id, userChanges(nil, &users[k], true)); err != nil {
+				return err
+			}
+			if err := uc.emitUserEvent(ctx, domain.EventUserCreated, userEventPayload(&users[k])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		report.Total++
+		if r.Error != "" {
+			report.Failed++
+		} else {
+			report.Created++
+		}
+	}
+	report.Rows = append(report.Rows, results...)
+	return nil
+}
+
+// hashPasswords checks and hashes the passwords of the rows at indexes in
+// parallel and returns the hashes indexed like chunk. Rows whose password is
+// breached are left without a hash; any other failure is returned.
+func (uc *userImportInteractor) hashPasswords(ctx context.Context, chunk []importRow, indexes []int) ([]string, error) {
+	hashes := make([]string, len(chunk))
+	work := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for w := 0; w < max(uc.settings.HashWorkers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				h, err := uc.newPasswordHash(ctx, chunk[i].password)
+				if errors.Is(err, domain.ErrPasswordBreached) {
+					continue
+				}
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					continue
+				}
+				hashes[i] = h
+			}
+		}()
+	}
+	for _, i := range indexes {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+	return hashes, firstErr
+}
@@ -0,0 +1,249 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/password"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestUserImportInteractor(users *mocks.MockUserRepository, imports *mocks.MockUserImportRepository, chunkSize int) UserImportInteractor {
+	return newBreachCheckedUserImportInteractor(users, imports, chunkSize, nil)
+}
+
+func newBreachCheckedUserImportInteractor(users *mocks.MockUserRepository, imports *mocks.MockUserImportRepository, chunkSize int, breaches password.BreachChecker) UserImportInteractor {
+	auditor := new(auditmocks.MockRecorder)
+	auditor.On("Record", mock.Anything, mock.Anything).Return(nil).Maybe()
+	outbox := new(mocks.MockOutboxRepository)
+	outbox.On("AppendOutboxEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+	settings := UserImportSettings{ChunkSize: chunkSize, HashWorkers: 2}
+	return NewUserImportInteractor(users, imports, testPasswords, breaches, new(mocks.InlineTxManager), auditor, outbox, clock.Real(), settings, log.New(io.Discard, "", 0))
+}
+
+func importAdminContext() context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "admin-1", Role: domain.RoleAdmin, MFA: true})
+}
+
+// usersWithEmails matches a CreateUsers argument holding exactly emails, in order.
+func usersWithEmails(emails ...string) interface{} {
+	return mock.MatchedBy(func(users []domain.User) bool {
+		if len(users) != len(emails) {
+			return false
+		}
+		for i, u := range users {
+			if u.Email != emails[i] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestUserImportInteractor_ImportUsers_CSV(t *testing.T) {
+	users := new(mocks.MockUserRepository)
+	uc := newTestUserImportInteractor(users, new(mocks.MockUserImportRepository), 2)
+
+	upload := "\ufeffName,Email,Password,Role\n" +
+		"Alice,alice@example.com,secret-1,\n" +
+		"Bob,not-an-email,secret-2,\n" +
+		"Carol,carol@example.com,secret-3,admin\n" +
+		"Alice Again,ALICE@example.com,secret-4,\n" +
+		"Dave,dave@example.com,secret-5,\n"
+
+	// Chunks of two rows: [Alice, Bob], [Carol, Alice Again], [Dave]
+	users.On("ListExistingUserEmails", mock.Anything, []string{"alice@example.com"}).Return([]string{}, nil).Once()
+	users.On("CreateUsers", mock.Anything, usersWithEmails("alice@example.com")).Run(func(args mock.Arguments) {
+		u := args.Get(1).([]domain.User)[0]
+		assert.Equal(t, domain.RoleUser, u.Role)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(u.Password), []byte("secret-1")))
+	}).Return([]string{"id-alice"}, nil).Once()
+	users.On("ListExistingUserEmails", mock.Anything, []string{"carol@example.com"}).Return([]string{}, nil).Once()
+	users.On("CreateUsers", mock.Anything, usersWithEmails("carol@example.com")).Run(func(args mock.Arguments) {
+		assert.Equal(t, domain.RoleAdmin, args.Get(1).([]domain.User)[0].Role)
+	}).Return([]string{"id-carol"}, nil).Once()
+	users.On("ListExistingUserEmails", mock.Anything, []string{"dave@example.com"}).Return([]string{"Dave@example.com"}, nil).Once()
+	users.On("CreateUsers", mock.Anything, usersWithEmails()).Return(nil, nil).Once()
+
+	report, err := uc.ImportUsers(importAdminContext(), domain.ImportFormatCSV, strings.NewReader(upload))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 3, report.Failed)
+	assert.Empty(t, report.Error)
+	assert.Equal(t, []domain.UserImportRowResult{
+		{Row: 1, Email: "alice@example.com", UserID: "id-alice"},
+		{Row: 2, Email: "not-an-email", Error: "email is not a valid address"},
+		{Row: 3, Email: "carol@example.com", UserID: "id-carol"},
+		{Row: 4, Email: "ALICE@example.com", Error: "email appears earlier in the upload"},
+		{Row: 5, Email: "dave@example.com", Error: "email is already registered"},
+	}, report.Rows)
+	users.AssertExpectations(t)
+}
+
+func TestUserImportInteractor_ImportUsers_NDJSON(t *testing.T) {
+	users := new(mocks.MockUserRepository)
+	uc := newTestUserImportInteractor(users, new(mocks.MockUserImportRepository), 500)
+
+	upload := `{"name":"Alice","email":"alice@example.com","password":"secret-1"}` + "\n" +
+		"\n" +
+		`{"name":"Bob",` + "\n" +
+		`{"name":"Carol","email":"carol@example.com","password":"secret-3","role":"owner"}` + "\n"
+
+	users.On("ListExistingUserEmails", mock.Anything, []string{"alice@example.com"}).Return([]string{}, nil).Once()
+	users.On("CreateUsers", mock.Anything, usersWithEmails("alice@example.com")).Return([]string{"id-alice"}, nil).Once()
+
+	report, err := uc.ImportUsers(importAdminContext(), domain.ImportFormatNDJSON, strings.NewReader(upload))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, "id-alice", report.Rows[0].UserID)
+	assert.Contains(t, report.Rows[1].Error, "invalid JSON")
+	assert.Equal(t, "role must be user or admin", report.Rows[2].Error)
+	users.AssertExpectations(t)
+}
+
+func TestUserImportInteractor_ImportUsers_StopsAtFailedChunk(t *testing.T) {
+	users := new(mocks.MockUserRepository)
+	uc := newTestUserImportInteractor(users, new(mocks.MockUserImportRepository), 1)
+
+	upload := "name,email,password\n" +
+		"Alice,alice@example.com,secret-1\n" +
+		"Bob,bob@example.com,secret-2\n" +
+		"Carol,carol@example.com,secret-3\n"
+
+	users.On("ListExistingUserEmails", mock.Anything, mock.Anything).Return([]string{}, nil)
+	users.On("CreateUsers", mock.Anything, usersWithEmails("alice@example.com")).Return([]string{"id-alice"}, nil).Once()
+	users.On("CreateUsers", mock.Anything, usersWithEmails("bob@example.com")).Return(nil, errors.New("connection lost")).Once()
+
+	report, err := uc.ImportUsers(importAdminContext(), domain.ImportFormatCSV, strings.NewReader(upload))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, "import stopped at row 2: connection lost", report.Error)
+	users.AssertNotCalled(t, "CreateUsers", mock.Anything, usersWithEmails("carol@example.com"))
+}
+
+func TestUserImportInteractor_ImportUsers_BreachedPasswords(t *testing.T) {
+	users := new(mocks.MockUserRepository)
+	breaches := &breachList{passwords: map[string]bool{"password123": true}}
+	uc := newBreachCheckedUserImportInteractor(users, new(mocks.MockUserImportRepository), 500, breaches)
+
+	upload := "name,email,password\n" +
+		"Alice,alice@example.com,password123\n" +
+		"Bob,bob@example.com,a quiet brass lantern\n"
+
+	// The breached row fails on its own; the rest of the chunk is created
+	users.On("ListExistingUserEmails", mock.Anything, []string{"bob@example.com"}).Return([]string{}, nil).Once()
+	users.On("CreateUsers", mock.Anything, usersWithEmails("bob@example.com")).Return([]string{"id-bob"}, nil).Once()
+
+	report, err := uc.ImportUsers(importAdminContext(), domain.ImportFormatCSV, strings.NewReader(upload))
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.Error)
+	assert.Equal(t, []domain.UserImportRowResult{
+		{Row: 1, Email: "alice@example.com", Error: "password has appeared in a data breach"},
+		{Row: 2, Email: "bob@example.com", UserID: "id-bob"},
+	}, report.Rows)
+	assert.ElementsMatch(t, []string{"password123", "a quiet brass lantern"}, breaches.checked)
+	users.AssertExpectations(t)
+
+	// An unreachable checker stops the import instead of letting passwords through
+	breaches.err = errors.New("range API unavailable")
+	report, err = uc.ImportUsers(importAdminContext(), domain.ImportFormatCSV, strings.NewReader(upload))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.Total)
+	assert.Contains(t, report.Error, "range API unavailable")
+}
+
+func TestUserImportInteractor_ImportUsers_InvalidHeader(t *testing.T) {
+	users := new(mocks.MockUserRepository)
+	uc := newTestUserImportInteractor(users, new(mocks.MockUserImportRepository), 500)
+
+	_, err := uc.ImportUsers(importAdminContext(), domain.ImportFormatCSV, strings.NewReader("name,email\nAlice,alice@example.com\n"))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	assert.Contains(t, err.Error(), "password column")
+	users.AssertNotCalled(t, "CreateUsers", mock.Anything, mock.Anything)
+}
+
+func TestUserImportInteractor_ImportUsers_Forbidden(t *testing.T) {
+	users := new(mocks.MockUserRepository)
+	uc := newTestUserImportInteractor(users, new(mocks.MockUserImportRepository), 500)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "user-1", Role: domain.RoleUser, MFA: true})
+
+	_, err := uc.ImportUsers(ctx, domain.ImportFormatCSV, strings.NewReader("name,email,password\n"))
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestUserImportInteractor_StartUserImport(t *testing.T) {
+	users := new(mocks.MockUserRepository)
+	imports := new(mocks.MockUserImportRepository)
+	uc := newTestUserImportInteractor(users, imports, 500)
+
+	imports.On("CreateUserImportJob", mock.Anything, mock.AnythingOfType("*domain.UserImportJob")).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.UserImportJob).ID = "job-1"
+	}).Return(nil).Once()
+	finished := make(chan domain.UserImportJob, 1)
+	imports.On("UpdateUserImportJob", mock.Anything, mock.AnythingOfType("*domain.UserImportJob")).Run(func(args mock.Arguments) {
+		if job := args.Get(1).(*domain.UserImportJob); job.Finished() {
+			finished <- *job
+		}
+	}).Return(nil)
+	users.On("ListExistingUserEmails", mock.Anything, []string{"alice@example.com"}).Return([]string{}, nil).Once()
+	users.On("CreateUsers", mock.Anything, usersWithEmails("alice@example.com")).Return([]string{"id-alice"}, nil).Once()
+
+	job, err := uc.StartUserImport(importAdminContext(), domain.ImportFormatCSV, strings.NewReader("name,email,password\nAlice,alice@example.com,secret-1\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "job-1", job.ID)
+	assert.Equal(t, domain.ImportStatusPending, job.Status)
+	assert.Equal(t, "admin-1", job.CreatedBy)
+
+	select {
+	case done := <-finished:
+		assert.Equal(t, domain.ImportStatusCompleted, done.Status)
+		assert.Equal(t, 1, done.Report.Created)
+		assert.False(t, done.FinishedAt.IsZero())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the import job did not finish")
+	}
+	users.AssertExpectations(t)
+}
+
+func TestUserImportInteractor_StartUserImport_InvalidHeader(t *testing.T) {
+	imports := new(mocks.MockUserImportRepository)
+	uc := newTestUserImportInteractor(new(mocks.MockUserRepository), imports, 500)
+
+	_, err := uc.StartUserImport(importAdminContext(), domain.ImportFormatCSV, strings.NewReader("email\n"))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	imports.AssertNotCalled(t, "CreateUserImportJob", mock.Anything, mock.Anything)
+}
+
+func TestUserImportInteractor_GetUserImport_NotFound(t *testing.T) {
+	imports := new(mocks.MockUserImportRepository)
+	uc := newTestUserImportInteractor(new(mocks.MockUserRepository), imports, 500)
+	imports.On("GetUserImportJob", mock.Anything, "missing").Return(nil, nil).Once()
+
+	_, err := uc.GetUserImport(importAdminContext(), "missing")
+
+	assert.ErrorIs(t, err, domain.ErrUserImportNotFound)
+}
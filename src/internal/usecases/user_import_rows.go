@@ -0,0 +1,181 @@
+package usecases
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"apiserver/internal/domain"
+)
+
+// maxImportLineBytes bounds a single NDJSON line, so that a file without line
+// breaks cannot be buffered whole.
+const maxImportLineBytes = 1 << 20
+
+// importRow is one data row of an upload.
+type importRow struct {
+	row      int // 1-based position among the data rows
+	name     string
+	email    string
+	password string
+	role     string
+	err      error // Set when the row could not be parsed
+}
+
+// rowReader reads an upload one row at a time. next returns io.EOF after the
+// last row; any other error means the rest of the upload cannot be read.
+type rowReader interface {
+	next() (importRow, error)
+}
+
+// newRowReader returns a reader for upload in format. CSV uploads must start
+// with a header naming the name, email and password columns, and may have a
+// role column; other columns are ignored.
+func newRowReader(format string, upload io.Reader) (rowReader, error) {
+	switch format {
+	case domain.ImportFormatCSV:
+		return newCSVRowReader(upload)
+	case domain.ImportFormatNDJSON:
+		s := bufio.NewScanner(upload)
+		s.Buffer(make([]byte, 64<<10), maxImportLineBytes)
+		return &ndjsonRowReader{scanner: s}, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported import format %q", domain.ErrInvalidArgument, format)
+}
+
+type csvRowReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+func newCSVRowReader(upload io.Reader) (*csvRowReader, error) {
+	reader := csv.NewReader(upload)
+	reader.ReuseRecord = true
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: the upload is empty", domain.ErrInvalidArgument)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid CSV header: %v", domain.ErrInvalidArgument, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // Byte order mark written by spreadsheet software
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"name", "email", "password"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: the CSV header has no %s column", domain.ErrInvalidArgument, required)
+		}
+	}
+	return &csvRowReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvRowReader) next() (importRow, error) {
+	record, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return importRow{}, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if err != nil && !errors.As(err, &parseErr) {
+		return importRow{}, err
+	}
+	r.row++
+	if err != nil {
+		return importRow{row: r.row, err: parseErr.Err}, nil
+	}
+	return importRow{
+		row:      r.row,
+		name:     r.field(record, "name"),
+		email:    r.field(record, "email"),
+		password: r.field(record, "password"),
+		role:     r.field(record, "role"),
+	}, nil
+}
+
+// field returns the value of column in record, or "" when there is no such column.
+func (r *csvRowReader) field(record []string, column string) string {
+	i, ok := r.columns[column]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+type ndjsonRowReader struct {
+	scanner *bufio.Scanner
+	row     int
+}
+
+func (r *ndjsonRowReader) next() (importRow, error) {
+	for r.scanner.Scan() {
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		r.row++
+		var v struct {
+			Name     string `json:"name"`
+			Email    string `json:"email"`
+			Password string `json:"password"`
+			Role     string `json:"role"`
+		}
+		if err := json.Unmarshal(line, &v); err != nil {
+			return importRow{row: r.row, err: fmt.Errorf("invalid JSON: %v", err)}, nil
+		}
+		return importRow{
+			row:      r.row,
+			name:     strings.TrimSpace(v.Name),
+			email:    strings.TrimSpace(v.Email),
+			password: v.Password,
+			role:     strings.TrimSpace(v.Role),
+		}, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return importRow{}, fmt.Errorf("row %d is longer than %d bytes", r.row+1, maxImportLineBytes)
+		}
+		return importRow{}, err
+	}
+	return importRow{}, io.EOF
+}
+
+// validateImportRow returns why row cannot be imported, or "" when it can.
+// maxPasswordLength is the longest password the hasher accepts.
+func validateImportRow(row importRow, maxPasswordLength int) string {
+	if row.err != nil {
+		return row.err.Error()
+	}
+	switch {
+	case row.name == "":
+		return "name is required"
+	case utf8.RuneCountInString(row.name) > 255:
+		return "name must be at most 255 characters"
+	case row.email == "":
+		return "email is required"
+	case len(row.email) > 255:
+		return "email must be at most 255 characters"
+	case row.password == "":
+		return "password is required"
+	case len(row.password) > maxPasswordLength:
+		return fmt.Sprintf("password must be at most %d bytes", maxPasswordLength)
+	case row.role != "" && row.role != domain.RoleUser && row.role != domain.RoleAdmin:
+		return "role must be user or admin"
+	}
+	if addr, err := mail.ParseAddress(row.email); err != nil || addr.Address != row.email {
+		return "email is not a valid address"
+	}
+	return ""
+}
@@ -3,41 +3,79 @@ package usecases
 import (
 	"context"
 	"errors" // For standard errors
+	"fmt"
 
+	"apiserver/internal/audit"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
 	"apiserver/internal/domain"
 	"apiserver/internal/repositories"
-	"golang.org/x/crypto/bcrypt"
+	"apiserver/internal/password"
+	"apiserver/internal/storage"
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultUserPageSize is used when the caller does not ask for a page size.
+	defaultUserPageSize = 20
+	// maxUserPageSize caps a single page of users.
+	maxUserPageSize = 100
 )
 
 // UserInteractor defines the interface for user-related business logic.
+// Reads of the global directory, which spans every organization, are limited
+// to administrators; users may still read their own record. The same holds for
+// updates and removals.
 type UserInteractor interface {
 	CreateNewUser(ctx context.Context, name, email, plainPassword string) (*domain.User, error)
 	FindUserByID(ctx context.Context, id string) (*domain.User, error)
-	GetAllUsers(ctx context.Context) ([]domain.User, error)
+	GetAllUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error)
+	// ExportUsers calls fn for every user matching filter, in ID order, without
+	// loading them all into memory. Exports are limited to administrators.
+	ExportUsers(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error
+	// ListUsersPage returns up to limit users in ID order after the user afterID.
+	ListUsersPage(ctx context.Context, afterID string, limit int) (*domain.UserPage, error)
 	UpdateExistingUser(ctx context.Context, id string, name, email *string, plainPassword *string) (*domain.User, error)
+	// PatchUser applies a partial update. Preferences and attributes are
+	// validated, attributes against the administrator-defined definitions.
+	PatchUser(ctx context.Context, id string, patch domain.UserPatch) (*domain.User, error)
 	RemoveUser(ctx context.Context, id string) error
 }
 
 // userInteractor implements UserInteractor.
+// Every mutation is recorded in the audit log and emits a domain event to the
+// outbox within the same transaction.
 type userInteractor struct {
-	userRepo repositories.UserRepository
+	userRepo  repositories.UserRepository
+	attrRepo  repositories.UserAttributeRepository
+	passwords password.Hasher
+	breaches  password.BreachChecker // Optional
+	store     storage.BlobStore
+	tx        repositories.TxManager
+	auditor   audit.Recorder
+	outbox    repositories.OutboxRepository
+	clock     clock.Clock
 }
 
-// NewUserInteractor creates a new instance of UserInteractor.
-func NewUserInteractor(repo repositories.UserRepository) UserInteractor {
-	return &userInteractor{userRepo: repo}
+// NewUserInteractor creates a new instance of UserInteractor. New passwords
+// are refused when breaches reports them; it may be nil to accept any. The
+// avatars of removed users are deleted from store.
+func NewUserInteractor(repo repositories.UserRepository, attrRepo repositories.UserAttributeRepository, passwords password.Hasher, breaches password.BreachChecker, store storage.BlobStore, tx repositories.TxManager, auditor audit.Recorder, outbox repositories.OutboxRepository, clk clock.Clock) UserInteractor {
+	return &userInteractor{userRepo: repo, attrRepo: attrRepo, passwords: passwords, breaches: breaches, store: store, tx: tx, auditor: auditor, outbox: outbox, clock: clk}
 }
 
 func (uc *userInteractor) CreateNewUser(ctx context.Context, name, email, plainPassword string) (*domain.User, error) {
+	if err := checkScope(ctx, domain.ScopeUsersWrite); err != nil {
+		return nil, err
+	}
 	if name == "" || email == "" || plainPassword == "" {
 		return nil, errors.New("name, email, and password are required") // Basic validation
 	}
 
-	hashedPasswordBytes, err := bcrypt.GenerateFromPassword([]byte(plainPassword), bcrypt.DefaultCost)
+	hashedPassword, err := uc.newPasswordHash(ctx, plainPassword)
 	if err != nil {
 		return nil, err
 	}
-	hashedPassword := string(hashedPasswordBytes)
 
 	user := &domain.User{
 		Name:  name,
@@ -45,21 +83,116 @@ func (uc *userInteractor) CreateNewUser(ctx context.Context, name, email, plainP
 		// ID, CreatedAt, UpdatedAt will be handled by repository/DB
 	}
 
-	return uc.userRepo.CreateUser(ctx, user, hashedPassword)
+	var created *domain.User
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = uc.userRepo.CreateUser(ctx, user, hashedPassword)
+		if err != nil {
+			return err
+		}
+		if err := uc.recordUserChange(ctx, domain.AuditActionUserCreated, created.ID, userChanges(nil, created, true)); err != nil {
+			return err
+		}
+		return uc.emitUserEvent(ctx, domain.EventUserCreated, userEventPayload(created))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
 }
 
 func (uc *userInteractor) FindUserByID(ctx context.Context, id string) (*domain.User, error) {
+	if err := checkScope(ctx, domain.ScopeUsersRead); err != nil {
+		return nil, err
+	}
 	if id == "" {
 		return nil, errors.New("user ID is required")
 	}
+	// Anyone may read their own record; other users are in the global directory
+	if p := auth.PrincipalFrom(ctx); p == nil || p.UserID != id {
+		if _, err := requireDirectoryRead(ctx); err != nil {
+			return nil, err
+		}
+	}
 	return uc.userRepo.GetUserByID(ctx, id)
 }
 
-func (uc *userInteractor) GetAllUsers(ctx context.Context) ([]domain.User, error) {
-	return uc.userRepo.ListUsers(ctx)
+func (uc *userInteractor) GetAllUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	if _, err := requireDirectoryRead(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateUserFilter(filter); err != nil {
+		return nil, err
+	}
+	return uc.userRepo.ListUsers(ctx, filter)
+}
+
+func (uc *userInteractor) ExportUsers(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error {
+	if _, err := requireAdmin(ctx); err != nil {
+		return err
+	}
+	if err := validateUserFilter(filter); err != nil {
+		return err
+	}
+	// Recorded up front, so that an export cut short is still on file
+	if err := uc.recordUserChange(ctx, domain.AuditActionUsersExported, "", nil); err != nil {
+		return err
+	}
+	return uc.userRepo.StreamUsers(ctx, filter, fn)
+}
+
+// validateUserFilter rejects filters that can never match.
+func validateUserFilter(filter domain.UserFilter) error {
+	if filter.Role != "" && filter.Role != domain.RoleUser && filter.Role != domain.RoleAdmin {
+		return fmt.Errorf("%w: role must be user or admin", domain.ErrInvalidArgument)
+	}
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedTo.After(filter.CreatedFrom) {
+		return fmt.Errorf("%w: created_to must be after created_from", domain.ErrInvalidArgument)
+	}
+	if filter.GroupID != "" {
+		if _, err := uuid.Parse(filter.GroupID); err != nil {
+			return fmt.Errorf("%w: group_id must be a UUID", domain.ErrInvalidArgument)
+		}
+	}
+	return nil
+}
+
+func (uc *userInteractor) ListUsersPage(ctx context.Context, afterID string, limit int) (*domain.UserPage, error) {
+	if _, err := requireDirectoryRead(ctx); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultUserPageSize
+	}
+	if limit > maxUserPageSize {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", domain.ErrInvalidArgument, maxUserPageSize)
+	}
+	if afterID != "" {
+		if _, err := uuid.Parse(afterID); err != nil {
+			return nil, fmt.Errorf("%w: invalid cursor", domain.ErrInvalidArgument)
+		}
+	}
+	// One extra row tells whether another page follows
+	users, err := uc.userRepo.ListUsersAfter(ctx, afterID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &domain.UserPage{Users: users}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		page.HasMore = true
+	}
+	return page, nil
 }
 
 func (uc *userInteractor) UpdateExistingUser(ctx context.Context, id string, name, email *string, plainPassword *string) (*domain.User, error) {
+	return uc.PatchUser(ctx, id, domain.UserPatch{Name: name, Email: email, Password: plainPassword})
+}
+
+func (uc *userInteractor) PatchUser(ctx context.Context, id string, patch domain.UserPatch) (*domain.User, error) {
+	if err := authorizeUserWrite(ctx, id); err != nil {
+		return nil, err
+	}
 	if id == "" {
 		return nil, errors.New("user ID is required for update")
 	}
@@ -67,26 +200,25 @@ func (uc *userInteractor) UpdateExistingUser(ctx context.Context, id string, nam
 	// Construct a domain.User for update, only setting fields if provided
 	// The repository's UpdateUser method is responsible for merging with existing data or handling partials.
 	updateData := &domain.User{} // Only pass non-nil fields to repo, or let repo handle merge
-	hasUpdate := false
-	if name != nil {
-		updateData.Name = *name
+	hasUpdate := patch.Preferences != nil || patch.Attributes != nil
+	if patch.Name != nil {
+		updateData.Name = *patch.Name
 		hasUpdate = true
 	}
-	if email != nil {
-		updateData.Email = *email
+	if patch.Email != nil {
+		updateData.Email = *patch.Email
 		hasUpdate = true
 	}
 
 	var newHashedPassword *string
-	if plainPassword != nil {
-		if *plainPassword == "" {
+	if patch.Password != nil {
+		if *patch.Password == "" {
 		    return nil, errors.New("password cannot be updated to empty string")
                 }
-		hashedPasswordBytes, err := bcrypt.GenerateFromPassword([]byte(*plainPassword), bcrypt.DefaultCost)
+		h, err := uc.newPasswordHash(ctx, *patch.Password)
 		if err != nil {
 			return nil, err
 		}
-		h := string(hashedPasswordBytes)
 		newHashedPassword = &h
 		hasUpdate = true
 	}
@@ -95,12 +227,84 @@ func (uc *userInteractor) UpdateExistingUser(ctx context.Context, id string, nam
 		return nil, errors.New("no update data provided") // Or fetch and return existing user
 	}
 
-	return uc.userRepo.UpdateUser(ctx, id, updateData, newHashedPassword)
+	var updated *domain.User
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := uc.userRepo.GetUserByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return domain.ErrNotFound
+		}
+		if !before.ErasedAt.IsZero() {
+			return fmt.Errorf("%w: user was erased", domain.ErrConflict)
+		}
+		// Preferences and attributes are saved whole, so start from the stored ones
+		updateData.Preferences = before.Preferences
+		updateData.Attributes = before.Attributes
+		if patch.Preferences != nil {
+			if updateData.Preferences, err = applyPreferencesPatch(before.Preferences, *patch.Preferences); err != nil {
+				return err
+			}
+		}
+		if patch.Attributes != nil {
+			defs, err := uc.attrRepo.ListAttributeDefinitions(ctx)
+			if err != nil {
+				return err
+			}
+			if updateData.Attributes, err = applyAttributesPatch(defs, before.Attributes, patch.Attributes); err != nil {
+				return err
+			}
+		}
+		updated, err = uc.userRepo.UpdateUser(ctx, id, updateData, newHashedPassword)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			return domain.ErrNotFound
+		}
+		changes := userChanges(before, updated, newHashedPassword != nil)
+		if err := uc.recordUserChange(ctx, domain.AuditActionUserUpdated, id, changes); err != nil {
+			return err
+		}
+		payload := userEventPayload(updated)
+		payload.ChangedFields = changedFields(changes)
+		return uc.emitUserEvent(ctx, domain.EventUserUpdated, payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
 }
 
 func (uc *userInteractor) RemoveUser(ctx context.Context, id string) error {
+	if err := authorizeUserWrite(ctx, id); err != nil {
+		return err
+	}
 	if id == "" {
 		return errors.New("user ID is required")
 	}
-	return uc.userRepo.DeleteUser(ctx, id)
+	// Blobs are not covered by the transaction; deleting them first leaves no
+	// orphaned avatar behind a removed user, and a failed removal can be retried
+	for _, size := range []int{avatarSize, avatarThumbnailSize} {
+		if err := uc.store.Delete(ctx, fmt.Sprintf("avatars/%s/%d.png", id, size)); err != nil {
+			return err
+		}
+	}
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := uc.userRepo.GetUserByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return domain.ErrNotFound
+		}
+		if err := uc.userRepo.DeleteUser(ctx, id); err != nil {
+			return err
+		}
+		if err := uc.recordUserChange(ctx, domain.AuditActionUserDeleted, id, userChanges(before, nil, false)); err != nil {
+			return err
+		}
+		return uc.emitUserEvent(ctx, domain.EventUserDeleted, domain.UserEventPayload{ID: id})
+	})
 }
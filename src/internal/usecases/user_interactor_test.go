@@ -3,19 +3,57 @@ package usecases
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
 	"apiserver/internal/domain"
 	"apiserver/internal/repositories/mocks" // Import the mock
+	"apiserver/internal/tenant"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// newTestUserInteractor wires the interactor with an inline transaction and an
+// audit recorder that accepts any event.
+func newTestUserInteractor(repo *mocks.MockUserRepository) UserInteractor {
+	auditor := new(auditmocks.MockRecorder)
+	auditor.On("Record", mock.Anything, mock.Anything).Return(nil).Maybe()
+	outbox := new(mocks.MockOutboxRepository)
+	outbox.On("AppendOutboxEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return NewUserInteractor(repo, new(mocks.MockUserAttributeRepository), testPasswords, nil, newMemoryBlobStore(), new(mocks.InlineTxManager), auditor, outbox, clock.Real())
+}
+
+// memoryBlobStore keeps objects in a map so tests can see what was deleted.
+type memoryBlobStore struct {
+	objects map[string][]byte
+}
+
+func newMemoryBlobStore() *memoryBlobStore {
+	return &memoryBlobStore{objects: map[string][]byte{}}
+}
+
+func (s *memoryBlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memoryBlobStore) Delete(ctx context.Context, key string) error {
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *memoryBlobStore) URL(key string) string {
+	return "https://cdn.example.com/" + key
+}
+
 func TestUserInteractor_CreateNewUser_Success(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	name := "Test User"
 	email := "test@example.com"
@@ -55,7 +93,7 @@ func TestUserInteractor_CreateNewUser_Error_Hashing(t *testing.T) {
 
 func TestUserInteractor_CreateNewUser_Error_Repo(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	name := "Test User"
 	email := "test@example.com"
@@ -74,23 +112,33 @@ func TestUserInteractor_CreateNewUser_Error_Repo(t *testing.T) {
 
 func TestUserInteractor_CreateNewUser_Error_Validation(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository) 
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	_, err := interactor.CreateNewUser(context.Background(), "", "test@example.com", "password123")
 	assert.Error(t, err)
 	assert.Equal(t, "name, email, and password are required", err.Error())
 }
 
+func TestUserInteractor_CreateNewUser_Error_PasswordTooLong(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	interactor := newTestUserInteractor(mockRepo)
+
+	// testPasswords uses bcrypt, which would ignore everything past 72 bytes
+	_, err := interactor.CreateNewUser(context.Background(), "Test User", "test@example.com", strings.Repeat("a", 73))
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestUserInteractor_FindUserByID_Success(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	userID := "test-id"
 	expectedUser := &domain.User{ID: userID, Name: "Found User", Email: "found@example.com"}
 
 	mockRepo.On("GetUserByID", mock.Anything, userID).Return(expectedUser, nil).Once()
 
-	user, err := interactor.FindUserByID(context.Background(), userID)
+	user, err := interactor.FindUserByID(sessionContext("admin-1", domain.RoleAdmin, false), userID)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedUser, user)
@@ -99,12 +147,12 @@ func TestUserInteractor_FindUserByID_Success(t *testing.T) {
 
 func TestUserInteractor_FindUserByID_NotFound(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	userID := "not-found-id"
 	mockRepo.On("GetUserByID", mock.Anything, userID).Return(nil, nil).Once() 
 
-	user, err := interactor.FindUserByID(context.Background(), userID)
+	user, err := interactor.FindUserByID(sessionContext("admin-1", domain.RoleAdmin, false), userID)
 
 	assert.NoError(t, err) 
 	assert.Nil(t, user)
@@ -113,13 +161,13 @@ func TestUserInteractor_FindUserByID_NotFound(t *testing.T) {
 
 func TestUserInteractor_FindUserByID_Error_Repo(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	userID := "test-id"
 	repoError := errors.New("repository error")
 	mockRepo.On("GetUserByID", mock.Anything, userID).Return(nil, repoError).Once()
 
-	_, err := interactor.FindUserByID(context.Background(), userID)
+	_, err := interactor.FindUserByID(sessionContext("admin-1", domain.RoleAdmin, false), userID)
 
 	assert.Error(t, err)
 	assert.Equal(t, repoError, err)
@@ -128,7 +176,7 @@ func TestUserInteractor_FindUserByID_Error_Repo(t *testing.T) {
 
 func TestUserInteractor_FindUserByID_Error_Validation(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	_, err := interactor.FindUserByID(context.Background(), "")
     assert.Error(t, err)
@@ -139,15 +187,15 @@ func TestUserInteractor_FindUserByID_Error_Validation(t *testing.T) {
 // Tests for GetAllUsers
 func TestUserInteractor_GetAllUsers_Success(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	expectedUsers := []domain.User{
 		{ID: "id1", Name: "User One", Email: "one@example.com"},
 		{ID: "id2", Name: "User Two", Email: "two@example.com"},
 	}
-	mockRepo.On("ListUsers", mock.Anything).Return(expectedUsers, nil).Once()
+	mockRepo.On("ListUsers", mock.Anything, domain.UserFilter{}).Return(expectedUsers, nil).Once()
 
-	users, err := interactor.GetAllUsers(context.Background())
+	users, err := interactor.GetAllUsers(sessionContext("admin-1", domain.RoleAdmin, false), domain.UserFilter{})
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedUsers, users)
@@ -156,31 +204,154 @@ func TestUserInteractor_GetAllUsers_Success(t *testing.T) {
 
 func TestUserInteractor_GetAllUsers_Error_Repo(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	repoError := errors.New("repository error")
-	mockRepo.On("ListUsers", mock.Anything).Return(nil, repoError).Once()
+	mockRepo.On("ListUsers", mock.Anything, domain.UserFilter{}).Return(nil, repoError).Once()
 
-	_, err := interactor.GetAllUsers(context.Background())
+	_, err := interactor.GetAllUsers(sessionContext("admin-1", domain.RoleAdmin, false), domain.UserFilter{})
 
 	assert.Error(t, err)
 	assert.Equal(t, repoError, err)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserInteractor_GetAllUsers_Filter(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	interactor := newTestUserInteractor(mockRepo)
+
+	filter := domain.UserFilter{Role: domain.RoleAdmin, CreatedFrom: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	mockRepo.On("ListUsers", mock.Anything, filter).Return([]domain.User{}, nil).Once()
+
+	_, err := interactor.GetAllUsers(sessionContext("admin-1", domain.RoleAdmin, false), filter)
+	assert.NoError(t, err)
+
+	_, err = interactor.GetAllUsers(sessionContext("admin-1", domain.RoleAdmin, false), domain.UserFilter{Role: "owner"})
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, err = interactor.GetAllUsers(sessionContext("admin-1", domain.RoleAdmin, false), domain.UserFilter{CreatedFrom: filter.CreatedFrom, CreatedTo: filter.CreatedFrom})
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tests for ExportUsers
+func TestUserInteractor_ExportUsers_Success(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	auditor := new(auditmocks.MockRecorder)
+	interactor := NewUserInteractor(mockRepo, new(mocks.MockUserAttributeRepository), testPasswords, nil, newMemoryBlobStore(), new(mocks.InlineTxManager), auditor, new(mocks.MockOutboxRepository), clock.Real())
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "admin-1", Role: domain.RoleAdmin, MFA: true})
+
+	filter := domain.UserFilter{Role: domain.RoleUser}
+	auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionUsersExported && e.ActorID == "admin-1"
+	})).Return(nil).Once()
+	mockRepo.On("StreamUsers", mock.Anything, filter).Return([]domain.User{{ID: "id1"}, {ID: "id2"}}, nil).Once()
+
+	var ids []string
+	err := interactor.ExportUsers(ctx, filter, func(u *domain.User) error {
+		ids = append(ids, u.ID)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id1", "id2"}, ids)
+	auditor.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserInteractor_ExportUsers_Forbidden(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	interactor := newTestUserInteractor(mockRepo)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "user-1", Role: domain.RoleUser, MFA: true})
+
+	err := interactor.ExportUsers(ctx, domain.UserFilter{}, func(*domain.User) error { return nil })
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "StreamUsers", mock.Anything, mock.Anything)
+}
+
+// Tests for ListUsersPage
+func TestUserInteractor_ListUsersPage_HasMore(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	interactor := newTestUserInteractor(mockRepo)
+
+	rows := []domain.User{{ID: "id1"}, {ID: "id2"}, {ID: "id3"}}
+	mockRepo.On("ListUsersAfter", mock.Anything, "", 3).Return(rows, nil).Once()
+
+	page, err := interactor.ListUsersPage(sessionContext("admin-1", domain.RoleAdmin, false), "", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, rows[:2], page.Users)
+	assert.True(t, page.HasMore)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserInteractor_ListUsersPage_LastPage(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	interactor := newTestUserInteractor(mockRepo)
+
+	after := "3f0e9a4c-1b2d-4c5e-8f70-9a1b2c3d4e5f"
+	rows := []domain.User{{ID: "id4"}}
+	mockRepo.On("ListUsersAfter", mock.Anything, after, defaultUserPageSize+1).Return(rows, nil).Once()
+
+	page, err := interactor.ListUsersPage(sessionContext("admin-1", domain.RoleAdmin, false), after, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, rows, page.Users)
+	assert.False(t, page.HasMore)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserInteractor_ListUsersPage_Error_Validation(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	interactor := newTestUserInteractor(mockRepo)
+
+	_, err := interactor.ListUsersPage(sessionContext("admin-1", domain.RoleAdmin, false), "", maxUserPageSize+1)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, err = interactor.ListUsersPage(sessionContext("admin-1", domain.RoleAdmin, false), "not-a-uuid", 10)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	mockRepo.AssertNotCalled(t, "ListUsersAfter", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserInteractor_DirectoryReads_RequireAdmin(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	interactor := newTestUserInteractor(mockRepo)
+	member := tenant.WithTenant(sessionContext("user-1", domain.RoleUser, false), &tenant.Tenant{OrganizationID: "org-1", Role: domain.OrgRoleOwner})
+
+	_, err := interactor.GetAllUsers(context.Background(), domain.UserFilter{})
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	// Even an organization owner only sees its users through the org-scoped endpoints
+	_, err = interactor.GetAllUsers(member, domain.UserFilter{})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = interactor.ListUsersPage(member, "", 10)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = interactor.FindUserByID(member, "user-2")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "ListUsersAfter", mock.Anything, mock.Anything, mock.Anything)
+
+	// Their own record stays readable
+	mockRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1"}, nil).Once()
+	user, err := interactor.FindUserByID(member, "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", user.ID)
+	mockRepo.AssertExpectations(t)
+}
+
 // Tests for UpdateExistingUser
 func TestUserInteractor_UpdateExistingUser_Success_AllFields(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	userID := "user-to-update"
 	newName := "Updated Name"
 	newEmail := "updated@example.com"
 	newPlainPassword := "newPassword123"
 
-	updatedDomainUser := &domain.User{Name: newName, Email: newEmail} // This is what's passed to repo (without ID)
 	expectedUserFromRepo := &domain.User{ID: userID, Name: newName, Email: newEmail} // This is what repo returns
 
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, Name: "Old Name", Email: "old@example.com"}, nil).Once()
 	mockRepo.On("UpdateUser", mock.Anything, userID, mock.MatchedBy(func(du *domain.User) bool {
 		return du.Name == newName && du.Email == newEmail
 	}), mock.AnythingOfType("*string")).Run(func(args mock.Arguments) {
@@ -190,7 +361,7 @@ func TestUserInteractor_UpdateExistingUser_Success_AllFields(t *testing.T) {
 		assert.NoError(t, err, "Password should be hashed correctly for update")
 	}).Return(expectedUserFromRepo, nil).Once()
 
-	user, err := interactor.UpdateExistingUser(context.Background(), userID, &newName, &newEmail, &newPlainPassword)
+	user, err := interactor.UpdateExistingUser(sessionContext("admin-1", domain.RoleAdmin, true), userID, &newName, &newEmail, &newPlainPassword)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedUserFromRepo, user)
@@ -199,19 +370,19 @@ func TestUserInteractor_UpdateExistingUser_Success_AllFields(t *testing.T) {
 
 func TestUserInteractor_UpdateExistingUser_Success_PartialUpdate_NameOnly(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	userID := "user-to-update"
 	newName := "Just Name Updated"
     
-	updatedDomainUser := &domain.User{Name: newName} // This is what's passed to repo (without ID)
 	expectedUserFromRepo := &domain.User{ID: userID, Name: newName, Email: "original@example.com"} 
 
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, Name: "Old Name", Email: "original@example.com"}, nil).Once()
 	mockRepo.On("UpdateUser", mock.Anything, userID, mock.MatchedBy(func(du *domain.User) bool {
 		return du.Name == newName && du.Email == "" // Email in updateData will be empty
 	}), (*string)(nil)).Return(expectedUserFromRepo, nil).Once() // No password update
 
-	user, err := interactor.UpdateExistingUser(context.Background(), userID, &newName, nil, nil)
+	user, err := interactor.UpdateExistingUser(sessionContext("admin-1", domain.RoleAdmin, true), userID, &newName, nil, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedUserFromRepo, user)
@@ -221,43 +392,57 @@ func TestUserInteractor_UpdateExistingUser_Success_PartialUpdate_NameOnly(t *tes
 
 func TestUserInteractor_UpdateExistingUser_Error_Validation_NoID(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 	someName := "name"
-	_, err := interactor.UpdateExistingUser(context.Background(), "", &someName, nil, nil)
+	_, err := interactor.UpdateExistingUser(sessionContext("admin-1", domain.RoleAdmin, true), "", &someName, nil, nil)
 	assert.Error(t, err)
 	assert.Equal(t, "user ID is required for update", err.Error())
 }
 
 func TestUserInteractor_UpdateExistingUser_Error_Validation_NoData(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
-	_, err := interactor.UpdateExistingUser(context.Background(), "some-id", nil, nil, nil)
+	interactor := newTestUserInteractor(mockRepo)
+	_, err := interactor.UpdateExistingUser(sessionContext("admin-1", domain.RoleAdmin, true), "some-id", nil, nil, nil)
 	assert.Error(t, err)
 	assert.Equal(t, "no update data provided", err.Error())
 }
 
 func TestUserInteractor_UpdateExistingUser_Error_Repo(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 	
 	userID := "user-to-update"
 	name := "name"
 	repoError := errors.New("repo update error")
 
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, Name: "Old Name"}, nil).Once()
 	mockRepo.On("UpdateUser", mock.Anything, userID, mock.AnythingOfType("*domain.User"), (*string)(nil)).Return(nil, repoError).Once()
 
-	_, err := interactor.UpdateExistingUser(context.Background(), userID, &name, nil, nil)
+	_, err := interactor.UpdateExistingUser(sessionContext("admin-1", domain.RoleAdmin, true), userID, &name, nil, nil)
 	assert.Error(t, err)
 	assert.Equal(t, repoError, err)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserInteractor_UpdateExistingUser_Error_Erased(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	interactor := newTestUserInteractor(mockRepo)
+
+	userID := "user-erased"
+	name := "name"
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, ErasedAt: time.Now()}, nil).Once()
+
+	_, err := interactor.UpdateExistingUser(sessionContext("admin-1", domain.RoleAdmin, true), userID, &name, nil, nil)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestUserInteractor_UpdateExistingUser_Error_PasswordEmpty(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 	userID := "user-to-update"
 	emptyPassword := ""
-	_, err := interactor.UpdateExistingUser(context.Background(), userID, nil, nil, &emptyPassword)
+	_, err := interactor.UpdateExistingUser(sessionContext("admin-1", domain.RoleAdmin, true), userID, nil, nil, &emptyPassword)
 	assert.Error(t, err)
 	assert.Equal(t, "password cannot be updated to empty string", err.Error())
 }
@@ -266,36 +451,112 @@ func TestUserInteractor_UpdateExistingUser_Error_PasswordEmpty(t *testing.T) {
 // Tests for RemoveUser
 func TestUserInteractor_RemoveUser_Success(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	userID := "user-to-delete"
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, Name: "Doomed"}, nil).Once()
 	mockRepo.On("DeleteUser", mock.Anything, userID).Return(nil).Once()
 
-	err := interactor.RemoveUser(context.Background(), userID)
+	err := interactor.RemoveUser(sessionContext("admin-1", domain.RoleAdmin, true), userID)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserInteractor_RemoveUser_DeletesAvatars(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	auditor := new(auditmocks.MockRecorder)
+	auditor.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+	outbox := new(mocks.MockOutboxRepository)
+	outbox.On("AppendOutboxEvent", mock.Anything, mock.Anything).Return(nil).Once()
+	store := newMemoryBlobStore()
+	interactor := NewUserInteractor(mockRepo, new(mocks.MockUserAttributeRepository), testPasswords, nil, store, new(mocks.InlineTxManager), auditor, outbox, clock.Real())
+	for _, key := range []string{"avatars/user-1/256.png", "avatars/user-1/64.png", "avatars/user-2/256.png"} {
+		assert.NoError(t, store.Put(context.Background(), key, []byte("png"), "image/png"))
+	}
+
+	mockRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", Name: "Doomed"}, nil).Once()
+	mockRepo.On("DeleteUser", mock.Anything, "user-1").Return(nil).Once()
+
+	err := interactor.RemoveUser(sessionContext("admin-1", domain.RoleAdmin, true), "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"avatars/user-2/256.png": []byte("png")}, store.objects)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestUserInteractor_RemoveUser_Error_Validation(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
-	err := interactor.RemoveUser(context.Background(), "")
+	err := interactor.RemoveUser(sessionContext("admin-1", domain.RoleAdmin, true), "")
 	assert.Error(t, err)
 	assert.Equal(t, "user ID is required", err.Error())
 }
 
 func TestUserInteractor_RemoveUser_Error_Repo(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	interactor := NewUserInteractor(mockRepo)
+	interactor := newTestUserInteractor(mockRepo)
 
 	userID := "user-to-delete"
 	repoError := errors.New("repo delete error")
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, Name: "Doomed"}, nil).Once()
 	mockRepo.On("DeleteUser", mock.Anything, userID).Return(repoError).Once()
 
-	err := interactor.RemoveUser(context.Background(), userID)
+	err := interactor.RemoveUser(sessionContext("admin-1", domain.RoleAdmin, true), userID)
 	assert.Error(t, err)
 	assert.Equal(t, repoError, err)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUserInteractor_Writes_RequireSelfSessionOrAdmin(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	interactor := newTestUserInteractor(mockRepo)
+	name := "Mallory"
+	member := sessionContext("user-1", domain.RoleUser, false)
+
+	_, err := interactor.UpdateExistingUser(context.Background(), "user-2", &name, nil, nil)
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	err = interactor.RemoveUser(context.Background(), "user-2")
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+
+	_, err = interactor.PatchUser(member, "user-2", domain.UserPatch{Name: &name})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	err = interactor.RemoveUser(member, "user-2")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	// Neither does an organization role, nor an API key for their own record
+	orgAdmin := tenant.WithTenant(member, &tenant.Tenant{OrganizationID: "org-1", Role: domain.OrgRoleOwner})
+	_, err = interactor.PatchUser(orgAdmin, "user-2", domain.UserPatch{Name: &name})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	ownKey := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "user-1", Role: domain.RoleUser, APIKeyID: "key-1", Scopes: []string{domain.ScopeUsersWrite}})
+	err = interactor.RemoveUser(ownKey, "user-1")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	// A grant for one user does not extend to another
+	_, err = interactor.UpdateExistingUser(withUserWriteGrant(member, "user-3"), "user-2", &name, nil, nil)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything)
+
+	// Their own record stays writable
+	mockRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", Name: "Alice"}, nil).Once()
+	mockRepo.On("UpdateUser", mock.Anything, "user-1", mock.Anything, (*string)(nil)).Return(&domain.User{ID: "user-1", Name: name}, nil).Once()
+	user, err := interactor.UpdateExistingUser(member, "user-1", &name, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, name, user.Name)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserInteractor_APIKeyScopes(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	interactor := newTestUserInteractor(mockRepo)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "svc-1", Role: domain.RoleAdmin, APIKeyID: "key-1", Scopes: []string{domain.ScopeUsersRead}})
+
+	mockRepo.On("ListUsers", mock.Anything, domain.UserFilter{}).Return([]domain.User{}, nil).Once()
+	_, err := interactor.GetAllUsers(ctx, domain.UserFilter{})
+	assert.NoError(t, err)
+
+	err = interactor.RemoveUser(ctx, "some-id")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
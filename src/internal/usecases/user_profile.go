@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"time"
+	_ "time/tzdata" // Time zones are validated without relying on the host's database
+
+	"apiserver/internal/domain"
+	"golang.org/x/text/language"
+)
+
+// attributeKeyPattern restricts attribute keys to names that are safe in
+// JSON paths and stable in the API.
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// maxAttributeStringLength caps a single string attribute value.
+const maxAttributeStringLength = 1000
+
+// applyPreferencesPatch returns prefs with patch applied, or an error wrapping
+// ErrInvalidArgument when a new value is not valid.
+func applyPreferencesPatch(prefs domain.UserPreferences, patch domain.PreferencesPatch) (domain.UserPreferences, error) {
+	if patch.Locale != nil {
+		locale := *patch.Locale
+		if locale != "" {
+			tag, err := language.Parse(locale)
+			if err != nil {
+				return prefs, fmt.Errorf("%w: locale must be a BCP 47 language tag", domain.ErrInvalidArgument)
+			}
+			locale = tag.String()
+		}
+		prefs.Locale = locale
+	}
+	if patch.Timezone != nil {
+		if tz := *patch.Timezone; tz != "" {
+			// LoadLocation also accepts "Local", which names no particular zone
+			if _, err := time.LoadLocation(tz); err != nil || tz == "Local" {
+				return prefs, fmt.Errorf("%w: timezone must be an IANA time zone name", domain.ErrInvalidArgument)
+			}
+		}
+		prefs.Timezone = *patch.Timezone
+	}
+	if patch.NotificationsEmail != nil {
+		prefs.Notifications.Email = *patch.NotificationsEmail
+	}
+	if patch.NotificationsDigest != nil {
+		switch *patch.NotificationsDigest {
+		case domain.DigestOff, domain.DigestDaily, domain.DigestWeekly:
+			prefs.Notifications.Digest = *patch.NotificationsDigest
+		default:
+			return prefs, fmt.Errorf("%w: notification digest must be one of off, daily, weekly", domain.ErrInvalidArgument)
+		}
+	}
+	return prefs, nil
+}
+
+// applyAttributesPatch merges patch into attrs and checks the result against
+// defs. Only the patched values are checked against their definitions, so
+// values stored before a definition changed are kept, but every required
+// attribute must be present afterwards. attrs is not modified.
+func applyAttributesPatch(defs []domain.AttributeDefinition, attrs, patch map[string]interface{}) (map[string]interface{}, error) {
+	byKey := make(map[string]*domain.AttributeDefinition, len(defs))
+	for i := range defs {
+		byKey[defs[i].Key] = &defs[i]
+	}
+
+	merged := make(map[string]interface{}, len(attrs)+len(patch))
+	for k, v := range attrs {
+		merged[k] = v
+	}
+	// Sorted so that the first error reported does not depend on map order
+	keys := make([]string, 0, len(patch))
+	for k := range patch {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		def, ok := byKey[k]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown attribute %q", domain.ErrInvalidArgument, k)
+		}
+		v := patch[k]
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		v, err := checkAttributeValue(def, v)
+		if err != nil {
+			return nil, err
+		}
+		merged[k] = v
+	}
+
+	for _, def := range defs {
+		if _, ok := merged[def.Key]; def.Required && !ok {
+			return nil, fmt.Errorf("%w: attribute %q is required", domain.ErrInvalidArgument, def.Key)
+		}
+	}
+	return merged, nil
+}
+
+// checkAttributeValue checks v against def and returns it in the form it is
+// stored: strings, float64 numbers or booleans, as decoded from JSON.
+func checkAttributeValue(def *domain.AttributeDefinition, v interface{}) (interface{}, error) {
+	invalid := func(want string) error {
+		return fmt.Errorf("%w: attribute %q must be %s", domain.ErrInvalidArgument, def.Key, want)
+	}
+	switch def.Type {
+	case domain.AttributeTypeString:
+		s, ok := v.(string)
+		if !ok {
+			return nil, invalid("a string")
+		}
+		if len(s) > maxAttributeStringLength {
+			return nil, fmt.Errorf("%w: attribute %q must not exceed %d bytes", domain.ErrInvalidArgument, def.Key, maxAttributeStringLength)
+		}
+		if len(def.Enum) > 0 && !slices.Contains(def.Enum, s) {
+			return nil, invalid(fmt.Sprintf("one of %v", def.Enum))
+		}
+		return s, nil
+	case domain.AttributeTypeNumber, domain.AttributeTypeInteger:
+		var f float64
+		switch n := v.(type) {
+		case float64:
+			f = n
+		case int:
+			f = float64(n)
+		default:
+			return nil, invalid("a number")
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, invalid("a finite number")
+		}
+		if def.Type == domain.AttributeTypeInteger && f != math.Trunc(f) {
+			return nil, invalid("an integer")
+		}
+		return f, nil
+	case domain.AttributeTypeBoolean:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, invalid("a boolean")
+		}
+		return b, nil
+	case domain.AttributeTypeDate:
+		s, ok := v.(string)
+		if !ok {
+			return nil, invalid("a date (YYYY-MM-DD)")
+		}
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return nil, invalid("a date (YYYY-MM-DD)")
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("attribute %q has unknown type %q", def.Key, def.Type)
+}
@@ -0,0 +1,169 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/auth"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPreferencesPatch(t *testing.T) {
+	prefs := domain.DefaultUserPreferences()
+	off := false
+
+	got, err := applyPreferencesPatch(prefs, domain.PreferencesPatch{
+		Locale:              strPtr("ja-jp"),
+		Timezone:            strPtr("Asia/Tokyo"),
+		NotificationsEmail:  &off,
+		NotificationsDigest: strPtr(domain.DigestWeekly),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserPreferences{
+		Locale:        "ja-JP",
+		Timezone:      "Asia/Tokyo",
+		Notifications: domain.NotificationPreferences{Email: false, Digest: domain.DigestWeekly},
+	}, got)
+
+	// Empty strings clear the locale and time zone
+	got, err = applyPreferencesPatch(got, domain.PreferencesPatch{Locale: strPtr(""), Timezone: strPtr("")})
+	require.NoError(t, err)
+	assert.Empty(t, got.Locale)
+	assert.Empty(t, got.Timezone)
+}
+
+func TestApplyPreferencesPatch_Invalid(t *testing.T) {
+	tests := map[string]domain.PreferencesPatch{
+		"locale":       {Locale: strPtr("not a locale")},
+		"timezone":     {Timezone: strPtr("Mars/Olympus")},
+		"local zone":   {Timezone: strPtr("Local")},
+		"digest value": {NotificationsDigest: strPtr("hourly")},
+	}
+	for name, patch := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := applyPreferencesPatch(domain.DefaultUserPreferences(), patch)
+			assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+		})
+	}
+}
+
+func testAttributeDefinitions() []domain.AttributeDefinition {
+	return []domain.AttributeDefinition{
+		{Key: "birthday", Type: domain.AttributeTypeDate},
+		{Key: "department", Type: domain.AttributeTypeString, Required: true, Enum: []string{"sales", "engineering"}},
+		{Key: "employee_number", Type: domain.AttributeTypeInteger},
+		{Key: "remote", Type: domain.AttributeTypeBoolean},
+		{Key: "score", Type: domain.AttributeTypeNumber},
+	}
+}
+
+func TestApplyAttributesPatch(t *testing.T) {
+	attrs := map[string]interface{}{"department": "sales", "remote": true}
+
+	got, err := applyAttributesPatch(testAttributeDefinitions(), attrs, map[string]interface{}{
+		"birthday":        "1990-04-01",
+		"employee_number": float64(42),
+		"score":           4.5,
+		"remote":          nil,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"department":      "sales",
+		"birthday":        "1990-04-01",
+		"employee_number": float64(42),
+		"score":           4.5,
+	}, got)
+	assert.Equal(t, true, attrs["remote"], "the stored attributes are not modified")
+}
+
+func TestApplyAttributesPatch_Invalid(t *testing.T) {
+	attrs := map[string]interface{}{"department": "sales"}
+	tests := map[string]map[string]interface{}{
+		"unknown key":       {"nickname": "bob"},
+		"not in enum":       {"department": "legal"},
+		"wrong type":        {"remote": "yes"},
+		"not an integer":    {"employee_number": 1.5},
+		"not a date":        {"birthday": "01/04/1990"},
+		"required removed":  {"department": nil},
+		"number as string":  {"score": "4.5"},
+		"integer as string": {"employee_number": "42"},
+	}
+	for name, patch := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := applyAttributesPatch(testAttributeDefinitions(), attrs, patch)
+			assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+		})
+	}
+}
+
+func TestApplyAttributesPatch_RequiredMissing(t *testing.T) {
+	_, err := applyAttributesPatch(testAttributeDefinitions(), nil, map[string]interface{}{"remote": true})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	assert.Contains(t, err.Error(), `"department" is required`)
+}
+
+func TestUserInteractor_PatchUser_PreferencesAndAttributes(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	attrRepo := new(mocks.MockUserAttributeRepository)
+	auditor := new(auditmocks.MockRecorder)
+	outbox := new(mocks.MockOutboxRepository)
+	interactor := NewUserInteractor(userRepo, attrRepo, testPasswords, nil, newMemoryBlobStore(), new(mocks.InlineTxManager), auditor, outbox, clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: "admin-1", Role: domain.RoleAdmin, MFA: true})
+
+	before := &domain.User{
+		ID:          "user-1",
+		Name:        "Alice",
+		Preferences: domain.DefaultUserPreferences(),
+		Attributes:  map[string]interface{}{"department": "sales"},
+	}
+	want := &domain.User{
+		Preferences: domain.UserPreferences{Timezone: "Asia/Tokyo", Notifications: before.Preferences.Notifications},
+		Attributes:  map[string]interface{}{"department": "engineering"},
+	}
+	after := &domain.User{ID: "user-1", Name: "Alice", Preferences: want.Preferences, Attributes: want.Attributes}
+	userRepo.On("GetUserByID", mock.Anything, "user-1").Return(before, nil).Once()
+	attrRepo.On("ListAttributeDefinitions", mock.Anything).Return(testAttributeDefinitions(), nil).Once()
+	userRepo.On("UpdateUser", mock.Anything, "user-1", want, (*string)(nil)).Return(after, nil).Once()
+	auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionUserUpdated && assert.ObjectsAreEqual([]domain.FieldChange{
+			{Field: "preferences.timezone", New: "Asia/Tokyo"},
+			{Field: "attributes.department", Old: domain.MaskedValue, New: domain.MaskedValue},
+		}, e.Changes)
+	})).Return(nil).Once()
+	outbox.On("AppendOutboxEvent", mock.Anything, mock.Anything).Return(nil).Once()
+
+	got, err := interactor.PatchUser(ctx, "user-1", domain.UserPatch{
+		Preferences: &domain.PreferencesPatch{Timezone: strPtr("Asia/Tokyo")},
+		Attributes:  map[string]interface{}{"department": "engineering"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, after, got)
+	userRepo.AssertExpectations(t)
+	auditor.AssertExpectations(t)
+}
+
+func TestUserInteractor_PatchUser_InvalidAttribute(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	attrRepo := new(mocks.MockUserAttributeRepository)
+	interactor := NewUserInteractor(userRepo, attrRepo, testPasswords, nil, newMemoryBlobStore(), new(mocks.InlineTxManager), new(auditmocks.MockRecorder), new(mocks.MockOutboxRepository), clock.Real())
+	userRepo.On("GetUserByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1"}, nil).Once()
+	attrRepo.On("ListAttributeDefinitions", mock.Anything).Return(testAttributeDefinitions(), nil).Once()
+
+	_, err := interactor.PatchUser(sessionContext("admin-1", domain.RoleAdmin, true), "user-1", domain.UserPatch{
+		Attributes: map[string]interface{}{"department": "legal"},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
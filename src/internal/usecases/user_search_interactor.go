@@ -0,0 +1,220 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories"
+)
+
+const (
+	// defaultUserSearchPageSize is used when the caller does not ask for a page size.
+	defaultUserSearchPageSize = 20
+	// maxUserSearchPageSize caps a single page of search hits.
+	maxUserSearchPageSize = 100
+	// maxUserSearchQueryLength caps the query, in characters.
+	maxUserSearchQueryLength = 100
+)
+
+// UserSearchSettings tunes user search.
+type UserSearchSettings struct {
+	// NgramTokenSize must match the MySQL ngram_token_size the full-text index
+	// was built with. Shorter queries cannot match it and search emails instead.
+	NgramTokenSize int
+}
+
+// DefaultUserSearchSettings returns the settings used when none are configured.
+func DefaultUserSearchSettings() UserSearchSettings {
+	return UserSearchSettings{NgramTokenSize: 2}
+}
+
+// UserSearchInteractor defines the interface for finding users by partial
+// name or email. It searches the global directory, so it is limited to
+// administrators.
+type UserSearchInteractor interface {
+	// SearchUsers returns up to limit users matching query, best match first,
+	// with the matched fragments of their names and emails. Queries holding an
+	// "@", queries too short for the full-text index and queries the index finds
+	// nothing for are matched against the start of emails instead. cursor is
+	// the NextCursor of the previous page, or empty for the first.
+	SearchUsers(ctx context.Context, query string, limit int, cursor string) (*domain.UserSearchPage, error)
+}
+
+// userSearchInteractor implements UserSearchInteractor.
+type userSearchInteractor struct {
+	userRepo repositories.UserRepository
+	settings UserSearchSettings
+}
+
+// NewUserSearchInteractor creates a new instance of UserSearchInteractor.
+func NewUserSearchInteractor(repo repositories.UserRepository, settings UserSearchSettings) UserSearchInteractor {
+	if settings.NgramTokenSize <= 0 {
+		settings.NgramTokenSize = DefaultUserSearchSettings().NgramTokenSize
+	}
+	return &userSearchInteractor{userRepo: repo, settings: settings}
+}
+
+func (uc *userSearchInteractor) SearchUsers(ctx context.Context, query string, limit int, cursor string) (*domain.UserSearchPage, error) {
+	if _, err := requireDirectoryRead(ctx); err != nil {
+		return nil, err
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: query is required", domain.ErrInvalidArgument)
+	}
+	if utf8.RuneCountInString(query) > maxUserSearchQueryLength {
+		return nil, fmt.Errorf("%w: query must not exceed %d characters", domain.ErrInvalidArgument, maxUserSearchQueryLength)
+	}
+	if limit <= 0 {
+		limit = defaultUserSearchPageSize
+	}
+	if limit > maxUserSearchPageSize {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", domain.ErrInvalidArgument, maxUserSearchPageSize)
+	}
+
+	mode, offset := uc.searchMode(query), 0
+	if cursor != "" {
+		var err error
+		if mode, offset, err = decodeSearchCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	// One extra hit tells whether another page follows
+	hits, err := uc.search(ctx, mode, query, limit+1, offset)
+	if err != nil {
+		return nil, err
+	}
+	// Later pages keep the mode of the first, which the cursor carries
+	if len(hits) == 0 && mode == domain.UserSearchFullText && cursor == "" {
+		mode = domain.UserSearchEmailPrefix
+		if hits, err = uc.search(ctx, mode, query, limit+1, offset); err != nil {
+			return nil, err
+		}
+	}
+
+	page := &domain.UserSearchPage{Hits: hits, Mode: mode}
+	if len(hits) > limit {
+		page.Hits = hits[:limit]
+		page.NextCursor = encodeSearchCursor(mode, offset+limit)
+	}
+	terms := strings.Fields(query)
+	for i := range page.Hits {
+		hit := &page.Hits[i]
+		if mode == domain.UserSearchEmailPrefix {
+			// Only the start of the email was matched
+			end := min(utf8.RuneCountInString(query), utf8.RuneCountInString(hit.User.Email))
+			hit.EmailMatches = []domain.TextRange{{Start: 0, End: end}}
+			continue
+		}
+		hit.NameMatches = highlightMatches(hit.User.Name, terms, uc.settings.NgramTokenSize)
+		hit.EmailMatches = highlightMatches(hit.User.Email, terms, uc.settings.NgramTokenSize)
+	}
+	return page, nil
+}
+
+// searchMode picks how a first page is searched. The ngram parser splits
+// emails at "@" and ".", and cannot match a query shorter than its tokens.
+func (uc *userSearchInteractor) searchMode(query string) string {
+	if strings.Contains(query, "@") || utf8.RuneCountInString(query) < uc.settings.NgramTokenSize {
+		return domain.UserSearchEmailPrefix
+	}
+	return domain.UserSearchFullText
+}
+
+func (uc *userSearchInteractor) search(ctx context.Context, mode, query string, limit, offset int) ([]domain.UserSearchHit, error) {
+	if mode == domain.UserSearchFullText {
+		return uc.userRepo.SearchUsers(ctx, query, limit, offset)
+	}
+	users, err := uc.userRepo.SearchUsersByEmailPrefix(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]domain.UserSearchHit, len(users))
+	for i, u := range users {
+		hits[i] = domain.UserSearchHit{User: u}
+	}
+	return hits, nil
+}
+
+// encodeSearchCursor returns an opaque cursor for the page of mode starting at offset.
+func encodeSearchCursor(mode string, offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(mode + ":" + strconv.Itoa(offset)))
+}
+
+func decodeSearchCursor(cursor string) (string, int, error) {
+	invalid := fmt.Errorf("%w: invalid cursor", domain.ErrInvalidArgument)
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", 0, invalid
+	}
+	mode, offsetStr, ok := strings.Cut(string(raw), ":")
+	if !ok || (mode != domain.UserSearchFullText && mode != domain.UserSearchEmailPrefix) {
+		return "", 0, invalid
+	}
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		return "", 0, invalid
+	}
+	return mode, offset, nil
+}
+
+// highlightMatches returns the fragments of text matching any of terms,
+// ignoring case. A term that does not occur whole is matched by its n-grams,
+// as the full-text index does.
+func highlightMatches(text string, terms []string, n int) []domain.TextRange {
+	runes := foldRunes(text)
+	matched := make([]bool, len(runes))
+	for _, term := range terms {
+		t := foldRunes(term)
+		if len(t) == 0 || markOccurrences(runes, t, matched) || len(t) <= n {
+			continue
+		}
+		for i := 0; i+n <= len(t); i++ {
+			markOccurrences(runes, t[i:i+n], matched)
+		}
+	}
+
+	var ranges []domain.TextRange
+	for i := 0; i < len(matched); i++ {
+		if !matched[i] {
+			continue
+		}
+		start := i
+		for i < len(matched) && matched[i] {
+			i++
+		}
+		ranges = append(ranges, domain.TextRange{Start: start, End: i})
+	}
+	return ranges
+}
+
+// foldRunes lower-cases s rune by rune, so indexes stay aligned with s.
+func foldRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// markOccurrences marks every occurrence of sub in runes and reports whether there was one.
+func markOccurrences(runes, sub []rune, matched []bool) bool {
+	found := false
+	for i := 0; i+len(sub) <= len(runes); i++ {
+		if string(runes[i:i+len(sub)]) != string(sub) {
+			continue
+		}
+		for j := i; j < i+len(sub); j++ {
+			matched[j] = true
+		}
+		found = true
+	}
+	return found
+}
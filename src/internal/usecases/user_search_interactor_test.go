@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// adminContext may read the global user directory.
+var adminContext = sessionContext("admin-1", domain.RoleAdmin, false)
+
+func TestUserSearchInteractor_SearchUsers_FullText(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	uc := NewUserSearchInteractor(mockRepo, DefaultUserSearchSettings())
+
+	mockRepo.On("SearchUsers", adminContext, "山田 太郎", 3, 0).Return([]domain.UserSearchHit{
+		{User: domain.User{ID: "u-1", Name: "山田太郎", Email: "taro@example.com"}, Relevance: 2.5},
+		{User: domain.User{ID: "u-2", Name: "山本花子", Email: "hanako@example.com"}, Relevance: 0.5},
+		{User: domain.User{ID: "u-3", Name: "田中太一", Email: "taichi@example.com"}, Relevance: 0.1},
+	}, nil).Once()
+
+	page, err := uc.SearchUsers(adminContext, "  山田 太郎 ", 2, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.UserSearchFullText, page.Mode)
+	if assert.Len(t, page.Hits, 2) {
+		assert.Equal(t, []domain.TextRange{{Start: 0, End: 4}}, page.Hits[0].NameMatches)
+		assert.Empty(t, page.Hits[0].EmailMatches)
+		// A single character shared with the query is shorter than a bigram
+		assert.Empty(t, page.Hits[1].NameMatches)
+	}
+	assert.NotEmpty(t, page.NextCursor)
+
+	// The next page continues from the cursor in the same mode
+	mockRepo.On("SearchUsers", adminContext, "山田 太郎", 3, 2).Return([]domain.UserSearchHit{
+		{User: domain.User{ID: "u-3", Name: "田中太一", Email: "taichi@example.com"}, Relevance: 0.1},
+	}, nil).Once()
+
+	next, err := uc.SearchUsers(adminContext, "山田 太郎", 2, page.NextCursor)
+
+	assert.NoError(t, err)
+	assert.Len(t, next.Hits, 1)
+	assert.Empty(t, next.NextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserSearchInteractor_SearchUsers_EmailPrefix(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	uc := NewUserSearchInteractor(mockRepo, DefaultUserSearchSettings())
+
+	mockRepo.On("SearchUsersByEmailPrefix", adminContext, "Alice@Ex", 21, 0).Return([]domain.User{
+		{ID: "u-1", Name: "Alice", Email: "alice@example.com"},
+	}, nil).Once()
+
+	page, err := uc.SearchUsers(adminContext, "Alice@Ex", 0, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.UserSearchEmailPrefix, page.Mode)
+	if assert.Len(t, page.Hits, 1) {
+		assert.Zero(t, page.Hits[0].Relevance)
+		assert.Equal(t, []domain.TextRange{{Start: 0, End: 8}}, page.Hits[0].EmailMatches)
+		assert.Empty(t, page.Hits[0].NameMatches)
+	}
+	mockRepo.AssertNotCalled(t, "SearchUsers")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserSearchInteractor_SearchUsers_FallsBackToEmailPrefix(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	uc := NewUserSearchInteractor(mockRepo, DefaultUserSearchSettings())
+
+	mockRepo.On("SearchUsers", adminContext, "bo", 21, 0).Return([]domain.UserSearchHit{}, nil).Once()
+	mockRepo.On("SearchUsersByEmailPrefix", adminContext, "bo", 21, 0).Return([]domain.User{
+		{ID: "u-1", Name: "Robert", Email: "bob@example.com"},
+	}, nil).Once()
+
+	page, err := uc.SearchUsers(adminContext, "bo", 0, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.UserSearchEmailPrefix, page.Mode)
+	assert.Len(t, page.Hits, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserSearchInteractor_SearchUsers_InvalidInput(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	uc := NewUserSearchInteractor(mockRepo, DefaultUserSearchSettings())
+
+	for name, call := range map[string]func() error{
+		"empty query": func() error { _, err := uc.SearchUsers(adminContext, "   ", 0, ""); return err },
+		"long query": func() error {
+			_, err := uc.SearchUsers(adminContext, string(make([]rune, maxUserSearchQueryLength+1)), 0, "")
+			return err
+		},
+		"limit": func() error {
+			_, err := uc.SearchUsers(adminContext, "alice", maxUserSearchPageSize+1, "")
+			return err
+		},
+		"cursor": func() error { _, err := uc.SearchUsers(adminContext, "alice", 0, "not a cursor"); return err },
+		"cursor mode": func() error {
+			_, err := uc.SearchUsers(adminContext, "alice", 0, encodeSearchCursor("regex", 20))
+			return err
+		},
+	} {
+		assert.ErrorIs(t, call(), domain.ErrInvalidArgument, name)
+	}
+	mockRepo.AssertNotCalled(t, "SearchUsers")
+}
+
+func TestUserSearchInteractor_SearchUsers_RequiresAdmin(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	uc := NewUserSearchInteractor(mockRepo, DefaultUserSearchSettings())
+
+	_, err := uc.SearchUsers(context.Background(), "alice", 0, "")
+	assert.ErrorIs(t, err, domain.ErrUnauthenticated)
+	_, err = uc.SearchUsers(sessionContext("user-1", domain.RoleUser, false), "alice", 0, "")
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "SearchUsers")
+}
+
+func TestHighlightMatches(t *testing.T) {
+	tests := []struct {
+		text  string
+		terms []string
+		want  []domain.TextRange
+	}{
+		{"Alice Smith", []string{"smith"}, []domain.TextRange{{Start: 6, End: 11}}},
+		{"Alice Smith", []string{"ali", "ith"}, []domain.TextRange{{Start: 0, End: 3}, {Start: 8, End: 11}}},
+		{"アリス・スミス", []string{"スミ"}, []domain.TextRange{{Start: 4, End: 6}}},
+		// A term that is not found whole is matched by its bigrams, which may overlap
+		{"山田花子", []string{"山田太郎"}, []domain.TextRange{{Start: 0, End: 2}}},
+		{"ababa", []string{"aba"}, []domain.TextRange{{Start: 0, End: 5}}},
+		{"Bob", []string{"x"}, nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, highlightMatches(tt.text, tt.terms, 2), "%q %q", tt.text, tt.terms)
+	}
+}
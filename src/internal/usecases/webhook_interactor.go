@@ -0,0 +1,339 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"apiserver/internal/audit"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/encryption"
+	"apiserver/internal/repositories"
+)
+
+const (
+	// webhookSecretTag starts every generated signing secret.
+	webhookSecretTag = "whsec_"
+	// webhookSecretBytes is the amount of randomness in a generated secret.
+	webhookSecretBytes = 32
+	// minWebhookSecretLength and maxWebhookSecretLength bound caller-supplied secrets.
+	minWebhookSecretLength = 16
+	maxWebhookSecretLength = 128
+	// maxWebhookURLLength matches webhook_subscriptions.url.
+	maxWebhookURLLength = 2048
+	// defaultWebhookDeliveryPageSize is used when the caller does not ask for a page size.
+	defaultWebhookDeliveryPageSize = 50
+	// maxWebhookDeliveryPageSize caps a single page of deliveries.
+	maxWebhookDeliveryPageSize = 200
+)
+
+// WebhookInput holds the fields of a new webhook subscription.
+type WebhookInput struct {
+	URL         string
+	EventTypes  []string
+	Description string
+	Secret      string // Generated when empty
+}
+
+// WebhookPatch holds the changes to a webhook subscription. Nil fields are left unchanged.
+type WebhookPatch struct {
+	URL         *string
+	EventTypes  []string
+	Description *string
+	Active      *bool
+	Secret      *string // Replaces the signing secret
+}
+
+// WebhookInteractor defines the interface for managing outgoing webhooks.
+// All operations require an administrator.
+type WebhookInteractor interface {
+	// CreateWebhook registers a subscription and returns it with its signing
+	// secret, which cannot be retrieved again.
+	CreateWebhook(ctx context.Context, in WebhookInput) (sub *domain.WebhookSubscription, secret string, err error)
+	ListWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error)
+	GetWebhook(ctx context.Context, id string) (*domain.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, id string, patch WebhookPatch) (*domain.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	// ListWebhookDeliveries returns the delivery history of a subscription, newest first.
+	ListWebhookDeliveries(ctx context.Context, id string, beforeID int64, limit int) ([]domain.WebhookDelivery, error)
+	// RedeliverWebhook queues a delivery to be sent again as soon as possible,
+	// whatever its current state.
+	RedeliverWebhook(ctx context.Context, id string, deliveryID int64) (*domain.WebhookDelivery, error)
+}
+
+// webhookInteractor implements WebhookInteractor.
+type webhookInteractor struct {
+	webhookRepo repositories.WebhookRepository
+	secrets     encryption.Cipher // Encrypts signing secrets at rest
+	auditor     audit.Recorder
+	clock       clock.Clock
+}
+
+// NewWebhookInteractor creates a new instance of WebhookInteractor.
+func NewWebhookInteractor(repo repositories.WebhookRepository, secrets encryption.Cipher, auditor audit.Recorder, clk clock.Clock) WebhookInteractor {
+	return &webhookInteractor{webhookRepo: repo, secrets: secrets, auditor: auditor, clock: clk}
+}
+
+// generateWebhookSecret returns a new signing secret formatted as "whsec_<base64url>".
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return webhookSecretTag + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// validateWebhookURL accepts absolute http and https URLs.
+func validateWebhookURL(raw string) error {
+	if len(raw) > maxWebhookURLLength {
+		return fmt.Errorf("%w: url must not exceed %d characters", domain.ErrInvalidArgument, maxWebhookURLLength)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", domain.ErrInvalidArgument)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: url must not contain credentials", domain.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// normalizeEventTypes validates event types and removes duplicates.
+func normalizeEventTypes(types []string) ([]string, error) {
+	if len(types) == 0 {
+		return nil, fmt.Errorf("%w: at least one event type is required", domain.ErrInvalidArgument)
+	}
+	seen := make(map[string]bool, len(types))
+	out := make([]string, 0, len(types))
+	for _, t := range types {
+		if !domain.IsWebhookEventType(t) {
+			return nil, fmt.Errorf("%w: unknown event type %q", domain.ErrInvalidArgument, t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func validateWebhookSecret(secret string) error {
+	if len(secret) < minWebhookSecretLength || len(secret) > maxWebhookSecretLength {
+		return fmt.Errorf("%w: secret must be %d to %d characters", domain.ErrInvalidArgument, minWebhookSecretLength, maxWebhookSecretLength)
+	}
+	return nil
+}
+
+func (uc *webhookInteractor) CreateWebhook(ctx context.Context, in WebhookInput) (*domain.WebhookSubscription, string, error) {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := validateWebhookURL(in.URL); err != nil {
+		return nil, "", err
+	}
+	eventTypes, err := normalizeEventTypes(in.EventTypes)
+	if err != nil {
+		return nil, "", err
+	}
+	secret := in.Secret
+	if secret == "" {
+		if secret, err = generateWebhookSecret(); err != nil {
+			return nil, "", err
+		}
+	} else if err := validateWebhookSecret(secret); err != nil {
+		return nil, "", err
+	}
+	ciphertext, err := uc.secrets.Encrypt([]byte(secret))
+	if err != nil {
+		return nil, "", err
+	}
+
+	sub, err := uc.webhookRepo.CreateWebhookSubscription(ctx, &domain.WebhookSubscription{
+		URL:              in.URL,
+		EventTypes:       eventTypes,
+		Description:      strings.TrimSpace(in.Description),
+		SecretCiphertext: ciphertext,
+		Active:           true,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if err := uc.recordWebhookChange(ctx, principal.UserID, domain.AuditActionWebhookCreated, sub, nil); err != nil {
+		return nil, "", err
+	}
+	return sub, secret, nil
+}
+
+func (uc *webhookInteractor) ListWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return uc.webhookRepo.ListWebhookSubscriptions(ctx)
+}
+
+func (uc *webhookInteractor) GetWebhook(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return uc.getWebhook(ctx, id)
+}
+
+// getWebhook returns the subscription or ErrWebhookNotFound.
+func (uc *webhookInteractor) getWebhook(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	sub, err := uc.webhookRepo.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return sub, nil
+}
+
+func (uc *webhookInteractor) UpdateWebhook(ctx context.Context, id string, patch WebhookPatch) (*domain.WebhookSubscription, error) {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := uc.getWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	if patch.URL != nil && *patch.URL != sub.URL {
+		if err := validateWebhookURL(*patch.URL); err != nil {
+			return nil, err
+		}
+		sub.URL = *patch.URL
+		changed = append(changed, "url")
+	}
+	if patch.EventTypes != nil {
+		if sub.EventTypes, err = normalizeEventTypes(patch.EventTypes); err != nil {
+			return nil, err
+		}
+		changed = append(changed, "event_types")
+	}
+	if patch.Description != nil {
+		sub.Description = strings.TrimSpace(*patch.Description)
+		changed = append(changed, "description")
+	}
+	if patch.Active != nil && *patch.Active != sub.Active {
+		sub.Active = *patch.Active
+		changed = append(changed, "active")
+	}
+	if patch.Secret != nil {
+		if err := validateWebhookSecret(*patch.Secret); err != nil {
+			return nil, err
+		}
+		if sub.SecretCiphertext, err = uc.secrets.Encrypt([]byte(*patch.Secret)); err != nil {
+			return nil, err
+		}
+		changed = append(changed, "secret")
+	}
+	if len(changed) == 0 {
+		return sub, nil
+	}
+
+	updated, err := uc.webhookRepo.UpdateWebhookSubscription(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, domain.ErrWebhookNotFound
+	}
+	if err := uc.recordWebhookChange(ctx, principal.UserID, domain.AuditActionWebhookUpdated, updated, map[string]string{"changed": strings.Join(changed, " ")}); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (uc *webhookInteractor) DeleteWebhook(ctx context.Context, id string) error {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+	sub, err := uc.getWebhook(ctx, id)
+	if err != nil {
+		return err
+	}
+	// Deliveries are removed with the subscription
+	if err := uc.webhookRepo.DeleteWebhookSubscription(ctx, sub.ID); err != nil {
+		return err
+	}
+	return uc.recordWebhookChange(ctx, principal.UserID, domain.AuditActionWebhookDeleted, sub, nil)
+}
+
+func (uc *webhookInteractor) ListWebhookDeliveries(ctx context.Context, id string, beforeID int64, limit int) ([]domain.WebhookDelivery, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultWebhookDeliveryPageSize
+	}
+	if limit > maxWebhookDeliveryPageSize {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", domain.ErrInvalidArgument, maxWebhookDeliveryPageSize)
+	}
+	if _, err := uc.getWebhook(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.webhookRepo.ListWebhookDeliveries(ctx, id, beforeID, limit)
+}
+
+func (uc *webhookInteractor) RedeliverWebhook(ctx context.Context, id string, deliveryID int64) (*domain.WebhookDelivery, error) {
+	principal, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := uc.getWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := uc.webhookRepo.GetWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil || delivery.SubscriptionID != sub.ID {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+	if !sub.Active {
+		return nil, fmt.Errorf("%w: the webhook is disabled", domain.ErrConflict)
+	}
+
+	now := uc.clock.Now()
+	if err := uc.webhookRepo.ResetWebhookDelivery(ctx, delivery.ID, now); err != nil {
+		return nil, err
+	}
+	delivery.Status = domain.DeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+
+	if err := uc.recordWebhookChange(ctx, principal.UserID, domain.AuditActionWebhookRedelivered, sub, map[string]string{
+		"delivery_id": strconv.FormatInt(delivery.ID, 10),
+		"event_id":    delivery.EventID,
+	}); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// recordWebhookChange appends an audit event for a change to sub.
+func (uc *webhookInteractor) recordWebhookChange(ctx context.Context, actorID, action string, sub *domain.WebhookSubscription, metadata map[string]string) error {
+	if metadata == nil {
+		metadata = make(map[string]string, 2)
+	}
+	metadata["webhook_id"] = sub.ID
+	metadata["url"] = sub.URL
+	return uc.auditor.Record(ctx, domain.AuditEvent{
+		Action:     action,
+		ActorID:    actorID,
+		Metadata:   metadata,
+		OccurredAt: uc.clock.Now(),
+	})
+}
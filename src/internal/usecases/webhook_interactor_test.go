@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	auditmocks "apiserver/internal/audit/mocks"
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/encryption"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookTestEnv struct {
+	repo       *mocks.MockWebhookRepository
+	auditor    *auditmocks.MockRecorder
+	cipher     encryption.Cipher
+	clock      *clock.Fake
+	interactor WebhookInteractor
+}
+
+func setupWebhookTestEnv(t *testing.T) *webhookTestEnv {
+	cipher, err := encryption.NewAESGCM(bytes.Repeat([]byte{7}, 32))
+	require.NoError(t, err)
+	env := &webhookTestEnv{
+		repo:    new(mocks.MockWebhookRepository),
+		auditor: new(auditmocks.MockRecorder),
+		cipher:  cipher,
+		clock:   clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	env.interactor = NewWebhookInteractor(env.repo, env.cipher, env.auditor, env.clock)
+	return env
+}
+
+func TestWebhookInteractor_CreateWebhook_GeneratesEncryptedSecret(t *testing.T) {
+	env := setupWebhookTestEnv(t)
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+	var stored *domain.WebhookSubscription
+
+	env.repo.On("CreateWebhookSubscription", mock.Anything, mock.AnythingOfType("*domain.WebhookSubscription")).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.WebhookSubscription)
+	}).Return(&domain.WebhookSubscription{ID: "wh-1", URL: "https://example.com/hook"}, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionWebhookCreated && e.ActorID == "admin-1" && e.Metadata["webhook_id"] == "wh-1"
+	})).Return(nil).Once()
+
+	sub, secret, err := env.interactor.CreateWebhook(ctx, WebhookInput{
+		URL:         "https://example.com/hook",
+		EventTypes:  []string{domain.EventUserCreated, domain.EventUserCreated},
+		Description: " CRM sync ",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "wh-1", sub.ID)
+	assert.True(t, strings.HasPrefix(secret, webhookSecretTag))
+	assert.Equal(t, []string{domain.EventUserCreated}, stored.EventTypes)
+	assert.Equal(t, "CRM sync", stored.Description)
+	assert.True(t, stored.Active)
+	assert.NotContains(t, string(stored.SecretCiphertext), secret)
+	plaintext, err := env.cipher.Decrypt(stored.SecretCiphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, secret, string(plaintext))
+	env.auditor.AssertExpectations(t)
+}
+
+func TestWebhookInteractor_CreateWebhook_Rejected(t *testing.T) {
+	env := setupWebhookTestEnv(t)
+	admin := sessionContext("admin-1", domain.RoleAdmin, true)
+	valid := WebhookInput{URL: "https://example.com/hook", EventTypes: []string{domain.EventUserCreated}}
+
+	tests := map[string]struct {
+		ctx     context.Context
+		mutate  func(in *WebhookInput)
+		wantErr error
+	}{
+		"non-admin":       {sessionContext("user-1", domain.RoleUser, false), func(*WebhookInput) {}, domain.ErrForbidden},
+		"relative url":    {admin, func(in *WebhookInput) { in.URL = "/hook" }, domain.ErrInvalidArgument},
+		"ftp url":         {admin, func(in *WebhookInput) { in.URL = "ftp://example.com/hook" }, domain.ErrInvalidArgument},
+		"url credentials": {admin, func(in *WebhookInput) { in.URL = "https://u:p@example.com/hook" }, domain.ErrInvalidArgument},
+		"no event types":  {admin, func(in *WebhookInput) { in.EventTypes = nil }, domain.ErrInvalidArgument},
+		"unknown event":   {admin, func(in *WebhookInput) { in.EventTypes = []string{"user.exploded"} }, domain.ErrInvalidArgument},
+		"short secret":    {admin, func(in *WebhookInput) { in.Secret = "short" }, domain.ErrInvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			in := valid
+			tt.mutate(&in)
+			_, _, err := env.interactor.CreateWebhook(tt.ctx, in)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+	env.repo.AssertNotCalled(t, "CreateWebhookSubscription", mock.Anything, mock.Anything)
+}
+
+func TestWebhookInteractor_UpdateWebhook_RotatesSecretAndRecordsChanges(t *testing.T) {
+	env := setupWebhookTestEnv(t)
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+	disabled := false
+	secret := "a-new-secret-of-enough-length"
+	var stored *domain.WebhookSubscription
+
+	env.repo.On("GetWebhookSubscription", mock.Anything, "wh-1").Return(&domain.WebhookSubscription{ID: "wh-1", URL: "https://example.com/hook", Active: true}, nil).Once()
+	env.repo.On("UpdateWebhookSubscription", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.WebhookSubscription)
+	}).Return(&domain.WebhookSubscription{ID: "wh-1", URL: "https://example.com/hook"}, nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionWebhookUpdated && e.Metadata["changed"] == "active secret"
+	})).Return(nil).Once()
+
+	_, err := env.interactor.UpdateWebhook(ctx, "wh-1", WebhookPatch{Active: &disabled, Secret: &secret})
+
+	assert.NoError(t, err)
+	assert.False(t, stored.Active)
+	plaintext, err := env.cipher.Decrypt(stored.SecretCiphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, secret, string(plaintext))
+	env.auditor.AssertExpectations(t)
+}
+
+func TestWebhookInteractor_UpdateWebhook_NotFound(t *testing.T) {
+	env := setupWebhookTestEnv(t)
+	env.repo.On("GetWebhookSubscription", mock.Anything, "missing").Return(nil, nil).Once()
+
+	_, err := env.interactor.UpdateWebhook(sessionContext("admin-1", domain.RoleAdmin, true), "missing", WebhookPatch{})
+
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestWebhookInteractor_ListWebhookDeliveries_Limit(t *testing.T) {
+	env := setupWebhookTestEnv(t)
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+
+	_, err := env.interactor.ListWebhookDeliveries(ctx, "wh-1", 0, maxWebhookDeliveryPageSize+1)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	env.repo.On("GetWebhookSubscription", mock.Anything, "wh-1").Return(&domain.WebhookSubscription{ID: "wh-1"}, nil).Once()
+	env.repo.On("ListWebhookDeliveries", mock.Anything, "wh-1", int64(10), defaultWebhookDeliveryPageSize).Return([]domain.WebhookDelivery{}, nil).Once()
+
+	_, err = env.interactor.ListWebhookDeliveries(ctx, "wh-1", 10, 0)
+	assert.NoError(t, err)
+	env.repo.AssertExpectations(t)
+}
+
+func TestWebhookInteractor_RedeliverWebhook(t *testing.T) {
+	env := setupWebhookTestEnv(t)
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+
+	env.repo.On("GetWebhookSubscription", mock.Anything, "wh-1").Return(&domain.WebhookSubscription{ID: "wh-1", Active: true}, nil).Once()
+	env.repo.On("GetWebhookDelivery", mock.Anything, int64(7)).Return(&domain.WebhookDelivery{ID: 7, SubscriptionID: "wh-1", EventID: "e1", Status: domain.DeliveryStatusDead, Attempts: 12}, nil).Once()
+	env.repo.On("ResetWebhookDelivery", mock.Anything, int64(7), env.clock.Now()).Return(nil).Once()
+	env.auditor.On("Record", mock.Anything, mock.MatchedBy(func(e domain.AuditEvent) bool {
+		return e.Action == domain.AuditActionWebhookRedelivered && e.Metadata["delivery_id"] == "7" && e.Metadata["event_id"] == "e1"
+	})).Return(nil).Once()
+
+	delivery, err := env.interactor.RedeliverWebhook(ctx, "wh-1", 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeliveryStatusPending, delivery.Status)
+	assert.Equal(t, 0, delivery.Attempts)
+	env.repo.AssertExpectations(t)
+	env.auditor.AssertExpectations(t)
+}
+
+func TestWebhookInteractor_RedeliverWebhook_Rejected(t *testing.T) {
+	ctx := sessionContext("admin-1", domain.RoleAdmin, true)
+
+	t.Run("delivery of another webhook", func(t *testing.T) {
+		env := setupWebhookTestEnv(t)
+		env.repo.On("GetWebhookSubscription", mock.Anything, "wh-1").Return(&domain.WebhookSubscription{ID: "wh-1", Active: true}, nil).Once()
+		env.repo.On("GetWebhookDelivery", mock.Anything, int64(7)).Return(&domain.WebhookDelivery{ID: 7, SubscriptionID: "wh-2"}, nil).Once()
+
+		_, err := env.interactor.RedeliverWebhook(ctx, "wh-1", 7)
+
+		assert.ErrorIs(t, err, domain.ErrWebhookDeliveryNotFound)
+	})
+
+	t.Run("disabled webhook", func(t *testing.T) {
+		env := setupWebhookTestEnv(t)
+		env.repo.On("GetWebhookSubscription", mock.Anything, "wh-1").Return(&domain.WebhookSubscription{ID: "wh-1"}, nil).Once()
+		env.repo.On("GetWebhookDelivery", mock.Anything, int64(7)).Return(&domain.WebhookDelivery{ID: 7, SubscriptionID: "wh-1"}, nil).Once()
+
+		_, err := env.interactor.RedeliverWebhook(ctx, "wh-1", 7)
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		env.repo.AssertNotCalled(t, "ResetWebhookDelivery", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
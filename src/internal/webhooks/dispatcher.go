@@ -0,0 +1,60 @@
+package webhooks
+
+import (
+	"context"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/events"
+	"apiserver/internal/repositories"
+)
+
+// Dispatcher fans domain events out to the webhook subscriptions that want
+// them by queuing one delivery per subscription. It implements
+// events.Publisher so the outbox relay can feed it. The relay publishes at
+// least once, outside the transaction that claimed the event, so an event may
+// be queued again after a failure; the repository inserts deliveries with
+// INSERT IGNORE and keeps one per (event, subscription).
+type Dispatcher struct {
+	repo  repositories.WebhookRepository
+	clock clock.Clock
+}
+
+var _ events.Publisher = (*Dispatcher)(nil)
+
+// NewDispatcher creates a new Dispatcher.
+func NewDispatcher(repo repositories.WebhookRepository, clk clock.Clock) *Dispatcher {
+	return &Dispatcher{repo: repo, clock: clk}
+}
+
+func (d *Dispatcher) Publish(ctx context.Context, event domain.DomainEvent) error {
+	subs, err := d.repo.ListActiveWebhookSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	var body []byte
+	now := d.clock.Now()
+	for i := range subs {
+		if !subs[i].Subscribes(event.Type) {
+			continue
+		}
+		if body == nil {
+			if body, err = events.Encode(event); err != nil {
+				return err
+			}
+		}
+		// Requeuing an event the relay already fanned out is ignored
+		if err := d.repo.EnqueueWebhookDelivery(ctx, &domain.WebhookDelivery{
+			SubscriptionID: subs[i].ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        body,
+			Status:         domain.DeliveryStatusPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDispatcher_Publish_QueuesForMatchingSubscriptions(t *testing.T) {
+	repo := new(mocks.MockWebhookRepository)
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	event := domain.DomainEvent{ID: "e1", Type: domain.EventUserUpdated, AggregateID: "user-1", Payload: json.RawMessage(`{"id":"user-1"}`), OccurredAt: clk.Now()}
+
+	repo.On("ListActiveWebhookSubscriptions", mock.Anything).Return([]domain.WebhookSubscription{
+		{ID: "sub-1", EventTypes: []string{domain.EventUserCreated, domain.EventUserUpdated}, Active: true},
+		{ID: "sub-2", EventTypes: []string{domain.EventUserDeleted}, Active: true},
+	}, nil).Once()
+	var queued *domain.WebhookDelivery
+	repo.On("EnqueueWebhookDelivery", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		queued = args.Get(1).(*domain.WebhookDelivery)
+	}).Return(nil).Once()
+
+	assert.NoError(t, NewDispatcher(repo, clk).Publish(context.Background(), event))
+
+	repo.AssertExpectations(t)
+	assert.Equal(t, "sub-1", queued.SubscriptionID)
+	assert.Equal(t, "e1", queued.EventID)
+	assert.Equal(t, domain.DeliveryStatusPending, queued.Status)
+	assert.Equal(t, clk.Now(), queued.NextAttemptAt)
+	assert.JSONEq(t, `{"id":"e1","type":"user.updated","aggregate_id":"user-1","occurred_at":"2026-01-01T00:00:00Z","data":{"id":"user-1"}}`, string(queued.Payload))
+}
@@ -0,0 +1,59 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers sent with every delivery.
+const (
+	HeaderID        = "X-Webhook-Id"        // Event ID; identical across retries so receivers can deduplicate
+	HeaderEvent     = "X-Webhook-Event"     // Event type, e.g. "user.created"
+	HeaderTimestamp = "X-Webhook-Timestamp" // Unix seconds when the attempt was signed
+	HeaderSignature = "X-Webhook-Signature" // "v1=" followed by the hex HMAC-SHA256
+)
+
+// signatureVersion prefixes signatures so the scheme can change without
+// breaking receivers that check it.
+const signatureVersion = "v1="
+
+var (
+	// ErrInvalidSignature is returned when a signature does not match the body.
+	ErrInvalidSignature = errors.New("webhook signature does not match")
+	// ErrTimestampOutOfRange is returned when a delivery is too old or from the future.
+	ErrTimestampOutOfRange = errors.New("webhook timestamp outside the tolerance")
+)
+
+// Sign returns the signature header value for body sent at timestamp. The
+// HMAC-SHA256 covers "<timestamp>.<body>" so a captured request cannot be
+// replayed with a different timestamp.
+func Sign(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the timestamp and signature headers of a delivery received at
+// now. Receivers should reject deliveries older than a few minutes.
+func Verify(secret []byte, timestampHeader, signatureHeader string, body []byte, now time.Time, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if math.Abs(float64(now.Unix()-ts)) > tolerance.Seconds() {
+		return ErrTimestampOutOfRange
+	}
+	if !strings.HasPrefix(signatureHeader, signatureVersion) ||
+		!hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signatureHeader)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	secret := []byte("whsec_test")
+	body := []byte(`{"id":"e1"}`)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sig := Sign(secret, now.Unix(), body)
+
+	assert.Regexp(t, `^v1=[0-9a-f]{64}$`, sig)
+	assert.NoError(t, Verify(secret, "1767225600", sig, body, now.Add(time.Minute), 5*time.Minute))
+
+	assert.ErrorIs(t, Verify([]byte("other"), "1767225600", sig, body, now, 5*time.Minute), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(secret, "1767225600", sig, []byte(`{"id":"e2"}`), now, 5*time.Minute), ErrInvalidSignature)
+	// The timestamp is covered by the signature
+	assert.ErrorIs(t, Verify(secret, "1767225601", sig, body, now, 5*time.Minute), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(secret, "not-a-number", sig, body, now, 5*time.Minute), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(secret, "1767225600", sig[3:], body, now, 5*time.Minute), ErrInvalidSignature)
+}
+
+func TestVerify_Tolerance(t *testing.T) {
+	secret := []byte("whsec_test")
+	body := []byte(`{}`)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sig := Sign(secret, now.Unix(), body)
+
+	assert.ErrorIs(t, Verify(secret, "1767225600", sig, body, now.Add(6*time.Minute), 5*time.Minute), ErrTimestampOutOfRange)
+	assert.ErrorIs(t, Verify(secret, "1767225600", sig, body, now.Add(-6*time.Minute), 5*time.Minute), ErrTimestampOutOfRange)
+}
@@ -0,0 +1,210 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/encryption"
+	"apiserver/internal/events"
+	"apiserver/internal/repositories"
+)
+
+// WorkerConfig tunes the delivery worker.
+type WorkerConfig struct {
+	PollInterval time.Duration // Wait between polls when no deliveries are due
+	BatchSize    int           // Deliveries claimed at a time
+	Lease        time.Duration // How long claimed deliveries are reserved for this worker
+	Concurrency  int           // Deliveries sent in parallel
+	MinBackoff   time.Duration // Delay before the first retry, doubled per attempt
+	MaxBackoff   time.Duration // Upper bound for the retry delay
+	MaxAttempts  int           // Attempts before a delivery is dead-lettered
+}
+
+// DefaultWorkerConfig returns the settings used when none are configured.
+// With these a delivery is retried for roughly a day before it is dead.
+func DefaultWorkerConfig() WorkerConfig {
+	return WorkerConfig{
+		PollInterval: time.Second,
+		BatchSize:    50,
+		Lease:        5 * time.Minute,
+		Concurrency:  10,
+		MinBackoff:   30 * time.Second,
+		MaxBackoff:   6 * time.Hour,
+		MaxAttempts:  12,
+	}
+}
+
+// Worker POSTs queued deliveries to subscriber endpoints. Each attempt is
+// signed afresh; a 2xx response marks the delivery succeeded, anything else
+// schedules a retry with exponential backoff until MaxAttempts is reached and
+// the delivery is dead-lettered. Several workers can share the queue.
+type Worker struct {
+	repo    repositories.WebhookRepository
+	tx      repositories.TxManager
+	secrets encryption.Cipher
+	client  *http.Client
+	clock   clock.Clock
+	config  WorkerConfig
+	logger  *log.Logger
+}
+
+// NewWorker creates a new Worker. client should have a timeout and should not
+// follow redirects, so that a redirect counts as a failed attempt.
+func NewWorker(repo repositories.WebhookRepository, tx repositories.TxManager, secrets encryption.Cipher, client *http.Client, clk clock.Clock, config WorkerConfig, logger *log.Logger) *Worker {
+	return &Worker{repo: repo, tx: tx, secrets: secrets, client: client, clock: clk, config: config, logger: logger}
+}
+
+// Run delivers webhooks until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		n, err := w.DeliverOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			w.logger.Printf("webhook worker: %v", err)
+		}
+		if err == nil && n == w.config.BatchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(w.config.PollInterval)
+		}
+	}
+}
+
+// DeliverOnce attempts one batch of due deliveries and returns how many were
+// claimed. A failed attempt is recorded on the delivery and is not an error.
+// Deliveries are claimed in a short transaction and sent outside it, up to
+// Concurrency at a time, so a slow receiver holds neither row locks nor the
+// deliveries to other receivers.
+func (w *Worker) DeliverOnce(ctx context.Context) (int, error) {
+	now := w.clock.Now()
+	leaseUntil := now.Add(w.config.Lease)
+	var deliveries []domain.WebhookDelivery
+	err := w.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		deliveries, err = w.repo.ClaimDueWebhookDeliveries(ctx, now, leaseUntil, w.config.BatchSize)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	subs := make(map[string]*domain.WebhookSubscription)
+	for _, d := range deliveries {
+		if _, ok := subs[d.SubscriptionID]; ok {
+			continue
+		}
+		sub, err := w.repo.GetWebhookSubscription(ctx, d.SubscriptionID)
+		if err != nil {
+			return len(deliveries), err
+		}
+		subs[d.SubscriptionID] = sub
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, max(w.config.Concurrency, 1))
+	for i := range deliveries {
+		d := &deliveries[i]
+		sem <- struct{}{}
+		// Past the lease another worker may claim the delivery; leave the rest to it
+		if w.clock.Now().Add(w.client.Timeout).After(leaseUntil) {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			w.attempt(ctx, subs[d.SubscriptionID], d)
+			if err := w.repo.RecordWebhookDeliveryAttempt(ctx, d); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return len(deliveries), errors.Join(errs...)
+}
+
+// attempt sends d to sub and updates d with the outcome.
+func (w *Worker) attempt(ctx context.Context, sub *domain.WebhookSubscription, d *domain.WebhookDelivery) {
+	if sub == nil || !sub.Active {
+		// Kept for the history and for redelivery once the subscription is re-enabled
+		d.Status = domain.DeliveryStatusDead
+		d.LastError = "subscription is disabled"
+		return
+	}
+
+	statusCode, err := w.send(ctx, sub, d)
+	now := w.clock.Now()
+	d.Attempts++
+	d.LastAttemptAt = now
+	d.LastStatusCode = statusCode
+	if err == nil {
+		d.Status = domain.DeliveryStatusSucceeded
+		d.LastError = ""
+		d.DeliveredAt = now
+		return
+	}
+
+	d.LastError = err.Error()
+	if d.Attempts >= w.config.MaxAttempts {
+		d.Status = domain.DeliveryStatusDead
+		w.logger.Printf("webhook worker: delivery %d of %s to %s is dead after %d attempts: %v", d.ID, d.EventID, sub.URL, d.Attempts, err)
+		return
+	}
+	d.Status = domain.DeliveryStatusPending
+	d.NextAttemptAt = now.Add(events.Backoff(w.config.MinBackoff, w.config.MaxBackoff, d.Attempts-1))
+}
+
+// send POSTs the delivery and returns the response status code, if any.
+func (w *Worker) send(ctx context.Context, sub *domain.WebhookSubscription, d *domain.WebhookDelivery) (int, error) {
+	secret, err := w.secrets.Decrypt(sub.SecretCiphertext)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := w.clock.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "apiserver-webhooks/1")
+	req.Header.Set(HeaderID, d.EventID)
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, d.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,206 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"apiserver/internal/clock"
+	"apiserver/internal/domain"
+	"apiserver/internal/encryption"
+	"apiserver/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("whsec_0123456789abcdef")
+
+type workerTestEnv struct {
+	repo     *mocks.MockWebhookRepository
+	clock    *clock.Fake
+	worker   *Worker
+	sub      *domain.WebhookSubscription
+	mu       sync.Mutex
+	recorded []domain.WebhookDelivery
+	onRecord func(d domain.WebhookDelivery)
+}
+
+// setupWorkerTestEnv starts a receiver that verifies the signature and
+// replies with the given status codes in turn.
+func setupWorkerTestEnv(t *testing.T, statuses ...int) *workerTestEnv {
+	cipher, err := encryption.NewAESGCM(bytes.Repeat([]byte{7}, 32))
+	require.NoError(t, err)
+	ciphertext, err := cipher.Encrypt(testSecret)
+	require.NoError(t, err)
+
+	env := &workerTestEnv{
+		repo:  new(mocks.MockWebhookRepository),
+		clock: clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := Verify(testSecret, r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature), body, env.clock.Now(), 5*time.Minute); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		env.mu.Lock()
+		status := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		env.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(receiver.Close)
+
+	env.sub = &domain.WebhookSubscription{ID: "sub-1", URL: receiver.URL, SecretCiphertext: ciphertext, Active: true}
+	config := DefaultWorkerConfig()
+	config.MaxAttempts = 3
+	env.worker = NewWorker(env.repo, new(mocks.InlineTxManager), cipher, receiver.Client(), env.clock, config, log.New(io.Discard, "", 0))
+	env.repo.On("GetWebhookSubscription", mock.Anything, "sub-1").Return(env.sub, nil).Maybe()
+	env.repo.On("RecordWebhookDeliveryAttempt", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		d := *args.Get(1).(*domain.WebhookDelivery)
+		env.mu.Lock()
+		env.recorded = append(env.recorded, d)
+		onRecord := env.onRecord
+		env.mu.Unlock()
+		if onRecord != nil {
+			onRecord(d)
+		}
+	}).Return(nil).Maybe()
+	return env
+}
+
+func (env *workerTestEnv) claim(deliveries ...domain.WebhookDelivery) {
+	env.repo.On("ClaimDueWebhookDeliveries", mock.Anything, env.clock.Now(), env.clock.Now().Add(5*time.Minute), 50).Return(deliveries, nil).Once()
+}
+
+func TestWorker_DeliverOnce_Succeeds(t *testing.T) {
+	env := setupWorkerTestEnv(t, http.StatusNoContent)
+	env.claim(domain.WebhookDelivery{ID: 1, SubscriptionID: "sub-1", EventID: "e1", EventType: domain.EventUserCreated, Payload: []byte(`{"id":"e1"}`), Status: domain.DeliveryStatusPending})
+
+	n, err := env.worker.DeliverOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Len(t, env.recorded, 1)
+	assert.Equal(t, domain.DeliveryStatusSucceeded, env.recorded[0].Status)
+	assert.Equal(t, 1, env.recorded[0].Attempts)
+	assert.Equal(t, http.StatusNoContent, env.recorded[0].LastStatusCode)
+	assert.Equal(t, env.clock.Now(), env.recorded[0].DeliveredAt)
+}
+
+func TestWorker_DeliverOnce_RetriesWithBackoffThenDeadLetters(t *testing.T) {
+	env := setupWorkerTestEnv(t, http.StatusInternalServerError)
+	delivery := domain.WebhookDelivery{ID: 1, SubscriptionID: "sub-1", EventID: "e1", EventType: domain.EventUserCreated, Payload: []byte(`{}`), Status: domain.DeliveryStatusPending, Attempts: 1}
+	env.claim(delivery)
+
+	_, err := env.worker.DeliverOnce(context.Background())
+
+	assert.NoError(t, err)
+	require.Len(t, env.recorded, 1)
+	assert.Equal(t, domain.DeliveryStatusPending, env.recorded[0].Status)
+	assert.Equal(t, 2, env.recorded[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, env.recorded[0].LastStatusCode)
+	assert.Equal(t, "receiver responded with 500 Internal Server Error", env.recorded[0].LastError)
+	// Second attempt: 30s doubled once
+	assert.Equal(t, env.clock.Now().Add(time.Minute), env.recorded[0].NextAttemptAt)
+
+	// The third failure exhausts MaxAttempts
+	env.clock.Advance(time.Minute)
+	env.claim(env.recorded[0])
+
+	_, err = env.worker.DeliverOnce(context.Background())
+
+	assert.NoError(t, err)
+	require.Len(t, env.recorded, 2)
+	assert.Equal(t, domain.DeliveryStatusDead, env.recorded[1].Status)
+	assert.Equal(t, 3, env.recorded[1].Attempts)
+}
+
+func TestWorker_DeliverOnce_DisabledSubscription(t *testing.T) {
+	env := setupWorkerTestEnv(t, http.StatusOK)
+	env.sub.Active = false
+	env.claim(domain.WebhookDelivery{ID: 1, SubscriptionID: "sub-1", EventID: "e1", Status: domain.DeliveryStatusPending})
+
+	_, err := env.worker.DeliverOnce(context.Background())
+
+	assert.NoError(t, err)
+	require.Len(t, env.recorded, 1)
+	assert.Equal(t, domain.DeliveryStatusDead, env.recorded[0].Status)
+	assert.Equal(t, 0, env.recorded[0].Attempts)
+	assert.Equal(t, "subscription is disabled", env.recorded[0].LastError)
+}
+
+func TestWorker_DeliverOnce_RedirectIsAFailure(t *testing.T) {
+	env := setupWorkerTestEnv(t, http.StatusFound)
+	env.claim(domain.WebhookDelivery{ID: 1, SubscriptionID: "sub-1", EventID: "e1", Payload: []byte(`{}`), Status: domain.DeliveryStatusPending})
+
+	_, err := env.worker.DeliverOnce(context.Background())
+
+	assert.NoError(t, err)
+	require.Len(t, env.recorded, 1)
+	assert.Equal(t, domain.DeliveryStatusPending, env.recorded[0].Status)
+	assert.Equal(t, http.StatusFound, env.recorded[0].LastStatusCode)
+}
+
+func TestWorker_DeliverOnce_SlowReceiverDoesNotHoldUpOthers(t *testing.T) {
+	env := setupWorkerTestEnv(t, http.StatusOK)
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+			w.WriteHeader(http.StatusOK)
+		case <-time.After(5 * time.Second):
+			w.WriteHeader(http.StatusGatewayTimeout)
+		}
+	}))
+	t.Cleanup(slow.Close)
+	slowSub := &domain.WebhookSubscription{ID: "sub-slow", URL: slow.URL, SecretCiphertext: env.sub.SecretCiphertext, Active: true}
+	env.repo.On("GetWebhookSubscription", mock.Anything, "sub-slow").Return(slowSub, nil)
+	// The slow receiver only answers once the other delivery has been recorded
+	env.onRecord = func(d domain.WebhookDelivery) {
+		if d.ID == 2 {
+			close(release)
+		}
+	}
+	env.claim(
+		domain.WebhookDelivery{ID: 1, SubscriptionID: "sub-slow", EventID: "e1", Payload: []byte(`{}`), Status: domain.DeliveryStatusPending},
+		domain.WebhookDelivery{ID: 2, SubscriptionID: "sub-1", EventID: "e2", Payload: []byte(`{}`), Status: domain.DeliveryStatusPending},
+	)
+
+	n, err := env.worker.DeliverOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	require.Len(t, env.recorded, 2)
+	assert.Equal(t, int64(2), env.recorded[0].ID)
+	assert.Equal(t, int64(1), env.recorded[1].ID)
+	assert.Equal(t, domain.DeliveryStatusSucceeded, env.recorded[1].Status)
+}
+
+func TestWorker_DeliverOnce_StopsAtLeaseEnd(t *testing.T) {
+	env := setupWorkerTestEnv(t, http.StatusOK)
+	env.worker.config.Concurrency = 1
+	// Each delivery takes most of the lease
+	env.onRecord = func(domain.WebhookDelivery) { env.clock.Advance(4*time.Minute + 55*time.Second) }
+	env.claim(
+		domain.WebhookDelivery{ID: 1, SubscriptionID: "sub-1", EventID: "e1", Payload: []byte(`{}`), Status: domain.DeliveryStatusPending},
+		domain.WebhookDelivery{ID: 2, SubscriptionID: "sub-1", EventID: "e2", Payload: []byte(`{}`), Status: domain.DeliveryStatusPending},
+	)
+	env.worker.client.Timeout = 10 * time.Second
+
+	n, err := env.worker.DeliverOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	require.Len(t, env.recorded, 1)
+	assert.Equal(t, int64(1), env.recorded[0].ID)
+}